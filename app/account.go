@@ -0,0 +1,366 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net"
+	"net/http"
+	"net/mail"
+	"nova/logger"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	userPasswordResetExpiry     = time.Hour
+	userEmailVerificationExpiry = 24 * time.Hour
+	userPasswordResetRoute      = "/nova/v1/user/password/reset/"
+	userEmailVerificationRoute  = "/nova/v1/user/email/verification/"
+)
+
+// errUserTokenNotFound is returned when password reset or email verification token is not stored, used or expired
+var errUserTokenNotFound = errors.New("user token not found")
+
+func (nova *Nova) HandleCreatePasswordReset(c *gin.Context) {
+	// request password reset link, response does not tell whether user exists
+	var request UserPasswordResetRequest
+	logger.Infof("handle request create password reset")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// update data cache by querying users in database
+	logger.Debugf("update data cache by querying users in database")
+	if err := nova.queryUsersInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying users in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying users in database")
+	// send reset link to email of user, earlier links stop working
+	logger.Debugf("send password reset mail")
+	if err := nova.sendPasswordResetMail(c, request.Username); err != nil {
+		logger.Errorf("error send password reset mail: %v", err)
+	} else {
+		logger.Debugf("successfully send password reset mail")
+	}
+	// return response
+	nova.response202Accepted(c, nil)
+	logger.Infof("response status code: %v", http.StatusAccepted)
+	return
+}
+
+func (nova *Nova) HandleCreatePasswordResetConfirmation(c *gin.Context) {
+	// reset password by token of reset link, every session of user is logged out
+	var request UserPasswordReset
+	logger.Infof("handle request create password reset confirmation")
+	// extract token from uri
+	token := c.Param("token")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check password correctness
+	logger.Debugf("check password is validate")
+	if len(request.Password) > userPasswordMaxLength {
+		nova.response400BadRequest(c, fmt.Errorf("password should be at most %v bytes", userPasswordMaxLength))
+		logger.Errorf("error check password is validate")
+		return
+	}
+	logger.Debugf("successfully check password is validate")
+	// token is used once
+	logger.Debugf("use password reset token in database")
+	resetToken, ok := nova.useUserToken(c, token, UserTokenPasswordReset)
+	if !ok {
+		return
+	}
+	logger.Debugf("successfully use password reset token in database")
+	// store hashed password of user in data cache & database
+	logger.Debugf("store user password in data cache & database")
+	password, err := hashUserPassword(request.Password)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error hash user password: %v", err)
+		return
+	}
+	if _, err := nova.modifyUserInDataCache(User{UserId: resetToken.UserId, Password: password}); err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error store user password in data cache: %v", err)
+		return
+	}
+	if err := nova.modifyUserInDatabase(resetToken.UserId); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store user password in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store user password in data cache & database")
+	// other reset links & every session of user stop working
+	logger.Debugf("delete user tokens & sessions in database & data cache")
+	if err := nova.db.DeleteUserTokens(resetToken.UserId, UserTokenPasswordReset); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error delete user tokens in database: %v", err)
+		return
+	}
+	if err := nova.deleteUserSessions(resetToken.UserId); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error delete user sessions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully delete user tokens & sessions in database & data cache")
+	// return response
+	nova.response204NoContent(c, nil)
+	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, resetToken.UserId)
+	return
+}
+
+func (nova *Nova) HandleCreateEmailVerification(c *gin.Context) {
+	// send verification link to current email of principal
+	logger.Infof("handle request create email verification")
+	// email of principal is verified
+	logger.Debugf("check user session is validate")
+	user, ok := nova.queryPrincipal(c)
+	if !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to verify email"))
+		logger.Errorf("error check user session is validate")
+		return
+	}
+	logger.Debugf("successfully check user session is validate")
+	// check email correctness
+	logger.Debugf("check email is validate")
+	if _, err := mail.ParseAddress(user.Email); err != nil {
+		nova.response400BadRequest(c, FieldErrors{{Field: "email", Reason: "should be valid email address"}})
+		logger.Errorf("error check email is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check email is validate")
+	// send verification link, earlier links stop working
+	logger.Debugf("send email verification mail")
+	if err := nova.db.DeleteUserTokens(user.UserId, UserTokenEmailVerification); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error delete user tokens in database: %v", err)
+		return
+	}
+	expiry := nova.queryUserTokenExpiry(UserTokenEmailVerification)
+	token, err := nova.createUserToken(user.UserId, UserTokenEmailVerification, user.Email, expiry)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store user token in database: %v", err)
+		return
+	}
+	message := MailMessage{
+		To:      user.Email,
+		Subject: "Verify your Nova email address",
+		Body: fmt.Sprintf("Hello %v,\n\nConfirm %v is your email address by opening the link below within %v:\n\n%v\n\n"+
+			"If you did not ask for it, ignore this mail.\n", user.Username, user.Email, expiry, nova.queryServiceURL()+userEmailVerificationRoute+token),
+	}
+	if err := nova.ms.Send(c.Request.Context(), message); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error send email verification mail: %v", err)
+		return
+	}
+	logger.Debugf("successfully send email verification mail")
+	// return response
+	nova.response202Accepted(c, nil)
+	logger.Infof("response status code: %v", http.StatusAccepted)
+	return
+}
+
+func (nova *Nova) HandleCreateEmailVerificationConfirmation(c *gin.Context) {
+	// verify email by token of verification link
+	logger.Infof("handle request create email verification confirmation")
+	// extract token from uri
+	token := c.Param("token")
+	// token is used once
+	logger.Debugf("use email verification token in database")
+	verificationToken, ok := nova.useUserToken(c, token, UserTokenEmailVerification)
+	if !ok {
+		return
+	}
+	logger.Debugf("successfully use email verification token in database")
+	// link only verifies email it was sent to
+	logger.Debugf("check email is unchanged")
+	user, err := nova.queryUserInDataCache(verificationToken.UserId)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query user in data cache: %v", err)
+		return
+	}
+	if user.Email != verificationToken.Email {
+		nova.response409Conflict(c, errors.New("email changed after verification link was sent"))
+		logger.Errorf("error check email is unchanged")
+		return
+	}
+	logger.Debugf("successfully check email is unchanged")
+	// store verified email in database
+	logger.Debugf("store verified email in database")
+	now := time.Now().UTC()
+	response := UserEmailVerification{UserId: user.UserId, Email: user.Email, Verified: true, VerifiedAt: &now}
+	if err := nova.db.UpdateUserEmailVerification(&response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store verified email in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store verified email in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleQueryEmailVerification(c *gin.Context) {
+	// query whether current email of user is verified
+	logger.Infof("handle request query email verification")
+	// extract userId from uri
+	userId := strings.ToLower(c.Param("userId"))
+	// update data cache by querying users in database
+	logger.Debugf("update data cache by querying users in database")
+	if err := nova.queryUsersInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying users in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying users in database")
+	// query user & verified email
+	logger.Debugf("query email verification in database")
+	user, err := nova.queryUserInDataCache(userId)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query user in data cache: %v", err)
+		return
+	}
+	verification, err := nova.db.QueryUserEmailVerification(userId)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query email verification in database: %v", err)
+		return
+	}
+	// email changed after verification is not verified
+	response := UserEmailVerification{UserId: userId, Email: user.Email}
+	if verification.Verified && verification.Email == user.Email {
+		response.Verified, response.VerifiedAt = true, verification.VerifiedAt
+	}
+	logger.Debugf("successfully query email verification in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) sendPasswordResetMail(c *gin.Context, username string) error {
+	// unknown users & users without email get no mail
+	userId, err := nova.queryUserFromDataCache(username)
+	if err != nil {
+		return err
+	}
+	user, err := nova.queryUserInDataCache(userId)
+	if err != nil {
+		return err
+	}
+	if _, err := mail.ParseAddress(user.Email); err != nil {
+		return fmt.Errorf("user %v has no valid email: %w", userId, err)
+	}
+	if err := nova.db.DeleteUserTokens(userId, UserTokenPasswordReset); err != nil {
+		return err
+	}
+	expiry := nova.queryUserTokenExpiry(UserTokenPasswordReset)
+	token, err := nova.createUserToken(userId, UserTokenPasswordReset, user.Email, expiry)
+	if err != nil {
+		return err
+	}
+	return nova.ms.Send(c.Request.Context(), MailMessage{
+		To:      user.Email,
+		Subject: "Reset your Nova password",
+		Body: fmt.Sprintf("Hello %v,\n\nA password reset was requested for your account. Choose a new password by opening the link below within %v:\n\n%v\n\n"+
+			"Every session of your account is logged out after reset. If you did not ask for it, ignore this mail.\n", user.Username, expiry, nova.queryServiceURL()+userPasswordResetRoute+token),
+	})
+}
+
+func (nova *Nova) createUserToken(userId string, purpose string, email string, expiry time.Duration) (string, error) {
+	// only token hash is stored, token is sent once
+	token, err := randomUserToken()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now().UTC()
+	userToken := UserToken{UserId: userId, Purpose: purpose, Email: email, CreatedAt: now, ExpiresAt: now.Add(expiry)}
+	if err := nova.db.CreateUserToken(hashUserSessionToken(token), &userToken); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (nova *Nova) useUserToken(c *gin.Context, token string, purpose string) (UserToken, bool) {
+	// query token of purpose from database
+	tokenHash := hashUserSessionToken(token)
+	userToken, err := nova.db.QueryUserToken(tokenHash, purpose)
+	if err != nil {
+		if errors.Is(err, errUserTokenNotFound) {
+			nova.response404NotFound(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error query user token in database: %v", err)
+		return UserToken{}, false
+	}
+	// expired token is deleted, concurrent use of token loses
+	deleted, err := nova.db.DeleteUserToken(tokenHash)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error delete user token in database: %v", err)
+		return UserToken{}, false
+	}
+	if !deleted || !time.Now().Before(userToken.ExpiresAt) {
+		nova.response404NotFound(c, errUserTokenNotFound)
+		logger.Errorf("error check user token is validate: used or expired")
+		return UserToken{}, false
+	}
+	// user of token should still exist
+	if err := nova.queryUsersInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying users in database: %v", err)
+		return UserToken{}, false
+	}
+	return *userToken, true
+}
+
+func (nova *Nova) queryUserTokenExpiry(purpose string) time.Duration {
+	if purpose == UserTokenPasswordReset {
+		if expiry := nova.conf.Configure.User.PasswordResetExpiry; expiry > 0 {
+			return time.Duration(expiry) * time.Second
+		}
+		return userPasswordResetExpiry
+	}
+	if expiry := nova.conf.Configure.User.EmailVerificationExpiry; expiry > 0 {
+		return time.Duration(expiry) * time.Second
+	}
+	return userEmailVerificationExpiry
+}
+
+func (nova *Nova) queryServiceURL() string {
+	// links in mail point at configured host of service
+	scheme, port := "https", nova.conf.Configure.Port
+	if nova.conf.Configure.TLS.TLSType == "non-tls" {
+		scheme = "http"
+	}
+	host := nova.conf.Configure.FQDN
+	if host == "" {
+		host = nova.conf.Configure.IPv4Addr
+	}
+	if host == "" {
+		host = "localhost"
+	}
+	if port <= 0 || (scheme == "https" && port == 443) || (scheme == "http" && port == 80) {
+		return scheme + "://" + host
+	}
+	return scheme + "://" + net.JoinHostPort(host, strconv.Itoa(port))
+}
@@ -0,0 +1,191 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"nova/utils"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"testing"
+)
+
+func setupAccountTestRouter(directory string) *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// deliver mail to files so tests read links
+	nova.ms, _ = NewFileMailSender(directory, mailDefaultFrom)
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	{
+		/* user management */
+		novaService.POST("/user/:userId", nova.HandleCreateUser)
+		novaService.PATCH("/user/:userId", nova.HandleModifyUser)
+		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
+		novaService.POST("/user/password/reset", nova.HandleCreatePasswordReset)
+		novaService.POST("/user/password/reset/:token", nova.HandleCreatePasswordResetConfirmation)
+		novaService.POST("/user/email/verification", nova.HandleCreateEmailVerification)
+		novaService.POST("/user/email/verification/:token", nova.HandleCreateEmailVerificationConfirmation)
+		novaService.GET("/user/email/:userId", nova.HandleQueryEmailVerification)
+	}
+	return router
+}
+
+func startAccountTestService(t *testing.T) (*httptest.Server, *gin.Engine, string) {
+	directory := t.TempDir()
+	router := setupAccountTestRouter(directory)
+	return httptest.NewServer(router), router, directory
+}
+
+func createAccountTestUser(t *testing.T, server *httptest.Server, router *gin.Engine) (User, UserSession) {
+	// create user with email & login
+	user := User{
+		UserId:      uuid.New().String(),
+		Username:    utils.RandomAlphabet(8),
+		Password:    utils.RandomAlphabetAndNumber(8),
+		PhoneNumber: utils.RandomNumber(11),
+		Email:       utils.RandomAlphabet(8) + "@example.com",
+	}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/"+user.UserId, user, "")
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/login/"+user.UserId, UserLogin{UserId: user.UserId, Username: user.Username, Password: user.Password}, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var session UserSession
+	unmarshalTestResponse(t, w, &session)
+	return user, session
+}
+
+func queryAccountTestLinks(t *testing.T, directory string, route string) []string {
+	// links of every mail in order mail was sent
+	files, err := filepath.Glob(filepath.Join(directory, "*.eml"))
+	assert.NoError(t, err)
+	sort.Strings(files)
+	pattern := regexp.MustCompile(regexp.QuoteMeta(route) + `[A-Za-z0-9_-]+`)
+	var links []string
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		links = append(links, pattern.FindAllString(string(b), -1)...)
+	}
+	return links
+}
+
+func TestNova_HandleCreatePasswordReset(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreatePasswordReset
+	// Test Purpose: Test password reset link is mailed once & resets password
+	// Test Steps:
+	// 1. send CreatePasswordReset request of unknown user, receive 202 Accepted Code without mail
+	// 2. send CreatePasswordReset request twice, only latest link works
+	// 3. send CreatePasswordResetConfirmation request, receive 204 No Content Code
+	// 4. old session & old password stop working, new password logs in
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router, directory := startAccountTestService(t)
+	defer server.Close()
+	user, session := createAccountTestUser(t, server, router)
+	url := server.URL + "/nova/v1/user/password/reset"
+	/* response does not tell whether user exists */
+	w := serveTestRequest(t, router, http.MethodPost, url, UserPasswordResetRequest{Username: utils.RandomAlphabet(8)}, "")
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Empty(t, queryAccountTestLinks(t, directory, userPasswordResetRoute))
+	/* latest link replaces earlier link */
+	w = serveTestRequest(t, router, http.MethodPost, url, UserPasswordResetRequest{Username: user.Username}, "")
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, url, UserPasswordResetRequest{Username: user.Username}, "")
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	links := queryAccountTestLinks(t, directory, userPasswordResetRoute)
+	if !assert.Len(t, links, 2) {
+		return
+	}
+	w = serveTestRequest(t, router, http.MethodPost, links[0], UserPasswordReset{Password: "new-password"}, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, url+"/"+utils.RandomAlphabet(43), UserPasswordReset{Password: "new-password"}, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	/* link resets password once */
+	w = serveTestRequest(t, router, http.MethodPost, links[1], UserPasswordReset{Password: "new-password"}, "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, links[1], UserPasswordReset{Password: "other-password"}, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	/* every session is logged out */
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/email/verification", nil, session.Token)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/login/"+user.UserId, UserLogin{UserId: user.UserId, Username: user.Username, Password: user.Password}, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/login/"+user.UserId, UserLogin{UserId: user.UserId, Username: user.Username, Password: "new-password"}, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestNova_HandleCreateEmailVerification(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateEmailVerification
+	// Test Purpose: Test email is verified by mailed link until email changes
+	// Test Steps:
+	// 1. send CreateEmailVerification request without session, receive 401 Unauthorized Code
+	// 2. send CreateEmailVerificationConfirmation request, receive verified email by using 200 OK Code
+	// 3. send QueryEmailVerification request after email changes, email is not verified
+	// 4. send CreateEmailVerificationConfirmation request of old email, receive 409 Conflict Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router, directory := startAccountTestService(t)
+	defer server.Close()
+	user, session := createAccountTestUser(t, server, router)
+	url := server.URL + "/nova/v1/user/email/verification"
+	query := server.URL + "/nova/v1/user/email/" + user.UserId
+	/* principal verifies own email */
+	w := serveTestRequest(t, router, http.MethodPost, url, nil, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, query, nil, "")
+	var verification UserEmailVerification
+	unmarshalTestResponse(t, w, &verification)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.False(t, verification.Verified)
+	w = serveTestRequest(t, router, http.MethodPost, url, nil, session.Token)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	links := queryAccountTestLinks(t, directory, userEmailVerificationRoute)
+	if !assert.Len(t, links, 1) {
+		return
+	}
+	w = serveTestRequest(t, router, http.MethodPost, links[0], nil, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, links[0], nil, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, query, nil, "")
+	unmarshalTestResponse(t, w, &verification)
+	assert.True(t, verification.Verified)
+	assert.Equal(t, user.Email, verification.Email)
+	assert.NotNil(t, verification.VerifiedAt)
+	/* changed email is verified again */
+	w = serveTestRequest(t, router, http.MethodPost, url, nil, session.Token)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	user.Email = utils.RandomAlphabet(8) + "@example.com"
+	w = serveTestRequest(t, router, http.MethodPatch, server.URL+"/nova/v1/user/"+user.UserId, user, session.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, query, nil, "")
+	verification = UserEmailVerification{}
+	unmarshalTestResponse(t, w, &verification)
+	assert.False(t, verification.Verified)
+	assert.Equal(t, user.Email, verification.Email)
+	links = queryAccountTestLinks(t, directory, userEmailVerificationRoute)
+	if assert.Len(t, links, 2) {
+		w = serveTestRequest(t, router, http.MethodPost, links[1], nil, "")
+		assert.Equal(t, http.StatusConflict, w.Code)
+	}
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/user/email/"+uuid.New().String(), nil, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,552 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"nova/logger"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	adaptiveMaxLengthDefault     = 20
+	adaptiveStandardErrorDefault = 0.3
+	adaptiveStandardErrorMax     = 1.0
+)
+
+// errAdaptiveExamNotFound is returned when adaptive exam is not stored
+var errAdaptiveExamNotFound = errors.New("adaptive exam not found")
+
+// errAdaptiveExamExisted is returned when adaptive exam with the same Id is stored
+var errAdaptiveExamExisted = errors.New("adaptive exam already exists")
+
+// errAdaptiveSessionNotFound is returned when adaptive session is not stored
+var errAdaptiveSessionNotFound = errors.New("adaptive session not found")
+
+func (nova *Nova) HandleCreateAdaptiveExam(c *gin.Context) {
+	// create adaptive exam drawing calibrated questions
+	var request AdaptiveExam
+	logger.Infof("handle request create adaptive exam")
+	// extract examId from uri
+	examId := strings.ToLower(c.Param("examId"))
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// examinees take exams but do not compose them
+	logger.Debugf("check principal is allowed to create exam")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to create exam"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to create exam"))
+		logger.Errorf("error check principal is allowed to create exam")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to create exam")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	if err := nova.queryQuestionWorkflowsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying question workflows in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// check request body correctness
+	logger.Debugf("check adaptive exam is validate")
+	request.Model = strings.ToLower(strings.TrimSpace(request.Model))
+	if b, err := nova.isAdaptiveExamValidate(nova.queryTenantOwner(c), examId, request); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check adaptive exam is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check adaptive exam is validate")
+	// store created adaptive exam in database
+	logger.Debugf("store adaptive exam in database")
+	response := AdaptiveExam{
+		Id:            examId,
+		Title:         request.Title,
+		Model:         request.Model,
+		Questions:     make([]ExamQuestion, 0, len(request.Questions)),
+		MaxLength:     request.MaxLength,
+		StandardError: request.StandardError,
+		CreatedBy:     nova.queryRequestAuthor(c),
+		CreatedAt:     time.Now().UTC(),
+	}
+	// exam without pool draws from every calibrated question
+	for _, v := range request.Questions {
+		response.Questions = append(response.Questions, ExamQuestion{Type: strings.ToLower(v.Type), Id: strings.ToLower(v.Id), Points: 1})
+	}
+	if response.MaxLength == 0 {
+		response.MaxLength = adaptiveMaxLengthDefault
+	}
+	if response.StandardError == 0 {
+		response.StandardError = adaptiveStandardErrorDefault
+	}
+	if err := nova.db.CreateAdaptiveExam(&response); err != nil {
+		if errors.Is(err, errAdaptiveExamExisted) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error store adaptive exam in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store adaptive exam in database")
+	// store adaptive exam tenant in database & data cache
+	logger.Debugf("store adaptive exam tenant in database & data cache")
+	if err := nova.createResourceTenant(c, TenantResourceAdaptiveExam, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store adaptive exam tenant in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store adaptive exam tenant in database & data cache")
+	// return response
+	nova.response201Created(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response)
+	return
+}
+
+func (nova *Nova) HandleQueryAdaptiveExam(c *gin.Context) {
+	// query adaptive exam
+	logger.Infof("handle request query adaptive exam")
+	// extract examId from uri
+	examId := strings.ToLower(c.Param("examId"))
+	// query adaptive exam from database
+	logger.Debugf("query adaptive exam in database")
+	response, ok := nova.queryAdaptiveExamInDatabase(c, examId)
+	if !ok {
+		return
+	}
+	logger.Debugf("successfully query adaptive exam in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleCreateAdaptiveSession(c *gin.Context) {
+	// start adaptive session with most informative question at prior ability
+	logger.Infof("handle request create adaptive session")
+	// sessions belong to authenticated users
+	logger.Debugf("check user session is validate")
+	if _, ok := nova.queryPrincipal(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to start adaptive session"))
+		logger.Errorf("error check user session is validate")
+		return
+	}
+	logger.Debugf("successfully check user session is validate")
+	// extract examId from uri
+	examId := strings.ToLower(c.Param("examId"))
+	// query adaptive exam from database
+	logger.Debugf("query adaptive exam in database")
+	exam, ok := nova.queryAdaptiveExamInDatabase(c, examId)
+	if !ok {
+		return
+	}
+	logger.Debugf("successfully query adaptive exam in database")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	if err := nova.queryQuestionWorkflowsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying question workflows in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// select first question from calibrated pool
+	logger.Debugf("select adaptive session question")
+	pool, err := nova.queryAdaptivePool(exam)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query adaptive question pool: %v", err)
+		return
+	}
+	response := AdaptiveSession{
+		SessionId:     uuid.New().String(),
+		ExamId:        exam.Id,
+		UserId:        nova.queryRequestAuthor(c),
+		State:         AdaptiveSessionStateOpen,
+		StandardError: 1,
+		Items:         []AdaptiveItem{},
+		CreatedAt:     time.Now().UTC(),
+	}
+	key, found := selectAdaptiveQuestion(pool, response.Items, response.Ability)
+	if !found {
+		nova.response409Conflict(c, fmt.Errorf("adaptive exam has no question calibrated for %v model", exam.Model))
+		logger.Errorf("error select adaptive session question: pool is empty")
+		return
+	}
+	if response.Question, err = nova.queryAdaptiveQuestion(key); err != nil {
+		nova.response409Conflict(c, err)
+		logger.Errorf("error select adaptive session question: %v", err)
+		return
+	}
+	logger.Debugf("successfully select adaptive session question")
+	// store adaptive session in database
+	logger.Debugf("store adaptive session in database")
+	if err := nova.db.CreateAdaptiveSession(&response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store adaptive session in database: %v", err)
+		return
+	}
+	applyAdaptiveSessionInterval(&response)
+	logger.Debugf("successfully store adaptive session in database")
+	// return response
+	nova.response201Created(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response.SessionId)
+	return
+}
+
+func (nova *Nova) HandleQueryAdaptiveSession(c *gin.Context) {
+	// query adaptive session with ability estimate
+	logger.Infof("handle request query adaptive session")
+	// extract sessionId from uri
+	sessionId := strings.ToLower(c.Param("sessionId"))
+	// query adaptive session from database
+	logger.Debugf("query adaptive session in database")
+	response, ok := nova.queryAdaptiveSessionInDatabase(c, sessionId)
+	if !ok {
+		return
+	}
+	logger.Debugf("successfully query adaptive session in database")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// current question is shown again
+	if response.Question != nil {
+		question, err := nova.queryAdaptiveQuestion(response.Question.Type + "/" + response.Question.Id)
+		if err != nil {
+			nova.response409Conflict(c, err)
+			logger.Errorf("error query adaptive session question: %v", err)
+			return
+		}
+		response.Question = question
+	}
+	applyAdaptiveSessionInterval(&response)
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response.SessionId)
+	return
+}
+
+func (nova *Nova) HandleCreateAdaptiveResponse(c *gin.Context) {
+	// answer current question, update ability & select next question
+	var request AdaptiveResponse
+	logger.Infof("handle request create adaptive response")
+	// extract sessionId from uri
+	sessionId := strings.ToLower(c.Param("sessionId"))
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// query adaptive session from database
+	logger.Debugf("query adaptive session in database")
+	session, ok := nova.queryAdaptiveSessionInDatabase(c, sessionId)
+	if !ok {
+		return
+	}
+	logger.Debugf("successfully query adaptive session in database")
+	// only examinee of session answers current question
+	logger.Debugf("check principal is examinee of session")
+	if session.UserId != nova.queryRequestAuthor(c) {
+		nova.response403Forbidden(c, errors.New("only examinee of session answers questions"))
+		logger.Errorf("error check principal is examinee of session")
+		return
+	}
+	if session.State != AdaptiveSessionStateOpen || session.Question == nil {
+		nova.response409Conflict(c, errors.New("adaptive session already finished"))
+		logger.Errorf("error check adaptive session is open")
+		return
+	}
+	current := *session.Question
+	if !strings.EqualFold(request.Type, current.Type) || !strings.EqualFold(request.Id, current.Id) {
+		nova.response409Conflict(c, FieldErrors{{Field: "id", Reason: fmt.Sprintf("should answer current question %v", current.Id)}})
+		logger.Errorf("error check response answers current question")
+		return
+	}
+	logger.Debugf("successfully check principal is examinee of session")
+	// query adaptive exam from database
+	logger.Debugf("query adaptive exam in database")
+	exam, err := nova.db.QueryAdaptiveExam(session.ExamId)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query adaptive exam in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query adaptive exam in database")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	if err := nova.queryQuestionWorkflowsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying question workflows in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// grade answer of current question
+	logger.Debugf("grade adaptive response")
+	grade, err := nova.gradeQuestion(current.Type, current.Id, QuestionSubmission{Answer: request.Answer})
+	if err != nil {
+		if errors.Is(err, errQuestionAnswerFormat) {
+			nova.response400BadRequest(c, FieldErrors{{Field: "answer", Reason: err.Error()}})
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error grade adaptive response: %v", err)
+		return
+	}
+	logger.Debugf("successfully grade adaptive response")
+	// estimate ability from every answered question & decide whether to stop
+	logger.Debugf("estimate ability of adaptive session")
+	pool, err := nova.queryAdaptivePool(*exam)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query adaptive question pool: %v", err)
+		return
+	}
+	now := time.Now().UTC()
+	item := AdaptiveItem{Type: current.Type, Id: current.Id, Answer: request.Answer, Correct: grade.Correct, AnsweredAt: now}
+	session.Items = append(session.Items, item)
+	session.Ability, session.StandardError = estimateAdaptiveAbility(pool, session.Items)
+	session.Items[len(session.Items)-1].Ability = roundToDecimals(session.Ability, 3)
+	session.Items[len(session.Items)-1].StandardError = roundToDecimals(session.StandardError, 3)
+	session.Question = nil
+	switch {
+	case session.StandardError <= exam.StandardError:
+		session.StopReason = AdaptiveStopStandardError
+	case len(session.Items) >= exam.MaxLength:
+		session.StopReason = AdaptiveStopMaxLength
+	default:
+		if key, found := selectAdaptiveQuestion(pool, session.Items, session.Ability); !found {
+			session.StopReason = AdaptiveStopPoolExhausted
+		} else if session.Question, err = nova.queryAdaptiveQuestion(key); err != nil {
+			nova.response409Conflict(c, err)
+			logger.Errorf("error select adaptive session question: %v", err)
+			return
+		}
+	}
+	if session.StopReason != "" {
+		session.State, session.FinishedAt = AdaptiveSessionStateFinished, &now
+	}
+	logger.Debugf("successfully estimate ability of adaptive session")
+	// store adaptive session in database
+	logger.Debugf("store adaptive session in database")
+	if updated, err := nova.db.UpdateAdaptiveSession(&session, current); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store adaptive session in database: %v", err)
+		return
+	} else if !updated {
+		nova.response409Conflict(c, errors.New("current question already answered"))
+		logger.Errorf("error store adaptive session in database: question is not current")
+		return
+	}
+	applyAdaptiveSessionInterval(&session)
+	logger.Debugf("successfully store adaptive session in database")
+	// return response
+	nova.response200OK(c, session)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, session.SessionId)
+	return
+}
+
+func (nova *Nova) isAdaptiveExamValidate(tenantId string, examId string, exam AdaptiveExam) (bool, error) {
+	// check adaptive exam identity matches uri
+	v := &questionValidator{}
+	v.checkId(exam.Id)
+	if !strings.EqualFold(exam.Id, examId) {
+		v.add("id", "should match examId of uri")
+	}
+	v.checkText("title", exam.Title, questionTitleMaxLength)
+	if b, err := isIRTModelValidate(exam.Model); !b {
+		v.add("model", "%v", err)
+	}
+	if exam.MaxLength < 0 || exam.MaxLength > examQuestionsMax {
+		v.add("max_length", "should be 1 to %v questions, got %v", examQuestionsMax, exam.MaxLength)
+	}
+	if exam.StandardError < 0 || exam.StandardError > adaptiveStandardErrorMax {
+		v.add("standard_error", "should be 0 to %v, got %v", adaptiveStandardErrorMax, exam.StandardError)
+	}
+	// check pool questions are dichotomous, exist, are published & are not repeated
+	if len(exam.Questions) > examQuestionsMax {
+		v.add("questions", "should have at most %v questions, got %v", examQuestionsMax, len(exam.Questions))
+	}
+	seen := make(map[string]bool, len(exam.Questions))
+	for k, question := range exam.Questions {
+		field := fmt.Sprintf("questions[%v]", k)
+		questionType, id := strings.ToLower(question.Type), strings.ToLower(question.Id)
+		if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+			v.add(field, "%v", err)
+			continue
+		}
+		switch {
+		case !isIRTQuestionType(questionType):
+			v.add(field+".type", "%v question is not calibrated", questionType)
+		case seen[questionType+"/"+id]:
+			v.add(field+".id", "duplicates question %v", id)
+		case !nova.isQuestionExisted(questionType, id) || !nova.isResourceInScope(tenantId, questionType, id):
+			v.add(field+".id", "%v question %v not found", questionType, id)
+		case !nova.isQuestionPublished(questionType, id):
+			v.add(field+".id", "%v question %v is not published", questionType, id)
+		}
+		seen[questionType+"/"+id] = true
+	}
+	return v.result()
+}
+
+func (nova *Nova) queryAdaptiveExamInDatabase(c *gin.Context, examId string) (AdaptiveExam, bool) {
+	// request examId correctness
+	if err := uuid.Validate(examId); err != nil {
+		nova.response400BadRequest(c, errors.New("examId format incorrect"))
+		logger.Errorf("error check examId is validate: %v", err)
+		return AdaptiveExam{}, false
+	}
+	// query adaptive exam from database
+	exam, err := nova.db.QueryAdaptiveExam(examId)
+	if err != nil {
+		if errors.Is(err, errAdaptiveExamNotFound) {
+			nova.response404NotFound(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error query adaptive exam in database: %v", err)
+		return AdaptiveExam{}, false
+	}
+	return *exam, true
+}
+
+func (nova *Nova) queryAdaptiveSessionInDatabase(c *gin.Context, sessionId string) (AdaptiveSession, bool) {
+	// request sessionId correctness
+	if err := uuid.Validate(sessionId); err != nil {
+		nova.response400BadRequest(c, errors.New("sessionId format incorrect"))
+		logger.Errorf("error check sessionId is validate: %v", err)
+		return AdaptiveSession{}, false
+	}
+	// query adaptive session from database
+	session, err := nova.db.QueryAdaptiveSession(sessionId)
+	if err != nil {
+		if errors.Is(err, errAdaptiveSessionNotFound) {
+			nova.response404NotFound(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error query adaptive session in database: %v", err)
+		return AdaptiveSession{}, false
+	}
+	// examinees only see their own sessions, sessions of adaptive exams outside tenant are not found
+	if user, role, ok := nova.queryPrincipalRole(c); ((!ok || role == RoleExaminee) && user.UserId != session.UserId) ||
+		!nova.isResourceVisible(c, TenantResourceAdaptiveExam, session.ExamId) {
+		nova.response404NotFound(c, errAdaptiveSessionNotFound)
+		logger.Errorf("error check adaptive session is visible")
+		return AdaptiveSession{}, false
+	}
+	return *session, true
+}
+
+func (nova *Nova) queryAdaptivePool(exam AdaptiveExam) (map[string]irtItem, error) {
+	// published questions of tenant calibrated for model of exam, restricted to pool when exam has one
+	tenantId, _ := nova.queryResourceTenant(TenantResourceAdaptiveExam, exam.Id)
+	parameters, err := nova.db.QueryIRTParameters(exam.Model)
+	if err != nil {
+		return nil, err
+	}
+	allowed := make(map[string]bool, len(exam.Questions))
+	for _, v := range exam.Questions {
+		allowed[v.Type+"/"+v.Id] = true
+	}
+	pool := make(map[string]irtItem, len(parameters))
+	for _, v := range parameters {
+		key := v.Type + "/" + v.Id
+		if len(allowed) > 0 && !allowed[key] {
+			continue
+		}
+		if !nova.isQuestionExisted(v.Type, v.Id) || !nova.isQuestionPublished(v.Type, v.Id) ||
+			!nova.isResourceInScope(tenantId, v.Type, v.Id) {
+			continue
+		}
+		pool[key] = irtItem{a: v.Discrimination, b: v.Difficulty, c: v.Guessing}
+	}
+	return pool, nil
+}
+
+func (nova *Nova) queryAdaptiveQuestion(key string) (*ExamAttemptQuestion, error) {
+	// question is shown the same way as in exam attempt, unshuffled
+	questionType, id, _ := strings.Cut(key, "/")
+	questions, err := nova.queryExamAttemptQuestions(Exam{Questions: []ExamQuestion{{Type: questionType, Id: id, Points: 1}}}, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &questions[0], nil
+}
+
+func selectAdaptiveQuestion(pool map[string]irtItem, items []AdaptiveItem, ability float64) (string, bool) {
+	// unanswered question of maximum information at current ability, ties go to first key
+	answered := make(map[string]bool, len(items))
+	for _, v := range items {
+		answered[v.Type+"/"+v.Id] = true
+	}
+	keys := make([]string, 0, len(pool))
+	for key := range pool {
+		if !answered[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	selected, information := "", -1.0
+	for _, key := range keys {
+		if v := irtInformation(pool[key], ability); v > information {
+			selected, information = key, v
+		}
+	}
+	return selected, selected != ""
+}
+
+func estimateAdaptiveAbility(pool map[string]irtItem, items []AdaptiveItem) (float64, float64) {
+	// questions recalibrated away from pool no longer count
+	var answered []irtItem
+	var responses []bool
+	for _, v := range items {
+		if item, ok := pool[v.Type+"/"+v.Id]; ok {
+			answered, responses = append(answered, item), append(responses, v.Correct)
+		}
+	}
+	return estimateIRTAbility(answered, responses)
+}
+
+func applyAdaptiveSessionInterval(session *AdaptiveSession) {
+	// 95% confidence interval of ability estimate
+	session.Ability = roundToDecimals(session.Ability, 3)
+	session.StandardError = roundToDecimals(session.StandardError, 3)
+	session.Lower = roundToDecimals(session.Ability-irtConfidenceZ*session.StandardError, 3)
+	session.Upper = roundToDecimals(session.Ability+irtConfidenceZ*session.StandardError, 3)
+}
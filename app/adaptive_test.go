@@ -0,0 +1,246 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"nova/utils"
+	"testing"
+)
+
+func setupAdaptiveTestRouter() *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// first administrator is granted by command line
+	setupTestAdministrator(nova)
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	{
+		/* user management */
+		novaService.POST("/user/:userId", nova.HandleCreateUser)
+		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
+		novaService.PUT("/user/role/:userId", nova.HandleUpdateUserRole)
+		/* exam management */
+		novaService.POST("/exam/:examId", nova.HandleCreateExam)
+		novaService.POST("/exam/adaptive/:examId", nova.HandleCreateAdaptiveExam)
+		novaService.GET("/exam/adaptive/:examId", nova.HandleQueryAdaptiveExam)
+		novaService.POST("/exam/adaptive/:examId/session", nova.HandleCreateAdaptiveSession)
+		novaService.GET("/exam/adaptive/session/:sessionId", nova.HandleQueryAdaptiveSession)
+		novaService.POST("/exam/adaptive/session/:sessionId/response", nova.HandleCreateAdaptiveResponse)
+		novaService.POST("/exam/:examId/attempt", nova.HandleCreateExamAttempt)
+		novaService.POST("/exam/attempt/:attemptId/submission", nova.HandleCreateExamSubmission)
+		/* question management */
+		// question irt related
+		novaService.POST("/question/irt/calibration", nova.HandleCreateIRTCalibration)
+		novaService.GET("/question/irt/:type/:Id", nova.HandleQueryQuestionIRTParameters)
+		// question workflow related
+		novaService.POST("/question/workflow/:type/:Id/transition", nova.HandleCreateQuestionTransition)
+		novaService.POST("/question/workflow/:type/:Id/approve", nova.HandleCreateQuestionApproval)
+		// question related
+		novaService.POST("/question/single-choice/:Id", nova.HandleCreateQuestionSingleChoice)
+		novaService.POST("/question/judgement/:Id", nova.HandleCreateQuestionJudgement)
+	}
+	return router
+}
+
+func startAdaptiveTestService() (*httptest.Server, *gin.Engine) {
+	router := setupAdaptiveTestRouter()
+	return httptest.NewServer(router), router
+}
+
+func TestEstimateIRTAbility(t *testing.T) {
+	// ability grows with correct answers & is more precise with more questions
+	items := []irtItem{{a: 1, b: -1}, {a: 1, b: 0}, {a: 1, b: 1}}
+	prior, priorError := estimateIRTAbility(nil, nil)
+	assert.InDelta(t, 0, prior, 1e-9)
+	assert.InDelta(t, 1, priorError, 0.01)
+	high, highError := estimateIRTAbility(items, []bool{true, true, true})
+	low, _ := estimateIRTAbility(items, []bool{false, false, false})
+	assert.Greater(t, high, 0.0)
+	assert.Less(t, low, 0.0)
+	assert.InDelta(t, -high, low, 1e-9)
+	assert.Less(t, highError, priorError)
+	_, fewerError := estimateIRTAbility(items[:1], []bool{true})
+	assert.Less(t, highError, fewerError)
+}
+
+func TestCalibrateIRTItems(t *testing.T) {
+	// difficulties recovered from simulated 2PL responses keep their order
+	random := rand.New(rand.NewSource(42))
+	truth := map[string]irtItem{"judgement/easy": {a: 1.5, b: -1}, "judgement/medium": {a: 1, b: 0}, "judgement/hard": {a: 1.5, b: 1}}
+	respondents := make([]map[string]bool, 500)
+	for k := range respondents {
+		theta := random.NormFloat64()
+		respondents[k] = make(map[string]bool)
+		for key, item := range truth {
+			respondents[k][key] = random.Float64() < irtProbability(item, theta)
+		}
+	}
+	items := calibrateIRTItems(IRTModel2PL, respondents, map[string]float64{"judgement/easy": 0, "judgement/medium": 0, "judgement/hard": 0})
+	assert.Less(t, items["judgement/easy"].b, items["judgement/medium"].b)
+	assert.Less(t, items["judgement/medium"].b, items["judgement/hard"].b)
+	for key, item := range truth {
+		assert.InDelta(t, item.b, items[key].b, 0.5, key)
+	}
+	one := calibrateIRTItems(IRTModel1PL, respondents, map[string]float64{"judgement/easy": 0})
+	assert.Equal(t, 1.0, one["judgement/easy"].a)
+}
+
+func TestNova_HandleCreateAdaptiveSession(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateAdaptiveSession
+	// Test Purpose: Test questions are calibrated & adaptive sessions stop at max length
+	// Test Steps:
+	// 1. send CreateExamAttempt & CreateExamSubmission requests for twenty attempts
+	// 2. send CreateIRTCalibration request with examinee session, receive 403 Forbidden Code
+	// 3. send CreateIRTCalibration request, receive difficulties ordered as answered by using 200 OK Code
+	// 4. send CreateAdaptiveExam & CreateAdaptiveSession requests, receive first question
+	// 5. send CreateAdaptiveResponse request for another question, receive 409 Conflict Code
+	// 6. send CreateAdaptiveResponse requests, session finishes at max length with ability interval
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startAdaptiveTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	_, examineeSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	easy := QuestionJudgement{Id: uuid.New().String(), Title: "HTTP runs over TCP " + utils.RandomAlphabet(12), StandardAnswer: true}
+	createExamTestQuestion(t, server, router, QuestionTypeJudgement, easy.Id, easy, admin.Token, true)
+	medium := QuestionJudgement{Id: uuid.New().String(), Title: "QUIC runs over UDP " + utils.RandomAlphabet(12), StandardAnswer: true}
+	createExamTestQuestion(t, server, router, QuestionTypeJudgement, medium.Id, medium, admin.Token, true)
+	hard := QuestionSingleChoice{
+		Id:    uuid.New().String(),
+		Title: "Which TCP flag starts a connection " + utils.RandomAlphabet(12),
+		Answers: []QuestionAnswer{
+			{AnswerMark: "A", AnswerText: "SYN"},
+			{AnswerMark: "B", AnswerText: "FIN"},
+			{AnswerMark: "C", AnswerText: "RST"},
+			{AnswerMark: "D", AnswerText: "PSH"},
+		},
+		StandardAnswer: QuestionAnswer{AnswerMark: "A", AnswerText: "SYN"},
+	}
+	createExamTestQuestion(t, server, router, QuestionTypeSingleChoice, hard.Id, hard, admin.Token, true)
+	exam := Exam{Id: uuid.New().String(), Title: "Transport quiz", Questions: []ExamQuestion{
+		{Type: QuestionTypeJudgement, Id: easy.Id},
+		{Type: QuestionTypeJudgement, Id: medium.Id},
+		{Type: QuestionTypeSingleChoice, Id: hard.Id},
+	}}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id, exam, admin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	/* submit attempts, stronger examinees answer harder questions correctly */
+	correct := map[string][]byte{easy.Id: []byte(`true`), medium.Id: []byte(`true`), hard.Id: []byte(`"A"`)}
+	wrong := map[string][]byte{easy.Id: []byte(`false`), medium.Id: []byte(`false`), hard.Id: []byte(`"B"`)}
+	for k := 0; k < irtCalibrationResponsesMin; k++ {
+		w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id+"/attempt", nil, examineeSession.Token)
+		var attempt ExamAttempt
+		unmarshalTestResponse(t, w, &attempt)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		submission := ExamSubmission{}
+		for n, id := range []string{easy.Id, medium.Id, hard.Id} {
+			answer := wrong[id]
+			if k >= []int{4, 10, 15}[n] {
+				answer = correct[id]
+			}
+			submission.Answers = append(submission.Answers, ExamAnswer{Type: exam.Questions[n].Type, Id: id, Answer: answer})
+		}
+		w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/attempt/"+attempt.AttemptId+"/submission", submission, examineeSession.Token)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+	/* calibrate questions */
+	url := server.URL + "/nova/v1/question/irt/calibration"
+	w = serveTestRequest(t, router, http.MethodPost, url, IRTCalibration{Model: IRTModel2PL}, examineeSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, url, IRTCalibration{Model: "4pl"}, admin.Token)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, url, IRTCalibration{Model: IRTModel2PL}, admin.Token)
+	var calibration IRTCalibrationResult
+	unmarshalTestResponse(t, w, &calibration)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 3*irtCalibrationResponsesMin, calibration.Responses)
+	difficulties := map[string]float64{}
+	for _, v := range calibration.Calibrated {
+		difficulties[v.Id] = v.Difficulty
+	}
+	assert.Len(t, difficulties, 3)
+	assert.Less(t, difficulties[easy.Id], difficulties[medium.Id])
+	assert.Less(t, difficulties[medium.Id], difficulties[hard.Id])
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/question/irt/judgement/"+easy.Id, nil, admin.Token)
+	var parameters []IRTParameters
+	unmarshalTestResponse(t, w, &parameters)
+	assert.Equal(t, http.StatusOK, w.Code)
+	if assert.Len(t, parameters, 1) {
+		assert.Equal(t, difficulties[easy.Id], parameters[0].Difficulty)
+	}
+	/* create adaptive exam over calibrated pool */
+	adaptive := AdaptiveExam{Id: uuid.New().String(), Title: "Adaptive transport quiz", Model: IRTModel2PL, MaxLength: 2, StandardError: 0.01}
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/adaptive/"+adaptive.Id, adaptive, examineeSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/adaptive/"+adaptive.Id, adaptive, admin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/adaptive/"+adaptive.Id, adaptive, admin.Token)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	/* start adaptive session, first question is most informative at prior ability */
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/adaptive/"+adaptive.Id+"/session", nil, examineeSession.Token)
+	var session AdaptiveSession
+	unmarshalTestResponse(t, w, &session)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, AdaptiveSessionStateOpen, session.State)
+	if !assert.NotNil(t, session.Question) {
+		return
+	}
+	assert.NotEmpty(t, session.Question.Title)
+	url = server.URL + "/nova/v1/exam/adaptive/session/" + session.SessionId + "/response"
+	other := easy.Id
+	if session.Question.Id == easy.Id {
+		other = medium.Id
+	}
+	w = serveTestRequest(t, router, http.MethodPost, url, AdaptiveResponse{Type: QuestionTypeJudgement, Id: other, Answer: []byte(`true`)}, examineeSession.Token)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, url, AdaptiveResponse{Type: session.Question.Type, Id: session.Question.Id, Answer: correct[session.Question.Id]}, admin.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	/* answer correctly until max length */
+	for k := 0; k < adaptive.MaxLength; k++ {
+		if !assert.NotNil(t, session.Question) {
+			return
+		}
+		w = serveTestRequest(t, router, http.MethodPost, url, AdaptiveResponse{Type: session.Question.Type, Id: session.Question.Id, Answer: correct[session.Question.Id]}, examineeSession.Token)
+		session = AdaptiveSession{}
+		unmarshalTestResponse(t, w, &session)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+	assert.Equal(t, AdaptiveSessionStateFinished, session.State)
+	assert.Equal(t, AdaptiveStopMaxLength, session.StopReason)
+	assert.Nil(t, session.Question)
+	assert.NotNil(t, session.FinishedAt)
+	if assert.Len(t, session.Items, 2) {
+		assert.True(t, session.Items[0].Correct && session.Items[1].Correct)
+		assert.NotEqual(t, session.Items[0].Id, session.Items[1].Id)
+		assert.Greater(t, session.Items[1].Ability, session.Items[0].Ability)
+	}
+	assert.Greater(t, session.Ability, 0.0)
+	assert.Less(t, session.Lower, session.Ability)
+	assert.Greater(t, session.Upper, session.Ability)
+	assert.InDelta(t, session.Upper-session.Ability, session.Ability-session.Lower, 0.002)
+	assert.False(t, math.IsNaN(session.StandardError))
+	w = serveTestRequest(t, router, http.MethodPost, url, AdaptiveResponse{Type: QuestionTypeJudgement, Id: easy.Id, Answer: []byte(`true`)}, examineeSession.Token)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	/* query finished session */
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/exam/adaptive/session/"+session.SessionId, nil, admin.Token)
+	var queried AdaptiveSession
+	unmarshalTestResponse(t, w, &queried)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, session.Ability, queried.Ability)
+	assert.Len(t, queried.Items, 2)
+}
@@ -0,0 +1,375 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"math"
+	"net/http"
+	"nova/logger"
+	"sort"
+	"strings"
+)
+
+const (
+	itemAnalysisSampleMin      = 5
+	itemAnalysisGroupRatio     = 0.27
+	itemFacilityMin            = 0.2
+	itemFacilityMax            = 0.9
+	itemDiscriminationMin      = 0.2
+	itemDistractorFrequencyMin = 0.05
+)
+
+// itemResponse is graded answer of question in one submitted attempt
+type itemResponse struct {
+	score  float64
+	rest   float64
+	total  float64
+	answer json.RawMessage
+	time   float64
+}
+
+func (nova *Nova) HandleQueryExamAnalysis(c *gin.Context) {
+	// analyze questions of exam from its submitted attempts
+	logger.Infof("handle request query exam analysis")
+	// extract examId from uri
+	examId := strings.ToLower(c.Param("examId"))
+	// request examId correctness
+	logger.Debugf("check examId is validate")
+	if err := uuid.Validate(examId); err != nil {
+		nova.response400BadRequest(c, errors.New("examId format incorrect"))
+		logger.Errorf("error check examId is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check examId is validate")
+	// examinees do not see statistics of items
+	logger.Debugf("check principal is allowed to query analysis")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to query item analysis"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to query item analysis"))
+		logger.Errorf("error check principal is allowed to query analysis")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to query analysis")
+	// query exam & its submitted attempts from database
+	logger.Debugf("query exam attempts in database")
+	exam, err := nova.db.QueryExam(examId)
+	if err != nil {
+		if errors.Is(err, errExamNotFound) {
+			nova.response404NotFound(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error query exam in database: %v", err)
+		return
+	}
+	attempts, err := nova.db.QuerySubmittedExamAttempts(examId)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query exam attempts in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query exam attempts in database")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// analyze every question asked in exam
+	logger.Debugf("analyze exam questions")
+	responses, keys := nova.collectItemResponses(attempts, map[string]*Exam{exam.Id: exam})
+	response := ExamAnalysis{ExamId: exam.Id, Attempts: len(attempts), Questions: make([]QuestionAnalysis, 0, len(keys))}
+	for _, attempt := range attempts {
+		response.AverageScore += attempt.Score / float64(len(attempts))
+	}
+	response.AverageScore = roundToDecimals(response.AverageScore, 2)
+	for _, key := range keys {
+		questionType, id, _ := strings.Cut(key, "/")
+		response.Questions = append(response.Questions, nova.analyzeQuestion(questionType, id, responses[key]))
+	}
+	logger.Debugf("successfully analyze exam questions")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionAnalysis(c *gin.Context) {
+	// analyze question from every submitted attempt asking it
+	logger.Infof("handle request query question analysis")
+	// extract question type & Id from uri
+	questionType := strings.ToLower(c.Param("type"))
+	id := strings.ToLower(c.Param("Id"))
+	// request question type & Id correctness
+	logger.Debugf("check question type & Id is validate")
+	if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question type & Id is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question type & Id is validate")
+	// examinees do not see statistics of items
+	logger.Debugf("check principal is allowed to query analysis")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to query item analysis"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to query item analysis"))
+		logger.Errorf("error check principal is allowed to query analysis")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to query analysis")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// check question existence
+	logger.Debugf("check question is existed")
+	if !nova.isQuestionExisted(questionType, id) {
+		nova.response404NotFound(c, fmt.Errorf("%v question not found", questionType))
+		logger.Errorf("error check question is existed")
+		return
+	}
+	logger.Debugf("successfully check question is existed")
+	// query submitted attempts of every exam from database
+	logger.Debugf("query exam attempts in database")
+	attempts, err := nova.db.QuerySubmittedExamAttempts("")
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query exam attempts in database: %v", err)
+		return
+	}
+	exams := make(map[string]*Exam)
+	for _, attempt := range attempts {
+		if _, ok := exams[attempt.ExamId]; ok {
+			continue
+		}
+		if exams[attempt.ExamId], err = nova.db.QueryExam(attempt.ExamId); err != nil {
+			nova.response500InternalServerError(c, err)
+			logger.Errorf("error query exam in database: %v", err)
+			return
+		}
+	}
+	logger.Debugf("successfully query exam attempts in database")
+	// analyze question over attempts asking it
+	logger.Debugf("analyze question")
+	// questions not graded automatically are left out of statistics
+	responses, _ := nova.collectItemResponses(attempts, exams)
+	response := nova.analyzeQuestion(questionType, id, responses[questionType+"/"+id])
+	logger.Debugf("successfully analyze question")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) collectItemResponses(attempts []*ExamAttempt, exams map[string]*Exam) (map[string][]itemResponse, []string) {
+	// graded answers are grouped by question, keys keep order questions were first asked
+	responses := make(map[string][]itemResponse)
+	var keys []string
+	for _, attempt := range attempts {
+		exam := exams[attempt.ExamId]
+		// scores are relative so that attempts of different exams compare
+		score, maxScore := 0.0, 0.0
+		for _, grade := range attempt.Grades {
+			if grade.Graded && grade.MaxScore > 0 {
+				score += grade.Score
+				maxScore += grade.MaxScore
+			}
+		}
+		if maxScore == 0 {
+			continue
+		}
+		answers := make(map[string]ExamAnswer, len(attempt.Answers))
+		for _, answer := range attempt.Answers {
+			answers[strings.ToLower(answer.Type)+"/"+strings.ToLower(answer.Id)] = answer
+		}
+		revisions := make(map[string]int, len(attempt.ExamQuestions))
+		for _, question := range attempt.ExamQuestions {
+			revisions[question.Type+"/"+question.Id] = question.Revision
+		}
+		for _, grade := range attempt.Grades {
+			if !grade.Graded || grade.MaxScore <= 0 {
+				continue
+			}
+			key := grade.Type + "/" + grade.Id
+			response := itemResponse{score: grade.Score / grade.MaxScore, total: score / maxScore}
+			if maxScore > grade.MaxScore {
+				response.rest = (score - grade.Score) / (maxScore - grade.MaxScore)
+			}
+			if answer, ok := answers[key]; ok {
+				response.answer, response.time = answer.Answer, answer.TimeSpent
+				// shuffled marks are counted as marks of question
+				if exam != nil && exam.ShuffleAnswers {
+					response.answer = remapExamAnswerMarks(nova.queryQuestionAnswers(grade.Type, grade.Id, revisions[key]), attempt.Seed, grade.Id, answer.Answer)
+				}
+			}
+			if _, ok := responses[key]; !ok {
+				keys = append(keys, key)
+			}
+			responses[key] = append(responses[key], response)
+		}
+	}
+	return responses, keys
+}
+
+func (nova *Nova) analyzeQuestion(questionType string, id string, responses []itemResponse) QuestionAnalysis {
+	analysis := QuestionAnalysis{Type: questionType, Id: id, Responses: len(responses), Flags: []string{}}
+	// facility is mean relative score, unanswered questions count as omitted
+	// & average time counts answers reporting it
+	scores, rests := make([]float64, len(responses)), make([]float64, len(responses))
+	timed := 0
+	for k, v := range responses {
+		scores[k], rests[k] = v.score, v.rest
+		analysis.Facility += v.score
+		if v.answer == nil {
+			analysis.Omitted++
+		}
+		if v.time > 0 {
+			analysis.AverageTime += v.time
+			timed++
+		}
+	}
+	if len(responses) > 0 {
+		analysis.Facility = roundToDecimals(analysis.Facility/float64(len(responses)), 3)
+	}
+	if timed > 0 {
+		analysis.AverageTime = roundToDecimals(analysis.AverageTime/float64(timed), 1)
+	}
+	analysis.Discrimination = roundToDecimals(queryItemDiscrimination(responses), 3)
+	analysis.PointBiserial = roundToDecimals(queryPearsonCorrelation(scores, rests), 3)
+	analysis.Distractors = nova.analyzeDistractors(questionType, id, responses)
+	// flags are raised only once enough examinees answered
+	switch {
+	case len(responses) < itemAnalysisSampleMin:
+		analysis.Flags = append(analysis.Flags, ItemFlagInsufficientData)
+	default:
+		if analysis.Facility > itemFacilityMax {
+			analysis.Flags = append(analysis.Flags, ItemFlagTooEasy)
+		}
+		if analysis.Facility < itemFacilityMin {
+			analysis.Flags = append(analysis.Flags, ItemFlagTooHard)
+		}
+		if analysis.Discrimination < itemDiscriminationMin {
+			analysis.Flags = append(analysis.Flags, ItemFlagLowDiscrimination)
+		}
+		if analysis.PointBiserial < 0 {
+			analysis.Flags = append(analysis.Flags, ItemFlagNegativePointBiserial)
+		}
+		for _, v := range analysis.Distractors {
+			if !v.Key && v.Frequency < itemDistractorFrequencyMin {
+				analysis.Flags = append(analysis.Flags, ItemFlagNonFunctioningDistractor)
+				break
+			}
+		}
+		analysis.Review = len(analysis.Flags) > 0
+	}
+	return analysis
+}
+
+func (nova *Nova) analyzeDistractors(questionType string, id string, responses []itemResponse) []DistractorAnalysis {
+	// options of choice questions with selection frequencies
+	var answers []QuestionAnswer
+	keys := make(map[string]bool)
+	switch questionType {
+	case QuestionTypeSingleChoice:
+		question, err := nova.querySingleChoiceQuestionInDataCache(id)
+		if err != nil {
+			return nil
+		}
+		answers = question.Answers
+		keys[normalizeAnswerMark(question.StandardAnswer.AnswerMark)] = true
+	case QuestionTypeMultipleChoice:
+		question, err := nova.queryMultipleChoiceQuestionInDataCache(id)
+		if err != nil {
+			return nil
+		}
+		answers = question.Answers
+		for _, v := range question.StandardAnswers {
+			keys[normalizeAnswerMark(v.AnswerMark)] = true
+		}
+	default:
+		return nil
+	}
+	counts := make(map[string]int, len(answers))
+	for _, v := range responses {
+		var marks []string
+		var mark string
+		if err := json.Unmarshal(v.answer, &mark); err == nil {
+			marks = []string{mark}
+		} else {
+			_ = json.Unmarshal(v.answer, &marks)
+		}
+		chosen := make(map[string]bool, len(marks))
+		for _, m := range marks {
+			chosen[normalizeAnswerMark(m)] = true
+		}
+		for m := range chosen {
+			counts[m]++
+		}
+	}
+	distractors := make([]DistractorAnalysis, 0, len(answers))
+	for _, v := range answers {
+		mark := normalizeAnswerMark(v.AnswerMark)
+		distractor := DistractorAnalysis{AnswerMark: v.AnswerMark, AnswerText: v.AnswerText, Key: keys[mark], Count: counts[mark]}
+		if len(responses) > 0 {
+			distractor.Frequency = roundToDecimals(float64(distractor.Count)/float64(len(responses)), 3)
+		}
+		distractors = append(distractors, distractor)
+	}
+	return distractors
+}
+
+func queryItemDiscrimination(responses []itemResponse) float64 {
+	// difference of facility between upper & lower groups ranked by total score
+	if len(responses) < 2 {
+		return 0
+	}
+	ranked := make([]itemResponse, len(responses))
+	copy(ranked, responses)
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].total > ranked[j].total })
+	n := min(int(math.Ceil(float64(len(ranked))*itemAnalysisGroupRatio)), len(ranked)/2)
+	upper, lower := 0.0, 0.0
+	for k := 0; k < n; k++ {
+		upper += ranked[k].score
+		lower += ranked[len(ranked)-1-k].score
+	}
+	return (upper - lower) / float64(n)
+}
+
+func queryPearsonCorrelation(x []float64, y []float64) float64 {
+	// correlation of item score & rest score, constant series correlate with nothing
+	n := float64(len(x))
+	if len(x) < 2 || len(x) != len(y) {
+		return 0
+	}
+	meanX, meanY := 0.0, 0.0
+	for k := range x {
+		meanX += x[k] / n
+		meanY += y[k] / n
+	}
+	cov, varX, varY := 0.0, 0.0, 0.0
+	for k := range x {
+		dx, dy := x[k]-meanX, y[k]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(varX*varY)
+}
@@ -0,0 +1,145 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"nova/utils"
+	"testing"
+)
+
+func setupAnalysisTestRouter() *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// first administrator is granted by command line
+	setupTestAdministrator(nova)
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	{
+		/* user management */
+		novaService.POST("/user/:userId", nova.HandleCreateUser)
+		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
+		novaService.PUT("/user/role/:userId", nova.HandleUpdateUserRole)
+		/* exam management */
+		novaService.POST("/exam/:examId", nova.HandleCreateExam)
+		novaService.GET("/exam/:examId/analysis", nova.HandleQueryExamAnalysis)
+		novaService.POST("/exam/:examId/attempt", nova.HandleCreateExamAttempt)
+		novaService.POST("/exam/attempt/:attemptId/submission", nova.HandleCreateExamSubmission)
+		/* question management */
+		// question analysis related
+		novaService.GET("/question/analysis/:type/:Id", nova.HandleQueryQuestionAnalysis)
+		// question workflow related
+		novaService.POST("/question/workflow/:type/:Id/transition", nova.HandleCreateQuestionTransition)
+		novaService.POST("/question/workflow/:type/:Id/approve", nova.HandleCreateQuestionApproval)
+		// question related
+		novaService.POST("/question/single-choice/:Id", nova.HandleCreateQuestionSingleChoice)
+		novaService.POST("/question/judgement/:Id", nova.HandleCreateQuestionJudgement)
+	}
+	return router
+}
+
+func startAnalysisTestService() (*httptest.Server, *gin.Engine) {
+	router := setupAnalysisTestRouter()
+	return httptest.NewServer(router), router
+}
+
+func TestNova_HandleQueryExamAnalysis(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleQueryExamAnalysis
+	// Test Purpose: Test item statistics are computed from submitted attempts
+	// Test Steps:
+	// 1. send CreateExamAttempt & CreateExamSubmission requests for six attempts
+	// 2. send QueryExamAnalysis request with examinee session, receive 403 Forbidden Code
+	// 3. send QueryExamAnalysis request, receive facility, discrimination & distractors by using 200 OK Code
+	// 4. send QueryQuestionAnalysis request, receive the same statistics of question by using 200 OK Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startAnalysisTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	_, examineeSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	single := QuestionSingleChoice{
+		Id:    uuid.New().String(),
+		Title: "Which port does DNS use " + utils.RandomAlphabet(12),
+		Answers: []QuestionAnswer{
+			{AnswerMark: "A", AnswerText: "53"},
+			{AnswerMark: "B", AnswerText: "80"},
+			{AnswerMark: "C", AnswerText: "22"},
+			{AnswerMark: "D", AnswerText: "25"},
+		},
+		StandardAnswer: QuestionAnswer{AnswerMark: "A", AnswerText: "53"},
+	}
+	createExamTestQuestion(t, server, router, QuestionTypeSingleChoice, single.Id, single, admin.Token, true)
+	judgement := QuestionJudgement{Id: uuid.New().String(), Title: "DNS may use TCP " + utils.RandomAlphabet(12), StandardAnswer: true}
+	createExamTestQuestion(t, server, router, QuestionTypeJudgement, judgement.Id, judgement, admin.Token, true)
+	exam := Exam{Id: uuid.New().String(), Title: "Networking quiz", Questions: []ExamQuestion{
+		{Type: QuestionTypeSingleChoice, Id: single.Id},
+		{Type: QuestionTypeJudgement, Id: judgement.Id},
+	}}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id, exam, admin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	/* submit attempts, strong examinees answer both questions correctly */
+	marks := []string{"A", "A", "A", "B", "B", "C"}
+	judgements := []string{"true", "true", "true", "true", "false", "false"}
+	for k := range marks {
+		w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id+"/attempt", nil, examineeSession.Token)
+		var attempt ExamAttempt
+		unmarshalTestResponse(t, w, &attempt)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		submission := ExamSubmission{Answers: []ExamAnswer{
+			{Type: QuestionTypeSingleChoice, Id: single.Id, Answer: []byte(`"` + marks[k] + `"`), TimeSpent: float64(30 * (k%2 + 1))},
+			{Type: QuestionTypeJudgement, Id: judgement.Id, Answer: []byte(judgements[k])},
+		}}
+		w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/attempt/"+attempt.AttemptId+"/submission", submission, examineeSession.Token)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+	/* examinee may not see analysis */
+	url := server.URL + "/nova/v1/exam/" + exam.Id + "/analysis"
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, examineeSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	/* analyze exam */
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, admin.Token)
+	var analysis ExamAnalysis
+	unmarshalTestResponse(t, w, &analysis)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 6, analysis.Attempts)
+	if !assert.Len(t, analysis.Questions, 2) {
+		return
+	}
+	item := analysis.Questions[0]
+	assert.Equal(t, single.Id, item.Id)
+	assert.Equal(t, 6, item.Responses)
+	assert.Equal(t, 0.5, item.Facility)
+	assert.Equal(t, 1.0, item.Discrimination)
+	assert.Greater(t, item.PointBiserial, 0.0)
+	assert.Equal(t, 45.0, item.AverageTime)
+	if assert.Len(t, item.Distractors, 4) {
+		assert.True(t, item.Distractors[0].Key)
+		assert.Equal(t, 3, item.Distractors[0].Count)
+		assert.Equal(t, 2, item.Distractors[1].Count)
+		assert.Equal(t, 0.333, item.Distractors[1].Frequency)
+		assert.Equal(t, 0, item.Distractors[3].Count)
+	}
+	assert.Equal(t, []string{ItemFlagNonFunctioningDistractor}, item.Flags)
+	assert.True(t, item.Review)
+	assert.Equal(t, judgement.Id, analysis.Questions[1].Id)
+	assert.Equal(t, 0.667, analysis.Questions[1].Facility)
+	assert.Empty(t, analysis.Questions[1].Distractors)
+	/* analyze question over every exam */
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/question/analysis/single-choice/"+single.Id, nil, admin.Token)
+	var question QuestionAnalysis
+	unmarshalTestResponse(t, w, &question)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, item, question)
+}
@@ -0,0 +1,618 @@
+package app
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"mime"
+	"net/http"
+	"nova/logger"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	attachmentDirectoryDefault = "./attachments"
+	attachmentMaxSizeDefault   = 10 << 20
+	attachmentURLExpiryDefault = 15 * time.Minute
+	attachmentGCGraceDefault   = 24 * time.Hour
+	attachmentNameMaxLength    = 255
+	attachmentsPerQuestionMax  = 20
+	// multipart framing around uploaded file
+	attachmentMultipartOverhead = 64 << 10
+)
+
+// attachmentMediaTypesDefault are sniffed media types accepted when none are configured
+var attachmentMediaTypesDefault = []string{
+	"image/png", "image/jpeg", "image/gif", "image/webp",
+	"audio/mpeg", "audio/wave", "application/ogg", "video/mp4", "video/webm",
+}
+
+// errAttachmentNotFound is returned when attachment is not stored
+var errAttachmentNotFound = errors.New("attachment not found")
+
+func (nova *Nova) HandleCreateAttachment(c *gin.Context) {
+	// upload attachment content, content is stored once under its digest
+	logger.Infof("handle request create attachment")
+	// examinees may not upload attachments
+	logger.Debugf("check principal is allowed to create attachment")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to create attachment"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to create attachment"))
+		logger.Errorf("error check principal is allowed to create attachment")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to create attachment")
+	// request body is bounded before multipart form is parsed
+	logger.Debugf("read attachment from multipart form")
+	maxSize := nova.queryAttachmentMaxSize()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+attachmentMultipartOverhead)
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			nova.response413RequestEntityTooLarge(c, fmt.Errorf("attachment should be at most %v bytes", maxSize))
+			logger.Errorf("error read attachment from multipart form: %v", err)
+			return
+		}
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error read attachment from multipart form: %v", err)
+		return
+	}
+	defer file.Close()
+	content, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error read attachment from multipart form: %v", err)
+		return
+	}
+	logger.Debugf("successfully read attachment from multipart form")
+	// attachment size & sniffed media type correctness
+	logger.Debugf("check attachment is validate")
+	if int64(len(content)) > maxSize {
+		nova.response413RequestEntityTooLarge(c, fmt.Errorf("attachment should be at most %v bytes", maxSize))
+		logger.Errorf("error check attachment is validate: too large")
+		return
+	}
+	if len(content) == 0 {
+		nova.response400BadRequest(c, errors.New("attachment should not be empty"))
+		logger.Errorf("error check attachment is validate: empty")
+		return
+	}
+	// declared content type is not trusted, media type is sniffed from content
+	mediaType, _, _ := mime.ParseMediaType(http.DetectContentType(content))
+	if !nova.isAttachmentMediaTypeValidate(mediaType) {
+		nova.response415UnsupportedMediaType(c, fmt.Errorf("attachment media type %v not supported", mediaType))
+		logger.Errorf("error check attachment is validate: media type %v", mediaType)
+		return
+	}
+	logger.Debugf("successfully check attachment is validate")
+	// store attachment content in blob store
+	logger.Debugf("store attachment content in blob store")
+	digest := sha256.Sum256(content)
+	response := Attachment{
+		Id:        hex.EncodeToString(digest[:]),
+		Name:      normalizeAttachmentName(header.Filename),
+		MediaType: mediaType,
+		Size:      int64(len(content)),
+		CreatedBy: nova.queryRequestAuthor(c),
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := nova.bs.Put(c.Request.Context(), response.Id, bytes.NewReader(content)); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store attachment content in blob store: %v", err)
+		return
+	}
+	logger.Debugf("successfully store attachment content in blob store")
+	// store attachment in database
+	logger.Debugf("store attachment in database")
+	created, err := nova.db.CreateAttachment(&response)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store attachment in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store attachment in database")
+	// same content uploaded again refers to stored attachment, uploads of other tenants are not disclosed
+	if !created {
+		stored, err := nova.db.QueryAttachment(response.Id)
+		if err != nil {
+			nova.response500InternalServerError(c, err)
+			logger.Errorf("error query attachment in database: %v", err)
+			return
+		}
+		if !nova.isAttachmentVisible(c, stored.Id) {
+			stored = &response
+		}
+		nova.signAttachment(stored)
+		nova.response200OK(c, stored)
+		logger.Infof("response status code: %v, body: %v", http.StatusOK, stored.Id)
+		return
+	}
+	// store tenant of attachment in database & data cache
+	logger.Debugf("store tenant of attachment in database & data cache")
+	if err := nova.createResourceTenant(c, TenantResourceAttachment, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store tenant of attachment in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store tenant of attachment in database & data cache")
+	// return response
+	nova.signAttachment(&response)
+	nova.response201Created(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response.Id)
+	return
+}
+
+func (nova *Nova) HandleQueryAttachment(c *gin.Context) {
+	// query attachment with signed download url
+	logger.Infof("handle request query attachment")
+	// extract attachmentId from uri
+	attachmentId := strings.ToLower(c.Param("attachmentId"))
+	// request attachmentId correctness
+	logger.Debugf("check attachmentId is validate")
+	if !blobDigestPattern.MatchString(attachmentId) {
+		nova.response400BadRequest(c, errors.New("attachmentId format incorrect"))
+		logger.Errorf("error check attachmentId is validate")
+		return
+	}
+	logger.Debugf("successfully check attachmentId is validate")
+	// query attachment from database
+	logger.Debugf("query attachment in database")
+	response, err := nova.db.QueryAttachment(attachmentId)
+	if errors.Is(err, errAttachmentNotFound) {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query attachment in database: %v", err)
+		return
+	}
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query attachment in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query attachment in database")
+	// examinees only reach attachments of questions shown to them
+	logger.Debugf("check attachment is visible")
+	if !nova.isAttachmentVisible(c, attachmentId) {
+		nova.response404NotFound(c, errAttachmentNotFound)
+		logger.Errorf("error check attachment is visible")
+		return
+	}
+	logger.Debugf("successfully check attachment is visible")
+	// return response
+	nova.signAttachment(response)
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response.Id)
+	return
+}
+
+func (nova *Nova) HandleQueryAttachmentContent(c *gin.Context) {
+	// download attachment content by signed url
+	logger.Infof("handle request query attachment content")
+	// extract attachmentId & signature from uri
+	attachmentId := strings.ToLower(c.Param("attachmentId"))
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	// signed url correctness, signature is checked before expiry so it is not an oracle
+	logger.Debugf("check attachment signature is validate")
+	if err != nil || !blobDigestPattern.MatchString(attachmentId) ||
+		!hmac.Equal([]byte(c.Query("signature")), []byte(nova.queryAttachmentSignature(attachmentId, expires))) {
+		nova.response403Forbidden(c, errors.New("attachment signature incorrect"))
+		logger.Errorf("error check attachment signature is validate")
+		return
+	}
+	if time.Now().Unix() > expires {
+		nova.response403Forbidden(c, errors.New("attachment url expired"))
+		logger.Errorf("error check attachment signature is validate: expired")
+		return
+	}
+	logger.Debugf("successfully check attachment signature is validate")
+	// query attachment from database
+	logger.Debugf("query attachment in database")
+	attachment, err := nova.db.QueryAttachment(attachmentId)
+	if errors.Is(err, errAttachmentNotFound) {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query attachment in database: %v", err)
+		return
+	}
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query attachment in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query attachment in database")
+	// open attachment content in blob store
+	logger.Debugf("open attachment content in blob store")
+	content, err := nova.bs.Open(c.Request.Context(), attachmentId)
+	if errors.Is(err, errBlobNotFound) {
+		nova.response404NotFound(c, errAttachmentNotFound)
+		logger.Errorf("error open attachment content in blob store: %v", err)
+		return
+	}
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error open attachment content in blob store: %v", err)
+		return
+	}
+	defer content.Close()
+	logger.Debugf("successfully open attachment content in blob store")
+	// browsers should neither sniff nor script attachment
+	headers := map[string]string{
+		"X-Content-Type-Options":  "nosniff",
+		"Content-Security-Policy": "default-src 'none'; sandbox",
+		"Content-Disposition":     mime.FormatMediaType("inline", map[string]string{"filename": attachment.Name}),
+		"Cache-Control":           fmt.Sprintf("private, max-age=%d", max(expires-time.Now().Unix(), 0)),
+	}
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.MediaType, content, headers)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, attachment.Id)
+	return
+}
+
+func (nova *Nova) HandleUpdateQuestionAttachments(c *gin.Context) {
+	// replace attachments referenced by question
+	var request QuestionAttachments
+	logger.Infof("handle request update question attachments")
+	// extract question type & Id from uri
+	questionType := strings.ToLower(c.Param("type"))
+	id := strings.ToLower(c.Param("Id"))
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// request question type & Id correctness
+	logger.Debugf("check question type & Id is validate")
+	if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question type & Id is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question type & Id is validate")
+	// examinees may not change questions
+	logger.Debugf("check principal is allowed to update question attachments")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to update question attachments"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to update question attachments"))
+		logger.Errorf("error check principal is allowed to update question attachments")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to update question attachments")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// check question existence
+	logger.Debugf("check question is existed")
+	if !nova.isQuestionExisted(questionType, id) {
+		nova.response404NotFound(c, fmt.Errorf("%v question not found", questionType))
+		logger.Errorf("error check question is existed")
+		return
+	}
+	logger.Debugf("successfully check question is existed")
+	// every referenced attachment should be stored
+	logger.Debugf("check question attachments are validate")
+	attachmentIds, err := nova.isQuestionAttachmentsValidate(request.Attachments)
+	if err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question attachments are validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question attachments are validate")
+	// store question attachments in database
+	logger.Debugf("store question attachments in database")
+	if err := nova.db.UpdateQuestionAttachments(questionType, id, attachmentIds); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store question attachments in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store question attachments in database")
+	// return response
+	response, err := nova.queryQuestionAttachments(questionType, id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query question attachments in database: %v", err)
+		return
+	}
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, len(response.Attachments))
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionAttachments(c *gin.Context) {
+	// query attachments referenced by question with signed download urls
+	logger.Infof("handle request query question attachments")
+	// extract question type & Id from uri
+	questionType := strings.ToLower(c.Param("type"))
+	id := strings.ToLower(c.Param("Id"))
+	// request question type & Id correctness
+	logger.Debugf("check question type & Id is validate")
+	if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question type & Id is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question type & Id is validate")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// check question existence
+	logger.Debugf("check question is existed")
+	if !nova.isQuestionExisted(questionType, id) || !nova.isQuestionVisible(c, questionType, id) {
+		nova.response404NotFound(c, fmt.Errorf("%v question not found", questionType))
+		logger.Errorf("error check question is existed")
+		return
+	}
+	logger.Debugf("successfully check question is existed")
+	// query question attachments from database
+	logger.Debugf("query question attachments in database")
+	response, err := nova.queryQuestionAttachments(questionType, id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query question attachments in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query question attachments in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, len(response.Attachments))
+	return
+}
+
+func (nova *Nova) HandleCreateAttachmentCollection(c *gin.Context) {
+	// collect attachments no question refers to anymore
+	logger.Infof("handle request create attachment collection")
+	// only admins outside tenants collect garbage, attachments are shared by every tenant
+	logger.Debugf("check principal is allowed to collect attachments")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to collect attachments"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role != RoleAdmin || nova.queryTenantScope(c) != tenantScopeAll {
+		nova.response403Forbidden(c, errors.New("only admin outside tenants is allowed to collect attachments"))
+		logger.Errorf("error check principal is allowed to collect attachments")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to collect attachments")
+	// grace period of unreferenced attachments may be shortened per request
+	logger.Debugf("check attachment grace period is validate")
+	grace := nova.queryAttachmentGCGrace()
+	if s := c.Query("grace"); s != "" {
+		seconds, err := strconv.Atoi(s)
+		if err != nil || seconds < 0 {
+			nova.response400BadRequest(c, errors.New("attachment grace period format incorrect"))
+			logger.Errorf("error check attachment grace period is validate: %v", s)
+			return
+		}
+		grace = time.Duration(seconds) * time.Second
+	}
+	logger.Debugf("successfully check attachment grace period is validate")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// collect attachments in blob store & database
+	logger.Debugf("collect orphan attachments")
+	response, err := nova.collectAttachments(c, time.Now().UTC().Add(-grace))
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error collect orphan attachments: %v", err)
+		return
+	}
+	logger.Debugf("successfully collect orphan attachments")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) collectAttachments(c *gin.Context, before time.Time) (AttachmentCollection, error) {
+	collection := AttachmentCollection{Attachments: []string{}}
+	// references of deleted questions are dropped first
+	references, err := nova.db.QueryAttachmentReferences("")
+	if err != nil {
+		return collection, err
+	}
+	for _, v := range references {
+		if nova.isQuestionExisted(v.Type, v.Id) {
+			continue
+		}
+		if err := nova.db.UpdateQuestionAttachments(v.Type, v.Id, nil); err != nil {
+			return collection, err
+		}
+		collection.References++
+	}
+	// attachments unreferenced past grace period are removed, uploads not yet referenced are kept
+	orphans, err := nova.db.QueryOrphanAttachments(before)
+	if err != nil {
+		return collection, err
+	}
+	for _, v := range orphans {
+		if err := nova.bs.Delete(c.Request.Context(), v.Id); err != nil {
+			return collection, err
+		}
+		if err := nova.db.DeleteAttachment(v.Id); err != nil {
+			return collection, err
+		}
+		if err := nova.deleteResourceTenant(TenantResourceAttachment, v.Id); err != nil {
+			return collection, err
+		}
+		collection.Attachments = append(collection.Attachments, v.Id)
+	}
+	return collection, nil
+}
+
+func (nova *Nova) isQuestionAttachmentsValidate(attachments []Attachment) ([]string, error) {
+	// check attachments are stored & referenced once
+	v := &questionValidator{}
+	if len(attachments) > attachmentsPerQuestionMax {
+		v.add("attachments", "should have at most %v attachments, got %v", attachmentsPerQuestionMax, len(attachments))
+	}
+	attachmentIds := make([]string, 0, len(attachments))
+	seen := make(map[string]bool, len(attachments))
+	for k, attachment := range attachments {
+		field := fmt.Sprintf("attachments[%v].id", k)
+		id := strings.ToLower(attachment.Id)
+		if !blobDigestPattern.MatchString(id) {
+			v.add(field, "should be SHA-256 digest of attachment")
+			continue
+		}
+		if seen[id] {
+			v.add(field, "duplicates attachment %v", id)
+			continue
+		}
+		seen[id] = true
+		if _, err := nova.db.QueryAttachment(id); errors.Is(err, errAttachmentNotFound) {
+			v.add(field, "attachment %v not found", id)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		attachmentIds = append(attachmentIds, id)
+	}
+	if b, err := v.result(); !b {
+		return nil, err
+	}
+	return attachmentIds, nil
+}
+
+func (nova *Nova) queryQuestionAttachments(questionType string, id string) (QuestionAttachments, error) {
+	// attachments of question in order with signed download urls
+	attachments, err := nova.db.QueryQuestionAttachments(questionType, id)
+	if err != nil {
+		return QuestionAttachments{}, err
+	}
+	response := QuestionAttachments{Type: questionType, Id: id, Attachments: make([]Attachment, 0, len(attachments))}
+	for _, v := range attachments {
+		nova.signAttachment(v)
+		response.Attachments = append(response.Attachments, *v)
+	}
+	return response, nil
+}
+
+func (nova *Nova) isAttachmentVisible(c *gin.Context, attachmentId string) bool {
+	// staff see attachments uploaded in their tenant, everyone sees attachments of questions visible to them
+	scope := nova.queryTenantScope(c)
+	if _, role, ok := nova.queryPrincipalRole(c); ok && role != RoleExaminee && nova.isResourceInScope(scope, TenantResourceAttachment, attachmentId) {
+		return true
+	}
+	references, err := nova.db.QueryAttachmentReferences(attachmentId)
+	if err != nil {
+		return false
+	}
+	for _, v := range references {
+		if nova.isResourceInScope(scope, v.Type, v.Id) && nova.isQuestionVisible(c, v.Type, v.Id) {
+			return true
+		}
+	}
+	return false
+}
+
+func (nova *Nova) isAttachmentMediaTypeValidate(mediaType string) bool {
+	// check sniffed media type is accepted
+	mediaTypes := nova.conf.Configure.Attachment.MediaTypes
+	if len(mediaTypes) == 0 {
+		mediaTypes = attachmentMediaTypesDefault
+	}
+	for _, v := range mediaTypes {
+		if strings.EqualFold(v, mediaType) {
+			return true
+		}
+	}
+	return false
+}
+
+func (nova *Nova) signAttachment(attachment *Attachment) {
+	// download url is valid until it expires, without user session
+	expiresAt := time.Now().UTC().Add(nova.queryAttachmentURLExpiry()).Truncate(time.Second)
+	signature := nova.queryAttachmentSignature(attachment.Id, expiresAt.Unix())
+	attachment.URL = fmt.Sprintf("/nova/v1/attachment/%v/content?expires=%v&signature=%v", attachment.Id, expiresAt.Unix(), signature)
+	attachment.ExpiresAt = &expiresAt
+}
+
+func (nova *Nova) queryAttachmentSignature(attachmentId string, expires int64) string {
+	mac := hmac.New(sha256.New, nova.ak)
+	_, _ = fmt.Fprintf(mac, "%v\n%v", attachmentId, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (nova *Nova) queryAttachmentSigningKey() ([]byte, error) {
+	// urls signed by random key do not outlive service
+	if key := nova.conf.Configure.Attachment.SigningKey; key != "" {
+		return []byte(key), nil
+	}
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (nova *Nova) queryAttachmentDirectory() string {
+	if directory := nova.conf.Configure.Attachment.Directory; directory != "" {
+		return directory
+	}
+	return attachmentDirectoryDefault
+}
+
+func (nova *Nova) queryAttachmentMaxSize() int64 {
+	if size := nova.conf.Configure.Attachment.MaxSize; size > 0 {
+		return size
+	}
+	return attachmentMaxSizeDefault
+}
+
+func (nova *Nova) queryAttachmentURLExpiry() time.Duration {
+	if expiry := nova.conf.Configure.Attachment.URLExpiry; expiry > 0 {
+		return time.Duration(expiry) * time.Second
+	}
+	return attachmentURLExpiryDefault
+}
+
+func (nova *Nova) queryAttachmentGCGrace() time.Duration {
+	if grace := nova.conf.Configure.Attachment.GCGrace; grace > 0 {
+		return time.Duration(grace) * time.Second
+	}
+	return attachmentGCGraceDefault
+}
+
+func normalizeAttachmentName(name string) string {
+	// keep base name of uploaded file only
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "." || name == "/" {
+		return ""
+	}
+	if utf8.RuneCountInString(name) > attachmentNameMaxLength {
+		name = string([]rune(name)[:attachmentNameMaxLength])
+	}
+	return name
+}
@@ -0,0 +1,233 @@
+package app
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"nova/utils"
+	"strings"
+	"testing"
+)
+
+func setupAttachmentTestRouter() *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// author uploading attachments
+	createTestAuthor(nova)
+	// first administrator is granted by command line
+	setupTestAdministrator(nova)
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	{
+		/* user management */
+		novaService.POST("/user/:userId", nova.HandleCreateUser)
+		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
+		novaService.PUT("/user/role/:userId", nova.HandleUpdateUserRole)
+		/* attachment management */
+		novaService.POST("/attachment", nova.HandleCreateAttachment)
+		novaService.POST("/attachment/gc", nova.HandleCreateAttachmentCollection)
+		novaService.GET("/attachment/:attachmentId", nova.HandleQueryAttachment)
+		novaService.GET("/attachment/:attachmentId/content", nova.HandleQueryAttachmentContent)
+		/* question management */
+		// question attachment related
+		novaService.PUT("/question/attachment/:type/:Id", nova.HandleUpdateQuestionAttachments)
+		novaService.GET("/question/attachment/:type/:Id", nova.HandleQueryQuestionAttachments)
+		// question related
+		novaService.POST("/question/single-choice/:Id", nova.HandleCreateQuestionSingleChoice)
+		novaService.DELETE("/question/single-choice/:Id", nova.HandleDeleteQuestionSingleChoice)
+	}
+	return router
+}
+
+func startAttachmentTestService() (*httptest.Server, *gin.Engine) {
+	router := setupAttachmentTestRouter()
+	return httptest.NewServer(router), router
+}
+
+func serveAttachmentTestRequest(t *testing.T, router *gin.Engine, url string, name string, content []byte, token string) *httptest.ResponseRecorder {
+	// upload content as multipart form file
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", name)
+	if err != nil {
+		t.Errorf("error create multipart form: %v", err)
+	}
+	_, _ = part.Write(content)
+	_ = writer.Close()
+	w := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, url, &body)
+	if err != nil {
+		t.Errorf("error creating request: %v", err)
+	}
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	router.ServeHTTP(w, request)
+	return w
+}
+
+func newAttachmentTestImage(t *testing.T) []byte {
+	// encode png with random pixels so every image has distinct content
+	var b bytes.Buffer
+	img := image.NewGray(image.Rect(0, 0, 16, 1))
+	id := uuid.New()
+	copy(img.Pix, id[:])
+	if err := png.Encode(&b, img); err != nil {
+		t.Errorf("error encode png: %v", err)
+	}
+	return b.Bytes()
+}
+
+func TestNova_HandleCreateAttachment(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateAttachment
+	// Test Purpose: Test attachments are sniffed, stored by digest & downloaded by signed url
+	// Test Steps:
+	// 1. send CreateAttachment request with png image, receive 201 Created Code
+	// 2. send CreateAttachment request with same image, receive stored attachment by using 200 OK Code
+	// 3. send CreateAttachment request with script content & oversized content, receive 415 & 413
+	// 4. send QueryAttachmentContent request with signed url, receive image by using 200 OK Code
+	// 5. send QueryAttachmentContent request with tampered signature, receive 403 Forbidden Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startAttachmentTestService()
+	defer server.Close()
+	/* upload image */
+	content := newAttachmentTestImage(t)
+	digest := sha256.Sum256(content)
+	url := server.URL + "/nova/v1/attachment"
+	w := serveAttachmentTestRequest(t, router, url, "../diagram.png", content, testAuthorToken)
+	var attachment Attachment
+	unmarshalTestResponse(t, w, &attachment)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, hex.EncodeToString(digest[:]), attachment.Id)
+	assert.Equal(t, "diagram.png", attachment.Name)
+	assert.Equal(t, "image/png", attachment.MediaType)
+	assert.Equal(t, int64(len(content)), attachment.Size)
+	assert.NotEmpty(t, attachment.URL)
+	/* same content is stored once */
+	w = serveAttachmentTestRequest(t, router, url, "copy.png", content, testAuthorToken)
+	var stored Attachment
+	unmarshalTestResponse(t, w, &stored)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, attachment.Id, stored.Id)
+	assert.Equal(t, "diagram.png", stored.Name)
+	/* declared name & type do not matter, content does */
+	w = serveAttachmentTestRequest(t, router, url, "image.png", []byte("<script>alert(1)</script>"), testAuthorToken)
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	w = serveAttachmentTestRequest(t, router, url, "large.png", append(content, make([]byte, attachmentMaxSizeDefault)...), testAuthorToken)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	/* download by signed url */
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+attachment.URL, nil, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, content, w.Body.Bytes())
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	/* tampered signature is rejected */
+	tampered := strings.Replace(attachment.URL, "expires=", "expires=1", 1)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+tampered, nil, "")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/attachment/"+attachment.Id+"/content", nil, "")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestNova_HandleUpdateQuestionAttachments(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleUpdateQuestionAttachments
+	// Test Purpose: Test questions refer to attachments & orphans are collected
+	// Test Steps:
+	// 1. send CreateQuestion & CreateAttachment requests by using POST method
+	// 2. send UpdateQuestionAttachments request with unknown attachment, receive 400 Bad Request Code
+	// 3. send UpdateQuestionAttachments request, receive attachments with signed urls by using 200 OK Code
+	// 4. send QueryAttachment request with examinee session for draft question, receive 404 Not Found Code
+	// 5. send DeleteQuestion & CreateAttachmentCollection requests, attachment is collected
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startAttachmentTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	_, examineeSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	/* create question & attachment */
+	question := QuestionSingleChoice{
+		Id:    uuid.New().String(),
+		Title: "Which shape is drawn in the diagram " + utils.RandomAlphabet(12),
+		Answers: []QuestionAnswer{
+			{AnswerMark: "A", AnswerText: "Line"},
+			{AnswerMark: "B", AnswerText: "Dot"},
+		},
+		StandardAnswer: QuestionAnswer{AnswerMark: "A", AnswerText: "Line"},
+	}
+	url := server.URL + "/nova/v1/question/single-choice/" + question.Id
+	w := serveTestRequest(t, router, http.MethodPost, url, question, admin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveAttachmentTestRequest(t, router, server.URL+"/nova/v1/attachment", "line.png", newAttachmentTestImage(t), admin.Token)
+	var attachment Attachment
+	unmarshalTestResponse(t, w, &attachment)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveAttachmentTestRequest(t, router, server.URL+"/nova/v1/attachment", "line.png", newAttachmentTestImage(t), examineeSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	/* unknown attachments are rejected */
+	attachmentURL := server.URL + "/nova/v1/question/attachment/single-choice/" + question.Id
+	unknown := strings.Repeat("0", 64)
+	w = serveTestRequest(t, router, http.MethodPut, attachmentURL, QuestionAttachments{Attachments: []Attachment{{Id: attachment.Id}, {Id: unknown}}}, admin.Token)
+	var problem ProblemDetails
+	unmarshalTestResponse(t, w, &problem)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	if assert.Len(t, problem.Errors, 1) {
+		assert.Equal(t, "attachments[1].id", problem.Errors[0].Field)
+	}
+	/* question refers to attachment */
+	w = serveTestRequest(t, router, http.MethodPut, attachmentURL, QuestionAttachments{Attachments: []Attachment{{Id: attachment.Id}}}, admin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, attachmentURL, nil, admin.Token)
+	var attachments QuestionAttachments
+	unmarshalTestResponse(t, w, &attachments)
+	assert.Equal(t, http.StatusOK, w.Code)
+	if assert.Len(t, attachments.Attachments, 1) {
+		assert.Equal(t, attachment.Id, attachments.Attachments[0].Id)
+		assert.NotEmpty(t, attachments.Attachments[0].URL)
+	}
+	/* examinee does not reach attachment of draft question */
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/attachment/"+attachment.Id, nil, examineeSession.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	/* referenced attachment is kept */
+	gcURL := server.URL + "/nova/v1/attachment/gc?grace=0"
+	w = serveTestRequest(t, router, http.MethodPost, gcURL, nil, examineeSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, gcURL, nil, admin.Token)
+	var collection AttachmentCollection
+	unmarshalTestResponse(t, w, &collection)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, collection.Attachments, attachment.Id)
+	/* attachment of deleted question is collected */
+	w = serveTestRequest(t, router, http.MethodDelete, url, nil, admin.Token)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, gcURL, nil, admin.Token)
+	collection = AttachmentCollection{}
+	unmarshalTestResponse(t, w, &collection)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1, collection.References)
+	assert.Contains(t, collection.Attachments, attachment.Id)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+attachment.URL, nil, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
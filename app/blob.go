@@ -0,0 +1,98 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// errBlobNotFound is returned when blob store has no content for digest
+var errBlobNotFound = errors.New("blob not found")
+
+// blobDigestPattern matches hex SHA-256 digest addressing blob content
+var blobDigestPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// BlobStore keeps attachment content addressed by SHA-256 digest of the content
+type BlobStore interface {
+	Put(ctx context.Context, digest string, content io.Reader) error
+	Open(ctx context.Context, digest string) (io.ReadCloser, error)
+	Delete(ctx context.Context, digest string) error
+}
+
+// FileBlobStore keeps blobs in local directory, fanned out by first byte of digest
+type FileBlobStore struct {
+	directory string
+}
+
+func NewFileBlobStore(directory string) (*FileBlobStore, error) {
+	if err := os.MkdirAll(directory, 0750); err != nil {
+		return nil, err
+	}
+	return &FileBlobStore{directory: directory}, nil
+}
+
+func (bs *FileBlobStore) path(digest string) (string, error) {
+	// digest is only trusted as path after it is checked
+	if !blobDigestPattern.MatchString(digest) {
+		return "", errors.New("blob digest format incorrect")
+	}
+	return filepath.Join(bs.directory, digest[:2], digest), nil
+}
+
+func (bs *FileBlobStore) Put(ctx context.Context, digest string, content io.Reader) error {
+	path, err := bs.path(digest)
+	if err != nil {
+		return err
+	}
+	// content addressed blob is written once
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	// write to temporary file & rename so readers never see partial blob
+	file, err := os.CreateTemp(filepath.Dir(path), ".blob-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := io.Copy(file, content); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+func (bs *FileBlobStore) Open(ctx context.Context, digest string) (io.ReadCloser, error) {
+	path, err := bs.path(digest)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errBlobNotFound
+	}
+	return file, err
+}
+
+func (bs *FileBlobStore) Delete(ctx context.Context, digest string) error {
+	path, err := bs.path(digest)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,435 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	mathrand "math/rand"
+	"net/http"
+	"nova/logger"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	examBlueprintRulesMax          = 50
+	examBlueprintRecentDaysDefault = 30
+)
+
+// errExamBlueprintNotFound is returned when exam blueprint is not stored
+var errExamBlueprintNotFound = errors.New("exam blueprint not found")
+
+// errExamBlueprintExisted is returned when exam blueprint with the same Id is stored
+var errExamBlueprintExisted = errors.New("exam blueprint already exists")
+
+func (nova *Nova) HandleCreateExamBlueprint(c *gin.Context) {
+	// create blueprint describing which questions an exam draws from the bank
+	var request ExamBlueprint
+	logger.Infof("handle request create exam blueprint")
+	// extract blueprintId from uri
+	blueprintId := strings.ToLower(c.Param("blueprintId"))
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// examinees take exams but do not compose them
+	logger.Debugf("check principal is allowed to create exam blueprint")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to create exam blueprint"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to create exam blueprint"))
+		logger.Errorf("error check principal is allowed to create exam blueprint")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to create exam blueprint")
+	// check request body correctness
+	logger.Debugf("check exam blueprint is validate")
+	if b, err := nova.isExamBlueprintValidate(blueprintId, request); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check exam blueprint is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check exam blueprint is validate")
+	// store created exam blueprint in database
+	logger.Debugf("store exam blueprint in database")
+	response := ExamBlueprint{
+		Id:               blueprintId,
+		Title:            request.Title,
+		Rules:            make([]ExamBlueprintRule, 0, len(request.Rules)),
+		TotalPoints:      request.TotalPoints,
+		Mode:             strings.ToLower(request.Mode),
+		RecentDays:       request.RecentDays,
+		ShuffleQuestions: request.ShuffleQuestions,
+		ShuffleAnswers:   request.ShuffleAnswers,
+		CreatedBy:        nova.queryRequestAuthor(c),
+		CreatedAt:        time.Now().UTC(),
+	}
+	if response.Mode == "" {
+		response.Mode = BlueprintModeFixed
+	}
+	if response.RecentDays == 0 {
+		response.RecentDays = examBlueprintRecentDaysDefault
+	}
+	for _, v := range request.Rules {
+		// question without points is worth one point
+		if v.Points == 0 {
+			v.Points = 1
+		}
+		v.Type, v.Difficulty = strings.ToLower(v.Type), strings.ToLower(v.Difficulty)
+		v.Category, v.Tag = strings.TrimSpace(v.Category), normalizeQuestionTag(v.Tag)
+		response.Rules = append(response.Rules, v)
+	}
+	if err := nova.db.CreateExamBlueprint(&response); err != nil {
+		if errors.Is(err, errExamBlueprintExisted) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error store exam blueprint in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store exam blueprint in database")
+	// store exam blueprint tenant in database & data cache
+	logger.Debugf("store exam blueprint tenant in database & data cache")
+	if err := nova.createResourceTenant(c, TenantResourceBlueprint, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store exam blueprint tenant in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store exam blueprint tenant in database & data cache")
+	// return response
+	nova.response201Created(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response)
+	return
+}
+
+func (nova *Nova) HandleQueryExamBlueprint(c *gin.Context) {
+	// query exam blueprint
+	logger.Infof("handle request query exam blueprint")
+	// extract blueprintId from uri
+	blueprintId := strings.ToLower(c.Param("blueprintId"))
+	// query exam blueprint from database
+	logger.Debugf("query exam blueprint in database")
+	response, ok := nova.queryExamBlueprintInDatabase(c, blueprintId)
+	if !ok {
+		return
+	}
+	logger.Debugf("successfully query exam blueprint in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleCreateExamBlueprintExam(c *gin.Context) {
+	// generate exam from blueprint
+	var request ExamGeneration
+	logger.Infof("handle request create exam from blueprint")
+	// extract blueprintId from uri
+	blueprintId := strings.ToLower(c.Param("blueprintId"))
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// request examId correctness
+	logger.Debugf("check examId is validate")
+	if err := uuid.Validate(request.Id); err != nil {
+		nova.response400BadRequest(c, FieldErrors{{Field: "id", Reason: fmt.Sprintf("should be UUID: %v", err)}})
+		logger.Errorf("error check examId is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check examId is validate")
+	// examinees take exams but do not compose them
+	logger.Debugf("check principal is allowed to create exam")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to create exam"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to create exam"))
+		logger.Errorf("error check principal is allowed to create exam")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to create exam")
+	// query exam blueprint from database
+	logger.Debugf("query exam blueprint in database")
+	blueprint, ok := nova.queryExamBlueprintInDatabase(c, blueprintId)
+	if !ok {
+		return
+	}
+	logger.Debugf("successfully query exam blueprint in database")
+	// timing sections split questions drawn by every rule
+	logger.Debugf("check exam timing is validate")
+	v, count := &questionValidator{}, 0
+	for _, rule := range blueprint.Rules {
+		count += rule.Count
+	}
+	checkExamTiming(v, request.Timing, count)
+	if b, err := v.result(); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check exam timing is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check exam timing is validate")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	if err := nova.queryQuestionWorkflowsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying question workflows in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// draw questions, exam generated per attempt only checks bank satisfies blueprint
+	logger.Debugf("sample exam questions from blueprint")
+	seed, err := newExamAttemptSeed()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error create exam seed: %v", err)
+		return
+	}
+	questions, err := nova.sampleExamBlueprint(blueprint, seed, nil)
+	if err != nil {
+		if errors.As(err, new(FieldErrors)) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error sample exam questions from blueprint: %v", err)
+		return
+	}
+	if blueprint.Mode == BlueprintModePerAttempt {
+		questions = nil
+	}
+	logger.Debugf("successfully sample exam questions from blueprint")
+	// store generated exam in database
+	logger.Debugf("store exam in database")
+	response := Exam{
+		Id:               strings.ToLower(request.Id),
+		Title:            request.Title,
+		Questions:        questions,
+		ShuffleQuestions: blueprint.ShuffleQuestions,
+		ShuffleAnswers:   blueprint.ShuffleAnswers,
+		BlueprintId:      blueprint.Id,
+		Timing:           normalizeExamTiming(request.Timing),
+		CreatedBy:        nova.queryRequestAuthor(c),
+		CreatedAt:        time.Now().UTC(),
+	}
+	if strings.TrimSpace(response.Title) == "" {
+		response.Title = blueprint.Title
+	}
+	if err := nova.db.CreateExam(&response); err != nil {
+		if errors.Is(err, errExamExisted) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error store exam in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store exam in database")
+	// generated exam belongs to tenant of its blueprint
+	tenantId, _ := nova.queryResourceTenant(TenantResourceBlueprint, blueprint.Id)
+	// store exam tenant in database & data cache
+	logger.Debugf("store exam tenant in database & data cache")
+	if err := nova.updateResourceTenant(TenantResourceExam, response.Id, tenantId); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store exam tenant in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store exam tenant in database & data cache")
+	// return response
+	nova.response201Created(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response)
+	return
+}
+
+func (nova *Nova) isExamBlueprintValidate(blueprintId string, blueprint ExamBlueprint) (bool, error) {
+	// check blueprint identity matches uri
+	v := &questionValidator{}
+	v.checkId(blueprint.Id)
+	if !strings.EqualFold(blueprint.Id, blueprintId) {
+		v.add("id", "should match blueprintId of uri")
+	}
+	v.checkText("title", blueprint.Title, questionTitleMaxLength)
+	switch strings.ToLower(blueprint.Mode) {
+	case "", BlueprintModeFixed, BlueprintModePerAttempt:
+	default:
+		v.add("mode", "should be %v or %v", BlueprintModeFixed, BlueprintModePerAttempt)
+	}
+	if blueprint.TotalPoints < 0 {
+		v.add("total_points", "should not be negative")
+	}
+	if blueprint.RecentDays < 0 {
+		v.add("recent_days", "should not be negative")
+	}
+	// check every rule selects questions the bank can hold
+	if len(blueprint.Rules) == 0 || len(blueprint.Rules) > examBlueprintRulesMax {
+		v.add("rules", "should have 1 to %v rules, got %v", examBlueprintRulesMax, len(blueprint.Rules))
+	}
+	count := 0
+	for k, rule := range blueprint.Rules {
+		field := fmt.Sprintf("rules[%v]", k)
+		if b, err := nova.isQuestionTypeValidate(strings.ToLower(rule.Type)); !b {
+			v.add(field+".type", "%v", err)
+		}
+		if b, err := isQuestionDifficultyValidate(strings.ToLower(rule.Difficulty)); !b {
+			v.add(field+".difficulty", "%v", err)
+		}
+		if rule.Count < 1 {
+			v.add(field+".count", "should be at least 1")
+		}
+		if rule.Points < 0 {
+			v.add(field+".points", "should not be negative")
+		}
+		count += rule.Count
+	}
+	if count > examQuestionsMax {
+		v.add("rules", "should draw at most %v questions, got %v", examQuestionsMax, count)
+	}
+	return v.result()
+}
+
+func (nova *Nova) queryExamBlueprintInDatabase(c *gin.Context, blueprintId string) (ExamBlueprint, bool) {
+	// request blueprintId correctness
+	if err := uuid.Validate(blueprintId); err != nil {
+		nova.response400BadRequest(c, errors.New("blueprintId format incorrect"))
+		logger.Errorf("error check blueprintId is validate: %v", err)
+		return ExamBlueprint{}, false
+	}
+	// query exam blueprint from database
+	blueprint, err := nova.db.QueryExamBlueprint(blueprintId)
+	if err != nil {
+		if errors.Is(err, errExamBlueprintNotFound) {
+			nova.response404NotFound(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error query exam blueprint in database: %v", err)
+		return ExamBlueprint{}, false
+	}
+	return *blueprint, true
+}
+
+func (nova *Nova) sampleExamAttemptQuestions(blueprintId string, userId string, seed int64) ([]ExamQuestion, error) {
+	// examinee preferably gets questions not seen within recent days
+	blueprint, err := nova.db.QueryExamBlueprint(blueprintId)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	if userId != anonymousAuthor {
+		since := time.Now().UTC().AddDate(0, 0, -blueprint.RecentDays)
+		questions, err := nova.db.QueryUserExamQuestions(userId, since)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range questions {
+			seen[v.Type+"/"+v.Id] = true
+		}
+	}
+	return nova.sampleExamBlueprint(*blueprint, seed, seen)
+}
+
+func (nova *Nova) sampleExamBlueprint(blueprint ExamBlueprint, seed int64, seen map[string]bool) ([]ExamQuestion, error) {
+	// classify published questions of bank
+	classifications, err := nova.db.QueryQuestionClassifications()
+	if err != nil {
+		return nil, err
+	}
+	classified := make(map[string]*QuestionClassification, len(classifications))
+	for _, v := range classifications {
+		classified[v.Type+"/"+v.Id] = v
+	}
+	// questions are drawn from bank of tenant owning blueprint
+	scope, _ := nova.queryResourceTenant(TenantResourceBlueprint, blueprint.Id)
+	sources := nova.queryQuestionFingerprintSources(scope)
+	sort.Slice(sources, func(i, j int) bool {
+		if sources[i].Type != sources[j].Type {
+			return sources[i].Type < sources[j].Type
+		}
+		return sources[i].Id < sources[j].Id
+	})
+	// every rule draws unseen questions first, one question is drawn at most once
+	v := &questionValidator{}
+	random := mathrand.New(mathrand.NewSource(seed))
+	drawn := make(map[string]bool)
+	var questions []ExamQuestion
+	for k, rule := range blueprint.Rules {
+		var fresh, stale []string
+		for _, source := range sources {
+			key := source.Type + "/" + source.Id
+			if source.Type != rule.Type || drawn[key] || !nova.isQuestionPublished(source.Type, source.Id) ||
+				!isQuestionClassificationMatched(classified[key], rule) {
+				continue
+			}
+			if seen[key] {
+				stale = append(stale, source.Id)
+			} else {
+				fresh = append(fresh, source.Id)
+			}
+		}
+		if n := len(fresh) + len(stale); n < rule.Count {
+			v.add(fmt.Sprintf("rules[%v].count", k), "bank has %v matching questions, %v required", n, rule.Count)
+			continue
+		}
+		random.Shuffle(len(fresh), func(i, j int) { fresh[i], fresh[j] = fresh[j], fresh[i] })
+		random.Shuffle(len(stale), func(i, j int) { stale[i], stale[j] = stale[j], stale[i] })
+		for _, id := range append(fresh, stale...)[:rule.Count] {
+			drawn[rule.Type+"/"+id] = true
+			questions = append(questions, ExamQuestion{Type: rule.Type, Id: id, Points: rule.Points})
+		}
+	}
+	if b, err := v.result(); !b {
+		return nil, err
+	}
+	// points are scaled to total of blueprint
+	if blueprint.TotalPoints > 0 {
+		total := 0.0
+		for _, question := range questions {
+			total += question.Points
+		}
+		for k := range questions {
+			questions[k].Points = roundToDecimals(questions[k].Points*blueprint.TotalPoints/total, 2)
+		}
+	}
+	return questions, nil
+}
+
+func isQuestionClassificationMatched(classification *QuestionClassification, rule ExamBlueprintRule) bool {
+	// unclassified question only matches rule without criteria
+	if classification == nil {
+		return rule.Category == "" && rule.Difficulty == "" && rule.Tag == ""
+	}
+	if rule.Category != "" && !strings.EqualFold(rule.Category, classification.Category) {
+		return false
+	}
+	if rule.Difficulty != "" && rule.Difficulty != classification.Difficulty {
+		return false
+	}
+	if rule.Tag == "" {
+		return true
+	}
+	for _, tag := range classification.Tags {
+		if tag == rule.Tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,242 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"nova/utils"
+	"testing"
+)
+
+func setupBlueprintTestRouter() *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// first administrator is granted by command line
+	setupTestAdministrator(nova)
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	{
+		/* user management */
+		novaService.POST("/user/:userId", nova.HandleCreateUser)
+		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
+		novaService.PUT("/user/role/:userId", nova.HandleUpdateUserRole)
+		/* exam management */
+		novaService.POST("/exam/blueprint/:blueprintId", nova.HandleCreateExamBlueprint)
+		novaService.GET("/exam/blueprint/:blueprintId", nova.HandleQueryExamBlueprint)
+		novaService.POST("/exam/blueprint/:blueprintId/exam", nova.HandleCreateExamBlueprintExam)
+		novaService.GET("/exam/:examId", nova.HandleQueryExam)
+		novaService.POST("/exam/:examId/attempt", nova.HandleCreateExamAttempt)
+		/* question management */
+		// question classification related
+		novaService.PUT("/question/classification/:type/:Id", nova.HandleUpdateQuestionClassification)
+		novaService.GET("/question/classification/:type/:Id", nova.HandleQueryQuestionClassification)
+		// question workflow related
+		novaService.POST("/question/workflow/:type/:Id/transition", nova.HandleCreateQuestionTransition)
+		novaService.POST("/question/workflow/:type/:Id/approve", nova.HandleCreateQuestionApproval)
+		// question related
+		novaService.POST("/question/judgement/:Id", nova.HandleCreateQuestionJudgement)
+		novaService.POST("/question/essay/:Id", nova.HandleCreateQuestionEssay)
+	}
+	return router
+}
+
+func startBlueprintTestService() (*httptest.Server, *gin.Engine) {
+	router := setupBlueprintTestRouter()
+	return httptest.NewServer(router), router
+}
+
+func createBlueprintTestJudgement(t *testing.T, server *httptest.Server, router *gin.Engine, category string, difficulty string, token string) string {
+	// create published judgement question & classify it
+	question := QuestionJudgement{Id: uuid.New().String(), Title: "Routers forward packets " + utils.RandomAlphabet(12), StandardAnswer: true}
+	createExamTestQuestion(t, server, router, QuestionTypeJudgement, question.Id, question, token, true)
+	url := server.URL + "/nova/v1/question/classification/judgement/" + question.Id
+	w := serveTestRequest(t, router, http.MethodPut, url, QuestionClassification{Category: category, Difficulty: difficulty}, token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	return question.Id
+}
+
+func TestNova_HandleUpdateQuestionClassification(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleUpdateQuestionClassification
+	// Test Purpose: Test questions are classified by category, difficulty & tags
+	// Test Steps:
+	// 1. send UpdateQuestionClassification request with unknown difficulty, receive 400 Bad Request Code
+	// 2. send UpdateQuestionClassification request with examinee session, receive 403 Forbidden Code
+	// 3. send UpdateQuestionClassification request, receive normalized tags by using 200 OK Code
+	// 4. send QueryQuestionClassification request, receive stored classification by using 200 OK Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startBlueprintTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	_, examineeSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	question := QuestionJudgement{Id: uuid.New().String(), Title: "Switches learn MAC addresses " + utils.RandomAlphabet(12), StandardAnswer: true}
+	createExamTestQuestion(t, server, router, QuestionTypeJudgement, question.Id, question, admin.Token, false)
+	url := server.URL + "/nova/v1/question/classification/judgement/" + question.Id
+	/* unknown difficulty is rejected */
+	w := serveTestRequest(t, router, http.MethodPut, url, QuestionClassification{Category: "Networking", Difficulty: "extreme"}, admin.Token)
+	var problem ProblemDetails
+	unmarshalTestResponse(t, w, &problem)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	if assert.Len(t, problem.Errors, 1) {
+		assert.Equal(t, "difficulty", problem.Errors[0].Field)
+	}
+	/* examinee may not classify */
+	classification := QuestionClassification{Category: " Networking ", Difficulty: "Medium", Tags: []string{"Layer2", "layer2 ", "ethernet"}}
+	w = serveTestRequest(t, router, http.MethodPut, url, classification, examineeSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	/* classify question */
+	w = serveTestRequest(t, router, http.MethodPut, url, classification, admin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, admin.Token)
+	var stored QuestionClassification
+	unmarshalTestResponse(t, w, &stored)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Networking", stored.Category)
+	assert.Equal(t, DifficultyMedium, stored.Difficulty)
+	assert.Equal(t, []string{"layer2", "ethernet"}, stored.Tags)
+	/* examinee does not see classification of draft */
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, examineeSession.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestNova_HandleCreateExamBlueprintExam(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateExamBlueprintExam
+	// Test Purpose: Test exams are generated from blueprint rules & shortages are reported
+	// Test Steps:
+	// 1. send CreateExamBlueprint request with unknown difficulty, receive 400 Bad Request Code
+	// 2. send CreateExamBlueprint request with rules by category & difficulty, receive 201 Created Code
+	// 3. send CreateExamBlueprintExam request, receive exam with matching questions & total points
+	// 4. send CreateExamBlueprintExam request for blueprint the bank cannot satisfy, receive 409 Conflict Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startBlueprintTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	/* create classified bank */
+	category := "Networking " + utils.RandomAlphabet(8)
+	medium := map[string]bool{}
+	for k := 0; k < 3; k++ {
+		medium[createBlueprintTestJudgement(t, server, router, category, DifficultyMedium, admin.Token)] = true
+	}
+	hard := createBlueprintTestJudgement(t, server, router, category, DifficultyHard, admin.Token)
+	essay := QuestionEssay{Id: uuid.New().String(), Title: "Explain subnetting " + utils.RandomAlphabet(12), Answer: "-", StandardAnswer: "-"}
+	createExamTestQuestion(t, server, router, QuestionTypeEssay, essay.Id, essay, admin.Token, true)
+	w := serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/question/classification/essay/"+essay.Id, QuestionClassification{Category: category}, admin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	/* invalid rule is rejected */
+	blueprint := ExamBlueprint{
+		Id:    uuid.New().String(),
+		Title: "Networking midterm",
+		Rules: []ExamBlueprintRule{
+			{Type: QuestionTypeJudgement, Category: category, Difficulty: "extreme", Count: 2},
+			{Type: QuestionTypeEssay, Category: category, Count: 1, Points: 2},
+		},
+		TotalPoints: 100,
+	}
+	url := server.URL + "/nova/v1/exam/blueprint/" + blueprint.Id
+	w = serveTestRequest(t, router, http.MethodPost, url, blueprint, admin.Token)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	/* create blueprint & generate exam */
+	blueprint.Rules[0].Difficulty = "Medium"
+	w = serveTestRequest(t, router, http.MethodPost, url, blueprint, admin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, url+"/exam", ExamGeneration{Id: uuid.New().String()}, admin.Token)
+	var exam Exam
+	unmarshalTestResponse(t, w, &exam)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, blueprint.Id, exam.BlueprintId)
+	assert.Equal(t, blueprint.Title, exam.Title)
+	if assert.Len(t, exam.Questions, 3) {
+		assert.True(t, medium[exam.Questions[0].Id])
+		assert.True(t, medium[exam.Questions[1].Id])
+		assert.NotEqual(t, exam.Questions[0].Id, exam.Questions[1].Id)
+		assert.NotEqual(t, hard, exam.Questions[1].Id)
+		assert.Equal(t, essay.Id, exam.Questions[2].Id)
+		assert.Equal(t, 25.0, exam.Questions[0].Points)
+		assert.Equal(t, 50.0, exam.Questions[2].Points)
+	}
+	/* bank cannot satisfy rule */
+	blueprint.Id = uuid.New().String()
+	blueprint.Rules[0].Count = 5
+	url = server.URL + "/nova/v1/exam/blueprint/" + blueprint.Id
+	w = serveTestRequest(t, router, http.MethodPost, url, blueprint, admin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, url+"/exam", ExamGeneration{Id: uuid.New().String()}, admin.Token)
+	var problem ProblemDetails
+	unmarshalTestResponse(t, w, &problem)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	if assert.Len(t, problem.Errors, 1) {
+		assert.Equal(t, "rules[0].count", problem.Errors[0].Field)
+		assert.Contains(t, problem.Errors[0].Reason, "bank has 3 matching questions")
+	}
+}
+
+func TestNova_HandleCreateExamAttemptFromBlueprint(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateExamAttemptFromBlueprint
+	// Test Purpose: Test exam generated per attempt avoids questions examinee has recently seen
+	// Test Steps:
+	// 1. send CreateExamBlueprint & CreateExamBlueprintExam requests in per-attempt mode
+	// 2. send CreateExamAttempt request twice, attempts draw different questions
+	// 3. send CreateExamAttempt request third time, seen questions are drawn again
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startBlueprintTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	_, examineeSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	/* create classified bank */
+	category := "Routing " + utils.RandomAlphabet(8)
+	for k := 0; k < 4; k++ {
+		createBlueprintTestJudgement(t, server, router, category, DifficultyEasy, admin.Token)
+	}
+	/* create per-attempt exam */
+	blueprint := ExamBlueprint{
+		Id:    uuid.New().String(),
+		Title: "Routing drill",
+		Rules: []ExamBlueprintRule{{Type: QuestionTypeJudgement, Category: category, Count: 2}},
+		Mode:  BlueprintModePerAttempt,
+	}
+	url := server.URL + "/nova/v1/exam/blueprint/" + blueprint.Id
+	w := serveTestRequest(t, router, http.MethodPost, url, blueprint, admin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, url+"/exam", ExamGeneration{Id: uuid.New().String(), Title: "Drill"}, admin.Token)
+	var exam Exam
+	unmarshalTestResponse(t, w, &exam)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, exam.Questions)
+	/* attempts prefer unseen questions */
+	seen := map[string]int{}
+	for k := 0; k < 3; k++ {
+		w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id+"/attempt", nil, examineeSession.Token)
+		var attempt ExamAttempt
+		unmarshalTestResponse(t, w, &attempt)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		if assert.Len(t, attempt.Questions, 2) {
+			for _, question := range attempt.Questions {
+				seen[question.Id]++
+			}
+		}
+		if k == 1 {
+			assert.Len(t, seen, 4)
+		}
+	}
+	assert.Len(t, seen, 4)
+}
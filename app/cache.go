@@ -8,7 +8,11 @@ import (
 
 type Cache struct {
 	userCache      UserCache
+	sessionCache   UserSessionCache
+	roleCache      UserRoleCache
 	questionsCache QuestionsCache
+	tenantCache    TenantCache
+	twoFactorCache UserTwoFactorCache
 }
 
 type UserCache struct {
@@ -16,11 +20,40 @@ type UserCache struct {
 	mutex   sync.RWMutex
 }
 
+type UserSessionCache struct {
+	sessionSet map[string]UserSession
+	mutex      sync.RWMutex
+}
+
+type UserRoleCache struct {
+	roleSet map[string]string
+	mutex   sync.RWMutex
+}
+
+type UserTwoFactorCache struct {
+	enabledSet map[string]bool
+	mutex      sync.RWMutex
+}
+
+type TenantCache struct {
+	resourceSet map[string]string
+	mutex       sync.RWMutex
+}
+
 type QuestionsCache struct {
 	singleChoiceCache   QuestionSingleChoiceCache
 	multipleChoiceCache QuestionMultipleChoiceCache
 	judgementCache      QuestionJudgementCache
 	essayCache          QuestionEssayCache
+	fillBlankCache      QuestionFillBlankCache
+	matchingCache       QuestionMatchingCache
+	orderingCache       QuestionOrderingCache
+	numericCache        QuestionNumericCache
+	templateCache       QuestionTemplateCache
+	codeCache           QuestionCodeCache
+	fingerprintCache    QuestionFingerprintCache
+	revisionCache       QuestionRevisionCache
+	workflowCache       QuestionWorkflowCache
 }
 
 type QuestionSingleChoiceCache struct {
@@ -43,6 +76,56 @@ type QuestionEssayCache struct {
 	mutex    sync.RWMutex
 }
 
+type QuestionFillBlankCache struct {
+	fillBlankSet []QuestionFillBlank
+	mutex        sync.RWMutex
+}
+
+type QuestionMatchingCache struct {
+	matchingSet []QuestionMatching
+	mutex       sync.RWMutex
+}
+
+type QuestionOrderingCache struct {
+	orderingSet []QuestionOrdering
+	mutex       sync.RWMutex
+}
+
+type QuestionNumericCache struct {
+	numericSet []QuestionNumeric
+	mutex      sync.RWMutex
+}
+
+type QuestionTemplateCache struct {
+	templateSet []QuestionTemplate
+	mutex       sync.RWMutex
+}
+
+type QuestionCodeCache struct {
+	codeSet []QuestionCode
+	mutex   sync.RWMutex
+}
+
+type QuestionFingerprintCache struct {
+	fingerprintSet map[string]QuestionFingerprint
+	mutex          sync.RWMutex
+}
+
+type QuestionRevisionCache struct {
+	revisionSet map[string]int
+	mutex       sync.Mutex
+}
+
+type QuestionWorkflowCache struct {
+	workflowSet map[string]QuestionWorkflow
+	mutex       sync.RWMutex
+}
+
+type QuestionFingerprint struct {
+	Digest    string
+	Signature []uint64
+}
+
 func NewCache() *Cache {
 	return &Cache{}
 }
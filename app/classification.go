@@ -0,0 +1,190 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"nova/logger"
+	"strings"
+	"time"
+)
+
+const (
+	questionCategoryMaxLength = 128
+	questionTagMaxLength      = 64
+	questionTagsMax           = 20
+)
+
+// errQuestionClassificationNotFound is returned when question is not classified
+var errQuestionClassificationNotFound = errors.New("question classification not found")
+
+func (nova *Nova) HandleUpdateQuestionClassification(c *gin.Context) {
+	// classify question by category, difficulty & tags
+	var request QuestionClassification
+	logger.Infof("handle request update question classification")
+	// extract question type & Id from uri
+	questionType := strings.ToLower(c.Param("type"))
+	id := strings.ToLower(c.Param("Id"))
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// request question type & Id correctness
+	logger.Debugf("check question type & Id is validate")
+	if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question type & Id is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question type & Id is validate")
+	// request classification correctness
+	logger.Debugf("check question classification is validate")
+	request.Category = strings.TrimSpace(request.Category)
+	request.Difficulty = strings.ToLower(strings.TrimSpace(request.Difficulty))
+	if b, err := validateQuestionClassification(request); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question classification is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question classification is validate")
+	// examinees may not classify questions
+	logger.Debugf("check principal is allowed to update classification")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to update question classification"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to update question classification"))
+		logger.Errorf("error check principal is allowed to update classification")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to update classification")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// check question existence
+	logger.Debugf("check question is existed")
+	if !nova.isQuestionExisted(questionType, id) {
+		nova.response404NotFound(c, fmt.Errorf("%v question not found", questionType))
+		logger.Errorf("error check question is existed")
+		return
+	}
+	logger.Debugf("successfully check question is existed")
+	// store question classification in database
+	logger.Debugf("store question classification in database")
+	response := QuestionClassification{
+		Type:       questionType,
+		Id:         id,
+		Category:   request.Category,
+		Difficulty: request.Difficulty,
+		Tags:       normalizeQuestionTags(request.Tags),
+		UpdatedBy:  nova.queryRequestAuthor(c),
+		UpdatedAt:  time.Now().UTC(),
+	}
+	if err := nova.db.UpdateQuestionClassification(&response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store question classification in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store question classification in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionClassification(c *gin.Context) {
+	// query category, difficulty & tags of question
+	logger.Infof("handle request query question classification")
+	// extract question type & Id from uri
+	questionType := strings.ToLower(c.Param("type"))
+	id := strings.ToLower(c.Param("Id"))
+	// request question type & Id correctness
+	logger.Debugf("check question type & Id is validate")
+	if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question type & Id is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question type & Id is validate")
+	// examinees only see classification of published questions
+	logger.Debugf("check question is visible")
+	if !nova.isQuestionVisible(c, questionType, id) {
+		nova.response404NotFound(c, errQuestionClassificationNotFound)
+		logger.Errorf("error check question is visible")
+		return
+	}
+	logger.Debugf("successfully check question is visible")
+	// query question classification from database
+	logger.Debugf("query question classification in database")
+	response, err := nova.db.QueryQuestionClassification(questionType, id)
+	if err != nil {
+		if errors.Is(err, errQuestionClassificationNotFound) {
+			nova.response404NotFound(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error query question classification in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query question classification in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func validateQuestionClassification(classification QuestionClassification) (bool, error) {
+	v := &questionValidator{}
+	if n := len([]rune(classification.Category)); n > questionCategoryMaxLength {
+		v.add("category", "should be at most %v characters, got %v", questionCategoryMaxLength, n)
+	}
+	if b, err := isQuestionDifficultyValidate(classification.Difficulty); !b {
+		v.add("difficulty", "%v", err)
+	}
+	if len(classification.Tags) > questionTagsMax {
+		v.add("tags", "should have at most %v tags, got %v", questionTagsMax, len(classification.Tags))
+	}
+	for k, tag := range classification.Tags {
+		v.checkText(fmt.Sprintf("tags[%v]", k), tag, questionTagMaxLength)
+	}
+	return v.result()
+}
+
+func isQuestionDifficultyValidate(difficulty string) (bool, error) {
+	// question may be left without difficulty
+	switch difficulty {
+	case "", DifficultyEasy, DifficultyMedium, DifficultyHard:
+		return true, nil
+	default:
+		return false, fmt.Errorf("difficulty %v not supported", difficulty)
+	}
+}
+
+func normalizeQuestionTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+func normalizeQuestionTags(tags []string) []string {
+	// tags are compared case-insensitively & kept once
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = normalizeQuestionTag(tag)
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,208 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"nova/logger"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// codeSourceMaxLength bounds submitted source & starter code
+const codeSourceMaxLength = 64 << 10
+
+func (nova *Nova) HandleCreateQuestionCodeAttempt(c *gin.Context) {
+	// run submitted source against test cases of code question
+	var request QuestionCodeSubmission
+	logger.Infof("handle request create code question attempt")
+	// attempts belong to authenticated users
+	logger.Debugf("check user session is validate")
+	if _, ok := nova.queryPrincipal(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to run code question"))
+		logger.Errorf("error check user session is validate")
+		return
+	}
+	logger.Debugf("successfully check user session is validate")
+	// extract questionId from uri
+	id := strings.ToLower(c.Param("Id"))
+	// request questionId correctness
+	logger.Debugf("check questionId is validate")
+	if b, _ := nova.isQuestionIdValidate(id); !b {
+		nova.response400BadRequest(c, errors.New("questionId format incorrect"))
+		logger.Errorf("error check questionId is validate")
+		return
+	}
+	logger.Debugf("successfully check questionId is validate")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// request source size
+	logger.Debugf("check source is validate")
+	if n := utf8.RuneCountInString(request.Source); n > codeSourceMaxLength {
+		nova.response400BadRequest(c, FieldErrors{{Field: "source", Reason: fmt.Sprintf("should be at most %v characters, got %v", codeSourceMaxLength, n)}})
+		logger.Errorf("error check source is validate")
+		return
+	}
+	logger.Debugf("successfully check source is validate")
+	// update data cache by querying code questions in database
+	logger.Debugf("update data cache by querying code questions in database")
+	if err := nova.queryCodeQuestionInDatabase(id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying code questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying code questions in database")
+	// query code question from data cache
+	logger.Debugf("query code question in data cache")
+	question, err := nova.queryCodeQuestionInDataCache(id)
+	if err != nil || !nova.isQuestionVisible(c, QuestionTypeCode, id) {
+		nova.response404NotFound(c, errors.New("code question not found"))
+		logger.Errorf("error query code question in data cache")
+		return
+	}
+	logger.Debugf("successfully query code question in data cache")
+	// run source in sandbox
+	logger.Debugf("run code question attempt in sandbox")
+	grade, results, err := nova.runCodeQuestion(c.Request.Context(), question, request.Source)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error run code question attempt in sandbox: %v", err)
+		return
+	}
+	logger.Debugf("successfully run code question attempt in sandbox")
+	// store attempt with per-test results
+	logger.Debugf("create code question attempt in database")
+	response := QuestionCodeAttempt{
+		AttemptId: uuid.New().String(),
+		Id:        id,
+		UserId:    nova.queryRequestAuthor(c),
+		Language:  question.Language,
+		Source:    request.Source,
+		Results:   results,
+		Score:     grade.Score,
+		MaxScore:  grade.MaxScore,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := nova.db.CreateQuestionCodeAttempt(&response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error create code question attempt in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully create code question attempt in database")
+	// return response
+	nova.response201Created(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response.AttemptId)
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionCodeAttempts(c *gin.Context) {
+	// query attempts of code question
+	logger.Infof("handle request query code question attempts")
+	// extract questionId from uri
+	id := strings.ToLower(c.Param("Id"))
+	// request questionId correctness
+	logger.Debugf("check questionId is validate")
+	if b, _ := nova.isQuestionIdValidate(id); !b {
+		nova.response400BadRequest(c, errors.New("questionId format incorrect"))
+		logger.Errorf("error check questionId is validate")
+		return
+	}
+	logger.Debugf("successfully check questionId is validate")
+	// query code question attempts from database
+	logger.Debugf("query code question attempts in database")
+	attempts, err := nova.db.QueryQuestionCodeAttempts(id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query code question attempts in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query code question attempts in database")
+	// examinees only see their own attempts
+	response := make([]QuestionCodeAttempt, 0, len(attempts))
+	for _, v := range attempts {
+		if nova.isCodeAttemptVisible(c, v) {
+			response = append(response, *v)
+		}
+	}
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, len(response))
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionCodeAttempt(c *gin.Context) {
+	// query attempt of code question
+	logger.Infof("handle request query code question attempt")
+	// extract questionId & attemptId from uri
+	id := strings.ToLower(c.Param("Id"))
+	attemptId := strings.ToLower(c.Param("attemptId"))
+	// request questionId & attemptId correctness
+	logger.Debugf("check questionId & attemptId is validate")
+	if b, _ := nova.isQuestionIdValidate(id); !b {
+		nova.response400BadRequest(c, errors.New("questionId format incorrect"))
+		logger.Errorf("error check questionId is validate")
+		return
+	}
+	if err := uuid.Validate(attemptId); err != nil {
+		nova.response400BadRequest(c, errors.New("attemptId format incorrect"))
+		logger.Errorf("error check attemptId is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check questionId & attemptId is validate")
+	// query code question attempt from database
+	logger.Debugf("query code question attempt in database")
+	response, err := nova.db.QueryQuestionCodeAttempt(attemptId)
+	if err != nil || response.Id != id || !nova.isCodeAttemptVisible(c, response) {
+		nova.response404NotFound(c, errors.New("code question attempt not found"))
+		logger.Errorf("error query code question attempt in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query code question attempt in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response.AttemptId)
+	return
+}
+
+func (nova *Nova) runCodeQuestion(ctx context.Context, question QuestionCode, source string) (QuestionGrade, []QuestionTestResult, error) {
+	// every passed test case is a correct part
+	results, err := nova.sb.Run(ctx, question, source)
+	if err != nil {
+		return QuestionGrade{}, nil, err
+	}
+	parts := make([]bool, 0, len(results))
+	for _, v := range results {
+		parts = append(parts, v.Status == TestStatusPassed)
+	}
+	return newPartialQuestionGrade(question.Scoring, parts), results, nil
+}
+
+func (nova *Nova) isCodeAttemptVisible(c *gin.Context, attempt *QuestionCodeAttempt) bool {
+	// examinees & anonymous requests only see their own attempts
+	if user, role, ok := nova.queryPrincipalRole(c); !ok || role == RoleExaminee {
+		return attempt.UserId == user.UserId
+	}
+	return true
+}
+
+func hideCodeTestCases(question QuestionCode) QuestionCode {
+	// hidden test cases are dropped from question shown to examinees
+	testCases := make([]QuestionTestCase, 0, len(question.TestCases))
+	for _, v := range question.TestCases {
+		if !v.Hidden {
+			testCases = append(testCases, v)
+		}
+	}
+	question.TestCases = testCases
+	return question
+}
@@ -0,0 +1,242 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	. "nova/configure"
+	"nova/utils"
+	"os"
+	"strconv"
+	"testing"
+)
+
+func setupCodeTestRouter() *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// author changing questions
+	createTestAuthor(nova)
+	// first administrator is granted by command line
+	setupTestAdministrator(nova)
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	{
+		/* user management */
+		novaService.POST("/user/:userId", nova.HandleCreateUser)
+		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
+		novaService.PUT("/user/role/:userId", nova.HandleUpdateUserRole)
+		/* question management */
+		// question workflow related
+		novaService.POST("/question/workflow/:type/:Id/transition", nova.HandleCreateQuestionTransition)
+		novaService.POST("/question/workflow/:type/:Id/approve", nova.HandleCreateQuestionApproval)
+		// question related
+		novaService.POST("/question/code/:Id", nova.HandleCreateQuestionCode)
+		novaService.GET("/question/code/:Id", nova.HandleQueryQuestionCode)
+		novaService.POST("/question/code/:Id/attempt", nova.HandleCreateQuestionCodeAttempt)
+		novaService.GET("/question/code/:Id/attempt", nova.HandleQueryQuestionCodeAttempts)
+		novaService.GET("/question/code/:Id/attempt/:attemptId", nova.HandleQueryQuestionCodeAttempt)
+	}
+	return router
+}
+
+func startCodeTestService() (*httptest.Server, *gin.Engine) {
+	router := setupCodeTestRouter()
+	return httptest.NewServer(router), router
+}
+
+func TestNova_HandleCreateQuestionCodeAttempt(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateQuestionCodeAttempt
+	// Test Purpose: Test python submissions run in sandbox against test cases
+	// Test Steps:
+	// 1. send CreateQuestion request for python code question with hidden test case
+	// 2. send QueryQuestion request with examinee session, hidden test case is dropped
+	// 3. send CreateQuestionCodeAttempt request with correct, wrong, looping & escaping sources
+	// 4. receive CreateQuestionCodeAttempt response with per-test results by using 201 Created Code
+	// 5. send QueryQuestionCodeAttempts request with examinee session, only own attempts are returned
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startCodeTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	examinee, examineeSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	/* create python code question */
+	question := QuestionCode{
+		Id:          uuid.New().String(),
+		Title:       "Read two integers and print their sum, " + utils.RandomAlphabet(12),
+		Language:    CodeLanguagePython,
+		StarterCode: "a, b = map(int, input().split())\n",
+		TestCases: []QuestionTestCase{
+			{Name: "small", Input: "1 2\n", Output: "3\n"},
+			{Name: "negative", Input: "-5 3\n", Output: "-2\n"},
+			{Name: "large", Input: "1000000000 1000000000\n", Output: "2000000000\n", Hidden: true},
+		},
+		TimeLimit: 1000,
+		Scoring:   ScoringPartial,
+	}
+	url := server.URL + "/nova/v1/question/code/" + question.Id
+	w := serveTestRequest(t, router, http.MethodPost, url, question, admin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	workflowURL := server.URL + "/nova/v1/question/workflow/code/" + question.Id
+	w = serveTestRequest(t, router, http.MethodPost, workflowURL+"/transition", QuestionTransition{State: QuestionStateInReview}, admin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, workflowURL+"/approve", nil, admin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	/* hidden test case is dropped for examinee */
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, examineeSession.Token)
+	var resQuestion QuestionCode
+	unmarshalTestResponse(t, w, &resQuestion)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, question.TestCases[:2], resQuestion.TestCases)
+	/* run sources in sandbox, service directory is outside private root */
+	service, err := os.Getwd()
+	assert.NoError(t, err)
+	escape := "import os, signal\na, b = map(int, input().split())\nforks = 0\ntry:\n    while forks < 1000:\n" +
+		"        if os.fork() == 0:\n            signal.pause()\n        forks += 1\nexcept OSError:\n    pass\n" +
+		"hidden = not os.path.exists(" + strconv.Quote(service) + ") and os.getuid() != 0\nprint(a + b if forks < 1000 and hidden else 0)\n"
+	for _, v := range []struct {
+		source   string
+		statuses []string
+		score    float64
+	}{
+		{
+			"a, b = map(int, input().split())\nprint(a + b)\n",
+			[]string{TestStatusPassed, TestStatusPassed, TestStatusPassed},
+			1,
+		},
+		{
+			"a, b = map(int, input().split())\nprint(abs(a) + abs(b))\n",
+			[]string{TestStatusPassed, TestStatusWrongAnswer, TestStatusPassed},
+			2.0 / 3,
+		},
+		{
+			"import socket\nsocket.create_connection(('1.1.1.1', 53), timeout=1)\n",
+			[]string{TestStatusRuntimeError, TestStatusRuntimeError, TestStatusRuntimeError},
+			0,
+		},
+		{
+			"while True:\n    pass\n",
+			[]string{TestStatusTimeLimitExceeded, TestStatusTimeLimitExceeded, TestStatusTimeLimitExceeded},
+			0,
+		},
+		{
+			escape,
+			[]string{TestStatusPassed, TestStatusPassed, TestStatusPassed},
+			1,
+		},
+	} {
+		w = serveTestRequest(t, router, http.MethodPost, url+"/attempt", QuestionCodeSubmission{Source: v.source}, examineeSession.Token)
+		var attempt QuestionCodeAttempt
+		unmarshalTestResponse(t, w, &attempt)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, examinee.UserId, attempt.UserId)
+		assert.InDelta(t, v.score, attempt.Score, 1e-9, v.source)
+		statuses := make([]string, 0, len(attempt.Results))
+		for _, result := range attempt.Results {
+			statuses = append(statuses, result.Status)
+		}
+		assert.Equal(t, v.statuses, statuses, v.source)
+		if assert.Len(t, attempt.Results, 3) {
+			assert.Empty(t, attempt.Results[2].Output)
+			assert.Empty(t, attempt.Results[2].Error)
+		}
+	}
+	/* query own attempts */
+	_, otherSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	w = serveTestRequest(t, router, http.MethodGet, url+"/attempt", nil, examineeSession.Token)
+	var attempts []QuestionCodeAttempt
+	unmarshalTestResponse(t, w, &attempts)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, attempts, 5)
+	w = serveTestRequest(t, router, http.MethodGet, url+"/attempt", nil, otherSession.Token)
+	attempts = nil
+	unmarshalTestResponse(t, w, &attempts)
+	assert.Empty(t, attempts)
+}
+
+func TestNova_HandleCreateQuestionCodeAttemptGo(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateQuestionCodeAttemptGo
+	// Test Purpose: Test go submissions are compiled & run in sandbox
+	// Test Steps:
+	// 1. send CreateQuestion request for go code question by using POST method
+	// 2. send CreateQuestionCodeAttempt request with source failing to compile
+	// 3. send CreateQuestionCodeAttempt request with correct source
+	// 4. send QueryQuestionCodeAttempt request, receive stored attempt by using 200 OK Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startCodeTestService()
+	defer server.Close()
+	/* create go code question */
+	question := QuestionCode{
+		Id:       uuid.New().String(),
+		Title:    "Print the greeting of " + utils.RandomAlphabet(12),
+		Language: CodeLanguageGo,
+		TestCases: []QuestionTestCase{
+			{Name: "world", Input: "world", Output: "hello, world"},
+		},
+	}
+	url := server.URL + "/nova/v1/question/code/" + question.Id
+	w := serveTestRequest(t, router, http.MethodPost, url, question, testAuthorToken)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	/* source fails to compile */
+	w = serveTestRequest(t, router, http.MethodPost, url+"/attempt", QuestionCodeSubmission{Source: "package main\n\nfunc main() { undefined() }\n"}, testAuthorToken)
+	var attempt QuestionCodeAttempt
+	unmarshalTestResponse(t, w, &attempt)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	if assert.Len(t, attempt.Results, 1) {
+		assert.Equal(t, TestStatusCompileError, attempt.Results[0].Status)
+		assert.Contains(t, attempt.Results[0].Error, "undefined")
+	}
+	/* correct source */
+	source := "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tvar name string\n\tfmt.Scan(&name)\n\tfmt.Println(\"hello, \" + name)\n}\n"
+	w = serveTestRequest(t, router, http.MethodPost, url+"/attempt", QuestionCodeSubmission{Source: source}, testAuthorToken)
+	attempt = QuestionCodeAttempt{}
+	unmarshalTestResponse(t, w, &attempt)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, 1.0, attempt.Score)
+	/* query stored attempt */
+	w = serveTestRequest(t, router, http.MethodGet, url+"/attempt/"+attempt.AttemptId, nil, testAuthorToken)
+	var resAttempt QuestionCodeAttempt
+	unmarshalTestResponse(t, w, &resAttempt)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, source, resAttempt.Source)
+	if assert.Len(t, resAttempt.Results, 1) {
+		assert.Equal(t, TestStatusPassed, resAttempt.Results[0].Status)
+		assert.Equal(t, "hello, world\n", resAttempt.Results[0].Output)
+	}
+}
+
+func TestNewSandbox(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNewSandbox
+	// Test Purpose: Test every sandbox worker runs programs as own identity
+	// Test Steps:
+	// 1. create sandbox with 3 workers, identities of workers differ from each other & compiler
+	// 2. create sandbox with too many workers, workers are limited
+	-----------------------------------------------------------------------------------------*/
+	sb := NewSandbox(SandboxSettings{Workers: 3})
+	ids := make(map[int]bool)
+	for k := 0; k < 3; k++ {
+		id := <-sb.workers
+		assert.NotEqual(t, sandboxCompilerId, id)
+		assert.False(t, ids[id])
+		ids[id] = true
+	}
+	assert.Len(t, sb.workers, 0)
+	sb = NewSandbox(SandboxSettings{Workers: 1000})
+	assert.Len(t, sb.workers, sandboxWorkersLimit)
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
 )
@@ -17,6 +18,12 @@ func (nova *Nova) response201Created(c *gin.Context, body any) {
 	return
 }
 
+func (nova *Nova) response202Accepted(c *gin.Context, body any) {
+	c.Header("Content-Type", "application/json")
+	c.JSON(http.StatusAccepted, body)
+	return
+}
+
 func (nova *Nova) response204NoContent(c *gin.Context, body any) {
 	c.Status(http.StatusNoContent)
 	return
@@ -28,11 +35,26 @@ func (nova *Nova) response400BadRequest(c *gin.Context, err error) {
 	problemDetails.Type = "Client Error"
 	problemDetails.Status = http.StatusBadRequest
 	problemDetails.Cause = err.Error()
+	var fieldErrors FieldErrors
+	if errors.As(err, &fieldErrors) {
+		problemDetails.Errors = fieldErrors
+	}
 	c.Header("Content-Type", "application/problem+json")
 	c.JSON(http.StatusBadRequest, problemDetails)
 	return
 }
 
+func (nova *Nova) response401Unauthorized(c *gin.Context, err error) {
+	var problemDetails ProblemDetails
+	problemDetails.Title = "Unauthorized"
+	problemDetails.Type = "Client Error"
+	problemDetails.Status = http.StatusUnauthorized
+	problemDetails.Cause = err.Error()
+	c.Header("Content-Type", "application/problem+json")
+	c.JSON(http.StatusUnauthorized, problemDetails)
+	return
+}
+
 func (nova *Nova) response403Forbidden(c *gin.Context, err error) {
 	var problemDetails ProblemDetails
 	problemDetails.Title = "Forbidden"
@@ -61,6 +83,10 @@ func (nova *Nova) response409Conflict(c *gin.Context, err error) {
 	problemDetails.Type = "Client Error"
 	problemDetails.Status = http.StatusConflict
 	problemDetails.Cause = err.Error()
+	var fieldErrors FieldErrors
+	if errors.As(err, &fieldErrors) {
+		problemDetails.Errors = fieldErrors
+	}
 	c.Header("Content-Type", "application/problem+json")
 	c.JSON(http.StatusConflict, problemDetails)
 	return
@@ -77,6 +103,28 @@ func (nova *Nova) response412PreconditionFailed(c *gin.Context, err error) {
 	return
 }
 
+func (nova *Nova) response413RequestEntityTooLarge(c *gin.Context, err error) {
+	var problemDetails ProblemDetails
+	problemDetails.Title = "RequestEntityTooLarge"
+	problemDetails.Type = "Client Error"
+	problemDetails.Status = http.StatusRequestEntityTooLarge
+	problemDetails.Cause = err.Error()
+	c.Header("Content-Type", "application/problem+json")
+	c.JSON(http.StatusRequestEntityTooLarge, problemDetails)
+	return
+}
+
+func (nova *Nova) response415UnsupportedMediaType(c *gin.Context, err error) {
+	var problemDetails ProblemDetails
+	problemDetails.Title = "UnsupportedMediaType"
+	problemDetails.Type = "Client Error"
+	problemDetails.Status = http.StatusUnsupportedMediaType
+	problemDetails.Cause = err.Error()
+	c.Header("Content-Type", "application/problem+json")
+	c.JSON(http.StatusUnsupportedMediaType, problemDetails)
+	return
+}
+
 func (nova *Nova) response417ExpectationFailed(c *gin.Context, err error) {
 	var problemDetails ProblemDetails
 	problemDetails.Title = "ExpectationFailed"
@@ -88,6 +136,17 @@ func (nova *Nova) response417ExpectationFailed(c *gin.Context, err error) {
 	return
 }
 
+func (nova *Nova) response429TooManyRequests(c *gin.Context, err error) {
+	var problemDetails ProblemDetails
+	problemDetails.Title = "TooManyRequests"
+	problemDetails.Type = "Client Error"
+	problemDetails.Status = http.StatusTooManyRequests
+	problemDetails.Cause = err.Error()
+	c.Header("Content-Type", "application/problem+json")
+	c.JSON(http.StatusTooManyRequests, problemDetails)
+	return
+}
+
 func (nova *Nova) response500InternalServerError(c *gin.Context, err error) {
 	var problemDetails ProblemDetails
 	problemDetails.Title = "Internal Server Error"
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"nova/utils"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+var (
+	testDatabaseDirectory  string
+	testDatabaseCount      atomic.Int64
+	testAuthorToken        string
+	testAdministratorGrant func(userId string) int
+)
+
+func TestMain(m *testing.M) {
+	// every test case owns database under temporary directory
+	directory, err := os.MkdirTemp("", "nova-test-")
+	if err != nil {
+		fmt.Printf("Failed to create test database directory: %s\n", err)
+		os.Exit(1)
+	}
+	testDatabaseDirectory = directory
+	code := m.Run()
+	_ = os.RemoveAll(directory)
+	os.Exit(code)
+}
+
+func newTestDatabaseSource() string {
+	// fresh database file, shared cache stays within test case
+	name := fmt.Sprintf("nova-%d.db", testDatabaseCount.Add(1))
+	return "file:" + filepath.ToSlash(filepath.Join(testDatabaseDirectory, name)) + "?cache=shared"
+}
+
+func createTestAuthor(nova *Nova) {
+	// author of test case, only authors & administrators change questions
+	user := User{
+		UserId:      uuid.New().String(),
+		Username:    utils.RandomAlphabet(8),
+		Password:    utils.RandomAlphabetAndNumber(8),
+		PhoneNumber: utils.RandomNumber(11),
+	}
+	nova.createUserInDataCache(user)
+	_ = nova.createUserInDatabase(user.UserId)
+	_ = nova.updateUserRole(UserRole{UserId: user.UserId, Role: RoleAuthor})
+	session, _ := nova.createUserSession(user.UserId)
+	testAuthorToken = session.Token
+}
+
+func setupTestAdministrator(nova *Nova) {
+	// first administrator of test case is granted by grant-admin command, not by http request
+	testAdministratorGrant = func(userId string) int {
+		return nova.GrantAdministrator([]string{"-user", userId}, io.Discard, io.Discard)
+	}
+}
+
+func newAuthorTestRequest(method string, url string, body io.Reader) (*http.Request, error) {
+	// request sent by author of test case
+	request, err := http.NewRequest(method, url, body)
+	if err == nil {
+		request.Header.Set("Authorization", "Bearer "+testAuthorToken)
+	}
+	return request, err
+}
+
+func serveTestRequest(t testing.TB, router *gin.Engine, method string, url string, body any, token string) *httptest.ResponseRecorder {
+	// marshal request body
+	var reader *bytes.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			t.Errorf("error marshal request body: %v", err)
+		}
+		reader = bytes.NewReader(b)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	// send request
+	w := httptest.NewRecorder()
+	request, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		t.Errorf("error creating request: %v", err)
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	router.ServeHTTP(w, request)
+	return w
+}
+
+func unmarshalTestResponse(t testing.TB, w *httptest.ResponseRecorder, v any) {
+	// unmarshal response body
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		t.Errorf("error unmarshal response: %v", err)
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"golang.org/x/crypto/bcrypt"
 	"modernc.org/sqlite"
 	sqlite3 "modernc.org/sqlite/lib"
 	. "nova/database"
+	"time"
 )
 
 type DB struct {
@@ -92,6 +94,540 @@ func (db *DB) CreateTables() error {
 	if err != nil {
 		return err
 	}
+	// create fill-blank question table
+	sql = `CREATE TABLE IF NOT EXISTS fill_blank (
+		id TEXT PRIMARY KEY NOT NULL,
+		title TEXT NOT NULL,
+		blanks TEXT NOT NULL
+	);`
+	err = db.createQuestionFillBlankTable(sql)
+	if err != nil {
+		return err
+	}
+	// create matching question table
+	sql = `CREATE TABLE IF NOT EXISTS matching (
+		id TEXT PRIMARY KEY NOT NULL,
+		title TEXT NOT NULL,
+		pairs TEXT NOT NULL,
+		scoring TEXT NOT NULL
+	);`
+	err = db.createQuestionMatchingTable(sql)
+	if err != nil {
+		return err
+	}
+	// create ordering question table
+	sql = `CREATE TABLE IF NOT EXISTS ordering (
+		id TEXT PRIMARY KEY NOT NULL,
+		title TEXT NOT NULL,
+		items TEXT NOT NULL,
+		scoring TEXT NOT NULL
+	);`
+	err = db.createQuestionOrderingTable(sql)
+	if err != nil {
+		return err
+	}
+	// create numeric question table
+	sql = `CREATE TABLE IF NOT EXISTS numeric (
+		id TEXT PRIMARY KEY NOT NULL,
+		title TEXT NOT NULL,
+		value REAL NOT NULL,
+		absolute_tolerance REAL NOT NULL,
+		relative_tolerance REAL NOT NULL,
+		unit TEXT NOT NULL,
+		units TEXT NOT NULL
+	);`
+	err = db.createQuestionNumericTable(sql)
+	if err != nil {
+		return err
+	}
+	// create template question table
+	sql = `CREATE TABLE IF NOT EXISTS template (
+		id TEXT PRIMARY KEY NOT NULL,
+		title TEXT NOT NULL,
+		variables TEXT NOT NULL,
+		formula TEXT NOT NULL,
+		precision INTEGER NOT NULL,
+		absolute_tolerance REAL NOT NULL,
+		relative_tolerance REAL NOT NULL
+	);`
+	err = db.createQuestionTemplateTable(sql)
+	if err != nil {
+		return err
+	}
+	// create code question table
+	sql = `CREATE TABLE IF NOT EXISTS code (
+		id TEXT PRIMARY KEY NOT NULL,
+		title TEXT NOT NULL,
+		test_cases TEXT NOT NULL,
+		language TEXT NOT NULL,
+		starter_code TEXT NOT NULL,
+		time_limit INTEGER NOT NULL,
+		memory_limit INTEGER NOT NULL,
+		scoring TEXT NOT NULL
+	);`
+	err = db.createQuestionCodeTable(sql)
+	if err != nil {
+		return err
+	}
+	// create code question attempt table
+	sql = `CREATE TABLE IF NOT EXISTS code_attempts (
+		attempt_id TEXT PRIMARY KEY NOT NULL,
+		question_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		language TEXT NOT NULL,
+		source TEXT NOT NULL,
+		results TEXT NOT NULL,
+		score REAL NOT NULL,
+		max_score REAL NOT NULL,
+		created_at DATETIME NOT NULL
+	);`
+	err = db.createQuestionCodeAttemptTable(sql)
+	if err != nil {
+		return err
+	}
+	// create user session table
+	sql = `CREATE TABLE IF NOT EXISTS user_sessions (
+		token_hash TEXT PRIMARY KEY NOT NULL,
+		user_id TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		expires_at DATETIME NOT NULL
+	);`
+	err = db.createUserSessionTable(sql)
+	if err != nil {
+		return err
+	}
+	// create question revision table
+	sql = `CREATE TABLE IF NOT EXISTS question_revisions (
+		question_type TEXT NOT NULL,
+		question_id TEXT NOT NULL,
+		revision INTEGER NOT NULL,
+		author TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		content TEXT NOT NULL,
+		PRIMARY KEY (question_type, question_id, revision)
+	);`
+	err = db.createQuestionRevisionTable(sql)
+	if err != nil {
+		return err
+	}
+	// create user role table
+	sql = `CREATE TABLE IF NOT EXISTS user_roles (
+		user_id TEXT PRIMARY KEY NOT NULL,
+		role TEXT NOT NULL
+	);`
+	err = db.createUserRoleTable(sql)
+	if err != nil {
+		return err
+	}
+	// create question workflow table
+	sql = `CREATE TABLE IF NOT EXISTS question_workflows (
+		question_type TEXT NOT NULL,
+		question_id TEXT NOT NULL,
+		state TEXT NOT NULL,
+		updated_by TEXT NOT NULL,
+		updated_at DATETIME NOT NULL,
+		PRIMARY KEY (question_type, question_id)
+	);`
+	err = db.createQuestionWorkflowTable(sql)
+	if err != nil {
+		return err
+	}
+	// create question review table
+	sql = `CREATE TABLE IF NOT EXISTS question_reviews (
+		question_type TEXT NOT NULL,
+		question_id TEXT NOT NULL,
+		reviewer TEXT NOT NULL,
+		state_from TEXT NOT NULL,
+		state_to TEXT NOT NULL,
+		comment TEXT NOT NULL,
+		created_at DATETIME NOT NULL
+	);`
+	err = db.createQuestionReviewTable(sql)
+	if err != nil {
+		return err
+	}
+	// create question guidance table
+	sql = `CREATE TABLE IF NOT EXISTS question_guidance (
+		question_type TEXT NOT NULL,
+		question_id TEXT NOT NULL,
+		revision INTEGER NOT NULL,
+		explanation TEXT NOT NULL,
+		hints TEXT NOT NULL,
+		feedback TEXT NOT NULL,
+		updated_by TEXT NOT NULL,
+		updated_at DATETIME NOT NULL,
+		PRIMARY KEY (question_type, question_id, revision)
+	);`
+	err = db.createQuestionGuidanceTable(sql)
+	if err != nil {
+		return err
+	}
+	// create question hint table
+	sql = `CREATE TABLE IF NOT EXISTS question_hints (
+		user_id TEXT NOT NULL,
+		question_type TEXT NOT NULL,
+		question_id TEXT NOT NULL,
+		revealed INTEGER NOT NULL,
+		PRIMARY KEY (user_id, question_type, question_id)
+	);`
+	err = db.createQuestionHintTable(sql)
+	if err != nil {
+		return err
+	}
+	// create question format table
+	sql = `CREATE TABLE IF NOT EXISTS question_formats (
+		question_type TEXT NOT NULL,
+		question_id TEXT NOT NULL,
+		format TEXT NOT NULL,
+		updated_by TEXT NOT NULL,
+		updated_at DATETIME NOT NULL,
+		PRIMARY KEY (question_type, question_id)
+	);`
+	err = db.createQuestionFormatTable(sql)
+	if err != nil {
+		return err
+	}
+	// create attachment table
+	sql = `CREATE TABLE IF NOT EXISTS attachments (
+		attachment_id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		media_type TEXT NOT NULL,
+		size INTEGER NOT NULL,
+		created_by TEXT NOT NULL,
+		created_at DATETIME NOT NULL
+	);`
+	err = db.createAttachmentTable(sql)
+	if err != nil {
+		return err
+	}
+	// create question attachment table
+	sql = `CREATE TABLE IF NOT EXISTS question_attachments (
+		question_type TEXT NOT NULL,
+		question_id TEXT NOT NULL,
+		attachment_id TEXT NOT NULL,
+		position INTEGER NOT NULL,
+		PRIMARY KEY (question_type, question_id, attachment_id)
+	);`
+	err = db.createQuestionAttachmentTable(sql)
+	if err != nil {
+		return err
+	}
+	// create exam table
+	sql = `CREATE TABLE IF NOT EXISTS exams (
+		exam_id TEXT PRIMARY KEY NOT NULL,
+		title TEXT NOT NULL,
+		questions TEXT NOT NULL,
+		shuffle_questions INTEGER NOT NULL,
+		shuffle_answers INTEGER NOT NULL,
+		created_by TEXT NOT NULL,
+		created_at DATETIME NOT NULL
+	);`
+	err = db.createExamTable(sql)
+	if err != nil {
+		return err
+	}
+	// create exam attempt table
+	sql = `CREATE TABLE IF NOT EXISTS exam_attempts (
+		attempt_id TEXT PRIMARY KEY NOT NULL,
+		exam_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		seed INTEGER NOT NULL,
+		state TEXT NOT NULL,
+		answers TEXT NOT NULL,
+		grades TEXT NOT NULL,
+		score REAL NOT NULL,
+		max_score REAL NOT NULL,
+		created_at DATETIME NOT NULL,
+		submitted_at DATETIME
+	);`
+	err = db.createExamAttemptTable(sql)
+	if err != nil {
+		return err
+	}
+	// create question classification table
+	sql = `CREATE TABLE IF NOT EXISTS question_classifications (
+		question_type TEXT NOT NULL,
+		question_id TEXT NOT NULL,
+		category TEXT NOT NULL,
+		difficulty TEXT NOT NULL,
+		tags TEXT NOT NULL,
+		updated_by TEXT NOT NULL,
+		updated_at DATETIME NOT NULL,
+		PRIMARY KEY (question_type, question_id)
+	);`
+	err = db.createQuestionClassificationTable(sql)
+	if err != nil {
+		return err
+	}
+	// create exam blueprint table
+	sql = `CREATE TABLE IF NOT EXISTS exam_blueprints (
+		blueprint_id TEXT PRIMARY KEY NOT NULL,
+		title TEXT NOT NULL,
+		rules TEXT NOT NULL,
+		total_points REAL NOT NULL,
+		mode TEXT NOT NULL,
+		recent_days INTEGER NOT NULL,
+		shuffle_questions INTEGER NOT NULL,
+		shuffle_answers INTEGER NOT NULL,
+		created_by TEXT NOT NULL,
+		created_at DATETIME NOT NULL
+	);`
+	err = db.createExamBlueprintTable(sql)
+	if err != nil {
+		return err
+	}
+	// create generated exam table
+	sql = `CREATE TABLE IF NOT EXISTS generated_exams (
+		exam_id TEXT PRIMARY KEY NOT NULL,
+		blueprint_id TEXT NOT NULL
+	);`
+	err = db.createGeneratedExamTable(sql)
+	if err != nil {
+		return err
+	}
+	// create exam attempt question table
+	sql = `CREATE TABLE IF NOT EXISTS exam_attempt_questions (
+		attempt_id TEXT PRIMARY KEY NOT NULL,
+		questions TEXT NOT NULL
+	);`
+	err = db.createExamAttemptQuestionTable(sql)
+	if err != nil {
+		return err
+	}
+	// create exam timing table
+	sql = `CREATE TABLE IF NOT EXISTS exam_timings (
+		exam_id TEXT PRIMARY KEY NOT NULL,
+		timing TEXT NOT NULL
+	);`
+	err = db.createExamTimingTable(sql)
+	if err != nil {
+		return err
+	}
+	// create exam attempt draft table
+	sql = `CREATE TABLE IF NOT EXISTS exam_attempt_drafts (
+		attempt_id TEXT NOT NULL,
+		question_type TEXT NOT NULL,
+		question_id TEXT NOT NULL,
+		answer TEXT NOT NULL,
+		locale TEXT NOT NULL,
+		saved_at DATETIME NOT NULL,
+		PRIMARY KEY (attempt_id, question_type, question_id)
+	);`
+	err = db.createExamAttemptDraftTable(sql)
+	if err != nil {
+		return err
+	}
+	// create exam attempt closure table
+	sql = `CREATE TABLE IF NOT EXISTS exam_attempt_closures (
+		attempt_id TEXT PRIMARY KEY NOT NULL,
+		late INTEGER NOT NULL,
+		penalty REAL NOT NULL,
+		auto_submitted INTEGER NOT NULL
+	);`
+	err = db.createExamAttemptClosureTable(sql)
+	if err != nil {
+		return err
+	}
+	// create question irt parameter table
+	sql = `CREATE TABLE IF NOT EXISTS question_irt_parameters (
+		question_type TEXT NOT NULL,
+		question_id TEXT NOT NULL,
+		model TEXT NOT NULL,
+		discrimination REAL NOT NULL,
+		difficulty REAL NOT NULL,
+		guessing REAL NOT NULL,
+		responses INTEGER NOT NULL,
+		calibrated_at DATETIME NOT NULL,
+		PRIMARY KEY (question_type, question_id, model)
+	);`
+	err = db.createIRTParameterTable(sql)
+	if err != nil {
+		return err
+	}
+	// create adaptive exam table
+	sql = `CREATE TABLE IF NOT EXISTS adaptive_exams (
+		exam_id TEXT PRIMARY KEY NOT NULL,
+		title TEXT NOT NULL,
+		model TEXT NOT NULL,
+		questions TEXT NOT NULL,
+		max_length INTEGER NOT NULL,
+		standard_error REAL NOT NULL,
+		created_by TEXT NOT NULL,
+		created_at DATETIME NOT NULL
+	);`
+	err = db.createAdaptiveExamTable(sql)
+	if err != nil {
+		return err
+	}
+	// create adaptive session table
+	sql = `CREATE TABLE IF NOT EXISTS adaptive_sessions (
+		session_id TEXT PRIMARY KEY NOT NULL,
+		exam_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		state TEXT NOT NULL,
+		ability REAL NOT NULL,
+		standard_error REAL NOT NULL,
+		items TEXT NOT NULL,
+		next_type TEXT NOT NULL,
+		next_id TEXT NOT NULL,
+		stop_reason TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		finished_at DATETIME
+	);`
+	err = db.createAdaptiveSessionTable(sql)
+	if err != nil {
+		return err
+	}
+	// create practice card table
+	sql = `CREATE TABLE IF NOT EXISTS practice_cards (
+		user_id TEXT NOT NULL,
+		question_type TEXT NOT NULL,
+		question_id TEXT NOT NULL,
+		ease REAL NOT NULL,
+		interval INTEGER NOT NULL,
+		repetitions INTEGER NOT NULL,
+		lapses INTEGER NOT NULL,
+		reviews INTEGER NOT NULL,
+		due_at DATETIME NOT NULL,
+		reviewed_at DATETIME NOT NULL,
+		PRIMARY KEY (user_id, question_type, question_id)
+	);`
+	err = db.createPracticeCardTable(sql)
+	if err != nil {
+		return err
+	}
+	// create practice review table
+	sql = `CREATE TABLE IF NOT EXISTS practice_reviews (
+		review_id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id TEXT NOT NULL,
+		question_type TEXT NOT NULL,
+		question_id TEXT NOT NULL,
+		answer TEXT NOT NULL,
+		correct INTEGER NOT NULL,
+		rating TEXT NOT NULL,
+		interval INTEGER NOT NULL,
+		ease REAL NOT NULL,
+		reviewed_at DATETIME NOT NULL
+	);`
+	err = db.createPracticeReviewTable(sql)
+	if err != nil {
+		return err
+	}
+	// create user group table
+	sql = `CREATE TABLE IF NOT EXISTS user_groups (
+		group_id TEXT PRIMARY KEY NOT NULL,
+		name TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		description TEXT NOT NULL,
+		created_by TEXT NOT NULL,
+		created_at DATETIME NOT NULL
+	);`
+	err = db.createGroupTable(sql)
+	if err != nil {
+		return err
+	}
+	// create user group member table
+	sql = `CREATE TABLE IF NOT EXISTS user_group_members (
+		group_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		added_at DATETIME NOT NULL,
+		PRIMARY KEY (group_id, user_id)
+	);`
+	err = db.createGroupMemberTable(sql)
+	if err != nil {
+		return err
+	}
+	// create exam assignment table
+	sql = `CREATE TABLE IF NOT EXISTS exam_assignments (
+		group_id TEXT NOT NULL,
+		exam_id TEXT NOT NULL,
+		due_at DATETIME,
+		assigned_by TEXT NOT NULL,
+		assigned_at DATETIME NOT NULL,
+		PRIMARY KEY (group_id, exam_id)
+	);`
+	err = db.createExamAssignmentTable(sql)
+	if err != nil {
+		return err
+	}
+	// create tenant table
+	sql = `CREATE TABLE IF NOT EXISTS tenants (
+		tenant_id TEXT PRIMARY KEY NOT NULL,
+		name TEXT NOT NULL,
+		created_by TEXT NOT NULL,
+		created_at DATETIME NOT NULL
+	);`
+	err = db.createTenantTable(sql)
+	if err != nil {
+		return err
+	}
+	// create tenant resource table
+	sql = `CREATE TABLE IF NOT EXISTS tenant_resources (
+		kind TEXT NOT NULL,
+		resource_id TEXT NOT NULL,
+		tenant_id TEXT NOT NULL,
+		PRIMARY KEY (kind, resource_id)
+	);`
+	err = db.createTenantResourceTable(sql)
+	if err != nil {
+		return err
+	}
+	// create user invitation table
+	sql = `CREATE TABLE IF NOT EXISTS user_invitations (
+		token_hash TEXT PRIMARY KEY NOT NULL,
+		user_id TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		expires_at DATETIME NOT NULL
+	);`
+	err = db.createUserInvitationTable(sql)
+	if err != nil {
+		return err
+	}
+	// create user token table
+	sql = `CREATE TABLE IF NOT EXISTS user_tokens (
+		token_hash TEXT PRIMARY KEY NOT NULL,
+		user_id TEXT NOT NULL,
+		purpose TEXT NOT NULL,
+		email TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		expires_at DATETIME NOT NULL
+	);`
+	err = db.createUserTokenTable(sql)
+	if err != nil {
+		return err
+	}
+	// create user email verification table
+	sql = `CREATE TABLE IF NOT EXISTS user_email_verifications (
+		user_id TEXT PRIMARY KEY NOT NULL,
+		email TEXT NOT NULL,
+		verified_at DATETIME NOT NULL
+	);`
+	err = db.createUserEmailVerificationTable(sql)
+	if err != nil {
+		return err
+	}
+	// create user two factor table
+	sql = `CREATE TABLE IF NOT EXISTS user_two_factors (
+		user_id TEXT PRIMARY KEY NOT NULL,
+		secret TEXT NOT NULL,
+		enabled INTEGER NOT NULL,
+		last_step INTEGER NOT NULL,
+		created_at DATETIME NOT NULL,
+		enabled_at DATETIME
+	);`
+	err = db.createUserTwoFactorTable(sql)
+	if err != nil {
+		return err
+	}
+	// create user recovery code table
+	sql = `CREATE TABLE IF NOT EXISTS user_recovery_codes (
+		code_hash TEXT PRIMARY KEY NOT NULL,
+		user_id TEXT NOT NULL
+	);`
+	err = db.createUserRecoveryCodeTable(sql)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -100,6 +636,47 @@ func (db *DB) createUserTable(sql string) error {
 	if _, err := db.sqliteDB.Exec(sql); err != nil {
 		return fmt.Errorf("create user table failed: %w", err)
 	}
+	// passwords stored before they were hashed are hashed in place
+	if err := db.hashUserPasswords(); err != nil {
+		return fmt.Errorf("hash user passwords failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) hashUserPasswords() error {
+	// query user passwords sql
+	query := `SELECT user_id, password FROM users`
+	// collect users with password not yet hashed
+	rows, err := db.sqliteDB.Query(query)
+	if err != nil {
+		return err
+	}
+	plain := make(map[string]string)
+	for rows.Next() {
+		var userId, password string
+		if err := rows.Scan(&userId, &password); err != nil {
+			rows.Close()
+			return err
+		}
+		if _, err := bcrypt.Cost([]byte(password)); err != nil {
+			plain[userId] = password
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	// replace every plaintext password by its hash
+	query = `UPDATE users SET password = ? WHERE user_id = ? AND password = ?`
+	for userId, password := range plain {
+		hash, err := hashUserPassword(password)
+		if err != nil {
+			return err
+		}
+		if _, err := db.sqliteDB.Exec(query, hash, userId, password); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -1374,3 +1951,4276 @@ func (db *DB) QueryQuestionsEssayContext(ctx context.Context) ([]*QuestionEssay,
 	}
 	return questions, nil
 }
+
+func (db *DB) createQuestionFillBlankTable(sql string) error {
+	// create fill-blank table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create fill-blank question table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) CreateQuestionFillBlank(question *QuestionFillBlank) (int64, error) {
+	// execute fill-blank sql
+	query := `
+	INSERT INTO fill_blank (id, title, blanks) 
+	VALUES (?, ?, ?)
+	`
+	// marshal json slices & structure
+	blanks, err := json.Marshal(question.Blanks)
+	if err != nil {
+		return 0, err
+	}
+	// perform insert fill-blank
+	result, err := db.sqliteDB.Exec(query, question.Id, question.Title, blanks)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) {
+			if sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+				return 0, fmt.Errorf("fill-blank question already exists")
+			}
+		}
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (db *DB) CreateQuestionFillBlankContext(ctx context.Context, question *QuestionFillBlank) (int64, error) {
+	// execute fill-blank sql
+	query := `
+	INSERT INTO fill_blank (id, title, blanks) 
+	VALUES (?, ?, ?)
+	`
+	// marshal json slices & structure
+	blanks, err := json.Marshal(question.Blanks)
+	if err != nil {
+		return 0, err
+	}
+	// perform insert fill-blank
+	result, err := db.sqliteDB.ExecContext(ctx, query, question.Id, question.Title, blanks)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) {
+			if sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+				return 0, fmt.Errorf("fill-blank question already exists")
+			}
+		}
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (db *DB) QueryQuestionFillBlank(id string) (*QuestionFillBlank, error) {
+	// query fill-blank sql
+	query := `
+	SELECT id, title, blanks
+	FROM fill_blank WHERE id = ?
+	`
+	// variables definition
+	var blanks []byte
+	// execute query fill-blank
+	row := db.sqliteDB.QueryRow(query, id)
+	question := &QuestionFillBlank{}
+	err := row.Scan(&question.Id, &question.Title, &blanks)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("fill-blank question not found")
+		}
+		return nil, err
+	}
+	// unmarshal json slices & structure
+	if err := json.Unmarshal(blanks, &question.Blanks); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+func (db *DB) QueryQuestionFillBlankContext(ctx context.Context, id string) (*QuestionFillBlank, error) {
+	// query fill-blank sql
+	query := `
+	SELECT id, title, blanks
+	FROM fill_blank WHERE id = ?
+	`
+	// variables definition
+	var blanks []byte
+	// execute query fill-blank
+	row := db.sqliteDB.QueryRowContext(ctx, query, id)
+	question := &QuestionFillBlank{}
+	err := row.Scan(&question.Id, &question.Title, &blanks)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("fill-blank question not found")
+		}
+		return nil, err
+	}
+	// unmarshal json slices & structure
+	if err := json.Unmarshal(blanks, &question.Blanks); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+func (db *DB) UpdateQuestionFillBlank(question *QuestionFillBlank) error {
+	// update fill-blank sql
+	query := `
+	UPDATE fill_blank 
+	SET title = ?, blanks = ?
+	WHERE id = ?
+	`
+	// marshal json slices & structure
+	blanks, err := json.Marshal(question.Blanks)
+	if err != nil {
+		return err
+	}
+	// execute update fill-blank
+	result, err := db.sqliteDB.Exec(query, question.Title, blanks, question.Id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("fill-blank question not found")
+	}
+	return nil
+}
+
+func (db *DB) UpdateQuestionFillBlankContext(ctx context.Context, question *QuestionFillBlank) error {
+	// update fill-blank sql
+	query := `
+	UPDATE fill_blank 
+	SET title = ?, blanks = ?
+	WHERE id = ?
+	`
+	// marshal json slices & structure
+	blanks, err := json.Marshal(question.Blanks)
+	if err != nil {
+		return err
+	}
+	// execute update fill-blank
+	result, err := db.sqliteDB.ExecContext(ctx, query, question.Title, blanks, question.Id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("fill-blank question not found")
+	}
+	return nil
+}
+
+func (db *DB) DeleteQuestionFillBlank(id string) error {
+	// update fill-blank sql
+	query := `DELETE FROM fill_blank WHERE id = ?`
+	// execute delete fill-blank
+	result, err := db.sqliteDB.Exec(query, id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("fill-blank question not found")
+	}
+	return nil
+}
+
+func (db *DB) DeleteQuestionFillBlankContext(ctx context.Context, id string) error {
+	// update fill-blank sql
+	query := `DELETE FROM fill_blank WHERE id = ?`
+	// execute delete fill-blank
+	result, err := db.sqliteDB.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("fill-blank question not found")
+	}
+	return nil
+}
+
+func (db *DB) QueryQuestionsFillBlank() ([]*QuestionFillBlank, error) {
+	// query fill-blank questions
+	query := `
+	SELECT id, title, blanks
+	FROM fill_blank
+	`
+	// execute query fill-blank questions
+	rows, err := db.sqliteDB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch fill-blank questions from database
+	var questions []*QuestionFillBlank
+	for rows.Next() {
+		// variables definition
+		var blanks []byte
+		// query fill-blank question
+		question := &QuestionFillBlank{}
+		if err := rows.Scan(&question.Id, &question.Title, &blanks); err != nil {
+			return nil, err
+		}
+		// unmarshal json slices & structure
+		if err := json.Unmarshal(blanks, &question.Blanks); err != nil {
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (db *DB) QueryQuestionsFillBlankContext(ctx context.Context) ([]*QuestionFillBlank, error) {
+	// query fill-blank questions
+	query := `
+	SELECT id, title, blanks
+	FROM fill_blank
+	`
+	// execute query fill-blank questions
+	rows, err := db.sqliteDB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch fill-blank questions from database
+	var questions []*QuestionFillBlank
+	for rows.Next() {
+		// variables definition
+		var blanks []byte
+		// query fill-blank question
+		question := &QuestionFillBlank{}
+		if err := rows.Scan(&question.Id, &question.Title, &blanks); err != nil {
+			return nil, err
+		}
+		// unmarshal json slices & structure
+		if err := json.Unmarshal(blanks, &question.Blanks); err != nil {
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (db *DB) createQuestionMatchingTable(sql string) error {
+	// create matching table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create matching question table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) CreateQuestionMatching(question *QuestionMatching) (int64, error) {
+	// execute matching sql
+	query := `
+	INSERT INTO matching (id, title, pairs, scoring) 
+	VALUES (?, ?, ?, ?)
+	`
+	// marshal json slices & structure
+	pairs, err := json.Marshal(question.Pairs)
+	if err != nil {
+		return 0, err
+	}
+	// perform insert matching
+	result, err := db.sqliteDB.Exec(query, question.Id, question.Title, pairs, question.Scoring)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) {
+			if sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+				return 0, fmt.Errorf("matching question already exists")
+			}
+		}
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (db *DB) CreateQuestionMatchingContext(ctx context.Context, question *QuestionMatching) (int64, error) {
+	// execute matching sql
+	query := `
+	INSERT INTO matching (id, title, pairs, scoring) 
+	VALUES (?, ?, ?, ?)
+	`
+	// marshal json slices & structure
+	pairs, err := json.Marshal(question.Pairs)
+	if err != nil {
+		return 0, err
+	}
+	// perform insert matching
+	result, err := db.sqliteDB.ExecContext(ctx, query, question.Id, question.Title, pairs, question.Scoring)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) {
+			if sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+				return 0, fmt.Errorf("matching question already exists")
+			}
+		}
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (db *DB) QueryQuestionMatching(id string) (*QuestionMatching, error) {
+	// query matching sql
+	query := `
+	SELECT id, title, pairs, scoring
+	FROM matching WHERE id = ?
+	`
+	// variables definition
+	var pairs []byte
+	// execute query matching
+	row := db.sqliteDB.QueryRow(query, id)
+	question := &QuestionMatching{}
+	err := row.Scan(&question.Id, &question.Title, &pairs, &question.Scoring)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("matching question not found")
+		}
+		return nil, err
+	}
+	// unmarshal json slices & structure
+	if err := json.Unmarshal(pairs, &question.Pairs); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+func (db *DB) QueryQuestionMatchingContext(ctx context.Context, id string) (*QuestionMatching, error) {
+	// query matching sql
+	query := `
+	SELECT id, title, pairs, scoring
+	FROM matching WHERE id = ?
+	`
+	// variables definition
+	var pairs []byte
+	// execute query matching
+	row := db.sqliteDB.QueryRowContext(ctx, query, id)
+	question := &QuestionMatching{}
+	err := row.Scan(&question.Id, &question.Title, &pairs, &question.Scoring)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("matching question not found")
+		}
+		return nil, err
+	}
+	// unmarshal json slices & structure
+	if err := json.Unmarshal(pairs, &question.Pairs); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+func (db *DB) UpdateQuestionMatching(question *QuestionMatching) error {
+	// update matching sql
+	query := `
+	UPDATE matching 
+	SET title = ?, pairs = ?, scoring = ?
+	WHERE id = ?
+	`
+	// marshal json slices & structure
+	pairs, err := json.Marshal(question.Pairs)
+	if err != nil {
+		return err
+	}
+	// execute update matching
+	result, err := db.sqliteDB.Exec(query, question.Title, pairs, question.Scoring, question.Id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("matching question not found")
+	}
+	return nil
+}
+
+func (db *DB) UpdateQuestionMatchingContext(ctx context.Context, question *QuestionMatching) error {
+	// update matching sql
+	query := `
+	UPDATE matching 
+	SET title = ?, pairs = ?, scoring = ?
+	WHERE id = ?
+	`
+	// marshal json slices & structure
+	pairs, err := json.Marshal(question.Pairs)
+	if err != nil {
+		return err
+	}
+	// execute update matching
+	result, err := db.sqliteDB.ExecContext(ctx, query, question.Title, pairs, question.Scoring, question.Id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("matching question not found")
+	}
+	return nil
+}
+
+func (db *DB) DeleteQuestionMatching(id string) error {
+	// update matching sql
+	query := `DELETE FROM matching WHERE id = ?`
+	// execute delete matching
+	result, err := db.sqliteDB.Exec(query, id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("matching question not found")
+	}
+	return nil
+}
+
+func (db *DB) DeleteQuestionMatchingContext(ctx context.Context, id string) error {
+	// update matching sql
+	query := `DELETE FROM matching WHERE id = ?`
+	// execute delete matching
+	result, err := db.sqliteDB.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("matching question not found")
+	}
+	return nil
+}
+
+func (db *DB) QueryQuestionsMatching() ([]*QuestionMatching, error) {
+	// query matching questions
+	query := `
+	SELECT id, title, pairs, scoring
+	FROM matching
+	`
+	// execute query matching questions
+	rows, err := db.sqliteDB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch matching questions from database
+	var questions []*QuestionMatching
+	for rows.Next() {
+		// variables definition
+		var pairs []byte
+		// query matching question
+		question := &QuestionMatching{}
+		if err := rows.Scan(&question.Id, &question.Title, &pairs, &question.Scoring); err != nil {
+			return nil, err
+		}
+		// unmarshal json slices & structure
+		if err := json.Unmarshal(pairs, &question.Pairs); err != nil {
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (db *DB) QueryQuestionsMatchingContext(ctx context.Context) ([]*QuestionMatching, error) {
+	// query matching questions
+	query := `
+	SELECT id, title, pairs, scoring
+	FROM matching
+	`
+	// execute query matching questions
+	rows, err := db.sqliteDB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch matching questions from database
+	var questions []*QuestionMatching
+	for rows.Next() {
+		// variables definition
+		var pairs []byte
+		// query matching question
+		question := &QuestionMatching{}
+		if err := rows.Scan(&question.Id, &question.Title, &pairs, &question.Scoring); err != nil {
+			return nil, err
+		}
+		// unmarshal json slices & structure
+		if err := json.Unmarshal(pairs, &question.Pairs); err != nil {
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (db *DB) createQuestionOrderingTable(sql string) error {
+	// create ordering table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create ordering question table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) CreateQuestionOrdering(question *QuestionOrdering) (int64, error) {
+	// execute ordering sql
+	query := `
+	INSERT INTO ordering (id, title, items, scoring) 
+	VALUES (?, ?, ?, ?)
+	`
+	// marshal json slices & structure
+	items, err := json.Marshal(question.Items)
+	if err != nil {
+		return 0, err
+	}
+	// perform insert ordering
+	result, err := db.sqliteDB.Exec(query, question.Id, question.Title, items, question.Scoring)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) {
+			if sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+				return 0, fmt.Errorf("ordering question already exists")
+			}
+		}
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (db *DB) CreateQuestionOrderingContext(ctx context.Context, question *QuestionOrdering) (int64, error) {
+	// execute ordering sql
+	query := `
+	INSERT INTO ordering (id, title, items, scoring) 
+	VALUES (?, ?, ?, ?)
+	`
+	// marshal json slices & structure
+	items, err := json.Marshal(question.Items)
+	if err != nil {
+		return 0, err
+	}
+	// perform insert ordering
+	result, err := db.sqliteDB.ExecContext(ctx, query, question.Id, question.Title, items, question.Scoring)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) {
+			if sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+				return 0, fmt.Errorf("ordering question already exists")
+			}
+		}
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (db *DB) QueryQuestionOrdering(id string) (*QuestionOrdering, error) {
+	// query ordering sql
+	query := `
+	SELECT id, title, items, scoring
+	FROM ordering WHERE id = ?
+	`
+	// variables definition
+	var items []byte
+	// execute query ordering
+	row := db.sqliteDB.QueryRow(query, id)
+	question := &QuestionOrdering{}
+	err := row.Scan(&question.Id, &question.Title, &items, &question.Scoring)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("ordering question not found")
+		}
+		return nil, err
+	}
+	// unmarshal json slices & structure
+	if err := json.Unmarshal(items, &question.Items); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+func (db *DB) QueryQuestionOrderingContext(ctx context.Context, id string) (*QuestionOrdering, error) {
+	// query ordering sql
+	query := `
+	SELECT id, title, items, scoring
+	FROM ordering WHERE id = ?
+	`
+	// variables definition
+	var items []byte
+	// execute query ordering
+	row := db.sqliteDB.QueryRowContext(ctx, query, id)
+	question := &QuestionOrdering{}
+	err := row.Scan(&question.Id, &question.Title, &items, &question.Scoring)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("ordering question not found")
+		}
+		return nil, err
+	}
+	// unmarshal json slices & structure
+	if err := json.Unmarshal(items, &question.Items); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+func (db *DB) UpdateQuestionOrdering(question *QuestionOrdering) error {
+	// update ordering sql
+	query := `
+	UPDATE ordering 
+	SET title = ?, items = ?, scoring = ?
+	WHERE id = ?
+	`
+	// marshal json slices & structure
+	items, err := json.Marshal(question.Items)
+	if err != nil {
+		return err
+	}
+	// execute update ordering
+	result, err := db.sqliteDB.Exec(query, question.Title, items, question.Scoring, question.Id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("ordering question not found")
+	}
+	return nil
+}
+
+func (db *DB) UpdateQuestionOrderingContext(ctx context.Context, question *QuestionOrdering) error {
+	// update ordering sql
+	query := `
+	UPDATE ordering 
+	SET title = ?, items = ?, scoring = ?
+	WHERE id = ?
+	`
+	// marshal json slices & structure
+	items, err := json.Marshal(question.Items)
+	if err != nil {
+		return err
+	}
+	// execute update ordering
+	result, err := db.sqliteDB.ExecContext(ctx, query, question.Title, items, question.Scoring, question.Id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("ordering question not found")
+	}
+	return nil
+}
+
+func (db *DB) DeleteQuestionOrdering(id string) error {
+	// update ordering sql
+	query := `DELETE FROM ordering WHERE id = ?`
+	// execute delete ordering
+	result, err := db.sqliteDB.Exec(query, id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("ordering question not found")
+	}
+	return nil
+}
+
+func (db *DB) DeleteQuestionOrderingContext(ctx context.Context, id string) error {
+	// update ordering sql
+	query := `DELETE FROM ordering WHERE id = ?`
+	// execute delete ordering
+	result, err := db.sqliteDB.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("ordering question not found")
+	}
+	return nil
+}
+
+func (db *DB) QueryQuestionsOrdering() ([]*QuestionOrdering, error) {
+	// query ordering questions
+	query := `
+	SELECT id, title, items, scoring
+	FROM ordering
+	`
+	// execute query ordering questions
+	rows, err := db.sqliteDB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch ordering questions from database
+	var questions []*QuestionOrdering
+	for rows.Next() {
+		// variables definition
+		var items []byte
+		// query ordering question
+		question := &QuestionOrdering{}
+		if err := rows.Scan(&question.Id, &question.Title, &items, &question.Scoring); err != nil {
+			return nil, err
+		}
+		// unmarshal json slices & structure
+		if err := json.Unmarshal(items, &question.Items); err != nil {
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (db *DB) QueryQuestionsOrderingContext(ctx context.Context) ([]*QuestionOrdering, error) {
+	// query ordering questions
+	query := `
+	SELECT id, title, items, scoring
+	FROM ordering
+	`
+	// execute query ordering questions
+	rows, err := db.sqliteDB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch ordering questions from database
+	var questions []*QuestionOrdering
+	for rows.Next() {
+		// variables definition
+		var items []byte
+		// query ordering question
+		question := &QuestionOrdering{}
+		if err := rows.Scan(&question.Id, &question.Title, &items, &question.Scoring); err != nil {
+			return nil, err
+		}
+		// unmarshal json slices & structure
+		if err := json.Unmarshal(items, &question.Items); err != nil {
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (db *DB) createQuestionNumericTable(sql string) error {
+	// create numeric table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create numeric question table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) CreateQuestionNumeric(question *QuestionNumeric) (int64, error) {
+	// execute numeric sql
+	query := `
+	INSERT INTO numeric (id, title, value, absolute_tolerance, relative_tolerance, unit, units) 
+	VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	// marshal json slices & structure
+	units, err := json.Marshal(question.Units)
+	if err != nil {
+		return 0, err
+	}
+	// perform insert numeric
+	result, err := db.sqliteDB.Exec(query, question.Id, question.Title, question.Value, question.AbsoluteTolerance, question.RelativeTolerance, question.Unit, units)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) {
+			if sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+				return 0, fmt.Errorf("numeric question already exists")
+			}
+		}
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (db *DB) CreateQuestionNumericContext(ctx context.Context, question *QuestionNumeric) (int64, error) {
+	// execute numeric sql
+	query := `
+	INSERT INTO numeric (id, title, value, absolute_tolerance, relative_tolerance, unit, units) 
+	VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	// marshal json slices & structure
+	units, err := json.Marshal(question.Units)
+	if err != nil {
+		return 0, err
+	}
+	// perform insert numeric
+	result, err := db.sqliteDB.ExecContext(ctx, query, question.Id, question.Title, question.Value, question.AbsoluteTolerance, question.RelativeTolerance, question.Unit, units)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) {
+			if sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+				return 0, fmt.Errorf("numeric question already exists")
+			}
+		}
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (db *DB) QueryQuestionNumeric(id string) (*QuestionNumeric, error) {
+	// query numeric sql
+	query := `
+	SELECT id, title, value, absolute_tolerance, relative_tolerance, unit, units
+	FROM numeric WHERE id = ?
+	`
+	// variables definition
+	var units []byte
+	// execute query numeric
+	row := db.sqliteDB.QueryRow(query, id)
+	question := &QuestionNumeric{}
+	err := row.Scan(&question.Id, &question.Title, &question.Value, &question.AbsoluteTolerance, &question.RelativeTolerance, &question.Unit, &units)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("numeric question not found")
+		}
+		return nil, err
+	}
+	// unmarshal json slices & structure
+	if err := json.Unmarshal(units, &question.Units); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+func (db *DB) QueryQuestionNumericContext(ctx context.Context, id string) (*QuestionNumeric, error) {
+	// query numeric sql
+	query := `
+	SELECT id, title, value, absolute_tolerance, relative_tolerance, unit, units
+	FROM numeric WHERE id = ?
+	`
+	// variables definition
+	var units []byte
+	// execute query numeric
+	row := db.sqliteDB.QueryRowContext(ctx, query, id)
+	question := &QuestionNumeric{}
+	err := row.Scan(&question.Id, &question.Title, &question.Value, &question.AbsoluteTolerance, &question.RelativeTolerance, &question.Unit, &units)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("numeric question not found")
+		}
+		return nil, err
+	}
+	// unmarshal json slices & structure
+	if err := json.Unmarshal(units, &question.Units); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+func (db *DB) UpdateQuestionNumeric(question *QuestionNumeric) error {
+	// update numeric sql
+	query := `
+	UPDATE numeric 
+	SET title = ?, value = ?, absolute_tolerance = ?, relative_tolerance = ?, unit = ?, units = ?
+	WHERE id = ?
+	`
+	// marshal json slices & structure
+	units, err := json.Marshal(question.Units)
+	if err != nil {
+		return err
+	}
+	// execute update numeric
+	result, err := db.sqliteDB.Exec(query, question.Title, question.Value, question.AbsoluteTolerance, question.RelativeTolerance, question.Unit, units, question.Id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("numeric question not found")
+	}
+	return nil
+}
+
+func (db *DB) UpdateQuestionNumericContext(ctx context.Context, question *QuestionNumeric) error {
+	// update numeric sql
+	query := `
+	UPDATE numeric 
+	SET title = ?, value = ?, absolute_tolerance = ?, relative_tolerance = ?, unit = ?, units = ?
+	WHERE id = ?
+	`
+	// marshal json slices & structure
+	units, err := json.Marshal(question.Units)
+	if err != nil {
+		return err
+	}
+	// execute update numeric
+	result, err := db.sqliteDB.ExecContext(ctx, query, question.Title, question.Value, question.AbsoluteTolerance, question.RelativeTolerance, question.Unit, units, question.Id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("numeric question not found")
+	}
+	return nil
+}
+
+func (db *DB) DeleteQuestionNumeric(id string) error {
+	// update numeric sql
+	query := `DELETE FROM numeric WHERE id = ?`
+	// execute delete numeric
+	result, err := db.sqliteDB.Exec(query, id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("numeric question not found")
+	}
+	return nil
+}
+
+func (db *DB) DeleteQuestionNumericContext(ctx context.Context, id string) error {
+	// update numeric sql
+	query := `DELETE FROM numeric WHERE id = ?`
+	// execute delete numeric
+	result, err := db.sqliteDB.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("numeric question not found")
+	}
+	return nil
+}
+
+func (db *DB) QueryQuestionsNumeric() ([]*QuestionNumeric, error) {
+	// query numeric questions
+	query := `
+	SELECT id, title, value, absolute_tolerance, relative_tolerance, unit, units
+	FROM numeric
+	`
+	// execute query numeric questions
+	rows, err := db.sqliteDB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch numeric questions from database
+	var questions []*QuestionNumeric
+	for rows.Next() {
+		// variables definition
+		var units []byte
+		// query numeric question
+		question := &QuestionNumeric{}
+		if err := rows.Scan(&question.Id, &question.Title, &question.Value, &question.AbsoluteTolerance, &question.RelativeTolerance, &question.Unit, &units); err != nil {
+			return nil, err
+		}
+		// unmarshal json slices & structure
+		if err := json.Unmarshal(units, &question.Units); err != nil {
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (db *DB) QueryQuestionsNumericContext(ctx context.Context) ([]*QuestionNumeric, error) {
+	// query numeric questions
+	query := `
+	SELECT id, title, value, absolute_tolerance, relative_tolerance, unit, units
+	FROM numeric
+	`
+	// execute query numeric questions
+	rows, err := db.sqliteDB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch numeric questions from database
+	var questions []*QuestionNumeric
+	for rows.Next() {
+		// variables definition
+		var units []byte
+		// query numeric question
+		question := &QuestionNumeric{}
+		if err := rows.Scan(&question.Id, &question.Title, &question.Value, &question.AbsoluteTolerance, &question.RelativeTolerance, &question.Unit, &units); err != nil {
+			return nil, err
+		}
+		// unmarshal json slices & structure
+		if err := json.Unmarshal(units, &question.Units); err != nil {
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (db *DB) createQuestionTemplateTable(sql string) error {
+	// create template table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create template question table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) CreateQuestionTemplate(question *QuestionTemplate) (int64, error) {
+	// execute template sql
+	query := `
+	INSERT INTO template (id, title, variables, formula, precision, absolute_tolerance, relative_tolerance) 
+	VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	// marshal json slices & structure
+	variables, err := json.Marshal(question.Variables)
+	if err != nil {
+		return 0, err
+	}
+	// perform insert template
+	result, err := db.sqliteDB.Exec(query, question.Id, question.Title, variables, question.Formula, question.Precision, question.AbsoluteTolerance, question.RelativeTolerance)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) {
+			if sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+				return 0, fmt.Errorf("template question already exists")
+			}
+		}
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (db *DB) CreateQuestionTemplateContext(ctx context.Context, question *QuestionTemplate) (int64, error) {
+	// execute template sql
+	query := `
+	INSERT INTO template (id, title, variables, formula, precision, absolute_tolerance, relative_tolerance) 
+	VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	// marshal json slices & structure
+	variables, err := json.Marshal(question.Variables)
+	if err != nil {
+		return 0, err
+	}
+	// perform insert template
+	result, err := db.sqliteDB.ExecContext(ctx, query, question.Id, question.Title, variables, question.Formula, question.Precision, question.AbsoluteTolerance, question.RelativeTolerance)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) {
+			if sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+				return 0, fmt.Errorf("template question already exists")
+			}
+		}
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (db *DB) QueryQuestionTemplate(id string) (*QuestionTemplate, error) {
+	// query template sql
+	query := `
+	SELECT id, title, variables, formula, precision, absolute_tolerance, relative_tolerance
+	FROM template WHERE id = ?
+	`
+	// variables definition
+	var variables []byte
+	// execute query template
+	row := db.sqliteDB.QueryRow(query, id)
+	question := &QuestionTemplate{}
+	err := row.Scan(&question.Id, &question.Title, &variables, &question.Formula, &question.Precision, &question.AbsoluteTolerance, &question.RelativeTolerance)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("template question not found")
+		}
+		return nil, err
+	}
+	// unmarshal json slices & structure
+	if err := json.Unmarshal(variables, &question.Variables); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+func (db *DB) QueryQuestionTemplateContext(ctx context.Context, id string) (*QuestionTemplate, error) {
+	// query template sql
+	query := `
+	SELECT id, title, variables, formula, precision, absolute_tolerance, relative_tolerance
+	FROM template WHERE id = ?
+	`
+	// variables definition
+	var variables []byte
+	// execute query template
+	row := db.sqliteDB.QueryRowContext(ctx, query, id)
+	question := &QuestionTemplate{}
+	err := row.Scan(&question.Id, &question.Title, &variables, &question.Formula, &question.Precision, &question.AbsoluteTolerance, &question.RelativeTolerance)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("template question not found")
+		}
+		return nil, err
+	}
+	// unmarshal json slices & structure
+	if err := json.Unmarshal(variables, &question.Variables); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+func (db *DB) UpdateQuestionTemplate(question *QuestionTemplate) error {
+	// update template sql
+	query := `
+	UPDATE template 
+	SET title = ?, variables = ?, formula = ?, precision = ?, absolute_tolerance = ?, relative_tolerance = ?
+	WHERE id = ?
+	`
+	// marshal json slices & structure
+	variables, err := json.Marshal(question.Variables)
+	if err != nil {
+		return err
+	}
+	// execute update template
+	result, err := db.sqliteDB.Exec(query, question.Title, variables, question.Formula, question.Precision, question.AbsoluteTolerance, question.RelativeTolerance, question.Id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("template question not found")
+	}
+	return nil
+}
+
+func (db *DB) UpdateQuestionTemplateContext(ctx context.Context, question *QuestionTemplate) error {
+	// update template sql
+	query := `
+	UPDATE template 
+	SET title = ?, variables = ?, formula = ?, precision = ?, absolute_tolerance = ?, relative_tolerance = ?
+	WHERE id = ?
+	`
+	// marshal json slices & structure
+	variables, err := json.Marshal(question.Variables)
+	if err != nil {
+		return err
+	}
+	// execute update template
+	result, err := db.sqliteDB.ExecContext(ctx, query, question.Title, variables, question.Formula, question.Precision, question.AbsoluteTolerance, question.RelativeTolerance, question.Id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("template question not found")
+	}
+	return nil
+}
+
+func (db *DB) DeleteQuestionTemplate(id string) error {
+	// update template sql
+	query := `DELETE FROM template WHERE id = ?`
+	// execute delete template
+	result, err := db.sqliteDB.Exec(query, id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("template question not found")
+	}
+	return nil
+}
+
+func (db *DB) DeleteQuestionTemplateContext(ctx context.Context, id string) error {
+	// update template sql
+	query := `DELETE FROM template WHERE id = ?`
+	// execute delete template
+	result, err := db.sqliteDB.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("template question not found")
+	}
+	return nil
+}
+
+func (db *DB) QueryQuestionsTemplate() ([]*QuestionTemplate, error) {
+	// query template questions
+	query := `
+	SELECT id, title, variables, formula, precision, absolute_tolerance, relative_tolerance
+	FROM template
+	`
+	// execute query template questions
+	rows, err := db.sqliteDB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch template questions from database
+	var questions []*QuestionTemplate
+	for rows.Next() {
+		// variables definition
+		var variables []byte
+		// query template question
+		question := &QuestionTemplate{}
+		if err := rows.Scan(&question.Id, &question.Title, &variables, &question.Formula, &question.Precision, &question.AbsoluteTolerance, &question.RelativeTolerance); err != nil {
+			return nil, err
+		}
+		// unmarshal json slices & structure
+		if err := json.Unmarshal(variables, &question.Variables); err != nil {
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (db *DB) QueryQuestionsTemplateContext(ctx context.Context) ([]*QuestionTemplate, error) {
+	// query template questions
+	query := `
+	SELECT id, title, variables, formula, precision, absolute_tolerance, relative_tolerance
+	FROM template
+	`
+	// execute query template questions
+	rows, err := db.sqliteDB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch template questions from database
+	var questions []*QuestionTemplate
+	for rows.Next() {
+		// variables definition
+		var variables []byte
+		// query template question
+		question := &QuestionTemplate{}
+		if err := rows.Scan(&question.Id, &question.Title, &variables, &question.Formula, &question.Precision, &question.AbsoluteTolerance, &question.RelativeTolerance); err != nil {
+			return nil, err
+		}
+		// unmarshal json slices & structure
+		if err := json.Unmarshal(variables, &question.Variables); err != nil {
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (db *DB) createQuestionCodeTable(sql string) error {
+	// create code table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create code question table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) CreateQuestionCode(question *QuestionCode) (int64, error) {
+	// execute code sql
+	query := `
+	INSERT INTO code (id, title, test_cases, language, starter_code, time_limit, memory_limit, scoring) 
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	// marshal json slices & structure
+	testCases, err := json.Marshal(question.TestCases)
+	if err != nil {
+		return 0, err
+	}
+	// perform insert code
+	result, err := db.sqliteDB.Exec(query, question.Id, question.Title, testCases, question.Language, question.StarterCode, question.TimeLimit, question.MemoryLimit, question.Scoring)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) {
+			if sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+				return 0, fmt.Errorf("code question already exists")
+			}
+		}
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (db *DB) CreateQuestionCodeContext(ctx context.Context, question *QuestionCode) (int64, error) {
+	// execute code sql
+	query := `
+	INSERT INTO code (id, title, test_cases, language, starter_code, time_limit, memory_limit, scoring) 
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	// marshal json slices & structure
+	testCases, err := json.Marshal(question.TestCases)
+	if err != nil {
+		return 0, err
+	}
+	// perform insert code
+	result, err := db.sqliteDB.ExecContext(ctx, query, question.Id, question.Title, testCases, question.Language, question.StarterCode, question.TimeLimit, question.MemoryLimit, question.Scoring)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) {
+			if sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+				return 0, fmt.Errorf("code question already exists")
+			}
+		}
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (db *DB) QueryQuestionCode(id string) (*QuestionCode, error) {
+	// query code sql
+	query := `
+	SELECT id, title, test_cases, language, starter_code, time_limit, memory_limit, scoring
+	FROM code WHERE id = ?
+	`
+	// test cases definition
+	var testCases []byte
+	// execute query code
+	row := db.sqliteDB.QueryRow(query, id)
+	question := &QuestionCode{}
+	err := row.Scan(&question.Id, &question.Title, &testCases, &question.Language, &question.StarterCode, &question.TimeLimit, &question.MemoryLimit, &question.Scoring)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("code question not found")
+		}
+		return nil, err
+	}
+	// unmarshal json slices & structure
+	if err := json.Unmarshal(testCases, &question.TestCases); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+func (db *DB) QueryQuestionCodeContext(ctx context.Context, id string) (*QuestionCode, error) {
+	// query code sql
+	query := `
+	SELECT id, title, test_cases, language, starter_code, time_limit, memory_limit, scoring
+	FROM code WHERE id = ?
+	`
+	// test cases definition
+	var testCases []byte
+	// execute query code
+	row := db.sqliteDB.QueryRowContext(ctx, query, id)
+	question := &QuestionCode{}
+	err := row.Scan(&question.Id, &question.Title, &testCases, &question.Language, &question.StarterCode, &question.TimeLimit, &question.MemoryLimit, &question.Scoring)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("code question not found")
+		}
+		return nil, err
+	}
+	// unmarshal json slices & structure
+	if err := json.Unmarshal(testCases, &question.TestCases); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+func (db *DB) UpdateQuestionCode(question *QuestionCode) error {
+	// update code sql
+	query := `
+	UPDATE code 
+	SET title = ?, test_cases = ?, language = ?, starter_code = ?, time_limit = ?, memory_limit = ?, scoring = ?
+	WHERE id = ?
+	`
+	// marshal json slices & structure
+	testCases, err := json.Marshal(question.TestCases)
+	if err != nil {
+		return err
+	}
+	// execute update code
+	result, err := db.sqliteDB.Exec(query, question.Title, testCases, question.Language, question.StarterCode, question.TimeLimit, question.MemoryLimit, question.Scoring, question.Id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("code question not found")
+	}
+	return nil
+}
+
+func (db *DB) UpdateQuestionCodeContext(ctx context.Context, question *QuestionCode) error {
+	// update code sql
+	query := `
+	UPDATE code 
+	SET title = ?, test_cases = ?, language = ?, starter_code = ?, time_limit = ?, memory_limit = ?, scoring = ?
+	WHERE id = ?
+	`
+	// marshal json slices & structure
+	testCases, err := json.Marshal(question.TestCases)
+	if err != nil {
+		return err
+	}
+	// execute update code
+	result, err := db.sqliteDB.ExecContext(ctx, query, question.Title, testCases, question.Language, question.StarterCode, question.TimeLimit, question.MemoryLimit, question.Scoring, question.Id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("code question not found")
+	}
+	return nil
+}
+
+func (db *DB) DeleteQuestionCode(id string) error {
+	// update code sql
+	query := `DELETE FROM code WHERE id = ?`
+	// execute delete code
+	result, err := db.sqliteDB.Exec(query, id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("code question not found")
+	}
+	return nil
+}
+
+func (db *DB) DeleteQuestionCodeContext(ctx context.Context, id string) error {
+	// update code sql
+	query := `DELETE FROM code WHERE id = ?`
+	// execute delete code
+	result, err := db.sqliteDB.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("code question not found")
+	}
+	return nil
+}
+
+func (db *DB) QueryQuestionsCode() ([]*QuestionCode, error) {
+	// query code questions
+	query := `
+	SELECT id, title, test_cases, language, starter_code, time_limit, memory_limit, scoring
+	FROM code
+	`
+	// execute query code questions
+	rows, err := db.sqliteDB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch code questions from database
+	var questions []*QuestionCode
+	for rows.Next() {
+		// test cases definition
+		var testCases []byte
+		// query code question
+		question := &QuestionCode{}
+		if err := rows.Scan(&question.Id, &question.Title, &testCases, &question.Language, &question.StarterCode, &question.TimeLimit, &question.MemoryLimit, &question.Scoring); err != nil {
+			return nil, err
+		}
+		// unmarshal json slices & structure
+		if err := json.Unmarshal(testCases, &question.TestCases); err != nil {
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (db *DB) QueryQuestionsCodeContext(ctx context.Context) ([]*QuestionCode, error) {
+	// query code questions
+	query := `
+	SELECT id, title, test_cases, language, starter_code, time_limit, memory_limit, scoring
+	FROM code
+	`
+	// execute query code questions
+	rows, err := db.sqliteDB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch code questions from database
+	var questions []*QuestionCode
+	for rows.Next() {
+		// test cases definition
+		var testCases []byte
+		// query code question
+		question := &QuestionCode{}
+		if err := rows.Scan(&question.Id, &question.Title, &testCases, &question.Language, &question.StarterCode, &question.TimeLimit, &question.MemoryLimit, &question.Scoring); err != nil {
+			return nil, err
+		}
+		// unmarshal json slices & structure
+		if err := json.Unmarshal(testCases, &question.TestCases); err != nil {
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (db *DB) createQuestionCodeAttemptTable(sql string) error {
+	// create code question attempt table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create code question attempt table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) CreateQuestionCodeAttempt(attempt *QuestionCodeAttempt) error {
+	return db.CreateQuestionCodeAttemptContext(context.Background(), attempt)
+}
+
+func (db *DB) CreateQuestionCodeAttemptContext(ctx context.Context, attempt *QuestionCodeAttempt) error {
+	// marshal test results
+	results, err := json.Marshal(attempt.Results)
+	if err != nil {
+		return err
+	}
+	// create code question attempt sql
+	query := `
+	INSERT INTO code_attempts (attempt_id, question_id, user_id, language, source, results, score, max_score, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	// execute create code question attempt
+	_, err = db.sqliteDB.ExecContext(ctx, query, attempt.AttemptId, attempt.Id, attempt.UserId, attempt.Language, attempt.Source, string(results), attempt.Score, attempt.MaxScore, attempt.CreatedAt)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) {
+			if sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY {
+				return fmt.Errorf("code question attempt already exists")
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func (db *DB) QueryQuestionCodeAttempt(attemptId string) (*QuestionCodeAttempt, error) {
+	return db.QueryQuestionCodeAttemptContext(context.Background(), attemptId)
+}
+
+func (db *DB) QueryQuestionCodeAttemptContext(ctx context.Context, attemptId string) (*QuestionCodeAttempt, error) {
+	// query code question attempt sql
+	query := `
+	SELECT attempt_id, question_id, user_id, language, source, results, score, max_score, created_at
+	FROM code_attempts WHERE attempt_id = ?
+	`
+	// execute query code question attempt
+	var results string
+	row := db.sqliteDB.QueryRowContext(ctx, query, attemptId)
+	attempt := &QuestionCodeAttempt{}
+	err := row.Scan(&attempt.AttemptId, &attempt.Id, &attempt.UserId, &attempt.Language, &attempt.Source, &results, &attempt.Score, &attempt.MaxScore, &attempt.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("code question attempt not found")
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(results), &attempt.Results); err != nil {
+		return nil, err
+	}
+	return attempt, nil
+}
+
+func (db *DB) QueryQuestionCodeAttempts(id string) ([]*QuestionCodeAttempt, error) {
+	return db.QueryQuestionCodeAttemptsContext(context.Background(), id)
+}
+
+func (db *DB) QueryQuestionCodeAttemptsContext(ctx context.Context, id string) ([]*QuestionCodeAttempt, error) {
+	// query code question attempts sql
+	query := `
+	SELECT attempt_id, question_id, user_id, language, source, results, score, max_score, created_at
+	FROM code_attempts WHERE question_id = ?
+	ORDER BY created_at
+	`
+	// execute query code question attempts
+	rows, err := db.sqliteDB.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch code question attempts from database
+	var attempts []*QuestionCodeAttempt
+	for rows.Next() {
+		var results string
+		attempt := &QuestionCodeAttempt{}
+		if err := rows.Scan(&attempt.AttemptId, &attempt.Id, &attempt.UserId, &attempt.Language, &attempt.Source, &results, &attempt.Score, &attempt.MaxScore, &attempt.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(results), &attempt.Results); err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+func (db *DB) createUserSessionTable(sql string) error {
+	// create user session table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create user session table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) CreateUserSession(tokenHash string, session *UserSession) error {
+	return db.CreateUserSessionContext(context.Background(), tokenHash, session)
+}
+
+func (db *DB) CreateUserSessionContext(ctx context.Context, tokenHash string, session *UserSession) error {
+	// create user session sql
+	query := `
+	INSERT INTO user_sessions (token_hash, user_id, created_at, expires_at)
+	VALUES (?, ?, ?, ?)
+	`
+	// execute create user session
+	if _, err := db.sqliteDB.ExecContext(ctx, query, tokenHash, session.UserId, session.CreatedAt, session.ExpiresAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) QueryUserSession(tokenHash string) (*UserSession, error) {
+	return db.QueryUserSessionContext(context.Background(), tokenHash)
+}
+
+func (db *DB) QueryUserSessionContext(ctx context.Context, tokenHash string) (*UserSession, error) {
+	// query user session sql
+	query := `
+	SELECT user_id, created_at, expires_at
+	FROM user_sessions WHERE token_hash = ?
+	`
+	// execute query user session
+	row := db.sqliteDB.QueryRowContext(ctx, query, tokenHash)
+	session := &UserSession{}
+	err := row.Scan(&session.UserId, &session.CreatedAt, &session.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("user session not found")
+		}
+		return nil, err
+	}
+	return session, nil
+}
+
+func (db *DB) DeleteUserSession(tokenHash string) error {
+	return db.DeleteUserSessionContext(context.Background(), tokenHash)
+}
+
+func (db *DB) DeleteUserSessionContext(ctx context.Context, tokenHash string) error {
+	// delete user session sql
+	query := `DELETE FROM user_sessions WHERE token_hash = ?`
+	// execute delete user session
+	if _, err := db.sqliteDB.ExecContext(ctx, query, tokenHash); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) DeleteUserSessions(userId string) error {
+	return db.DeleteUserSessionsContext(context.Background(), userId)
+}
+
+func (db *DB) DeleteUserSessionsContext(ctx context.Context, userId string) error {
+	// delete user sessions sql
+	query := `DELETE FROM user_sessions WHERE user_id = ?`
+	// execute delete user sessions
+	if _, err := db.sqliteDB.ExecContext(ctx, query, userId); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) createQuestionRevisionTable(sql string) error {
+	// create question revision table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create question revision table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) CreateQuestionRevision(revision *QuestionRevision) error {
+	return db.CreateQuestionRevisionContext(context.Background(), revision)
+}
+
+func (db *DB) CreateQuestionRevisionContext(ctx context.Context, revision *QuestionRevision) error {
+	// create question revision sql
+	query := `
+	INSERT INTO question_revisions (question_type, question_id, revision, author, created_at, content)
+	VALUES (?, ?, ?, ?, ?, ?)
+	`
+	// execute create question revision
+	_, err := db.sqliteDB.ExecContext(ctx, query, revision.Type, revision.Id, revision.Revision, revision.Author, revision.CreatedAt, string(revision.Content))
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) {
+			if sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY {
+				return fmt.Errorf("question revision already exists")
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func (db *DB) QueryQuestionRevision(questionType string, id string, revision int) (*QuestionRevision, error) {
+	return db.QueryQuestionRevisionContext(context.Background(), questionType, id, revision)
+}
+
+func (db *DB) QueryQuestionRevisionContext(ctx context.Context, questionType string, id string, revision int) (*QuestionRevision, error) {
+	// query question revision sql
+	query := `
+	SELECT question_type, question_id, revision, author, created_at, content
+	FROM question_revisions WHERE question_type = ? AND question_id = ? AND revision = ?
+	`
+	// execute query question revision
+	var content string
+	row := db.sqliteDB.QueryRowContext(ctx, query, questionType, id, revision)
+	result := &QuestionRevision{}
+	err := row.Scan(&result.Type, &result.Id, &result.Revision, &result.Author, &result.CreatedAt, &content)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("question revision not found")
+		}
+		return nil, err
+	}
+	result.Content = json.RawMessage(content)
+	return result, nil
+}
+
+func (db *DB) QueryQuestionRevisions(questionType string, id string) ([]*QuestionRevision, error) {
+	return db.QueryQuestionRevisionsContext(context.Background(), questionType, id)
+}
+
+func (db *DB) QueryQuestionRevisionsContext(ctx context.Context, questionType string, id string) ([]*QuestionRevision, error) {
+	// query question revisions sql
+	query := `
+	SELECT question_type, question_id, revision, author, created_at, content
+	FROM question_revisions WHERE question_type = ? AND question_id = ?
+	ORDER BY revision
+	`
+	// execute query question revisions
+	rows, err := db.sqliteDB.QueryContext(ctx, query, questionType, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch question revisions from database
+	var revisions []*QuestionRevision
+	for rows.Next() {
+		var content string
+		revision := &QuestionRevision{}
+		if err := rows.Scan(&revision.Type, &revision.Id, &revision.Revision, &revision.Author, &revision.CreatedAt, &content); err != nil {
+			return nil, err
+		}
+		revision.Content = json.RawMessage(content)
+		revisions = append(revisions, revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (db *DB) QueryQuestionLatestRevision(questionType string, id string) (int, error) {
+	return db.QueryQuestionLatestRevisionContext(context.Background(), questionType, id)
+}
+
+func (db *DB) QueryQuestionLatestRevisionContext(ctx context.Context, questionType string, id string) (int, error) {
+	// query latest question revision sql
+	query := `
+	SELECT COALESCE(MAX(revision), 0)
+	FROM question_revisions WHERE question_type = ? AND question_id = ?
+	`
+	// execute query latest question revision
+	var revision int
+	row := db.sqliteDB.QueryRowContext(ctx, query, questionType, id)
+	if err := row.Scan(&revision); err != nil {
+		return 0, err
+	}
+	return revision, nil
+}
+
+func (db *DB) createUserRoleTable(sql string) error {
+	// create user role table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create user role table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) UpdateUserRole(role *UserRole) error {
+	return db.UpdateUserRoleContext(context.Background(), role)
+}
+
+func (db *DB) UpdateUserRoleContext(ctx context.Context, role *UserRole) error {
+	// update user role sql
+	query := `
+	INSERT INTO user_roles (user_id, role) VALUES (?, ?)
+	ON CONFLICT (user_id) DO UPDATE SET role = excluded.role
+	`
+	// execute update user role
+	if _, err := db.sqliteDB.ExecContext(ctx, query, role.UserId, role.Role); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) QueryUserRoles() ([]*UserRole, error) {
+	return db.QueryUserRolesContext(context.Background())
+}
+
+func (db *DB) QueryUserRolesContext(ctx context.Context) ([]*UserRole, error) {
+	// query user roles sql
+	query := `SELECT user_id, role FROM user_roles`
+	// execute query user roles
+	rows, err := db.sqliteDB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch user roles from database
+	var roles []*UserRole
+	for rows.Next() {
+		role := &UserRole{}
+		if err := rows.Scan(&role.UserId, &role.Role); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (db *DB) createQuestionWorkflowTable(sql string) error {
+	// create question workflow table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create question workflow table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) UpdateQuestionWorkflow(workflow *QuestionWorkflow) error {
+	return db.UpdateQuestionWorkflowContext(context.Background(), workflow)
+}
+
+func (db *DB) UpdateQuestionWorkflowContext(ctx context.Context, workflow *QuestionWorkflow) error {
+	// update question workflow sql
+	query := `
+	INSERT INTO question_workflows (question_type, question_id, state, updated_by, updated_at) VALUES (?, ?, ?, ?, ?)
+	ON CONFLICT (question_type, question_id) DO UPDATE SET state = excluded.state, updated_by = excluded.updated_by, updated_at = excluded.updated_at
+	`
+	// execute update question workflow
+	if _, err := db.sqliteDB.ExecContext(ctx, query, workflow.Type, workflow.Id, workflow.State, workflow.UpdatedBy, workflow.UpdatedAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) DeleteQuestionWorkflow(questionType string, id string) error {
+	return db.DeleteQuestionWorkflowContext(context.Background(), questionType, id)
+}
+
+func (db *DB) DeleteQuestionWorkflowContext(ctx context.Context, questionType string, id string) error {
+	// delete question workflow sql
+	query := `DELETE FROM question_workflows WHERE question_type = ? AND question_id = ?`
+	// execute delete question workflow
+	if _, err := db.sqliteDB.ExecContext(ctx, query, questionType, id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) QueryQuestionWorkflows() ([]*QuestionWorkflow, error) {
+	return db.QueryQuestionWorkflowsContext(context.Background())
+}
+
+func (db *DB) QueryQuestionWorkflowsContext(ctx context.Context) ([]*QuestionWorkflow, error) {
+	// query question workflows sql
+	query := `SELECT question_type, question_id, state, updated_by, updated_at FROM question_workflows`
+	// execute query question workflows
+	rows, err := db.sqliteDB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch question workflows from database
+	var workflows []*QuestionWorkflow
+	for rows.Next() {
+		workflow := &QuestionWorkflow{}
+		if err := rows.Scan(&workflow.Type, &workflow.Id, &workflow.State, &workflow.UpdatedBy, &workflow.UpdatedAt); err != nil {
+			return nil, err
+		}
+		workflows = append(workflows, workflow)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return workflows, nil
+}
+
+func (db *DB) createQuestionReviewTable(sql string) error {
+	// create question review table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create question review table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) CreateQuestionReview(questionType string, id string, review *QuestionReview) error {
+	return db.CreateQuestionReviewContext(context.Background(), questionType, id, review)
+}
+
+func (db *DB) CreateQuestionReviewContext(ctx context.Context, questionType string, id string, review *QuestionReview) error {
+	// create question review sql
+	query := `
+	INSERT INTO question_reviews (question_type, question_id, reviewer, state_from, state_to, comment, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	// execute create question review
+	if _, err := db.sqliteDB.ExecContext(ctx, query, questionType, id, review.Reviewer, review.From, review.To, review.Comment, review.CreatedAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) QueryQuestionReviews(questionType string, id string) ([]*QuestionReview, error) {
+	return db.QueryQuestionReviewsContext(context.Background(), questionType, id)
+}
+
+func (db *DB) QueryQuestionReviewsContext(ctx context.Context, questionType string, id string) ([]*QuestionReview, error) {
+	// query question reviews sql
+	query := `
+	SELECT reviewer, state_from, state_to, comment, created_at
+	FROM question_reviews WHERE question_type = ? AND question_id = ?
+	ORDER BY created_at, rowid
+	`
+	// execute query question reviews
+	rows, err := db.sqliteDB.QueryContext(ctx, query, questionType, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch question reviews from database
+	var reviews []*QuestionReview
+	for rows.Next() {
+		review := &QuestionReview{}
+		if err := rows.Scan(&review.Reviewer, &review.From, &review.To, &review.Comment, &review.CreatedAt); err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+func (db *DB) createQuestionGuidanceTable(sql string) error {
+	// create question guidance table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create question guidance table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) UpdateQuestionGuidance(guidance *QuestionGuidance) error {
+	return db.UpdateQuestionGuidanceContext(context.Background(), guidance)
+}
+
+func (db *DB) UpdateQuestionGuidanceContext(ctx context.Context, guidance *QuestionGuidance) error {
+	// marshal json slices & structure
+	hints, err := json.Marshal(guidance.Hints)
+	if err != nil {
+		return err
+	}
+	feedback, err := json.Marshal(guidance.Feedback)
+	if err != nil {
+		return err
+	}
+	// update question guidance sql
+	query := `
+	INSERT INTO question_guidance (question_type, question_id, revision, explanation, hints, feedback, updated_by, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT (question_type, question_id, revision) DO UPDATE SET explanation = excluded.explanation, hints = excluded.hints,
+	feedback = excluded.feedback, updated_by = excluded.updated_by, updated_at = excluded.updated_at
+	`
+	// execute update question guidance
+	if _, err := db.sqliteDB.ExecContext(ctx, query, guidance.Type, guidance.Id, guidance.Revision, guidance.Explanation, string(hints), string(feedback), guidance.UpdatedBy, guidance.UpdatedAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) QueryQuestionGuidance(questionType string, id string, revision int) (*QuestionGuidance, error) {
+	return db.QueryQuestionGuidanceContext(context.Background(), questionType, id, revision)
+}
+
+func (db *DB) QueryQuestionGuidanceContext(ctx context.Context, questionType string, id string, revision int) (*QuestionGuidance, error) {
+	// query guidance in effect at question revision sql
+	query := `
+	SELECT question_type, question_id, revision, explanation, hints, feedback, updated_by, updated_at
+	FROM question_guidance WHERE question_type = ? AND question_id = ? AND revision <= ?
+	ORDER BY revision DESC LIMIT 1
+	`
+	// execute query question guidance
+	var hints, feedback string
+	row := db.sqliteDB.QueryRowContext(ctx, query, questionType, id, revision)
+	guidance := &QuestionGuidance{}
+	err := row.Scan(&guidance.Type, &guidance.Id, &guidance.Revision, &guidance.Explanation, &hints, &feedback, &guidance.UpdatedBy, &guidance.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errQuestionGuidanceNotFound
+		}
+		return nil, err
+	}
+	// unmarshal json slices & structure
+	if err := json.Unmarshal([]byte(hints), &guidance.Hints); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(feedback), &guidance.Feedback); err != nil {
+		return nil, err
+	}
+	return guidance, nil
+}
+
+func (db *DB) createQuestionHintTable(sql string) error {
+	// create question hint table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create question hint table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) UpdateQuestionHints(userId string, questionType string, id string, revealed int) error {
+	return db.UpdateQuestionHintsContext(context.Background(), userId, questionType, id, revealed)
+}
+
+func (db *DB) UpdateQuestionHintsContext(ctx context.Context, userId string, questionType string, id string, revealed int) error {
+	// update revealed hints sql
+	query := `
+	INSERT INTO question_hints (user_id, question_type, question_id, revealed) VALUES (?, ?, ?, ?)
+	ON CONFLICT (user_id, question_type, question_id) DO UPDATE SET revealed = excluded.revealed
+	`
+	// execute update revealed hints
+	if _, err := db.sqliteDB.ExecContext(ctx, query, userId, questionType, id, revealed); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) QueryQuestionHints(userId string, questionType string, id string) (int, error) {
+	return db.QueryQuestionHintsContext(context.Background(), userId, questionType, id)
+}
+
+func (db *DB) QueryQuestionHintsContext(ctx context.Context, userId string, questionType string, id string) (int, error) {
+	// query revealed hints sql
+	query := `
+	SELECT COALESCE(MAX(revealed), 0)
+	FROM question_hints WHERE user_id = ? AND question_type = ? AND question_id = ?
+	`
+	// execute query revealed hints
+	var revealed int
+	row := db.sqliteDB.QueryRowContext(ctx, query, userId, questionType, id)
+	if err := row.Scan(&revealed); err != nil {
+		return 0, err
+	}
+	return revealed, nil
+}
+
+func (db *DB) createQuestionFormatTable(sql string) error {
+	// create question format table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create question format table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) UpdateQuestionFormat(format *QuestionFormat) error {
+	return db.UpdateQuestionFormatContext(context.Background(), format)
+}
+
+func (db *DB) UpdateQuestionFormatContext(ctx context.Context, format *QuestionFormat) error {
+	// update question format sql
+	query := `
+	INSERT INTO question_formats (question_type, question_id, format, updated_by, updated_at) VALUES (?, ?, ?, ?, ?)
+	ON CONFLICT (question_type, question_id) DO UPDATE SET format = excluded.format, updated_by = excluded.updated_by,
+	updated_at = excluded.updated_at
+	`
+	// execute update question format
+	if _, err := db.sqliteDB.ExecContext(ctx, query, format.Type, format.Id, format.Format, format.UpdatedBy, format.UpdatedAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) QueryQuestionFormat(questionType string, id string) (*QuestionFormat, error) {
+	return db.QueryQuestionFormatContext(context.Background(), questionType, id)
+}
+
+func (db *DB) QueryQuestionFormatContext(ctx context.Context, questionType string, id string) (*QuestionFormat, error) {
+	// query question format sql
+	query := `
+	SELECT question_type, question_id, format, updated_by, updated_at
+	FROM question_formats WHERE question_type = ? AND question_id = ?
+	`
+	// execute query question format
+	row := db.sqliteDB.QueryRowContext(ctx, query, questionType, id)
+	format := &QuestionFormat{}
+	err := row.Scan(&format.Type, &format.Id, &format.Format, &format.UpdatedBy, &format.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errQuestionFormatNotFound
+		}
+		return nil, err
+	}
+	return format, nil
+}
+
+func (db *DB) createAttachmentTable(sql string) error {
+	// create attachment table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create attachment table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) CreateAttachment(attachment *Attachment) (bool, error) {
+	return db.CreateAttachmentContext(context.Background(), attachment)
+}
+
+func (db *DB) CreateAttachmentContext(ctx context.Context, attachment *Attachment) (bool, error) {
+	// create attachment sql, same content is stored once
+	query := `
+	INSERT INTO attachments (attachment_id, name, media_type, size, created_by, created_at) VALUES (?, ?, ?, ?, ?, ?)
+	ON CONFLICT (attachment_id) DO NOTHING
+	`
+	// execute create attachment
+	result, err := db.sqliteDB.ExecContext(ctx, query, attachment.Id, attachment.Name, attachment.MediaType, attachment.Size, attachment.CreatedBy, attachment.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+	// check rows affected
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+func (db *DB) QueryAttachment(attachmentId string) (*Attachment, error) {
+	return db.QueryAttachmentContext(context.Background(), attachmentId)
+}
+
+func (db *DB) QueryAttachmentContext(ctx context.Context, attachmentId string) (*Attachment, error) {
+	// query attachment sql
+	query := `
+	SELECT attachment_id, name, media_type, size, created_by, created_at
+	FROM attachments WHERE attachment_id = ?
+	`
+	// execute query attachment
+	row := db.sqliteDB.QueryRowContext(ctx, query, attachmentId)
+	attachment := &Attachment{}
+	err := row.Scan(&attachment.Id, &attachment.Name, &attachment.MediaType, &attachment.Size, &attachment.CreatedBy, &attachment.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errAttachmentNotFound
+		}
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func (db *DB) DeleteAttachment(attachmentId string) error {
+	return db.DeleteAttachmentContext(context.Background(), attachmentId)
+}
+
+func (db *DB) DeleteAttachmentContext(ctx context.Context, attachmentId string) error {
+	// delete attachment sql
+	query := `DELETE FROM attachments WHERE attachment_id = ?`
+	// execute delete attachment
+	if _, err := db.sqliteDB.ExecContext(ctx, query, attachmentId); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) QueryOrphanAttachments(before time.Time) ([]*Attachment, error) {
+	return db.QueryOrphanAttachmentsContext(context.Background(), before)
+}
+
+func (db *DB) QueryOrphanAttachmentsContext(ctx context.Context, before time.Time) ([]*Attachment, error) {
+	// query attachments no question refers to sql
+	query := `
+	SELECT a.attachment_id, a.name, a.media_type, a.size, a.created_by, a.created_at
+	FROM attachments a LEFT JOIN question_attachments q ON q.attachment_id = a.attachment_id
+	WHERE q.attachment_id IS NULL AND a.created_at < ?
+	`
+	// execute query orphan attachments
+	rows, err := db.sqliteDB.QueryContext(ctx, query, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch orphan attachments from database
+	var attachments []*Attachment
+	for rows.Next() {
+		attachment := &Attachment{}
+		if err := rows.Scan(&attachment.Id, &attachment.Name, &attachment.MediaType, &attachment.Size, &attachment.CreatedBy, &attachment.CreatedAt); err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (db *DB) createQuestionAttachmentTable(sql string) error {
+	// create question attachment table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create question attachment table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) UpdateQuestionAttachments(questionType string, id string, attachmentIds []string) error {
+	return db.UpdateQuestionAttachmentsContext(context.Background(), questionType, id, attachmentIds)
+}
+
+func (db *DB) UpdateQuestionAttachmentsContext(ctx context.Context, questionType string, id string, attachmentIds []string) error {
+	// references of question are replaced as a whole
+	tx, err := db.sqliteDB.BeginTxContext(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	// delete question attachments sql
+	query := `DELETE FROM question_attachments WHERE question_type = ? AND question_id = ?`
+	if _, err := tx.ExecContext(ctx, query, questionType, id); err != nil {
+		return err
+	}
+	// create question attachments sql
+	query = `
+	INSERT INTO question_attachments (question_type, question_id, attachment_id, position) VALUES (?, ?, ?, ?)
+	`
+	for k, v := range attachmentIds {
+		if _, err := tx.ExecContext(ctx, query, questionType, id, v, k); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (db *DB) QueryQuestionAttachments(questionType string, id string) ([]*Attachment, error) {
+	return db.QueryQuestionAttachmentsContext(context.Background(), questionType, id)
+}
+
+func (db *DB) QueryQuestionAttachmentsContext(ctx context.Context, questionType string, id string) ([]*Attachment, error) {
+	// query attachments of question in order sql
+	query := `
+	SELECT a.attachment_id, a.name, a.media_type, a.size, a.created_by, a.created_at
+	FROM question_attachments q JOIN attachments a ON a.attachment_id = q.attachment_id
+	WHERE q.question_type = ? AND q.question_id = ? ORDER BY q.position
+	`
+	// execute query question attachments
+	rows, err := db.sqliteDB.QueryContext(ctx, query, questionType, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch question attachments from database
+	var attachments []*Attachment
+	for rows.Next() {
+		attachment := &Attachment{}
+		if err := rows.Scan(&attachment.Id, &attachment.Name, &attachment.MediaType, &attachment.Size, &attachment.CreatedBy, &attachment.CreatedAt); err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (db *DB) QueryAttachmentReferences(attachmentId string) ([]*QuestionAttachments, error) {
+	return db.QueryAttachmentReferencesContext(context.Background(), attachmentId)
+}
+
+func (db *DB) QueryAttachmentReferencesContext(ctx context.Context, attachmentId string) ([]*QuestionAttachments, error) {
+	// query questions referring to attachment, every question when attachment is empty
+	query := `
+	SELECT DISTINCT question_type, question_id
+	FROM question_attachments WHERE ? = '' OR attachment_id = ?
+	`
+	// execute query attachment references
+	rows, err := db.sqliteDB.QueryContext(ctx, query, attachmentId, attachmentId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch attachment references from database
+	var references []*QuestionAttachments
+	for rows.Next() {
+		reference := &QuestionAttachments{}
+		if err := rows.Scan(&reference.Type, &reference.Id); err != nil {
+			return nil, err
+		}
+		references = append(references, reference)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return references, nil
+}
+
+func (db *DB) createExamTable(sql string) error {
+	// create exam table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create exam table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) CreateExam(exam *Exam) error {
+	return db.CreateExamContext(context.Background(), exam)
+}
+
+func (db *DB) CreateExamContext(ctx context.Context, exam *Exam) error {
+	// marshal exam questions & timing
+	questions, err := json.Marshal(exam.Questions)
+	if err != nil {
+		return err
+	}
+	timing, err := json.Marshal(exam.Timing)
+	if err != nil {
+		return err
+	}
+	tx, err := db.sqliteDB.BeginTxContext(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	// create exam sql
+	query := `
+	INSERT INTO exams (exam_id, title, questions, shuffle_questions, shuffle_answers, created_by, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	// execute create exam
+	_, err = tx.ExecContext(ctx, query, exam.Id, exam.Title, string(questions), exam.ShuffleQuestions, exam.ShuffleAnswers, exam.CreatedBy, exam.CreatedAt)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) {
+			if sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY {
+				return errExamExisted
+			}
+		}
+		return err
+	}
+	// exam generated from blueprint keeps its source
+	if exam.BlueprintId != "" {
+		query = `INSERT INTO generated_exams (exam_id, blueprint_id) VALUES (?, ?)`
+		if _, err := tx.ExecContext(ctx, query, exam.Id, exam.BlueprintId); err != nil {
+			return err
+		}
+	}
+	// timed exam keeps its time limits
+	if exam.Timing != nil {
+		query = `INSERT INTO exam_timings (exam_id, timing) VALUES (?, ?)`
+		if _, err := tx.ExecContext(ctx, query, exam.Id, string(timing)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (db *DB) QueryExam(examId string) (*Exam, error) {
+	return db.QueryExamContext(context.Background(), examId)
+}
+
+func (db *DB) QueryExamContext(ctx context.Context, examId string) (*Exam, error) {
+	// query exam sql
+	query := `
+	SELECT e.exam_id, e.title, e.questions, e.shuffle_questions, e.shuffle_answers, COALESCE(g.blueprint_id, ''), COALESCE(t.timing, 'null'),
+	e.created_by, e.created_at
+	FROM exams e LEFT JOIN generated_exams g ON g.exam_id = e.exam_id LEFT JOIN exam_timings t ON t.exam_id = e.exam_id
+	WHERE e.exam_id = ?
+	`
+	// execute query exam
+	var questions, timing string
+	row := db.sqliteDB.QueryRowContext(ctx, query, examId)
+	exam := &Exam{}
+	err := row.Scan(&exam.Id, &exam.Title, &questions, &exam.ShuffleQuestions, &exam.ShuffleAnswers, &exam.BlueprintId, &timing,
+		&exam.CreatedBy, &exam.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errExamNotFound
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(questions), &exam.Questions); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(timing), &exam.Timing); err != nil {
+		return nil, err
+	}
+	return exam, nil
+}
+
+func (db *DB) createExamAttemptTable(sql string) error {
+	// create exam attempt table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create exam attempt table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) CreateExamAttempt(attempt *ExamAttempt) error {
+	return db.CreateExamAttemptContext(context.Background(), attempt)
+}
+
+func (db *DB) CreateExamAttemptContext(ctx context.Context, attempt *ExamAttempt) error {
+	// marshal submitted answers & grades
+	answers, err := json.Marshal(attempt.Answers)
+	if err != nil {
+		return err
+	}
+	grades, err := json.Marshal(attempt.Grades)
+	if err != nil {
+		return err
+	}
+	questions, err := json.Marshal(attempt.ExamQuestions)
+	if err != nil {
+		return err
+	}
+	tx, err := db.sqliteDB.BeginTxContext(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	// create exam attempt sql
+	query := `
+	INSERT INTO exam_attempts (attempt_id, exam_id, user_id, seed, state, answers, grades, score, max_score, created_at, submitted_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	// execute create exam attempt
+	_, err = tx.ExecContext(ctx, query, attempt.AttemptId, attempt.ExamId, attempt.UserId, attempt.Seed, attempt.State,
+		string(answers), string(grades), attempt.Score, attempt.MaxScore, attempt.CreatedAt, attempt.SubmittedAt)
+	if err != nil {
+		return err
+	}
+	// questions drawn for attempt are kept with it
+	query = `INSERT INTO exam_attempt_questions (attempt_id, questions) VALUES (?, ?)`
+	if _, err := tx.ExecContext(ctx, query, attempt.AttemptId, string(questions)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (db *DB) UpdateExamAttempt(attempt *ExamAttempt, state string) (bool, error) {
+	return db.UpdateExamAttemptContext(context.Background(), attempt, state)
+}
+
+func (db *DB) UpdateExamAttemptContext(ctx context.Context, attempt *ExamAttempt, state string) (bool, error) {
+	// marshal submitted answers & grades
+	answers, err := json.Marshal(attempt.Answers)
+	if err != nil {
+		return false, err
+	}
+	grades, err := json.Marshal(attempt.Grades)
+	if err != nil {
+		return false, err
+	}
+	tx, err := db.sqliteDB.BeginTxContext(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	// update exam attempt sql, only attempt still in given state is updated
+	query := `
+	UPDATE exam_attempts SET state = ?, answers = ?, grades = ?, score = ?, max_score = ?, submitted_at = ?
+	WHERE attempt_id = ? AND state = ?
+	`
+	// execute update exam attempt
+	result, err := tx.ExecContext(ctx, query, attempt.State, string(answers), string(grades), attempt.Score, attempt.MaxScore,
+		attempt.SubmittedAt, attempt.AttemptId, state)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil || n == 0 {
+		return false, err
+	}
+	// submitted attempt records how it was closed
+	if attempt.State == ExamAttemptStateSubmitted {
+		query = `INSERT OR REPLACE INTO exam_attempt_closures (attempt_id, late, penalty, auto_submitted) VALUES (?, ?, ?, ?)`
+		if _, err := tx.ExecContext(ctx, query, attempt.AttemptId, attempt.Late, attempt.Penalty, attempt.AutoSubmitted); err != nil {
+			return false, err
+		}
+	}
+	return true, tx.Commit()
+}
+
+func (db *DB) QueryExamAttempt(attemptId string) (*ExamAttempt, error) {
+	return db.QueryExamAttemptContext(context.Background(), attemptId)
+}
+
+func (db *DB) QueryExamAttemptContext(ctx context.Context, attemptId string) (*ExamAttempt, error) {
+	// query exam attempt sql
+	query := `
+	SELECT a.attempt_id, a.exam_id, a.user_id, a.seed, a.state, a.answers, a.grades, a.score, a.max_score, a.created_at, a.submitted_at,
+	COALESCE(q.questions, 'null'), COALESCE(c.late, 0), COALESCE(c.penalty, 0), COALESCE(c.auto_submitted, 0)
+	FROM exam_attempts a LEFT JOIN exam_attempt_questions q ON q.attempt_id = a.attempt_id
+	LEFT JOIN exam_attempt_closures c ON c.attempt_id = a.attempt_id
+	WHERE a.attempt_id = ?
+	`
+	// execute query exam attempt
+	attempt, err := scanExamAttempt(db.sqliteDB.QueryRowContext(ctx, query, attemptId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errExamAttemptNotFound
+	}
+	return attempt, err
+}
+
+func (db *DB) QueryExamAttempts(examId string) ([]*ExamAttempt, error) {
+	return db.QueryExamAttemptsContext(context.Background(), examId)
+}
+
+func (db *DB) QueryExamAttemptsContext(ctx context.Context, examId string) ([]*ExamAttempt, error) {
+	// query exam attempts sql
+	query := `
+	SELECT a.attempt_id, a.exam_id, a.user_id, a.seed, a.state, a.answers, a.grades, a.score, a.max_score, a.created_at, a.submitted_at,
+	COALESCE(q.questions, 'null'), COALESCE(c.late, 0), COALESCE(c.penalty, 0), COALESCE(c.auto_submitted, 0)
+	FROM exam_attempts a LEFT JOIN exam_attempt_questions q ON q.attempt_id = a.attempt_id
+	LEFT JOIN exam_attempt_closures c ON c.attempt_id = a.attempt_id
+	WHERE a.exam_id = ?
+	ORDER BY a.created_at
+	`
+	// execute query exam attempts
+	rows, err := db.sqliteDB.QueryContext(ctx, query, examId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch exam attempts from database
+	var attempts []*ExamAttempt
+	for rows.Next() {
+		attempt, err := scanExamAttempt(rows)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+func scanExamAttempt(row interface{ Scan(...any) error }) (*ExamAttempt, error) {
+	// scan exam attempt & unmarshal submitted answers & grades
+	var answers, grades, questions string
+	var submittedAt sql.NullTime
+	attempt := &ExamAttempt{}
+	err := row.Scan(&attempt.AttemptId, &attempt.ExamId, &attempt.UserId, &attempt.Seed, &attempt.State, &answers, &grades,
+		&attempt.Score, &attempt.MaxScore, &attempt.CreatedAt, &submittedAt, &questions, &attempt.Late, &attempt.Penalty, &attempt.AutoSubmitted)
+	if err != nil {
+		return nil, err
+	}
+	if submittedAt.Valid {
+		attempt.SubmittedAt = &submittedAt.Time
+	}
+	if err := json.Unmarshal([]byte(answers), &attempt.Answers); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(grades), &attempt.Grades); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(questions), &attempt.ExamQuestions); err != nil {
+		return nil, err
+	}
+	return attempt, nil
+}
+
+func (db *DB) QuerySubmittedExamAttempts(examId string) ([]*ExamAttempt, error) {
+	return db.QuerySubmittedExamAttemptsContext(context.Background(), examId)
+}
+
+func (db *DB) QuerySubmittedExamAttemptsContext(ctx context.Context, examId string) ([]*ExamAttempt, error) {
+	// query submitted attempts of exam sql, empty examId queries attempts of every exam
+	query := `
+	SELECT a.attempt_id, a.exam_id, a.user_id, a.seed, a.state, a.answers, a.grades, a.score, a.max_score, a.created_at, a.submitted_at,
+	COALESCE(q.questions, 'null'), COALESCE(c.late, 0), COALESCE(c.penalty, 0), COALESCE(c.auto_submitted, 0)
+	FROM exam_attempts a LEFT JOIN exam_attempt_questions q ON q.attempt_id = a.attempt_id
+	LEFT JOIN exam_attempt_closures c ON c.attempt_id = a.attempt_id
+	WHERE a.state = ? AND (? = '' OR a.exam_id = ?)
+	ORDER BY a.created_at
+	`
+	// execute query submitted exam attempts
+	rows, err := db.sqliteDB.QueryContext(ctx, query, ExamAttemptStateSubmitted, examId, examId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch exam attempts from database
+	var attempts []*ExamAttempt
+	for rows.Next() {
+		attempt, err := scanExamAttempt(rows)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+func (db *DB) QueryOpenExamAttempts() ([]*ExamAttempt, error) {
+	return db.QueryOpenExamAttemptsContext(context.Background())
+}
+
+func (db *DB) QueryOpenExamAttemptsContext(ctx context.Context) ([]*ExamAttempt, error) {
+	// query attempts of timed exams still open sql
+	query := `
+	SELECT a.attempt_id, a.exam_id, a.user_id, a.seed, a.state, a.answers, a.grades, a.score, a.max_score, a.created_at, a.submitted_at,
+	COALESCE(q.questions, 'null'), COALESCE(c.late, 0), COALESCE(c.penalty, 0), COALESCE(c.auto_submitted, 0)
+	FROM exam_attempts a JOIN exam_timings t ON t.exam_id = a.exam_id
+	LEFT JOIN exam_attempt_questions q ON q.attempt_id = a.attempt_id
+	LEFT JOIN exam_attempt_closures c ON c.attempt_id = a.attempt_id
+	WHERE a.state = ?
+	ORDER BY a.created_at
+	`
+	// execute query open exam attempts
+	rows, err := db.sqliteDB.QueryContext(ctx, query, ExamAttemptStateOpen)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch exam attempts from database
+	var attempts []*ExamAttempt
+	for rows.Next() {
+		attempt, err := scanExamAttempt(rows)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+func (db *DB) createExamAttemptQuestionTable(sql string) error {
+	// create exam attempt question table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create exam attempt question table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) QueryUserExamQuestions(userId string, since time.Time) ([]ExamQuestion, error) {
+	return db.QueryUserExamQuestionsContext(context.Background(), userId, since)
+}
+
+func (db *DB) QueryUserExamQuestionsContext(ctx context.Context, userId string, since time.Time) ([]ExamQuestion, error) {
+	// query questions of attempts started by user since given time sql
+	query := `
+	SELECT q.questions
+	FROM exam_attempts a JOIN exam_attempt_questions q ON q.attempt_id = a.attempt_id
+	WHERE a.user_id = ? AND a.created_at >= ?
+	`
+	// execute query user exam questions
+	rows, err := db.sqliteDB.QueryContext(ctx, query, userId, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch questions of every attempt
+	var questions []ExamQuestion
+	for rows.Next() {
+		var text string
+		var attemptQuestions []ExamQuestion
+		if err := rows.Scan(&text); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(text), &attemptQuestions); err != nil {
+			return nil, err
+		}
+		questions = append(questions, attemptQuestions...)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (db *DB) createGeneratedExamTable(sql string) error {
+	// create generated exam table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create generated exam table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) createExamBlueprintTable(sql string) error {
+	// create exam blueprint table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create exam blueprint table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) CreateExamBlueprint(blueprint *ExamBlueprint) error {
+	return db.CreateExamBlueprintContext(context.Background(), blueprint)
+}
+
+func (db *DB) CreateExamBlueprintContext(ctx context.Context, blueprint *ExamBlueprint) error {
+	// marshal blueprint rules
+	rules, err := json.Marshal(blueprint.Rules)
+	if err != nil {
+		return err
+	}
+	// create exam blueprint sql
+	query := `
+	INSERT INTO exam_blueprints (blueprint_id, title, rules, total_points, mode, recent_days, shuffle_questions, shuffle_answers, created_by, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	// execute create exam blueprint
+	_, err = db.sqliteDB.ExecContext(ctx, query, blueprint.Id, blueprint.Title, string(rules), blueprint.TotalPoints, blueprint.Mode,
+		blueprint.RecentDays, blueprint.ShuffleQuestions, blueprint.ShuffleAnswers, blueprint.CreatedBy, blueprint.CreatedAt)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) {
+			if sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY {
+				return errExamBlueprintExisted
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func (db *DB) QueryExamBlueprint(blueprintId string) (*ExamBlueprint, error) {
+	return db.QueryExamBlueprintContext(context.Background(), blueprintId)
+}
+
+func (db *DB) QueryExamBlueprintContext(ctx context.Context, blueprintId string) (*ExamBlueprint, error) {
+	// query exam blueprint sql
+	query := `
+	SELECT blueprint_id, title, rules, total_points, mode, recent_days, shuffle_questions, shuffle_answers, created_by, created_at
+	FROM exam_blueprints WHERE blueprint_id = ?
+	`
+	// execute query exam blueprint
+	var rules string
+	row := db.sqliteDB.QueryRowContext(ctx, query, blueprintId)
+	blueprint := &ExamBlueprint{}
+	err := row.Scan(&blueprint.Id, &blueprint.Title, &rules, &blueprint.TotalPoints, &blueprint.Mode, &blueprint.RecentDays,
+		&blueprint.ShuffleQuestions, &blueprint.ShuffleAnswers, &blueprint.CreatedBy, &blueprint.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errExamBlueprintNotFound
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(rules), &blueprint.Rules); err != nil {
+		return nil, err
+	}
+	return blueprint, nil
+}
+
+func (db *DB) createQuestionClassificationTable(sql string) error {
+	// create question classification table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create question classification table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) UpdateQuestionClassification(classification *QuestionClassification) error {
+	return db.UpdateQuestionClassificationContext(context.Background(), classification)
+}
+
+func (db *DB) UpdateQuestionClassificationContext(ctx context.Context, classification *QuestionClassification) error {
+	// marshal question tags
+	tags, err := json.Marshal(classification.Tags)
+	if err != nil {
+		return err
+	}
+	// update question classification sql
+	query := `
+	INSERT INTO question_classifications (question_type, question_id, category, difficulty, tags, updated_by, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT (question_type, question_id) DO UPDATE SET category = excluded.category, difficulty = excluded.difficulty,
+	tags = excluded.tags, updated_by = excluded.updated_by, updated_at = excluded.updated_at
+	`
+	// execute update question classification
+	if _, err := db.sqliteDB.ExecContext(ctx, query, classification.Type, classification.Id, classification.Category,
+		classification.Difficulty, string(tags), classification.UpdatedBy, classification.UpdatedAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) QueryQuestionClassification(questionType string, id string) (*QuestionClassification, error) {
+	return db.QueryQuestionClassificationContext(context.Background(), questionType, id)
+}
+
+func (db *DB) QueryQuestionClassificationContext(ctx context.Context, questionType string, id string) (*QuestionClassification, error) {
+	// query question classification sql
+	query := `
+	SELECT question_type, question_id, category, difficulty, tags, updated_by, updated_at
+	FROM question_classifications WHERE question_type = ? AND question_id = ?
+	`
+	// execute query question classification
+	classification, err := scanQuestionClassification(db.sqliteDB.QueryRowContext(ctx, query, questionType, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errQuestionClassificationNotFound
+	}
+	return classification, err
+}
+
+func (db *DB) QueryQuestionClassifications() ([]*QuestionClassification, error) {
+	return db.QueryQuestionClassificationsContext(context.Background())
+}
+
+func (db *DB) QueryQuestionClassificationsContext(ctx context.Context) ([]*QuestionClassification, error) {
+	// query question classifications sql
+	query := `
+	SELECT question_type, question_id, category, difficulty, tags, updated_by, updated_at
+	FROM question_classifications
+	`
+	// execute query question classifications
+	rows, err := db.sqliteDB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch question classifications from database
+	var classifications []*QuestionClassification
+	for rows.Next() {
+		classification, err := scanQuestionClassification(rows)
+		if err != nil {
+			return nil, err
+		}
+		classifications = append(classifications, classification)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return classifications, nil
+}
+
+func scanQuestionClassification(row interface{ Scan(...any) error }) (*QuestionClassification, error) {
+	// scan question classification & unmarshal its tags
+	var tags string
+	classification := &QuestionClassification{}
+	err := row.Scan(&classification.Type, &classification.Id, &classification.Category, &classification.Difficulty, &tags,
+		&classification.UpdatedBy, &classification.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(tags), &classification.Tags); err != nil {
+		return nil, err
+	}
+	return classification, nil
+}
+
+func (db *DB) createExamTimingTable(sql string) error {
+	// create exam timing table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create exam timing table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) createExamAttemptClosureTable(sql string) error {
+	// create exam attempt closure table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create exam attempt closure table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) createExamAttemptDraftTable(sql string) error {
+	// create exam attempt draft table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create exam attempt draft table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) UpdateExamAttemptDraft(draft *ExamAttemptDraft) error {
+	return db.UpdateExamAttemptDraftContext(context.Background(), draft)
+}
+
+func (db *DB) UpdateExamAttemptDraftContext(ctx context.Context, draft *ExamAttemptDraft) error {
+	tx, err := db.sqliteDB.BeginTxContext(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	// update exam attempt draft sql, answer saved again replaces former one
+	query := `
+	INSERT INTO exam_attempt_drafts (attempt_id, question_type, question_id, answer, locale, saved_at)
+	VALUES (?, ?, ?, ?, ?, ?)
+	ON CONFLICT (attempt_id, question_type, question_id) DO UPDATE SET answer = excluded.answer, locale = excluded.locale, saved_at = excluded.saved_at
+	`
+	// execute update exam attempt draft
+	for _, answer := range draft.Answers {
+		_, err := tx.ExecContext(ctx, query, draft.AttemptId, answer.Type, answer.Id, string(answer.Answer), draft.Locale, draft.SavedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (db *DB) QueryExamAttemptDraft(attemptId string) (*ExamAttemptDraft, error) {
+	return db.QueryExamAttemptDraftContext(context.Background(), attemptId)
+}
+
+func (db *DB) QueryExamAttemptDraftContext(ctx context.Context, attemptId string) (*ExamAttemptDraft, error) {
+	// query exam attempt draft sql
+	query := `
+	SELECT question_type, question_id, answer, locale, saved_at
+	FROM exam_attempt_drafts
+	WHERE attempt_id = ?
+	ORDER BY saved_at, question_type, question_id
+	`
+	// execute query exam attempt draft
+	rows, err := db.sqliteDB.QueryContext(ctx, query, attemptId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch saved answers, latest save gives locale of draft
+	draft := &ExamAttemptDraft{AttemptId: attemptId, Answers: []ExamAnswer{}}
+	for rows.Next() {
+		var answer ExamAnswer
+		var text string
+		var savedAt time.Time
+		if err := rows.Scan(&answer.Type, &answer.Id, &text, &draft.Locale, &savedAt); err != nil {
+			return nil, err
+		}
+		answer.Answer = json.RawMessage(text)
+		draft.Answers = append(draft.Answers, answer)
+		draft.SavedAt = &savedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+func (db *DB) createIRTParameterTable(sql string) error {
+	// create question irt parameter table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create question irt parameter table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) UpdateIRTParameters(parameters []IRTParameters) error {
+	return db.UpdateIRTParametersContext(context.Background(), parameters)
+}
+
+func (db *DB) UpdateIRTParametersContext(ctx context.Context, parameters []IRTParameters) error {
+	tx, err := db.sqliteDB.BeginTxContext(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	// update question irt parameters sql, recalibration replaces parameters of the same model
+	query := `
+	INSERT INTO question_irt_parameters (question_type, question_id, model, discrimination, difficulty, guessing, responses, calibrated_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT (question_type, question_id, model) DO UPDATE SET discrimination = excluded.discrimination, difficulty = excluded.difficulty,
+	guessing = excluded.guessing, responses = excluded.responses, calibrated_at = excluded.calibrated_at
+	`
+	// execute update question irt parameters
+	for _, v := range parameters {
+		if _, err := tx.ExecContext(ctx, query, v.Type, v.Id, v.Model, v.Discrimination, v.Difficulty, v.Guessing, v.Responses, v.CalibratedAt); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (db *DB) QueryIRTParameters(model string) ([]*IRTParameters, error) {
+	return db.QueryIRTParametersContext(context.Background(), model, "", "")
+}
+
+func (db *DB) QueryQuestionIRTParameters(questionType string, id string) ([]*IRTParameters, error) {
+	return db.QueryIRTParametersContext(context.Background(), "", questionType, id)
+}
+
+func (db *DB) QueryIRTParametersContext(ctx context.Context, model string, questionType string, id string) ([]*IRTParameters, error) {
+	// query question irt parameters sql, empty filters match every model or question
+	query := `
+	SELECT question_type, question_id, model, discrimination, difficulty, guessing, responses, calibrated_at
+	FROM question_irt_parameters
+	WHERE (? = '' OR model = ?) AND (? = '' OR (question_type = ? AND question_id = ?))
+	ORDER BY question_type, question_id, model
+	`
+	// execute query question irt parameters
+	rows, err := db.sqliteDB.QueryContext(ctx, query, model, model, id, questionType, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch question irt parameters from database
+	var parameters []*IRTParameters
+	for rows.Next() {
+		v := &IRTParameters{}
+		if err := rows.Scan(&v.Type, &v.Id, &v.Model, &v.Discrimination, &v.Difficulty, &v.Guessing, &v.Responses, &v.CalibratedAt); err != nil {
+			return nil, err
+		}
+		parameters = append(parameters, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return parameters, nil
+}
+
+func (db *DB) createAdaptiveExamTable(sql string) error {
+	// create adaptive exam table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create adaptive exam table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) CreateAdaptiveExam(exam *AdaptiveExam) error {
+	return db.CreateAdaptiveExamContext(context.Background(), exam)
+}
+
+func (db *DB) CreateAdaptiveExamContext(ctx context.Context, exam *AdaptiveExam) error {
+	// marshal adaptive exam question pool
+	questions, err := json.Marshal(exam.Questions)
+	if err != nil {
+		return err
+	}
+	// create adaptive exam sql
+	query := `
+	INSERT INTO adaptive_exams (exam_id, title, model, questions, max_length, standard_error, created_by, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	// execute create adaptive exam
+	_, err = db.sqliteDB.ExecContext(ctx, query, exam.Id, exam.Title, exam.Model, string(questions), exam.MaxLength, exam.StandardError,
+		exam.CreatedBy, exam.CreatedAt)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) {
+			if sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY {
+				return errAdaptiveExamExisted
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func (db *DB) QueryAdaptiveExam(examId string) (*AdaptiveExam, error) {
+	return db.QueryAdaptiveExamContext(context.Background(), examId)
+}
+
+func (db *DB) QueryAdaptiveExamContext(ctx context.Context, examId string) (*AdaptiveExam, error) {
+	// query adaptive exam sql
+	query := `
+	SELECT exam_id, title, model, questions, max_length, standard_error, created_by, created_at
+	FROM adaptive_exams WHERE exam_id = ?
+	`
+	// execute query adaptive exam
+	var questions string
+	exam := &AdaptiveExam{}
+	err := db.sqliteDB.QueryRowContext(ctx, query, examId).Scan(&exam.Id, &exam.Title, &exam.Model, &questions, &exam.MaxLength,
+		&exam.StandardError, &exam.CreatedBy, &exam.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errAdaptiveExamNotFound
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(questions), &exam.Questions); err != nil {
+		return nil, err
+	}
+	return exam, nil
+}
+
+func (db *DB) createAdaptiveSessionTable(sql string) error {
+	// create adaptive session table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create adaptive session table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) CreateAdaptiveSession(session *AdaptiveSession) error {
+	return db.CreateAdaptiveSessionContext(context.Background(), session)
+}
+
+func (db *DB) CreateAdaptiveSessionContext(ctx context.Context, session *AdaptiveSession) error {
+	// marshal answered items
+	items, err := json.Marshal(session.Items)
+	if err != nil {
+		return err
+	}
+	next := ExamAttemptQuestion{}
+	if session.Question != nil {
+		next = *session.Question
+	}
+	// create adaptive session sql
+	query := `
+	INSERT INTO adaptive_sessions (session_id, exam_id, user_id, state, ability, standard_error, items, next_type, next_id, stop_reason, created_at, finished_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	// execute create adaptive session
+	_, err = db.sqliteDB.ExecContext(ctx, query, session.SessionId, session.ExamId, session.UserId, session.State, session.Ability,
+		session.StandardError, string(items), next.Type, next.Id, session.StopReason, session.CreatedAt, session.FinishedAt)
+	return err
+}
+
+func (db *DB) UpdateAdaptiveSession(session *AdaptiveSession, current ExamAttemptQuestion) (bool, error) {
+	return db.UpdateAdaptiveSessionContext(context.Background(), session, current)
+}
+
+func (db *DB) UpdateAdaptiveSessionContext(ctx context.Context, session *AdaptiveSession, current ExamAttemptQuestion) (bool, error) {
+	// marshal answered items
+	items, err := json.Marshal(session.Items)
+	if err != nil {
+		return false, err
+	}
+	next := ExamAttemptQuestion{}
+	if session.Question != nil {
+		next = *session.Question
+	}
+	// update adaptive session sql, only session still waiting for answer of current question is updated
+	query := `
+	UPDATE adaptive_sessions SET state = ?, ability = ?, standard_error = ?, items = ?, next_type = ?, next_id = ?, stop_reason = ?, finished_at = ?
+	WHERE session_id = ? AND state = ? AND next_type = ? AND next_id = ?
+	`
+	// execute update adaptive session
+	result, err := db.sqliteDB.ExecContext(ctx, query, session.State, session.Ability, session.StandardError, string(items), next.Type, next.Id,
+		session.StopReason, session.FinishedAt, session.SessionId, AdaptiveSessionStateOpen, current.Type, current.Id)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (db *DB) QueryAdaptiveSession(sessionId string) (*AdaptiveSession, error) {
+	return db.QueryAdaptiveSessionContext(context.Background(), sessionId)
+}
+
+func (db *DB) QueryAdaptiveSessionContext(ctx context.Context, sessionId string) (*AdaptiveSession, error) {
+	// query adaptive session sql
+	query := `
+	SELECT session_id, exam_id, user_id, state, ability, standard_error, items, next_type, next_id, stop_reason, created_at, finished_at
+	FROM adaptive_sessions WHERE session_id = ?
+	`
+	// execute query adaptive session
+	session, err := scanAdaptiveSession(db.sqliteDB.QueryRowContext(ctx, query, sessionId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errAdaptiveSessionNotFound
+	}
+	return session, err
+}
+
+func (db *DB) QueryAdaptiveSessions() ([]*AdaptiveSession, error) {
+	return db.QueryAdaptiveSessionsContext(context.Background())
+}
+
+func (db *DB) QueryAdaptiveSessionsContext(ctx context.Context) ([]*AdaptiveSession, error) {
+	// query adaptive sessions sql
+	query := `
+	SELECT session_id, exam_id, user_id, state, ability, standard_error, items, next_type, next_id, stop_reason, created_at, finished_at
+	FROM adaptive_sessions ORDER BY created_at
+	`
+	// execute query adaptive sessions
+	rows, err := db.sqliteDB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch adaptive sessions from database
+	var sessions []*AdaptiveSession
+	for rows.Next() {
+		session, err := scanAdaptiveSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func scanAdaptiveSession(row interface{ Scan(...any) error }) (*AdaptiveSession, error) {
+	// scan adaptive session & unmarshal answered items
+	var items, nextType, nextId string
+	var finishedAt sql.NullTime
+	session := &AdaptiveSession{}
+	err := row.Scan(&session.SessionId, &session.ExamId, &session.UserId, &session.State, &session.Ability, &session.StandardError, &items,
+		&nextType, &nextId, &session.StopReason, &session.CreatedAt, &finishedAt)
+	if err != nil {
+		return nil, err
+	}
+	if finishedAt.Valid {
+		session.FinishedAt = &finishedAt.Time
+	}
+	if nextId != "" {
+		session.Question = &ExamAttemptQuestion{Type: nextType, Id: nextId}
+	}
+	if err := json.Unmarshal([]byte(items), &session.Items); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (db *DB) createPracticeCardTable(sql string) error {
+	// create practice card table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create practice card table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) createPracticeReviewTable(sql string) error {
+	// create practice review table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create practice review table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) UpdatePracticeCard(card *PracticeCard, answer json.RawMessage, correct bool, rating string) error {
+	return db.UpdatePracticeCardContext(context.Background(), card, answer, correct, rating)
+}
+
+func (db *DB) UpdatePracticeCardContext(ctx context.Context, card *PracticeCard, answer json.RawMessage, correct bool, rating string) error {
+	tx, err := db.sqliteDB.BeginTxContext(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	// update practice card sql, card keeps memory state of latest review
+	query := `
+	INSERT INTO practice_cards (user_id, question_type, question_id, ease, interval, repetitions, lapses, reviews, due_at, reviewed_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT (user_id, question_type, question_id) DO UPDATE SET ease = excluded.ease, interval = excluded.interval,
+	repetitions = excluded.repetitions, lapses = excluded.lapses, reviews = excluded.reviews, due_at = excluded.due_at,
+	reviewed_at = excluded.reviewed_at
+	`
+	// execute update practice card
+	if _, err := tx.ExecContext(ctx, query, card.UserId, card.Type, card.Id, card.Ease, card.Interval, card.Repetitions, card.Lapses,
+		card.Reviews, card.DueAt, card.ReviewedAt); err != nil {
+		return err
+	}
+	// insert practice review sql
+	query = `
+	INSERT INTO practice_reviews (user_id, question_type, question_id, answer, correct, rating, interval, ease, reviewed_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	// execute insert practice review
+	if _, err := tx.ExecContext(ctx, query, card.UserId, card.Type, card.Id, string(answer), correct, rating, card.Interval, card.Ease,
+		card.ReviewedAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (db *DB) QueryPracticeCard(userId string, questionType string, id string) (*PracticeCard, error) {
+	return db.QueryPracticeCardContext(context.Background(), userId, questionType, id)
+}
+
+func (db *DB) QueryPracticeCardContext(ctx context.Context, userId string, questionType string, id string) (*PracticeCard, error) {
+	// query practice card sql
+	query := `
+	SELECT user_id, question_type, question_id, ease, interval, repetitions, lapses, reviews, due_at, reviewed_at
+	FROM practice_cards WHERE user_id = ? AND question_type = ? AND question_id = ?
+	`
+	// execute query practice card
+	card, err := scanPracticeCard(db.sqliteDB.QueryRowContext(ctx, query, userId, questionType, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errPracticeCardNotFound
+	}
+	return card, err
+}
+
+func (db *DB) QueryPracticeCards(userId string) ([]*PracticeCard, error) {
+	return db.QueryPracticeCardsContext(context.Background(), userId)
+}
+
+func (db *DB) QueryPracticeCardsContext(ctx context.Context, userId string) ([]*PracticeCard, error) {
+	// query practice cards sql, most overdue first
+	query := `
+	SELECT user_id, question_type, question_id, ease, interval, repetitions, lapses, reviews, due_at, reviewed_at
+	FROM practice_cards WHERE user_id = ? ORDER BY due_at, question_type, question_id
+	`
+	// execute query practice cards
+	rows, err := db.sqliteDB.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch practice cards from database
+	var cards []*PracticeCard
+	for rows.Next() {
+		card, err := scanPracticeCard(rows)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, card)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
+
+func scanPracticeCard(row interface{ Scan(...any) error }) (*PracticeCard, error) {
+	// scan practice card
+	var reviewedAt time.Time
+	card := &PracticeCard{}
+	err := row.Scan(&card.UserId, &card.Type, &card.Id, &card.Ease, &card.Interval, &card.Repetitions, &card.Lapses, &card.Reviews,
+		&card.DueAt, &reviewedAt)
+	if err != nil {
+		return nil, err
+	}
+	card.ReviewedAt = &reviewedAt
+	return card, nil
+}
+
+func (db *DB) createGroupTable(sql string) error {
+	// create user group table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create user group table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) createGroupMemberTable(sql string) error {
+	// create user group member table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create user group member table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) createExamAssignmentTable(sql string) error {
+	// create exam assignment table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create exam assignment table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) CreateGroup(group *Group) error {
+	return db.CreateGroupContext(context.Background(), group)
+}
+
+func (db *DB) CreateGroupContext(ctx context.Context, group *Group) error {
+	// create user group sql
+	query := `
+	INSERT INTO user_groups (group_id, name, kind, description, created_by, created_at) VALUES (?, ?, ?, ?, ?, ?)
+	`
+	// execute create user group
+	_, err := db.sqliteDB.ExecContext(ctx, query, group.Id, group.Name, group.Kind, group.Description, group.CreatedBy, group.CreatedAt)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) {
+			if sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY {
+				return errGroupExisted
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func (db *DB) QueryGroup(groupId string) (*Group, error) {
+	return db.QueryGroupContext(context.Background(), groupId)
+}
+
+func (db *DB) QueryGroupContext(ctx context.Context, groupId string) (*Group, error) {
+	// query user group with member count sql
+	query := `
+	SELECT g.group_id, g.name, g.kind, g.description, (SELECT COUNT(*) FROM user_group_members m WHERE m.group_id = g.group_id),
+	g.created_by, g.created_at
+	FROM user_groups g WHERE g.group_id = ?
+	`
+	// execute query user group
+	group, err := scanGroup(db.sqliteDB.QueryRowContext(ctx, query, groupId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errGroupNotFound
+	}
+	return group, err
+}
+
+func (db *DB) QueryGroups(scope string, userId string) ([]*Group, error) {
+	return db.QueryGroupsContext(context.Background(), scope, userId)
+}
+
+func (db *DB) QueryGroupsContext(ctx context.Context, scope string, userId string) ([]*Group, error) {
+	// query user groups of tenant scope sql, empty userId queries every group instead of groups of user
+	query := `
+	SELECT g.group_id, g.name, g.kind, g.description, (SELECT COUNT(*) FROM user_group_members m WHERE m.group_id = g.group_id),
+	g.created_by, g.created_at
+	FROM user_groups g
+	WHERE (? = '' OR EXISTS (SELECT 1 FROM user_group_members m WHERE m.group_id = g.group_id AND m.user_id = ?))
+	AND ` + tenantScopeCondition("g.group_id") + `
+	ORDER BY g.name, g.group_id
+	`
+	// execute query user groups
+	rows, err := db.sqliteDB.QueryContext(ctx, query, userId, userId, scope, TenantResourceGroup, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch user groups from database
+	var groups []*Group
+	for rows.Next() {
+		group, err := scanGroup(rows)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func scanGroup(row interface{ Scan(...any) error }) (*Group, error) {
+	// scan user group
+	group := &Group{}
+	if err := row.Scan(&group.Id, &group.Name, &group.Kind, &group.Description, &group.Members, &group.CreatedBy, &group.CreatedAt); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+func (db *DB) DeleteGroup(groupId string) error {
+	return db.DeleteGroupContext(context.Background(), groupId)
+}
+
+func (db *DB) DeleteGroupContext(ctx context.Context, groupId string) error {
+	tx, err := db.sqliteDB.BeginTxContext(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	// delete user group sql, members & exam assignments go with group
+	result, err := tx.ExecContext(ctx, `DELETE FROM user_groups WHERE group_id = ?`, groupId)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return errGroupNotFound
+	}
+	for _, query := range []string{`DELETE FROM user_group_members WHERE group_id = ?`, `DELETE FROM exam_assignments WHERE group_id = ?`} {
+		if _, err := tx.ExecContext(ctx, query, groupId); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (db *DB) UpdateGroupMembers(groupId string, userIds []string, replace bool, addedAt time.Time) (int, int, error) {
+	return db.UpdateGroupMembersContext(context.Background(), groupId, userIds, replace, addedAt)
+}
+
+func (db *DB) UpdateGroupMembersContext(ctx context.Context, groupId string, userIds []string, replace bool, addedAt time.Time) (int, int, error) {
+	tx, err := db.sqliteDB.BeginTxContext(ctx, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+	// replacing membership removes users not listed
+	removed := 0
+	if replace {
+		listed, err := json.Marshal(userIds)
+		if err != nil {
+			return 0, 0, err
+		}
+		query := `
+		DELETE FROM user_group_members WHERE group_id = ? AND user_id NOT IN (SELECT value FROM json_each(?))
+		`
+		result, err := tx.ExecContext(ctx, query, groupId, string(listed))
+		if err != nil {
+			return 0, 0, err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return 0, 0, err
+		}
+		removed = int(n)
+	}
+	// insert user group member sql, members already in group keep time they were added
+	query := `
+	INSERT INTO user_group_members (group_id, user_id, added_at) VALUES (?, ?, ?) ON CONFLICT (group_id, user_id) DO NOTHING
+	`
+	added := 0
+	for _, userId := range userIds {
+		result, err := tx.ExecContext(ctx, query, groupId, userId, addedAt)
+		if err != nil {
+			return 0, 0, err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return 0, 0, err
+		}
+		added += int(n)
+	}
+	return added, removed, tx.Commit()
+}
+
+func (db *DB) DeleteGroupMember(groupId string, userId string) (bool, error) {
+	return db.DeleteGroupMemberContext(context.Background(), groupId, userId)
+}
+
+func (db *DB) DeleteGroupMemberContext(ctx context.Context, groupId string, userId string) (bool, error) {
+	// delete user group member sql
+	query := `
+	DELETE FROM user_group_members WHERE group_id = ? AND user_id = ?
+	`
+	// execute delete user group member
+	result, err := db.sqliteDB.ExecContext(ctx, query, groupId, userId)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+func (db *DB) QueryGroupMembers(groupId string) ([]*GroupMember, error) {
+	return db.QueryGroupMembersContext(context.Background(), groupId)
+}
+
+func (db *DB) QueryGroupMembersContext(ctx context.Context, groupId string) ([]*GroupMember, error) {
+	// query user group members sql
+	query := `
+	SELECT user_id, added_at FROM user_group_members WHERE group_id = ? ORDER BY added_at, user_id
+	`
+	// execute query user group members
+	rows, err := db.sqliteDB.QueryContext(ctx, query, groupId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch user group members from database
+	var members []*GroupMember
+	for rows.Next() {
+		member := &GroupMember{}
+		if err := rows.Scan(&member.UserId, &member.AddedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (db *DB) UpdateExamAssignment(assignment *ExamAssignment) error {
+	return db.UpdateExamAssignmentContext(context.Background(), assignment)
+}
+
+func (db *DB) UpdateExamAssignmentContext(ctx context.Context, assignment *ExamAssignment) error {
+	// update exam assignment sql, assigning again changes due time
+	query := `
+	INSERT INTO exam_assignments (group_id, exam_id, due_at, assigned_by, assigned_at) VALUES (?, ?, ?, ?, ?)
+	ON CONFLICT (group_id, exam_id) DO UPDATE SET due_at = excluded.due_at, assigned_by = excluded.assigned_by,
+	assigned_at = excluded.assigned_at
+	`
+	// execute update exam assignment
+	if _, err := db.sqliteDB.ExecContext(ctx, query, assignment.GroupId, assignment.ExamId, assignment.DueAt, assignment.AssignedBy,
+		assignment.AssignedAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) DeleteExamAssignment(groupId string, examId string) (bool, error) {
+	return db.DeleteExamAssignmentContext(context.Background(), groupId, examId)
+}
+
+func (db *DB) DeleteExamAssignmentContext(ctx context.Context, groupId string, examId string) (bool, error) {
+	// delete exam assignment sql
+	query := `
+	DELETE FROM exam_assignments WHERE group_id = ? AND exam_id = ?
+	`
+	// execute delete exam assignment
+	result, err := db.sqliteDB.ExecContext(ctx, query, groupId, examId)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+func (db *DB) QueryExamAssignments(groupId string, examId string) ([]*ExamAssignment, error) {
+	return db.QueryExamAssignmentsContext(context.Background(), tenantScopeAll, groupId, examId, "")
+}
+
+func (db *DB) QueryUserExamAssignments(scope string, userId string, examId string) ([]*ExamAssignment, error) {
+	return db.QueryExamAssignmentsContext(context.Background(), scope, "", examId, userId)
+}
+
+func (db *DB) QueryExamAssignmentsContext(ctx context.Context, scope string, groupId string, examId string, userId string) ([]*ExamAssignment, error) {
+	// query exam assignments of groups in tenant scope sql, empty filters match every group, exam or user
+	query := `
+	SELECT a.group_id, a.exam_id, a.due_at, a.assigned_by, a.assigned_at
+	FROM exam_assignments a
+	WHERE (? = '' OR a.group_id = ?) AND (? = '' OR a.exam_id = ?)
+	AND (? = '' OR EXISTS (SELECT 1 FROM user_group_members m WHERE m.group_id = a.group_id AND m.user_id = ?))
+	AND ` + tenantScopeCondition("a.group_id") + `
+	ORDER BY a.assigned_at, a.group_id, a.exam_id
+	`
+	// execute query exam assignments
+	rows, err := db.sqliteDB.QueryContext(ctx, query, groupId, groupId, examId, examId, userId, userId, scope, TenantResourceGroup, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch exam assignments from database
+	var assignments []*ExamAssignment
+	for rows.Next() {
+		var dueAt sql.NullTime
+		assignment := &ExamAssignment{}
+		if err := rows.Scan(&assignment.GroupId, &assignment.ExamId, &dueAt, &assignment.AssignedBy, &assignment.AssignedAt); err != nil {
+			return nil, err
+		}
+		if dueAt.Valid {
+			assignment.DueAt = &dueAt.Time
+		}
+		assignments = append(assignments, assignment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+func (db *DB) createTenantTable(sql string) error {
+	// create tenant table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create tenant table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) createTenantResourceTable(sql string) error {
+	// create tenant resource table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create tenant resource table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) CreateTenant(tenant *Tenant) error {
+	return db.CreateTenantContext(context.Background(), tenant)
+}
+
+func (db *DB) CreateTenantContext(ctx context.Context, tenant *Tenant) error {
+	// create tenant sql
+	query := `
+	INSERT INTO tenants (tenant_id, name, created_by, created_at) VALUES (?, ?, ?, ?)
+	`
+	// execute create tenant
+	_, err := db.sqliteDB.ExecContext(ctx, query, tenant.Id, tenant.Name, tenant.CreatedBy, tenant.CreatedAt)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) {
+			if sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY {
+				return errTenantExisted
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func (db *DB) QueryTenant(tenantId string) (*Tenant, error) {
+	tenants, err := db.QueryTenantsContext(context.Background(), tenantId)
+	if err != nil {
+		return nil, err
+	}
+	if len(tenants) == 0 {
+		return nil, errTenantNotFound
+	}
+	return tenants[0], nil
+}
+
+func (db *DB) QueryTenants() ([]*Tenant, error) {
+	return db.QueryTenantsContext(context.Background(), "")
+}
+
+func (db *DB) QueryTenantsContext(ctx context.Context, tenantId string) ([]*Tenant, error) {
+	// query tenants with user count sql, empty tenantId matches every tenant
+	query := `
+	SELECT t.tenant_id, t.name, t.created_by, t.created_at,
+	(SELECT COUNT(*) FROM tenant_resources r WHERE r.tenant_id = t.tenant_id AND r.kind = ?)
+	FROM tenants t
+	WHERE ? = '' OR t.tenant_id = ?
+	ORDER BY t.created_at, t.tenant_id
+	`
+	// execute query tenants
+	rows, err := db.sqliteDB.QueryContext(ctx, query, TenantResourceUser, tenantId, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch tenants from database
+	var tenants []*Tenant
+	for rows.Next() {
+		tenant := &Tenant{}
+		if err := rows.Scan(&tenant.Id, &tenant.Name, &tenant.CreatedBy, &tenant.CreatedAt, &tenant.Users); err != nil {
+			return nil, err
+		}
+		tenants = append(tenants, tenant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tenants, nil
+}
+
+func (db *DB) UpdateTenantResource(resource *TenantResource) error {
+	return db.UpdateTenantResourceContext(context.Background(), resource)
+}
+
+func (db *DB) UpdateTenantResourceContext(ctx context.Context, resource *TenantResource) error {
+	// update tenant of resource sql
+	query := `
+	INSERT INTO tenant_resources (kind, resource_id, tenant_id) VALUES (?, ?, ?)
+	ON CONFLICT (kind, resource_id) DO UPDATE SET tenant_id = excluded.tenant_id
+	`
+	// execute update tenant of resource
+	if _, err := db.sqliteDB.ExecContext(ctx, query, resource.Kind, resource.Id, resource.TenantId); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) DeleteTenantResource(kind string, resourceId string) (bool, error) {
+	return db.DeleteTenantResourceContext(context.Background(), kind, resourceId)
+}
+
+func (db *DB) DeleteTenantResourceContext(ctx context.Context, kind string, resourceId string) (bool, error) {
+	// delete tenant of resource sql
+	query := `
+	DELETE FROM tenant_resources WHERE kind = ? AND resource_id = ?
+	`
+	// execute delete tenant of resource
+	result, err := db.sqliteDB.ExecContext(ctx, query, kind, resourceId)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+func tenantScopeCondition(column string) string {
+	// resource named by column is in tenant scope, binds scope, kind & scope, resources outside tenants have no row
+	return `(? = '` + tenantScopeAll + `' OR COALESCE((SELECT r.tenant_id FROM tenant_resources r WHERE r.kind = ? AND r.resource_id = ` + column + `), '') = ?)`
+}
+
+func (db *DB) QueryTenantResources(tenantId string, kind string) ([]*TenantResource, error) {
+	return db.QueryTenantResourcesContext(context.Background(), tenantId, kind)
+}
+
+func (db *DB) QueryTenantResourcesContext(ctx context.Context, tenantId string, kind string) ([]*TenantResource, error) {
+	// query resources of tenant sql, empty filters match every tenant or kind
+	query := `
+	SELECT kind, resource_id, tenant_id FROM tenant_resources
+	WHERE (? = '' OR tenant_id = ?) AND (? = '' OR kind = ?)
+	ORDER BY tenant_id, kind, resource_id
+	`
+	// execute query resources of tenant
+	rows, err := db.sqliteDB.QueryContext(ctx, query, tenantId, tenantId, kind, kind)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// fetch resources of tenant from database
+	var resources []*TenantResource
+	for rows.Next() {
+		resource := &TenantResource{}
+		if err := rows.Scan(&resource.Kind, &resource.Id, &resource.TenantId); err != nil {
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+func (db *DB) createUserInvitationTable(sql string) error {
+	// create user invitation table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create user invitation table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) CreateUserInvitation(tokenHash string, invitation *UserInvitation) error {
+	return db.CreateUserInvitationContext(context.Background(), tokenHash, invitation)
+}
+
+func (db *DB) CreateUserInvitationContext(ctx context.Context, tokenHash string, invitation *UserInvitation) error {
+	// create user invitation sql
+	query := `
+	INSERT INTO user_invitations (token_hash, user_id, created_at, expires_at)
+	VALUES (?, ?, ?, ?)
+	`
+	// execute create user invitation
+	if _, err := db.sqliteDB.ExecContext(ctx, query, tokenHash, invitation.UserId, invitation.CreatedAt, invitation.ExpiresAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) QueryUserInvitation(tokenHash string) (*UserInvitation, error) {
+	return db.QueryUserInvitationContext(context.Background(), tokenHash)
+}
+
+func (db *DB) QueryUserInvitationContext(ctx context.Context, tokenHash string) (*UserInvitation, error) {
+	// query user invitation sql
+	query := `
+	SELECT user_id, created_at, expires_at
+	FROM user_invitations WHERE token_hash = ?
+	`
+	// execute query user invitation
+	row := db.sqliteDB.QueryRowContext(ctx, query, tokenHash)
+	invitation := &UserInvitation{}
+	err := row.Scan(&invitation.UserId, &invitation.CreatedAt, &invitation.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errUserInvitationNotFound
+		}
+		return nil, err
+	}
+	return invitation, nil
+}
+
+func (db *DB) DeleteUserInvitation(tokenHash string) (bool, error) {
+	return db.DeleteUserInvitationContext(context.Background(), tokenHash)
+}
+
+func (db *DB) DeleteUserInvitationContext(ctx context.Context, tokenHash string) (bool, error) {
+	// delete user invitation sql, invitation is used once
+	query := `DELETE FROM user_invitations WHERE token_hash = ?`
+	// execute delete user invitation
+	result, err := db.sqliteDB.ExecContext(ctx, query, tokenHash)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+func (db *DB) createUserTokenTable(sql string) error {
+	// create user token table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create user token table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) CreateUserToken(tokenHash string, token *UserToken) error {
+	return db.CreateUserTokenContext(context.Background(), tokenHash, token)
+}
+
+func (db *DB) CreateUserTokenContext(ctx context.Context, tokenHash string, token *UserToken) error {
+	// create user token sql
+	query := `
+	INSERT INTO user_tokens (token_hash, user_id, purpose, email, created_at, expires_at)
+	VALUES (?, ?, ?, ?, ?, ?)
+	`
+	// execute create user token
+	if _, err := db.sqliteDB.ExecContext(ctx, query, tokenHash, token.UserId, token.Purpose, token.Email, token.CreatedAt, token.ExpiresAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) QueryUserToken(tokenHash string, purpose string) (*UserToken, error) {
+	return db.QueryUserTokenContext(context.Background(), tokenHash, purpose)
+}
+
+func (db *DB) QueryUserTokenContext(ctx context.Context, tokenHash string, purpose string) (*UserToken, error) {
+	// query user token sql, token of other purpose is not found
+	query := `
+	SELECT user_id, purpose, email, created_at, expires_at
+	FROM user_tokens WHERE token_hash = ? AND purpose = ?
+	`
+	// execute query user token
+	row := db.sqliteDB.QueryRowContext(ctx, query, tokenHash, purpose)
+	token := &UserToken{}
+	err := row.Scan(&token.UserId, &token.Purpose, &token.Email, &token.CreatedAt, &token.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errUserTokenNotFound
+		}
+		return nil, err
+	}
+	return token, nil
+}
+
+func (db *DB) DeleteUserToken(tokenHash string) (bool, error) {
+	return db.DeleteUserTokenContext(context.Background(), tokenHash)
+}
+
+func (db *DB) DeleteUserTokenContext(ctx context.Context, tokenHash string) (bool, error) {
+	// delete user token sql, token is used once
+	query := `DELETE FROM user_tokens WHERE token_hash = ?`
+	// execute delete user token
+	result, err := db.sqliteDB.ExecContext(ctx, query, tokenHash)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+func (db *DB) DeleteUserTokens(userId string, purpose string) error {
+	return db.DeleteUserTokensContext(context.Background(), userId, purpose)
+}
+
+func (db *DB) DeleteUserTokensContext(ctx context.Context, userId string, purpose string) error {
+	// delete user tokens of purpose sql
+	query := `DELETE FROM user_tokens WHERE user_id = ? AND purpose = ?`
+	// execute delete user tokens
+	if _, err := db.sqliteDB.ExecContext(ctx, query, userId, purpose); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) createUserEmailVerificationTable(sql string) error {
+	// create user email verification table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create user email verification table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) UpdateUserEmailVerification(verification *UserEmailVerification) error {
+	return db.UpdateUserEmailVerificationContext(context.Background(), verification)
+}
+
+func (db *DB) UpdateUserEmailVerificationContext(ctx context.Context, verification *UserEmailVerification) error {
+	// update verified email of user sql
+	query := `
+	INSERT INTO user_email_verifications (user_id, email, verified_at) VALUES (?, ?, ?)
+	ON CONFLICT (user_id) DO UPDATE SET email = excluded.email, verified_at = excluded.verified_at
+	`
+	// execute update verified email of user
+	if _, err := db.sqliteDB.ExecContext(ctx, query, verification.UserId, verification.Email, verification.VerifiedAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) QueryUserEmailVerification(userId string) (*UserEmailVerification, error) {
+	return db.QueryUserEmailVerificationContext(context.Background(), userId)
+}
+
+func (db *DB) QueryUserEmailVerificationContext(ctx context.Context, userId string) (*UserEmailVerification, error) {
+	// query verified email of user sql, user never verified has no email
+	query := `
+	SELECT email, verified_at FROM user_email_verifications WHERE user_id = ?
+	`
+	// execute query verified email of user
+	verification := &UserEmailVerification{UserId: userId}
+	var verifiedAt time.Time
+	err := db.sqliteDB.QueryRowContext(ctx, query, userId).Scan(&verification.Email, &verifiedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return verification, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	verification.Verified, verification.VerifiedAt = true, &verifiedAt
+	return verification, nil
+}
+
+func (db *DB) createUserTwoFactorTable(sql string) error {
+	// create user two factor table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create user two factor table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) UpdateUserTwoFactor(twoFactor *UserTwoFactor) error {
+	return db.UpdateUserTwoFactorContext(context.Background(), twoFactor)
+}
+
+func (db *DB) UpdateUserTwoFactorContext(ctx context.Context, twoFactor *UserTwoFactor) error {
+	// update two factor of user sql, new secret accepts codes of every time step
+	query := `
+	INSERT INTO user_two_factors (user_id, secret, enabled, last_step, created_at, enabled_at) VALUES (?, ?, ?, 0, ?, ?)
+	ON CONFLICT (user_id) DO UPDATE SET secret = excluded.secret, enabled = excluded.enabled,
+	last_step = CASE WHEN user_two_factors.secret = excluded.secret THEN user_two_factors.last_step ELSE 0 END,
+	created_at = excluded.created_at, enabled_at = excluded.enabled_at
+	`
+	// execute update two factor of user
+	if _, err := db.sqliteDB.ExecContext(ctx, query, twoFactor.UserId, twoFactor.Secret, twoFactor.Enabled, twoFactor.CreatedAt, twoFactor.EnabledAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) QueryUserTwoFactor(userId string) (*UserTwoFactor, error) {
+	return db.QueryUserTwoFactorContext(context.Background(), userId)
+}
+
+func (db *DB) QueryUserTwoFactorContext(ctx context.Context, userId string) (*UserTwoFactor, error) {
+	// query two factor of user sql
+	query := `
+	SELECT secret, enabled, created_at, enabled_at,
+	(SELECT COUNT(*) FROM user_recovery_codes WHERE user_recovery_codes.user_id = user_two_factors.user_id)
+	FROM user_two_factors WHERE user_id = ?
+	`
+	// execute query two factor of user
+	twoFactor := &UserTwoFactor{UserId: userId}
+	var enabledAt sql.NullTime
+	err := db.sqliteDB.QueryRowContext(ctx, query, userId).Scan(&twoFactor.Secret, &twoFactor.Enabled, &twoFactor.CreatedAt, &enabledAt, &twoFactor.RecoveryCodes)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errUserTwoFactorNotFound
+		}
+		return nil, err
+	}
+	if enabledAt.Valid {
+		twoFactor.EnabledAt = &enabledAt.Time
+	}
+	return twoFactor, nil
+}
+
+func (db *DB) UpdateUserTwoFactorStep(userId string, step int64) (bool, error) {
+	return db.UpdateUserTwoFactorStepContext(context.Background(), userId, step)
+}
+
+func (db *DB) UpdateUserTwoFactorStepContext(ctx context.Context, userId string, step int64) (bool, error) {
+	// update last used time step sql, code of used or earlier time step is replayed
+	query := `UPDATE user_two_factors SET last_step = ? WHERE user_id = ? AND last_step < ?`
+	// execute update last used time step
+	result, err := db.sqliteDB.ExecContext(ctx, query, step, userId, step)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+func (db *DB) DeleteUserTwoFactor(userId string) error {
+	return db.DeleteUserTwoFactorContext(context.Background(), userId)
+}
+
+func (db *DB) DeleteUserTwoFactorContext(ctx context.Context, userId string) error {
+	// two factor & recovery codes of user are deleted together
+	tx, err := db.sqliteDB.BeginTxContext(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	// delete two factor of user sql
+	query := `DELETE FROM user_two_factors WHERE user_id = ?`
+	if _, err := tx.ExecContext(ctx, query, userId); err != nil {
+		return err
+	}
+	// delete recovery codes of user sql
+	query = `DELETE FROM user_recovery_codes WHERE user_id = ?`
+	if _, err := tx.ExecContext(ctx, query, userId); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (db *DB) createUserRecoveryCodeTable(sql string) error {
+	// create user recovery code table
+	if _, err := db.sqliteDB.Exec(sql); err != nil {
+		return fmt.Errorf("create user recovery code table failed: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) UpdateUserRecoveryCodes(userId string, codeHashes []string) error {
+	return db.UpdateUserRecoveryCodesContext(context.Background(), userId, codeHashes)
+}
+
+func (db *DB) UpdateUserRecoveryCodesContext(ctx context.Context, userId string, codeHashes []string) error {
+	// recovery codes of user are replaced as a whole
+	tx, err := db.sqliteDB.BeginTxContext(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	// delete recovery codes of user sql
+	query := `DELETE FROM user_recovery_codes WHERE user_id = ?`
+	if _, err := tx.ExecContext(ctx, query, userId); err != nil {
+		return err
+	}
+	// create recovery codes of user sql
+	query = `INSERT INTO user_recovery_codes (code_hash, user_id) VALUES (?, ?)`
+	for _, v := range codeHashes {
+		if _, err := tx.ExecContext(ctx, query, v, userId); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (db *DB) DeleteUserRecoveryCode(userId string, codeHash string) (bool, error) {
+	return db.DeleteUserRecoveryCodeContext(context.Background(), userId, codeHash)
+}
+
+func (db *DB) DeleteUserRecoveryCodeContext(ctx context.Context, userId string, codeHash string) (bool, error) {
+	// delete recovery code sql, recovery code is used once
+	query := `DELETE FROM user_recovery_codes WHERE code_hash = ? AND user_id = ?`
+	// execute delete recovery code
+	result, err := db.sqliteDB.ExecContext(ctx, query, codeHash, userId)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
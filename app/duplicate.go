@@ -0,0 +1,492 @@
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"hash/fnv"
+	"net/http"
+	"nova/logger"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	questionDuplicateActionOff    = "off"
+	questionDuplicateActionWarn   = "warn"
+	questionDuplicateActionReject = "reject"
+	questionDuplicateThreshold    = 0.8
+	questionFingerprintShingle    = 4
+	questionFingerprintHashes     = 64
+	questionFingerprintBandRows   = 4
+	// 16 bands of 4 rows find pairs of similarity 0.75 with probability over 99%
+	questionFingerprintBandThreshold = 0.75
+)
+
+// errQuestionDuplicated is returned when duplicate action rejects near-duplicate question
+var errQuestionDuplicated = errors.New("duplicates")
+
+type questionFingerprintSource struct {
+	Type  string
+	Id    string
+	Title string
+	Text  string
+}
+
+func (nova *Nova) HandleQueryQuestionDuplicates(c *gin.Context) {
+	// query duplicate question clusters
+	logger.Infof("handle request query duplicate questions")
+	// extract similarity threshold from query
+	threshold := nova.queryQuestionDuplicateThreshold()
+	if s := c.Query("threshold"); s != "" {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil || v <= 0 || v > 1 {
+			nova.response400BadRequest(c, errors.New("threshold should be a number in (0, 1]"))
+			logger.Errorf("error check threshold is validate: %v", s)
+			return
+		}
+		threshold = v
+	}
+	logger.Debugf("query duplicate questions with threshold: %v", threshold)
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// cluster near-duplicate questions
+	logger.Debugf("cluster duplicate questions in data cache")
+	response := nova.queryQuestionDuplicateClusters(nova.queryTenantScope(c), threshold)
+	logger.Debugf("successfully cluster duplicate questions in data cache")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) queryQuestionDuplicateAction() string {
+	// duplicate detection only warns unless configured otherwise
+	switch nova.conf.Configure.Duplicate.Action {
+	case questionDuplicateActionOff, questionDuplicateActionReject:
+		return nova.conf.Configure.Duplicate.Action
+	default:
+		return questionDuplicateActionWarn
+	}
+}
+
+func (nova *Nova) queryQuestionDuplicateThreshold() float64 {
+	// fall back to default threshold if configure out of range
+	threshold := nova.conf.Configure.Duplicate.Threshold
+	if threshold <= 0 || threshold > 1 {
+		return questionDuplicateThreshold
+	}
+	return threshold
+}
+
+func (nova *Nova) isQuestionDuplicated(scope string, questionType string, id string, text string) (bool, []QuestionDuplicate) {
+	// skip detection if duplicate action is off
+	if nova.queryQuestionDuplicateAction() == questionDuplicateActionOff {
+		return false, nil
+	}
+	// compare fingerprint with all questions of tenant in data cache
+	threshold := nova.queryQuestionDuplicateThreshold()
+	signature := questionFingerprintSignature(text)
+	var duplicates []QuestionDuplicate
+	for _, source := range nova.queryQuestionFingerprintSources(scope) {
+		if source.Type == questionType && source.Id == id {
+			continue
+		}
+		similarity := questionFingerprintSimilarity(signature, nova.queryQuestionFingerprint(source).Signature)
+		if similarity >= threshold {
+			duplicates = append(duplicates, QuestionDuplicate{
+				Type:       source.Type,
+				Id:         source.Id,
+				Title:      source.Title,
+				Similarity: similarity,
+			})
+		}
+	}
+	// most similar question first
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return duplicates[i].Similarity > duplicates[j].Similarity
+	})
+	return len(duplicates) > 0, duplicates
+}
+
+func (nova *Nova) checkQuestionDuplicated(c *gin.Context, questionType string, id string, text string) error {
+	// skip detection if duplicate action is off
+	if nova.queryQuestionDuplicateAction() == questionDuplicateActionOff {
+		return nil
+	}
+	// questions of every type are compared, so every data cache should be up to date
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		return err
+	}
+	// search near-duplicate questions in tenant owning question
+	scope, ok := nova.queryResourceTenant(questionType, id)
+	if !ok {
+		scope = nova.queryTenantOwner(c)
+	}
+	b, duplicates := nova.isQuestionDuplicated(scope, questionType, id, text)
+	if !b {
+		return nil
+	}
+	// reject or warn according to duplicate action
+	if nova.queryQuestionDuplicateAction() == questionDuplicateActionReject {
+		return fmt.Errorf("%v question %w %v question %v (similarity %.2f)", questionType, errQuestionDuplicated, duplicates[0].Type, duplicates[0].Id, duplicates[0].Similarity)
+	}
+	for _, v := range duplicates {
+		c.Writer.Header().Add("Warning", fmt.Sprintf("199 nova \"possible duplicate of %v question %v (similarity %.2f)\"", v.Type, v.Id, v.Similarity))
+	}
+	logger.Warnf("%v question %v possibly duplicates %v questions", questionType, id, len(duplicates))
+	return nil
+}
+
+func (nova *Nova) queryQuestionDuplicateClusters(scope string, threshold float64) []QuestionDuplicateCluster {
+	// fingerprint all questions of tenant in data cache
+	sources := nova.queryQuestionFingerprintSources(scope)
+	signatures := make([][]uint64, len(sources))
+	for k, source := range sources {
+		signatures[k] = nova.queryQuestionFingerprint(source).Signature
+	}
+	if scope == tenantScopeAll {
+		nova.pruneQuestionFingerprints(sources)
+	}
+	// union questions whose estimated similarity reaches threshold
+	parent := make([]int, len(sources))
+	for k := range parent {
+		parent[k] = k
+	}
+	var find func(int) int
+	find = func(k int) int {
+		if parent[k] != k {
+			parent[k] = find(parent[k])
+		}
+		return parent[k]
+	}
+	best := make([]float64, len(sources))
+	union := func(i int, j int) {
+		similarity := questionFingerprintSimilarity(signatures[i], signatures[j])
+		if similarity < threshold {
+			return
+		}
+		parent[find(i)] = find(j)
+		best[i] = max(best[i], similarity)
+		best[j] = max(best[j], similarity)
+	}
+	// bands miss too many pairs below their threshold, compare every pair instead
+	if threshold < questionFingerprintBandThreshold {
+		for i := 0; i < len(sources); i++ {
+			for j := i + 1; j < len(sources); j++ {
+				union(i, j)
+			}
+		}
+	} else {
+		// locality sensitive hashing, questions sharing any band are candidates
+		buckets := make(map[string][]int)
+		for k, signature := range signatures {
+			for band := 0; band < questionFingerprintHashes/questionFingerprintBandRows; band++ {
+				rows := signature[band*questionFingerprintBandRows : (band+1)*questionFingerprintBandRows]
+				key := strconv.Itoa(band)
+				for _, v := range rows {
+					key += ":" + strconv.FormatUint(v, 16)
+				}
+				buckets[key] = append(buckets[key], k)
+			}
+		}
+		visited := make(map[[2]int]bool)
+		for _, bucket := range buckets {
+			for i := 0; i < len(bucket); i++ {
+				for j := i + 1; j < len(bucket); j++ {
+					pair := [2]int{bucket[i], bucket[j]}
+					if visited[pair] {
+						continue
+					}
+					visited[pair] = true
+					union(pair[0], pair[1])
+				}
+			}
+		}
+	}
+	// collect clusters with more than one question
+	groups := make(map[int][]int)
+	for k := range sources {
+		if best[k] > 0 {
+			groups[find(k)] = append(groups[find(k)], k)
+		}
+	}
+	clusters := make([]QuestionDuplicateCluster, 0, len(groups))
+	for _, members := range groups {
+		cluster := QuestionDuplicateCluster{}
+		for _, k := range members {
+			cluster.Similarity = max(cluster.Similarity, best[k])
+			cluster.Questions = append(cluster.Questions, QuestionDuplicate{
+				Type:       sources[k].Type,
+				Id:         sources[k].Id,
+				Title:      sources[k].Title,
+				Similarity: best[k],
+			})
+		}
+		clusters = append(clusters, cluster)
+	}
+	// largest & most similar cluster first
+	sort.Slice(clusters, func(i, j int) bool {
+		if len(clusters[i].Questions) != len(clusters[j].Questions) {
+			return len(clusters[i].Questions) > len(clusters[j].Questions)
+		}
+		if clusters[i].Similarity != clusters[j].Similarity {
+			return clusters[i].Similarity > clusters[j].Similarity
+		}
+		return clusters[i].Questions[0].Id < clusters[j].Questions[0].Id
+	})
+	return clusters
+}
+
+func (nova *Nova) queryQuestionFingerprintSources(scope string) []questionFingerprintSource {
+	var sources []questionFingerprintSource
+	// collect single-choice questions
+	nova.cache.questionsCache.singleChoiceCache.mutex.RLock()
+	for _, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+		sources = append(sources, questionFingerprintSource{QuestionTypeSingleChoice, v.Id, v.Title, singleChoiceFingerprintText(v)})
+	}
+	nova.cache.questionsCache.singleChoiceCache.mutex.RUnlock()
+	// collect multiple-choice questions
+	nova.cache.questionsCache.multipleChoiceCache.mutex.RLock()
+	for _, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+		sources = append(sources, questionFingerprintSource{QuestionTypeMultipleChoice, v.Id, v.Title, multipleChoiceFingerprintText(v)})
+	}
+	nova.cache.questionsCache.multipleChoiceCache.mutex.RUnlock()
+	// collect judgement questions
+	nova.cache.questionsCache.judgementCache.mutex.RLock()
+	for _, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+		sources = append(sources, questionFingerprintSource{QuestionTypeJudgement, v.Id, v.Title, judgementFingerprintText(v)})
+	}
+	nova.cache.questionsCache.judgementCache.mutex.RUnlock()
+	// collect essay questions
+	nova.cache.questionsCache.essayCache.mutex.RLock()
+	for _, v := range nova.cache.questionsCache.essayCache.essaySet {
+		sources = append(sources, questionFingerprintSource{QuestionTypeEssay, v.Id, v.Title, essayFingerprintText(v)})
+	}
+	nova.cache.questionsCache.essayCache.mutex.RUnlock()
+	// collect fill-blank questions
+	nova.cache.questionsCache.fillBlankCache.mutex.RLock()
+	for _, v := range nova.cache.questionsCache.fillBlankCache.fillBlankSet {
+		sources = append(sources, questionFingerprintSource{QuestionTypeFillBlank, v.Id, v.Title, fillBlankFingerprintText(v)})
+	}
+	nova.cache.questionsCache.fillBlankCache.mutex.RUnlock()
+	// collect matching questions
+	nova.cache.questionsCache.matchingCache.mutex.RLock()
+	for _, v := range nova.cache.questionsCache.matchingCache.matchingSet {
+		sources = append(sources, questionFingerprintSource{QuestionTypeMatching, v.Id, v.Title, matchingFingerprintText(v)})
+	}
+	nova.cache.questionsCache.matchingCache.mutex.RUnlock()
+	// collect ordering questions
+	nova.cache.questionsCache.orderingCache.mutex.RLock()
+	for _, v := range nova.cache.questionsCache.orderingCache.orderingSet {
+		sources = append(sources, questionFingerprintSource{QuestionTypeOrdering, v.Id, v.Title, orderingFingerprintText(v)})
+	}
+	nova.cache.questionsCache.orderingCache.mutex.RUnlock()
+	// collect numeric questions
+	nova.cache.questionsCache.numericCache.mutex.RLock()
+	for _, v := range nova.cache.questionsCache.numericCache.numericSet {
+		sources = append(sources, questionFingerprintSource{QuestionTypeNumeric, v.Id, v.Title, numericFingerprintText(v)})
+	}
+	nova.cache.questionsCache.numericCache.mutex.RUnlock()
+	// collect template questions
+	nova.cache.questionsCache.templateCache.mutex.RLock()
+	for _, v := range nova.cache.questionsCache.templateCache.templateSet {
+		sources = append(sources, questionFingerprintSource{QuestionTypeTemplate, v.Id, v.Title, templateFingerprintText(v)})
+	}
+	nova.cache.questionsCache.templateCache.mutex.RUnlock()
+	// collect code questions
+	nova.cache.questionsCache.codeCache.mutex.RLock()
+	for _, v := range nova.cache.questionsCache.codeCache.codeSet {
+		sources = append(sources, questionFingerprintSource{QuestionTypeCode, v.Id, v.Title, codeFingerprintText(v)})
+	}
+	nova.cache.questionsCache.codeCache.mutex.RUnlock()
+	// keep questions in tenant scope
+	if scope == tenantScopeAll {
+		return sources
+	}
+	scoped := sources[:0]
+	for _, source := range sources {
+		if nova.isResourceInScope(scope, source.Type, source.Id) {
+			scoped = append(scoped, source)
+		}
+	}
+	return scoped
+}
+
+func (nova *Nova) queryQuestionFingerprint(source questionFingerprintSource) QuestionFingerprint {
+	// reuse fingerprint if question text unchanged
+	sum := sha256.Sum256([]byte(source.Text))
+	digest := hex.EncodeToString(sum[:])
+	key := source.Type + "/" + source.Id
+	nova.cache.questionsCache.fingerprintCache.mutex.RLock()
+	fingerprint, ok := nova.cache.questionsCache.fingerprintCache.fingerprintSet[key]
+	nova.cache.questionsCache.fingerprintCache.mutex.RUnlock()
+	if ok && fingerprint.Digest == digest {
+		return fingerprint
+	}
+	// calculate & store fingerprint in data cache
+	fingerprint = QuestionFingerprint{
+		Digest:    digest,
+		Signature: questionFingerprintSignature(source.Text),
+	}
+	nova.cache.questionsCache.fingerprintCache.mutex.Lock()
+	defer nova.cache.questionsCache.fingerprintCache.mutex.Unlock()
+	if nova.cache.questionsCache.fingerprintCache.fingerprintSet == nil {
+		nova.cache.questionsCache.fingerprintCache.fingerprintSet = make(map[string]QuestionFingerprint)
+	}
+	nova.cache.questionsCache.fingerprintCache.fingerprintSet[key] = fingerprint
+	return fingerprint
+}
+
+func (nova *Nova) pruneQuestionFingerprints(sources []questionFingerprintSource) {
+	// enable fingerprint cache write lock
+	nova.cache.questionsCache.fingerprintCache.mutex.Lock()
+	defer nova.cache.questionsCache.fingerprintCache.mutex.Unlock()
+	// delete fingerprints of removed questions
+	keys := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		keys[source.Type+"/"+source.Id] = true
+	}
+	for key := range nova.cache.questionsCache.fingerprintCache.fingerprintSet {
+		if !keys[key] {
+			delete(nova.cache.questionsCache.fingerprintCache.fingerprintSet, key)
+		}
+	}
+	return
+}
+
+func singleChoiceFingerprintText(question QuestionSingleChoice) string {
+	return questionFingerprintText(question.Title, question.Answers)
+}
+
+func multipleChoiceFingerprintText(question QuestionMultipleChoice) string {
+	return questionFingerprintText(question.Title, question.Answers)
+}
+
+func judgementFingerprintText(question QuestionJudgement) string {
+	return questionFingerprintText(question.Title, []QuestionAnswer{{AnswerText: strconv.FormatBool(question.StandardAnswer)}})
+}
+
+func essayFingerprintText(question QuestionEssay) string {
+	return questionFingerprintText(question.Title, []QuestionAnswer{{AnswerText: question.StandardAnswer}})
+}
+
+func fillBlankFingerprintText(question QuestionFillBlank) string {
+	answers := make([]QuestionAnswer, 0, len(question.Blanks))
+	for _, blank := range question.Blanks {
+		answers = append(answers, QuestionAnswer{AnswerText: strings.Join(blank.Answers, " ")})
+	}
+	return questionFingerprintText(question.Title, answers)
+}
+
+func questionFingerprintText(title string, answers []QuestionAnswer) string {
+	// answer marks & order should not affect fingerprint
+	texts := make([]string, 0, len(answers))
+	for _, v := range answers {
+		texts = append(texts, normalizeQuestionText(v.AnswerText))
+	}
+	sort.Strings(texts)
+	return strings.TrimSpace(normalizeQuestionText(title) + " " + strings.Join(texts, " "))
+}
+
+func normalizeQuestionText(text string) string {
+	// lower case & replace punctuation with blank
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, text)
+	// collapse blanks
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func questionFingerprintSignature(text string) []uint64 {
+	// split text into character shingles
+	runes := []rune(text)
+	var shingles []string
+	if len(runes) <= questionFingerprintShingle {
+		shingles = append(shingles, text)
+	} else {
+		for k := 0; k+questionFingerprintShingle <= len(runes); k++ {
+			shingles = append(shingles, string(runes[k:k+questionFingerprintShingle]))
+		}
+	}
+	// minimum hash value of every permutation
+	signature := make([]uint64, questionFingerprintHashes)
+	for k := range signature {
+		signature[k] = ^uint64(0)
+	}
+	for _, shingle := range shingles {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(shingle))
+		base := h.Sum64()
+		for k := range signature {
+			if v := questionFingerprintMix(base ^ questionFingerprintMix(uint64(k+1))); v < signature[k] {
+				signature[k] = v
+			}
+		}
+	}
+	return signature
+}
+
+func questionFingerprintSimilarity(a []uint64, b []uint64) float64 {
+	// estimate jaccard similarity by matching minimum hashes
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	n := 0
+	for k := range a {
+		if a[k] == b[k] {
+			n++
+		}
+	}
+	return float64(n) / float64(len(a))
+}
+
+func questionFingerprintMix(v uint64) uint64 {
+	// splitmix64 finalizer
+	v += 0x9e3779b97f4a7c15
+	v = (v ^ (v >> 30)) * 0xbf58476d1ce4e5b9
+	v = (v ^ (v >> 27)) * 0x94d049bb133111eb
+	return v ^ (v >> 31)
+}
+
+func matchingFingerprintText(question QuestionMatching) string {
+	answers := make([]QuestionAnswer, 0, len(question.Pairs))
+	for _, pair := range question.Pairs {
+		answers = append(answers, QuestionAnswer{AnswerText: pair.Left + " " + pair.Right})
+	}
+	return questionFingerprintText(question.Title, answers)
+}
+
+func orderingFingerprintText(question QuestionOrdering) string {
+	answers := make([]QuestionAnswer, 0, len(question.Items))
+	for _, item := range question.Items {
+		answers = append(answers, QuestionAnswer{AnswerText: item})
+	}
+	return questionFingerprintText(question.Title, answers)
+}
+
+func numericFingerprintText(question QuestionNumeric) string {
+	value := strconv.FormatFloat(question.Value, 'g', -1, 64)
+	return questionFingerprintText(question.Title, []QuestionAnswer{{AnswerText: strings.TrimSpace(value + " " + question.Unit)}})
+}
+
+func templateFingerprintText(question QuestionTemplate) string {
+	return questionFingerprintText(question.Title, []QuestionAnswer{{AnswerText: question.Formula}})
+}
+
+func codeFingerprintText(question QuestionCode) string {
+	return questionFingerprintText(question.Title, nil)
+}
@@ -0,0 +1,395 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"nova/utils"
+	"testing"
+)
+
+func setupDuplicateTestRouter(action string) *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// author changing questions
+	createTestAuthor(nova)
+	nova.conf.Configure.Duplicate.Action = action
+	nova.conf.Configure.Duplicate.Threshold = 0.8
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	{
+		/* question management */
+		// question duplicate related
+		novaService.GET("/question/duplicates", nova.HandleQueryQuestionDuplicates)
+		// question related
+		novaService.POST("/question/single-choice/:Id", nova.HandleCreateQuestionSingleChoice)
+		novaService.PUT("/question/single-choice/:Id", nova.HandleUpdateQuestionSingleChoice)
+		novaService.PATCH("/question/single-choice/:Id", nova.HandleModifyQuestionSingleChoice)
+		novaService.POST("/question/multiple-choice/:Id", nova.HandleCreateQuestionMultipleChoice)
+		novaService.POST("/question/judgement/:Id", nova.HandleCreateQuestionJudgement)
+		novaService.POST("/question/essay/:Id", nova.HandleCreateQuestionEssay)
+	}
+	return router
+}
+
+func startDuplicateTestService(action string) (*httptest.Server, *gin.Engine) {
+	router := setupDuplicateTestRouter(action)
+	return httptest.NewServer(router), router
+}
+
+func createDuplicateTestQuestion(t *testing.T, router *gin.Engine, url string, question any) *httptest.ResponseRecorder {
+	// marshal question
+	body, err := json.Marshal(question)
+	if err != nil {
+		t.Errorf("error marshal question: %v", err)
+	}
+	// request create question
+	w := httptest.NewRecorder()
+	request, err := newAuthorTestRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		t.Errorf("error creating request: %v", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, request)
+	return w
+}
+
+func TestNova_HandleQueryQuestionDuplicates(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleQueryQuestionDuplicates
+	// Test Purpose: Test HandleQueryQuestionDuplicates cluster near-duplicate questions
+	// Test Steps:
+	// 1. send CreateQuestion request with single-choice & near-duplicate multiple-choice question
+	// 2. send CreateQuestion request with unrelated essay question
+	// 3. send QueryQuestionDuplicates request by using GET method
+	// 4. receive QueryQuestionDuplicates response with both choice questions in one cluster
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startDuplicateTestService("warn")
+	defer server.Close()
+	/* create questions */
+	token := utils.RandomAlphabet(12)
+	singleChoice := QuestionSingleChoice{
+		Id:    uuid.New().String(),
+		Title: "Which transport protocol does the " + token + " service use by default?",
+		Answers: []QuestionAnswer{
+			{"A", "TCP"},
+			{"B", "UDP"},
+			{"C", "SCTP"},
+		},
+		StandardAnswer: QuestionAnswer{"A", "TCP"},
+	}
+	multipleChoice := QuestionMultipleChoice{
+		Id:    uuid.New().String(),
+		Title: "Which transport protocols does the " + token + " service use by default?",
+		Answers: []QuestionAnswer{
+			{"A", "UDP"},
+			{"B", "TCP"},
+			{"C", "SCTP"},
+		},
+		StandardAnswers: []QuestionAnswer{{"B", "TCP"}},
+	}
+	essay := QuestionEssay{
+		Id:             uuid.New().String(),
+		Title:          "Describe how " + utils.RandomAlphabet(12) + " recovers from a partial network outage.",
+		Answer:         "-",
+		StandardAnswer: "It retries with exponential backoff.",
+	}
+	w := createDuplicateTestQuestion(t, router, server.URL+"/nova/v1/question/single-choice/"+singleChoice.Id, singleChoice)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = createDuplicateTestQuestion(t, router, server.URL+"/nova/v1/question/multiple-choice/"+multipleChoice.Id, multipleChoice)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = createDuplicateTestQuestion(t, router, server.URL+"/nova/v1/question/essay/"+essay.Id, essay)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	/* query duplicates */
+	wDuplicates := httptest.NewRecorder()
+	reqDuplicates, err := newAuthorTestRequest(http.MethodGet, server.URL+"/nova/v1/question/duplicates?threshold=0.7", nil)
+	if err != nil {
+		t.Errorf("error creating request: %v", err)
+	}
+	router.ServeHTTP(wDuplicates, reqDuplicates)
+	// return response
+	var resDuplicates []QuestionDuplicateCluster
+	err = json.Unmarshal(wDuplicates.Body.Bytes(), &resDuplicates)
+	if err != nil {
+		t.Errorf("error unmarshal response: %v", err)
+	}
+	// validate response
+	assert.Equal(t, http.StatusOK, wDuplicates.Code)
+	assert.Equal(t, "application/json", wDuplicates.Header().Get("Content-Type"))
+	var cluster *QuestionDuplicateCluster
+	for k, v := range resDuplicates {
+		for _, q := range v.Questions {
+			assert.NotEqual(t, essay.Id, q.Id)
+			if q.Id == singleChoice.Id {
+				cluster = &resDuplicates[k]
+			}
+		}
+	}
+	if assert.NotNil(t, cluster) {
+		var ids []string
+		for _, q := range cluster.Questions {
+			ids = append(ids, q.Type+"/"+q.Id)
+		}
+		assert.Contains(t, ids, QuestionTypeMultipleChoice+"/"+multipleChoice.Id)
+		assert.GreaterOrEqual(t, cluster.Similarity, 0.7)
+	}
+}
+
+func TestNova_HandleQueryQuestionDuplicatesBadThreshold(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleQueryQuestionDuplicates (bad threshold)
+	// Test Purpose: Test HandleQueryQuestionDuplicates reject threshold out of range
+	// Test Steps:
+	// 1. send QueryQuestionDuplicates request with threshold 1.5 by using GET method
+	// 2. receive QueryQuestionDuplicates response by using 400 Bad Request Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startDuplicateTestService("warn")
+	defer server.Close()
+	// request query duplicates
+	w := httptest.NewRecorder()
+	request, err := newAuthorTestRequest(http.MethodGet, server.URL+"/nova/v1/question/duplicates?threshold=1.5", nil)
+	if err != nil {
+		t.Errorf("error creating request: %v", err)
+	}
+	router.ServeHTTP(w, request)
+	// validate response
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+}
+
+func TestNova_HandleCreateQuestionDuplicateWarn(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateQuestion (duplicate warn)
+	// Test Purpose: Test HandleCreateQuestion warn near-duplicate question
+	// Test Steps:
+	// 1. send CreateQuestion request with judgement question by using POST method
+	// 2. send CreateQuestion request with reworded judgement question by using POST method
+	// 3. receive CreateQuestion response with Warning header by using 201 Created Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startDuplicateTestService("warn")
+	defer server.Close()
+	// request create questions
+	token := utils.RandomAlphabet(12)
+	first := QuestionJudgement{
+		Id:             uuid.New().String(),
+		Title:          "The " + token + " protocol guarantees in-order delivery of every message.",
+		StandardAnswer: true,
+	}
+	second := QuestionJudgement{
+		Id:             uuid.New().String(),
+		Title:          "The " + token + " protocol guarantees in order delivery of every message!",
+		StandardAnswer: true,
+	}
+	w := createDuplicateTestQuestion(t, router, server.URL+"/nova/v1/question/judgement/"+first.Id, first)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = createDuplicateTestQuestion(t, router, server.URL+"/nova/v1/question/judgement/"+second.Id, second)
+	// validate response
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Header().Get("Warning"), first.Id)
+}
+
+func TestNova_HandleCreateQuestionDuplicateReject(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateQuestion (duplicate reject)
+	// Test Purpose: Test HandleCreateQuestion reject near-duplicate question
+	// Test Steps:
+	// 1. send CreateQuestion request with single-choice question by using POST method
+	// 2. send CreateQuestion request with same single-choice question in other Id by using POST method
+	// 3. receive CreateQuestion response by using 409 Conflict Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startDuplicateTestService("reject")
+	defer server.Close()
+	// request create questions
+	token := utils.RandomAlphabet(12)
+	question := QuestionSingleChoice{
+		Id:    uuid.New().String(),
+		Title: "What is the default port of " + token + "?",
+		Answers: []QuestionAnswer{
+			{"A", "80"},
+			{"B", "443"},
+		},
+		StandardAnswer: QuestionAnswer{"B", "443"},
+	}
+	w := createDuplicateTestQuestion(t, router, server.URL+"/nova/v1/question/single-choice/"+question.Id, question)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	question.Id = uuid.New().String()
+	w = createDuplicateTestQuestion(t, router, server.URL+"/nova/v1/question/single-choice/"+question.Id, question)
+	// validate response
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+}
+
+func TestNova_HandleUpdateQuestionDuplicateReject(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleUpdateQuestion (duplicate reject)
+	// Test Purpose: Test HandleUpdateQuestion & HandleModifyQuestion reject near-duplicate question
+	// Test Steps:
+	// 1. send CreateQuestion request with two different single-choice questions by using POST method
+	// 2. send UpdateQuestion & ModifyQuestion request copying first question into second
+	// 3. receive UpdateQuestion & ModifyQuestion response by using 409 Conflict Code
+	// 4. send UpdateQuestion request with unchanged first question, receive 200 OK Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startDuplicateTestService("reject")
+	defer server.Close()
+	// request create questions
+	first := QuestionSingleChoice{
+		Id:    uuid.New().String(),
+		Title: "What is the default port of " + utils.RandomAlphabet(12) + "?",
+		Answers: []QuestionAnswer{
+			{"A", "80"},
+			{"B", "443"},
+		},
+		StandardAnswer: QuestionAnswer{"B", "443"},
+	}
+	second := QuestionSingleChoice{
+		Id:    uuid.New().String(),
+		Title: "Which signal terminates " + utils.RandomAlphabet(12) + " immediately?",
+		Answers: []QuestionAnswer{
+			{"A", "SIGTERM"},
+			{"B", "SIGKILL"},
+		},
+		StandardAnswer: QuestionAnswer{"B", "SIGKILL"},
+	}
+	w := createDuplicateTestQuestion(t, router, server.URL+"/nova/v1/question/single-choice/"+first.Id, first)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = createDuplicateTestQuestion(t, router, server.URL+"/nova/v1/question/single-choice/"+second.Id, second)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	// request update & modify question into duplicate
+	copied := first
+	copied.Id = second.Id
+	w = serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/question/single-choice/"+second.Id, copied, testAuthorToken)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	w = serveTestRequest(t, router, http.MethodPatch, server.URL+"/nova/v1/question/single-choice/"+second.Id, copied, testAuthorToken)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	// question is not duplicate of itself
+	w = serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/question/single-choice/"+first.Id, first, testAuthorToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestNova_HandleCreateQuestionDuplicateOtherType(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateQuestion (duplicate of other type)
+	// Test Purpose: Test HandleCreateQuestion compare questions not yet loaded into data cache
+	// Test Steps:
+	// 1. send CreateQuestion request with single-choice question to first service
+	// 2. send CreateQuestion request with same multiple-choice question to second service
+	// 3. receive CreateQuestion response by using 409 Conflict Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test services sharing database
+	server, router := startDuplicateTestService("reject")
+	defer server.Close()
+	question := QuestionSingleChoice{
+		Id:    uuid.New().String(),
+		Title: "Which transport protocol does " + utils.RandomAlphabet(12) + " use?",
+		Answers: []QuestionAnswer{
+			{"A", "TCP"},
+			{"B", "UDP"},
+		},
+		StandardAnswer: QuestionAnswer{"A", "TCP"},
+	}
+	w := createDuplicateTestQuestion(t, router, server.URL+"/nova/v1/question/single-choice/"+question.Id, question)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	other, otherRouter := startDuplicateTestService("reject")
+	defer other.Close()
+	duplicate := QuestionMultipleChoice{
+		Id:              uuid.New().String(),
+		Title:           question.Title,
+		Answers:         question.Answers,
+		StandardAnswers: []QuestionAnswer{question.StandardAnswer},
+	}
+	w = createDuplicateTestQuestion(t, otherRouter, other.URL+"/nova/v1/question/multiple-choice/"+duplicate.Id, duplicate)
+	// validate response
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestJudgementFingerprintText(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestJudgementFingerprintText
+	// Test Purpose: Test standard answer is part of judgement question fingerprint
+	// Test Steps:
+	// 1. fingerprint judgement questions with same title & opposite standard answers
+	// 2. fingerprints differ
+	-----------------------------------------------------------------------------------------*/
+	question := QuestionJudgement{Title: "The sun rises in the east."}
+	truth := judgementFingerprintText(question)
+	question.StandardAnswer = true
+	assert.NotEqual(t, truth, judgementFingerprintText(question))
+	assert.Contains(t, judgementFingerprintText(question), "true")
+}
+
+func TestNova_QueryQuestionDuplicateClustersLowThreshold(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_QueryQuestionDuplicateClustersLowThreshold
+	// Test Purpose: Test queryQuestionDuplicateClusters find every pair under band threshold
+	// Test Steps:
+	// 1. create pairs of single-choice questions sharing half of title in data cache
+	// 2. cluster questions with threshold under band threshold
+	// 3. every pair reaching threshold is in one cluster
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	/* create question pairs */
+	threshold := 0.25
+	var pairs [][2]QuestionSingleChoice
+	for k := 0; k < 20; k++ {
+		prefix := utils.RandomAlphabet(40)
+		var pair [2]QuestionSingleChoice
+		for i := range pair {
+			pair[i] = QuestionSingleChoice{Id: uuid.New().String(), Title: prefix + utils.RandomAlphabet(40)}
+			nova.createSingleChoiceQuestionInDataCache(pair[i])
+		}
+		pairs = append(pairs, pair)
+	}
+	/* cluster questions */
+	clusters := nova.queryQuestionDuplicateClusters(tenantScopeAll, threshold)
+	cluster := make(map[string]int)
+	for k, v := range clusters {
+		for _, q := range v.Questions {
+			cluster[q.Id] = k + 1
+		}
+	}
+	n := 0
+	for _, pair := range pairs {
+		a := questionFingerprintSignature(singleChoiceFingerprintText(pair[0]))
+		b := questionFingerprintSignature(singleChoiceFingerprintText(pair[1]))
+		if questionFingerprintSimilarity(a, b) < threshold {
+			continue
+		}
+		n++
+		assert.NotZero(t, cluster[pair[0].Id])
+		assert.Equal(t, cluster[pair[0].Id], cluster[pair[1].Id])
+	}
+	assert.Greater(t, n, 0)
+}
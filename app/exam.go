@@ -0,0 +1,711 @@
+package app
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"hash/fnv"
+	mathrand "math/rand"
+	"net/http"
+	"nova/logger"
+	"strings"
+	"time"
+)
+
+// examQuestionsMax bounds questions of single exam
+const examQuestionsMax = 200
+
+// errExamNotFound is returned when exam is not stored
+var errExamNotFound = errors.New("exam not found")
+
+// errExamExisted is returned when exam with the same Id is stored
+var errExamExisted = errors.New("exam already exists")
+
+// errExamAttemptNotFound is returned when exam attempt is not stored
+var errExamAttemptNotFound = errors.New("exam attempt not found")
+
+func (nova *Nova) HandleCreateExam(c *gin.Context) {
+	// create exam from published questions
+	var request Exam
+	logger.Infof("handle request create exam")
+	// extract examId from uri
+	examId := strings.ToLower(c.Param("examId"))
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// examinees take exams but do not compose them
+	logger.Debugf("check principal is allowed to create exam")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to create exam"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to create exam"))
+		logger.Errorf("error check principal is allowed to create exam")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to create exam")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	if err := nova.queryQuestionWorkflowsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying question workflows in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// check request body correctness
+	logger.Debugf("check exam is validate")
+	if b, err := nova.isExamValidate(nova.queryTenantOwner(c), examId, request); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check exam is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check exam is validate")
+	// store created exam in database
+	logger.Debugf("store exam in database")
+	response := Exam{
+		Id:               examId,
+		Title:            request.Title,
+		Questions:        make([]ExamQuestion, 0, len(request.Questions)),
+		ShuffleQuestions: request.ShuffleQuestions,
+		ShuffleAnswers:   request.ShuffleAnswers,
+		Timing:           normalizeExamTiming(request.Timing),
+		CreatedBy:        nova.queryRequestAuthor(c),
+		CreatedAt:        time.Now().UTC(),
+	}
+	for _, v := range request.Questions {
+		// question without points is worth one point
+		if v.Points == 0 {
+			v.Points = 1
+		}
+		response.Questions = append(response.Questions, ExamQuestion{Type: strings.ToLower(v.Type), Id: strings.ToLower(v.Id), Points: v.Points})
+	}
+	if err := nova.db.CreateExam(&response); err != nil {
+		if errors.Is(err, errExamExisted) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error store exam in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store exam in database")
+	// store exam tenant in database & data cache
+	logger.Debugf("store exam tenant in database & data cache")
+	if err := nova.createResourceTenant(c, TenantResourceExam, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store exam tenant in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store exam tenant in database & data cache")
+	// return response
+	nova.response201Created(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response)
+	return
+}
+
+func (nova *Nova) HandleQueryExam(c *gin.Context) {
+	// query exam
+	logger.Infof("handle request query exam")
+	// extract examId from uri
+	examId := strings.ToLower(c.Param("examId"))
+	// request examId correctness
+	logger.Debugf("check examId is validate")
+	if err := uuid.Validate(examId); err != nil {
+		nova.response400BadRequest(c, errors.New("examId format incorrect"))
+		logger.Errorf("error check examId is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check examId is validate")
+	// query exam from database
+	logger.Debugf("query exam in database")
+	response, err := nova.db.QueryExam(examId)
+	if err != nil {
+		if errors.Is(err, errExamNotFound) {
+			nova.response404NotFound(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error query exam in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query exam in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleCreateExamAttempt(c *gin.Context) {
+	// start attempt of exam with its own seed
+	logger.Infof("handle request create exam attempt")
+	// attempts belong to authenticated users
+	logger.Debugf("check user session is validate")
+	if _, ok := nova.queryPrincipal(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to start exam attempt"))
+		logger.Errorf("error check user session is validate")
+		return
+	}
+	logger.Debugf("successfully check user session is validate")
+	// extract examId from uri
+	examId := strings.ToLower(c.Param("examId"))
+	// request examId correctness
+	logger.Debugf("check examId is validate")
+	if err := uuid.Validate(examId); err != nil {
+		nova.response400BadRequest(c, errors.New("examId format incorrect"))
+		logger.Errorf("error check examId is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check examId is validate")
+	// query exam from database
+	logger.Debugf("query exam in database")
+	exam, err := nova.db.QueryExam(examId)
+	if err != nil {
+		if errors.Is(err, errExamNotFound) {
+			nova.response404NotFound(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error query exam in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query exam in database")
+	// exam assigned to groups is only taken by their members until due
+	logger.Debugf("check exam is assigned to examinee")
+	if err := nova.checkExamAssignment(c, examId, time.Now().UTC()); err != nil {
+		if errors.Is(err, errExamNotAssigned) {
+			nova.response403Forbidden(c, err)
+		} else if errors.Is(err, errExamAssignmentPastDue) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check exam is assigned to examinee: %v", err)
+		return
+	}
+	logger.Debugf("successfully check exam is assigned to examinee")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	if err := nova.queryQuestionWorkflowsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying question workflows in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// lay out questions of attempt from new seed
+	logger.Debugf("create exam attempt questions")
+	seed, err := newExamAttemptSeed()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error create exam attempt seed: %v", err)
+		return
+	}
+	response := ExamAttempt{
+		AttemptId: uuid.New().String(),
+		ExamId:    exam.Id,
+		UserId:    nova.queryRequestAuthor(c),
+		Seed:      seed,
+		State:     ExamAttemptStateOpen,
+		CreatedAt: time.Now().UTC(),
+	}
+	// exam generated per attempt draws fresh questions from its blueprint
+	if exam.BlueprintId != "" && len(exam.Questions) == 0 {
+		if exam.Questions, err = nova.sampleExamAttemptQuestions(exam.BlueprintId, response.UserId, seed); err != nil {
+			if errors.As(err, new(FieldErrors)) {
+				nova.response409Conflict(c, err)
+			} else {
+				nova.response500InternalServerError(c, err)
+			}
+			logger.Errorf("error sample exam attempt questions from blueprint: %v", err)
+			return
+		}
+	}
+	// attempt keeps revisions of questions so that later edits do not change it
+	if exam.Questions, err = nova.pinExamQuestionRevisions(exam.Questions); err != nil {
+		nova.response409Conflict(c, err)
+		logger.Errorf("error pin exam attempt question revisions: %v", err)
+		return
+	}
+	response.ExamQuestions = exam.Questions
+	applyExamAttemptTiming(&response, exam.Timing)
+	if response.Questions, err = nova.queryExamAttemptQuestions(*exam, seed); err != nil {
+		nova.response409Conflict(c, err)
+		logger.Errorf("error create exam attempt questions: %v", err)
+		return
+	}
+	for _, v := range response.Questions {
+		response.MaxScore += v.Points
+	}
+	logger.Debugf("successfully create exam attempt questions")
+	// store exam attempt in database
+	logger.Debugf("store exam attempt in database")
+	if err := nova.db.CreateExamAttempt(&response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store exam attempt in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store exam attempt in database")
+	// return response
+	nova.response201Created(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response.AttemptId)
+	return
+}
+
+func (nova *Nova) HandleQueryExamAttempt(c *gin.Context) {
+	// query exam attempt laid out again from its seed
+	logger.Infof("handle request query exam attempt")
+	// extract attemptId from uri
+	attemptId := strings.ToLower(c.Param("attemptId"))
+	// query exam attempt & its exam from database
+	logger.Debugf("query exam attempt in database")
+	attempt, exam, ok := nova.queryExamAttemptInDatabase(c, attemptId)
+	if !ok {
+		return
+	}
+	logger.Debugf("successfully query exam attempt in database")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// the same seed gives the same questions & answers order
+	logger.Debugf("create exam attempt questions")
+	questions, err := nova.queryExamAttemptQuestions(exam, attempt.Seed)
+	if err != nil {
+		nova.response409Conflict(c, err)
+		logger.Errorf("error create exam attempt questions: %v", err)
+		return
+	}
+	attempt.Questions = applyExamAttemptAnswers(questions, attempt.Answers, attempt.Grades)
+	applyExamAttemptTiming(&attempt, exam.Timing)
+	logger.Debugf("successfully create exam attempt questions")
+	// return response
+	nova.response200OK(c, attempt)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, attempt.AttemptId)
+	return
+}
+
+func (nova *Nova) HandleCreateExamSubmission(c *gin.Context) {
+	// submit answers of exam attempt & grade them
+	var request ExamSubmission
+	logger.Infof("handle request create exam submission")
+	// extract attemptId from uri
+	attemptId := strings.ToLower(c.Param("attemptId"))
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// numeric answers are parsed in locale of examinee
+	if request.Locale == "" {
+		request.Locale = c.GetHeader("Accept-Language")
+	}
+	// query exam attempt & its exam from database
+	logger.Debugf("query exam attempt in database")
+	attempt, exam, ok := nova.queryExamAttemptInDatabase(c, attemptId)
+	if !ok {
+		return
+	}
+	logger.Debugf("successfully query exam attempt in database")
+	// only examinee of attempt submits its answers
+	logger.Debugf("check principal is examinee of attempt")
+	if attempt.UserId != nova.queryRequestAuthor(c) {
+		nova.response403Forbidden(c, errors.New("only examinee of attempt submits answers"))
+		logger.Errorf("error check principal is examinee of attempt")
+		return
+	}
+	if attempt.State != ExamAttemptStateOpen {
+		nova.response409Conflict(c, errors.New("exam attempt already submitted"))
+		logger.Errorf("error check exam attempt is open")
+		return
+	}
+	logger.Debugf("successfully check principal is examinee of attempt")
+	// submission past deadline is rejected or penalized by late policy of exam
+	logger.Debugf("check exam attempt deadline")
+	now := time.Now().UTC()
+	applyExamAttemptTiming(&attempt, exam.Timing)
+	late, err := checkExamAttemptDeadline(exam.Timing, attempt.CreatedAt, now)
+	if err != nil {
+		nova.response409Conflict(c, err)
+		logger.Errorf("error check exam attempt deadline: %v", err)
+		return
+	}
+	logger.Debugf("successfully check exam attempt deadline")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// grade answers in order shown to examinee, saved answers fill in the rest
+	logger.Debugf("grade exam attempt answers")
+	questions, err := nova.queryExamAttemptQuestions(exam, attempt.Seed)
+	if err != nil {
+		nova.response409Conflict(c, err)
+		logger.Errorf("error create exam attempt questions: %v", err)
+		return
+	}
+	submission, err := nova.mergeExamAttemptDraft(attempt, exam, questions, request, now)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query exam attempt draft in database: %v", err)
+		return
+	}
+	updated, err := nova.submitExamAttempt(&attempt, exam, questions, submission, now, late)
+	if err != nil {
+		if errors.As(err, new(FieldErrors)) {
+			nova.response400BadRequest(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error grade & store exam attempt submission: %v", err)
+		return
+	}
+	logger.Debugf("successfully grade exam attempt answers")
+	// check submitted exam attempt is stored in database
+	logger.Debugf("store exam attempt submission in database")
+	if !updated {
+		nova.response409Conflict(c, errors.New("exam attempt already submitted"))
+		logger.Errorf("error store exam attempt submission in database: attempt is not open")
+		return
+	}
+	attempt.Questions = applyExamAttemptAnswers(questions, attempt.Answers, attempt.Grades)
+	logger.Debugf("successfully store exam attempt submission in database")
+	// return response
+	nova.response200OK(c, attempt)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, attempt.Score)
+	return
+}
+
+func (nova *Nova) isExamValidate(tenantId string, examId string, exam Exam) (bool, error) {
+	// check exam identity matches uri
+	v := &questionValidator{}
+	v.checkId(exam.Id)
+	if !strings.EqualFold(exam.Id, examId) {
+		v.add("id", "should match examId of uri")
+	}
+	v.checkText("title", exam.Title, questionTitleMaxLength)
+	// check questions exist, are published & are not repeated
+	if len(exam.Questions) == 0 || len(exam.Questions) > examQuestionsMax {
+		v.add("questions", "should have 1 to %v questions, got %v", examQuestionsMax, len(exam.Questions))
+	}
+	checkExamTiming(v, exam.Timing, len(exam.Questions))
+	seen := make(map[string]bool, len(exam.Questions))
+	for k, question := range exam.Questions {
+		field := fmt.Sprintf("questions[%v]", k)
+		questionType, id := strings.ToLower(question.Type), strings.ToLower(question.Id)
+		if question.Points < 0 {
+			v.add(field+".points", "should not be negative")
+		}
+		if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+			v.add(field, "%v", err)
+			continue
+		}
+		switch {
+		case seen[questionType+"/"+id]:
+			v.add(field+".id", "duplicates question %v", id)
+		case !nova.isQuestionExisted(questionType, id) || !nova.isResourceInScope(tenantId, questionType, id):
+			v.add(field+".id", "%v question %v not found", questionType, id)
+		case !nova.isQuestionPublished(questionType, id):
+			v.add(field+".id", "%v question %v is not published", questionType, id)
+		}
+		seen[questionType+"/"+id] = true
+	}
+	return v.result()
+}
+
+func (nova *Nova) queryExamAttemptInDatabase(c *gin.Context, attemptId string) (ExamAttempt, Exam, bool) {
+	// request attemptId correctness
+	if err := uuid.Validate(attemptId); err != nil {
+		nova.response400BadRequest(c, errors.New("attemptId format incorrect"))
+		logger.Errorf("error check attemptId is validate: %v", err)
+		return ExamAttempt{}, Exam{}, false
+	}
+	// query exam attempt from database
+	attempt, err := nova.db.QueryExamAttempt(attemptId)
+	if err != nil {
+		if errors.Is(err, errExamAttemptNotFound) {
+			nova.response404NotFound(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error query exam attempt in database: %v", err)
+		return ExamAttempt{}, Exam{}, false
+	}
+	// examinees only see their own attempts, attempts of exams outside tenant are not found
+	if user, role, ok := nova.queryPrincipalRole(c); ((!ok || role == RoleExaminee) && user.UserId != attempt.UserId) ||
+		!nova.isResourceVisible(c, TenantResourceExam, attempt.ExamId) {
+		nova.response404NotFound(c, errExamAttemptNotFound)
+		logger.Errorf("error check exam attempt is visible")
+		return ExamAttempt{}, Exam{}, false
+	}
+	// query exam of attempt from database
+	exam, err := nova.db.QueryExam(attempt.ExamId)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query exam in database: %v", err)
+		return ExamAttempt{}, Exam{}, false
+	}
+	// attempt keeps questions drawn for it
+	if len(attempt.ExamQuestions) > 0 {
+		exam.Questions = attempt.ExamQuestions
+	}
+	return *attempt, *exam, true
+}
+
+func (nova *Nova) submitExamAttempt(attempt *ExamAttempt, exam Exam, questions []ExamAttemptQuestion, submission ExamSubmission, now time.Time, late bool) (bool, error) {
+	// answers saved for auto-submitted attempt are graded even when malformed
+	grades, err := nova.gradeExamAttempt(exam, attempt.Seed, questions, submission, attempt.AutoSubmitted)
+	if err != nil {
+		return false, err
+	}
+	attempt.State = ExamAttemptStateSubmitted
+	attempt.SubmittedAt = &now
+	attempt.Answers = submission.Answers
+	attempt.Grades = grades
+	attempt.Score, attempt.MaxScore = 0, 0
+	for k, v := range grades {
+		attempt.Score += v.Score
+		attempt.MaxScore += questions[k].Points
+	}
+	// late submission loses penalty percent of its score
+	if late {
+		attempt.Late, attempt.Penalty = true, exam.Timing.LatePenalty
+		attempt.Score = roundToDecimals(attempt.Score*(1-attempt.Penalty/100), 2)
+	}
+	return nova.db.UpdateExamAttempt(attempt, ExamAttemptStateOpen)
+}
+
+func (nova *Nova) queryExamAttemptQuestions(exam Exam, seed int64) ([]ExamAttemptQuestion, error) {
+	// questions are shown in shuffled order when exam asks for it
+	order := make([]int, len(exam.Questions))
+	for k := range order {
+		order[k] = k
+	}
+	if exam.ShuffleQuestions {
+		random := mathrand.New(mathrand.NewSource(seed))
+		for _, r := range queryExamQuestionRanges(exam) {
+			section := order[r[0]:r[1]]
+			random.Shuffle(len(section), func(i, j int) { section[i], section[j] = section[j], section[i] })
+		}
+	}
+	questions := make([]ExamAttemptQuestion, 0, len(order))
+	for _, k := range order {
+		v := exam.Questions[k]
+		question := ExamAttemptQuestion{Type: v.Type, Id: v.Id, Revision: v.Revision, Points: v.Points}
+		content, err := nova.queryQuestionRevisionContent(v.Type, v.Id, v.Revision)
+		if err != nil {
+			return nil, fmt.Errorf("%v question %v of exam not found", v.Type, v.Id)
+		}
+		switch q := content.(type) {
+		case QuestionSingleChoice:
+			question.Title, question.Answers = q.Title, q.Answers
+		case QuestionMultipleChoice:
+			question.Title, question.Answers = q.Title, q.Answers
+		case QuestionTemplate:
+			// template question draws values from seed of attempt
+			instance, err := newQuestionTemplateInstance(q, seed)
+			if err != nil {
+				return nil, err
+			}
+			question.Title, question.Seed = instance.Title, seed
+		default:
+			question.Title = questionContentFields(content)[0].Source
+		}
+		if exam.ShuffleAnswers && len(question.Answers) > 0 {
+			question.Answers, _ = shuffleQuestionAnswers(question.Answers, seed, v.Id)
+		}
+		questions = append(questions, question)
+	}
+	return questions, nil
+}
+
+func (nova *Nova) gradeExamAttempt(exam Exam, seed int64, questions []ExamAttemptQuestion, submission ExamSubmission, lenient bool) ([]QuestionGrade, error) {
+	// index submitted answers by question, unknown questions are rejected
+	v := &questionValidator{}
+	answers := make(map[string]int, len(submission.Answers))
+	shown := make(map[string]ExamAttemptQuestion, len(questions))
+	for _, question := range questions {
+		shown[question.Type+"/"+question.Id] = question
+	}
+	for k, answer := range submission.Answers {
+		key := strings.ToLower(answer.Type) + "/" + strings.ToLower(answer.Id)
+		if _, ok := shown[key]; !ok {
+			v.add(fmt.Sprintf("answers[%v].id", k), "question %v is not in exam", answer.Id)
+			continue
+		}
+		answers[key] = k
+	}
+	// grade every question, unanswered question earns nothing
+	grades := make([]QuestionGrade, len(questions))
+	for k, question := range questions {
+		n, ok := answers[question.Type+"/"+question.Id]
+		if !ok {
+			grades[k] = newQuestionGrade(false)
+		} else {
+			// answer is graded against revision shown to examinee
+			content, err := nova.queryQuestionRevisionContent(question.Type, question.Id, question.Revision)
+			if err != nil {
+				return nil, err
+			}
+			// shuffled marks are mapped back to marks of standard answer
+			raw := submission.Answers[n].Answer
+			if exam.ShuffleAnswers && len(question.Answers) > 0 {
+				raw = remapExamAnswerMarks(questionChoiceAnswers(content), seed, question.Id, raw)
+			}
+			grade, err := nova.gradeQuestionContent(content, QuestionSubmission{Answer: raw, Locale: submission.Locale, Seed: question.Seed})
+			switch {
+			case err == nil:
+				grades[k] = grade
+			case errors.Is(err, errQuestionAnswerFormat) && lenient:
+				grades[k] = newQuestionGrade(false)
+			case errors.Is(err, errQuestionAnswerFormat):
+				v.add(fmt.Sprintf("answers[%v].answer", n), "%v", err)
+				continue
+			default:
+				return nil, err
+			}
+		}
+		// grade is scaled to points of question in exam
+		if grades[k].MaxScore > 0 {
+			grades[k].Score = grades[k].Score / grades[k].MaxScore * question.Points
+		}
+		grades[k].MaxScore = question.Points
+		grades[k].Type, grades[k].Id = question.Type, question.Id
+	}
+	if b, err := v.result(); !b {
+		return nil, err
+	}
+	return grades, nil
+}
+
+func (nova *Nova) pinExamQuestionRevisions(questions []ExamQuestion) ([]ExamQuestion, error) {
+	// latest revision of every question is shown & graded for whole attempt
+	pinned := make([]ExamQuestion, len(questions))
+	for k, v := range questions {
+		if !nova.isQuestionExisted(v.Type, v.Id) {
+			return nil, fmt.Errorf("%v question %v of exam not found", v.Type, v.Id)
+		}
+		revision, err := nova.pinQuestionRevision(v.Type, v.Id)
+		if err != nil {
+			return nil, err
+		}
+		pinned[k] = v
+		pinned[k].Revision = revision
+	}
+	return pinned, nil
+}
+
+func (nova *Nova) queryQuestionAnswers(questionType string, id string, revision int) []QuestionAnswer {
+	// answers of choice questions in revision shown to examinee
+	question, err := nova.queryQuestionRevisionContent(questionType, id, revision)
+	if err != nil {
+		return nil
+	}
+	return questionChoiceAnswers(question)
+}
+
+func questionChoiceAnswers(question any) []QuestionAnswer {
+	// answers of choice questions, other questions have none
+	switch q := question.(type) {
+	case QuestionSingleChoice:
+		return q.Answers
+	case QuestionMultipleChoice:
+		return q.Answers
+	default:
+		return nil
+	}
+}
+
+func applyExamAttemptAnswers(questions []ExamAttemptQuestion, answers []ExamAnswer, grades []QuestionGrade) []ExamAttemptQuestion {
+	// submitted answers & grades are shown next to questions
+	for k, question := range questions {
+		for _, answer := range answers {
+			if strings.EqualFold(answer.Type, question.Type) && strings.EqualFold(answer.Id, question.Id) {
+				questions[k].Answer = answer.Answer
+			}
+		}
+		if k < len(grades) {
+			grade := grades[k]
+			questions[k].Grade = &grade
+		}
+	}
+	return questions
+}
+
+func newExamAttemptSeed() (int64, error) {
+	// seed is drawn from crypto source so examinees cannot predict layout
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(b[:]) >> 1), nil
+}
+
+func shuffleQuestionAnswers(answers []QuestionAnswer, seed int64, id string) ([]QuestionAnswer, map[string]string) {
+	// the same seed always gives the same order, different questions are shuffled differently
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(id))
+	random := mathrand.New(mathrand.NewSource(seed ^ int64(hash.Sum64())))
+	permutation := random.Perm(len(answers))
+	// marks stay in place so that examinees cannot match marks between screens
+	shuffled := make([]QuestionAnswer, len(answers))
+	marks := make(map[string]string, len(answers))
+	for k, v := range permutation {
+		shuffled[k] = QuestionAnswer{AnswerMark: answers[k].AnswerMark, AnswerText: answers[v].AnswerText}
+		marks[normalizeAnswerMark(answers[k].AnswerMark)] = answers[v].AnswerMark
+	}
+	return shuffled, marks
+}
+
+func remapExamAnswerMarks(answers []QuestionAnswer, seed int64, id string, answer json.RawMessage) json.RawMessage {
+	// translate shown marks to marks of question, unknown marks are kept & graded incorrect
+	_, marks := shuffleQuestionAnswers(answers, seed, id)
+	remap := func(mark string) string {
+		if v, ok := marks[normalizeAnswerMark(mark)]; ok {
+			return v
+		}
+		return mark
+	}
+	var mark string
+	if err := json.Unmarshal(answer, &mark); err == nil {
+		b, _ := json.Marshal(remap(mark))
+		return b
+	}
+	var list []string
+	if err := json.Unmarshal(answer, &list); err == nil {
+		for k, v := range list {
+			list[k] = remap(v)
+		}
+		b, _ := json.Marshal(list)
+		return b
+	}
+	return answer
+}
@@ -0,0 +1,371 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"nova/utils"
+	"testing"
+)
+
+func setupExamTestRouter() *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// first administrator is granted by command line
+	setupTestAdministrator(nova)
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	{
+		/* user management */
+		novaService.POST("/user/:userId", nova.HandleCreateUser)
+		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
+		novaService.PUT("/user/role/:userId", nova.HandleUpdateUserRole)
+		/* exam management */
+		novaService.POST("/exam/:examId", nova.HandleCreateExam)
+		novaService.GET("/exam/:examId", nova.HandleQueryExam)
+		novaService.POST("/exam/:examId/attempt", nova.HandleCreateExamAttempt)
+		novaService.GET("/exam/attempt/:attemptId", nova.HandleQueryExamAttempt)
+		novaService.POST("/exam/attempt/:attemptId/submission", nova.HandleCreateExamSubmission)
+		/* question management */
+		// question workflow related
+		novaService.POST("/question/workflow/:type/:Id/transition", nova.HandleCreateQuestionTransition)
+		novaService.POST("/question/workflow/:type/:Id/approve", nova.HandleCreateQuestionApproval)
+		// question related
+		novaService.POST("/question/single-choice/:Id", nova.HandleCreateQuestionSingleChoice)
+		novaService.PUT("/question/single-choice/:Id", nova.HandleUpdateQuestionSingleChoice)
+		novaService.DELETE("/question/single-choice/:Id", nova.HandleDeleteQuestionSingleChoice)
+		novaService.GET("/question/single-choice/:Id", nova.HandleQueryQuestionSingleChoice)
+		novaService.POST("/question/multiple-choice/:Id", nova.HandleCreateQuestionMultipleChoice)
+		novaService.POST("/question/judgement/:Id", nova.HandleCreateQuestionJudgement)
+	}
+	return router
+}
+
+func startExamTestService() (*httptest.Server, *gin.Engine) {
+	router := setupExamTestRouter()
+	return httptest.NewServer(router), router
+}
+
+func createExamTestQuestion(t *testing.T, server *httptest.Server, router *gin.Engine, questionType string, id string, question any, token string, publish bool) {
+	// create question & publish it through review
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/"+questionType+"/"+id, question, token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	if !publish {
+		return
+	}
+	url := server.URL + "/nova/v1/question/workflow/" + questionType + "/" + id
+	w = serveTestRequest(t, router, http.MethodPost, url+"/transition", QuestionTransition{State: QuestionStateInReview}, token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, url+"/approve", QuestionTransition{}, token)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestNova_HandleCreateExam(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateExam
+	// Test Purpose: Test exams are composed of published questions by staff
+	// Test Steps:
+	// 1. send CreateExam request without session or with examinee session, receive 401 & 403
+	// 2. send CreateExam request with draft & unknown questions, receive 400 Bad Request Code
+	// 3. send CreateExam request with published questions, receive 201 Created Code
+	// 4. send QueryExam request, receive exam with default points by using 200 OK Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startExamTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	_, examineeSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	/* create published & draft questions */
+	published := QuestionJudgement{Id: uuid.New().String(), Title: "The earth is round " + utils.RandomAlphabet(12), StandardAnswer: true}
+	createExamTestQuestion(t, server, router, QuestionTypeJudgement, published.Id, published, admin.Token, true)
+	draft := QuestionJudgement{Id: uuid.New().String(), Title: "The moon is square " + utils.RandomAlphabet(12)}
+	createExamTestQuestion(t, server, router, QuestionTypeJudgement, draft.Id, draft, admin.Token, false)
+	/* examinee does not compose exams */
+	exam := Exam{
+		Id:    uuid.New().String(),
+		Title: "Astronomy quiz",
+		Questions: []ExamQuestion{
+			{Type: QuestionTypeJudgement, Id: published.Id},
+			{Type: QuestionTypeJudgement, Id: draft.Id, Points: 2},
+			{Type: QuestionTypeJudgement, Id: uuid.New().String()},
+		},
+	}
+	url := server.URL + "/nova/v1/exam/" + exam.Id
+	w := serveTestRequest(t, router, http.MethodPost, url, exam, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, url, exam, examineeSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	/* only published questions are added */
+	w = serveTestRequest(t, router, http.MethodPost, url, exam, admin.Token)
+	var problem ProblemDetails
+	unmarshalTestResponse(t, w, &problem)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	if assert.Len(t, problem.Errors, 2) {
+		assert.Equal(t, "questions[1].id", problem.Errors[0].Field)
+		assert.Contains(t, problem.Errors[0].Reason, "not published")
+		assert.Equal(t, "questions[2].id", problem.Errors[1].Field)
+	}
+	/* create exam */
+	exam.Questions = exam.Questions[:1]
+	w = serveTestRequest(t, router, http.MethodPost, url, exam, admin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, url, exam, admin.Token)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, examineeSession.Token)
+	var stored Exam
+	unmarshalTestResponse(t, w, &stored)
+	assert.Equal(t, http.StatusOK, w.Code)
+	if assert.Len(t, stored.Questions, 1) {
+		assert.Equal(t, 1.0, stored.Questions[0].Points)
+	}
+}
+
+func TestNova_HandleCreateExamAttempt(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateExamAttempt
+	// Test Purpose: Test answers are shuffled per attempt & graded against canonical answers
+	// Test Steps:
+	// 1. send CreateExam request with shuffled questions & answers by using POST method
+	// 2. send CreateExamAttempt request, receive shuffled answers keeping marks in place
+	// 3. send QueryExamAttempt request, receive the same order derived from seed
+	// 4. send CreateExamSubmission request with shown marks, receive full score by using 200 OK Code
+	// 5. send QueryQuestion request, standard answer is unchanged
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startExamTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	_, examineeSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	_, otherSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	/* create published choice questions */
+	single := QuestionSingleChoice{
+		Id:    uuid.New().String(),
+		Title: "Which port does HTTPS use " + utils.RandomAlphabet(12),
+		Answers: []QuestionAnswer{
+			{AnswerMark: "A", AnswerText: "443"},
+			{AnswerMark: "B", AnswerText: "80"},
+			{AnswerMark: "C", AnswerText: "22"},
+			{AnswerMark: "D", AnswerText: "25"},
+			{AnswerMark: "E", AnswerText: "53"},
+			{AnswerMark: "F", AnswerText: "110"},
+		},
+		StandardAnswer: QuestionAnswer{AnswerMark: "A", AnswerText: "443"},
+	}
+	createExamTestQuestion(t, server, router, QuestionTypeSingleChoice, single.Id, single, admin.Token, true)
+	multiple := QuestionMultipleChoice{
+		Id:    uuid.New().String(),
+		Title: "Which protocols are connection oriented " + utils.RandomAlphabet(12),
+		Answers: []QuestionAnswer{
+			{AnswerMark: "A", AnswerText: "TCP"},
+			{AnswerMark: "B", AnswerText: "UDP"},
+			{AnswerMark: "C", AnswerText: "SCTP"},
+			{AnswerMark: "D", AnswerText: "ICMP"},
+		},
+		StandardAnswers: []QuestionAnswer{{AnswerMark: "A", AnswerText: "TCP"}, {AnswerMark: "C", AnswerText: "SCTP"}},
+	}
+	createExamTestQuestion(t, server, router, QuestionTypeMultipleChoice, multiple.Id, multiple, admin.Token, true)
+	/* create shuffled exam */
+	exam := Exam{
+		Id:    uuid.New().String(),
+		Title: "Networking quiz",
+		Questions: []ExamQuestion{
+			{Type: QuestionTypeSingleChoice, Id: single.Id, Points: 2},
+			{Type: QuestionTypeMultipleChoice, Id: multiple.Id, Points: 3},
+		},
+		ShuffleQuestions: true,
+		ShuffleAnswers:   true,
+	}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id, exam, admin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	/* start attempt, anonymous requests do not take exams */
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id+"/attempt", nil, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id+"/attempt", nil, examineeSession.Token)
+	var attempt ExamAttempt
+	unmarshalTestResponse(t, w, &attempt)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, ExamAttemptStateOpen, attempt.State)
+	assert.Equal(t, 5.0, attempt.MaxScore)
+	if !assert.Len(t, attempt.Questions, 2) {
+		return
+	}
+	for _, question := range attempt.Questions {
+		assert.NotEmpty(t, question.Answers)
+		for k, answer := range question.Answers {
+			assert.Equal(t, string(rune('A'+k)), answer.AnswerMark)
+		}
+		assert.Nil(t, question.Grade)
+	}
+	/* attempt is laid out again from its seed */
+	url := server.URL + "/nova/v1/exam/attempt/" + attempt.AttemptId
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, examineeSession.Token)
+	var review ExamAttempt
+	unmarshalTestResponse(t, w, &review)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, attempt.Seed, review.Seed)
+	assert.Equal(t, attempt.Questions, review.Questions)
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, otherSession.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	/* answer with marks shown in attempt */
+	shown := func(question ExamAttemptQuestion, text string) string {
+		for _, v := range question.Answers {
+			if v.AnswerText == text {
+				return v.AnswerMark
+			}
+		}
+		return ""
+	}
+	submission := ExamSubmission{}
+	for _, question := range attempt.Questions {
+		switch question.Type {
+		case QuestionTypeSingleChoice:
+			submission.Answers = append(submission.Answers, ExamAnswer{Type: question.Type, Id: question.Id, Answer: []byte(`"` + shown(question, "443") + `"`)})
+		case QuestionTypeMultipleChoice:
+			submission.Answers = append(submission.Answers, ExamAnswer{Type: question.Type, Id: question.Id,
+				Answer: []byte(`["` + shown(question, "SCTP") + `","` + shown(question, "TCP") + `"]`)})
+		}
+	}
+	submissionURL := url + "/submission"
+	w = serveTestRequest(t, router, http.MethodPost, submissionURL, submission, otherSession.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, submissionURL, submission, examineeSession.Token)
+	var graded ExamAttempt
+	unmarshalTestResponse(t, w, &graded)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, ExamAttemptStateSubmitted, graded.State)
+	assert.Equal(t, 5.0, graded.Score)
+	for _, question := range graded.Questions {
+		if assert.NotNil(t, question.Grade) {
+			assert.True(t, question.Grade.Correct)
+			assert.Equal(t, question.Points, question.Grade.Score)
+		}
+	}
+	w = serveTestRequest(t, router, http.MethodPost, submissionURL, submission, examineeSession.Token)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	/* canonical standard answer is unchanged */
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/question/single-choice/"+single.Id, nil, admin.Token)
+	var stored QuestionSingleChoice
+	unmarshalTestResponse(t, w, &stored)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, single.StandardAnswer, stored.StandardAnswer)
+	assert.Equal(t, single.Answers, stored.Answers)
+}
+
+func TestNova_HandleCreateExamSubmissionRevision(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateExamSubmission (question revision)
+	// Test Purpose: Test attempt shows & grades question revision pinned when it started
+	// Test Steps:
+	// 1. send CreateExam & CreateExamAttempt request with published single-choice question
+	// 2. send UpdateQuestion request changing title & standard answer during attempt
+	// 3. send QueryExamAttempt request, receive question as it was when attempt started
+	// 4. send CreateExamSubmission request with former standard answer, receive full score
+	// 5. send DeleteQuestion request, QueryExamAttempt still receives 200 OK Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startExamTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	_, examineeSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	/* start attempt of published question */
+	single := QuestionSingleChoice{
+		Id:    uuid.New().String(),
+		Title: "Which port does SSH use " + utils.RandomAlphabet(12),
+		Answers: []QuestionAnswer{
+			{AnswerMark: "A", AnswerText: "22"},
+			{AnswerMark: "B", AnswerText: "80"},
+		},
+		StandardAnswer: QuestionAnswer{AnswerMark: "A", AnswerText: "22"},
+	}
+	createExamTestQuestion(t, server, router, QuestionTypeSingleChoice, single.Id, single, admin.Token, true)
+	exam := Exam{
+		Id:        uuid.New().String(),
+		Title:     "Remote access quiz",
+		Questions: []ExamQuestion{{Type: QuestionTypeSingleChoice, Id: single.Id, Points: 1}},
+	}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id, exam, admin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id+"/attempt", nil, examineeSession.Token)
+	var attempt ExamAttempt
+	unmarshalTestResponse(t, w, &attempt)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	if !assert.Len(t, attempt.Questions, 1) {
+		return
+	}
+	assert.Equal(t, 1, attempt.Questions[0].Revision)
+	/* question is edited during attempt */
+	edited := single
+	edited.Title = "Which port does Telnet use " + utils.RandomAlphabet(12)
+	edited.StandardAnswer = QuestionAnswer{AnswerMark: "B", AnswerText: "80"}
+	questionURL := server.URL + "/nova/v1/question/single-choice/" + single.Id
+	w = serveTestRequest(t, router, http.MethodPut, questionURL, edited, admin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	url := server.URL + "/nova/v1/exam/attempt/" + attempt.AttemptId
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, examineeSession.Token)
+	var review ExamAttempt
+	unmarshalTestResponse(t, w, &review)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, attempt.Questions, review.Questions)
+	/* answer key of pinned revision grades attempt */
+	submission := ExamSubmission{Answers: []ExamAnswer{{Type: QuestionTypeSingleChoice, Id: single.Id, Answer: []byte(`"A"`)}}}
+	w = serveTestRequest(t, router, http.MethodPost, url+"/submission", submission, examineeSession.Token)
+	var graded ExamAttempt
+	unmarshalTestResponse(t, w, &graded)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1.0, graded.Score)
+	/* deleted question is still reviewed */
+	w = serveTestRequest(t, router, http.MethodDelete, questionURL, nil, admin.Token)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, examineeSession.Token)
+	review = ExamAttempt{}
+	unmarshalTestResponse(t, w, &review)
+	assert.Equal(t, http.StatusOK, w.Code)
+	if assert.Len(t, review.Questions, 1) {
+		assert.Equal(t, single.Title, review.Questions[0].Title)
+		if assert.NotNil(t, review.Questions[0].Grade) {
+			assert.True(t, review.Questions[0].Grade.Correct)
+		}
+	}
+}
+
+func TestShuffleQuestionAnswers(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestShuffleQuestionAnswers
+	// Test Purpose: Test answer order is stable for seed & marks are remapped to canonical marks
+	-----------------------------------------------------------------------------------------*/
+	answers := []QuestionAnswer{
+		{AnswerMark: "A", AnswerText: "one"},
+		{AnswerMark: "B", AnswerText: "two"},
+		{AnswerMark: "C", AnswerText: "three"},
+		{AnswerMark: "D", AnswerText: "four"},
+	}
+	id := uuid.New().String()
+	shuffled, marks := shuffleQuestionAnswers(answers, 42, id)
+	again, _ := shuffleQuestionAnswers(answers, 42, id)
+	assert.Equal(t, shuffled, again)
+	texts := make(map[string]string, len(answers))
+	for _, v := range answers {
+		texts[v.AnswerMark] = v.AnswerText
+	}
+	for k, v := range shuffled {
+		assert.Equal(t, answers[k].AnswerMark, v.AnswerMark)
+		assert.Equal(t, v.AnswerText, texts[marks[v.AnswerMark]])
+	}
+	remapped := remapExamAnswerMarks(answers, 42, id, []byte(`["a","d","x"]`))
+	assert.JSONEq(t, `["`+marks["A"]+`","`+marks["D"]+`","x"]`, string(remapped))
+}
@@ -0,0 +1,260 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"unicode"
+)
+
+// formulaFunctions are single argument functions available in template formula
+var formulaFunctions = map[string]func(float64) float64{
+	"abs":   math.Abs,
+	"sqrt":  math.Sqrt,
+	"exp":   math.Exp,
+	"ln":    math.Log,
+	"log":   math.Log10,
+	"sin":   math.Sin,
+	"cos":   math.Cos,
+	"tan":   math.Tan,
+	"floor": math.Floor,
+	"ceil":  math.Ceil,
+	"round": math.Round,
+}
+
+// formulaConstants are named constants available in template formula
+var formulaConstants = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
+
+// formulaNode is evaluated against values of template variables
+type formulaNode interface {
+	eval(values map[string]float64) float64
+}
+
+type formulaNumber float64
+
+type formulaVariable string
+
+type formulaNegation struct {
+	operand formulaNode
+}
+
+type formulaOperation struct {
+	operator    byte
+	left, right formulaNode
+}
+
+type formulaCall struct {
+	function func(float64) float64
+	argument formulaNode
+}
+
+func (n formulaNumber) eval(map[string]float64) float64 {
+	return float64(n)
+}
+
+func (n formulaVariable) eval(values map[string]float64) float64 {
+	if v, ok := values[string(n)]; ok {
+		return v
+	}
+	return math.NaN()
+}
+
+func (n formulaNegation) eval(values map[string]float64) float64 {
+	return -n.operand.eval(values)
+}
+
+func (n formulaOperation) eval(values map[string]float64) float64 {
+	left, right := n.left.eval(values), n.right.eval(values)
+	switch n.operator {
+	case '+':
+		return left + right
+	case '-':
+		return left - right
+	case '*':
+		return left * right
+	case '/':
+		return left / right
+	default:
+		return math.Pow(left, right)
+	}
+}
+
+func (n formulaCall) eval(values map[string]float64) float64 {
+	return n.function(n.argument.eval(values))
+}
+
+// formulaParser is recursive descent parser of arithmetic formula
+type formulaParser struct {
+	text      []rune
+	pos       int
+	variables []string
+}
+
+// parseFormula parses formula like "distance / hours * 3.6" and returns variables it refers to
+func parseFormula(text string) (formulaNode, []string, error) {
+	p := &formulaParser{text: []rune(text)}
+	node, err := p.parseExpression()
+	if err != nil {
+		return nil, nil, err
+	}
+	if p.skipSpace(); p.pos < len(p.text) {
+		return nil, nil, fmt.Errorf("unexpected %q at position %v", p.text[p.pos], p.pos+1)
+	}
+	return node, p.variables, nil
+}
+
+func (p *formulaParser) skipSpace() {
+	for p.pos < len(p.text) && unicode.IsSpace(p.text[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *formulaParser) accept(operators string) (byte, bool) {
+	p.skipSpace()
+	if p.pos < len(p.text) {
+		for k := 0; k < len(operators); k++ {
+			if p.text[p.pos] == rune(operators[k]) {
+				p.pos++
+				return operators[k], true
+			}
+		}
+	}
+	return 0, false
+}
+
+func (p *formulaParser) parseExpression() (formulaNode, error) {
+	// expression := term { ("+" | "-") term }
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		operator, ok := p.accept("+-")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = formulaOperation{operator: operator, left: left, right: right}
+	}
+}
+
+func (p *formulaParser) parseTerm() (formulaNode, error) {
+	// term := unary { ("*" | "/") unary }
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		operator, ok := p.accept("*/")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = formulaOperation{operator: operator, left: left, right: right}
+	}
+}
+
+func (p *formulaParser) parseUnary() (formulaNode, error) {
+	// unary := ("+" | "-") unary | power
+	if operator, ok := p.accept("+-"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if operator == '-' {
+			return formulaNegation{operand: operand}, nil
+		}
+		return operand, nil
+	}
+	return p.parsePower()
+}
+
+func (p *formulaParser) parsePower() (formulaNode, error) {
+	// power := primary [ "^" unary ], exponent is right associative
+	base, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.accept("^"); !ok {
+		return base, nil
+	}
+	exponent, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return formulaOperation{operator: '^', left: base, right: exponent}, nil
+}
+
+func (p *formulaParser) parsePrimary() (formulaNode, error) {
+	// primary := number | "(" expression ")" | name [ "(" expression ")" ]
+	p.skipSpace()
+	if p.pos >= len(p.text) {
+		return nil, fmt.Errorf("unexpected end of formula")
+	}
+	start := p.pos
+	switch r := p.text[p.pos]; {
+	case r == '(':
+		p.pos++
+		node, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.accept(")"); !ok {
+			return nil, fmt.Errorf("missing ) of ( at position %v", start+1)
+		}
+		return node, nil
+	case unicode.IsDigit(r) || r == '.':
+		for p.pos < len(p.text) && (unicode.IsDigit(p.text[p.pos]) || p.text[p.pos] == '.') {
+			p.pos++
+		}
+		if p.pos < len(p.text) && (p.text[p.pos] == 'e' || p.text[p.pos] == 'E') {
+			end := p.pos + 1
+			if end < len(p.text) && (p.text[end] == '+' || p.text[end] == '-') {
+				end++
+			}
+			if end < len(p.text) && unicode.IsDigit(p.text[end]) {
+				for p.pos = end; p.pos < len(p.text) && unicode.IsDigit(p.text[p.pos]); p.pos++ {
+				}
+			}
+		}
+		v, err := strconv.ParseFloat(string(p.text[start:p.pos]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %v at position %v", string(p.text[start:p.pos]), start+1)
+		}
+		return formulaNumber(v), nil
+	case r == '_' || unicode.IsLetter(r):
+		for p.pos < len(p.text) && (p.text[p.pos] == '_' || unicode.IsLetter(p.text[p.pos]) || unicode.IsDigit(p.text[p.pos])) {
+			p.pos++
+		}
+		name := string(p.text[start:p.pos])
+		if function, ok := formulaFunctions[name]; ok {
+			if _, ok := p.accept("("); !ok {
+				return nil, fmt.Errorf("function %v should be followed by (", name)
+			}
+			argument, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := p.accept(")"); !ok {
+				return nil, fmt.Errorf("missing ) of function %v", name)
+			}
+			return formulaCall{function: function, argument: argument}, nil
+		}
+		if v, ok := formulaConstants[name]; ok {
+			return formulaNumber(v), nil
+		}
+		p.variables = append(p.variables, name)
+		return formulaVariable(name), nil
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %v", r, start+1)
+	}
+}
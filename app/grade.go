@@ -0,0 +1,392 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"math"
+	"net/http"
+	"nova/logger"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// errQuestionAnswerFormat is returned when submitted answer does not fit question type
+var errQuestionAnswerFormat = errors.New("answer format incorrect")
+
+// numericAnswerPattern splits numeric answer like "1 234,5 kPa" into number & unit
+var numericAnswerPattern = regexp.MustCompile(`^([+-]?(?:\d[\d.,' \x{00a0}\x{202f}]*)?\d(?:[eE][+-]?\d+)?)\s*(.*)$`)
+
+// numberGroupPattern matches number with thousands grouped by point like 1.234.567
+var numberGroupPattern = regexp.MustCompile(`^[+-]?\d{1,3}(?:\.\d{3})+$`)
+
+// decimalPointLanguages write decimals with point, most other languages use comma
+var decimalPointLanguages = map[string]bool{
+	"en": true, "zh": true, "ja": true, "ko": true, "he": true, "th": true, "hi": true, "ms": true, "ga": true, "mt": true,
+}
+
+func (nova *Nova) HandleCreateQuestionGrade(c *gin.Context) {
+	// grade submitted answer of question
+	var request QuestionSubmission
+	logger.Infof("handle request create question grade")
+	// extract question type & Id from uri
+	questionType := strings.ToLower(c.Param("type"))
+	id := strings.ToLower(c.Param("Id"))
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// numeric answers are parsed in locale of examinee
+	if request.Locale == "" {
+		request.Locale = c.GetHeader("Accept-Language")
+	}
+	// request question type & Id correctness
+	logger.Debugf("check question type & Id is validate")
+	if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question type & Id is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question type & Id is validate")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// check question existence
+	logger.Debugf("check question is existed")
+	if !nova.isQuestionExisted(questionType, id) || !nova.isQuestionVisible(c, questionType, id) {
+		nova.response404NotFound(c, fmt.Errorf("%v question not found", questionType))
+		logger.Errorf("error check question is existed")
+		return
+	}
+	logger.Debugf("successfully check question is existed")
+	// grade answer against standard answer
+	logger.Debugf("grade question answer")
+	response, err := nova.gradeQuestion(questionType, id, request)
+	if err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error grade question answer: %v", err)
+		return
+	}
+	logger.Debugf("successfully grade question answer")
+	// apply hint penalty & reveal guidance
+	logger.Debugf("apply question guidance to grade")
+	if err := nova.applyQuestionGuidance(c, questionType, id, request, &response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error apply question guidance to grade: %v", err)
+		return
+	}
+	logger.Debugf("successfully apply question guidance to grade")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) gradeQuestion(questionType string, id string, submission QuestionSubmission) (QuestionGrade, error) {
+	// grade answer against question in data cache
+	question, err := nova.queryQuestionInDataCache(questionType, id)
+	if err != nil {
+		return QuestionGrade{}, err
+	}
+	grade, err := nova.gradeQuestionContent(question, submission)
+	if err != nil {
+		return QuestionGrade{}, err
+	}
+	grade.Type = questionType
+	grade.Id = id
+	return grade, nil
+}
+
+func (nova *Nova) gradeQuestionContent(question any, submission QuestionSubmission) (QuestionGrade, error) {
+	// grade answer by question type
+	answer := submission.Answer
+	switch q := question.(type) {
+	case QuestionSingleChoice:
+		return gradeSingleChoiceQuestion(q, answer)
+	case QuestionMultipleChoice:
+		return gradeMultipleChoiceQuestion(q, answer)
+	case QuestionJudgement:
+		return gradeJudgementQuestion(q, answer)
+	case QuestionEssay:
+		return gradeEssayQuestion(q, answer)
+	case QuestionFillBlank:
+		return gradeFillBlankQuestion(q, answer)
+	case QuestionMatching:
+		return gradeMatchingQuestion(q, answer)
+	case QuestionOrdering:
+		return gradeOrderingQuestion(q, answer)
+	case QuestionNumeric:
+		return gradeNumericQuestion(q, answer, submission.Locale)
+	case QuestionTemplate:
+		return gradeTemplateQuestion(q, answer, submission)
+	case QuestionCode:
+		var source string
+		if err := json.Unmarshal(answer, &source); err != nil {
+			return QuestionGrade{}, fmt.Errorf("code %w: should be source text", errQuestionAnswerFormat)
+		}
+		grade, _, err := nova.runCodeQuestion(context.Background(), q, source)
+		return grade, err
+	default:
+		return QuestionGrade{}, fmt.Errorf("question %T not supported", question)
+	}
+}
+
+func newQuestionGrade(correct bool) QuestionGrade {
+	grade := QuestionGrade{Graded: true, Correct: correct, MaxScore: 1}
+	if correct {
+		grade.Score = 1
+	}
+	return grade
+}
+
+func gradeSingleChoiceQuestion(question QuestionSingleChoice, answer json.RawMessage) (QuestionGrade, error) {
+	// answer is mark of chosen answer
+	var mark string
+	if err := json.Unmarshal(answer, &mark); err != nil {
+		return QuestionGrade{}, fmt.Errorf("single-choice %w: should be answer mark", errQuestionAnswerFormat)
+	}
+	return newQuestionGrade(normalizeAnswerMark(mark) == normalizeAnswerMark(question.StandardAnswer.AnswerMark)), nil
+}
+
+func gradeMultipleChoiceQuestion(question QuestionMultipleChoice, answer json.RawMessage) (QuestionGrade, error) {
+	// answer is marks of chosen answers, order does not matter
+	var marks []string
+	if err := json.Unmarshal(answer, &marks); err != nil {
+		return QuestionGrade{}, fmt.Errorf("multiple-choice %w: should be list of answer marks", errQuestionAnswerFormat)
+	}
+	chosen := make(map[string]bool, len(marks))
+	for _, mark := range marks {
+		chosen[normalizeAnswerMark(mark)] = true
+	}
+	correct := len(chosen) == len(question.StandardAnswers)
+	for _, v := range question.StandardAnswers {
+		correct = correct && chosen[normalizeAnswerMark(v.AnswerMark)]
+	}
+	return newQuestionGrade(correct), nil
+}
+
+func gradeJudgementQuestion(question QuestionJudgement, answer json.RawMessage) (QuestionGrade, error) {
+	// answer is true or false
+	var judgement bool
+	if err := json.Unmarshal(answer, &judgement); err != nil {
+		return QuestionGrade{}, fmt.Errorf("judgement %w: should be boolean", errQuestionAnswerFormat)
+	}
+	return newQuestionGrade(judgement == question.StandardAnswer), nil
+}
+
+func gradeEssayQuestion(question QuestionEssay, answer json.RawMessage) (QuestionGrade, error) {
+	// essay is graded manually, only answer format is checked
+	var text string
+	if err := json.Unmarshal(answer, &text); err != nil {
+		return QuestionGrade{}, fmt.Errorf("essay %w: should be text", errQuestionAnswerFormat)
+	}
+	return QuestionGrade{Graded: false, MaxScore: 1}, nil
+}
+
+func gradeFillBlankQuestion(question QuestionFillBlank, answer json.RawMessage) (QuestionGrade, error) {
+	// answer is text of every blank in order
+	var texts []string
+	if err := json.Unmarshal(answer, &texts); err != nil || len(texts) != len(question.Blanks) {
+		return QuestionGrade{}, fmt.Errorf("fill-blank %w: should be list of %v texts", errQuestionAnswerFormat, len(question.Blanks))
+	}
+	// every blank earns an equal share of score
+	parts := make([]bool, len(texts))
+	for k, blank := range question.Blanks {
+		parts[k] = isBlankAnswerMatched(blank, texts[k])
+	}
+	return newPartialQuestionGrade(ScoringPartial, parts), nil
+}
+
+func gradeMatchingQuestion(question QuestionMatching, answer json.RawMessage) (QuestionGrade, error) {
+	// answer maps every left item to chosen right item
+	var chosen map[string]string
+	if err := json.Unmarshal(answer, &chosen); err != nil {
+		return QuestionGrade{}, fmt.Errorf("matching %w: should be object of left to right items", errQuestionAnswerFormat)
+	}
+	parts := make([]bool, len(question.Pairs))
+	for k, pair := range question.Pairs {
+		right, ok := chosen[pair.Left]
+		parts[k] = ok && right == pair.Right
+	}
+	return newPartialQuestionGrade(question.Scoring, parts), nil
+}
+
+func gradeOrderingQuestion(question QuestionOrdering, answer json.RawMessage) (QuestionGrade, error) {
+	// answer is all items in chosen order
+	var items []string
+	if err := json.Unmarshal(answer, &items); err != nil || len(items) != len(question.Items) {
+		return QuestionGrade{}, fmt.Errorf("ordering %w: should be list of %v items", errQuestionAnswerFormat, len(question.Items))
+	}
+	parts := make([]bool, len(items))
+	for k, item := range question.Items {
+		parts[k] = items[k] == item
+	}
+	return newPartialQuestionGrade(question.Scoring, parts), nil
+}
+
+func gradeNumericQuestion(question QuestionNumeric, answer json.RawMessage, locale string) (QuestionGrade, error) {
+	// answer is number or text of number followed by unit
+	var value float64
+	var unit string
+	if err := json.Unmarshal(answer, &value); err != nil {
+		var text string
+		if err := json.Unmarshal(answer, &text); err != nil {
+			return QuestionGrade{}, fmt.Errorf("numeric %w: should be number or text", errQuestionAnswerFormat)
+		}
+		value, unit, err = parseNumericAnswer(text, locale)
+		if err != nil {
+			return QuestionGrade{}, fmt.Errorf("numeric %w: %v", errQuestionAnswerFormat, err)
+		}
+	}
+	// convert answer into unit of value, unknown unit is incorrect
+	factor, ok := 1.0, unit == "" || unit == question.Unit
+	for _, v := range question.Units {
+		if v.Symbol == unit {
+			factor, ok = v.Factor, true
+		}
+	}
+	if !ok {
+		return newQuestionGrade(false), nil
+	}
+	return newQuestionGrade(isNumericAnswerMatched(question, value*factor)), nil
+}
+
+func gradeTemplateQuestion(question QuestionTemplate, answer json.RawMessage, submission QuestionSubmission) (QuestionGrade, error) {
+	// answer is computed from instance of submission seed
+	instance, err := newQuestionTemplateInstance(question, submission.Seed)
+	if err != nil {
+		return QuestionGrade{}, err
+	}
+	// without tolerance answer is expected to precision of template
+	numeric := QuestionNumeric{
+		Value:             instance.Answer,
+		AbsoluteTolerance: question.AbsoluteTolerance,
+		RelativeTolerance: question.RelativeTolerance,
+	}
+	if numeric.AbsoluteTolerance == 0 && numeric.RelativeTolerance == 0 {
+		numeric.AbsoluteTolerance = 0.5 * math.Pow10(-question.Precision)
+	}
+	return gradeNumericQuestion(numeric, answer, submission.Locale)
+}
+
+func isNumericAnswerMatched(question QuestionNumeric, value float64) bool {
+	// larger of absolute & relative tolerance applies, float noise is always tolerated
+	tolerance := math.Max(question.AbsoluteTolerance, question.RelativeTolerance*math.Abs(question.Value))
+	tolerance = math.Max(tolerance, 1e-9*math.Max(1, math.Abs(question.Value)))
+	return math.Abs(value-question.Value) <= tolerance
+}
+
+func parseNumericAnswer(text string, locale string) (float64, string, error) {
+	// split number from trailing unit
+	m := numericAnswerPattern.FindStringSubmatch(strings.TrimSpace(text))
+	if m == nil {
+		return 0, "", fmt.Errorf("%v is not a number", text)
+	}
+	number := strings.NewReplacer(" ", "", "\u00a0", "", "\u202f", "", "'", "").Replace(m[1])
+	// decimal separator is the last of mixed separators, otherwise it follows locale
+	decimalComma := isDecimalCommaLocale(locale)
+	dot, comma := strings.LastIndex(number, "."), strings.LastIndex(number, ",")
+	switch {
+	case dot >= 0 && comma >= 0 && comma > dot:
+		number = strings.ReplaceAll(strings.ReplaceAll(number, ".", ""), ",", ".")
+	case dot >= 0 && comma >= 0:
+		number = strings.ReplaceAll(number, ",", "")
+	case comma >= 0 && decimalComma != nil && !*decimalComma && numberGroupPattern.MatchString(strings.ReplaceAll(number, ",", ".")):
+		number = strings.ReplaceAll(number, ",", "")
+	case comma >= 0 && strings.Count(number, ",") == 1:
+		number = strings.Replace(number, ",", ".", 1)
+	case comma >= 0:
+		number = strings.ReplaceAll(number, ",", "")
+	case dot >= 0 && decimalComma != nil && *decimalComma && numberGroupPattern.MatchString(number):
+		number = strings.ReplaceAll(number, ".", "")
+	}
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("%v is not a number", text)
+	}
+	return value, strings.TrimSpace(m[2]), nil
+}
+
+func isDecimalCommaLocale(locale string) *bool {
+	// language of first preference decides, nil if locale is unknown
+	tag := strings.SplitN(strings.SplitN(locale, ",", 2)[0], ";", 2)[0]
+	language := strings.ToLower(strings.TrimSpace(strings.SplitN(strings.ReplaceAll(tag, "_", "-"), "-", 2)[0]))
+	if language == "" || language == "*" {
+		return nil
+	}
+	decimalComma := !decimalPointLanguages[language]
+	return &decimalComma
+}
+
+func newPartialQuestionGrade(scoring string, parts []bool) QuestionGrade {
+	// partial scoring credits every correct part, otherwise all parts should be correct
+	grade := newQuestionGrade(true)
+	grade.Parts = parts
+	correct := 0
+	for _, v := range parts {
+		if v {
+			correct++
+		}
+	}
+	grade.Correct = correct == len(parts)
+	switch {
+	case grade.Correct:
+		grade.Score = grade.MaxScore
+	case scoring == ScoringPartial:
+		grade.Score = grade.MaxScore * float64(correct) / float64(len(parts))
+	default:
+		grade.Score = 0
+	}
+	return grade
+}
+
+func isBlankAnswerMatched(blank QuestionBlank, text string) bool {
+	// any accepted answer matches blank
+	if blank.TrimSpace || blank.Match == BlankMatchNumeric {
+		text = strings.TrimSpace(text)
+	}
+	for _, answer := range blank.Answers {
+		switch blank.Match {
+		case BlankMatchRegex:
+			pattern, err := compileBlankPattern(blank, answer)
+			if err == nil && pattern.MatchString(text) {
+				return true
+			}
+		case BlankMatchNumeric:
+			expected, err := strconv.ParseFloat(strings.TrimSpace(answer), 64)
+			actual, e := strconv.ParseFloat(text, 64)
+			if err == nil && e == nil && math.Abs(expected-actual) <= blank.Tolerance {
+				return true
+			}
+		default:
+			if blank.TrimSpace {
+				answer = strings.TrimSpace(answer)
+			}
+			if answer == text || (blank.IgnoreCase && strings.EqualFold(answer, text)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func compileBlankPattern(blank QuestionBlank, answer string) (*regexp.Regexp, error) {
+	// regular expression should match whole answer
+	pattern := "^(?:" + answer + ")$"
+	if blank.IgnoreCase {
+		pattern = "(?i)" + pattern
+	}
+	return regexp.Compile(pattern)
+}
@@ -0,0 +1,384 @@
+package app
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"nova/utils"
+	"testing"
+)
+
+func setupGradeTestRouter() *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// author changing & grading draft questions
+	createTestAuthor(nova)
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	{
+		/* question management */
+		// question grade related
+		novaService.POST("/question/grade/:type/:Id", nova.HandleCreateQuestionGrade)
+		// question related
+		novaService.POST("/question/multiple-choice/:Id", nova.HandleCreateQuestionMultipleChoice)
+		novaService.POST("/question/fill-blank/:Id", nova.HandleCreateQuestionFillBlank)
+		novaService.PUT("/question/fill-blank/:Id", nova.HandleUpdateQuestionFillBlank)
+		novaService.DELETE("/question/fill-blank/:Id", nova.HandleDeleteQuestionFillBlank)
+		novaService.GET("/question/fill-blank/:Id", nova.HandleQueryQuestionFillBlank)
+		novaService.POST("/question/matching/:Id", nova.HandleCreateQuestionMatching)
+		novaService.POST("/question/ordering/:Id", nova.HandleCreateQuestionOrdering)
+		novaService.GET("/question/ordering/:Id", nova.HandleQueryQuestionOrdering)
+		novaService.POST("/question/numeric/:Id", nova.HandleCreateQuestionNumeric)
+		novaService.GET("/question/numeric/:Id", nova.HandleQueryQuestionNumeric)
+		novaService.POST("/question/template/:Id", nova.HandleCreateQuestionTemplate)
+		novaService.GET("/question/template/:Id/preview", nova.HandleQueryQuestionTemplatePreview)
+	}
+	return router
+}
+
+func startGradeTestService() (*httptest.Server, *gin.Engine) {
+	router := setupGradeTestRouter()
+	return httptest.NewServer(router), router
+}
+
+func gradeTestAnswer(t *testing.T, server *httptest.Server, router *gin.Engine, questionType string, id string, answer any) (int, QuestionGrade) {
+	// marshal answer into submission
+	b, err := json.Marshal(answer)
+	if err != nil {
+		t.Errorf("error marshal answer: %v", err)
+	}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/grade/"+questionType+"/"+id, QuestionSubmission{Answer: b}, testAuthorToken)
+	var grade QuestionGrade
+	if w.Code == http.StatusOK {
+		unmarshalTestResponse(t, w, &grade)
+	}
+	return w.Code, grade
+}
+
+func TestNova_HandleCreateQuestionFillBlank(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateQuestionFillBlank
+	// Test Purpose: Test fill-blank question CRUD & placeholder validation
+	// Test Steps:
+	// 1. send CreateQuestion request with missing placeholder by using POST method
+	// 2. receive CreateQuestion response with field errors by using 400 Bad Request Code
+	// 3. send CreateQuestion request with two blanks by using POST method
+	// 4. receive CreateQuestion response with created question by using 201 Created Code
+	// 5. send UpdateQuestion & QueryQuestion request, receive updated question by using 200 OK Code
+	// 6. send DeleteQuestion request, receive response by using 204 No Content Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startGradeTestService()
+	defer server.Close()
+	/* create fill-blank question without placeholder */
+	question := QuestionFillBlank{
+		Id:    uuid.New().String(),
+		Title: "The capital of " + utils.RandomAlphabet(12) + " is {{1}}.",
+		Blanks: []QuestionBlank{
+			{Answers: []string{"paris"}, Match: BlankMatchText, IgnoreCase: true, TrimSpace: true},
+			{Answers: []string{"[0-9]+"}, Match: BlankMatchRegex},
+		},
+	}
+	url := server.URL + "/nova/v1/question/fill-blank/" + question.Id
+	w := serveTestRequest(t, router, http.MethodPost, url, question, testAuthorToken)
+	// return response
+	var problemDetails ProblemDetails
+	unmarshalTestResponse(t, w, &problemDetails)
+	// validate response
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, []FieldError{{Field: "title", Reason: "placeholder {{2}} is missing"}}, problemDetails.Errors)
+	/* create fill-blank question */
+	question.Title += " It was founded in year {{2}}."
+	w = serveTestRequest(t, router, http.MethodPost, url, question, testAuthorToken)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	/* update & query fill-blank question */
+	question.Blanks[1] = QuestionBlank{Answers: []string{"250"}, Match: BlankMatchNumeric, Tolerance: 50}
+	w = serveTestRequest(t, router, http.MethodPut, url, question, testAuthorToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, testAuthorToken)
+	// return response
+	var resQuestion QuestionFillBlank
+	unmarshalTestResponse(t, w, &resQuestion)
+	// validate response
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, question, resQuestion)
+	/* delete fill-blank question */
+	w = serveTestRequest(t, router, http.MethodDelete, url, nil, testAuthorToken)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestNova_HandleCreateQuestionGrade(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateQuestionGrade
+	// Test Purpose: Test HandleCreateQuestionGrade grade answers with matching options
+	// Test Steps:
+	// 1. send CreateQuestion request for fill-blank & multiple-choice questions
+	// 2. send CreateQuestionGrade request with correct, partial & malformed answers
+	// 3. receive CreateQuestionGrade response with score by using 200 OK Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startGradeTestService()
+	defer server.Close()
+	/* create fill-blank question */
+	fillBlank := QuestionFillBlank{
+		Id:    uuid.New().String(),
+		Title: "Water of " + utils.RandomAlphabet(12) + " is {{1}} and boils at {{2}} degrees in {{3}}.",
+		Blanks: []QuestionBlank{
+			{Answers: []string{"H2O"}, Match: BlankMatchText, IgnoreCase: true, TrimSpace: true},
+			{Answers: []string{"100"}, Match: BlankMatchNumeric, Tolerance: 0.5},
+			{Answers: []string{"celsius|centigrade"}, Match: BlankMatchRegex, IgnoreCase: true},
+		},
+	}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/fill-blank/"+fillBlank.Id, fillBlank, testAuthorToken)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	/* grade fill-blank answers */
+	code, grade := gradeTestAnswer(t, server, router, QuestionTypeFillBlank, fillBlank.Id, []string{" h2o ", "99.8", "Centigrade"})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, QuestionGrade{Type: QuestionTypeFillBlank, Id: fillBlank.Id, Graded: true, Correct: true, Score: 1, MaxScore: 1, Parts: []bool{true, true, true}}, grade)
+	code, grade = gradeTestAnswer(t, server, router, QuestionTypeFillBlank, fillBlank.Id, []string{"H2O", "98", "kelvin"})
+	assert.Equal(t, http.StatusOK, code)
+	assert.False(t, grade.Correct)
+	assert.Equal(t, []bool{true, false, false}, grade.Parts)
+	assert.InDelta(t, 1.0/3, grade.Score, 1e-9)
+	code, _ = gradeTestAnswer(t, server, router, QuestionTypeFillBlank, fillBlank.Id, []string{"H2O"})
+	assert.Equal(t, http.StatusBadRequest, code)
+	/* grade multiple-choice answers */
+	multipleChoice := QuestionMultipleChoice{
+		Id:    uuid.New().String(),
+		Title: "Which of " + utils.RandomAlphabet(12) + " are primes?",
+		Answers: []QuestionAnswer{
+			{"A", "2"},
+			{"B", "4"},
+			{"C", "5"},
+		},
+		StandardAnswers: []QuestionAnswer{{"A", "2"}, {"C", "5"}},
+	}
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/multiple-choice/"+multipleChoice.Id, multipleChoice, testAuthorToken)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	code, grade = gradeTestAnswer(t, server, router, QuestionTypeMultipleChoice, multipleChoice.Id, []string{"c", "a"})
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, grade.Correct)
+	code, grade = gradeTestAnswer(t, server, router, QuestionTypeMultipleChoice, multipleChoice.Id, []string{"A"})
+	assert.Equal(t, http.StatusOK, code)
+	assert.False(t, grade.Correct)
+	/* grade unknown question */
+	code, _ = gradeTestAnswer(t, server, router, QuestionTypeFillBlank, uuid.New().String(), []string{})
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestNova_HandleCreateQuestionMatchingGrade(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateQuestionMatchingGrade
+	// Test Purpose: Test matching & ordering questions grade with all-or-nothing & partial credit
+	// Test Steps:
+	// 1. send CreateQuestion request for matching question with partial scoring
+	// 2. send CreateQuestionGrade request, receive per-pair credit by using 200 OK Code
+	// 3. send CreateQuestion request for ordering question with all-or-nothing scoring
+	// 4. send CreateQuestionGrade request, receive zero score for wrong position by using 200 OK Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startGradeTestService()
+	defer server.Close()
+	/* create matching question */
+	matching := QuestionMatching{
+		Id:    uuid.New().String(),
+		Title: "Match " + utils.RandomAlphabet(12) + " protocols to their ports",
+		Pairs: []QuestionPair{
+			{"HTTP", "80"},
+			{"HTTPS", "443"},
+			{"SSH", "22"},
+			{"DNS", "53"},
+		},
+		Scoring: ScoringPartial,
+	}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/matching/"+matching.Id, matching, testAuthorToken)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	/* grade matching answer */
+	code, grade := gradeTestAnswer(t, server, router, QuestionTypeMatching, matching.Id, map[string]string{"HTTP": "80", "HTTPS": "22", "SSH": "443", "DNS": "53"})
+	assert.Equal(t, http.StatusOK, code)
+	assert.False(t, grade.Correct)
+	assert.Equal(t, 0.5, grade.Score)
+	assert.Equal(t, []bool{true, false, false, true}, grade.Parts)
+	/* create ordering question with duplicated items */
+	ordering := QuestionOrdering{
+		Id:    uuid.New().String(),
+		Title: "Put " + utils.RandomAlphabet(12) + " TCP handshake steps in order",
+		Items: []string{"SYN", "SYN-ACK", "SYN"},
+	}
+	url := server.URL + "/nova/v1/question/ordering/" + ordering.Id
+	w = serveTestRequest(t, router, http.MethodPost, url, ordering, testAuthorToken)
+	var problemDetails ProblemDetails
+	unmarshalTestResponse(t, w, &problemDetails)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, []FieldError{{Field: "items[2]", Reason: "duplicates item SYN"}}, problemDetails.Errors)
+	/* create ordering question */
+	ordering.Items = []string{"SYN", "SYN-ACK", "ACK"}
+	w = serveTestRequest(t, router, http.MethodPost, url, ordering, testAuthorToken)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, testAuthorToken)
+	var resQuestion QuestionOrdering
+	unmarshalTestResponse(t, w, &resQuestion)
+	assert.Equal(t, ordering, resQuestion)
+	/* grade ordering answer */
+	code, grade = gradeTestAnswer(t, server, router, QuestionTypeOrdering, ordering.Id, []string{"SYN", "ACK", "SYN-ACK"})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, QuestionGrade{Type: QuestionTypeOrdering, Id: ordering.Id, Graded: true, MaxScore: 1, Parts: []bool{true, false, false}}, grade)
+	code, grade = gradeTestAnswer(t, server, router, QuestionTypeOrdering, ordering.Id, []string{"SYN", "SYN-ACK", "ACK"})
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, grade.Correct)
+	assert.Equal(t, 1.0, grade.Score)
+}
+
+func TestNova_HandleCreateQuestionNumericGrade(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateQuestionNumericGrade
+	// Test Purpose: Test numeric question grade with tolerance, unit conversion & locale
+	// Test Steps:
+	// 1. send CreateQuestion request for numeric question with kPa & Pa units
+	// 2. send QueryQuestion request, receive created question by using 200 OK Code
+	// 3. send CreateQuestionGrade request with answers in different units & locales
+	// 4. receive CreateQuestionGrade response by using 200 OK Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startGradeTestService()
+	defer server.Close()
+	/* create numeric question */
+	question := QuestionNumeric{
+		Id:                uuid.New().String(),
+		Title:             "What is the pressure of " + utils.RandomAlphabet(12) + "?",
+		Value:             1234.5,
+		RelativeTolerance: 0.001,
+		Unit:              "kPa",
+		Units:             []QuestionUnit{{"Pa", 0.001}, {"MPa", 1000}},
+	}
+	url := server.URL + "/nova/v1/question/numeric/" + question.Id
+	w := serveTestRequest(t, router, http.MethodPost, url, question, testAuthorToken)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, testAuthorToken)
+	var resQuestion QuestionNumeric
+	unmarshalTestResponse(t, w, &resQuestion)
+	assert.Equal(t, question, resQuestion)
+	/* grade numeric answers */
+	for _, v := range []struct {
+		answer  any
+		locale  string
+		correct bool
+	}{
+		{1234.5, "", true},
+		{"1,234.5 kPa", "en-US", true},
+		{"1.234,5 kPa", "de-DE", true},
+		{"1 234,5kPa", "fr", true},
+		{"1235", "", true},
+		{"1,2345 MPa", "", true},
+		{"1234500 Pa", "", true},
+		{"1,230 kPa", "en", false},
+		{"1234.5 psi", "", false},
+	} {
+		b, _ := json.Marshal(v.answer)
+		w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/grade/numeric/"+question.Id, QuestionSubmission{Answer: b, Locale: v.locale}, testAuthorToken)
+		var grade QuestionGrade
+		unmarshalTestResponse(t, w, &grade)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, v.correct, grade.Correct, "answer %v in locale %v", v.answer, v.locale)
+	}
+	/* grade malformed numeric answer */
+	code, _ := gradeTestAnswer(t, server, router, QuestionTypeNumeric, question.Id, "about twelve hundred")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestNova_HandleQueryQuestionTemplatePreview(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleQueryQuestionTemplatePreview
+	// Test Purpose: Test template question generates deterministic instances & grades them
+	// Test Steps:
+	// 1. send CreateQuestion request for template question with undefined formula variable
+	// 2. receive CreateQuestion response with field errors by using 400 Bad Request Code
+	// 3. send CreateQuestion request for template question by using POST method
+	// 4. send QueryQuestionTemplatePreview request twice, receive the same instances
+	// 5. send CreateQuestionGrade request with seed of instance by using 200 OK Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startGradeTestService()
+	defer server.Close()
+	/* create template question with undefined variable */
+	question := QuestionTemplate{
+		Id:    uuid.New().String(),
+		Title: "A " + utils.RandomAlphabet(8) + " travels {{distance}} km in {{hours}} hours, what is its speed in km/h?",
+		Variables: []QuestionVariable{
+			{Name: "distance", Min: 100, Max: 300, Step: 10},
+			{Name: "hours", Values: []float64{2, 4, 5}},
+		},
+		Formula:   "distance / time",
+		Precision: 1,
+	}
+	url := server.URL + "/nova/v1/question/template/" + question.Id
+	w := serveTestRequest(t, router, http.MethodPost, url, question, testAuthorToken)
+	var problemDetails ProblemDetails
+	unmarshalTestResponse(t, w, &problemDetails)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, []FieldError{{Field: "formula", Reason: "variable time is not defined"}}, problemDetails.Errors)
+	/* create template question */
+	question.Formula = "distance / hours"
+	w = serveTestRequest(t, router, http.MethodPost, url, question, testAuthorToken)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	/* preview instances */
+	w = serveTestRequest(t, router, http.MethodGet, url+"/preview?seed=7&count=3", nil, testAuthorToken)
+	var instances []QuestionTemplateInstance
+	unmarshalTestResponse(t, w, &instances)
+	assert.Equal(t, http.StatusOK, w.Code)
+	if assert.Len(t, instances, 3) {
+		for k, instance := range instances {
+			assert.Equal(t, int64(7+k), instance.Seed)
+			assert.NotContains(t, instance.Title, "{{")
+			assert.Contains(t, []float64{2, 4, 5}, instance.Variables["hours"])
+			assert.True(t, instance.Variables["distance"] >= 100 && instance.Variables["distance"] <= 300)
+			assert.Equal(t, roundToDecimals(instance.Variables["distance"]/instance.Variables["hours"], 1), instance.Answer)
+		}
+	}
+	w = serveTestRequest(t, router, http.MethodGet, url+"/preview?seed=7&count=3", nil, testAuthorToken)
+	var again []QuestionTemplateInstance
+	unmarshalTestResponse(t, w, &again)
+	assert.Equal(t, instances, again)
+	w = serveTestRequest(t, router, http.MethodGet, url+"/preview?count=100", nil, testAuthorToken)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	/* grade answers of instance */
+	if len(instances) == 0 {
+		return
+	}
+	instance := instances[1]
+	for _, v := range []struct {
+		answer  float64
+		correct bool
+	}{
+		{instance.Answer, true},
+		{instance.Answer + 0.04, true},
+		{instance.Answer + 1, false},
+	} {
+		b, _ := json.Marshal(v.answer)
+		w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/grade/template/"+question.Id, QuestionSubmission{Answer: b, Seed: instance.Seed}, testAuthorToken)
+		var grade QuestionGrade
+		unmarshalTestResponse(t, w, &grade)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, v.correct, grade.Correct, "answer %v of seed %v", v.answer, instance.Seed)
+	}
+}
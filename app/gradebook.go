@@ -0,0 +1,423 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"mime"
+	"net/http"
+	"nova/logger"
+	"sort"
+	"strings"
+	"time"
+)
+
+// gradebookFilter selects submitted attempts of gradebook, zero values match everything but scope
+type gradebookFilter struct {
+	examId  string
+	groupId string
+	userId  string
+	users   map[string]bool
+	from    time.Time
+	to      time.Time
+	// scope is tenant scope of principal, only attempts of exams in scope are selected
+	scope string
+}
+
+func (nova *Nova) HandleQueryGradebooks(c *gin.Context) {
+	// query gradebooks of every user for instructors, optionally exported as csv or xlsx
+	logger.Infof("handle request query gradebooks")
+	// extract filters & export format from query
+	filter, err := queryGradebookFilter(c)
+	if err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check gradebook filter is validate: %v", err)
+		return
+	}
+	format := strings.ToLower(c.DefaultQuery("format", GradebookFormatJSON))
+	sheet := strings.ToLower(c.DefaultQuery("sheet", "attempts"))
+	if format != GradebookFormatJSON && format != GradebookFormatCSV && format != GradebookFormatXLSX {
+		nova.response400BadRequest(c, fmt.Errorf("format %v not supported", format))
+		logger.Errorf("error check gradebook format is validate: %v", format)
+		return
+	}
+	if sheet != "attempts" && sheet != "mastery" {
+		nova.response400BadRequest(c, fmt.Errorf("sheet %v not supported", sheet))
+		logger.Errorf("error check gradebook sheet is validate: %v", sheet)
+		return
+	}
+	// examinees only see their own gradebook
+	logger.Debugf("check principal is allowed to query gradebooks")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to query gradebooks"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to query gradebooks"))
+		logger.Errorf("error check principal is allowed to query gradebooks")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to query gradebooks")
+	// check exam & group of filter existence
+	if ok := nova.checkGradebookScope(c, &filter); !ok {
+		return
+	}
+	// build gradebooks from submitted attempts
+	logger.Debugf("query gradebooks in database")
+	gradebooks, err := nova.queryGradebooks(filter)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query gradebooks in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query gradebooks in database")
+	// export gradebooks as spreadsheet
+	if format != GradebookFormatJSON {
+		var b bytes.Buffer
+		sheets := gradebookSheets(gradebooks)
+		contentType := "text/csv; charset=utf-8"
+		if format == GradebookFormatCSV && sheet == "mastery" {
+			err = writeSpreadsheetCSV(&b, sheets[1])
+		} else if format == GradebookFormatCSV {
+			err = writeSpreadsheetCSV(&b, sheets[0])
+		} else {
+			contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+			err = writeSpreadsheetXLSX(&b, sheets)
+		}
+		if err != nil {
+			nova.response500InternalServerError(c, err)
+			logger.Errorf("error export gradebooks: %v", err)
+			return
+		}
+		c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": "gradebook." + format}))
+		c.Data(http.StatusOK, contentType, b.Bytes())
+		logger.Infof("response status code: %v, body: %v", http.StatusOK, len(gradebooks))
+		return
+	}
+	response := GradebookReport{ExamId: filter.examId, GroupId: filter.groupId, Users: gradebooks}
+	if !filter.from.IsZero() {
+		response.From = &filter.from
+	}
+	if !filter.to.IsZero() {
+		response.To = &filter.to
+	}
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, len(gradebooks))
+	return
+}
+
+func (nova *Nova) HandleQueryUserGradebook(c *gin.Context) {
+	// query gradebook of user
+	logger.Infof("handle request query user gradebook")
+	// extract userId from uri & filters from query
+	userId := c.Param("userId")
+	filter, err := queryGradebookFilter(c)
+	if err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check gradebook filter is validate: %v", err)
+		return
+	}
+	filter.userId = userId
+	// examinees only see their own gradebook
+	logger.Debugf("check principal is allowed to query gradebook")
+	if user, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to query gradebook"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee && user.UserId != userId {
+		nova.response403Forbidden(c, errors.New("examinee is only allowed to query own gradebook"))
+		logger.Errorf("error check principal is allowed to query gradebook")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to query gradebook")
+	// update data cache by querying users in database
+	logger.Debugf("update data cache by querying users in database")
+	if err := nova.queryUsersInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying users in database: %v", err)
+		return
+	}
+	if _, err := nova.queryUserInDataCache(userId); err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query user in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying users in database")
+	// check exam & group of filter existence
+	if ok := nova.checkGradebookScope(c, &filter); !ok {
+		return
+	}
+	// build gradebook from submitted attempts
+	logger.Debugf("query gradebook in database")
+	gradebooks, err := nova.queryGradebooks(filter)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query gradebook in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query gradebook in database")
+	response := Gradebook{UserId: userId, Attempts: []GradebookAttempt{}, Mastery: []CategoryMastery{}}
+	if len(gradebooks) > 0 {
+		response = gradebooks[0]
+	}
+	if user, err := nova.queryUserInDataCache(userId); err == nil {
+		response.Username = user.Username
+	}
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response.UserId)
+	return
+}
+
+func queryGradebookFilter(c *gin.Context) (gradebookFilter, error) {
+	// dates cover whole day, timestamps are exact bounds
+	filter := gradebookFilter{examId: strings.ToLower(c.Query("exam")), groupId: strings.ToLower(c.Query("group"))}
+	if filter.examId != "" {
+		if err := uuid.Validate(filter.examId); err != nil {
+			return gradebookFilter{}, errors.New("exam format incorrect")
+		}
+	}
+	if filter.groupId != "" {
+		if err := uuid.Validate(filter.groupId); err != nil {
+			return gradebookFilter{}, errors.New("group format incorrect")
+		}
+	}
+	for _, v := range []struct {
+		name  string
+		value *time.Time
+		end   bool
+	}{{"from", &filter.from, false}, {"to", &filter.to, true}} {
+		s := c.Query(v.name)
+		if s == "" {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			*v.value = t.UTC()
+		} else if t, err := time.Parse(time.DateOnly, s); err == nil {
+			if v.end {
+				t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			}
+			*v.value = t
+		} else {
+			return gradebookFilter{}, fmt.Errorf("%v should be date or RFC 3339 time", v.name)
+		}
+	}
+	if !filter.from.IsZero() && !filter.to.IsZero() && filter.to.Before(filter.from) {
+		return gradebookFilter{}, errors.New("to should not be before from")
+	}
+	return filter, nil
+}
+
+func (nova *Nova) checkGradebookScope(c *gin.Context, filter *gradebookFilter) bool {
+	// filtering by unknown exam or group is not found rather than empty
+	filter.scope = nova.queryTenantScope(c)
+	if filter.examId != "" {
+		if !nova.isResourceInScope(filter.scope, TenantResourceExam, filter.examId) {
+			nova.response404NotFound(c, errExamNotFound)
+			logger.Errorf("error check exam %v is in tenant scope", filter.examId)
+			return false
+		}
+		if _, err := nova.db.QueryExam(filter.examId); err != nil {
+			if errors.Is(err, errExamNotFound) {
+				nova.response404NotFound(c, err)
+			} else {
+				nova.response500InternalServerError(c, err)
+			}
+			logger.Errorf("error query exam in database: %v", err)
+			return false
+		}
+	}
+	if filter.groupId != "" {
+		if _, ok := nova.queryGroupInDatabase(c, filter.groupId); !ok {
+			return false
+		}
+		users, err := nova.queryGroupMemberSet(filter.groupId)
+		if err != nil {
+			nova.response500InternalServerError(c, err)
+			logger.Errorf("error query group members in database: %v", err)
+			return false
+		}
+		filter.users = users
+	}
+	return true
+}
+
+func (f gradebookFilter) isMatched(attempt *ExamAttempt) bool {
+	if f.userId != "" && attempt.UserId != f.userId {
+		return false
+	}
+	if f.users != nil && !f.users[attempt.UserId] {
+		return false
+	}
+	if attempt.SubmittedAt == nil {
+		return false
+	}
+	if !f.from.IsZero() && attempt.SubmittedAt.Before(f.from) {
+		return false
+	}
+	return f.to.IsZero() || !attempt.SubmittedAt.After(f.to)
+}
+
+func (nova *Nova) queryGradebooks(filter gradebookFilter) ([]Gradebook, error) {
+	// percentile ranks compare every submitted attempt of exam, filters only select rows
+	attempts, err := nova.db.QuerySubmittedExamAttempts(filter.examId)
+	if err != nil {
+		return nil, err
+	}
+	classifications, err := nova.db.QueryQuestionClassifications()
+	if err != nil {
+		return nil, err
+	}
+	if err := nova.queryUsersInDatabase(); err != nil {
+		return nil, err
+	}
+	categories := make(map[string][]string, len(classifications))
+	for _, v := range classifications {
+		categories[v.Type+"/"+v.Id] = queryMasteryCategories(v)
+	}
+	percentiles := queryExamAttemptPercentiles(attempts)
+	titles := make(map[string]string)
+	gradebooks := make(map[string]*Gradebook)
+	mastery := make(map[string]map[string]*CategoryMastery)
+	for _, attempt := range attempts {
+		if !filter.isMatched(attempt) || !nova.isResourceInScope(filter.scope, TenantResourceExam, attempt.ExamId) {
+			continue
+		}
+		title, ok := titles[attempt.ExamId]
+		if !ok {
+			if exam, err := nova.db.QueryExam(attempt.ExamId); err == nil {
+				title = exam.Title
+			}
+			titles[attempt.ExamId] = title
+		}
+		gradebook, ok := gradebooks[attempt.UserId]
+		if !ok {
+			gradebook = &Gradebook{UserId: attempt.UserId, Attempts: []GradebookAttempt{}, Mastery: []CategoryMastery{}}
+			if user, err := nova.queryUserInDataCache(attempt.UserId); err == nil {
+				gradebook.Username = user.Username
+			}
+			gradebooks[attempt.UserId], mastery[attempt.UserId] = gradebook, make(map[string]*CategoryMastery)
+		}
+		gradebook.Attempts = append(gradebook.Attempts, GradebookAttempt{
+			AttemptId:   attempt.AttemptId,
+			ExamId:      attempt.ExamId,
+			ExamTitle:   title,
+			Score:       attempt.Score,
+			MaxScore:    attempt.MaxScore,
+			Percent:     queryExamAttemptPercent(attempt),
+			Percentile:  percentiles[attempt.AttemptId],
+			Late:        attempt.Late,
+			SubmittedAt: *attempt.SubmittedAt,
+		})
+		// every graded answer counts toward category & tags of its question
+		for _, grade := range attempt.Grades {
+			if !grade.Graded || grade.MaxScore <= 0 {
+				continue
+			}
+			for _, category := range categories[grade.Type+"/"+grade.Id] {
+				v, ok := mastery[attempt.UserId][category]
+				if !ok {
+					v = &CategoryMastery{Category: category}
+					mastery[attempt.UserId][category] = v
+				}
+				v.Questions++
+				v.Score += grade.Score
+				v.MaxScore += grade.MaxScore
+			}
+		}
+	}
+	response := make([]Gradebook, 0, len(gradebooks))
+	for userId, gradebook := range gradebooks {
+		total := 0.0
+		for _, v := range gradebook.Attempts {
+			total += v.Percent
+		}
+		gradebook.AverageScore = roundToDecimals(total/float64(len(gradebook.Attempts)), 2)
+		for _, v := range mastery[userId] {
+			v.Score, v.MaxScore = roundToDecimals(v.Score, 2), roundToDecimals(v.MaxScore, 2)
+			v.Mastery = roundToDecimals(v.Score/v.MaxScore, 3)
+			gradebook.Mastery = append(gradebook.Mastery, *v)
+		}
+		sort.Slice(gradebook.Mastery, func(i, j int) bool { return gradebook.Mastery[i].Category < gradebook.Mastery[j].Category })
+		response = append(response, *gradebook)
+	}
+	sort.Slice(response, func(i, j int) bool {
+		if response[i].Username != response[j].Username {
+			return response[i].Username < response[j].Username
+		}
+		return response[i].UserId < response[j].UserId
+	})
+	return response, nil
+}
+
+func queryMasteryCategories(classification *QuestionClassification) []string {
+	// category & tags of question, compared case-insensitively
+	var categories []string
+	seen := make(map[string]bool)
+	for _, v := range append([]string{classification.Category}, classification.Tags...) {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v != "" && !seen[v] {
+			seen[v] = true
+			categories = append(categories, v)
+		}
+	}
+	return categories
+}
+
+func queryExamAttemptPercent(attempt *ExamAttempt) float64 {
+	if attempt.MaxScore <= 0 {
+		return 0
+	}
+	return roundToDecimals(attempt.Score/attempt.MaxScore*100, 2)
+}
+
+func queryExamAttemptPercentiles(attempts []*ExamAttempt) map[string]float64 {
+	// percentile rank of attempt is computed among attempts of the same exam
+	percents := make(map[string][]float64)
+	for _, attempt := range attempts {
+		percents[attempt.ExamId] = append(percents[attempt.ExamId], queryExamAttemptPercent(attempt))
+	}
+	percentiles := make(map[string]float64, len(attempts))
+	for _, attempt := range attempts {
+		percentiles[attempt.AttemptId] = queryPercentileRank(percents[attempt.ExamId], queryExamAttemptPercent(attempt))
+	}
+	return percentiles
+}
+
+func queryPercentileRank(values []float64, value float64) float64 {
+	// percentile rank counts values below & half of values equal to value
+	below, same := 0.0, 0.0
+	for _, v := range values {
+		if v < value {
+			below++
+		} else if v == value {
+			same++
+		}
+	}
+	if len(values) == 0 {
+		return 0
+	}
+	return roundToDecimals((below+same/2)/float64(len(values))*100, 1)
+}
+
+func gradebookSheets(gradebooks []Gradebook) []spreadsheetSheet {
+	// attempts & category mastery are exported as separate sheets
+	attempts := spreadsheetSheet{Name: "Attempts", Rows: [][]any{{"user_id", "username", "exam_id", "exam_title", "attempt_id",
+		"submitted_at", "score", "max_score", "percent", "percentile", "late"}}}
+	mastery := spreadsheetSheet{Name: "Mastery", Rows: [][]any{{"user_id", "username", "category", "questions", "score", "max_score",
+		"mastery"}}}
+	for _, gradebook := range gradebooks {
+		for _, v := range gradebook.Attempts {
+			attempts.Rows = append(attempts.Rows, []any{gradebook.UserId, gradebook.Username, v.ExamId, v.ExamTitle, v.AttemptId,
+				v.SubmittedAt, v.Score, v.MaxScore, v.Percent, v.Percentile, v.Late})
+		}
+		for _, v := range gradebook.Mastery {
+			mastery.Rows = append(mastery.Rows, []any{gradebook.UserId, gradebook.Username, v.Category, v.Questions, v.Score, v.MaxScore,
+				v.Mastery})
+		}
+	}
+	return []spreadsheetSheet{attempts, mastery}
+}
@@ -0,0 +1,180 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"nova/utils"
+	"strings"
+	"testing"
+	"time"
+)
+
+func setupGradebookTestRouter() *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// first administrator is granted by command line
+	setupTestAdministrator(nova)
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	{
+		/* user management */
+		novaService.POST("/user/:userId", nova.HandleCreateUser)
+		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
+		novaService.PUT("/user/role/:userId", nova.HandleUpdateUserRole)
+		/* exam management */
+		novaService.POST("/exam/:examId", nova.HandleCreateExam)
+		novaService.POST("/exam/:examId/attempt", nova.HandleCreateExamAttempt)
+		novaService.POST("/exam/attempt/:attemptId/submission", nova.HandleCreateExamSubmission)
+		/* gradebook management */
+		novaService.GET("/gradebook", nova.HandleQueryGradebooks)
+		novaService.GET("/gradebook/:userId", nova.HandleQueryUserGradebook)
+		/* question management */
+		// question workflow related
+		novaService.POST("/question/workflow/:type/:Id/transition", nova.HandleCreateQuestionTransition)
+		novaService.POST("/question/workflow/:type/:Id/approve", nova.HandleCreateQuestionApproval)
+		// question classification related
+		novaService.PUT("/question/classification/:type/:Id", nova.HandleUpdateQuestionClassification)
+		// question related
+		novaService.POST("/question/judgement/:Id", nova.HandleCreateQuestionJudgement)
+	}
+	return router
+}
+
+func startGradebookTestService() (*httptest.Server, *gin.Engine) {
+	router := setupGradebookTestRouter()
+	return httptest.NewServer(router), router
+}
+
+func TestNova_HandleQueryGradebooks(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleQueryGradebooks
+	// Test Purpose: Test gradebooks report scores, percentiles & mastery of categories
+	// Test Steps:
+	// 1. send CreateExamAttempt & CreateExamSubmission requests of two examinees
+	// 2. send QueryGradebooks request with examinee session, receive 403 Forbidden Code
+	// 3. send QueryGradebooks request, receive percentiles & mastery by using 200 OK Code
+	// 4. send QueryGradebooks request with date range & export formats
+	// 5. send QueryUserGradebook request of examinee, receive own gradebook by using 200 OK Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startGradebookTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	strong, strongSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	weak, weakSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	exam := Exam{Id: uuid.New().String(), Title: "Name resolution quiz " + utils.RandomAlphabet(12)}
+	for _, classification := range []QuestionClassification{
+		{Category: "Networking", Tags: []string{"dns"}},
+		{Category: "Security", Tags: []string{"DNS", "tls"}},
+	} {
+		question := QuestionJudgement{Id: uuid.New().String(), Title: "Resolvers cache answers " + utils.RandomAlphabet(12), StandardAnswer: true}
+		createExamTestQuestion(t, server, router, QuestionTypeJudgement, question.Id, question, admin.Token, true)
+		w := serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/question/classification/judgement/"+question.Id, classification, admin.Token)
+		assert.Equal(t, http.StatusOK, w.Code)
+		exam.Questions = append(exam.Questions, ExamQuestion{Type: QuestionTypeJudgement, Id: question.Id})
+	}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id, exam, admin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	/* strong examinee answers both questions correctly, weak examinee only first */
+	for token, answers := range map[string][]string{strongSession.Token: {"true", "true"}, weakSession.Token: {"true", "false"}} {
+		w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id+"/attempt", nil, token)
+		var attempt ExamAttempt
+		unmarshalTestResponse(t, w, &attempt)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		submission := ExamSubmission{}
+		for k, question := range exam.Questions {
+			submission.Answers = append(submission.Answers, ExamAnswer{Type: question.Type, Id: question.Id, Answer: []byte(answers[k])})
+		}
+		w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/attempt/"+attempt.AttemptId+"/submission", submission, token)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+	/* examinees do not see gradebooks of others */
+	url := server.URL + "/nova/v1/gradebook"
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, strongSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, url+"/"+weak.UserId, nil, strongSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, url+"?from=yesterday", nil, admin.Token)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, url+"?exam="+uuid.New().String(), nil, admin.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	/* gradebooks of exam */
+	today := time.Now().UTC().Format(time.DateOnly)
+	w = serveTestRequest(t, router, http.MethodGet, url+"?exam="+exam.Id+"&from="+today+"&to="+today, nil, admin.Token)
+	var report GradebookReport
+	unmarshalTestResponse(t, w, &report)
+	assert.Equal(t, http.StatusOK, w.Code)
+	if !assert.Len(t, report.Users, 2) {
+		return
+	}
+	gradebooks := map[string]Gradebook{}
+	for _, v := range report.Users {
+		gradebooks[v.UserId] = v
+	}
+	if assert.Len(t, gradebooks[strong.UserId].Attempts, 1) && assert.Len(t, gradebooks[weak.UserId].Attempts, 1) {
+		assert.Equal(t, 100.0, gradebooks[strong.UserId].Attempts[0].Percent)
+		assert.Equal(t, 75.0, gradebooks[strong.UserId].Attempts[0].Percentile)
+		assert.Equal(t, exam.Title, gradebooks[strong.UserId].Attempts[0].ExamTitle)
+		assert.Equal(t, 50.0, gradebooks[weak.UserId].Attempts[0].Percent)
+		assert.Equal(t, 25.0, gradebooks[weak.UserId].Attempts[0].Percentile)
+	}
+	assert.Equal(t, []CategoryMastery{
+		{Category: "dns", Questions: 2, Score: 1, MaxScore: 2, Mastery: 0.5},
+		{Category: "networking", Questions: 1, Score: 1, MaxScore: 1, Mastery: 1},
+		{Category: "security", Questions: 1, Score: 0, MaxScore: 1, Mastery: 0},
+		{Category: "tls", Questions: 1, Score: 0, MaxScore: 1, Mastery: 0},
+	}, gradebooks[weak.UserId].Mastery)
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format(time.DateOnly)
+	w = serveTestRequest(t, router, http.MethodGet, url+"?from="+tomorrow, nil, admin.Token)
+	report = GradebookReport{}
+	unmarshalTestResponse(t, w, &report)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, report.Users)
+	/* export gradebooks */
+	w = serveTestRequest(t, router, http.MethodGet, url+"?format=csv&sheet=mastery", nil, admin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/csv")
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "gradebook.csv")
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	assert.Len(t, lines, 9)
+	assert.True(t, strings.HasPrefix(lines[0], "user_id,username,category"))
+	w = serveTestRequest(t, router, http.MethodGet, url+"?format=xlsx", nil, admin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	if assert.NoError(t, err) {
+		parts := map[string]string{}
+		for _, f := range archive.File {
+			r, _ := f.Open()
+			content, _ := io.ReadAll(r)
+			parts[f.Name] = string(content)
+		}
+		assert.Contains(t, parts["xl/workbook.xml"], `name="Mastery"`)
+		assert.Contains(t, parts["xl/worksheets/sheet1.xml"], exam.Title)
+		assert.Contains(t, parts["xl/worksheets/sheet2.xml"], "tls")
+	}
+	w = serveTestRequest(t, router, http.MethodGet, url+"?format=pdf", nil, admin.Token)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	/* examinee gradebook */
+	w = serveTestRequest(t, router, http.MethodGet, url+"/"+strong.UserId, nil, strongSession.Token)
+	var gradebook Gradebook
+	unmarshalTestResponse(t, w, &gradebook)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, strong.Username, gradebook.Username)
+	assert.Equal(t, 100.0, gradebook.AverageScore)
+	assert.Len(t, gradebook.Attempts, 1)
+}
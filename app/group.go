@@ -0,0 +1,770 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"io"
+	"net/http"
+	"nova/logger"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	groupNameMaxLength        = 256
+	groupDescriptionMaxLength = 4096
+	groupMembersMax           = 1000
+)
+
+// errGroupNotFound is returned when user group is not stored
+var errGroupNotFound = errors.New("group not found")
+
+// errGroupExisted is returned when user group with the same Id is stored
+var errGroupExisted = errors.New("group already exists")
+
+// errExamNotAssigned is returned when examinee is in none of groups exam is assigned to
+var errExamNotAssigned = errors.New("exam is not assigned to groups of examinee")
+
+// errExamAssignmentPastDue is returned when every assignment of exam to examinee is past due
+var errExamAssignmentPastDue = errors.New("exam assignment is past due")
+
+func (nova *Nova) HandleCreateGroup(c *gin.Context) {
+	// create class, team or cohort of users
+	var request Group
+	logger.Infof("handle request create group")
+	// extract groupId from uri
+	groupId := strings.ToLower(c.Param("groupId"))
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// examinees are members of groups but do not manage them
+	logger.Debugf("check principal is allowed to manage groups")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to manage groups"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to manage groups"))
+		logger.Errorf("error check principal is allowed to manage groups")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to manage groups")
+	// check request body correctness
+	logger.Debugf("check group is validate")
+	request.Kind = strings.ToLower(strings.TrimSpace(request.Kind))
+	if b, err := isGroupValidate(groupId, request); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check group is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check group is validate")
+	// store created group in database
+	logger.Debugf("store group in database")
+	response := Group{
+		Id:          groupId,
+		Name:        strings.TrimSpace(request.Name),
+		Kind:        request.Kind,
+		Description: request.Description,
+		CreatedBy:   nova.queryRequestAuthor(c),
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := nova.db.CreateGroup(&response); err != nil {
+		if errors.Is(err, errGroupExisted) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error store group in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store group in database")
+	// store group tenant in database & data cache
+	logger.Debugf("store group tenant in database & data cache")
+	if err := nova.createResourceTenant(c, TenantResourceGroup, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store group tenant in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store group tenant in database & data cache")
+	// return response
+	nova.response201Created(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response)
+	return
+}
+
+func (nova *Nova) HandleQueryGroups(c *gin.Context) {
+	// query groups of tenant, examinees only see groups they are members of
+	logger.Infof("handle request query groups")
+	userId := ""
+	if user, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to query groups"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		userId = user.UserId
+	}
+	// query groups from database
+	logger.Debugf("query groups in database")
+	groups, err := nova.db.QueryGroups(nova.queryTenantScope(c), userId)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query groups in database: %v", err)
+		return
+	}
+	response := make([]Group, 0, len(groups))
+	for _, v := range groups {
+		response = append(response, *v)
+	}
+	logger.Debugf("successfully query groups in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, len(response))
+	return
+}
+
+func (nova *Nova) HandleQueryGroup(c *gin.Context) {
+	// query group
+	logger.Infof("handle request query group")
+	// extract groupId from uri
+	groupId := strings.ToLower(c.Param("groupId"))
+	// query group from database
+	logger.Debugf("query group in database")
+	response, ok := nova.queryGroupInDatabase(c, groupId)
+	if !ok {
+		return
+	}
+	logger.Debugf("successfully query group in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleDeleteGroup(c *gin.Context) {
+	// delete group with its memberships & exam assignments
+	logger.Infof("handle request delete group")
+	// extract groupId from uri
+	groupId := strings.ToLower(c.Param("groupId"))
+	// examinees are members of groups but do not manage them
+	logger.Debugf("check principal is allowed to manage groups")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to manage groups"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to manage groups"))
+		logger.Errorf("error check principal is allowed to manage groups")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to manage groups")
+	// delete group from database
+	logger.Debugf("delete group in database")
+	if _, ok := nova.queryGroupInDatabase(c, groupId); !ok {
+		return
+	}
+	if err := nova.db.DeleteGroup(groupId); err != nil {
+		if errors.Is(err, errGroupNotFound) {
+			nova.response404NotFound(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error delete group in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully delete group in database")
+	// delete group tenant in database & data cache
+	logger.Debugf("delete group tenant in database & data cache")
+	if err := nova.deleteResourceTenant(TenantResourceGroup, groupId); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error delete group tenant in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully delete group tenant in database & data cache")
+	// return response
+	nova.response204NoContent(c, nil)
+	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, groupId)
+	return
+}
+
+func (nova *Nova) HandleCreateGroupMembers(c *gin.Context) {
+	// add users to group in bulk
+	logger.Infof("handle request create group members")
+	nova.updateGroupMembers(c, false)
+}
+
+func (nova *Nova) HandleUpdateGroupMembers(c *gin.Context) {
+	// replace members of group in bulk
+	logger.Infof("handle request update group members")
+	nova.updateGroupMembers(c, true)
+}
+
+func (nova *Nova) HandleDeleteGroupMember(c *gin.Context) {
+	// remove user from group
+	logger.Infof("handle request delete group member")
+	// extract groupId & userId from uri
+	groupId := strings.ToLower(c.Param("groupId"))
+	userId := c.Param("userId")
+	// examinees are members of groups but do not manage them
+	logger.Debugf("check principal is allowed to manage groups")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to manage groups"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to manage groups"))
+		logger.Errorf("error check principal is allowed to manage groups")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to manage groups")
+	// delete group member from database
+	logger.Debugf("delete group member in database")
+	if _, ok := nova.queryGroupInDatabase(c, groupId); !ok {
+		return
+	}
+	deleted, err := nova.db.DeleteGroupMember(groupId, userId)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error delete group member in database: %v", err)
+		return
+	}
+	if !deleted {
+		nova.response404NotFound(c, errors.New("user is not member of group"))
+		logger.Errorf("error delete group member in database: user is not member")
+		return
+	}
+	logger.Debugf("successfully delete group member in database")
+	// return response
+	nova.response204NoContent(c, nil)
+	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, userId)
+	return
+}
+
+func (nova *Nova) HandleQueryGroupMembers(c *gin.Context) {
+	// query members of group
+	logger.Infof("handle request query group members")
+	// extract groupId from uri
+	groupId := strings.ToLower(c.Param("groupId"))
+	// examinees do not list other members
+	logger.Debugf("check principal is allowed to query group members")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to query group members"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to query group members"))
+		logger.Errorf("error check principal is allowed to query group members")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to query group members")
+	// query group members from database
+	logger.Debugf("query group members in database")
+	if _, ok := nova.queryGroupInDatabase(c, groupId); !ok {
+		return
+	}
+	members, err := nova.db.QueryGroupMembers(groupId)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query group members in database: %v", err)
+		return
+	}
+	if err := nova.queryUsersInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying users in database: %v", err)
+		return
+	}
+	response := make([]GroupMember, 0, len(members))
+	for _, v := range members {
+		if user, err := nova.queryUserInDataCache(v.UserId); err == nil {
+			v.Username = user.Username
+		}
+		response = append(response, *v)
+	}
+	logger.Debugf("successfully query group members in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, len(response))
+	return
+}
+
+func (nova *Nova) HandleCreateExamAssignment(c *gin.Context) {
+	// assign exam to every member of group, optionally until due time
+	var request ExamAssignment
+	logger.Infof("handle request create exam assignment")
+	// extract groupId & examId from uri
+	groupId := strings.ToLower(c.Param("groupId"))
+	examId := strings.ToLower(c.Param("examId"))
+	// request body is optional
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// examinees take exams but do not assign them
+	logger.Debugf("check principal is allowed to assign exams")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to assign exams"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to assign exams"))
+		logger.Errorf("error check principal is allowed to assign exams")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to assign exams")
+	// check group & exam existence
+	logger.Debugf("check group & exam are existed")
+	if _, ok := nova.queryGroupInDatabase(c, groupId); !ok {
+		return
+	}
+	if !nova.checkGroupExam(c, examId) {
+		return
+	}
+	now := time.Now().UTC()
+	if request.DueAt != nil && !request.DueAt.After(now) {
+		nova.response400BadRequest(c, FieldErrors{{Field: "due_at", Reason: "should be in the future"}})
+		logger.Errorf("error check exam assignment is validate: due time passed")
+		return
+	}
+	logger.Debugf("successfully check group & exam are existed")
+	// store exam assignment in database
+	logger.Debugf("store exam assignment in database")
+	response := ExamAssignment{GroupId: groupId, ExamId: examId, DueAt: request.DueAt, AssignedBy: nova.queryRequestAuthor(c), AssignedAt: now}
+	if response.DueAt != nil {
+		due := response.DueAt.UTC()
+		response.DueAt = &due
+	}
+	if err := nova.db.UpdateExamAssignment(&response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store exam assignment in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store exam assignment in database")
+	// return response
+	nova.response201Created(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response)
+	return
+}
+
+func (nova *Nova) HandleDeleteExamAssignment(c *gin.Context) {
+	// withdraw exam from group
+	logger.Infof("handle request delete exam assignment")
+	// extract groupId & examId from uri
+	groupId := strings.ToLower(c.Param("groupId"))
+	examId := strings.ToLower(c.Param("examId"))
+	// examinees take exams but do not assign them
+	logger.Debugf("check principal is allowed to assign exams")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to assign exams"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to assign exams"))
+		logger.Errorf("error check principal is allowed to assign exams")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to assign exams")
+	// delete exam assignment from database
+	logger.Debugf("delete exam assignment in database")
+	deleted, err := nova.db.DeleteExamAssignment(groupId, examId)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error delete exam assignment in database: %v", err)
+		return
+	}
+	if !deleted {
+		nova.response404NotFound(c, errors.New("exam is not assigned to group"))
+		logger.Errorf("error delete exam assignment in database: exam is not assigned")
+		return
+	}
+	logger.Debugf("successfully delete exam assignment in database")
+	// return response
+	nova.response204NoContent(c, nil)
+	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, examId)
+	return
+}
+
+func (nova *Nova) HandleQueryGroupExams(c *gin.Context) {
+	// query exams assigned to group
+	logger.Infof("handle request query group exams")
+	// extract groupId from uri
+	groupId := strings.ToLower(c.Param("groupId"))
+	// query exam assignments from database
+	logger.Debugf("query exam assignments in database")
+	if _, ok := nova.queryGroupInDatabase(c, groupId); !ok {
+		return
+	}
+	assignments, err := nova.db.QueryExamAssignments(groupId, "")
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query exam assignments in database: %v", err)
+		return
+	}
+	response := make([]ExamAssignment, 0, len(assignments))
+	for _, v := range assignments {
+		response = append(response, *v)
+	}
+	logger.Debugf("successfully query exam assignments in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, len(response))
+	return
+}
+
+func (nova *Nova) HandleQueryAssignedExams(c *gin.Context) {
+	// query exams assigned to groups of user
+	logger.Infof("handle request query assigned exams")
+	// assignments follow group memberships of authenticated user
+	logger.Debugf("check user session is validate")
+	user, ok := nova.queryPrincipal(c)
+	if !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to query assigned exams"))
+		logger.Errorf("error check user session is validate")
+		return
+	}
+	logger.Debugf("successfully check user session is validate")
+	// query exam assignments from database
+	logger.Debugf("query exam assignments in database")
+	assignments, err := nova.db.QueryUserExamAssignments(nova.queryTenantScope(c), user.UserId, "")
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query exam assignments in database: %v", err)
+		return
+	}
+	response := make([]ExamAssignment, 0, len(assignments))
+	for _, v := range assignments {
+		response = append(response, *v)
+	}
+	logger.Debugf("successfully query exam assignments in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, len(response))
+	return
+}
+
+func (nova *Nova) HandleQueryGroupResults(c *gin.Context) {
+	// aggregate results of group members in exam
+	logger.Infof("handle request query group results")
+	// extract groupId & examId from uri
+	groupId := strings.ToLower(c.Param("groupId"))
+	examId := strings.ToLower(c.Param("examId"))
+	// examinees do not see results of other members
+	logger.Debugf("check principal is allowed to query group results")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to query group results"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to query group results"))
+		logger.Errorf("error check principal is allowed to query group results")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to query group results")
+	// check group & exam existence
+	if _, ok := nova.queryGroupInDatabase(c, groupId); !ok {
+		return
+	}
+	if !nova.checkGroupExam(c, examId) {
+		return
+	}
+	// aggregate best submitted attempt of every member
+	logger.Debugf("query group results in database")
+	response, err := nova.queryGroupResults(groupId, examId)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query group results in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query group results in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response.Submitted)
+	return
+}
+
+func (nova *Nova) updateGroupMembers(c *gin.Context, replace bool) {
+	var request GroupMembers
+	// extract groupId from uri
+	groupId := strings.ToLower(c.Param("groupId"))
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// examinees are members of groups but do not manage them
+	logger.Debugf("check principal is allowed to manage groups")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to manage groups"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to manage groups"))
+		logger.Errorf("error check principal is allowed to manage groups")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to manage groups")
+	if _, ok := nova.queryGroupInDatabase(c, groupId); !ok {
+		return
+	}
+	// update data cache by querying users in database
+	logger.Debugf("update data cache by querying users in database")
+	if err := nova.queryUsersInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying users in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying users in database")
+	// every listed user should exist in tenant of group, repeated users are added once
+	logger.Debugf("check group members are validate")
+	tenantId, _ := nova.queryResourceTenant(TenantResourceGroup, groupId)
+	v := &questionValidator{}
+	if len(request.Members) > groupMembersMax {
+		v.add("members", "should have at most %v users, got %v", groupMembersMax, len(request.Members))
+	}
+	members := make([]string, 0, len(request.Members))
+	seen := make(map[string]bool, len(request.Members))
+	for k, userId := range request.Members {
+		if _, err := nova.queryUserInDataCache(userId); err != nil || !nova.isResourceInScope(tenantId, TenantResourceUser, userId) {
+			v.add(fmt.Sprintf("members[%v]", k), "user %v not found", userId)
+			continue
+		}
+		if !seen[userId] {
+			seen[userId] = true
+			members = append(members, userId)
+		}
+	}
+	if b, err := v.result(); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check group members are validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check group members are validate")
+	// store group members in database
+	logger.Debugf("store group members in database")
+	added, removed, err := nova.db.UpdateGroupMembers(groupId, members, replace, time.Now().UTC())
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store group members in database: %v", err)
+		return
+	}
+	group, err := nova.db.QueryGroup(groupId)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query group in database: %v", err)
+		return
+	}
+	response := GroupMembership{GroupId: groupId, Added: added, Removed: removed, Members: group.Members}
+	logger.Debugf("successfully store group members in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func isGroupValidate(groupId string, group Group) (bool, error) {
+	// check group identity matches uri
+	v := &questionValidator{}
+	v.checkId(group.Id)
+	if !strings.EqualFold(group.Id, groupId) {
+		v.add("id", "should match groupId of uri")
+	}
+	v.checkText("name", group.Name, groupNameMaxLength)
+	switch group.Kind {
+	case GroupKindClass, GroupKindTeam, GroupKindCohort:
+	default:
+		v.add("kind", "group kind %v not supported", group.Kind)
+	}
+	if n := utf8.RuneCountInString(group.Description); n > groupDescriptionMaxLength {
+		v.add("description", "should be at most %v characters, got %v", groupDescriptionMaxLength, n)
+	}
+	return v.result()
+}
+
+func (nova *Nova) queryGroupInDatabase(c *gin.Context, groupId string) (Group, bool) {
+	// request groupId correctness
+	if err := uuid.Validate(groupId); err != nil {
+		nova.response400BadRequest(c, errors.New("groupId format incorrect"))
+		logger.Errorf("error check groupId is validate: %v", err)
+		return Group{}, false
+	}
+	// query group from database
+	group, err := nova.db.QueryGroup(groupId)
+	if err != nil {
+		if errors.Is(err, errGroupNotFound) {
+			nova.response404NotFound(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error query group in database: %v", err)
+		return Group{}, false
+	}
+	// examinees & anonymous requests only see groups they are members of
+	if user, role, ok := nova.queryPrincipalRole(c); !ok || role == RoleExaminee {
+		member, err := nova.isGroupMember(groupId, user.UserId)
+		if err != nil {
+			nova.response500InternalServerError(c, err)
+			logger.Errorf("error query group members in database: %v", err)
+			return Group{}, false
+		}
+		if !member {
+			nova.response404NotFound(c, errGroupNotFound)
+			logger.Errorf("error check group is visible")
+			return Group{}, false
+		}
+	}
+	return *group, true
+}
+
+func (nova *Nova) checkGroupExam(c *gin.Context, examId string) bool {
+	// exam of group should be stored
+	if err := uuid.Validate(examId); err != nil {
+		nova.response400BadRequest(c, errors.New("examId format incorrect"))
+		logger.Errorf("error check examId is validate: %v", err)
+		return false
+	}
+	if _, err := nova.db.QueryExam(examId); err != nil {
+		if errors.Is(err, errExamNotFound) {
+			nova.response404NotFound(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error query exam in database: %v", err)
+		return false
+	}
+	return true
+}
+
+func (nova *Nova) isGroupMember(groupId string, userId string) (bool, error) {
+	groups, err := nova.db.QueryGroups(tenantScopeAll, userId)
+	if err != nil {
+		return false, err
+	}
+	for _, v := range groups {
+		if v.Id == groupId {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (nova *Nova) queryGroupMemberSet(groupId string) (map[string]bool, error) {
+	members, err := nova.db.QueryGroupMembers(groupId)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]bool, len(members))
+	for _, v := range members {
+		set[v.UserId] = true
+	}
+	return set, nil
+}
+
+func (nova *Nova) checkExamAssignment(c *gin.Context, examId string, now time.Time) error {
+	// exam assigned to groups is taken by examinees of those groups until due, open exam by everyone
+	user, role, ok := nova.queryPrincipalRole(c)
+	if ok && role != RoleExaminee {
+		return nil
+	}
+	assignments, err := nova.db.QueryExamAssignments("", examId)
+	if err != nil || len(assignments) == 0 {
+		return err
+	}
+	assigned, err := nova.db.QueryUserExamAssignments(nova.queryTenantScope(c), user.UserId, examId)
+	if err != nil {
+		return err
+	}
+	if len(assigned) == 0 {
+		return errExamNotAssigned
+	}
+	for _, v := range assigned {
+		if v.DueAt == nil || now.Before(*v.DueAt) {
+			return nil
+		}
+	}
+	return errExamAssignmentPastDue
+}
+
+func (nova *Nova) queryGroupResults(groupId string, examId string) (GroupResults, error) {
+	// best submitted attempt of every member counts, members without attempt are pending
+	members, err := nova.db.QueryGroupMembers(groupId)
+	if err != nil {
+		return GroupResults{}, err
+	}
+	attempts, err := nova.db.QuerySubmittedExamAttempts(examId)
+	if err != nil {
+		return GroupResults{}, err
+	}
+	if err := nova.queryUsersInDatabase(); err != nil {
+		return GroupResults{}, err
+	}
+	response := GroupResults{GroupId: groupId, ExamId: examId, Members: len(members), Users: make([]GroupMemberResult, 0, len(members))}
+	results := make(map[string]*GroupMemberResult, len(members))
+	for _, v := range members {
+		result := GroupMemberResult{UserId: v.UserId}
+		if user, err := nova.queryUserInDataCache(v.UserId); err == nil {
+			result.Username = user.Username
+		}
+		response.Users = append(response.Users, result)
+	}
+	for k := range response.Users {
+		results[response.Users[k].UserId] = &response.Users[k]
+	}
+	for _, attempt := range attempts {
+		result, ok := results[attempt.UserId]
+		if !ok {
+			continue
+		}
+		result.Attempts++
+		result.BestScore = max(result.BestScore, queryExamAttemptPercent(attempt))
+		if result.SubmittedAt == nil || attempt.SubmittedAt.After(*result.SubmittedAt) {
+			result.SubmittedAt = attempt.SubmittedAt
+		}
+	}
+	var scores []float64
+	for _, v := range response.Users {
+		if v.Attempts > 0 {
+			scores = append(scores, v.BestScore)
+		}
+	}
+	for k, v := range response.Users {
+		if v.Attempts > 0 {
+			response.Users[k].Percentile = queryPercentileRank(scores, v.BestScore)
+		}
+	}
+	response.Submitted, response.Pending = len(scores), len(members)-len(scores)
+	if len(scores) > 0 {
+		sort.Float64s(scores)
+		total := 0.0
+		for _, v := range scores {
+			total += v
+		}
+		response.AverageScore = roundToDecimals(total/float64(len(scores)), 2)
+		response.MedianScore = roundToDecimals((scores[(len(scores)-1)/2]+scores[len(scores)/2])/2, 2)
+		response.LowestScore, response.HighestScore = scores[0], scores[len(scores)-1]
+	}
+	// highest score first, pending members last
+	sort.SliceStable(response.Users, func(i, j int) bool {
+		a, b := response.Users[i], response.Users[j]
+		if (a.Attempts > 0) != (b.Attempts > 0) {
+			return a.Attempts > 0
+		}
+		if a.BestScore != b.BestScore {
+			return a.BestScore > b.BestScore
+		}
+		return a.Username < b.Username
+	})
+	return response, nil
+}
@@ -0,0 +1,188 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"nova/utils"
+	"testing"
+	"time"
+)
+
+func setupGroupTestRouter() *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// first administrator is granted by command line
+	setupTestAdministrator(nova)
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	{
+		/* user management */
+		novaService.POST("/user/:userId", nova.HandleCreateUser)
+		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
+		novaService.PUT("/user/role/:userId", nova.HandleUpdateUserRole)
+		/* exam management */
+		novaService.POST("/exam/:examId", nova.HandleCreateExam)
+		novaService.GET("/exam/assigned", nova.HandleQueryAssignedExams)
+		novaService.POST("/exam/:examId/attempt", nova.HandleCreateExamAttempt)
+		novaService.POST("/exam/attempt/:attemptId/submission", nova.HandleCreateExamSubmission)
+		/* gradebook management */
+		novaService.GET("/gradebook", nova.HandleQueryGradebooks)
+		/* group management */
+		novaService.POST("/group/:groupId", nova.HandleCreateGroup)
+		novaService.GET("/group", nova.HandleQueryGroups)
+		novaService.GET("/group/:groupId", nova.HandleQueryGroup)
+		novaService.POST("/group/:groupId/member", nova.HandleCreateGroupMembers)
+		novaService.PUT("/group/:groupId/member", nova.HandleUpdateGroupMembers)
+		novaService.GET("/group/:groupId/member", nova.HandleQueryGroupMembers)
+		novaService.DELETE("/group/:groupId/member/:userId", nova.HandleDeleteGroupMember)
+		novaService.POST("/group/:groupId/exam/:examId", nova.HandleCreateExamAssignment)
+		novaService.GET("/group/:groupId/exam/:examId/results", nova.HandleQueryGroupResults)
+		/* question management */
+		// question workflow related
+		novaService.POST("/question/workflow/:type/:Id/transition", nova.HandleCreateQuestionTransition)
+		novaService.POST("/question/workflow/:type/:Id/approve", nova.HandleCreateQuestionApproval)
+		// question related
+		novaService.POST("/question/judgement/:Id", nova.HandleCreateQuestionJudgement)
+	}
+	return router
+}
+
+func startGroupTestService() (*httptest.Server, *gin.Engine) {
+	router := setupGroupTestRouter()
+	return httptest.NewServer(router), router
+}
+
+func TestNova_HandleCreateGroup(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateGroup
+	// Test Purpose: Test groups manage members, gate assigned exams & aggregate results
+	// Test Steps:
+	// 1. send CreateGroup request with examinee session, receive 403 Forbidden Code
+	// 2. send CreateGroup & CreateGroupMembers requests, receive group membership by using 200 OK Code
+	// 3. send CreateExamAssignment request, only members start attempts of exam
+	// 4. send QueryGroupResults & QueryGradebooks requests, receive results of members
+	// 5. send UpdateGroupMembers & DeleteGroupMember requests, receive changed membership
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startGroupTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	first, firstSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	second, secondSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	outsider, outsiderSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	question := QuestionJudgement{Id: uuid.New().String(), Title: "TCP handshake has three steps " + utils.RandomAlphabet(12), StandardAnswer: true}
+	createExamTestQuestion(t, server, router, QuestionTypeJudgement, question.Id, question, admin.Token, true)
+	exam := Exam{Id: uuid.New().String(), Title: "Transport layer quiz " + utils.RandomAlphabet(12),
+		Questions: []ExamQuestion{{Type: QuestionTypeJudgement, Id: question.Id}}}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id, exam, admin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	/* examinees do not manage groups */
+	group := Group{Id: uuid.New().String(), Name: "Networking 101 " + utils.RandomAlphabet(12), Kind: "Class"}
+	url := server.URL + "/nova/v1/group/" + group.Id
+	w = serveTestRequest(t, router, http.MethodPost, url, group, firstSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, url, Group{Id: group.Id, Name: group.Name, Kind: "club"}, admin.Token)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, url, group, admin.Token)
+	var created Group
+	unmarshalTestResponse(t, w, &created)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, GroupKindClass, created.Kind)
+	w = serveTestRequest(t, router, http.MethodPost, url, group, admin.Token)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	/* members are added in bulk, unknown users are rejected */
+	w = serveTestRequest(t, router, http.MethodPost, url+"/member", GroupMembers{Members: []string{first.UserId, uuid.New().String()}}, admin.Token)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, url+"/member", GroupMembers{Members: []string{first.UserId, second.UserId, first.UserId}}, admin.Token)
+	var membership GroupMembership
+	unmarshalTestResponse(t, w, &membership)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, GroupMembership{GroupId: group.Id, Added: 2, Members: 2}, membership)
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, outsiderSession.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/group", nil, firstSession.Token)
+	var groups []Group
+	unmarshalTestResponse(t, w, &groups)
+	assert.Equal(t, http.StatusOK, w.Code)
+	if assert.Len(t, groups, 1) {
+		assert.Equal(t, 2, groups[0].Members)
+	}
+	/* assigned exam is only taken by members */
+	past := time.Now().Add(-time.Hour)
+	w = serveTestRequest(t, router, http.MethodPost, url+"/exam/"+exam.Id, ExamAssignment{DueAt: &past}, admin.Token)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, url+"/exam/"+exam.Id, nil, admin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/exam/assigned", nil, firstSession.Token)
+	var assignments []ExamAssignment
+	unmarshalTestResponse(t, w, &assignments)
+	assert.Equal(t, http.StatusOK, w.Code)
+	if assert.Len(t, assignments, 1) {
+		assert.Equal(t, exam.Id, assignments[0].ExamId)
+	}
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id+"/attempt", nil, outsiderSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	for token, answer := range map[string]string{firstSession.Token: "true", secondSession.Token: "false"} {
+		w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id+"/attempt", nil, token)
+		var attempt ExamAttempt
+		unmarshalTestResponse(t, w, &attempt)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		submission := ExamSubmission{Answers: []ExamAnswer{{Type: QuestionTypeJudgement, Id: question.Id, Answer: []byte(answer)}}}
+		w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/attempt/"+attempt.AttemptId+"/submission", submission, token)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+	/* results of members */
+	w = serveTestRequest(t, router, http.MethodGet, url+"/exam/"+exam.Id+"/results", nil, firstSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, url+"/exam/"+exam.Id+"/results", nil, admin.Token)
+	var results GroupResults
+	unmarshalTestResponse(t, w, &results)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 2, results.Submitted)
+	assert.Equal(t, 0, results.Pending)
+	assert.Equal(t, 50.0, results.AverageScore)
+	assert.Equal(t, 100.0, results.HighestScore)
+	assert.Equal(t, 0.0, results.LowestScore)
+	if assert.Len(t, results.Users, 2) {
+		assert.Equal(t, first.UserId, results.Users[0].UserId)
+		assert.Equal(t, 75.0, results.Users[0].Percentile)
+		assert.Equal(t, first.Username, results.Users[0].Username)
+	}
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/gradebook?group="+group.Id, nil, admin.Token)
+	var report GradebookReport
+	unmarshalTestResponse(t, w, &report)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, group.Id, report.GroupId)
+	assert.Len(t, report.Users, 2)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/gradebook?group="+uuid.New().String(), nil, admin.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	/* membership is replaced & reduced */
+	w = serveTestRequest(t, router, http.MethodPut, url+"/member", GroupMembers{Members: []string{first.UserId, outsider.UserId}}, admin.Token)
+	membership = GroupMembership{}
+	unmarshalTestResponse(t, w, &membership)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, GroupMembership{GroupId: group.Id, Added: 1, Removed: 1, Members: 2}, membership)
+	w = serveTestRequest(t, router, http.MethodDelete, url+"/member/"+outsider.UserId, nil, admin.Token)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = serveTestRequest(t, router, http.MethodDelete, url+"/member/"+outsider.UserId, nil, admin.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, url+"/member", nil, admin.Token)
+	var members []GroupMember
+	unmarshalTestResponse(t, w, &members)
+	assert.Equal(t, http.StatusOK, w.Code)
+	if assert.Len(t, members, 1) {
+		assert.Equal(t, first.Username, members[0].Username)
+	}
+}
@@ -0,0 +1,412 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"nova/logger"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	guidanceRevealNever       = "never"
+	guidanceRevealAfterAnswer = "after_answer"
+)
+
+// errQuestionGuidanceNotFound is returned when question has no guidance at revision
+var errQuestionGuidanceNotFound = errors.New("question guidance not found")
+
+func (nova *Nova) HandleUpdateQuestionGuidance(c *gin.Context) {
+	// update explanation, hints & feedback of question
+	var request QuestionGuidance
+	logger.Infof("handle request update question guidance")
+	// extract question type & Id from uri
+	questionType := strings.ToLower(c.Param("type"))
+	id := strings.ToLower(c.Param("Id"))
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// request question type & Id correctness
+	logger.Debugf("check question type & Id is validate")
+	if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question type & Id is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question type & Id is validate")
+	// examinees may not edit guidance they are going to be shown
+	logger.Debugf("check principal is allowed to update guidance")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to update question guidance"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to update question guidance"))
+		logger.Errorf("error check principal is allowed to update guidance")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to update guidance")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// check question existence
+	logger.Debugf("check question is existed")
+	if !nova.isQuestionExisted(questionType, id) {
+		nova.response404NotFound(c, fmt.Errorf("%v question not found", questionType))
+		logger.Errorf("error check question is existed")
+		return
+	}
+	logger.Debugf("successfully check question is existed")
+	// request guidance content correctness
+	logger.Debugf("check question guidance is validate")
+	feedback := make(map[string]string, len(request.Feedback))
+	for k, v := range request.Feedback {
+		feedback[normalizeQuestionOption(questionType, k)] = v
+	}
+	request.Feedback = feedback
+	options := nova.queryQuestionOptions(questionType, id)
+	if b, err := validateQuestionGuidance(request, options); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question guidance is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question guidance is validate")
+	// guidance belongs to latest revision of question
+	logger.Debugf("query question latest revision")
+	revision, err := nova.queryQuestionLatestRevision(questionType, id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query question latest revision: %v", err)
+		return
+	}
+	logger.Debugf("successfully query question latest revision")
+	// store question guidance in database
+	logger.Debugf("store question guidance in database")
+	response := QuestionGuidance{
+		Type:        questionType,
+		Id:          id,
+		Revision:    revision,
+		Explanation: request.Explanation,
+		Hints:       request.Hints,
+		Feedback:    request.Feedback,
+		UpdatedBy:   nova.queryRequestAuthor(c),
+		UpdatedAt:   time.Now().UTC(),
+	}
+	if response.Hints == nil {
+		response.Hints = []QuestionHint{}
+	}
+	if err := nova.db.UpdateQuestionGuidance(&response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store question guidance in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store question guidance in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionGuidance(c *gin.Context) {
+	// query explanation, hints & feedback of question
+	logger.Infof("handle request query question guidance")
+	// extract question type & Id from uri
+	questionType := strings.ToLower(c.Param("type"))
+	id := strings.ToLower(c.Param("Id"))
+	// request question type & Id correctness
+	logger.Debugf("check question type & Id is validate")
+	if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question type & Id is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question type & Id is validate")
+	// query guidance of latest revision unless specified
+	logger.Debugf("query question revision")
+	revision, err := nova.queryQuestionLatestRevision(questionType, id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query question latest revision: %v", err)
+		return
+	}
+	if s := c.Query("revision"); s != "" {
+		revision, err = strconv.Atoi(s)
+		if err != nil || revision < 0 {
+			nova.response400BadRequest(c, errors.New("question revision format incorrect"))
+			logger.Errorf("error check question revision is validate: %v", s)
+			return
+		}
+	}
+	logger.Debugf("successfully query question revision")
+	// query question guidance from database
+	logger.Debugf("query question guidance in database")
+	response, err := nova.db.QueryQuestionGuidance(questionType, id, revision)
+	if errors.Is(err, errQuestionGuidanceNotFound) {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query question guidance in database: %v", err)
+		return
+	}
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query question guidance in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query question guidance in database")
+	// examinees only see hints they revealed
+	if user, role, ok := nova.queryPrincipalRole(c); !ok || role == RoleExaminee {
+		logger.Debugf("check question is visible")
+		if !nova.isQuestionVisible(c, questionType, id) {
+			nova.response404NotFound(c, errQuestionGuidanceNotFound)
+			logger.Errorf("error check question is visible")
+			return
+		}
+		logger.Debugf("successfully check question is visible")
+		revealed, err := nova.db.QueryQuestionHints(user.UserId, questionType, id)
+		if err != nil {
+			nova.response500InternalServerError(c, err)
+			logger.Errorf("error query revealed hints in database: %v", err)
+			return
+		}
+		response = hideQuestionGuidance(response, revealed)
+	}
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleCreateQuestionHint(c *gin.Context) {
+	// reveal next hint of question to user
+	logger.Infof("handle request create question hint")
+	// extract question type & Id from uri
+	questionType := strings.ToLower(c.Param("type"))
+	id := strings.ToLower(c.Param("Id"))
+	// request question type & Id correctness
+	logger.Debugf("check question type & Id is validate")
+	if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question type & Id is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question type & Id is validate")
+	// revealed hints are counted for authenticated user
+	logger.Debugf("check user session is validate")
+	user, ok := nova.queryPrincipal(c)
+	if !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to reveal hint"))
+		logger.Errorf("error check user session is validate")
+		return
+	}
+	logger.Debugf("successfully check user session is validate")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// check question existence
+	logger.Debugf("check question is existed")
+	if !nova.isQuestionExisted(questionType, id) || !nova.isQuestionVisible(c, questionType, id) {
+		nova.response404NotFound(c, fmt.Errorf("%v question not found", questionType))
+		logger.Errorf("error check question is existed")
+		return
+	}
+	logger.Debugf("successfully check question is existed")
+	// query question guidance of latest revision
+	logger.Debugf("query question guidance in database")
+	guidance, err := nova.queryQuestionGuidance(questionType, id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query question guidance in database: %v", err)
+		return
+	}
+	if guidance == nil {
+		nova.response404NotFound(c, errQuestionGuidanceNotFound)
+		logger.Errorf("error query question guidance in database: %v", errQuestionGuidanceNotFound)
+		return
+	}
+	logger.Debugf("successfully query question guidance in database")
+	// hints are revealed one by one
+	logger.Debugf("update revealed hints in database")
+	revealed, err := nova.db.QueryQuestionHints(user.UserId, questionType, id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query revealed hints in database: %v", err)
+		return
+	}
+	if revealed >= len(guidance.Hints) {
+		nova.response409Conflict(c, errors.New("all hints of question are revealed"))
+		logger.Errorf("error update revealed hints in database: all %v hints revealed", revealed)
+		return
+	}
+	if err := nova.db.UpdateQuestionHints(user.UserId, questionType, id, revealed+1); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update revealed hints in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update revealed hints in database")
+	// return response
+	response := guidance.Hints[:revealed+1]
+	nova.response201Created(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response)
+	return
+}
+
+func (nova *Nova) queryQuestionGuidance(questionType string, id string) (*QuestionGuidance, error) {
+	// guidance in effect for latest revision, nil when question has none
+	revision, err := nova.queryQuestionLatestRevision(questionType, id)
+	if err != nil {
+		return nil, err
+	}
+	guidance, err := nova.db.QueryQuestionGuidance(questionType, id, revision)
+	if errors.Is(err, errQuestionGuidanceNotFound) {
+		return nil, nil
+	}
+	return guidance, err
+}
+
+func (nova *Nova) restoreQuestionGuidance(c *gin.Context, questionType string, id string, from int, to int) error {
+	// restored revision brings back guidance it was answered with
+	guidance, err := nova.db.QueryQuestionGuidance(questionType, id, from)
+	if errors.Is(err, errQuestionGuidanceNotFound) {
+		// later guidance should not apply to restored question either
+		latest, err := nova.queryQuestionGuidance(questionType, id)
+		if err != nil || latest == nil {
+			return err
+		}
+		guidance = &QuestionGuidance{Type: questionType, Id: id, Hints: []QuestionHint{}, Feedback: map[string]string{}}
+	} else if err != nil {
+		return err
+	}
+	guidance.Revision = to
+	guidance.UpdatedBy = nova.queryRequestAuthor(c)
+	guidance.UpdatedAt = time.Now().UTC()
+	return nova.db.UpdateQuestionGuidance(guidance)
+}
+
+func (nova *Nova) applyQuestionGuidance(c *gin.Context, questionType string, id string, submission QuestionSubmission, grade *QuestionGrade) error {
+	guidance, err := nova.queryQuestionGuidance(questionType, id)
+	if err != nil || guidance == nil {
+		return err
+	}
+	// every revealed hint costs its penalty of max score
+	if user, ok := nova.queryPrincipal(c); ok {
+		revealed, err := nova.db.QueryQuestionHints(user.UserId, questionType, id)
+		if err != nil {
+			return err
+		}
+		penalty := 0.0
+		for _, v := range guidance.Hints[:min(revealed, len(guidance.Hints))] {
+			penalty += v.Penalty
+		}
+		grade.Penalty = min(grade.Score, penalty*grade.MaxScore)
+		grade.Score -= grade.Penalty
+	}
+	// explanation & feedback of chosen options are revealed after answering
+	if nova.queryQuestionGuidanceReveal() == guidanceRevealAfterAnswer {
+		grade.Explanation = guidance.Explanation
+		for _, option := range questionChosenOptions(questionType, submission.Answer) {
+			if v, ok := guidance.Feedback[option]; ok {
+				if grade.Feedback == nil {
+					grade.Feedback = make(map[string]string)
+				}
+				grade.Feedback[option] = v
+			}
+		}
+	}
+	return nil
+}
+
+func (nova *Nova) queryQuestionGuidanceReveal() string {
+	// explanation & feedback are revealed after answering by default
+	if nova.conf.Configure.Guidance.Reveal == guidanceRevealNever {
+		return guidanceRevealNever
+	}
+	return guidanceRevealAfterAnswer
+}
+
+func (nova *Nova) queryQuestionOptions(questionType string, id string) map[string]bool {
+	// options which per-option feedback may refer to, nil when question type has none
+	options := make(map[string]bool)
+	switch questionType {
+	case QuestionTypeSingleChoice:
+		question, err := nova.querySingleChoiceQuestionInDataCache(id)
+		if err != nil {
+			return options
+		}
+		for _, v := range question.Answers {
+			options[normalizeAnswerMark(v.AnswerMark)] = true
+		}
+	case QuestionTypeMultipleChoice:
+		question, err := nova.queryMultipleChoiceQuestionInDataCache(id)
+		if err != nil {
+			return options
+		}
+		for _, v := range question.Answers {
+			options[normalizeAnswerMark(v.AnswerMark)] = true
+		}
+	case QuestionTypeJudgement:
+		options["true"], options["false"] = true, true
+	default:
+		return nil
+	}
+	return options
+}
+
+func normalizeQuestionOption(questionType string, option string) string {
+	// judgement options are booleans, other options are answer marks
+	if questionType == QuestionTypeJudgement {
+		return strings.ToLower(strings.TrimSpace(option))
+	}
+	return normalizeAnswerMark(option)
+}
+
+func questionChosenOptions(questionType string, answer json.RawMessage) []string {
+	// submitted answer of question types supporting per-option feedback
+	switch questionType {
+	case QuestionTypeSingleChoice:
+		var mark string
+		if json.Unmarshal(answer, &mark) == nil {
+			return []string{normalizeAnswerMark(mark)}
+		}
+	case QuestionTypeMultipleChoice:
+		var marks []string
+		if json.Unmarshal(answer, &marks) == nil {
+			for k, v := range marks {
+				marks[k] = normalizeAnswerMark(v)
+			}
+			return marks
+		}
+	case QuestionTypeJudgement:
+		var v bool
+		if json.Unmarshal(answer, &v) == nil {
+			return []string{strconv.FormatBool(v)}
+		}
+	}
+	return nil
+}
+
+func hideQuestionGuidance(guidance *QuestionGuidance, revealed int) *QuestionGuidance {
+	// explanation & feedback are revealed by grading, hints one by one
+	hidden := *guidance
+	hidden.Explanation = ""
+	hidden.Feedback = map[string]string{}
+	hidden.Hints = guidance.Hints[:min(revealed, len(guidance.Hints))]
+	return &hidden
+}
@@ -0,0 +1,235 @@
+package app
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"nova/utils"
+	"testing"
+)
+
+func setupGuidanceTestRouter() *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// author changing questions
+	createTestAuthor(nova)
+	// first administrator is granted by command line
+	setupTestAdministrator(nova)
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	{
+		/* user management */
+		novaService.POST("/user/:userId", nova.HandleCreateUser)
+		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
+		novaService.PUT("/user/role/:userId", nova.HandleUpdateUserRole)
+		/* question management */
+		// question workflow related
+		novaService.POST("/question/workflow/:type/:Id/transition", nova.HandleCreateQuestionTransition)
+		novaService.POST("/question/workflow/:type/:Id/approve", nova.HandleCreateQuestionApproval)
+		// question revision related
+		novaService.POST("/question/revision/:type/:Id/:revision/restore", nova.HandleCreateQuestionRevisionRestore)
+		// question guidance related
+		novaService.PUT("/question/guidance/:type/:Id", nova.HandleUpdateQuestionGuidance)
+		novaService.GET("/question/guidance/:type/:Id", nova.HandleQueryQuestionGuidance)
+		novaService.POST("/question/guidance/:type/:Id/hint", nova.HandleCreateQuestionHint)
+		// question grade related
+		novaService.POST("/question/grade/:type/:Id", nova.HandleCreateQuestionGrade)
+		// question related
+		novaService.POST("/question/single-choice/:Id", nova.HandleCreateQuestionSingleChoice)
+		novaService.PUT("/question/single-choice/:Id", nova.HandleUpdateQuestionSingleChoice)
+		novaService.POST("/question/essay/:Id", nova.HandleCreateQuestionEssay)
+	}
+	return router
+}
+
+func startGuidanceTestService() (*httptest.Server, *gin.Engine) {
+	router := setupGuidanceTestRouter()
+	return httptest.NewServer(router), router
+}
+
+func TestNova_HandleUpdateQuestionGuidance(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleUpdateQuestionGuidance
+	// Test Purpose: Test question guidance is validated & versioned with question
+	// Test Steps:
+	// 1. send UpdateQuestionGuidance request with unknown option & excessive penalties
+	// 2. receive UpdateQuestionGuidance response with field errors by using 400 Bad Request Code
+	// 3. send UpdateQuestionGuidance request for first & second revision of question
+	// 4. send QueryQuestionGuidance request of every revision by using 200 OK Code
+	// 5. send CreateQuestionRevisionRestore request, guidance of first revision is restored
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startGuidanceTestService()
+	defer server.Close()
+	/* create single-choice question */
+	question := QuestionSingleChoice{
+		Id:    uuid.New().String(),
+		Title: "Which planet is known as the red planet, " + utils.RandomAlphabet(12) + "?",
+		Answers: []QuestionAnswer{
+			{"A", "Venus"},
+			{"B", "Mars"},
+		},
+		StandardAnswer: QuestionAnswer{"B", "Mars"},
+	}
+	url := server.URL + "/nova/v1/question/single-choice/" + question.Id
+	w := serveTestRequest(t, router, http.MethodPost, url, question, testAuthorToken)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	/* update invalid guidance */
+	guidanceURL := server.URL + "/nova/v1/question/guidance/single-choice/" + question.Id
+	guidance := QuestionGuidance{
+		Explanation: "Iron oxide on its surface makes Mars look red.",
+		Hints:       []QuestionHint{{"It is the fourth planet.", 0.6}, {"Its moons are Phobos & Deimos.", 0.6}},
+		Feedback:    map[string]string{"a": "Venus is yellowish white.", "C": "There is no option C."},
+	}
+	w = serveTestRequest(t, router, http.MethodPut, guidanceURL, guidance, testAuthorToken)
+	var problemDetails ProblemDetails
+	unmarshalTestResponse(t, w, &problemDetails)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, []FieldError{
+		{Field: "hints", Reason: "penalties should add up to at most 1, got 1.2"},
+		{Field: "feedback[C]", Reason: "option C is not one of answers"},
+	}, problemDetails.Errors)
+	/* update guidance of first revision */
+	guidance.Hints[1].Penalty = 0.2
+	delete(guidance.Feedback, "C")
+	w = serveTestRequest(t, router, http.MethodPut, guidanceURL, guidance, testAuthorToken)
+	var first QuestionGuidance
+	unmarshalTestResponse(t, w, &first)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1, first.Revision)
+	assert.Equal(t, map[string]string{"A": "Venus is yellowish white."}, first.Feedback)
+	/* update question & guidance of second revision */
+	question.Title = "Which planet is called the red planet, " + utils.RandomAlphabet(12) + "?"
+	w = serveTestRequest(t, router, http.MethodPut, url, question, testAuthorToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, guidanceURL, nil, testAuthorToken)
+	var resGuidance QuestionGuidance
+	unmarshalTestResponse(t, w, &resGuidance)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, first.Explanation, resGuidance.Explanation)
+	guidance.Explanation = "Mars is red because of rust."
+	w = serveTestRequest(t, router, http.MethodPut, guidanceURL, guidance, testAuthorToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+	/* query guidance of every revision */
+	for revision, explanation := range map[string]string{"1": first.Explanation, "2": guidance.Explanation} {
+		w = serveTestRequest(t, router, http.MethodGet, guidanceURL+"?revision="+revision, nil, testAuthorToken)
+		resGuidance = QuestionGuidance{}
+		unmarshalTestResponse(t, w, &resGuidance)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, explanation, resGuidance.Explanation)
+	}
+	/* restore first revision with its guidance */
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/revision/single-choice/"+question.Id+"/1/restore", nil, testAuthorToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, guidanceURL, nil, testAuthorToken)
+	resGuidance = QuestionGuidance{}
+	unmarshalTestResponse(t, w, &resGuidance)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 3, resGuidance.Revision)
+	assert.Equal(t, first.Explanation, resGuidance.Explanation)
+	/* per-option feedback of essay question */
+	essay := QuestionEssay{Id: uuid.New().String(), Title: "Describe " + utils.RandomAlphabet(12), Answer: "-", StandardAnswer: "Anything."}
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/essay/"+essay.Id, essay, testAuthorToken)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/question/guidance/essay/"+essay.Id, guidance, testAuthorToken)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestNova_HandleCreateQuestionHint(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateQuestionHint
+	// Test Purpose: Test progressive hints reduce score & guidance is revealed after answering
+	// Test Steps:
+	// 1. send UpdateQuestionGuidance request with two hints & per-option feedback
+	// 2. send QueryQuestionGuidance request with examinee session, hints are hidden
+	// 3. send CreateQuestionHint request until all hints are revealed
+	// 4. send CreateQuestionGrade request, score is reduced by penalties of revealed hints
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startGuidanceTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	_, examinee := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	/* create single-choice question with guidance */
+	question := QuestionSingleChoice{
+		Id:    uuid.New().String(),
+		Title: "Which gas do plants absorb, " + utils.RandomAlphabet(12) + "?",
+		Answers: []QuestionAnswer{
+			{"A", "Oxygen"},
+			{"B", "Carbon dioxide"},
+		},
+		StandardAnswer: QuestionAnswer{"B", "Carbon dioxide"},
+	}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/single-choice/"+question.Id, question, admin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	workflowURL := server.URL + "/nova/v1/question/workflow/single-choice/" + question.Id
+	w = serveTestRequest(t, router, http.MethodPost, workflowURL+"/transition", QuestionTransition{State: QuestionStateInReview}, admin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, workflowURL+"/approve", nil, admin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	guidanceURL := server.URL + "/nova/v1/question/guidance/single-choice/" + question.Id
+	guidance := QuestionGuidance{
+		Explanation: "Photosynthesis turns carbon dioxide into sugar.",
+		Hints:       []QuestionHint{{"It is exhaled by animals.", 0.25}, {"Its formula is CO2.", 0.5}},
+		Feedback:    map[string]string{"A": "Plants release oxygen.", "B": "Right, plants absorb carbon dioxide."},
+	}
+	w = serveTestRequest(t, router, http.MethodPut, guidanceURL, guidance, admin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodPut, guidanceURL, guidance, examinee.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	/* hints & explanation are hidden from examinee */
+	w = serveTestRequest(t, router, http.MethodGet, guidanceURL, nil, examinee.Token)
+	var resGuidance QuestionGuidance
+	unmarshalTestResponse(t, w, &resGuidance)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, resGuidance.Hints)
+	assert.Empty(t, resGuidance.Explanation)
+	assert.Empty(t, resGuidance.Feedback)
+	/* reveal hints one by one */
+	w = serveTestRequest(t, router, http.MethodPost, guidanceURL+"/hint", nil, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, guidanceURL+"/hint", nil, examinee.Token)
+	var hints []QuestionHint
+	unmarshalTestResponse(t, w, &hints)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, guidance.Hints[:1], hints)
+	w = serveTestRequest(t, router, http.MethodPost, guidanceURL+"/hint", nil, examinee.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, guidanceURL+"/hint", nil, examinee.Token)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, guidanceURL, nil, examinee.Token)
+	resGuidance = QuestionGuidance{}
+	unmarshalTestResponse(t, w, &resGuidance)
+	assert.Equal(t, guidance.Hints, resGuidance.Hints)
+	/* grade answer with hint penalty */
+	b, _ := json.Marshal("b")
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/grade/single-choice/"+question.Id, QuestionSubmission{Answer: b}, examinee.Token)
+	var grade QuestionGrade
+	unmarshalTestResponse(t, w, &grade)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, grade.Correct)
+	assert.InDelta(t, 0.25, grade.Score, 1e-9)
+	assert.InDelta(t, 0.75, grade.Penalty, 1e-9)
+	assert.Equal(t, guidance.Explanation, grade.Explanation)
+	assert.Equal(t, map[string]string{"B": guidance.Feedback["B"]}, grade.Feedback)
+	/* grade answer without hints */
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/grade/single-choice/"+question.Id, QuestionSubmission{Answer: b}, admin.Token)
+	grade = QuestionGrade{}
+	unmarshalTestResponse(t, w, &grade)
+	assert.Equal(t, 1.0, grade.Score)
+	assert.Zero(t, grade.Penalty)
+}
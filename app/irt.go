@@ -0,0 +1,384 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"math"
+	"net/http"
+	"nova/logger"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	irtQuadratureLimit         = 4.0
+	irtQuadraturePoints        = 81
+	irtCalibrationResponsesMin = 20
+	irtCalibrationCycles       = 5
+	irtFitIterations           = 300
+	irtFitStep                 = 1.0
+	irtDiscriminationMin       = 0.1
+	irtDiscriminationMax       = 4.0
+	irtDifficultyLimit         = 4.0
+	irtConfidenceZ             = 1.96
+)
+
+// irtItem is calibrated item of logistic model
+type irtItem struct {
+	a float64
+	b float64
+	c float64
+}
+
+func (nova *Nova) HandleCreateIRTCalibration(c *gin.Context) {
+	// calibrate irt parameters of single-choice & judgement questions from stored responses
+	var request IRTCalibration
+	logger.Infof("handle request create irt calibration")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// request irt model correctness
+	logger.Debugf("check irt model is validate")
+	model := strings.ToLower(strings.TrimSpace(request.Model))
+	if b, err := isIRTModelValidate(model); !b {
+		nova.response400BadRequest(c, FieldErrors{{Field: "model", Reason: err.Error()}})
+		logger.Errorf("error check irt model is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check irt model is validate")
+	// examinees do not calibrate questions
+	logger.Debugf("check principal is allowed to calibrate questions")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to calibrate questions"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to calibrate questions"))
+		logger.Errorf("error check principal is allowed to calibrate questions")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to calibrate questions")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// collect responses of exam attempts & adaptive sessions
+	logger.Debugf("query irt responses in database")
+	respondents, err := nova.queryIRTRespondents()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query irt responses in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query irt responses in database")
+	// questions answered often enough are calibrated, guessing is fixed at chance level for 3PL
+	logger.Debugf("calibrate irt parameters")
+	response := IRTCalibrationResult{Model: model, Calibrated: []IRTParameters{}}
+	counts := make(map[string]int)
+	for _, respondent := range respondents {
+		for key := range respondent {
+			counts[key]++
+			response.Responses++
+		}
+	}
+	scope := nova.queryTenantScope(c)
+	guessing := make(map[string]float64)
+	for key, n := range counts {
+		questionType, id, _ := strings.Cut(key, "/")
+		if n < irtCalibrationResponsesMin || !nova.isQuestionExisted(questionType, id) || !nova.isResourceInScope(scope, questionType, id) {
+			response.Skipped++
+			continue
+		}
+		guessing[key] = 0
+		if model == IRTModel3PL {
+			guessing[key] = nova.queryIRTGuessing(questionType, id)
+		}
+	}
+	now := time.Now().UTC()
+	for key, item := range calibrateIRTItems(model, respondents, guessing) {
+		questionType, id, _ := strings.Cut(key, "/")
+		response.Calibrated = append(response.Calibrated, IRTParameters{
+			Type:           questionType,
+			Id:             id,
+			Model:          model,
+			Discrimination: roundToDecimals(item.a, 3),
+			Difficulty:     roundToDecimals(item.b, 3),
+			Guessing:       roundToDecimals(item.c, 3),
+			Responses:      counts[key],
+			CalibratedAt:   now,
+		})
+	}
+	sort.Slice(response.Calibrated, func(i, j int) bool {
+		return response.Calibrated[i].Type+"/"+response.Calibrated[i].Id < response.Calibrated[j].Type+"/"+response.Calibrated[j].Id
+	})
+	logger.Debugf("successfully calibrate irt parameters")
+	// store irt parameters in database
+	logger.Debugf("store irt parameters in database")
+	if err := nova.db.UpdateIRTParameters(response.Calibrated); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store irt parameters in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store irt parameters in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, len(response.Calibrated))
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionIRTParameters(c *gin.Context) {
+	// query irt parameters of question for every calibrated model
+	logger.Infof("handle request query question irt parameters")
+	// extract question type & Id from uri
+	questionType := strings.ToLower(c.Param("type"))
+	id := strings.ToLower(c.Param("Id"))
+	// request question type & Id correctness
+	logger.Debugf("check question type & Id is validate")
+	if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question type & Id is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question type & Id is validate")
+	// examinees do not see parameters of items
+	logger.Debugf("check principal is allowed to query irt parameters")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to query irt parameters"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to query irt parameters"))
+		logger.Errorf("error check principal is allowed to query irt parameters")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to query irt parameters")
+	// query irt parameters from database
+	logger.Debugf("query irt parameters in database")
+	parameters, err := nova.db.QueryQuestionIRTParameters(questionType, id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query irt parameters in database: %v", err)
+		return
+	}
+	response := make([]IRTParameters, 0, len(parameters))
+	for _, v := range parameters {
+		response = append(response, *v)
+	}
+	logger.Debugf("successfully query irt parameters in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) queryIRTRespondents() ([]map[string]bool, error) {
+	// every submitted attempt & adaptive session is one respondent
+	var respondents []map[string]bool
+	attempts, err := nova.db.QuerySubmittedExamAttempts("")
+	if err != nil {
+		return nil, err
+	}
+	for _, attempt := range attempts {
+		respondent := make(map[string]bool)
+		for _, grade := range attempt.Grades {
+			if grade.Graded && isIRTQuestionType(grade.Type) {
+				respondent[grade.Type+"/"+grade.Id] = grade.Correct
+			}
+		}
+		if len(respondent) > 0 {
+			respondents = append(respondents, respondent)
+		}
+	}
+	sessions, err := nova.db.QueryAdaptiveSessions()
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		respondent := make(map[string]bool)
+		for _, item := range session.Items {
+			respondent[item.Type+"/"+item.Id] = item.Correct
+		}
+		if len(respondent) > 0 {
+			respondents = append(respondents, respondent)
+		}
+	}
+	return respondents, nil
+}
+
+func (nova *Nova) queryIRTGuessing(questionType string, id string) float64 {
+	// chance of answering correctly by picking any option
+	if questionType == QuestionTypeSingleChoice {
+		if question, err := nova.querySingleChoiceQuestionInDataCache(id); err == nil && len(question.Answers) > 0 {
+			return 1 / float64(len(question.Answers))
+		}
+	}
+	return 0.5
+}
+
+func isIRTModelValidate(model string) (bool, error) {
+	switch model {
+	case IRTModel1PL, IRTModel2PL, IRTModel3PL:
+		return true, nil
+	default:
+		return false, fmt.Errorf("irt model %v not supported", model)
+	}
+}
+
+func isIRTQuestionType(questionType string) bool {
+	// only dichotomous questions are calibrated
+	return questionType == QuestionTypeSingleChoice || questionType == QuestionTypeJudgement
+}
+
+func irtProbability(item irtItem, theta float64) float64 {
+	return item.c + (1-item.c)/(1+math.Exp(-item.a*(theta-item.b)))
+}
+
+func irtInformation(item irtItem, theta float64) float64 {
+	// fisher information of item at ability
+	p := irtProbability(item, theta)
+	if p <= 0 || p >= 1 {
+		return 0
+	}
+	r := (p - item.c) / (1 - item.c)
+	return item.a * item.a * (1 - p) / p * r * r
+}
+
+func estimateIRTAbility(items []irtItem, responses []bool) (float64, float64) {
+	// expected a posteriori ability with standard normal prior, finite for all-correct patterns too
+	step := 2 * irtQuadratureLimit / float64(irtQuadraturePoints-1)
+	weights := make([]float64, irtQuadraturePoints)
+	thetas := make([]float64, irtQuadraturePoints)
+	total := 0.0
+	for k := range weights {
+		theta := -irtQuadratureLimit + float64(k)*step
+		logLikelihood := -theta * theta / 2
+		for n, item := range items {
+			p := math.Min(math.Max(irtProbability(item, theta), 1e-9), 1-1e-9)
+			if responses[n] {
+				logLikelihood += math.Log(p)
+			} else {
+				logLikelihood += math.Log(1 - p)
+			}
+		}
+		thetas[k], weights[k] = theta, math.Exp(logLikelihood)
+		total += weights[k]
+	}
+	mean, variance := 0.0, 0.0
+	for k := range weights {
+		mean += thetas[k] * weights[k] / total
+	}
+	for k := range weights {
+		variance += (thetas[k] - mean) * (thetas[k] - mean) * weights[k] / total
+	}
+	return mean, math.Sqrt(variance)
+}
+
+func calibrateIRTItems(model string, respondents []map[string]bool, guessing map[string]float64) map[string]irtItem {
+	// abilities start from proportion correct & alternate with item fitting
+	items := make(map[string]irtItem, len(guessing))
+	if len(guessing) == 0 {
+		return items
+	}
+	thetas := make([]float64, len(respondents))
+	for k, respondent := range respondents {
+		correct, n := 0.0, 0.0
+		for key, v := range respondent {
+			if _, ok := guessing[key]; !ok {
+				continue
+			}
+			n++
+			if v {
+				correct++
+			}
+		}
+		thetas[k] = math.Log((correct + 0.5) / (n - correct + 0.5))
+	}
+	standardizeIRTAbilities(thetas)
+	for key, c := range guessing {
+		items[key] = irtItem{a: 1, c: c}
+	}
+	for cycle := 0; cycle < irtCalibrationCycles; cycle++ {
+		for key, item := range items {
+			var abilities []float64
+			var responses []bool
+			for k, respondent := range respondents {
+				if v, ok := respondent[key]; ok {
+					abilities, responses = append(abilities, thetas[k]), append(responses, v)
+				}
+			}
+			items[key] = fitIRTItem(model, abilities, responses, item.c)
+		}
+		for k, respondent := range respondents {
+			var answered []irtItem
+			var responses []bool
+			for key, v := range respondent {
+				if item, ok := items[key]; ok {
+					answered, responses = append(answered, item), append(responses, v)
+				}
+			}
+			thetas[k], _ = estimateIRTAbility(answered, responses)
+		}
+		standardizeIRTAbilities(thetas)
+	}
+	return items
+}
+
+func fitIRTItem(model string, thetas []float64, responses []bool, guessing float64) irtItem {
+	// penalized maximum likelihood by gradient ascent, priors keep items everyone answers alike finite
+	item := irtItem{a: 1, b: 0, c: guessing}
+	if len(thetas) == 0 {
+		return item
+	}
+	n := float64(len(thetas))
+	for iteration := 0; iteration < irtFitIterations; iteration++ {
+		gradientA, gradientB := -(item.a - 1), -item.b/4
+		for k, theta := range thetas {
+			s := 1 / (1 + math.Exp(-item.a*(theta-item.b)))
+			p := math.Min(math.Max(item.c+(1-item.c)*s, 1e-9), 1-1e-9)
+			u := 0.0
+			if responses[k] {
+				u = 1
+			}
+			w := (u - p) / (p * (1 - p)) * (1 - item.c) * s * (1 - s)
+			gradientA += w * (theta - item.b)
+			gradientB -= w * item.a
+		}
+		if model != IRTModel1PL {
+			item.a = math.Min(math.Max(item.a+irtFitStep*gradientA/n, irtDiscriminationMin), irtDiscriminationMax)
+		}
+		item.b = math.Min(math.Max(item.b+irtFitStep*gradientB/n, -irtDifficultyLimit), irtDifficultyLimit)
+	}
+	return item
+}
+
+func standardizeIRTAbilities(thetas []float64) {
+	// abilities are kept on scale of mean 0 & deviation 1
+	if len(thetas) < 2 {
+		return
+	}
+	mean, variance := 0.0, 0.0
+	for _, v := range thetas {
+		mean += v / float64(len(thetas))
+	}
+	for _, v := range thetas {
+		variance += (v - mean) * (v - mean) / float64(len(thetas))
+	}
+	deviation := math.Sqrt(variance)
+	for k := range thetas {
+		thetas[k] -= mean
+		if deviation > 0 {
+			thetas[k] /= deviation
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	. "nova/configure"
+	"nova/logger"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	MailSenderSMTP = "smtp"
+	MailSenderFile = "file"
+	MailSenderLog  = "log"
+)
+
+const (
+	mailDefaultFrom      = "nova@localhost"
+	mailDefaultDirectory = "./mails"
+	mailDefaultPort      = 25
+)
+
+// MailMessage is plain text notification mail sent to user
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// MailSender delivers notification mail such as password reset & email verification links
+type MailSender interface {
+	Send(ctx context.Context, message MailMessage) error
+}
+
+func NewMailSender(settings MailSettings) (MailSender, error) {
+	from := settings.From
+	if from == "" {
+		from = mailDefaultFrom
+	}
+	if _, err := mail.ParseAddress(from); err != nil {
+		return nil, fmt.Errorf("mail sender address incorrect: %w", err)
+	}
+	switch settings.SenderType {
+	case MailSenderSMTP:
+		port := settings.Port
+		if port <= 0 {
+			port = mailDefaultPort
+		}
+		return NewSMTPMailSender(settings.Host, port, settings.Username, settings.Password, from), nil
+	case MailSenderFile:
+		directory := settings.Directory
+		if directory == "" {
+			directory = mailDefaultDirectory
+		}
+		return NewFileMailSender(directory, from)
+	case MailSenderLog, "":
+		return NewLogMailSender(from), nil
+	default:
+		return nil, fmt.Errorf("mail sender %v not supported", settings.SenderType)
+	}
+}
+
+// SMTPMailSender delivers mail through smtp server, plain authentication is used when username is set
+type SMTPMailSender struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+}
+
+func NewSMTPMailSender(host string, port int, username string, password string, from string) *SMTPMailSender {
+	return &SMTPMailSender{
+		addr:     net.JoinHostPort(host, strconv.Itoa(port)),
+		host:     host,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+func (ms *SMTPMailSender) Send(ctx context.Context, message MailMessage) error {
+	content, err := formatMailMessage(ms.from, message)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var auth smtp.Auth
+	if ms.username != "" {
+		auth = smtp.PlainAuth("", ms.username, ms.password, ms.host)
+	}
+	sender, _ := mail.ParseAddress(ms.from)
+	return smtp.SendMail(ms.addr, auth, sender.Address, []string{message.To}, content)
+}
+
+// FileMailSender writes every mail as .eml file in local directory instead of delivering it
+type FileMailSender struct {
+	directory string
+	from      string
+}
+
+func NewFileMailSender(directory string, from string) (*FileMailSender, error) {
+	if err := os.MkdirAll(directory, 0750); err != nil {
+		return nil, err
+	}
+	return &FileMailSender{directory: directory, from: from}, nil
+}
+
+func (ms *FileMailSender) Send(ctx context.Context, message MailMessage) error {
+	content, err := formatMailMessage(ms.from, message)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+	// file names sort in order mail was sent
+	name := fmt.Sprintf("%020d-%v.eml", time.Now().UnixNano(), hex.EncodeToString(b))
+	return os.WriteFile(filepath.Join(ms.directory, name), content, 0640)
+}
+
+// LogMailSender writes every mail to service log, useful when no smtp server is available
+type LogMailSender struct {
+	from string
+}
+
+func NewLogMailSender(from string) *LogMailSender {
+	return &LogMailSender{from: from}
+}
+
+func (ms *LogMailSender) Send(ctx context.Context, message MailMessage) error {
+	content, err := formatMailMessage(ms.from, message)
+	if err != nil {
+		return err
+	}
+	logger.Infof("send mail to %v:\n%s", message.To, content)
+	return ctx.Err()
+}
+
+func formatMailMessage(from string, message MailMessage) ([]byte, error) {
+	// recipient is parsed so user supplied address cannot inject headers
+	to, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return nil, fmt.Errorf("mail recipient address incorrect: %w", err)
+	}
+	if strings.ContainsAny(message.Subject, "\r\n") {
+		return nil, errors.New("mail subject should be single line")
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %v\r\n", from)
+	fmt.Fprintf(&b, "To: %v\r\n", to.String())
+	fmt.Fprintf(&b, "Subject: %v\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&b, "Date: %v\r\n", time.Now().UTC().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(message.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return b.Bytes(), nil
+}
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net"
+	. "nova/configure"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func startMailTestServer(t *testing.T) (string, int, chan string) {
+	// minimal smtp server accepting one mail
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening smtp server: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r, w := bufio.NewReader(conn), bufio.NewWriter(conn)
+		reply := func(line string) {
+			_, _ = w.WriteString(line + "\r\n")
+			_ = w.Flush()
+		}
+		reply("220 localhost ESMTP")
+		var envelope, data strings.Builder
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(command, "MAIL FROM"), strings.HasPrefix(command, "RCPT TO"):
+				envelope.WriteString(strings.TrimSpace(line) + "\n")
+				reply("250 OK")
+			case command == "DATA":
+				reply("354 end data with <CR><LF>.<CR><LF>")
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				reply("250 OK")
+				received <- envelope.String() + data.String()
+			case command == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+	addr := listener.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, received
+}
+
+func TestSMTPMailSender_Send(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestSMTPMailSender_Send
+	// Test Purpose: Test mail is delivered through smtp server
+	// Test Steps:
+	// 1. start local smtp server
+	// 2. send mail by SMTPMailSender, smtp server receives envelope & message
+	-----------------------------------------------------------------------------------------*/
+	host, port, received := startMailTestServer(t)
+	ms, err := NewMailSender(MailSettings{SenderType: MailSenderSMTP, Host: host, Port: port, From: "Nova <nova@example.com>"})
+	assert.NoError(t, err)
+	err = ms.Send(context.Background(), MailMessage{To: "user@example.com", Subject: "Reset your Nova password", Body: "first line\nsecond line\n"})
+	assert.NoError(t, err)
+	message := <-received
+	assert.Contains(t, message, "MAIL FROM:<nova@example.com>")
+	assert.Contains(t, message, "RCPT TO:<user@example.com>")
+	assert.Contains(t, message, "From: Nova <nova@example.com>\r\n")
+	assert.Contains(t, message, "To: <user@example.com>\r\n")
+	assert.Contains(t, message, "Subject: Reset your Nova password\r\n")
+	assert.Contains(t, message, "first line\r\nsecond line\r\n")
+	assert.Equal(t, strconv.Itoa(port), strings.Split(ms.(*SMTPMailSender).addr, ":")[1])
+}
+
+func TestFileMailSender_Send(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestFileMailSender_Send
+	// Test Purpose: Test mail is written as file & headers cannot be injected
+	// Test Steps:
+	// 1. send mail by FileMailSender, receive .eml file in directory
+	// 2. send mail with multi-line subject or incorrect recipient, receive error
+	// 3. create mail sender of unsupported type, receive error
+	-----------------------------------------------------------------------------------------*/
+	directory := filepath.Join(t.TempDir(), "mails")
+	ms, err := NewMailSender(MailSettings{SenderType: MailSenderFile, Directory: directory})
+	assert.NoError(t, err)
+	assert.NoError(t, ms.Send(context.Background(), MailMessage{To: "user@example.com", Subject: "Verify", Body: "link"}))
+	files, err := filepath.Glob(filepath.Join(directory, "*.eml"))
+	assert.NoError(t, err)
+	if assert.Len(t, files, 1) {
+		b, err := os.ReadFile(files[0])
+		assert.NoError(t, err)
+		assert.Contains(t, string(b), "From: "+mailDefaultFrom+"\r\n")
+		assert.True(t, strings.HasSuffix(string(b), "\r\n\r\nlink"))
+	}
+	/* headers cannot be injected */
+	err = ms.Send(context.Background(), MailMessage{To: "user@example.com", Subject: "Verify\r\nBcc: other@example.com", Body: "link"})
+	assert.Error(t, err)
+	err = ms.Send(context.Background(), MailMessage{To: "user@example.com\r\nBcc: other@example.com", Subject: "Verify", Body: "link"})
+	assert.Error(t, err)
+	files, _ = filepath.Glob(filepath.Join(directory, "*.eml"))
+	assert.Len(t, files, 1)
+	/* unsupported sender */
+	_, err = NewMailSender(MailSettings{SenderType: "pigeon"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,620 @@
+package app
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// markdownMathSnippetLength bounds source quoted in math problems
+	markdownMathSnippetLength = 24
+	// markdownEscapable lists characters a backslash turns into text
+	markdownEscapable = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+)
+
+var (
+	// markdownFencePattern matches opening line of fenced code block with optional language
+	markdownFencePattern = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})[ \t]*([A-Za-z0-9_+#.-]*)[ \t]*$")
+	// markdownHeadingPattern matches ATX heading with optional closing hashes
+	markdownHeadingPattern = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	// markdownBulletPattern matches item of bullet list
+	markdownBulletPattern = regexp.MustCompile(`^( {0,3})([-*+])(?:[ \t]+(.*))?$`)
+	// markdownOrderedPattern matches item of ordered list
+	markdownOrderedPattern = regexp.MustCompile(`^( {0,3})(\d{1,9})([.)])(?:[ \t]+(.*))?$`)
+	// markdownAutolinkPattern matches autolink like <https://example.com>
+	markdownAutolinkPattern = regexp.MustCompile(`^<((?:https?|mailto):[^\s<>]+)>`)
+	// markdownEnvironmentPattern matches begin & end of LaTeX environment
+	markdownEnvironmentPattern = regexp.MustCompile(`\\(begin|end)\{([^{}]*)\}`)
+)
+
+// markdownRenderer renders a subset of Markdown to HTML. Source text is always escaped, raw HTML is
+// never passed through and only tags written by renderer itself reach output, links & images are
+// limited to safe URL schemes. Math is kept as escaped LaTeX for client-side typesetting, problems
+// with its delimiters are collected while rendering.
+type markdownRenderer struct {
+	problems []string
+}
+
+func renderMarkdown(text string) (string, []string) {
+	r := &markdownRenderer{}
+	var b strings.Builder
+	r.renderBlocks(&b, strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n"))
+	return b.String(), r.problems
+}
+
+func renderPlainText(text string) string {
+	// plain text keeps its line breaks
+	text = html.EscapeString(strings.ReplaceAll(text, "\r\n", "\n"))
+	return strings.ReplaceAll(text, "\n", "<br>\n")
+}
+
+func (r *markdownRenderer) renderBlocks(b *strings.Builder, lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+		case markdownFencePattern.MatchString(line):
+			i = r.renderFence(b, lines, i)
+		case markdownHeadingPattern.MatchString(line):
+			m := markdownHeadingPattern.FindStringSubmatch(line)
+			level := len(m[1])
+			fmt.Fprintf(b, "<h%d>", level)
+			r.renderInline(b, m[2])
+			fmt.Fprintf(b, "</h%d>\n", level)
+			i++
+		case isMarkdownRule(line):
+			b.WriteString("<hr>\n")
+			i++
+		case isMarkdownQuote(line):
+			var quoted []string
+			for ; i < len(lines) && isMarkdownQuote(lines[i]); i++ {
+				quoted = append(quoted, trimMarkdownQuote(lines[i]))
+			}
+			b.WriteString("<blockquote>\n")
+			r.renderBlocks(b, quoted)
+			b.WriteString("</blockquote>\n")
+		case isMarkdownListItem(line):
+			i = r.renderList(b, lines, i)
+		case strings.HasPrefix(strings.TrimSpace(line), "$$") && strings.Count(line, "$$") == 1:
+			if next := r.renderMathBlock(b, lines, i); next > i {
+				i = next
+				continue
+			}
+			i = r.renderParagraph(b, lines, i)
+		default:
+			i = r.renderParagraph(b, lines, i)
+		}
+	}
+}
+
+func (r *markdownRenderer) renderFence(b *strings.Builder, lines []string, i int) int {
+	// fenced code block runs to closing fence of same kind or to end of text
+	m := markdownFencePattern.FindStringSubmatch(lines[i])
+	fence := m[1]
+	var body []string
+	j := i + 1
+	for ; j < len(lines); j++ {
+		closing := strings.TrimSpace(lines[j])
+		if len(closing) >= len(fence) && strings.Trim(closing, fence[:1]) == "" {
+			j++
+			break
+		}
+		body = append(body, lines[j])
+	}
+	b.WriteString("<pre><code")
+	if m[2] != "" {
+		fmt.Fprintf(b, ` class="language-%s"`, html.EscapeString(m[2]))
+	}
+	b.WriteString(">")
+	for _, v := range body {
+		b.WriteString(html.EscapeString(v))
+		b.WriteString("\n")
+	}
+	b.WriteString("</code></pre>\n")
+	return j
+}
+
+func (r *markdownRenderer) renderMathBlock(b *strings.Builder, lines []string, i int) int {
+	// display math opened by $$ on its own line runs to line closing it
+	for j := i + 1; j < len(lines); j++ {
+		if k := strings.Index(lines[j], "$$"); k >= 0 {
+			if strings.TrimSpace(lines[j][k+2:]) != "" {
+				return i
+			}
+			body := []string{strings.TrimSpace(lines[i])[2:]}
+			body = append(body, lines[i+1:j]...)
+			body = append(body, lines[j][:k])
+			r.renderMath(b, strings.TrimSpace(strings.Join(body, "\n")), true)
+			b.WriteString("\n")
+			return j + 1
+		}
+		if strings.TrimSpace(lines[j]) == "" {
+			break
+		}
+	}
+	return i
+}
+
+func (r *markdownRenderer) renderParagraph(b *strings.Builder, lines []string, i int) int {
+	// paragraph runs to blank line or to start of another block
+	j := i + 1
+	for ; j < len(lines); j++ {
+		line := lines[j]
+		if strings.TrimSpace(line) == "" || markdownFencePattern.MatchString(line) || markdownHeadingPattern.MatchString(line) ||
+			isMarkdownRule(line) || isMarkdownQuote(line) || isMarkdownListItem(line) {
+			break
+		}
+	}
+	paragraph := make([]string, 0, j-i)
+	for _, v := range lines[i:j] {
+		paragraph = append(paragraph, strings.TrimLeft(v, " \t"))
+	}
+	b.WriteString("<p>")
+	r.renderInline(b, strings.TrimRight(strings.Join(paragraph, "\n"), " \t"))
+	b.WriteString("</p>\n")
+	return j
+}
+
+// markdownListItem is marker of list item
+type markdownListItem struct {
+	ordered bool
+	marker  string
+	start   int
+	indent  int
+	text    string
+}
+
+func parseMarkdownListItem(line string) (markdownListItem, bool) {
+	if m := markdownBulletPattern.FindStringSubmatch(line); m != nil && !isMarkdownRule(line) {
+		item := markdownListItem{marker: m[2], indent: len(m[1]) + 2, text: m[3]}
+		if m[3] != "" {
+			item.indent = len(line) - len(m[3])
+		}
+		return item, true
+	}
+	if m := markdownOrderedPattern.FindStringSubmatch(line); m != nil {
+		start, _ := strconv.Atoi(m[2])
+		item := markdownListItem{ordered: true, marker: m[3], start: start, indent: len(m[1]) + len(m[2]) + 2, text: m[4]}
+		if m[4] != "" {
+			item.indent = len(line) - len(m[4])
+		}
+		return item, true
+	}
+	return markdownListItem{}, false
+}
+
+func isMarkdownListItem(line string) bool {
+	_, ok := parseMarkdownListItem(line)
+	return ok
+}
+
+func (r *markdownRenderer) renderList(b *strings.Builder, lines []string, i int) int {
+	first, _ := parseMarkdownListItem(lines[i])
+	tag := "ul"
+	if first.ordered {
+		tag = "ol"
+	}
+	b.WriteString("<" + tag)
+	if first.ordered && first.start != 1 {
+		fmt.Fprintf(b, ` start="%d"`, first.start)
+	}
+	b.WriteString(">\n")
+	for i < len(lines) {
+		item, ok := parseMarkdownListItem(lines[i])
+		if !ok || item.ordered != first.ordered || item.marker != first.marker {
+			break
+		}
+		// item owns lines indented below its marker & lazy paragraph continuations
+		content := []string{item.text}
+		loose := false
+		j := i + 1
+		for ; j < len(lines); j++ {
+			line := lines[j]
+			if strings.TrimSpace(line) == "" {
+				if j+1 < len(lines) && markdownIndent(lines[j+1]) >= item.indent {
+					content = append(content, "")
+					loose = true
+					continue
+				}
+				break
+			}
+			if markdownIndent(line) >= item.indent {
+				content = append(content, line[item.indent:])
+				continue
+			}
+			if isMarkdownListItem(line) || markdownFencePattern.MatchString(line) || markdownHeadingPattern.MatchString(line) ||
+				isMarkdownRule(line) || isMarkdownQuote(line) {
+				break
+			}
+			content = append(content, line)
+		}
+		var sub strings.Builder
+		r.renderBlocks(&sub, content)
+		text := sub.String()
+		// tight items are not wrapped in paragraphs, every <p> in output is written by renderer
+		if !loose {
+			text = strings.ReplaceAll(strings.ReplaceAll(text, "<p>", ""), "</p>\n", "\n")
+		}
+		b.WriteString("<li>")
+		b.WriteString(strings.TrimSuffix(text, "\n"))
+		b.WriteString("</li>\n")
+		i = j
+		// blank line between items continues the list
+		if i+1 < len(lines) && strings.TrimSpace(lines[i]) == "" && isMarkdownListItem(lines[i+1]) {
+			i++
+		}
+	}
+	b.WriteString("</" + tag + ">\n")
+	return i
+}
+
+func (r *markdownRenderer) renderInline(b *strings.Builder, text string) {
+	for i := 0; i < len(text); {
+		switch c := text[i]; c {
+		case '\\':
+			i = r.renderEscape(b, text, i)
+		case '`':
+			i = r.renderCodeSpan(b, text, i)
+		case '$':
+			i = r.renderInlineMath(b, text, i)
+		case '*', '_':
+			i = r.renderEmphasis(b, text, i)
+		case '[':
+			i = r.renderLink(b, text, i)
+		case '!':
+			if strings.HasPrefix(text[i:], "![") {
+				i = r.renderImage(b, text, i)
+				continue
+			}
+			b.WriteByte('!')
+			i++
+		case '<':
+			if m := markdownAutolinkPattern.FindStringSubmatch(text[i:]); m != nil {
+				if link, ok := sanitizeMarkdownURL(m[1], false); ok {
+					fmt.Fprintf(b, `<a href="%s" rel="nofollow noopener noreferrer">%s</a>`, html.EscapeString(link), html.EscapeString(m[1]))
+					i += len(m[0])
+					continue
+				}
+			}
+			b.WriteString("&lt;")
+			i++
+		case '\n':
+			// two trailing spaces break line
+			if i >= 2 && text[i-1] == ' ' && text[i-2] == ' ' {
+				b.WriteString("<br>\n")
+			} else {
+				b.WriteByte('\n')
+			}
+			i++
+		default:
+			_, size := utf8.DecodeRuneInString(text[i:])
+			b.WriteString(html.EscapeString(text[i : i+size]))
+			i += size
+		}
+	}
+}
+
+func (r *markdownRenderer) renderEscape(b *strings.Builder, text string, i int) int {
+	if i+1 >= len(text) {
+		b.WriteByte('\\')
+		return i + 1
+	}
+	switch next := text[i+1]; {
+	case next == '\n':
+		b.WriteString("<br>\n")
+		return i + 2
+	case next == '(' || next == '[':
+		// \( \) & \[ \] delimit inline & display math
+		closing, display := `\)`, false
+		if next == '[' {
+			closing, display = `\]`, true
+		}
+		end := strings.Index(text[i+2:], closing)
+		if end < 0 {
+			r.problems = append(r.problems, fmt.Sprintf("math opened by %v is not closed by %v", quoteMarkdownMath(text[i:]), closing))
+			b.WriteString(html.EscapeString(text[i : i+2]))
+			return i + 2
+		}
+		r.renderMath(b, text[i+2:i+2+end], display)
+		return i + 2 + end + 2
+	case strings.IndexByte(markdownEscapable, next) >= 0:
+		b.WriteString(html.EscapeString(text[i+1 : i+2]))
+		return i + 2
+	default:
+		b.WriteByte('\\')
+		return i + 1
+	}
+}
+
+func (r *markdownRenderer) renderCodeSpan(b *strings.Builder, text string, i int) int {
+	// code span closes at backtick run of same length
+	run := countMarkdownRun(text, i, '`')
+	for j := i + run; j < len(text); {
+		k := strings.IndexByte(text[j:], '`')
+		if k < 0 {
+			break
+		}
+		j += k
+		if n := countMarkdownRun(text, j, '`'); n != run {
+			j += n
+			continue
+		}
+		code := strings.ReplaceAll(text[i+run:j], "\n", " ")
+		if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+			code = code[1 : len(code)-1]
+		}
+		b.WriteString("<code>")
+		b.WriteString(html.EscapeString(code))
+		b.WriteString("</code>")
+		return j + run
+	}
+	b.WriteString(text[i : i+run])
+	return i + run
+}
+
+func (r *markdownRenderer) renderInlineMath(b *strings.Builder, text string, i int) int {
+	// $$ delimits display math
+	if strings.HasPrefix(text[i:], "$$") {
+		end := strings.Index(text[i+2:], "$$")
+		if end < 0 {
+			r.problems = append(r.problems, fmt.Sprintf("display math opened by %v is not closed by $$", quoteMarkdownMath(text[i:])))
+			b.WriteString("$$")
+			return i + 2
+		}
+		r.renderMath(b, text[i+2:i+2+end], true)
+		return i + 2 + end + 2
+	}
+	// $ opens inline math when followed by non-blank, closing $ follows non-blank & is not followed by digit
+	if i+1 >= len(text) || isMarkdownSpace(text[i+1]) {
+		b.WriteByte('$')
+		return i + 1
+	}
+	for j := i + 1; j < len(text); j++ {
+		switch text[j] {
+		case '\\':
+			j++
+		case '$':
+			if isMarkdownSpace(text[j-1]) || j+1 < len(text) && text[j+1] >= '0' && text[j+1] <= '9' {
+				continue
+			}
+			r.renderMath(b, text[i+1:j], false)
+			return j + 1
+		}
+	}
+	// amounts like $5 are kept as text, other unclosed math is reported
+	if text[i+1] < '0' || text[i+1] > '9' {
+		r.problems = append(r.problems, fmt.Sprintf(`inline math opened by %v is not closed by $, escape literal dollar as \$`, quoteMarkdownMath(text[i:])))
+	}
+	b.WriteByte('$')
+	return i + 1
+}
+
+func (r *markdownRenderer) renderMath(b *strings.Builder, math string, display bool) {
+	r.checkMath(math)
+	if display {
+		b.WriteString(`<span class="math display">\[`)
+		b.WriteString(html.EscapeString(math))
+		b.WriteString(`\]</span>`)
+		return
+	}
+	b.WriteString(`<span class="math inline">\(`)
+	b.WriteString(html.EscapeString(math))
+	b.WriteString(`\)</span>`)
+}
+
+func (r *markdownRenderer) checkMath(math string) {
+	// braces & environments should be balanced
+	if strings.TrimSpace(math) == "" {
+		r.problems = append(r.problems, "math should not be empty")
+		return
+	}
+	depth := 0
+	for i := 0; i < len(math); i++ {
+		switch math[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+		}
+		if depth < 0 {
+			break
+		}
+	}
+	if depth != 0 {
+		r.problems = append(r.problems, fmt.Sprintf("math %v has unbalanced braces", quoteMarkdownMath(math)))
+	}
+	var environments []string
+	for _, m := range markdownEnvironmentPattern.FindAllStringSubmatch(math, -1) {
+		if m[1] == "begin" {
+			environments = append(environments, m[2])
+			continue
+		}
+		if len(environments) == 0 || environments[len(environments)-1] != m[2] {
+			r.problems = append(r.problems, fmt.Sprintf(`math %v ends environment %v which is not open`, quoteMarkdownMath(math), m[2]))
+			return
+		}
+		environments = environments[:len(environments)-1]
+	}
+	if len(environments) > 0 {
+		r.problems = append(r.problems, fmt.Sprintf(`math %v does not end environment %v`, quoteMarkdownMath(math), environments[len(environments)-1]))
+	}
+}
+
+func (r *markdownRenderer) renderEmphasis(b *strings.Builder, text string, i int) int {
+	c := text[i]
+	run := countMarkdownRun(text, i, c)
+	// underscore inside words like snake_case is text
+	if c == '_' && i > 0 && isMarkdownWord(text[i-1]) {
+		b.WriteString(text[i : i+run])
+		return i + run
+	}
+	for _, n := range []int{2, 1} {
+		if run < n || i+n >= len(text) || isMarkdownSpace(text[i+n]) {
+			continue
+		}
+		delimiter := strings.Repeat(string(c), n)
+		for j := i + n + 1; j+n <= len(text); j++ {
+			if !strings.HasPrefix(text[j:], delimiter) || isMarkdownSpace(text[j-1]) {
+				continue
+			}
+			// single delimiter does not close at double one
+			if n == 1 && j+1 < len(text) && text[j+1] == c {
+				j++
+				continue
+			}
+			if c == '_' && j+n < len(text) && isMarkdownWord(text[j+n]) {
+				continue
+			}
+			tag := "em"
+			if n == 2 {
+				tag = "strong"
+			}
+			b.WriteString("<" + tag + ">")
+			r.renderInline(b, text[i+n:j])
+			b.WriteString("</" + tag + ">")
+			return j + n
+		}
+	}
+	b.WriteString(text[i : i+run])
+	return i + run
+}
+
+func (r *markdownRenderer) renderLink(b *strings.Builder, text string, i int) int {
+	label, target, end, ok := parseMarkdownLink(text, i)
+	if !ok {
+		b.WriteByte('[')
+		return i + 1
+	}
+	link, ok := sanitizeMarkdownURL(target, false)
+	if !ok {
+		// unsafe link keeps its label only
+		r.renderInline(b, label)
+		return end
+	}
+	fmt.Fprintf(b, `<a href="%s" rel="nofollow noopener noreferrer">`, html.EscapeString(link))
+	r.renderInline(b, label)
+	b.WriteString("</a>")
+	return end
+}
+
+func (r *markdownRenderer) renderImage(b *strings.Builder, text string, i int) int {
+	alt, target, end, ok := parseMarkdownLink(text, i+1)
+	if !ok {
+		b.WriteByte('!')
+		return i + 1
+	}
+	link, ok := sanitizeMarkdownURL(target, true)
+	if !ok {
+		b.WriteString(html.EscapeString(alt))
+		return end
+	}
+	fmt.Fprintf(b, `<img src="%s" alt="%s">`, html.EscapeString(link), html.EscapeString(alt))
+	return end
+}
+
+func parseMarkdownLink(text string, i int) (string, string, int, bool) {
+	// [label](target "title") with nested brackets in label
+	depth := 0
+	for j := i; j < len(text); j++ {
+		switch text[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if j+1 >= len(text) || text[j+1] != '(' {
+				return "", "", 0, false
+			}
+			k := strings.IndexByte(text[j+2:], ')')
+			if k < 0 {
+				return "", "", 0, false
+			}
+			fields := strings.Fields(text[j+2 : j+2+k])
+			if len(fields) == 0 {
+				return "", "", 0, false
+			}
+			target := strings.TrimSuffix(strings.TrimPrefix(fields[0], "<"), ">")
+			return text[i+1 : j], target, j + 2 + k + 1, true
+		}
+	}
+	return "", "", 0, false
+}
+
+func sanitizeMarkdownURL(raw string, image bool) (string, bool) {
+	// only web & mail links, images are only loaded over web
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		return u.String(), u.Host != ""
+	case "mailto":
+		return u.String(), !image
+	case "":
+		// relative reference may not smuggle scheme past parser
+		return u.String(), u.Opaque == "" && !strings.ContainsAny(raw, ":\\")
+	default:
+		return "", false
+	}
+}
+
+func isMarkdownRule(line string) bool {
+	// three or more of same -, * or _ with optional spaces
+	text := strings.ReplaceAll(strings.ReplaceAll(strings.TrimSpace(line), " ", ""), "\t", "")
+	if len(text) < 3 || markdownIndent(line) > 3 {
+		return false
+	}
+	return strings.Trim(text, text[:1]) == "" && strings.ContainsAny(text[:1], "-*_")
+}
+
+func isMarkdownQuote(line string) bool {
+	return markdownIndent(line) <= 3 && strings.HasPrefix(strings.TrimLeft(line, " "), ">")
+}
+
+func trimMarkdownQuote(line string) string {
+	line = strings.TrimPrefix(strings.TrimLeft(line, " "), ">")
+	return strings.TrimPrefix(line, " ")
+}
+
+func markdownIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func countMarkdownRun(text string, i int, c byte) int {
+	n := 0
+	for i+n < len(text) && text[i+n] == c {
+		n++
+	}
+	return n
+}
+
+func isMarkdownSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+func isMarkdownWord(c byte) bool {
+	return c >= utf8.RuneSelf || c == '_' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
+
+func quoteMarkdownMath(text string) string {
+	// problems quote start of offending source
+	text = strings.ReplaceAll(text, "\n", " ")
+	if utf8.RuneCountInString(text) > markdownMathSnippetLength {
+		text = string([]rune(text)[:markdownMathSnippetLength]) + "..."
+	}
+	return strconv.Quote(text)
+}
@@ -17,11 +17,21 @@ import (
 	"time"
 )
 
+// databaseSource data source name of Nova database
+var databaseSource = "file:nova.db?cache=shared"
+
 type Nova struct {
 	conf  *Config
 	cache *Cache
 	db    *DB
 	rc    *RedisCache
+	sb    *Sandbox
+	bs    BlobStore
+	ak    []byte
+	ms    MailSender
+	lt    LoginThrottle
+	sweep context.CancelFunc
+	swept chan struct{}
 }
 
 func New() *Nova {
@@ -69,7 +79,7 @@ func (nova *Nova) Init() {
 	}
 	// create database
 	logger.Info("Create Nova database...")
-	nova.db, err = NewDB("file:nova.db?cache=shared")
+	nova.db, err = NewDB(databaseSource)
 	if err != nil {
 		logger.Fatalf("Failed to create database: %s\n", err)
 		fmt.Printf("Failed to create database: %s\n", err)
@@ -85,6 +95,33 @@ func (nova *Nova) Init() {
 		os.Exit(6)
 	}
 	logger.Info("Successfully create tables.")
+	// create code sandbox
+	nova.sb = NewSandbox(nova.conf.Configure.Sandbox)
+	// create attachment blob store
+	logger.Info("Create attachment blob store...")
+	nova.bs, err = NewFileBlobStore(nova.queryAttachmentDirectory())
+	if err == nil {
+		nova.ak, err = nova.queryAttachmentSigningKey()
+	}
+	if err != nil {
+		logger.Fatalf("Failed to create attachment blob store: %s\n", err)
+		fmt.Printf("Failed to create attachment blob store: %s\n", err)
+		os.Exit(20)
+	}
+	logger.Info("Successfully create attachment blob store.")
+	// create mail sender
+	logger.Info("Create mail sender...")
+	nova.ms, err = NewMailSender(nova.conf.Configure.Mail)
+	if err != nil {
+		logger.Fatalf("Failed to create mail sender: %s\n", err)
+		fmt.Printf("Failed to create mail sender: %s\n", err)
+		os.Exit(22)
+	}
+	logger.Info("Successfully create mail sender.")
+	// create login throttle, counters are shared through redis data cache
+	logger.Info("Create login throttle...")
+	nova.lt = NewLoginThrottle(nova.rc)
+	logger.Info("Successfully create login throttle.")
 	// query users from database
 	logger.Info("Query users from database...")
 	users, err := nova.db.QueryUsers()
@@ -145,6 +182,102 @@ func (nova *Nova) Init() {
 		nova.cache.questionsCache.essayCache.essaySet = append(nova.cache.questionsCache.essayCache.essaySet, *question)
 	}
 	logger.Info("Successfully query essay questions from database.")
+	// query fill-blank questions from database
+	logger.Info("Query fill-blank questions from database...")
+	fillBlankQuestions, err := nova.db.QueryQuestionsFillBlank()
+	if err != nil {
+		logger.Fatalf("Failed to query fill-blank questions from database: %s\n", err)
+		fmt.Printf("Failed to query fill-blank questions from database: %s\n", err)
+		os.Exit(14)
+	}
+	for _, question := range fillBlankQuestions {
+		nova.cache.questionsCache.fillBlankCache.fillBlankSet = append(nova.cache.questionsCache.fillBlankCache.fillBlankSet, *question)
+	}
+	logger.Info("Successfully query fill-blank questions from database.")
+	// query matching questions from database
+	logger.Info("Query matching questions from database...")
+	matchingQuestions, err := nova.db.QueryQuestionsMatching()
+	if err != nil {
+		logger.Fatalf("Failed to query matching questions from database: %s\n", err)
+		fmt.Printf("Failed to query matching questions from database: %s\n", err)
+		os.Exit(15)
+	}
+	for _, question := range matchingQuestions {
+		nova.cache.questionsCache.matchingCache.matchingSet = append(nova.cache.questionsCache.matchingCache.matchingSet, *question)
+	}
+	logger.Info("Successfully query matching questions from database.")
+	// query ordering questions from database
+	logger.Info("Query ordering questions from database...")
+	orderingQuestions, err := nova.db.QueryQuestionsOrdering()
+	if err != nil {
+		logger.Fatalf("Failed to query ordering questions from database: %s\n", err)
+		fmt.Printf("Failed to query ordering questions from database: %s\n", err)
+		os.Exit(16)
+	}
+	for _, question := range orderingQuestions {
+		nova.cache.questionsCache.orderingCache.orderingSet = append(nova.cache.questionsCache.orderingCache.orderingSet, *question)
+	}
+	logger.Info("Successfully query ordering questions from database.")
+	// query numeric questions from database
+	logger.Info("Query numeric questions from database...")
+	numericQuestions, err := nova.db.QueryQuestionsNumeric()
+	if err != nil {
+		logger.Fatalf("Failed to query numeric questions from database: %s\n", err)
+		fmt.Printf("Failed to query numeric questions from database: %s\n", err)
+		os.Exit(17)
+	}
+	for _, question := range numericQuestions {
+		nova.cache.questionsCache.numericCache.numericSet = append(nova.cache.questionsCache.numericCache.numericSet, *question)
+	}
+	logger.Info("Successfully query numeric questions from database.")
+	// query template questions from database
+	logger.Info("Query template questions from database...")
+	templateQuestions, err := nova.db.QueryQuestionsTemplate()
+	if err != nil {
+		logger.Fatalf("Failed to query template questions from database: %s\n", err)
+		fmt.Printf("Failed to query template questions from database: %s\n", err)
+		os.Exit(18)
+	}
+	for _, question := range templateQuestions {
+		nova.cache.questionsCache.templateCache.templateSet = append(nova.cache.questionsCache.templateCache.templateSet, *question)
+	}
+	logger.Info("Successfully query template questions from database.")
+	// query code questions from database
+	logger.Info("Query code questions from database...")
+	codeQuestions, err := nova.db.QueryQuestionsCode()
+	if err != nil {
+		logger.Fatalf("Failed to query code questions from database: %s\n", err)
+		fmt.Printf("Failed to query code questions from database: %s\n", err)
+		os.Exit(19)
+	}
+	for _, question := range codeQuestions {
+		nova.cache.questionsCache.codeCache.codeSet = append(nova.cache.questionsCache.codeCache.codeSet, *question)
+	}
+	logger.Info("Successfully query code questions from database.")
+	// query user roles from database
+	logger.Info("Query user roles from database...")
+	if err := nova.queryUserRolesInDatabase(); err != nil {
+		logger.Fatalf("Failed to query user roles from database: %s\n", err)
+		fmt.Printf("Failed to query user roles from database: %s\n", err)
+		os.Exit(12)
+	}
+	logger.Info("Successfully query user roles from database.")
+	// query question workflows from database
+	logger.Info("Query question workflows from database...")
+	if err := nova.queryQuestionWorkflowsInDatabase(); err != nil {
+		logger.Fatalf("Failed to query question workflows from database: %s\n", err)
+		fmt.Printf("Failed to query question workflows from database: %s\n", err)
+		os.Exit(13)
+	}
+	logger.Info("Successfully query question workflows from database.")
+	// query tenant resources from database
+	logger.Info("Query tenant resources from database...")
+	if err := nova.queryTenantResourcesInDatabase(); err != nil {
+		logger.Fatalf("Failed to query tenant resources from database: %s\n", err)
+		fmt.Printf("Failed to query tenant resources from database: %s\n", err)
+		os.Exit(21)
+	}
+	logger.Info("Successfully query tenant resources from database.")
 }
 
 func (nova *Nova) Start() {
@@ -153,10 +286,19 @@ func (nova *Nova) Start() {
 	// apply Gin logger & recovery middleware
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
+	// client ip of login throttling is only taken from trusted proxies
+	if err := nova.setupTrustedProxies(router); err != nil {
+		logger.Panicf("Failed to set trusted proxies: %s\n", err)
+	}
+	// start sweeper auto-submitting exam attempts past deadline
+	nova.startExamAttemptSweeper()
 	// create router group for nova
 	novaService := router.Group("nova/v1")
+	// resources named by uri are restricted to tenant of principal
+	novaService.Use(nova.HandleTenantScope)
 	{
 		novaService.GET("/test", func(c *gin.Context) { c.String(http.StatusOK, "hello Nova\n") })
+		novaService.GET("/time", nova.HandleQueryServerTime)
 		/* user management */
 		// userId related
 		novaService.POST("/user/userId", nova.HandleCreateUserId)
@@ -169,9 +311,119 @@ func (nova *Nova) Start() {
 		novaService.GET("/user/:userId", nova.HandleQueryUser)
 		// user login related
 		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
+		// user role related
+		novaService.PUT("/user/role/:userId", nova.HandleUpdateUserRole)
+		novaService.GET("/user/role/:userId", nova.HandleQueryUserRole)
+		// user provisioning related
+		novaService.POST("/user/import", nova.HandleCreateUserImport)
+		novaService.POST("/user/invitation/:token", nova.HandleCreateUserInvitationAcceptance)
+		// password reset & email verification related
+		novaService.POST("/user/password/reset", nova.HandleCreatePasswordReset)
+		novaService.POST("/user/password/reset/:token", nova.HandleCreatePasswordResetConfirmation)
+		novaService.POST("/user/email/verification", nova.HandleCreateEmailVerification)
+		novaService.POST("/user/email/verification/:token", nova.HandleCreateEmailVerificationConfirmation)
+		novaService.GET("/user/email/:userId", nova.HandleQueryEmailVerification)
+		// two-factor authentication related
+		novaService.POST("/user/totp", nova.HandleCreateUserTwoFactor)
+		novaService.POST("/user/totp/verification", nova.HandleCreateUserTwoFactorVerification)
+		novaService.POST("/user/totp/recovery", nova.HandleCreateUserRecoveryCodes)
+		novaService.DELETE("/user/totp/:userId", nova.HandleDeleteUserTwoFactor)
+		novaService.GET("/user/totp/:userId", nova.HandleQueryUserTwoFactor)
+		// login lockout related
+		novaService.GET("/user/lockout/:userId", nova.HandleQueryUserLockout)
+		novaService.DELETE("/user/lockout/:userId", nova.HandleDeleteUserLockout)
 		/* question management */
 		// questionId related
 		novaService.POST("/question/Id", nova.HandleCreateQuestionId)
+		// question duplicate related
+		novaService.GET("/question/duplicates", nova.HandleQueryQuestionDuplicates)
+		// question revision related
+		novaService.GET("/question/revision/:type/:Id", nova.HandleQueryQuestionRevisions)
+		novaService.GET("/question/revision/:type/:Id/:revision", nova.HandleQueryQuestionRevision)
+		novaService.GET("/question/revision/:type/:Id/:revision/diff", nova.HandleQueryQuestionRevisionDiff)
+		novaService.POST("/question/revision/:type/:Id/:revision/restore", nova.HandleCreateQuestionRevisionRestore)
+		// question workflow related
+		novaService.GET("/question/workflow", nova.HandleQueryQuestionWorkflows)
+		novaService.GET("/question/workflow/:type/:Id", nova.HandleQueryQuestionWorkflow)
+		novaService.POST("/question/workflow/:type/:Id/transition", nova.HandleCreateQuestionTransition)
+		novaService.POST("/question/workflow/:type/:Id/approve", nova.HandleCreateQuestionApproval)
+		// question guidance related
+		novaService.PUT("/question/guidance/:type/:Id", nova.HandleUpdateQuestionGuidance)
+		novaService.GET("/question/guidance/:type/:Id", nova.HandleQueryQuestionGuidance)
+		novaService.POST("/question/guidance/:type/:Id/hint", nova.HandleCreateQuestionHint)
+		// attachment related
+		novaService.POST("/attachment", nova.HandleCreateAttachment)
+		novaService.POST("/attachment/gc", nova.HandleCreateAttachmentCollection)
+		novaService.GET("/attachment/:attachmentId", nova.HandleQueryAttachment)
+		novaService.GET("/attachment/:attachmentId/content", nova.HandleQueryAttachmentContent)
+		// question attachment related
+		novaService.PUT("/question/attachment/:type/:Id", nova.HandleUpdateQuestionAttachments)
+		novaService.GET("/question/attachment/:type/:Id", nova.HandleQueryQuestionAttachments)
+		/* exam management */
+		// exam related
+		novaService.POST("/exam/:examId", nova.HandleCreateExam)
+		novaService.GET("/exam/:examId", nova.HandleQueryExam)
+		novaService.GET("/exam/:examId/analysis", nova.HandleQueryExamAnalysis)
+		novaService.GET("/exam/assigned", nova.HandleQueryAssignedExams)
+		// exam blueprint related
+		novaService.POST("/exam/blueprint/:blueprintId", nova.HandleCreateExamBlueprint)
+		novaService.GET("/exam/blueprint/:blueprintId", nova.HandleQueryExamBlueprint)
+		novaService.POST("/exam/blueprint/:blueprintId/exam", nova.HandleCreateExamBlueprintExam)
+		// adaptive exam related
+		novaService.POST("/exam/adaptive/:examId", nova.HandleCreateAdaptiveExam)
+		novaService.GET("/exam/adaptive/:examId", nova.HandleQueryAdaptiveExam)
+		novaService.POST("/exam/adaptive/:examId/session", nova.HandleCreateAdaptiveSession)
+		novaService.GET("/exam/adaptive/session/:sessionId", nova.HandleQueryAdaptiveSession)
+		novaService.POST("/exam/adaptive/session/:sessionId/response", nova.HandleCreateAdaptiveResponse)
+		// exam attempt related
+		novaService.POST("/exam/:examId/attempt", nova.HandleCreateExamAttempt)
+		novaService.GET("/exam/attempt/:attemptId", nova.HandleQueryExamAttempt)
+		novaService.POST("/exam/attempt/sweep", nova.HandleCreateExamAttemptSweep)
+		novaService.POST("/exam/attempt/:attemptId/submission", nova.HandleCreateExamSubmission)
+		novaService.PUT("/exam/attempt/:attemptId/draft", nova.HandleUpdateExamAttemptDraft)
+		novaService.GET("/exam/attempt/:attemptId/draft", nova.HandleQueryExamAttemptDraft)
+		/* practice management */
+		novaService.GET("/practice/due", nova.HandleQueryPracticeQueue)
+		novaService.POST("/practice/review/:type/:Id", nova.HandleCreatePracticeReview)
+		novaService.GET("/practice/card/:type/:Id", nova.HandleQueryPracticeCard)
+		/* gradebook management */
+		novaService.GET("/gradebook", nova.HandleQueryGradebooks)
+		novaService.GET("/gradebook/:userId", nova.HandleQueryUserGradebook)
+		/* tenant management */
+		novaService.POST("/tenant/:tenantId", nova.HandleCreateTenant)
+		novaService.GET("/tenant", nova.HandleQueryTenants)
+		novaService.GET("/tenant/:tenantId", nova.HandleQueryTenant)
+		novaService.PUT("/tenant/:tenantId/user/:userId", nova.HandleUpdateTenantUser)
+		novaService.DELETE("/tenant/:tenantId/user/:userId", nova.HandleDeleteTenantUser)
+		/* group management */
+		// group related
+		novaService.POST("/group/:groupId", nova.HandleCreateGroup)
+		novaService.GET("/group", nova.HandleQueryGroups)
+		novaService.GET("/group/:groupId", nova.HandleQueryGroup)
+		novaService.DELETE("/group/:groupId", nova.HandleDeleteGroup)
+		// group member related
+		novaService.POST("/group/:groupId/member", nova.HandleCreateGroupMembers)
+		novaService.PUT("/group/:groupId/member", nova.HandleUpdateGroupMembers)
+		novaService.GET("/group/:groupId/member", nova.HandleQueryGroupMembers)
+		novaService.DELETE("/group/:groupId/member/:userId", nova.HandleDeleteGroupMember)
+		// group exam assignment related
+		novaService.POST("/group/:groupId/exam/:examId", nova.HandleCreateExamAssignment)
+		novaService.DELETE("/group/:groupId/exam/:examId", nova.HandleDeleteExamAssignment)
+		novaService.GET("/group/:groupId/exam", nova.HandleQueryGroupExams)
+		novaService.GET("/group/:groupId/exam/:examId/results", nova.HandleQueryGroupResults)
+		// question classification related
+		novaService.PUT("/question/classification/:type/:Id", nova.HandleUpdateQuestionClassification)
+		novaService.GET("/question/classification/:type/:Id", nova.HandleQueryQuestionClassification)
+		// question analysis related
+		novaService.GET("/question/analysis/:type/:Id", nova.HandleQueryQuestionAnalysis)
+		// question irt related
+		novaService.POST("/question/irt/calibration", nova.HandleCreateIRTCalibration)
+		novaService.GET("/question/irt/:type/:Id", nova.HandleQueryQuestionIRTParameters)
+		// question format related
+		novaService.PUT("/question/format/:type/:Id", nova.HandleUpdateQuestionFormat)
+		novaService.GET("/question/render/:type/:Id", nova.HandleQueryQuestionRendering)
+		// question grade related
+		novaService.POST("/question/grade/:type/:Id", nova.HandleCreateQuestionGrade)
 		// question related
 		novaService.POST("/question/single-choice/:Id", nova.HandleCreateQuestionSingleChoice)
 		novaService.PUT("/question/single-choice/:Id", nova.HandleUpdateQuestionSingleChoice)
@@ -193,6 +445,40 @@ func (nova *Nova) Start() {
 		novaService.DELETE("/question/essay/:Id", nova.HandleDeleteQuestionEssay)
 		novaService.PATCH("/question/essay/:Id", nova.HandleModifyQuestionEssay)
 		novaService.GET("/question/essay/:Id", nova.HandleQueryQuestionEssay)
+		novaService.POST("/question/fill-blank/:Id", nova.HandleCreateQuestionFillBlank)
+		novaService.PUT("/question/fill-blank/:Id", nova.HandleUpdateQuestionFillBlank)
+		novaService.DELETE("/question/fill-blank/:Id", nova.HandleDeleteQuestionFillBlank)
+		novaService.PATCH("/question/fill-blank/:Id", nova.HandleModifyQuestionFillBlank)
+		novaService.GET("/question/fill-blank/:Id", nova.HandleQueryQuestionFillBlank)
+		novaService.POST("/question/matching/:Id", nova.HandleCreateQuestionMatching)
+		novaService.PUT("/question/matching/:Id", nova.HandleUpdateQuestionMatching)
+		novaService.DELETE("/question/matching/:Id", nova.HandleDeleteQuestionMatching)
+		novaService.PATCH("/question/matching/:Id", nova.HandleModifyQuestionMatching)
+		novaService.GET("/question/matching/:Id", nova.HandleQueryQuestionMatching)
+		novaService.POST("/question/ordering/:Id", nova.HandleCreateQuestionOrdering)
+		novaService.PUT("/question/ordering/:Id", nova.HandleUpdateQuestionOrdering)
+		novaService.DELETE("/question/ordering/:Id", nova.HandleDeleteQuestionOrdering)
+		novaService.PATCH("/question/ordering/:Id", nova.HandleModifyQuestionOrdering)
+		novaService.GET("/question/ordering/:Id", nova.HandleQueryQuestionOrdering)
+		novaService.POST("/question/numeric/:Id", nova.HandleCreateQuestionNumeric)
+		novaService.PUT("/question/numeric/:Id", nova.HandleUpdateQuestionNumeric)
+		novaService.DELETE("/question/numeric/:Id", nova.HandleDeleteQuestionNumeric)
+		novaService.PATCH("/question/numeric/:Id", nova.HandleModifyQuestionNumeric)
+		novaService.GET("/question/numeric/:Id", nova.HandleQueryQuestionNumeric)
+		novaService.POST("/question/template/:Id", nova.HandleCreateQuestionTemplate)
+		novaService.PUT("/question/template/:Id", nova.HandleUpdateQuestionTemplate)
+		novaService.DELETE("/question/template/:Id", nova.HandleDeleteQuestionTemplate)
+		novaService.PATCH("/question/template/:Id", nova.HandleModifyQuestionTemplate)
+		novaService.GET("/question/template/:Id", nova.HandleQueryQuestionTemplate)
+		novaService.GET("/question/template/:Id/preview", nova.HandleQueryQuestionTemplatePreview)
+		novaService.POST("/question/code/:Id", nova.HandleCreateQuestionCode)
+		novaService.PUT("/question/code/:Id", nova.HandleUpdateQuestionCode)
+		novaService.DELETE("/question/code/:Id", nova.HandleDeleteQuestionCode)
+		novaService.PATCH("/question/code/:Id", nova.HandleModifyQuestionCode)
+		novaService.GET("/question/code/:Id", nova.HandleQueryQuestionCode)
+		novaService.POST("/question/code/:Id/attempt", nova.HandleCreateQuestionCodeAttempt)
+		novaService.GET("/question/code/:Id/attempt", nova.HandleQueryQuestionCodeAttempts)
+		novaService.GET("/question/code/:Id/attempt/:attemptId", nova.HandleQueryQuestionCodeAttempt)
 	}
 	// enable tls settings
 	var tlsConfig *tls.Config
@@ -307,6 +593,8 @@ func (nova *Nova) Start() {
 }
 
 func (nova *Nova) Stop() error {
+	// stop exam attempt sweeper
+	nova.stopExamAttemptSweeper()
 	// stop redis cache (configure redis)
 	if nova.conf.Configure.Cache.CacheType == "redis" {
 		// stop redis cache
@@ -0,0 +1,354 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"hash/fnv"
+	"math"
+	mathrand "math/rand"
+	"net/http"
+	"nova/logger"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	practiceEaseDefault       = 2.5
+	practiceEaseMin           = 1.3
+	practiceQueueLimitDefault = 20
+	practiceQueueLimitMax     = 100
+	practiceNewLimitDefault   = 10
+)
+
+// practiceRatingQuality maps rating to SM-2 response quality from 0 to 5
+var practiceRatingQuality = map[string]float64{
+	PracticeRatingAgain: 1,
+	PracticeRatingHard:  3,
+	PracticeRatingGood:  4,
+	PracticeRatingEasy:  5,
+}
+
+// errPracticeCardNotFound is returned when user has never practiced question
+var errPracticeCardNotFound = errors.New("practice card not found")
+
+func (nova *Nova) HandleQueryPracticeQueue(c *gin.Context) {
+	// query questions due today followed by questions never practiced
+	logger.Infof("handle request query practice queue")
+	// extract queue limits from query
+	limit, fresh, err := queryPracticeQueueLimits(c)
+	if err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check practice queue limits are validate: %v", err)
+		return
+	}
+	// memory state is kept per authenticated user
+	logger.Debugf("check user session is validate")
+	user, ok := nova.queryPrincipal(c)
+	if !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to practice"))
+		logger.Errorf("error check user session is validate")
+		return
+	}
+	logger.Debugf("successfully check user session is validate")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	if err := nova.queryQuestionWorkflowsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying question workflows in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// query practice cards from database
+	logger.Debugf("query practice cards in database")
+	cards, err := nova.db.QueryPracticeCards(user.UserId)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query practice cards in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query practice cards in database")
+	// cards due before tomorrow come first, most overdue first
+	logger.Debugf("build practice queue")
+	now := time.Now().UTC()
+	today := now.Truncate(24 * time.Hour)
+	seed := queryPracticeSeed(user.UserId, today)
+	response := PracticeQueue{Date: today.Format(time.DateOnly), Questions: []PracticeQueueItem{}}
+	var questions []ExamQuestion
+	var due []*PracticeCard
+	practiced := make(map[string]bool, len(cards))
+	for _, card := range cards {
+		practiced[card.Type+"/"+card.Id] = true
+		if !card.DueAt.Before(today.AddDate(0, 0, 1)) || !nova.isQuestionExisted(card.Type, card.Id) ||
+			!nova.isQuestionPublished(card.Type, card.Id) {
+			continue
+		}
+		response.Due++
+		if len(due) < limit {
+			due = append(due, card)
+			questions = append(questions, ExamQuestion{Type: card.Type, Id: card.Id, Points: 1})
+		}
+	}
+	// published questions never practiced fill the rest, shuffled per user & day
+	var sources []questionFingerprintSource
+	for _, source := range nova.queryQuestionFingerprintSources(nova.queryTenantScope(c)) {
+		if !practiced[source.Type+"/"+source.Id] && nova.isQuestionPublished(source.Type, source.Id) {
+			sources = append(sources, source)
+		}
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		if sources[i].Type != sources[j].Type {
+			return sources[i].Type < sources[j].Type
+		}
+		return sources[i].Id < sources[j].Id
+	})
+	mathrand.New(mathrand.NewSource(seed)).Shuffle(len(sources), func(i, j int) { sources[i], sources[j] = sources[j], sources[i] })
+	for _, source := range sources[:min(len(sources), fresh, limit-len(questions))] {
+		questions = append(questions, ExamQuestion{Type: source.Type, Id: source.Id, Points: 1})
+		response.New++
+	}
+	shown, err := nova.queryExamAttemptQuestions(Exam{Questions: questions}, seed)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error build practice queue: %v", err)
+		return
+	}
+	for k, question := range shown {
+		item := PracticeQueueItem{Question: question}
+		if k < len(due) {
+			item.Card = due[k]
+		}
+		response.Questions = append(response.Questions, item)
+	}
+	logger.Debugf("successfully build practice queue")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, len(response.Questions))
+	return
+}
+
+func (nova *Nova) HandleCreatePracticeReview(c *gin.Context) {
+	// grade practice answer immediately & reschedule question for user
+	var request PracticeReview
+	logger.Infof("handle request create practice review")
+	// extract question type & Id from uri
+	questionType := strings.ToLower(c.Param("type"))
+	id := strings.ToLower(c.Param("Id"))
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// numeric answers are parsed in locale of learner
+	if request.Locale == "" {
+		request.Locale = c.GetHeader("Accept-Language")
+	}
+	request.Rating = strings.ToLower(strings.TrimSpace(request.Rating))
+	// request question type, Id & rating correctness
+	logger.Debugf("check question type & Id is validate")
+	if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question type & Id is validate: %v", err)
+		return
+	}
+	if _, ok := practiceRatingQuality[request.Rating]; request.Rating != "" && !ok {
+		nova.response400BadRequest(c, FieldErrors{{Field: "rating", Reason: fmt.Sprintf("rating %v not supported", request.Rating)}})
+		logger.Errorf("error check practice rating is validate: %v", request.Rating)
+		return
+	}
+	logger.Debugf("successfully check question type & Id is validate")
+	// memory state is kept per authenticated user
+	logger.Debugf("check user session is validate")
+	user, ok := nova.queryPrincipal(c)
+	if !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to practice"))
+		logger.Errorf("error check user session is validate")
+		return
+	}
+	logger.Debugf("successfully check user session is validate")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	if err := nova.queryQuestionWorkflowsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying question workflows in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// only published questions are practiced
+	logger.Debugf("check question is existed")
+	if !nova.isQuestionExisted(questionType, id) || !nova.isQuestionPublished(questionType, id) {
+		nova.response404NotFound(c, fmt.Errorf("%v question not found", questionType))
+		logger.Errorf("error check question is existed")
+		return
+	}
+	logger.Debugf("successfully check question is existed")
+	// grade answer & reveal guidance as feedback
+	logger.Debugf("grade practice answer")
+	submission := QuestionSubmission{Answer: request.Answer, Locale: request.Locale, Seed: request.Seed}
+	grade, err := nova.gradeQuestion(questionType, id, submission)
+	if err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error grade practice answer: %v", err)
+		return
+	}
+	if err := nova.applyQuestionGuidance(c, questionType, id, submission, &grade); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error apply question guidance to grade: %v", err)
+		return
+	}
+	rating, err := queryPracticeRating(grade, request.Rating)
+	if err != nil {
+		nova.response400BadRequest(c, FieldErrors{{Field: "rating", Reason: err.Error()}})
+		logger.Errorf("error grade practice answer: %v", err)
+		return
+	}
+	logger.Debugf("successfully grade practice answer")
+	// schedule next review from memory state of card
+	logger.Debugf("schedule practice card")
+	card, err := nova.db.QueryPracticeCard(user.UserId, questionType, id)
+	if errors.Is(err, errPracticeCardNotFound) {
+		card, err = &PracticeCard{UserId: user.UserId, Type: questionType, Id: id, Ease: practiceEaseDefault}, nil
+	}
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query practice card in database: %v", err)
+		return
+	}
+	response := PracticeReviewResult{Grade: grade, Rating: rating, Card: schedulePracticeCard(*card, rating, time.Now().UTC())}
+	logger.Debugf("successfully schedule practice card")
+	// store practice card & review in database
+	logger.Debugf("store practice review in database")
+	if err := nova.db.UpdatePracticeCard(&response.Card, request.Answer, grade.Correct, rating); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store practice review in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store practice review in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleQueryPracticeCard(c *gin.Context) {
+	// query memory state of question for user
+	logger.Infof("handle request query practice card")
+	// extract question type & Id from uri
+	questionType := strings.ToLower(c.Param("type"))
+	id := strings.ToLower(c.Param("Id"))
+	// request question type & Id correctness
+	logger.Debugf("check question type & Id is validate")
+	if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question type & Id is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question type & Id is validate")
+	// memory state is kept per authenticated user
+	logger.Debugf("check user session is validate")
+	user, ok := nova.queryPrincipal(c)
+	if !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to practice"))
+		logger.Errorf("error check user session is validate")
+		return
+	}
+	logger.Debugf("successfully check user session is validate")
+	// query practice card from database
+	logger.Debugf("query practice card in database")
+	response, err := nova.db.QueryPracticeCard(user.UserId, questionType, id)
+	if err != nil {
+		if errors.Is(err, errPracticeCardNotFound) {
+			nova.response404NotFound(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error query practice card in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query practice card in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func queryPracticeQueueLimits(c *gin.Context) (int, int, error) {
+	// queue holds default number of questions unless specified
+	limit, fresh := practiceQueueLimitDefault, practiceNewLimitDefault
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > practiceQueueLimitMax {
+			return 0, 0, fmt.Errorf("limit should be integer from 1 to %v", practiceQueueLimitMax)
+		}
+		limit = n
+	}
+	if v := c.Query("new"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 || n > practiceQueueLimitMax {
+			return 0, 0, fmt.Errorf("new should be integer from 0 to %v", practiceQueueLimitMax)
+		}
+		fresh = n
+	}
+	return limit, fresh, nil
+}
+
+func queryPracticeSeed(userId string, day time.Time) int64 {
+	// queue order & template values stay the same for user during day
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(userId + "/" + day.Format(time.DateOnly)))
+	return int64(h.Sum64() >> 1)
+}
+
+func queryPracticeRating(grade QuestionGrade, rating string) (string, error) {
+	// wrong answer is always forgotten, otherwise learner rates recall or it follows from grade
+	switch {
+	case grade.Graded && !grade.Correct:
+		return PracticeRatingAgain, nil
+	case rating != "":
+		return rating, nil
+	case grade.Graded:
+		return PracticeRatingGood, nil
+	default:
+		return "", errors.New("should rate recall of question not graded automatically")
+	}
+}
+
+func schedulePracticeCard(card PracticeCard, rating string, now time.Time) PracticeCard {
+	// SM-2 scheduling, forgotten question is repeated the same day & ease is kept
+	quality := practiceRatingQuality[rating]
+	card.Reviews++
+	card.ReviewedAt = &now
+	if quality < 3 {
+		card.Repetitions, card.Interval = 0, 0
+		card.Lapses++
+		card.DueAt = now
+		return card
+	}
+	card.Ease = roundToDecimals(math.Max(practiceEaseMin, card.Ease+0.1-(5-quality)*(0.08+(5-quality)*0.02)), 2)
+	card.Repetitions++
+	switch card.Repetitions {
+	case 1:
+		card.Interval = 1
+	case 2:
+		card.Interval = 6
+	default:
+		card.Interval = int(math.Round(float64(card.Interval) * card.Ease))
+	}
+	card.DueAt = now.AddDate(0, 0, card.Interval)
+	return card
+}
@@ -0,0 +1,170 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"nova/utils"
+	"testing"
+	"time"
+)
+
+func setupPracticeTestRouter() *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// first administrator is granted by command line
+	setupTestAdministrator(nova)
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	{
+		/* user management */
+		novaService.POST("/user/:userId", nova.HandleCreateUser)
+		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
+		novaService.PUT("/user/role/:userId", nova.HandleUpdateUserRole)
+		/* practice management */
+		novaService.GET("/practice/due", nova.HandleQueryPracticeQueue)
+		novaService.POST("/practice/review/:type/:Id", nova.HandleCreatePracticeReview)
+		novaService.GET("/practice/card/:type/:Id", nova.HandleQueryPracticeCard)
+		/* question management */
+		// question workflow related
+		novaService.POST("/question/workflow/:type/:Id/transition", nova.HandleCreateQuestionTransition)
+		novaService.POST("/question/workflow/:type/:Id/approve", nova.HandleCreateQuestionApproval)
+		// question related
+		novaService.POST("/question/judgement/:Id", nova.HandleCreateQuestionJudgement)
+	}
+	return router
+}
+
+func startPracticeTestService() (*httptest.Server, *gin.Engine) {
+	router := setupPracticeTestRouter()
+	return httptest.NewServer(router), router
+}
+
+func TestSchedulePracticeCard(t *testing.T) {
+	// intervals grow by ease after first two repetitions, forgetting starts over
+	now := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	card := PracticeCard{Ease: practiceEaseDefault}
+	for _, interval := range []int{1, 6, 15} {
+		card = schedulePracticeCard(card, PracticeRatingGood, now)
+		assert.Equal(t, interval, card.Interval)
+		assert.Equal(t, now.AddDate(0, 0, interval), card.DueAt)
+	}
+	assert.Equal(t, practiceEaseDefault, card.Ease)
+	card = schedulePracticeCard(card, PracticeRatingAgain, now)
+	assert.Equal(t, 0, card.Interval)
+	assert.Equal(t, 0, card.Repetitions)
+	assert.Equal(t, 1, card.Lapses)
+	assert.Equal(t, now, card.DueAt)
+	card = schedulePracticeCard(card, PracticeRatingHard, now)
+	assert.Equal(t, 1, card.Interval)
+	assert.Equal(t, 2.36, card.Ease)
+	for k := 0; k < 10; k++ {
+		card = schedulePracticeCard(card, PracticeRatingHard, now)
+	}
+	assert.Equal(t, practiceEaseMin, card.Ease)
+	assert.Equal(t, 15, card.Reviews)
+}
+
+func TestNova_HandleCreatePracticeReview(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreatePracticeReview
+	// Test Purpose: Test practice reviews are graded immediately & rescheduled per user
+	// Test Steps:
+	// 1. send QueryPracticeQueue request without user session, receive 401 Unauthorized Code
+	// 2. send QueryPracticeQueue request, receive published questions as new by using 200 OK Code
+	// 3. send CreatePracticeReview requests with wrong & correct answers, receive grade & schedule
+	// 4. send QueryPracticeQueue request, receive forgotten question due today
+	// 5. send QueryPracticeCard request, receive memory state of question by using 200 OK Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startPracticeTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	_, examineeSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	first := QuestionJudgement{Id: uuid.New().String(), Title: "ARP resolves IP to MAC " + utils.RandomAlphabet(12), StandardAnswer: true}
+	createExamTestQuestion(t, server, router, QuestionTypeJudgement, first.Id, first, admin.Token, true)
+	second := QuestionJudgement{Id: uuid.New().String(), Title: "NAT hides private addresses " + utils.RandomAlphabet(12), StandardAnswer: true}
+	createExamTestQuestion(t, server, router, QuestionTypeJudgement, second.Id, second, admin.Token, true)
+	draft := QuestionJudgement{Id: uuid.New().String(), Title: "VLANs split broadcast domains " + utils.RandomAlphabet(12), StandardAnswer: true}
+	createExamTestQuestion(t, server, router, QuestionTypeJudgement, draft.Id, draft, admin.Token, false)
+	/* practice requires user session */
+	url := server.URL + "/nova/v1/practice/due"
+	w := serveTestRequest(t, router, http.MethodGet, url, nil, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, url+"?limit=0", nil, examineeSession.Token)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	/* published questions are new */
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, examineeSession.Token)
+	var queue PracticeQueue
+	unmarshalTestResponse(t, w, &queue)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, time.Now().UTC().Format(time.DateOnly), queue.Date)
+	assert.Equal(t, 0, queue.Due)
+	assert.Equal(t, 2, queue.New)
+	ids := []string{}
+	for _, v := range queue.Questions {
+		ids = append(ids, v.Question.Id)
+		assert.Nil(t, v.Card)
+		assert.NotEmpty(t, v.Question.Title)
+	}
+	assert.ElementsMatch(t, []string{first.Id, second.Id}, ids)
+	/* wrong answer is repeated today, correct answer tomorrow */
+	review := server.URL + "/nova/v1/practice/review/judgement/"
+	w = serveTestRequest(t, router, http.MethodPost, review+first.Id, PracticeReview{Answer: []byte(`true`), Rating: "perfect"}, examineeSession.Token)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, review+draft.Id, PracticeReview{Answer: []byte(`true`)}, examineeSession.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, review+first.Id, PracticeReview{Answer: []byte(`false`), Rating: PracticeRatingEasy}, examineeSession.Token)
+	var result PracticeReviewResult
+	unmarshalTestResponse(t, w, &result)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, result.Grade.Graded)
+	assert.False(t, result.Grade.Correct)
+	assert.Equal(t, PracticeRatingAgain, result.Rating)
+	assert.Equal(t, 0, result.Card.Interval)
+	assert.Equal(t, 1, result.Card.Lapses)
+	w = serveTestRequest(t, router, http.MethodPost, review+second.Id, PracticeReview{Answer: []byte(`true`)}, examineeSession.Token)
+	result = PracticeReviewResult{}
+	unmarshalTestResponse(t, w, &result)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, result.Grade.Correct)
+	assert.Equal(t, PracticeRatingGood, result.Rating)
+	assert.Equal(t, 1, result.Card.Interval)
+	assert.Equal(t, practiceEaseDefault, result.Card.Ease)
+	assert.True(t, result.Card.DueAt.After(time.Now().Add(23*time.Hour)))
+	/* forgotten question is due today */
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, examineeSession.Token)
+	queue = PracticeQueue{}
+	unmarshalTestResponse(t, w, &queue)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1, queue.Due)
+	assert.Equal(t, 0, queue.New)
+	if assert.Len(t, queue.Questions, 1) && assert.NotNil(t, queue.Questions[0].Card) {
+		assert.Equal(t, first.Id, queue.Questions[0].Question.Id)
+		assert.Equal(t, 1, queue.Questions[0].Card.Reviews)
+	}
+	w = serveTestRequest(t, router, http.MethodPost, review+first.Id, PracticeReview{Answer: []byte(`true`), Rating: PracticeRatingEasy}, examineeSession.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	/* memory state of question */
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/practice/card/judgement/"+first.Id, nil, examineeSession.Token)
+	var card PracticeCard
+	unmarshalTestResponse(t, w, &card)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 2, card.Reviews)
+	assert.Equal(t, 1, card.Lapses)
+	assert.Equal(t, 1, card.Repetitions)
+	assert.Equal(t, 2.6, card.Ease)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/practice/card/judgement/"+first.Id, nil, admin.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,437 @@
+package app
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"io"
+	"net/http"
+	"nova/logger"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	userImportRowsMax         = 1000
+	userPasswordMaxLength     = 72
+	userInvitationTokenBytes  = 32
+	userInvitationExpiry      = 7 * 24 * time.Hour
+	userInvitationRoutePrefix = "/nova/v1/user/invitation/"
+)
+
+// errUserInvitationNotFound is returned when invitation is not stored, already used or expired
+var errUserInvitationNotFound = errors.New("user invitation not found")
+
+// userImportColumns are csv header names of import rows, same as json names
+var userImportColumns = []string{"userId", "username", "password", "phone_number", "email", "address", "company"}
+
+func (nova *Nova) HandleCreateUserImport(c *gin.Context) {
+	// create users in bulk from csv or json rows
+	logger.Infof("handle request create user import")
+	// extract import format from query
+	format := strings.ToLower(c.DefaultQuery("format", UserImportFormatJSON))
+	if format != UserImportFormatJSON && format != UserImportFormatCSV {
+		nova.response400BadRequest(c, fmt.Errorf("format %v not supported", format))
+		logger.Errorf("error check user import format is validate: %v", format)
+		return
+	}
+	// only administrators provision users, administrators of tenant provision users of tenant
+	logger.Debugf("check principal is allowed to import users")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to import users"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role != RoleAdmin {
+		nova.response403Forbidden(c, errors.New("only administrator imports users"))
+		logger.Errorf("error check principal is administrator")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to import users")
+	// request body should be csv or json rows
+	logger.Debugf("read user import rows")
+	rows, err := readUserImportRows(c.Request.Body, format)
+	if err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error read user import rows: %v", err)
+		return
+	}
+	logger.Debugf("successfully read user import rows")
+	// create users row by row
+	logger.Debugf("import users in database & data cache")
+	response, err := nova.importUsers(rows, nova.queryTenantOwner(c))
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error import users in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully import users in database & data cache")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v created, %v invited, %v failed", http.StatusOK, response.Created, response.Invited, response.Failed)
+	return
+}
+
+func (nova *Nova) HandleCreateUserInvitationAcceptance(c *gin.Context) {
+	// accept invitation of imported user by choosing password
+	var request UserInvitationAcceptance
+	logger.Infof("handle request create user invitation acceptance")
+	// extract invitation token from uri
+	token := c.Param("token")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check password correctness
+	logger.Debugf("check password is validate")
+	if len(request.Password) > userPasswordMaxLength {
+		nova.response400BadRequest(c, fmt.Errorf("password should be at most %v bytes", userPasswordMaxLength))
+		logger.Errorf("error check password is validate")
+		return
+	}
+	logger.Debugf("successfully check password is validate")
+	// query invitation from database
+	logger.Debugf("query user invitation in database")
+	tokenHash := hashUserSessionToken(token)
+	invitation, err := nova.db.QueryUserInvitation(tokenHash)
+	if err != nil {
+		if errors.Is(err, errUserInvitationNotFound) {
+			nova.response404NotFound(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error query user invitation in database: %v", err)
+		return
+	}
+	if !time.Now().Before(invitation.ExpiresAt) {
+		_, _ = nova.db.DeleteUserInvitation(tokenHash)
+		nova.response404NotFound(c, errUserInvitationNotFound)
+		logger.Errorf("error check user invitation is expired")
+		return
+	}
+	logger.Debugf("successfully query user invitation in database")
+	// update data cache by querying users in database
+	logger.Debugf("update data cache by querying users in database")
+	if err := nova.queryUsersInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying users in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying users in database")
+	// invitation is used once, concurrent acceptance loses
+	logger.Debugf("delete user invitation in database")
+	if ok, err := nova.db.DeleteUserInvitation(tokenHash); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error delete user invitation in database: %v", err)
+		return
+	} else if !ok {
+		nova.response404NotFound(c, errUserInvitationNotFound)
+		logger.Errorf("error delete user invitation in database: already used")
+		return
+	}
+	logger.Debugf("successfully delete user invitation in database")
+	// store hashed password of user in data cache & database
+	logger.Debugf("store user password in data cache & database")
+	password, err := hashUserPassword(request.Password)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error hash user password: %v", err)
+		return
+	}
+	if _, err := nova.modifyUserInDataCache(User{UserId: invitation.UserId, Password: password}); err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error store user password in data cache: %v", err)
+		return
+	}
+	if err := nova.modifyUserInDatabase(invitation.UserId); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store user password in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store user password in data cache & database")
+	// return response
+	nova.response204NoContent(c, nil)
+	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, invitation.UserId)
+	return
+}
+
+// ImportUsers provisions users from csv or json file given by command line arguments,
+// report is written to stdout and exit code is non-zero when any row failed
+func (nova *Nova) ImportUsers(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("import-users", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	file := flags.String("file", "", "csv or json file of users, - reads stdin")
+	format := flags.String("format", "", "csv or json, guessed from file extension when empty")
+	tenantId := flags.String("tenant", "", "tenant imported users belong to")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *file == "" {
+		_, _ = fmt.Fprintln(stderr, "import-users: -file is required")
+		flags.Usage()
+		return 2
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*file)), ".")
+	}
+	if *format != UserImportFormatJSON && *format != UserImportFormatCSV {
+		_, _ = fmt.Fprintf(stderr, "import-users: format %v not supported\n", *format)
+		return 2
+	}
+	// users are imported into existing tenant only
+	*tenantId = strings.ToLower(*tenantId)
+	if *tenantId != "" {
+		if _, err := nova.db.QueryTenant(*tenantId); err != nil {
+			_, _ = fmt.Fprintf(stderr, "import-users: %v\n", err)
+			return 1
+		}
+	}
+	// read rows from file or stdin
+	var reader io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "import-users: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		reader = f
+	}
+	rows, err := readUserImportRows(reader, *format)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "import-users: %v\n", err)
+		return 1
+	}
+	// import users & print report
+	report, err := nova.importUsers(rows, *tenantId)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "import-users: %v\n", err)
+		return 1
+	}
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		_, _ = fmt.Fprintf(stderr, "import-users: %v\n", err)
+		return 1
+	}
+	if report.Failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+func readUserImportRows(reader io.Reader, format string) ([]UserImportRow, error) {
+	// json body is array of rows, csv body has header naming columns
+	var rows []UserImportRow
+	if format == UserImportFormatJSON {
+		if err := json.NewDecoder(reader).Decode(&rows); err != nil {
+			return nil, err
+		}
+	} else {
+		records, err := csv.NewReader(reader).ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		if len(records) == 0 {
+			return nil, errors.New("csv header is required")
+		}
+		columns := make(map[string]int, len(records[0]))
+		for k, v := range records[0] {
+			name := strings.TrimSpace(strings.TrimPrefix(v, "\ufeff"))
+			if !isUserImportColumn(name) {
+				return nil, fmt.Errorf("csv column %v not supported", name)
+			}
+			columns[name] = k
+		}
+		for _, name := range []string{"username", "phone_number"} {
+			if _, ok := columns[name]; !ok {
+				return nil, fmt.Errorf("csv column %v is required", name)
+			}
+		}
+		for _, record := range records[1:] {
+			field := func(name string) string {
+				if k, ok := columns[name]; ok && k < len(record) {
+					return strings.TrimSpace(record[k])
+				}
+				return ""
+			}
+			rows = append(rows, UserImportRow{
+				UserId:      field("userId"),
+				Username:    field("username"),
+				Password:    field("password"),
+				PhoneNumber: field("phone_number"),
+				Email:       field("email"),
+				Address:     field("address"),
+				Company:     field("company"),
+			})
+		}
+	}
+	if len(rows) == 0 || len(rows) > userImportRowsMax {
+		return nil, fmt.Errorf("should have 1 to %v users, got %v", userImportRowsMax, len(rows))
+	}
+	return rows, nil
+}
+
+func isUserImportColumn(name string) bool {
+	for _, v := range userImportColumns {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (nova *Nova) importUsers(rows []UserImportRow, tenantId string) (UserImportReport, error) {
+	// uniqueness is checked against stored users & earlier rows
+	if err := nova.queryUsersInDatabase(); err != nil {
+		return UserImportReport{}, err
+	}
+	report := UserImportReport{Results: make([]UserImportResult, 0, len(rows))}
+	userIds := make(map[string]int, len(rows))
+	usernames := make(map[string]int, len(rows))
+	phoneNumbers := make(map[string]int, len(rows))
+	for k, row := range rows {
+		result := UserImportResult{Row: k + 1, Username: strings.TrimSpace(row.Username)}
+		user, reason := nova.checkUserImportRow(row, userIds, usernames, phoneNumbers)
+		if reason == "" {
+			result.UserId = user.UserId
+			userIds[user.UserId], usernames[user.Username], phoneNumbers[user.PhoneNumber] = k+1, k+1, k+1
+			reason = nova.createImportedUser(&user, &result, tenantId)
+		}
+		if reason != "" {
+			result.Status, result.Reason = UserImportStatusFailed, reason
+			report.Failed++
+		} else if result.Status == UserImportStatusInvited {
+			report.Invited++
+		} else {
+			report.Created++
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report, nil
+}
+
+func (nova *Nova) checkUserImportRow(row UserImportRow, userIds map[string]int, usernames map[string]int, phoneNumbers map[string]int) (User, string) {
+	// generated userId unless row has one
+	user := User{
+		UserId:      strings.ToLower(strings.TrimSpace(row.UserId)),
+		Username:    strings.TrimSpace(row.Username),
+		Password:    row.Password,
+		PhoneNumber: strings.TrimSpace(row.PhoneNumber),
+		Email:       strings.TrimSpace(row.Email),
+		Address:     strings.TrimSpace(row.Address),
+		Company:     strings.TrimSpace(row.Company),
+	}
+	if user.UserId == "" {
+		user.UserId = uuid.New().String()
+	}
+	switch {
+	case user.Username == "":
+		return user, "username is required"
+	case user.PhoneNumber == "":
+		return user, "phone_number is required"
+	case len(user.Password) > userPasswordMaxLength:
+		return user, fmt.Sprintf("password should be at most %v bytes", userPasswordMaxLength)
+	}
+	if b, _ := nova.isUserIdValidate(user.UserId); !b {
+		return user, "userId format incorrect"
+	}
+	if row, ok := userIds[user.UserId]; ok {
+		return user, fmt.Sprintf("userId repeats row %v", row)
+	}
+	if row, ok := usernames[user.Username]; ok {
+		return user, fmt.Sprintf("username repeats row %v", row)
+	}
+	if row, ok := phoneNumbers[user.PhoneNumber]; ok {
+		return user, fmt.Sprintf("phone_number repeats row %v", row)
+	}
+	if nova.isUserExisted(user.UserId) {
+		return user, "user already exists"
+	}
+	if nova.isUserNameOrPhoneExisted(user) {
+		return user, "userName or phoneNumber already exists"
+	}
+	return user, ""
+}
+
+func (nova *Nova) createImportedUser(user *User, result *UserImportResult, tenantId string) string {
+	// users without password are invited to choose one, invited users cannot login before
+	result.Status = UserImportStatusCreated
+	password := user.Password
+	var token string
+	if password == "" {
+		var err error
+		if token, err = randomUserToken(); err != nil {
+			return err.Error()
+		}
+		if password, err = randomUserToken(); err != nil {
+			return err.Error()
+		}
+		result.Status = UserImportStatusInvited
+	}
+	hash, err := hashUserPassword(password)
+	if err != nil {
+		return err.Error()
+	}
+	user.Password = hash
+	// store user tenant, user & invitation in database & data cache
+	if err := nova.updateResourceTenant(TenantResourceUser, user.UserId, tenantId); err != nil {
+		return err.Error()
+	}
+	nova.createUserInDataCache(*user)
+	if err := nova.createUserInDatabase(user.UserId); err != nil {
+		nova.deleteUserInDataCache(user.UserId)
+		_ = nova.deleteResourceTenant(TenantResourceUser, user.UserId)
+		return err.Error()
+	}
+	if token != "" {
+		now := time.Now().UTC()
+		invitation := UserInvitation{UserId: user.UserId, CreatedAt: now, ExpiresAt: now.Add(nova.queryUserInvitationExpiry())}
+		if err := nova.db.CreateUserInvitation(hashUserSessionToken(token), &invitation); err != nil {
+			return err.Error()
+		}
+		result.InvitationLink = userInvitationRoutePrefix + token
+	}
+	logger.Debugf("import user %v: %v", user.UserId, result.Status)
+	return ""
+}
+
+func (nova *Nova) queryUserInvitationExpiry() time.Duration {
+	if expiry := nova.conf.Configure.User.InvitationExpiry; expiry > 0 {
+		return time.Duration(expiry) * time.Second
+	}
+	return userInvitationExpiry
+}
+
+func randomUserToken() (string, error) {
+	b := make([]byte, userInvitationTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashUserPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func isUserPasswordMatched(stored string, password string) bool {
+	// every stored password is bcrypt hash
+	return bcrypt.CompareHashAndPassword([]byte(stored), []byte(password)) == nil
+}
@@ -0,0 +1,248 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"nova/utils"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setupProvisionTestRouter() *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// first administrator is granted by command line
+	setupTestAdministrator(nova)
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	{
+		/* user management */
+		novaService.POST("/user/:userId", nova.HandleCreateUser)
+		novaService.PUT("/user/:userId", nova.HandleUpdateUser)
+		novaService.PATCH("/user/:userId", nova.HandleModifyUser)
+		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
+		novaService.PUT("/user/role/:userId", nova.HandleUpdateUserRole)
+		novaService.POST("/user/import", nova.HandleCreateUserImport)
+		novaService.POST("/user/invitation/:token", nova.HandleCreateUserInvitationAcceptance)
+	}
+	return router
+}
+
+func startProvisionTestService() (*httptest.Server, *gin.Engine) {
+	router := setupProvisionTestRouter()
+	return httptest.NewServer(router), router
+}
+
+func serveUserImportTestRequest(t *testing.T, router *gin.Engine, url string, body string, token string) *httptest.ResponseRecorder {
+	// send raw csv or json rows
+	w := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		t.Errorf("error creating request: %v", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(w, request)
+	return w
+}
+
+func TestNova_HandleCreateUserImport(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateUserImport
+	// Test Purpose: Test users are imported in bulk with hashed passwords or invitations
+	// Test Steps:
+	// 1. send CreateUserImport request with examinee session, receive 403 Forbidden Code
+	// 2. send CreateUserImport request with csv rows, receive per-row report by using 200 OK Code
+	// 3. send CreateUserLogin request of imported user, login with hashed password
+	// 4. send CreateUserInvitationAcceptance request, invited user chooses password once
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startProvisionTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	existing, examinee := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	url := server.URL + "/nova/v1/user/import"
+	/* only administrators import users */
+	rows := `[{"username": "` + utils.RandomAlphabet(8) + `", "phone_number": "` + utils.RandomNumber(11) + `"}]`
+	w := serveUserImportTestRequest(t, router, url, rows, examinee.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveUserImportTestRequest(t, router, url+"?format=xml", rows, admin.Token)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serveUserImportTestRequest(t, router, url+"?format=csv", "username,phone_number,role\n", admin.Token)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	/* every row is reported */
+	created := User{Username: utils.RandomAlphabet(8), Password: utils.RandomAlphabetAndNumber(8), PhoneNumber: utils.RandomNumber(11)}
+	invited := User{UserId: uuid.New().String(), Username: utils.RandomAlphabet(8), PhoneNumber: utils.RandomNumber(11)}
+	csv := "username,password,phone_number,userId,email\n" +
+		created.Username + "," + created.Password + "," + created.PhoneNumber + ",,first@example.com\n" +
+		invited.Username + ",," + invited.PhoneNumber + "," + invited.UserId + ",\n" +
+		existing.Username + ",," + utils.RandomNumber(11) + ",,\n" +
+		utils.RandomAlphabet(8) + ",," + created.PhoneNumber + ",,\n" +
+		utils.RandomAlphabet(8) + ",," + utils.RandomNumber(11) + ",not-a-uuid,\n" +
+		",," + utils.RandomNumber(11) + ",,\n"
+	w = serveUserImportTestRequest(t, router, url+"?format=csv", csv, admin.Token)
+	var report UserImportReport
+	unmarshalTestResponse(t, w, &report)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Invited)
+	assert.Equal(t, 4, report.Failed)
+	if assert.Len(t, report.Results, 6) {
+		assert.Equal(t, UserImportStatusCreated, report.Results[0].Status)
+		assert.NoError(t, uuid.Validate(report.Results[0].UserId))
+		assert.Equal(t, UserImportStatusInvited, report.Results[1].Status)
+		assert.Equal(t, invited.UserId, report.Results[1].UserId)
+		assert.True(t, strings.HasPrefix(report.Results[1].InvitationLink, "/nova/v1/user/invitation/"))
+		assert.Equal(t, UserImportResult{Row: 3, Username: existing.Username, Status: UserImportStatusFailed, Reason: "userName or phoneNumber already exists"}, report.Results[2])
+		assert.Equal(t, "phone_number repeats row 1", report.Results[3].Reason)
+		assert.Equal(t, "userId format incorrect", report.Results[4].Reason)
+		assert.Equal(t, "username is required", report.Results[5].Reason)
+	}
+	/* imported password is hashed but still logs in */
+	created.UserId = report.Results[0].UserId
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/login/"+created.UserId, UserLogin{UserId: created.UserId, Username: created.Username, Password: created.Password}, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/login/"+created.UserId, UserLogin{UserId: created.UserId, Username: created.Username, Password: "wrong"}, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	/* invited user chooses password once */
+	link := server.URL + report.Results[1].InvitationLink
+	token := strings.TrimPrefix(report.Results[1].InvitationLink, "/nova/v1/user/invitation/")
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/login/"+invited.UserId, UserLogin{UserId: invited.UserId, Username: invited.Username, Password: token}, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, link, UserInvitationAcceptance{Password: "chosen-password"}, "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, link, UserInvitationAcceptance{Password: "another-password"}, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/login/"+invited.UserId, UserLogin{UserId: invited.UserId, Username: invited.Username, Password: "chosen-password"}, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestNova_ImportUsers(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_ImportUsers
+	// Test Purpose: Test import-users command provisions users from json file
+	// Test Steps:
+	// 1. run ImportUsers without file, receive usage exit code
+	// 2. run ImportUsers with json file, receive report & failure exit code for repeated rows
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// initialize Nova instance
+	nova := New()
+	nova.Init()
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 2, nova.ImportUsers(nil, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "-file is required")
+	/* rows are imported from json file */
+	username := utils.RandomAlphabet(8)
+	rows := []UserImportRow{
+		{Username: username, Password: utils.RandomAlphabetAndNumber(8), PhoneNumber: utils.RandomNumber(11)},
+		{Username: username, PhoneNumber: utils.RandomNumber(11)},
+	}
+	b, err := json.Marshal(rows)
+	assert.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "users.json")
+	assert.NoError(t, os.WriteFile(file, b, 0644))
+	stdout.Reset()
+	assert.Equal(t, 1, nova.ImportUsers([]string{"-file", file}, &stdout, &stderr))
+	var report UserImportReport
+	assert.NoError(t, json.Unmarshal(stdout.Bytes(), &report))
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Failed)
+	if assert.Len(t, report.Results, 2) {
+		assert.True(t, nova.isUserExisted(report.Results[0].UserId))
+		assert.Equal(t, "username repeats row 1", report.Results[1].Reason)
+	}
+}
+
+func TestNova_GrantAdministrator(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_GrantAdministrator
+	// Test Purpose: Test grant-admin command grants administrator role to existing user
+	// Test Steps:
+	// 1. run GrantAdministrator without user or with unknown user, receive failure exit code
+	// 2. run GrantAdministrator with created user, user role is administrator
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startProvisionTestService()
+	defer server.Close()
+	nova := New()
+	nova.Init()
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 2, nova.GrantAdministrator(nil, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "-user is required")
+	assert.Equal(t, 1, nova.GrantAdministrator([]string{"-user", uuid.New().String()}, &stdout, &stderr))
+	/* user created by http request is granted */
+	user, _ := createWorkflowTestUser(t, server, router, RoleExaminee, "")
+	assert.Equal(t, 0, nova.GrantAdministrator([]string{"-user", strings.ToUpper(user.UserId)}, &stdout, &stderr))
+	assert.Equal(t, RoleAdmin, nova.queryUserRole(user.UserId))
+	examinee, _ := createWorkflowTestUser(t, server, router, RoleExaminee, "")
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/login/"+user.UserId, UserLogin{UserId: user.UserId, Username: user.Username, Password: user.Password}, "")
+	var admin UserSession
+	unmarshalTestResponse(t, w, &admin)
+	w = serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/user/role/"+examinee.UserId, UserRole{Role: RoleAuthor}, admin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestNova_HashUserPasswords(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HashUserPasswords
+	// Test Purpose: Test passwords are stored hashed on every write & plaintext rows are migrated
+	// Test Steps:
+	// 1. send CreateUser, UpdateUser & ModifyUser request, stored password is bcrypt hash
+	// 2. send CreateUserLogin request with latest password, receive 200 OK Code
+	// 3. store plaintext password in database & create tables again, password is hashed
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startProvisionTestService()
+	defer server.Close()
+	user, session := createWorkflowTestUser(t, server, router, RoleExaminee, "")
+	nova := New()
+	nova.Init()
+	stored := func() string {
+		v, err := nova.db.QueryUser(user.UserId)
+		if !assert.NoError(t, err) {
+			return ""
+		}
+		return v.Password
+	}
+	assert.True(t, isUserPasswordMatched(stored(), user.Password))
+	/* updated & modified passwords are hashed */
+	url := server.URL + "/nova/v1/user/" + user.UserId
+	user.Password = utils.RandomAlphabetAndNumber(8)
+	w := serveTestRequest(t, router, http.MethodPut, url, user, session.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, isUserPasswordMatched(stored(), user.Password))
+	user.Password = utils.RandomAlphabetAndNumber(8)
+	w = serveTestRequest(t, router, http.MethodPatch, url, user, session.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, isUserPasswordMatched(stored(), user.Password))
+	assert.NotEqual(t, user.Password, stored())
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/login/"+user.UserId, UserLogin{UserId: user.UserId, Username: user.Username, Password: user.Password}, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	/* plaintext password is migrated */
+	user.Password = utils.RandomAlphabetAndNumber(8)
+	assert.NoError(t, nova.db.UpdateUser(&user))
+	assert.False(t, isUserPasswordMatched(stored(), user.Password))
+	assert.NoError(t, nova.db.CreateTables())
+	assert.True(t, isUserPasswordMatched(stored(), user.Password))
+}
@@ -27,6 +27,12 @@ func (nova *Nova) HandleCreateQuestionSingleChoice(c *gin.Context) {
 	// create single-choice question
 	var request QuestionSingleChoice
 	logger.Infof("handle request create single-choice question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change single-choice question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change single-choice question")
 	// request body should bind json
 	logger.Debugf("request body bind json format")
 	if err := c.ShouldBindJSON(&request); err != nil {
@@ -61,6 +67,26 @@ func (nova *Nova) HandleCreateQuestionSingleChoice(c *gin.Context) {
 		return
 	}
 	logger.Debugf("successfully check single-choice question is existed")
+	// check single-choice question duplication
+	logger.Debugf("check single-choice question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeSingleChoice, strings.ToLower(request.Id), singleChoiceFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check single-choice question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check single-choice question is duplicated")
+	// store single-choice question tenant in database & data cache
+	logger.Debugf("store single-choice question tenant in database & data cache")
+	if err = nova.createResourceTenant(c, QuestionTypeSingleChoice, strings.ToLower(request.Id)); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store single-choice question tenant in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store single-choice question tenant in database & data cache")
 	// store created single-choice question in data cache
 	logger.Debugf("store single-choice question in data cache")
 	response := QuestionSingleChoice{
@@ -79,6 +105,22 @@ func (nova *Nova) HandleCreateQuestionSingleChoice(c *gin.Context) {
 		return
 	}
 	logger.Debugf("successfully store single-choice question in database")
+	// store single-choice question revision in database
+	logger.Debugf("store single-choice question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeSingleChoice, response.Id, nil, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store single-choice question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store single-choice question revision in database")
+	// store single-choice question workflow as draft in database
+	logger.Debugf("store single-choice question workflow in database")
+	if _, err = nova.createQuestionWorkflow(c, QuestionTypeSingleChoice, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store single-choice question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store single-choice question workflow in database")
 	// return response
 	nova.response201Created(c, response)
 	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response)
@@ -89,6 +131,12 @@ func (nova *Nova) HandleCreateQuestionMultipleChoice(c *gin.Context) {
 	// create multiple-choice question
 	var request QuestionMultipleChoice
 	logger.Infof("handle request create multiple-choice question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change multiple-choice question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change multiple-choice question")
 	// request body should bind json
 	logger.Debugf("request body bind json format")
 	if err := c.BindJSON(&request); err != nil {
@@ -123,6 +171,26 @@ func (nova *Nova) HandleCreateQuestionMultipleChoice(c *gin.Context) {
 		return
 	}
 	logger.Debugf("successfully check multiple-choice question is existed")
+	// check multiple-choice question duplication
+	logger.Debugf("check multiple-choice question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeMultipleChoice, strings.ToLower(request.Id), multipleChoiceFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check multiple-choice question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check multiple-choice question is duplicated")
+	// store multiple-choice question tenant in database & data cache
+	logger.Debugf("store multiple-choice question tenant in database & data cache")
+	if err = nova.createResourceTenant(c, QuestionTypeMultipleChoice, strings.ToLower(request.Id)); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store multiple-choice question tenant in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store multiple-choice question tenant in database & data cache")
 	// store created multiple-choice question in data cache
 	logger.Debugf("store multiple-choice question in data cache")
 	response := QuestionMultipleChoice{
@@ -141,6 +209,22 @@ func (nova *Nova) HandleCreateQuestionMultipleChoice(c *gin.Context) {
 		return
 	}
 	logger.Debugf("successfully store multiple-choice question in database")
+	// store multiple-choice question revision in database
+	logger.Debugf("store multiple-choice question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeMultipleChoice, response.Id, nil, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store multiple-choice question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store multiple-choice question revision in database")
+	// store multiple-choice question workflow as draft in database
+	logger.Debugf("store multiple-choice question workflow in database")
+	if _, err = nova.createQuestionWorkflow(c, QuestionTypeMultipleChoice, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store multiple-choice question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store multiple-choice question workflow in database")
 	// return response
 	nova.response201Created(c, response)
 	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response)
@@ -151,6 +235,12 @@ func (nova *Nova) HandleCreateQuestionJudgement(c *gin.Context) {
 	// create judgement question
 	var request QuestionJudgement
 	logger.Infof("handle request create judgement question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change judgement question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change judgement question")
 	// request body should bind json
 	logger.Debugf("request body bind json format")
 	if err := c.BindJSON(&request); err != nil {
@@ -186,6 +276,26 @@ func (nova *Nova) HandleCreateQuestionJudgement(c *gin.Context) {
 		return
 	}
 	logger.Debugf("successfully check judgement question is existed")
+	// check judgement question duplication
+	logger.Debugf("check judgement question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeJudgement, strings.ToLower(request.Id), judgementFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check judgement question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check judgement question is duplicated")
+	// store judgement question tenant in database & data cache
+	logger.Debugf("store judgement question tenant in database & data cache")
+	if err = nova.createResourceTenant(c, QuestionTypeJudgement, strings.ToLower(request.Id)); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store judgement question tenant in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store judgement question tenant in database & data cache")
 	// store created judgement question in data cache
 	logger.Debugf("store judgement question in data cache")
 	response := QuestionJudgement{
@@ -204,6 +314,22 @@ func (nova *Nova) HandleCreateQuestionJudgement(c *gin.Context) {
 		return
 	}
 	logger.Debugf("successfully store judgement question in database")
+	// store judgement question revision in database
+	logger.Debugf("store judgement question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeJudgement, response.Id, nil, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store judgement question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store judgement question revision in database")
+	// store judgement question workflow as draft in database
+	logger.Debugf("store judgement question workflow in database")
+	if _, err = nova.createQuestionWorkflow(c, QuestionTypeJudgement, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store judgement question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store judgement question workflow in database")
 	// return response
 	nova.response201Created(c, response)
 	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response)
@@ -214,6 +340,12 @@ func (nova *Nova) HandleCreateQuestionEssay(c *gin.Context) {
 	// create essay question
 	var request QuestionEssay
 	logger.Infof("handle request create essay question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change essay question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change essay question")
 	// request body should bind json
 	logger.Debugf("request body bind json format")
 	if err := c.BindJSON(&request); err != nil {
@@ -248,6 +380,26 @@ func (nova *Nova) HandleCreateQuestionEssay(c *gin.Context) {
 		return
 	}
 	logger.Debugf("successfully check essay question is existed")
+	// check essay question duplication
+	logger.Debugf("check essay question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeEssay, strings.ToLower(request.Id), essayFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check essay question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check essay question is duplicated")
+	// store essay question tenant in database & data cache
+	logger.Debugf("store essay question tenant in database & data cache")
+	if err = nova.createResourceTenant(c, QuestionTypeEssay, strings.ToLower(request.Id)); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store essay question tenant in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store essay question tenant in database & data cache")
 	// store created essay question in data cache
 	logger.Debugf("store judgement question in data cache")
 	response := QuestionEssay{
@@ -266,513 +418,744 @@ func (nova *Nova) HandleCreateQuestionEssay(c *gin.Context) {
 		return
 	}
 	logger.Debugf("successfully store essay question in database")
+	// store essay question revision in database
+	logger.Debugf("store essay question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeEssay, response.Id, nil, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store essay question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store essay question revision in database")
+	// store essay question workflow as draft in database
+	logger.Debugf("store essay question workflow in database")
+	if _, err = nova.createQuestionWorkflow(c, QuestionTypeEssay, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store essay question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store essay question workflow in database")
 	// return response
 	nova.response201Created(c, response)
 	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response)
 	return
 }
 
-func (nova *Nova) HandleDeleteQuestionSingleChoice(c *gin.Context) {
-	// delete single choice question
-	logger.Infof("handle request delete single-choice question")
-	// extract single choice question id from uri
-	id := strings.ToLower(c.Param("Id"))
-	// request question Id correctness
-	logger.Debugf("check single-choice question Id is validate")
-	if err := uuid.Validate(id); err != nil {
-		nova.response400BadRequest(c, errors.New("single-choice question Id format incorrect"))
-		logger.Error("error single-choice question Id is validate")
+func (nova *Nova) HandleCreateQuestionFillBlank(c *gin.Context) {
+	// create fill-blank question
+	var request QuestionFillBlank
+	logger.Infof("handle request create fill-blank question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change fill-blank question")
+	if !nova.checkQuestionAuthor(c) {
 		return
 	}
-	logger.Debugf("successfully check single-choice question Id is validate")
-	// update data cache by querying single choice question in database
-	logger.Debugf("update data cache by querying single-choice questions in database")
-	err := nova.querySingleChoiceQuestionsInDatabase()
+	logger.Debugf("successfully check principal is allowed to change fill-blank question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.BindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check fill-blank question is validate")
+	b, err := nova.isFillBlankQuestionValidate(request)
+	if !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check fill-blank question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check fill-blank question is validate")
+	// update data cache by querying fill-blank questions in database
+	logger.Debugf("update data cache by querying fill-blank question in database")
+	err = nova.queryFillBlankQuestionsInDatabase()
 	if err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error update data cache by querying single-choice questions in database: %v", err)
+		logger.Errorf("error update data cache by querying fill-blank question in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully update data cache by querying single-choice questions in database")
-	// check single choice question existence
-	logger.Debugf("check single-choice question is validate")
-	if !nova.isSingleChoiceQuestionExisted(id) {
-		nova.response404NotFound(c, errors.New("single-choice question not found"))
-		logger.Error("error check single-choice question is validate")
+	logger.Debugf("successfully update data cache by querying fill-blank question in database")
+	// check fill-blank question existence
+	logger.Debugf("check fill-blank question is existed")
+	if nova.isFillBlankQuestionExisted(strings.ToLower(request.Id)) {
+		nova.response409Conflict(c, errors.New("fill-blank question already exists"))
+		logger.Errorf("error check fill-blank question is existed: %v", err)
 		return
 	}
-	logger.Debugf("successfully check single-choice question is validate")
-	// delete single choice question from database
-	logger.Debugf("delete single-choice question in database")
-	if err := nova.deleteSingleChoiceQuestionInDatabase(id); err != nil {
-		nova.response500InternalServerError(c, err)
-		logger.Error("error delete single-choice question in database")
+	logger.Debugf("successfully check fill-blank question is existed")
+	// check fill-blank question duplication
+	logger.Debugf("check fill-blank question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeFillBlank, strings.ToLower(request.Id), fillBlankFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check fill-blank question is duplicated: %v", err)
 		return
 	}
-	logger.Debugf("successfully delete single-choice question in database")
-	// delete single-choice question from data cache
-	logger.Debugf("delete single-choice question in data cache")
-	nova.deleteSingleChoiceQuestionInDataCache(id)
-	// return response
-	nova.response204NoContent(c, nil)
-	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, nil)
-	return
-}
-
-func (nova *Nova) HandleDeleteQuestionMultipleChoice(c *gin.Context) {
-	// delete multiple-choice question
-	logger.Infof("handle request delete multiple-choice question")
-	// extract multiple-choice question id from uri
-	id := strings.ToLower(c.Param("Id"))
-	// request question Id correctness
-	logger.Debugf("check multiple-choice question Id is validate")
-	if err := uuid.Validate(id); err != nil {
-		nova.response400BadRequest(c, errors.New("multiple-choice question Id format incorrect"))
-		logger.Error("error multiple-choice question Id is validate")
+	logger.Debugf("successfully check fill-blank question is duplicated")
+	// store fill-blank question tenant in database & data cache
+	logger.Debugf("store fill-blank question tenant in database & data cache")
+	if err = nova.createResourceTenant(c, QuestionTypeFillBlank, strings.ToLower(request.Id)); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store fill-blank question tenant in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully check multiple-choice question Id is validate")
-	// update data cache by querying multiple-choice question in database
-	logger.Debugf("update data cache by querying multiple-choice questions in database")
-	err := nova.queryMultipleChoiceQuestionsInDatabase()
-	if err != nil {
+	logger.Debugf("successfully store fill-blank question tenant in database & data cache")
+	// store created fill-blank question in data cache
+	logger.Debugf("store fill-blank question in data cache")
+	response := QuestionFillBlank{
+		Id:     strings.ToLower(request.Id),
+		Title:  request.Title,
+		Blanks: request.Blanks,
+	}
+	nova.createFillBlankQuestionInDataCache(response)
+	logger.Debugf("successfully store fill-blank question in data cache")
+	// store created fill-blank question in database
+	logger.Debugf("store fill-blank question in database")
+	if err = nova.createFillBlankQuestionInDatabase(response.Id); err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error update data cache by querying multiple-choice questions in database: %v", err)
+		logger.Errorf("error fill-blank question in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully update data cache by querying multiple-choice questions in database")
-	// check multiple-choice question existence
-	logger.Debugf("check multiple-choice question is validate")
-	if !nova.isMultipleChoiceQuestionExisted(id) {
-		nova.response404NotFound(c, errors.New("multiple-choice question not found"))
-		logger.Error("error check multiple-choice question is validate")
+	logger.Debugf("successfully store fill-blank question in database")
+	// store fill-blank question revision in database
+	logger.Debugf("store fill-blank question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeFillBlank, response.Id, nil, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store fill-blank question revision in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully check multiple-choice question is validate")
-	// delete multiple-choice question from database
-	logger.Debugf("delete multiple-choice question in database")
-	if err := nova.deleteMultipleChoiceQuestionInDatabase(id); err != nil {
+	logger.Debugf("successfully store fill-blank question revision in database")
+	// store fill-blank question workflow as draft in database
+	logger.Debugf("store fill-blank question workflow in database")
+	if _, err = nova.createQuestionWorkflow(c, QuestionTypeFillBlank, response.Id); err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Error("error delete multiple-choice question in database")
+		logger.Errorf("error store fill-blank question workflow in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully delete multiple-choice question in database")
-	// delete multiple-choice question from data cache
-	logger.Debugf("delete multiple-choice question in data cache")
-	nova.deleteMultipleChoiceQuestionInDataCache(id)
+	logger.Debugf("successfully store fill-blank question workflow in database")
 	// return response
-	nova.response204NoContent(c, nil)
-	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, nil)
+	nova.response201Created(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response)
 	return
 }
 
-func (nova *Nova) HandleDeleteQuestionJudgement(c *gin.Context) {
-	// delete judgement question
-	logger.Infof("handle request delete judgement question")
-	// extract judgement question id from uri
-	id := strings.ToLower(c.Param("Id"))
-	// request question Id correctness
-	logger.Debugf("check judgement question Id is validate")
-	if err := uuid.Validate(id); err != nil {
-		nova.response400BadRequest(c, errors.New("judgement question Id format incorrect"))
-		logger.Error("error judgement question Id is validate")
+func (nova *Nova) HandleCreateQuestionMatching(c *gin.Context) {
+	// create matching question
+	var request QuestionMatching
+	logger.Infof("handle request create matching question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change matching question")
+	if !nova.checkQuestionAuthor(c) {
 		return
 	}
-	logger.Debugf("successfully check judgement question Id is validate")
-	// update data cache by querying judgement question in database
-	logger.Debugf("update data cache by querying judgement question in database")
-	err := nova.queryJudgementQuestionsInDatabase()
+	logger.Debugf("successfully check principal is allowed to change matching question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.BindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check matching question is validate")
+	b, err := nova.isMatchingQuestionValidate(request)
+	if !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check matching question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check matching question is validate")
+	// update data cache by querying matching questions in database
+	logger.Debugf("update data cache by querying matching question in database")
+	err = nova.queryMatchingQuestionsInDatabase()
 	if err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error update data cache by querying judgement questions in database: %v", err)
+		logger.Errorf("error update data cache by querying matching question in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully update data cache by querying judgement question in database")
-	// check judgement question existence
-	logger.Debugf("check judgement question is validate")
-	if !nova.isJudgementQuestionExisted(id) {
-		nova.response404NotFound(c, errors.New("judgement question not found"))
-		logger.Error("error check judgement question is validate")
+	logger.Debugf("successfully update data cache by querying matching question in database")
+	// check matching question existence
+	logger.Debugf("check matching question is existed")
+	if nova.isMatchingQuestionExisted(strings.ToLower(request.Id)) {
+		nova.response409Conflict(c, errors.New("matching question already exists"))
+		logger.Errorf("error check matching question is existed: %v", err)
 		return
 	}
-	logger.Debugf("successfully check judgement question is validate")
-	// delete judgement question from database
-	logger.Debugf("delete judgement question in database")
-	if err := nova.deleteJudgementQuestionInDatabase(id); err != nil {
-		nova.response500InternalServerError(c, err)
-		logger.Error("error delete judgement question in database")
+	logger.Debugf("successfully check matching question is existed")
+	// check matching question duplication
+	logger.Debugf("check matching question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeMatching, strings.ToLower(request.Id), matchingFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check matching question is duplicated: %v", err)
 		return
 	}
-	logger.Debugf("successfully delete judgement question in database")
-	// delete judgement question from data cache
-	logger.Debugf("delete judgement question in data cache")
-	nova.deleteJudgementQuestionInDataCache(id)
-	// return response
-	nova.response204NoContent(c, nil)
-	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, nil)
-	return
-}
-
-func (nova *Nova) HandleDeleteQuestionEssay(c *gin.Context) {
-	// delete essay question
-	logger.Infof("handle request delete essay question")
-	// extract essay question id from uri
-	id := strings.ToLower(c.Param("Id"))
-	// request question Id correctness
-	logger.Debugf("check essay question Id is validate")
-	if err := uuid.Validate(id); err != nil {
-		nova.response400BadRequest(c, errors.New("essay question Id format incorrect"))
-		logger.Error("error essay question Id is validate")
+	logger.Debugf("successfully check matching question is duplicated")
+	// store matching question tenant in database & data cache
+	logger.Debugf("store matching question tenant in database & data cache")
+	if err = nova.createResourceTenant(c, QuestionTypeMatching, strings.ToLower(request.Id)); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store matching question tenant in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully check essay question Id is validate")
-	// update data cache by querying essay question in database
-	logger.Debugf("update data cache by querying essay question in database")
-	err := nova.queryEssayQuestionsInDatabase()
-	if err != nil {
+	logger.Debugf("successfully store matching question tenant in database & data cache")
+	// store created matching question in data cache
+	logger.Debugf("store matching question in data cache")
+	response := QuestionMatching{
+		Id:      strings.ToLower(request.Id),
+		Title:   request.Title,
+		Pairs:   request.Pairs,
+		Scoring: request.Scoring,
+	}
+	nova.createMatchingQuestionInDataCache(response)
+	logger.Debugf("successfully store matching question in data cache")
+	// store created matching question in database
+	logger.Debugf("store matching question in database")
+	if err = nova.createMatchingQuestionInDatabase(response.Id); err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error update data cache by querying essay questions in database: %v", err)
+		logger.Errorf("error matching question in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully update data cache by querying essay question in database")
-	// check essay question existence
-	logger.Debugf("check essay question is validate")
-	if !nova.isEssayQuestionExisted(id) {
-		nova.response404NotFound(c, errors.New("essay question not found"))
-		logger.Error("error check essay question is validate")
+	logger.Debugf("successfully store matching question in database")
+	// store matching question revision in database
+	logger.Debugf("store matching question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeMatching, response.Id, nil, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store matching question revision in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully check essay question is validate")
-	// delete essay question from database
-	logger.Debugf("delete essay question in database")
-	if err := nova.deleteEssayQuestionInDatabase(id); err != nil {
+	logger.Debugf("successfully store matching question revision in database")
+	// store matching question workflow as draft in database
+	logger.Debugf("store matching question workflow in database")
+	if _, err = nova.createQuestionWorkflow(c, QuestionTypeMatching, response.Id); err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Error("error delete essay question in database")
+		logger.Errorf("error store matching question workflow in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully delete essay question in database")
-	// delete essay question from data cache
-	logger.Debugf("delete essay question in data cache")
-	nova.deleteEssayQuestionInDataCache(id)
+	logger.Debugf("successfully store matching question workflow in database")
 	// return response
-	nova.response204NoContent(c, nil)
-	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, nil)
+	nova.response201Created(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response)
 	return
 }
 
-func (nova *Nova) HandleModifyQuestionSingleChoice(c *gin.Context) {
-	// modify single-choice question
-	var request QuestionSingleChoice
-	logger.Infof("handle request modify single-choice question")
+func (nova *Nova) HandleCreateQuestionOrdering(c *gin.Context) {
+	// create ordering question
+	var request QuestionOrdering
+	logger.Infof("handle request create ordering question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change ordering question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change ordering question")
 	// request body should bind json
 	logger.Debugf("request body bind json format")
-	if err := c.ShouldBindJSON(&request); err != nil {
+	if err := c.BindJSON(&request); err != nil {
 		nova.response400BadRequest(c, err)
 		logger.Errorf("error bind request to json: %v", err)
 		return
 	}
 	logger.Debugf("successfully bind request json format")
 	// check request body correctness
-	logger.Debugf("check single-choice question is validate")
-	b, err := nova.isSingleChoiceQuestionValidate(request)
+	logger.Debugf("check ordering question is validate")
+	b, err := nova.isOrderingQuestionValidate(request)
 	if !b {
 		nova.response400BadRequest(c, err)
-		logger.Errorf("error check single-choice question is validate: %v", err)
+		logger.Errorf("error check ordering question is validate: %v", err)
 		return
 	}
-	logger.Debugf("successfully check single-choice question is validate")
-	// update data cache by querying single-choice questions in database
-	logger.Debugf("update data cache by querying single-choice question in database")
-	err = nova.querySingleChoiceQuestionsInDatabase()
+	logger.Debugf("successfully check ordering question is validate")
+	// update data cache by querying ordering questions in database
+	logger.Debugf("update data cache by querying ordering question in database")
+	err = nova.queryOrderingQuestionsInDatabase()
 	if err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error update data cache by querying single-choice question in database: %v", err)
+		logger.Errorf("error update data cache by querying ordering question in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully update data cache by querying single-choice question in database")
-	// check single-choice question existence
-	logger.Debugf("check single-choice question is existed")
-	if !nova.isSingleChoiceQuestionExisted(strings.ToLower(request.Id)) {
-		nova.response404NotFound(c, errors.New("single-choice question not found"))
-		logger.Errorf("error check single-choice question is existed: %v", err)
+	logger.Debugf("successfully update data cache by querying ordering question in database")
+	// check ordering question existence
+	logger.Debugf("check ordering question is existed")
+	if nova.isOrderingQuestionExisted(strings.ToLower(request.Id)) {
+		nova.response409Conflict(c, errors.New("ordering question already exists"))
+		logger.Errorf("error check ordering question is existed: %v", err)
 		return
 	}
-	logger.Debugf("successfully check single-choice question is existed")
-	// store modified single-choice question in data cache
-	logger.Debugf("store modify single-choice question in data cache")
-	response, err := nova.modifySingleChoiceQuestionInDataCache(request)
-	if err != nil {
-		nova.response404NotFound(c, err)
-		logger.Errorf("error store modify single-choice question in data cache: %v", err)
+	logger.Debugf("successfully check ordering question is existed")
+	// check ordering question duplication
+	logger.Debugf("check ordering question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeOrdering, strings.ToLower(request.Id), orderingFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check ordering question is duplicated: %v", err)
 		return
 	}
-	logger.Debugf("successfully store modify single-choice question in data cache")
-	// store modified single-choice question in database
-	logger.Debugf("store modify single-choice question in database")
-	if err = nova.modifySingleChoiceQuestionInDatabase(response.Id); err != nil {
+	logger.Debugf("successfully check ordering question is duplicated")
+	// store ordering question tenant in database & data cache
+	logger.Debugf("store ordering question tenant in database & data cache")
+	if err = nova.createResourceTenant(c, QuestionTypeOrdering, strings.ToLower(request.Id)); err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error store modify single-choice question in database: %v", err)
+		logger.Errorf("error store ordering question tenant in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully store modify single-choice question in database")
+	logger.Debugf("successfully store ordering question tenant in database & data cache")
+	// store created ordering question in data cache
+	logger.Debugf("store ordering question in data cache")
+	response := QuestionOrdering{
+		Id:      strings.ToLower(request.Id),
+		Title:   request.Title,
+		Items:   request.Items,
+		Scoring: request.Scoring,
+	}
+	nova.createOrderingQuestionInDataCache(response)
+	logger.Debugf("successfully store ordering question in data cache")
+	// store created ordering question in database
+	logger.Debugf("store ordering question in database")
+	if err = nova.createOrderingQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error ordering question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store ordering question in database")
+	// store ordering question revision in database
+	logger.Debugf("store ordering question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeOrdering, response.Id, nil, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store ordering question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store ordering question revision in database")
+	// store ordering question workflow as draft in database
+	logger.Debugf("store ordering question workflow in database")
+	if _, err = nova.createQuestionWorkflow(c, QuestionTypeOrdering, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store ordering question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store ordering question workflow in database")
 	// return response
-	nova.response200OK(c, response)
-	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	nova.response201Created(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response)
 	return
 }
 
-func (nova *Nova) HandleModifyQuestionMultipleChoice(c *gin.Context) {
-	// modify multiple-choice question
-	var request QuestionMultipleChoice
-	logger.Infof("handle request modify multiple-choice question")
+func (nova *Nova) HandleCreateQuestionNumeric(c *gin.Context) {
+	// create numeric question
+	var request QuestionNumeric
+	logger.Infof("handle request create numeric question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change numeric question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change numeric question")
 	// request body should bind json
 	logger.Debugf("request body bind json format")
-	if err := c.ShouldBindJSON(&request); err != nil {
+	if err := c.BindJSON(&request); err != nil {
 		nova.response400BadRequest(c, err)
 		logger.Errorf("error bind request to json: %v", err)
 		return
 	}
 	logger.Debugf("successfully bind request json format")
 	// check request body correctness
-	logger.Debugf("check multiple-choice question is validate")
-	b, err := nova.isMultipleChoiceQuestionValidate(request)
+	logger.Debugf("check numeric question is validate")
+	b, err := nova.isNumericQuestionValidate(request)
 	if !b {
 		nova.response400BadRequest(c, err)
-		logger.Errorf("error check multiple-choice question is validate: %v", err)
+		logger.Errorf("error check numeric question is validate: %v", err)
 		return
 	}
-	logger.Debugf("successfully check multiple-choice question is validate")
-	// update data cache by querying multiple-choice questions in database
-	logger.Debugf("update data cache by querying multiple-choice question in database")
-	err = nova.queryMultipleChoiceQuestionsInDatabase()
+	logger.Debugf("successfully check numeric question is validate")
+	// update data cache by querying numeric questions in database
+	logger.Debugf("update data cache by querying numeric question in database")
+	err = nova.queryNumericQuestionsInDatabase()
 	if err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error update data cache by querying multiple-choice question in database: %v", err)
+		logger.Errorf("error update data cache by querying numeric question in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully update data cache by querying multiple-choice question in database")
-	// check multiple-choice question existence
-	logger.Debugf("check multiple-choice question is existed")
-	if !nova.isMultipleChoiceQuestionExisted(strings.ToLower(request.Id)) {
-		nova.response404NotFound(c, errors.New("multiple-choice question not found"))
-		logger.Errorf("error check multiple-choice question is existed: %v", err)
+	logger.Debugf("successfully update data cache by querying numeric question in database")
+	// check numeric question existence
+	logger.Debugf("check numeric question is existed")
+	if nova.isNumericQuestionExisted(strings.ToLower(request.Id)) {
+		nova.response409Conflict(c, errors.New("numeric question already exists"))
+		logger.Errorf("error check numeric question is existed: %v", err)
 		return
 	}
-	logger.Debugf("successfully check multiple-choice question is existed")
-	// store modified multiple-choice question in data cache
-	logger.Debugf("store modify single-choice question in data cache")
-	response, err := nova.modifyMultipleChoiceQuestionInDataCache(request)
-	if err != nil {
-		nova.response404NotFound(c, err)
-		logger.Errorf("error store modify multiple-choice question in data cache: %v", err)
+	logger.Debugf("successfully check numeric question is existed")
+	// check numeric question duplication
+	logger.Debugf("check numeric question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeNumeric, strings.ToLower(request.Id), numericFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check numeric question is duplicated: %v", err)
 		return
 	}
-	logger.Debugf("successfully store modify multiple-choice question in data cache")
-	// store modified multiple-choice question in database
-	logger.Debugf("store modify multiple-choice question in database")
-	if err = nova.modifyMultipleChoiceQuestionInDatabase(response.Id); err != nil {
+	logger.Debugf("successfully check numeric question is duplicated")
+	// store numeric question tenant in database & data cache
+	logger.Debugf("store numeric question tenant in database & data cache")
+	if err = nova.createResourceTenant(c, QuestionTypeNumeric, strings.ToLower(request.Id)); err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error store modify multiple-choice question in database: %v", err)
+		logger.Errorf("error store numeric question tenant in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully store modify multiple-choice question in database")
+	logger.Debugf("successfully store numeric question tenant in database & data cache")
+	// store created numeric question in data cache
+	logger.Debugf("store numeric question in data cache")
+	response := QuestionNumeric{
+		Id:                strings.ToLower(request.Id),
+		Title:             request.Title,
+		Value:             request.Value,
+		AbsoluteTolerance: request.AbsoluteTolerance,
+		RelativeTolerance: request.RelativeTolerance,
+		Unit:              request.Unit,
+		Units:             request.Units,
+	}
+	nova.createNumericQuestionInDataCache(response)
+	logger.Debugf("successfully store numeric question in data cache")
+	// store created numeric question in database
+	logger.Debugf("store numeric question in database")
+	if err = nova.createNumericQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error numeric question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store numeric question in database")
+	// store numeric question revision in database
+	logger.Debugf("store numeric question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeNumeric, response.Id, nil, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store numeric question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store numeric question revision in database")
+	// store numeric question workflow as draft in database
+	logger.Debugf("store numeric question workflow in database")
+	if _, err = nova.createQuestionWorkflow(c, QuestionTypeNumeric, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store numeric question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store numeric question workflow in database")
 	// return response
-	nova.response200OK(c, response)
-	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	nova.response201Created(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response)
 	return
 }
 
-func (nova *Nova) HandleModifyQuestionJudgement(c *gin.Context) {
-	// modify judgement question
-	var request QuestionJudgement
-	logger.Infof("handle request modify judgement question")
+func (nova *Nova) HandleCreateQuestionTemplate(c *gin.Context) {
+	// create template question
+	var request QuestionTemplate
+	logger.Infof("handle request create template question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change template question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change template question")
 	// request body should bind json
 	logger.Debugf("request body bind json format")
-	if err := c.ShouldBindJSON(&request); err != nil {
+	if err := c.BindJSON(&request); err != nil {
 		nova.response400BadRequest(c, err)
 		logger.Errorf("error bind request to json: %v", err)
 		return
 	}
 	logger.Debugf("successfully bind request json format")
 	// check request body correctness
-	logger.Debugf("check judgement question is validate")
-	b, err := nova.isJudgementQuestionValidate(request)
+	logger.Debugf("check template question is validate")
+	b, err := nova.isTemplateQuestionValidate(request)
 	if !b {
 		nova.response400BadRequest(c, err)
-		logger.Errorf("error check judgement question is validate: %v", err)
+		logger.Errorf("error check template question is validate: %v", err)
 		return
 	}
-	logger.Debugf("successfully check judgement question is validate")
-	// update data cache by querying judgement questions in database
-	logger.Debugf("update data cache by querying judgement question in database")
-	err = nova.queryJudgementQuestionsInDatabase()
+	logger.Debugf("successfully check template question is validate")
+	// update data cache by querying template questions in database
+	logger.Debugf("update data cache by querying template question in database")
+	err = nova.queryTemplateQuestionsInDatabase()
 	if err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error update data cache by querying judgement question in database: %v", err)
+		logger.Errorf("error update data cache by querying template question in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully update data cache by querying judgement question in database")
-	// check judgement question existence
-	logger.Debugf("check judgement question is existed")
-	if !nova.isJudgementQuestionExisted(strings.ToLower(request.Id)) {
-		nova.response404NotFound(c, errors.New("judgement question not found"))
-		logger.Errorf("error check judgement question is existed: %v", err)
+	logger.Debugf("successfully update data cache by querying template question in database")
+	// check template question existence
+	logger.Debugf("check template question is existed")
+	if nova.isTemplateQuestionExisted(strings.ToLower(request.Id)) {
+		nova.response409Conflict(c, errors.New("template question already exists"))
+		logger.Errorf("error check template question is existed: %v", err)
 		return
 	}
-	logger.Debugf("successfully check judgement question is existed")
-	// store modified judgement question in data cache
-	logger.Debugf("store modify judgement question in data cache")
-	response, err := nova.modifyJudgementQuestionInDataCache(request)
-	if err != nil {
-		nova.response404NotFound(c, err)
-		logger.Errorf("error store modify judgement question in data cache: %v", err)
+	logger.Debugf("successfully check template question is existed")
+	// check template question duplication
+	logger.Debugf("check template question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeTemplate, strings.ToLower(request.Id), templateFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check template question is duplicated: %v", err)
 		return
 	}
-	logger.Debugf("successfully store modify judgement question in data cache")
-	// store modified judgement question in database
-	logger.Debugf("store modify judgement question in database")
-	if err = nova.modifyJudgementQuestionInDatabase(response.Id); err != nil {
+	logger.Debugf("successfully check template question is duplicated")
+	// store template question tenant in database & data cache
+	logger.Debugf("store template question tenant in database & data cache")
+	if err = nova.createResourceTenant(c, QuestionTypeTemplate, strings.ToLower(request.Id)); err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error store modify judgement question in database: %v", err)
+		logger.Errorf("error store template question tenant in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully store modify judgement question in database")
+	logger.Debugf("successfully store template question tenant in database & data cache")
+	// store created template question in data cache
+	logger.Debugf("store template question in data cache")
+	response := QuestionTemplate{
+		Id:                strings.ToLower(request.Id),
+		Title:             request.Title,
+		Variables:         request.Variables,
+		Formula:           request.Formula,
+		Precision:         request.Precision,
+		AbsoluteTolerance: request.AbsoluteTolerance,
+		RelativeTolerance: request.RelativeTolerance,
+	}
+	nova.createTemplateQuestionInDataCache(response)
+	logger.Debugf("successfully store template question in data cache")
+	// store created template question in database
+	logger.Debugf("store template question in database")
+	if err = nova.createTemplateQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error template question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store template question in database")
+	// store template question revision in database
+	logger.Debugf("store template question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeTemplate, response.Id, nil, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store template question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store template question revision in database")
+	// store template question workflow as draft in database
+	logger.Debugf("store template question workflow in database")
+	if _, err = nova.createQuestionWorkflow(c, QuestionTypeTemplate, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store template question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store template question workflow in database")
 	// return response
-	nova.response200OK(c, response)
-	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	nova.response201Created(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response)
 	return
 }
 
-func (nova *Nova) HandleModifyQuestionEssay(c *gin.Context) {
-	// modify essay question
-	var request QuestionEssay
-	logger.Infof("handle request modify essay question")
+func (nova *Nova) HandleCreateQuestionCode(c *gin.Context) {
+	// create code question
+	var request QuestionCode
+	logger.Infof("handle request create code question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change code question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change code question")
 	// request body should bind json
 	logger.Debugf("request body bind json format")
-	if err := c.ShouldBindJSON(&request); err != nil {
+	if err := c.BindJSON(&request); err != nil {
 		nova.response400BadRequest(c, err)
 		logger.Errorf("error bind request to json: %v", err)
 		return
 	}
 	logger.Debugf("successfully bind request json format")
 	// check request body correctness
-	logger.Debugf("check essay question is validate")
-	b, err := nova.isEssayQuestionValidate(request)
+	logger.Debugf("check code question is validate")
+	b, err := nova.isCodeQuestionValidate(request)
 	if !b {
 		nova.response400BadRequest(c, err)
-		logger.Errorf("error check essay question is validate: %v", err)
+		logger.Errorf("error check code question is validate: %v", err)
 		return
 	}
-	logger.Debugf("successfully check essay question is validate")
-	// update data cache by querying essay questions in database
-	logger.Debugf("update data cache by querying essay question in database")
-	err = nova.queryEssayQuestionsInDatabase()
+	logger.Debugf("successfully check code question is validate")
+	// update data cache by querying code questions in database
+	logger.Debugf("update data cache by querying code question in database")
+	err = nova.queryCodeQuestionsInDatabase()
 	if err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error update data cache by essay judgement question in database: %v", err)
+		logger.Errorf("error update data cache by querying code question in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully update data cache by querying essay question in database")
-	// check essay question existence
-	logger.Debugf("check essay question is existed")
-	if !nova.isEssayQuestionExisted(strings.ToLower(request.Id)) {
-		nova.response404NotFound(c, errors.New("essay question not found"))
-		logger.Errorf("error check essay question is existed: %v", err)
+	logger.Debugf("successfully update data cache by querying code question in database")
+	// check code question existence
+	logger.Debugf("check code question is existed")
+	if nova.isCodeQuestionExisted(strings.ToLower(request.Id)) {
+		nova.response409Conflict(c, errors.New("code question already exists"))
+		logger.Errorf("error check code question is existed: %v", err)
 		return
 	}
-	logger.Debugf("successfully check essay question is existed")
-	// store modified essay question in data cache
-	logger.Debugf("store modify essay question in data cache")
-	response, err := nova.modifyEssayQuestionInDataCache(request)
-	if err != nil {
-		nova.response404NotFound(c, err)
-		logger.Errorf("error store modify essay question in data cache: %v", err)
+	logger.Debugf("successfully check code question is existed")
+	// check code question duplication
+	logger.Debugf("check code question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeCode, strings.ToLower(request.Id), codeFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check code question is duplicated: %v", err)
 		return
 	}
-	logger.Debugf("successfully store modify essay question in data cache")
-	// store modified essay question in database
-	logger.Debugf("store modify essay question in database")
-	if err = nova.modifyEssayQuestionInDatabase(response.Id); err != nil {
+	logger.Debugf("successfully check code question is duplicated")
+	// store code question tenant in database & data cache
+	logger.Debugf("store code question tenant in database & data cache")
+	if err = nova.createResourceTenant(c, QuestionTypeCode, strings.ToLower(request.Id)); err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error store modify essay question in database: %v", err)
+		logger.Errorf("error store code question tenant in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully store modify essay question in database")
+	logger.Debugf("successfully store code question tenant in database & data cache")
+	// store created code question in data cache
+	logger.Debugf("store code question in data cache")
+	response := QuestionCode{
+		Id:          strings.ToLower(request.Id),
+		Title:       request.Title,
+		Language:    request.Language,
+		StarterCode: request.StarterCode,
+		TestCases:   request.TestCases,
+		TimeLimit:   request.TimeLimit,
+		MemoryLimit: request.MemoryLimit,
+		Scoring:     request.Scoring,
+	}
+	nova.createCodeQuestionInDataCache(response)
+	logger.Debugf("successfully store code question in data cache")
+	// store created code question in database
+	logger.Debugf("store code question in database")
+	if err = nova.createCodeQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error code question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store code question in database")
+	// store code question revision in database
+	logger.Debugf("store code question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeCode, response.Id, nil, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store code question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store code question revision in database")
+	// store code question workflow as draft in database
+	logger.Debugf("store code question workflow in database")
+	if _, err = nova.createQuestionWorkflow(c, QuestionTypeCode, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store code question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store code question workflow in database")
 	// return response
-	nova.response200OK(c, response)
-	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	nova.response201Created(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response)
 	return
 }
 
-func (nova *Nova) HandleQueryQuestionSingleChoice(c *gin.Context) {
-	// query question single-choice
-	logger.Infof("handle request query single-choice question")
-	// extract questionId from uri
+func (nova *Nova) HandleDeleteQuestionSingleChoice(c *gin.Context) {
+	// delete single choice question
+	logger.Infof("handle request delete single-choice question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change single-choice question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change single-choice question")
+	// extract single choice question id from uri
 	id := strings.ToLower(c.Param("Id"))
-	// request questionId correctness
-	logger.Debugf("check questionId is validate")
-	if b, _ := nova.isSingleChoiceQuestionValidate(QuestionSingleChoice{Id: id}); !b {
-		nova.response400BadRequest(c, errors.New("questionId format incorrect"))
-		logger.Errorf("error check questionId is validate")
+	// request question Id correctness
+	logger.Debugf("check single-choice question Id is validate")
+	if err := uuid.Validate(id); err != nil {
+		nova.response400BadRequest(c, errors.New("single-choice question Id format incorrect"))
+		logger.Error("error single-choice question Id is validate")
 		return
 	}
-	logger.Debugf("successfully check questionId is validate")
-	// update data cache by querying single-choice questions in database
+	logger.Debugf("successfully check single-choice question Id is validate")
+	// update data cache by querying single choice question in database
 	logger.Debugf("update data cache by querying single-choice questions in database")
-	err := nova.querySingleChoiceQuestionInDatabase(id)
+	err := nova.querySingleChoiceQuestionsInDatabase()
 	if err != nil {
 		nova.response500InternalServerError(c, err)
 		logger.Errorf("error update data cache by querying single-choice questions in database: %v", err)
 		return
 	}
 	logger.Debugf("successfully update data cache by querying single-choice questions in database")
-	// check single-choice question existence
-	logger.Debugf("check single-choice question is existed")
+	// check single choice question existence
+	logger.Debugf("check single-choice question is validate")
 	if !nova.isSingleChoiceQuestionExisted(id) {
 		nova.response404NotFound(c, errors.New("single-choice question not found"))
-		logger.Errorf("error check single-choice question is existed")
+		logger.Error("error check single-choice question is validate")
 		return
 	}
-	logger.Debugf("successfully check single-choice question is existed")
-	// query single-choice question from database
-	logger.Debugf("query single-choice question in database")
-	if err := nova.querySingleChoiceQuestionInDatabase(id); err != nil {
+	logger.Debugf("successfully check single-choice question is validate")
+	// delete single choice question from database
+	logger.Debugf("delete single-choice question in database")
+	if err := nova.deleteSingleChoiceQuestionInDatabase(id); err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error query single-choice question in database: %v", err)
+		logger.Error("error delete single-choice question in database")
 		return
 	}
-	logger.Debugf("successfully query single-choice question in database")
-	// query single-choice question from data cache
-	logger.Debugf("query single-choice question in data cache")
-	response, err := nova.querySingleChoiceQuestionInDataCache(id)
-	if err != nil {
-		nova.response404NotFound(c, err)
-		logger.Errorf("error query single-choice question in data cache: %v", err)
+	logger.Debugf("successfully delete single-choice question in database")
+	// delete single-choice question from data cache
+	logger.Debugf("delete single-choice question in data cache")
+	nova.deleteSingleChoiceQuestionInDataCache(id)
+	// delete single-choice question workflow in database
+	logger.Debugf("delete single-choice question workflow in database")
+	if err := nova.deleteQuestionWorkflow(QuestionTypeSingleChoice, id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error delete single-choice question workflow in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully query single-choice question in data cache")
+	logger.Debugf("successfully delete single-choice question workflow in database")
 	// return response
-	nova.response200OK(c, response)
-	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	nova.response204NoContent(c, nil)
+	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, nil)
 	return
 }
 
-func (nova *Nova) HandleQueryQuestionMultipleChoice(c *gin.Context) {
-	// query question multiple-choice
-	logger.Infof("handle request query multiple-choice question")
-	// extract questionId from uri
+func (nova *Nova) HandleDeleteQuestionMultipleChoice(c *gin.Context) {
+	// delete multiple-choice question
+	logger.Infof("handle request delete multiple-choice question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change multiple-choice question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change multiple-choice question")
+	// extract multiple-choice question id from uri
 	id := strings.ToLower(c.Param("Id"))
-	// request questionId correctness
-	logger.Debugf("check questionId is validate")
-	if b, _ := nova.isMultipleChoiceQuestionValidate(QuestionMultipleChoice{Id: id}); !b {
-		nova.response400BadRequest(c, errors.New("questionId format incorrect"))
-		logger.Errorf("error check questionId is validate")
+	// request question Id correctness
+	logger.Debugf("check multiple-choice question Id is validate")
+	if err := uuid.Validate(id); err != nil {
+		nova.response400BadRequest(c, errors.New("multiple-choice question Id format incorrect"))
+		logger.Error("error multiple-choice question Id is validate")
 		return
 	}
-	logger.Debugf("successfully check questionId is validate")
-	// update data cache by querying multiple-choice questions in database
+	logger.Debugf("successfully check multiple-choice question Id is validate")
+	// update data cache by querying multiple-choice question in database
 	logger.Debugf("update data cache by querying multiple-choice questions in database")
-	err := nova.queryMultipleChoiceQuestionInDatabase(id)
+	err := nova.queryMultipleChoiceQuestionsInDatabase()
 	if err != nil {
 		nova.response500InternalServerError(c, err)
 		logger.Errorf("error update data cache by querying multiple-choice questions in database: %v", err)
@@ -780,539 +1163,4836 @@ func (nova *Nova) HandleQueryQuestionMultipleChoice(c *gin.Context) {
 	}
 	logger.Debugf("successfully update data cache by querying multiple-choice questions in database")
 	// check multiple-choice question existence
-	logger.Debugf("check multiple-choice question is existed")
+	logger.Debugf("check multiple-choice question is validate")
 	if !nova.isMultipleChoiceQuestionExisted(id) {
 		nova.response404NotFound(c, errors.New("multiple-choice question not found"))
-		logger.Errorf("error check multiple-choice question is existed")
+		logger.Error("error check multiple-choice question is validate")
 		return
 	}
-	logger.Debugf("successfully check multiple-choice question is existed")
-	// query multiple-choice question from database
-	logger.Debugf("query multiple-choice question in database")
-	if err := nova.queryMultipleChoiceQuestionInDatabase(id); err != nil {
+	logger.Debugf("successfully check multiple-choice question is validate")
+	// delete multiple-choice question from database
+	logger.Debugf("delete multiple-choice question in database")
+	if err := nova.deleteMultipleChoiceQuestionInDatabase(id); err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error query multiple-choice question in database: %v", err)
+		logger.Error("error delete multiple-choice question in database")
 		return
 	}
-	logger.Debugf("successfully query multiple-choice question in database")
-	// query multiple-choice question from data cache
-	logger.Debugf("query multiple-choice question in data cache")
-	response, err := nova.queryMultipleChoiceQuestionInDataCache(id)
-	if err != nil {
-		nova.response404NotFound(c, err)
-		logger.Errorf("error query multiple-choice question in data cache: %v", err)
+	logger.Debugf("successfully delete multiple-choice question in database")
+	// delete multiple-choice question from data cache
+	logger.Debugf("delete multiple-choice question in data cache")
+	nova.deleteMultipleChoiceQuestionInDataCache(id)
+	// delete multiple-choice question workflow in database
+	logger.Debugf("delete multiple-choice question workflow in database")
+	if err := nova.deleteQuestionWorkflow(QuestionTypeMultipleChoice, id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error delete multiple-choice question workflow in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully query multiple-choice question in data cache")
+	logger.Debugf("successfully delete multiple-choice question workflow in database")
 	// return response
-	nova.response200OK(c, response)
-	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	nova.response204NoContent(c, nil)
+	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, nil)
 	return
 }
 
-func (nova *Nova) HandleQueryQuestionJudgement(c *gin.Context) {
-	// query question judgement
-	logger.Infof("handle request query judgement question")
-	// extract questionId from uri
+func (nova *Nova) HandleDeleteQuestionJudgement(c *gin.Context) {
+	// delete judgement question
+	logger.Infof("handle request delete judgement question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change judgement question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change judgement question")
+	// extract judgement question id from uri
 	id := strings.ToLower(c.Param("Id"))
-	// request questionId correctness
-	logger.Debugf("check questionId is validate")
-	if b, _ := nova.isJudgementQuestionValidate(QuestionJudgement{Id: id}); !b {
-		nova.response400BadRequest(c, errors.New("questionId format incorrect"))
-		logger.Errorf("error check questionId is validate")
+	// request question Id correctness
+	logger.Debugf("check judgement question Id is validate")
+	if err := uuid.Validate(id); err != nil {
+		nova.response400BadRequest(c, errors.New("judgement question Id format incorrect"))
+		logger.Error("error judgement question Id is validate")
 		return
 	}
-	logger.Debugf("successfully check questionId is validate")
-	// update data cache by querying judgement questions in database
-	logger.Debugf("update data cache by querying judgement questions in database")
-	err := nova.queryJudgementQuestionInDatabase(id)
+	logger.Debugf("successfully check judgement question Id is validate")
+	// update data cache by querying judgement question in database
+	logger.Debugf("update data cache by querying judgement question in database")
+	err := nova.queryJudgementQuestionsInDatabase()
 	if err != nil {
 		nova.response500InternalServerError(c, err)
 		logger.Errorf("error update data cache by querying judgement questions in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully update data cache by querying judgement questions in database")
+	logger.Debugf("successfully update data cache by querying judgement question in database")
 	// check judgement question existence
-	logger.Debugf("check judgement question is existed")
+	logger.Debugf("check judgement question is validate")
 	if !nova.isJudgementQuestionExisted(id) {
 		nova.response404NotFound(c, errors.New("judgement question not found"))
-		logger.Errorf("error check judgement question is existed")
+		logger.Error("error check judgement question is validate")
 		return
 	}
-	logger.Debugf("successfully check judgement question is existed")
-	// query judgement question from database
-	logger.Debugf("query judgement question in database")
-	if err := nova.queryJudgementQuestionInDatabase(id); err != nil {
+	logger.Debugf("successfully check judgement question is validate")
+	// delete judgement question from database
+	logger.Debugf("delete judgement question in database")
+	if err := nova.deleteJudgementQuestionInDatabase(id); err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error query judgement question in database: %v", err)
+		logger.Error("error delete judgement question in database")
 		return
 	}
-	logger.Debugf("successfully query judgement question in database")
-	// query judgement question from data cache
-	logger.Debugf("query judgement question in data cache")
-	response, err := nova.queryJudgementQuestionInDataCache(id)
-	if err != nil {
-		nova.response404NotFound(c, err)
-		logger.Errorf("error query judgement question in data cache: %v", err)
+	logger.Debugf("successfully delete judgement question in database")
+	// delete judgement question from data cache
+	logger.Debugf("delete judgement question in data cache")
+	nova.deleteJudgementQuestionInDataCache(id)
+	// delete judgement question workflow in database
+	logger.Debugf("delete judgement question workflow in database")
+	if err := nova.deleteQuestionWorkflow(QuestionTypeJudgement, id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error delete judgement question workflow in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully query judgement question in data cache")
+	logger.Debugf("successfully delete judgement question workflow in database")
 	// return response
-	nova.response200OK(c, response)
-	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	nova.response204NoContent(c, nil)
+	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, nil)
 	return
 }
 
-func (nova *Nova) HandleQueryQuestionEssay(c *gin.Context) {
-	// query question essay
-	logger.Infof("handle request query essay question")
-	// extract questionId from uri
+func (nova *Nova) HandleDeleteQuestionEssay(c *gin.Context) {
+	// delete essay question
+	logger.Infof("handle request delete essay question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change essay question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change essay question")
+	// extract essay question id from uri
 	id := strings.ToLower(c.Param("Id"))
-	// request questionId correctness
-	logger.Debugf("check questionId is validate")
-	if b, _ := nova.isEssayQuestionValidate(QuestionEssay{Id: id}); !b {
-		nova.response400BadRequest(c, errors.New("questionId format incorrect"))
-		logger.Errorf("error check questionId is validate")
+	// request question Id correctness
+	logger.Debugf("check essay question Id is validate")
+	if err := uuid.Validate(id); err != nil {
+		nova.response400BadRequest(c, errors.New("essay question Id format incorrect"))
+		logger.Error("error essay question Id is validate")
 		return
 	}
-	logger.Debugf("successfully check questionId is validate")
-	// update data cache by querying essay questions in database
-	logger.Debugf("update data cache by querying essay questions in database")
-	err := nova.queryEssayQuestionInDatabase(id)
+	logger.Debugf("successfully check essay question Id is validate")
+	// update data cache by querying essay question in database
+	logger.Debugf("update data cache by querying essay question in database")
+	err := nova.queryEssayQuestionsInDatabase()
 	if err != nil {
 		nova.response500InternalServerError(c, err)
 		logger.Errorf("error update data cache by querying essay questions in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully update data cache by querying essay questions in database")
+	logger.Debugf("successfully update data cache by querying essay question in database")
 	// check essay question existence
-	logger.Debugf("check essay question is existed")
+	logger.Debugf("check essay question is validate")
 	if !nova.isEssayQuestionExisted(id) {
 		nova.response404NotFound(c, errors.New("essay question not found"))
-		logger.Errorf("error check essay question is existed")
+		logger.Error("error check essay question is validate")
 		return
 	}
-	logger.Debugf("successfully check essay question is existed")
-	// query essay question from database
-	logger.Debugf("query essay question in database")
-	if err := nova.queryEssayQuestionInDatabase(id); err != nil {
+	logger.Debugf("successfully check essay question is validate")
+	// delete essay question from database
+	logger.Debugf("delete essay question in database")
+	if err := nova.deleteEssayQuestionInDatabase(id); err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error query essay question in database: %v", err)
+		logger.Error("error delete essay question in database")
 		return
 	}
-	logger.Debugf("successfully query essay question in database")
-	// query essay question from data cache
-	logger.Debugf("query essay question in data cache")
-	response, err := nova.queryEssayQuestionInDataCache(id)
-	if err != nil {
-		nova.response404NotFound(c, err)
-		logger.Errorf("error query essay question in data cache: %v", err)
+	logger.Debugf("successfully delete essay question in database")
+	// delete essay question from data cache
+	logger.Debugf("delete essay question in data cache")
+	nova.deleteEssayQuestionInDataCache(id)
+	// delete essay question workflow in database
+	logger.Debugf("delete essay question workflow in database")
+	if err := nova.deleteQuestionWorkflow(QuestionTypeEssay, id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error delete essay question workflow in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully query essay question in data cache")
+	logger.Debugf("successfully delete essay question workflow in database")
 	// return response
-	nova.response200OK(c, response)
-	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	nova.response204NoContent(c, nil)
+	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, nil)
 	return
 }
 
-func (nova *Nova) HandleUpdateQuestionSingleChoice(c *gin.Context) {
-	// update single-choice question
-	var request QuestionSingleChoice
-	logger.Infof("handle request update single choice question")
-	// request body should bind json
-	logger.Debugf("request body bind json format")
-	err := c.ShouldBindJSON(&request)
-	if err != nil {
-		nova.response400BadRequest(c, err)
-		logger.Errorf("error bind request to json: %v", err)
+func (nova *Nova) HandleDeleteQuestionFillBlank(c *gin.Context) {
+	// delete fill-blank question
+	logger.Infof("handle request delete fill-blank question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change fill-blank question")
+	if !nova.checkQuestionAuthor(c) {
 		return
 	}
-	logger.Debugf("successfully bind request json format")
-	// check request body correctness
-	// update data cache by querying single-choice questions in database
-	logger.Debugf("update data cache by querying single-choice questions in database")
-	err = nova.querySingleChoiceQuestionsInDatabase()
+	logger.Debugf("successfully check principal is allowed to change fill-blank question")
+	// extract fill-blank question id from uri
+	id := strings.ToLower(c.Param("Id"))
+	// request question Id correctness
+	logger.Debugf("check fill-blank question Id is validate")
+	if err := uuid.Validate(id); err != nil {
+		nova.response400BadRequest(c, errors.New("fill-blank question Id format incorrect"))
+		logger.Error("error fill-blank question Id is validate")
+		return
+	}
+	logger.Debugf("successfully check fill-blank question Id is validate")
+	// update data cache by querying fill-blank question in database
+	logger.Debugf("update data cache by querying fill-blank question in database")
+	err := nova.queryFillBlankQuestionsInDatabase()
 	if err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error update data cache by querying single-choice questions in database: %v", err)
+		logger.Errorf("error update data cache by querying fill-blank questions in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully update data cache by querying single-choice questions in database")
-	// check single-choice questions existence
-	logger.Debugf("check single-choice questions existence")
-	if !nova.isSingleChoiceQuestionExisted(strings.ToLower(request.Id)) {
-		nova.response403Forbidden(c, errors.New("forbidden replace single-choice question without create it"))
-		logger.Errorf("error check single-choice question existence")
+	logger.Debugf("successfully update data cache by querying fill-blank question in database")
+	// check fill-blank question existence
+	logger.Debugf("check fill-blank question is validate")
+	if !nova.isFillBlankQuestionExisted(id) {
+		nova.response404NotFound(c, errors.New("fill-blank question not found"))
+		logger.Error("error check fill-blank question is validate")
 		return
 	}
-	logger.Debugf("successfully check single-choice question existence")
-	// store updated single-choice question in data cache
-	logger.Debugf("update single-choice question in data cache")
-	response := QuestionSingleChoice{
-		Id:             strings.ToLower(request.Id),
-		Title:          request.Title,
-		Answers:        request.Answers,
-		StandardAnswer: request.StandardAnswer,
-	}
-	if b := nova.updateSingleChoiceQuestionInDataCache(response); !b {
-		nova.response404NotFound(c, errors.New("single-choice question not found"))
-		logger.Errorf("error update single-choice question in data cache")
+	logger.Debugf("successfully check fill-blank question is validate")
+	// delete fill-blank question from database
+	logger.Debugf("delete fill-blank question in database")
+	if err := nova.deleteFillBlankQuestionInDatabase(id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Error("error delete fill-blank question in database")
 		return
 	}
-	logger.Debugf("successfully update single-choice question in data cache")
-	// store update single-choice question in database
-	logger.Debugf("update single-choice question in database")
-	if err = nova.updateSingleChoiceQuestionInDatabase(response.Id); err != nil {
+	logger.Debugf("successfully delete fill-blank question in database")
+	// delete fill-blank question from data cache
+	logger.Debugf("delete fill-blank question in data cache")
+	nova.deleteFillBlankQuestionInDataCache(id)
+	// delete fill-blank question workflow in database
+	logger.Debugf("delete fill-blank question workflow in database")
+	if err := nova.deleteQuestionWorkflow(QuestionTypeFillBlank, id); err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error update single-choice question in database")
+		logger.Errorf("error delete fill-blank question workflow in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully update single-choice question in database")
+	logger.Debugf("successfully delete fill-blank question workflow in database")
 	// return response
-	nova.response200OK(c, response)
-	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	nova.response204NoContent(c, nil)
+	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, nil)
 	return
 }
 
-func (nova *Nova) HandleUpdateQuestionMultipleChoice(c *gin.Context) {
-	// update multiple-choice question
-	var request QuestionMultipleChoice
-	logger.Infof("handle request update multiple choice question")
-	// request body should bind json
-	logger.Debugf("request body bind json format")
-	err := c.ShouldBindJSON(&request)
-	if err != nil {
-		nova.response400BadRequest(c, err)
-		logger.Errorf("error bind request to json: %v", err)
+func (nova *Nova) HandleDeleteQuestionMatching(c *gin.Context) {
+	// delete matching question
+	logger.Infof("handle request delete matching question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change matching question")
+	if !nova.checkQuestionAuthor(c) {
 		return
 	}
-	logger.Debugf("successfully bind request json format")
-	// check request body correctness
-	// update data cache by querying multiple-choice questions in database
-	logger.Debugf("update data cache by querying multiple-choice questions in database")
-	err = nova.queryMultipleChoiceQuestionsInDatabase()
+	logger.Debugf("successfully check principal is allowed to change matching question")
+	// extract matching question id from uri
+	id := strings.ToLower(c.Param("Id"))
+	// request question Id correctness
+	logger.Debugf("check matching question Id is validate")
+	if err := uuid.Validate(id); err != nil {
+		nova.response400BadRequest(c, errors.New("matching question Id format incorrect"))
+		logger.Error("error matching question Id is validate")
+		return
+	}
+	logger.Debugf("successfully check matching question Id is validate")
+	// update data cache by querying matching question in database
+	logger.Debugf("update data cache by querying matching question in database")
+	err := nova.queryMatchingQuestionsInDatabase()
 	if err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error update data cache by querying multiple-choice questions in database: %v", err)
+		logger.Errorf("error update data cache by querying matching questions in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully update data cache by querying multiple-choice questions in database")
-	// check multiple-choice questions existence
-	logger.Debugf("check multiple-choice questions existence")
-	if !nova.isMultipleChoiceQuestionExisted(strings.ToLower(request.Id)) {
-		nova.response403Forbidden(c, errors.New("forbidden replace multiple-choice question without create it"))
-		logger.Errorf("error check multiple-choice question existence")
+	logger.Debugf("successfully update data cache by querying matching question in database")
+	// check matching question existence
+	logger.Debugf("check matching question is validate")
+	if !nova.isMatchingQuestionExisted(id) {
+		nova.response404NotFound(c, errors.New("matching question not found"))
+		logger.Error("error check matching question is validate")
 		return
 	}
-	logger.Debugf("successfully check multiple-choice question existence")
-	// store updated multiple-choice question in data cache
-	logger.Debugf("update multiple-choice question in data cache")
-	response := QuestionMultipleChoice{
-		Id:              strings.ToLower(request.Id),
-		Title:           request.Title,
-		Answers:         request.Answers,
-		StandardAnswers: request.StandardAnswers,
-	}
-	if b := nova.updateMultipleChoiceQuestionInDataCache(response); !b {
-		nova.response404NotFound(c, errors.New("multiple-choice question not found"))
-		logger.Errorf("error update multiple-choice question in data cache")
+	logger.Debugf("successfully check matching question is validate")
+	// delete matching question from database
+	logger.Debugf("delete matching question in database")
+	if err := nova.deleteMatchingQuestionInDatabase(id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Error("error delete matching question in database")
 		return
 	}
-	logger.Debugf("successfully update multiple-choice question in data cache")
-	// store update multiple-choice question in database
-	logger.Debugf("update multiple-choice question in database")
-	if err = nova.updateMultipleChoiceQuestionInDatabase(response.Id); err != nil {
+	logger.Debugf("successfully delete matching question in database")
+	// delete matching question from data cache
+	logger.Debugf("delete matching question in data cache")
+	nova.deleteMatchingQuestionInDataCache(id)
+	// delete matching question workflow in database
+	logger.Debugf("delete matching question workflow in database")
+	if err := nova.deleteQuestionWorkflow(QuestionTypeMatching, id); err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error update multiple-choice question in database")
+		logger.Errorf("error delete matching question workflow in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully update multiple-choice question in database")
+	logger.Debugf("successfully delete matching question workflow in database")
 	// return response
-	nova.response200OK(c, response)
-	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	nova.response204NoContent(c, nil)
+	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, nil)
 	return
 }
 
-func (nova *Nova) HandleUpdateQuestionJudgement(c *gin.Context) {
-	// update judgement question
-	var request QuestionJudgement
-	logger.Infof("handle request update judgement question")
-	// request body should bind json
-	logger.Debugf("request body bind json format")
-	err := c.ShouldBindJSON(&request)
-	if err != nil {
-		nova.response400BadRequest(c, err)
-		logger.Errorf("error bind request to json: %v", err)
+func (nova *Nova) HandleDeleteQuestionOrdering(c *gin.Context) {
+	// delete ordering question
+	logger.Infof("handle request delete ordering question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change ordering question")
+	if !nova.checkQuestionAuthor(c) {
 		return
 	}
-	logger.Debugf("successfully bind request json format")
-	// check request body correctness
-	// update data cache by querying judgement in database
-	logger.Debugf("update data cache by querying judgement in database")
-	err = nova.queryJudgementQuestionsInDatabase()
+	logger.Debugf("successfully check principal is allowed to change ordering question")
+	// extract ordering question id from uri
+	id := strings.ToLower(c.Param("Id"))
+	// request question Id correctness
+	logger.Debugf("check ordering question Id is validate")
+	if err := uuid.Validate(id); err != nil {
+		nova.response400BadRequest(c, errors.New("ordering question Id format incorrect"))
+		logger.Error("error ordering question Id is validate")
+		return
+	}
+	logger.Debugf("successfully check ordering question Id is validate")
+	// update data cache by querying ordering question in database
+	logger.Debugf("update data cache by querying ordering question in database")
+	err := nova.queryOrderingQuestionsInDatabase()
 	if err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error update data cache by querying judgement questions in database: %v", err)
+		logger.Errorf("error update data cache by querying ordering questions in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully update data cache by querying judgement questions in database")
-	// check judgement questions existence
-	logger.Debugf("check judgement questions existence")
-	if !nova.isJudgementQuestionExisted(strings.ToLower(request.Id)) {
-		nova.response403Forbidden(c, errors.New("forbidden replace judgement question without create it"))
-		logger.Errorf("error check judgement question existence")
+	logger.Debugf("successfully update data cache by querying ordering question in database")
+	// check ordering question existence
+	logger.Debugf("check ordering question is validate")
+	if !nova.isOrderingQuestionExisted(id) {
+		nova.response404NotFound(c, errors.New("ordering question not found"))
+		logger.Error("error check ordering question is validate")
 		return
 	}
-	logger.Debugf("successfully check judgement question existence")
-	// store updated judgement question in data cache
-	logger.Debugf("update judgement question in data cache")
-	response := QuestionJudgement{
-		Id:             strings.ToLower(request.Id),
-		Title:          request.Title,
-		Answer:         request.Answer,
-		StandardAnswer: request.StandardAnswer,
+	logger.Debugf("successfully check ordering question is validate")
+	// delete ordering question from database
+	logger.Debugf("delete ordering question in database")
+	if err := nova.deleteOrderingQuestionInDatabase(id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Error("error delete ordering question in database")
+		return
 	}
-	if b := nova.updateJudgementQuestionInDataCache(response); !b {
-		nova.response404NotFound(c, errors.New("judgement question not found"))
-		logger.Errorf("error update judgement question in data cache")
+	logger.Debugf("successfully delete ordering question in database")
+	// delete ordering question from data cache
+	logger.Debugf("delete ordering question in data cache")
+	nova.deleteOrderingQuestionInDataCache(id)
+	// delete ordering question workflow in database
+	logger.Debugf("delete ordering question workflow in database")
+	if err := nova.deleteQuestionWorkflow(QuestionTypeOrdering, id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error delete ordering question workflow in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully update judgement question in data cache")
-	// store update judgement question in database
-	logger.Debugf("update judgement question in database")
-	if err = nova.updateJudgementQuestionInDatabase(response.Id); err != nil {
+	logger.Debugf("successfully delete ordering question workflow in database")
+	// return response
+	nova.response204NoContent(c, nil)
+	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, nil)
+	return
+}
+
+func (nova *Nova) HandleDeleteQuestionNumeric(c *gin.Context) {
+	// delete numeric question
+	logger.Infof("handle request delete numeric question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change numeric question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change numeric question")
+	// extract numeric question id from uri
+	id := strings.ToLower(c.Param("Id"))
+	// request question Id correctness
+	logger.Debugf("check numeric question Id is validate")
+	if err := uuid.Validate(id); err != nil {
+		nova.response400BadRequest(c, errors.New("numeric question Id format incorrect"))
+		logger.Error("error numeric question Id is validate")
+		return
+	}
+	logger.Debugf("successfully check numeric question Id is validate")
+	// update data cache by querying numeric question in database
+	logger.Debugf("update data cache by querying numeric question in database")
+	err := nova.queryNumericQuestionsInDatabase()
+	if err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error update judgement question in database")
+		logger.Errorf("error update data cache by querying numeric questions in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully update judgement question in database")
+	logger.Debugf("successfully update data cache by querying numeric question in database")
+	// check numeric question existence
+	logger.Debugf("check numeric question is validate")
+	if !nova.isNumericQuestionExisted(id) {
+		nova.response404NotFound(c, errors.New("numeric question not found"))
+		logger.Error("error check numeric question is validate")
+		return
+	}
+	logger.Debugf("successfully check numeric question is validate")
+	// delete numeric question from database
+	logger.Debugf("delete numeric question in database")
+	if err := nova.deleteNumericQuestionInDatabase(id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Error("error delete numeric question in database")
+		return
+	}
+	logger.Debugf("successfully delete numeric question in database")
+	// delete numeric question from data cache
+	logger.Debugf("delete numeric question in data cache")
+	nova.deleteNumericQuestionInDataCache(id)
+	// delete numeric question workflow in database
+	logger.Debugf("delete numeric question workflow in database")
+	if err := nova.deleteQuestionWorkflow(QuestionTypeNumeric, id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error delete numeric question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully delete numeric question workflow in database")
+	// return response
+	nova.response204NoContent(c, nil)
+	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, nil)
+	return
+}
+
+func (nova *Nova) HandleDeleteQuestionTemplate(c *gin.Context) {
+	// delete template question
+	logger.Infof("handle request delete template question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change template question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change template question")
+	// extract template question id from uri
+	id := strings.ToLower(c.Param("Id"))
+	// request question Id correctness
+	logger.Debugf("check template question Id is validate")
+	if err := uuid.Validate(id); err != nil {
+		nova.response400BadRequest(c, errors.New("template question Id format incorrect"))
+		logger.Error("error template question Id is validate")
+		return
+	}
+	logger.Debugf("successfully check template question Id is validate")
+	// update data cache by querying template question in database
+	logger.Debugf("update data cache by querying template question in database")
+	err := nova.queryTemplateQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying template questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying template question in database")
+	// check template question existence
+	logger.Debugf("check template question is validate")
+	if !nova.isTemplateQuestionExisted(id) {
+		nova.response404NotFound(c, errors.New("template question not found"))
+		logger.Error("error check template question is validate")
+		return
+	}
+	logger.Debugf("successfully check template question is validate")
+	// delete template question from database
+	logger.Debugf("delete template question in database")
+	if err := nova.deleteTemplateQuestionInDatabase(id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Error("error delete template question in database")
+		return
+	}
+	logger.Debugf("successfully delete template question in database")
+	// delete template question from data cache
+	logger.Debugf("delete template question in data cache")
+	nova.deleteTemplateQuestionInDataCache(id)
+	// delete template question workflow in database
+	logger.Debugf("delete template question workflow in database")
+	if err := nova.deleteQuestionWorkflow(QuestionTypeTemplate, id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error delete template question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully delete template question workflow in database")
+	// return response
+	nova.response204NoContent(c, nil)
+	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, nil)
+	return
+}
+
+func (nova *Nova) HandleDeleteQuestionCode(c *gin.Context) {
+	// delete code question
+	logger.Infof("handle request delete code question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change code question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change code question")
+	// extract code question id from uri
+	id := strings.ToLower(c.Param("Id"))
+	// request question Id correctness
+	logger.Debugf("check code question Id is validate")
+	if err := uuid.Validate(id); err != nil {
+		nova.response400BadRequest(c, errors.New("code question Id format incorrect"))
+		logger.Error("error code question Id is validate")
+		return
+	}
+	logger.Debugf("successfully check code question Id is validate")
+	// update data cache by querying code question in database
+	logger.Debugf("update data cache by querying code question in database")
+	err := nova.queryCodeQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying code questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying code question in database")
+	// check code question existence
+	logger.Debugf("check code question is validate")
+	if !nova.isCodeQuestionExisted(id) {
+		nova.response404NotFound(c, errors.New("code question not found"))
+		logger.Error("error check code question is validate")
+		return
+	}
+	logger.Debugf("successfully check code question is validate")
+	// delete code question from database
+	logger.Debugf("delete code question in database")
+	if err := nova.deleteCodeQuestionInDatabase(id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Error("error delete code question in database")
+		return
+	}
+	logger.Debugf("successfully delete code question in database")
+	// delete code question from data cache
+	logger.Debugf("delete code question in data cache")
+	nova.deleteCodeQuestionInDataCache(id)
+	// delete code question workflow in database
+	logger.Debugf("delete code question workflow in database")
+	if err := nova.deleteQuestionWorkflow(QuestionTypeCode, id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error delete code question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully delete code question workflow in database")
+	// return response
+	nova.response204NoContent(c, nil)
+	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, nil)
+	return
+}
+
+func (nova *Nova) HandleModifyQuestionSingleChoice(c *gin.Context) {
+	// modify single-choice question
+	var request QuestionSingleChoice
+	logger.Infof("handle request modify single-choice question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change single-choice question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change single-choice question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check single-choice question is validate")
+	b, err := nova.isSingleChoiceQuestionValidate(request)
+	if !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check single-choice question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check single-choice question is validate")
+	// update data cache by querying single-choice questions in database
+	logger.Debugf("update data cache by querying single-choice question in database")
+	err = nova.querySingleChoiceQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying single-choice question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying single-choice question in database")
+	// check single-choice question existence
+	logger.Debugf("check single-choice question is existed")
+	if !nova.isSingleChoiceQuestionExisted(strings.ToLower(request.Id)) || !nova.isResourceVisible(c, QuestionTypeSingleChoice, strings.ToLower(request.Id)) {
+		nova.response404NotFound(c, errors.New("single-choice question not found"))
+		logger.Errorf("error check single-choice question is existed: %v", err)
+		return
+	}
+	logger.Debugf("successfully check single-choice question is existed")
+	// check single-choice question duplication
+	logger.Debugf("check single-choice question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeSingleChoice, strings.ToLower(request.Id), singleChoiceFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check single-choice question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check single-choice question is duplicated")
+	// query previous single-choice question in data cache
+	previous, _ := nova.querySingleChoiceQuestionInDataCache(strings.ToLower(request.Id))
+	// store modified single-choice question in data cache
+	logger.Debugf("store modify single-choice question in data cache")
+	response, err := nova.modifySingleChoiceQuestionInDataCache(request)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error store modify single-choice question in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully store modify single-choice question in data cache")
+	// store modified single-choice question in database
+	logger.Debugf("store modify single-choice question in database")
+	if err = nova.modifySingleChoiceQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store modify single-choice question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store modify single-choice question in database")
+	// store single-choice question revision in database
+	logger.Debugf("store single-choice question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeSingleChoice, response.Id, previous, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store single-choice question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store single-choice question revision in database")
+	// edited published single-choice question goes back to draft
+	logger.Debugf("store single-choice question workflow in database")
+	if err = nova.reviseQuestionWorkflow(c, QuestionTypeSingleChoice, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store single-choice question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store single-choice question workflow in database")
 	// return response
 	nova.response200OK(c, response)
 	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
 	return
 }
 
-func (nova *Nova) HandleUpdateQuestionEssay(c *gin.Context) {
-	// update essay question
-	var request QuestionEssay
-	logger.Infof("handle request update essay question")
+func (nova *Nova) HandleModifyQuestionMultipleChoice(c *gin.Context) {
+	// modify multiple-choice question
+	var request QuestionMultipleChoice
+	logger.Infof("handle request modify multiple-choice question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change multiple-choice question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change multiple-choice question")
 	// request body should bind json
 	logger.Debugf("request body bind json format")
-	err := c.ShouldBindJSON(&request)
-	if err != nil {
+	if err := c.ShouldBindJSON(&request); err != nil {
 		nova.response400BadRequest(c, err)
 		logger.Errorf("error bind request to json: %v", err)
 		return
 	}
 	logger.Debugf("successfully bind request json format")
 	// check request body correctness
-	// update data cache by querying essay in database
-	logger.Debugf("update data cache by querying essay in database")
-	err = nova.queryEssayQuestionsInDatabase()
+	logger.Debugf("check multiple-choice question is validate")
+	b, err := nova.isMultipleChoiceQuestionValidate(request)
+	if !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check multiple-choice question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check multiple-choice question is validate")
+	// update data cache by querying multiple-choice questions in database
+	logger.Debugf("update data cache by querying multiple-choice question in database")
+	err = nova.queryMultipleChoiceQuestionsInDatabase()
 	if err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error update data cache by querying essay questions in database: %v", err)
+		logger.Errorf("error update data cache by querying multiple-choice question in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully update data cache by querying essay questions in database")
-	// check essay questions existence
-	logger.Debugf("check essay questions existence")
-	if !nova.isEssayQuestionExisted(strings.ToLower(request.Id)) {
-		nova.response403Forbidden(c, errors.New("forbidden replace essay question without create it"))
-		logger.Errorf("error check essay question existence")
+	logger.Debugf("successfully update data cache by querying multiple-choice question in database")
+	// check multiple-choice question existence
+	logger.Debugf("check multiple-choice question is existed")
+	if !nova.isMultipleChoiceQuestionExisted(strings.ToLower(request.Id)) || !nova.isResourceVisible(c, QuestionTypeMultipleChoice, strings.ToLower(request.Id)) {
+		nova.response404NotFound(c, errors.New("multiple-choice question not found"))
+		logger.Errorf("error check multiple-choice question is existed: %v", err)
 		return
 	}
-	logger.Debugf("successfully check essay question existence")
-	// store updated judgement question in data cache
-	logger.Debugf("update judgement question in data cache")
-	response := QuestionEssay{
-		Id:             strings.ToLower(request.Id),
-		Title:          request.Title,
-		Answer:         request.Answer,
-		StandardAnswer: request.StandardAnswer,
+	logger.Debugf("successfully check multiple-choice question is existed")
+	// check multiple-choice question duplication
+	logger.Debugf("check multiple-choice question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeMultipleChoice, strings.ToLower(request.Id), multipleChoiceFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check multiple-choice question is duplicated: %v", err)
+		return
 	}
-	if b := nova.updateEssayQuestionInDataCache(response); !b {
-		nova.response404NotFound(c, errors.New("essay question not found"))
-		logger.Errorf("error update essay question in data cache")
+	logger.Debugf("successfully check multiple-choice question is duplicated")
+	// query previous multiple-choice question in data cache
+	previous, _ := nova.queryMultipleChoiceQuestionInDataCache(strings.ToLower(request.Id))
+	// store modified multiple-choice question in data cache
+	logger.Debugf("store modify single-choice question in data cache")
+	response, err := nova.modifyMultipleChoiceQuestionInDataCache(request)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error store modify multiple-choice question in data cache: %v", err)
 		return
 	}
-	logger.Debugf("successfully update essay question in data cache")
-	// store update essay question in database
-	logger.Debugf("update essay question in database")
-	if err = nova.updateEssayQuestionInDatabase(response.Id); err != nil {
+	logger.Debugf("successfully store modify multiple-choice question in data cache")
+	// store modified multiple-choice question in database
+	logger.Debugf("store modify multiple-choice question in database")
+	if err = nova.modifyMultipleChoiceQuestionInDatabase(response.Id); err != nil {
 		nova.response500InternalServerError(c, err)
-		logger.Errorf("error update essay question in database")
+		logger.Errorf("error store modify multiple-choice question in database: %v", err)
 		return
 	}
-	logger.Debugf("successfully update essay question in database")
+	logger.Debugf("successfully store modify multiple-choice question in database")
+	// store multiple-choice question revision in database
+	logger.Debugf("store multiple-choice question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeMultipleChoice, response.Id, previous, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store multiple-choice question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store multiple-choice question revision in database")
+	// edited published multiple-choice question goes back to draft
+	logger.Debugf("store multiple-choice question workflow in database")
+	if err = nova.reviseQuestionWorkflow(c, QuestionTypeMultipleChoice, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store multiple-choice question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store multiple-choice question workflow in database")
 	// return response
 	nova.response200OK(c, response)
 	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
 	return
 }
 
-func (nova *Nova) isSingleChoiceQuestionExisted(id string) bool {
-	// enable single-choice question cache read lock
-	nova.cache.questionsCache.singleChoiceCache.mutex.RLock()
-	defer nova.cache.questionsCache.singleChoiceCache.mutex.RUnlock()
-	// search Id in data cache
-	for _, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+func (nova *Nova) HandleModifyQuestionJudgement(c *gin.Context) {
+	// modify judgement question
+	var request QuestionJudgement
+	logger.Infof("handle request modify judgement question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change judgement question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change judgement question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check judgement question is validate")
+	b, err := nova.isJudgementQuestionValidate(request)
+	if !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check judgement question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check judgement question is validate")
+	// update data cache by querying judgement questions in database
+	logger.Debugf("update data cache by querying judgement question in database")
+	err = nova.queryJudgementQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying judgement question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying judgement question in database")
+	// check judgement question existence
+	logger.Debugf("check judgement question is existed")
+	if !nova.isJudgementQuestionExisted(strings.ToLower(request.Id)) || !nova.isResourceVisible(c, QuestionTypeJudgement, strings.ToLower(request.Id)) {
+		nova.response404NotFound(c, errors.New("judgement question not found"))
+		logger.Errorf("error check judgement question is existed: %v", err)
+		return
+	}
+	logger.Debugf("successfully check judgement question is existed")
+	// check judgement question duplication
+	logger.Debugf("check judgement question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeJudgement, strings.ToLower(request.Id), judgementFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check judgement question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check judgement question is duplicated")
+	// query previous judgement question in data cache
+	previous, _ := nova.queryJudgementQuestionInDataCache(strings.ToLower(request.Id))
+	// store modified judgement question in data cache
+	logger.Debugf("store modify judgement question in data cache")
+	response, err := nova.modifyJudgementQuestionInDataCache(request)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error store modify judgement question in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully store modify judgement question in data cache")
+	// store modified judgement question in database
+	logger.Debugf("store modify judgement question in database")
+	if err = nova.modifyJudgementQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store modify judgement question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store modify judgement question in database")
+	// store judgement question revision in database
+	logger.Debugf("store judgement question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeJudgement, response.Id, previous, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store judgement question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store judgement question revision in database")
+	// edited published judgement question goes back to draft
+	logger.Debugf("store judgement question workflow in database")
+	if err = nova.reviseQuestionWorkflow(c, QuestionTypeJudgement, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store judgement question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store judgement question workflow in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleModifyQuestionEssay(c *gin.Context) {
+	// modify essay question
+	var request QuestionEssay
+	logger.Infof("handle request modify essay question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change essay question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change essay question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check essay question is validate")
+	b, err := nova.isEssayQuestionValidate(request)
+	if !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check essay question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check essay question is validate")
+	// update data cache by querying essay questions in database
+	logger.Debugf("update data cache by querying essay question in database")
+	err = nova.queryEssayQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by essay judgement question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying essay question in database")
+	// check essay question existence
+	logger.Debugf("check essay question is existed")
+	if !nova.isEssayQuestionExisted(strings.ToLower(request.Id)) || !nova.isResourceVisible(c, QuestionTypeEssay, strings.ToLower(request.Id)) {
+		nova.response404NotFound(c, errors.New("essay question not found"))
+		logger.Errorf("error check essay question is existed: %v", err)
+		return
+	}
+	logger.Debugf("successfully check essay question is existed")
+	// check essay question duplication
+	logger.Debugf("check essay question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeEssay, strings.ToLower(request.Id), essayFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check essay question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check essay question is duplicated")
+	// query previous essay question in data cache
+	previous, _ := nova.queryEssayQuestionInDataCache(strings.ToLower(request.Id))
+	// store modified essay question in data cache
+	logger.Debugf("store modify essay question in data cache")
+	response, err := nova.modifyEssayQuestionInDataCache(request)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error store modify essay question in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully store modify essay question in data cache")
+	// store modified essay question in database
+	logger.Debugf("store modify essay question in database")
+	if err = nova.modifyEssayQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store modify essay question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store modify essay question in database")
+	// store essay question revision in database
+	logger.Debugf("store essay question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeEssay, response.Id, previous, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store essay question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store essay question revision in database")
+	// edited published essay question goes back to draft
+	logger.Debugf("store essay question workflow in database")
+	if err = nova.reviseQuestionWorkflow(c, QuestionTypeEssay, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store essay question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store essay question workflow in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleModifyQuestionFillBlank(c *gin.Context) {
+	// modify fill-blank question
+	var request QuestionFillBlank
+	logger.Infof("handle request modify fill-blank question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change fill-blank question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change fill-blank question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check fill-blank question is validate")
+	b, err := nova.isFillBlankQuestionValidate(request)
+	if !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check fill-blank question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check fill-blank question is validate")
+	// update data cache by querying fill-blank questions in database
+	logger.Debugf("update data cache by querying fill-blank question in database")
+	err = nova.queryFillBlankQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by fill-blank judgement question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying fill-blank question in database")
+	// check fill-blank question existence
+	logger.Debugf("check fill-blank question is existed")
+	if !nova.isFillBlankQuestionExisted(strings.ToLower(request.Id)) || !nova.isResourceVisible(c, QuestionTypeFillBlank, strings.ToLower(request.Id)) {
+		nova.response404NotFound(c, errors.New("fill-blank question not found"))
+		logger.Errorf("error check fill-blank question is existed: %v", err)
+		return
+	}
+	logger.Debugf("successfully check fill-blank question is existed")
+	// check fill-blank question duplication
+	logger.Debugf("check fill-blank question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeFillBlank, strings.ToLower(request.Id), fillBlankFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check fill-blank question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check fill-blank question is duplicated")
+	// query previous fill-blank question in data cache
+	previous, _ := nova.queryFillBlankQuestionInDataCache(strings.ToLower(request.Id))
+	// store modified fill-blank question in data cache
+	logger.Debugf("store modify fill-blank question in data cache")
+	response, err := nova.modifyFillBlankQuestionInDataCache(request)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error store modify fill-blank question in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully store modify fill-blank question in data cache")
+	// store modified fill-blank question in database
+	logger.Debugf("store modify fill-blank question in database")
+	if err = nova.modifyFillBlankQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store modify fill-blank question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store modify fill-blank question in database")
+	// store fill-blank question revision in database
+	logger.Debugf("store fill-blank question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeFillBlank, response.Id, previous, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store fill-blank question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store fill-blank question revision in database")
+	// edited published fill-blank question goes back to draft
+	logger.Debugf("store fill-blank question workflow in database")
+	if err = nova.reviseQuestionWorkflow(c, QuestionTypeFillBlank, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store fill-blank question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store fill-blank question workflow in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleModifyQuestionMatching(c *gin.Context) {
+	// modify matching question
+	var request QuestionMatching
+	logger.Infof("handle request modify matching question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change matching question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change matching question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check matching question is validate")
+	b, err := nova.isMatchingQuestionValidate(request)
+	if !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check matching question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check matching question is validate")
+	// update data cache by querying matching questions in database
+	logger.Debugf("update data cache by querying matching question in database")
+	err = nova.queryMatchingQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by matching judgement question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying matching question in database")
+	// check matching question existence
+	logger.Debugf("check matching question is existed")
+	if !nova.isMatchingQuestionExisted(strings.ToLower(request.Id)) || !nova.isResourceVisible(c, QuestionTypeMatching, strings.ToLower(request.Id)) {
+		nova.response404NotFound(c, errors.New("matching question not found"))
+		logger.Errorf("error check matching question is existed: %v", err)
+		return
+	}
+	logger.Debugf("successfully check matching question is existed")
+	// check matching question duplication
+	logger.Debugf("check matching question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeMatching, strings.ToLower(request.Id), matchingFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check matching question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check matching question is duplicated")
+	// query previous matching question in data cache
+	previous, _ := nova.queryMatchingQuestionInDataCache(strings.ToLower(request.Id))
+	// store modified matching question in data cache
+	logger.Debugf("store modify matching question in data cache")
+	response, err := nova.modifyMatchingQuestionInDataCache(request)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error store modify matching question in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully store modify matching question in data cache")
+	// store modified matching question in database
+	logger.Debugf("store modify matching question in database")
+	if err = nova.modifyMatchingQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store modify matching question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store modify matching question in database")
+	// store matching question revision in database
+	logger.Debugf("store matching question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeMatching, response.Id, previous, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store matching question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store matching question revision in database")
+	// edited published matching question goes back to draft
+	logger.Debugf("store matching question workflow in database")
+	if err = nova.reviseQuestionWorkflow(c, QuestionTypeMatching, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store matching question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store matching question workflow in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleModifyQuestionOrdering(c *gin.Context) {
+	// modify ordering question
+	var request QuestionOrdering
+	logger.Infof("handle request modify ordering question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change ordering question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change ordering question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check ordering question is validate")
+	b, err := nova.isOrderingQuestionValidate(request)
+	if !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check ordering question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check ordering question is validate")
+	// update data cache by querying ordering questions in database
+	logger.Debugf("update data cache by querying ordering question in database")
+	err = nova.queryOrderingQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by ordering judgement question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying ordering question in database")
+	// check ordering question existence
+	logger.Debugf("check ordering question is existed")
+	if !nova.isOrderingQuestionExisted(strings.ToLower(request.Id)) || !nova.isResourceVisible(c, QuestionTypeOrdering, strings.ToLower(request.Id)) {
+		nova.response404NotFound(c, errors.New("ordering question not found"))
+		logger.Errorf("error check ordering question is existed: %v", err)
+		return
+	}
+	logger.Debugf("successfully check ordering question is existed")
+	// check ordering question duplication
+	logger.Debugf("check ordering question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeOrdering, strings.ToLower(request.Id), orderingFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check ordering question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check ordering question is duplicated")
+	// query previous ordering question in data cache
+	previous, _ := nova.queryOrderingQuestionInDataCache(strings.ToLower(request.Id))
+	// store modified ordering question in data cache
+	logger.Debugf("store modify ordering question in data cache")
+	response, err := nova.modifyOrderingQuestionInDataCache(request)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error store modify ordering question in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully store modify ordering question in data cache")
+	// store modified ordering question in database
+	logger.Debugf("store modify ordering question in database")
+	if err = nova.modifyOrderingQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store modify ordering question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store modify ordering question in database")
+	// store ordering question revision in database
+	logger.Debugf("store ordering question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeOrdering, response.Id, previous, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store ordering question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store ordering question revision in database")
+	// edited published ordering question goes back to draft
+	logger.Debugf("store ordering question workflow in database")
+	if err = nova.reviseQuestionWorkflow(c, QuestionTypeOrdering, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store ordering question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store ordering question workflow in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleModifyQuestionNumeric(c *gin.Context) {
+	// modify numeric question
+	var request QuestionNumeric
+	logger.Infof("handle request modify numeric question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change numeric question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change numeric question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check numeric question is validate")
+	b, err := nova.isNumericQuestionValidate(request)
+	if !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check numeric question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check numeric question is validate")
+	// update data cache by querying numeric questions in database
+	logger.Debugf("update data cache by querying numeric question in database")
+	err = nova.queryNumericQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by numeric judgement question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying numeric question in database")
+	// check numeric question existence
+	logger.Debugf("check numeric question is existed")
+	if !nova.isNumericQuestionExisted(strings.ToLower(request.Id)) || !nova.isResourceVisible(c, QuestionTypeNumeric, strings.ToLower(request.Id)) {
+		nova.response404NotFound(c, errors.New("numeric question not found"))
+		logger.Errorf("error check numeric question is existed: %v", err)
+		return
+	}
+	logger.Debugf("successfully check numeric question is existed")
+	// check numeric question duplication
+	logger.Debugf("check numeric question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeNumeric, strings.ToLower(request.Id), numericFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check numeric question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check numeric question is duplicated")
+	// query previous numeric question in data cache
+	previous, _ := nova.queryNumericQuestionInDataCache(strings.ToLower(request.Id))
+	// store modified numeric question in data cache
+	logger.Debugf("store modify numeric question in data cache")
+	response, err := nova.modifyNumericQuestionInDataCache(request)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error store modify numeric question in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully store modify numeric question in data cache")
+	// store modified numeric question in database
+	logger.Debugf("store modify numeric question in database")
+	if err = nova.modifyNumericQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store modify numeric question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store modify numeric question in database")
+	// store numeric question revision in database
+	logger.Debugf("store numeric question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeNumeric, response.Id, previous, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store numeric question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store numeric question revision in database")
+	// edited published numeric question goes back to draft
+	logger.Debugf("store numeric question workflow in database")
+	if err = nova.reviseQuestionWorkflow(c, QuestionTypeNumeric, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store numeric question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store numeric question workflow in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleModifyQuestionTemplate(c *gin.Context) {
+	// modify template question
+	var request QuestionTemplate
+	logger.Infof("handle request modify template question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change template question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change template question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check template question is validate")
+	b, err := nova.isTemplateQuestionValidate(request)
+	if !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check template question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check template question is validate")
+	// update data cache by querying template questions in database
+	logger.Debugf("update data cache by querying template question in database")
+	err = nova.queryTemplateQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by template judgement question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying template question in database")
+	// check template question existence
+	logger.Debugf("check template question is existed")
+	if !nova.isTemplateQuestionExisted(strings.ToLower(request.Id)) || !nova.isResourceVisible(c, QuestionTypeTemplate, strings.ToLower(request.Id)) {
+		nova.response404NotFound(c, errors.New("template question not found"))
+		logger.Errorf("error check template question is existed: %v", err)
+		return
+	}
+	logger.Debugf("successfully check template question is existed")
+	// check template question duplication
+	logger.Debugf("check template question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeTemplate, strings.ToLower(request.Id), templateFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check template question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check template question is duplicated")
+	// query previous template question in data cache
+	previous, _ := nova.queryTemplateQuestionInDataCache(strings.ToLower(request.Id))
+	// store modified template question in data cache
+	logger.Debugf("store modify template question in data cache")
+	response, err := nova.modifyTemplateQuestionInDataCache(request)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error store modify template question in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully store modify template question in data cache")
+	// store modified template question in database
+	logger.Debugf("store modify template question in database")
+	if err = nova.modifyTemplateQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store modify template question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store modify template question in database")
+	// store template question revision in database
+	logger.Debugf("store template question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeTemplate, response.Id, previous, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store template question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store template question revision in database")
+	// edited published template question goes back to draft
+	logger.Debugf("store template question workflow in database")
+	if err = nova.reviseQuestionWorkflow(c, QuestionTypeTemplate, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store template question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store template question workflow in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleModifyQuestionCode(c *gin.Context) {
+	// modify code question
+	var request QuestionCode
+	logger.Infof("handle request modify code question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change code question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change code question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check code question is validate")
+	b, err := nova.isCodeQuestionValidate(request)
+	if !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check code question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check code question is validate")
+	// update data cache by querying code questions in database
+	logger.Debugf("update data cache by querying code question in database")
+	err = nova.queryCodeQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by code judgement question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying code question in database")
+	// check code question existence
+	logger.Debugf("check code question is existed")
+	if !nova.isCodeQuestionExisted(strings.ToLower(request.Id)) || !nova.isResourceVisible(c, QuestionTypeCode, strings.ToLower(request.Id)) {
+		nova.response404NotFound(c, errors.New("code question not found"))
+		logger.Errorf("error check code question is existed: %v", err)
+		return
+	}
+	logger.Debugf("successfully check code question is existed")
+	// check code question duplication
+	logger.Debugf("check code question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeCode, strings.ToLower(request.Id), codeFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check code question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check code question is duplicated")
+	// query previous code question in data cache
+	previous, _ := nova.queryCodeQuestionInDataCache(strings.ToLower(request.Id))
+	// store modified code question in data cache
+	logger.Debugf("store modify code question in data cache")
+	response, err := nova.modifyCodeQuestionInDataCache(request)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error store modify code question in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully store modify code question in data cache")
+	// store modified code question in database
+	logger.Debugf("store modify code question in database")
+	if err = nova.modifyCodeQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store modify code question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store modify code question in database")
+	// store code question revision in database
+	logger.Debugf("store code question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeCode, response.Id, previous, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store code question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store code question revision in database")
+	// edited published code question goes back to draft
+	logger.Debugf("store code question workflow in database")
+	if err = nova.reviseQuestionWorkflow(c, QuestionTypeCode, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store code question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store code question workflow in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionSingleChoice(c *gin.Context) {
+	// query question single-choice
+	logger.Infof("handle request query single-choice question")
+	// extract questionId from uri
+	id := strings.ToLower(c.Param("Id"))
+	// request questionId correctness
+	logger.Debugf("check questionId is validate")
+	if b, _ := nova.isQuestionIdValidate(id); !b {
+		nova.response400BadRequest(c, errors.New("questionId format incorrect"))
+		logger.Errorf("error check questionId is validate")
+		return
+	}
+	logger.Debugf("successfully check questionId is validate")
+	// update data cache by querying single-choice questions in database
+	logger.Debugf("update data cache by querying single-choice questions in database")
+	err := nova.querySingleChoiceQuestionInDatabase(id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying single-choice questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying single-choice questions in database")
+	// check single-choice question existence
+	logger.Debugf("check single-choice question is existed")
+	if !nova.isSingleChoiceQuestionExisted(id) {
+		nova.response404NotFound(c, errors.New("single-choice question not found"))
+		logger.Errorf("error check single-choice question is existed")
+		return
+	}
+	logger.Debugf("successfully check single-choice question is existed")
+	// query single-choice question from database
+	logger.Debugf("query single-choice question in database")
+	if err := nova.querySingleChoiceQuestionInDatabase(id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query single-choice question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query single-choice question in database")
+	// query single-choice question from data cache
+	logger.Debugf("query single-choice question in data cache")
+	response, err := nova.querySingleChoiceQuestionInDataCache(id)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query single-choice question in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully query single-choice question in data cache")
+	// check single-choice question is visible to principal
+	logger.Debugf("check single-choice question is visible")
+	if !nova.isQuestionVisible(c, QuestionTypeSingleChoice, id) {
+		nova.response404NotFound(c, errors.New("single-choice question not found"))
+		logger.Errorf("error check single-choice question is visible")
+		return
+	}
+	logger.Debugf("successfully check single-choice question is visible")
+	// answer keys are only shown to staff
+	if nova.isQuestionAnswerHidden(c) {
+		response = hideQuestionAnswers(response).(QuestionSingleChoice)
+	}
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionMultipleChoice(c *gin.Context) {
+	// query question multiple-choice
+	logger.Infof("handle request query multiple-choice question")
+	// extract questionId from uri
+	id := strings.ToLower(c.Param("Id"))
+	// request questionId correctness
+	logger.Debugf("check questionId is validate")
+	if b, _ := nova.isQuestionIdValidate(id); !b {
+		nova.response400BadRequest(c, errors.New("questionId format incorrect"))
+		logger.Errorf("error check questionId is validate")
+		return
+	}
+	logger.Debugf("successfully check questionId is validate")
+	// update data cache by querying multiple-choice questions in database
+	logger.Debugf("update data cache by querying multiple-choice questions in database")
+	err := nova.queryMultipleChoiceQuestionInDatabase(id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying multiple-choice questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying multiple-choice questions in database")
+	// check multiple-choice question existence
+	logger.Debugf("check multiple-choice question is existed")
+	if !nova.isMultipleChoiceQuestionExisted(id) {
+		nova.response404NotFound(c, errors.New("multiple-choice question not found"))
+		logger.Errorf("error check multiple-choice question is existed")
+		return
+	}
+	logger.Debugf("successfully check multiple-choice question is existed")
+	// query multiple-choice question from database
+	logger.Debugf("query multiple-choice question in database")
+	if err := nova.queryMultipleChoiceQuestionInDatabase(id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query multiple-choice question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query multiple-choice question in database")
+	// query multiple-choice question from data cache
+	logger.Debugf("query multiple-choice question in data cache")
+	response, err := nova.queryMultipleChoiceQuestionInDataCache(id)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query multiple-choice question in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully query multiple-choice question in data cache")
+	// check multiple-choice question is visible to principal
+	logger.Debugf("check multiple-choice question is visible")
+	if !nova.isQuestionVisible(c, QuestionTypeMultipleChoice, id) {
+		nova.response404NotFound(c, errors.New("multiple-choice question not found"))
+		logger.Errorf("error check multiple-choice question is visible")
+		return
+	}
+	logger.Debugf("successfully check multiple-choice question is visible")
+	// answer keys are only shown to staff
+	if nova.isQuestionAnswerHidden(c) {
+		response = hideQuestionAnswers(response).(QuestionMultipleChoice)
+	}
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionJudgement(c *gin.Context) {
+	// query question judgement
+	logger.Infof("handle request query judgement question")
+	// extract questionId from uri
+	id := strings.ToLower(c.Param("Id"))
+	// request questionId correctness
+	logger.Debugf("check questionId is validate")
+	if b, _ := nova.isQuestionIdValidate(id); !b {
+		nova.response400BadRequest(c, errors.New("questionId format incorrect"))
+		logger.Errorf("error check questionId is validate")
+		return
+	}
+	logger.Debugf("successfully check questionId is validate")
+	// update data cache by querying judgement questions in database
+	logger.Debugf("update data cache by querying judgement questions in database")
+	err := nova.queryJudgementQuestionInDatabase(id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying judgement questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying judgement questions in database")
+	// check judgement question existence
+	logger.Debugf("check judgement question is existed")
+	if !nova.isJudgementQuestionExisted(id) {
+		nova.response404NotFound(c, errors.New("judgement question not found"))
+		logger.Errorf("error check judgement question is existed")
+		return
+	}
+	logger.Debugf("successfully check judgement question is existed")
+	// query judgement question from database
+	logger.Debugf("query judgement question in database")
+	if err := nova.queryJudgementQuestionInDatabase(id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query judgement question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query judgement question in database")
+	// query judgement question from data cache
+	logger.Debugf("query judgement question in data cache")
+	response, err := nova.queryJudgementQuestionInDataCache(id)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query judgement question in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully query judgement question in data cache")
+	// check judgement question is visible to principal
+	logger.Debugf("check judgement question is visible")
+	if !nova.isQuestionVisible(c, QuestionTypeJudgement, id) {
+		nova.response404NotFound(c, errors.New("judgement question not found"))
+		logger.Errorf("error check judgement question is visible")
+		return
+	}
+	logger.Debugf("successfully check judgement question is visible")
+	// answer keys are only shown to staff
+	if nova.isQuestionAnswerHidden(c) {
+		response = hideQuestionAnswers(response).(QuestionJudgement)
+	}
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionEssay(c *gin.Context) {
+	// query question essay
+	logger.Infof("handle request query essay question")
+	// extract questionId from uri
+	id := strings.ToLower(c.Param("Id"))
+	// request questionId correctness
+	logger.Debugf("check questionId is validate")
+	if b, _ := nova.isQuestionIdValidate(id); !b {
+		nova.response400BadRequest(c, errors.New("questionId format incorrect"))
+		logger.Errorf("error check questionId is validate")
+		return
+	}
+	logger.Debugf("successfully check questionId is validate")
+	// update data cache by querying essay questions in database
+	logger.Debugf("update data cache by querying essay questions in database")
+	err := nova.queryEssayQuestionInDatabase(id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying essay questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying essay questions in database")
+	// check essay question existence
+	logger.Debugf("check essay question is existed")
+	if !nova.isEssayQuestionExisted(id) {
+		nova.response404NotFound(c, errors.New("essay question not found"))
+		logger.Errorf("error check essay question is existed")
+		return
+	}
+	logger.Debugf("successfully check essay question is existed")
+	// query essay question from database
+	logger.Debugf("query essay question in database")
+	if err := nova.queryEssayQuestionInDatabase(id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query essay question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query essay question in database")
+	// query essay question from data cache
+	logger.Debugf("query essay question in data cache")
+	response, err := nova.queryEssayQuestionInDataCache(id)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query essay question in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully query essay question in data cache")
+	// check essay question is visible to principal
+	logger.Debugf("check essay question is visible")
+	if !nova.isQuestionVisible(c, QuestionTypeEssay, id) {
+		nova.response404NotFound(c, errors.New("essay question not found"))
+		logger.Errorf("error check essay question is visible")
+		return
+	}
+	logger.Debugf("successfully check essay question is visible")
+	// answer keys are only shown to staff
+	if nova.isQuestionAnswerHidden(c) {
+		response = hideQuestionAnswers(response).(QuestionEssay)
+	}
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionFillBlank(c *gin.Context) {
+	// query question fill-blank
+	logger.Infof("handle request query fill-blank question")
+	// extract questionId from uri
+	id := strings.ToLower(c.Param("Id"))
+	// request questionId correctness
+	logger.Debugf("check questionId is validate")
+	if b, _ := nova.isQuestionIdValidate(id); !b {
+		nova.response400BadRequest(c, errors.New("questionId format incorrect"))
+		logger.Errorf("error check questionId is validate")
+		return
+	}
+	logger.Debugf("successfully check questionId is validate")
+	// update data cache by querying fill-blank questions in database
+	logger.Debugf("update data cache by querying fill-blank questions in database")
+	err := nova.queryFillBlankQuestionInDatabase(id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying fill-blank questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying fill-blank questions in database")
+	// check fill-blank question existence
+	logger.Debugf("check fill-blank question is existed")
+	if !nova.isFillBlankQuestionExisted(id) {
+		nova.response404NotFound(c, errors.New("fill-blank question not found"))
+		logger.Errorf("error check fill-blank question is existed")
+		return
+	}
+	logger.Debugf("successfully check fill-blank question is existed")
+	// query fill-blank question from database
+	logger.Debugf("query fill-blank question in database")
+	if err := nova.queryFillBlankQuestionInDatabase(id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query fill-blank question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query fill-blank question in database")
+	// query fill-blank question from data cache
+	logger.Debugf("query fill-blank question in data cache")
+	response, err := nova.queryFillBlankQuestionInDataCache(id)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query fill-blank question in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully query fill-blank question in data cache")
+	// check fill-blank question is visible to principal
+	logger.Debugf("check fill-blank question is visible")
+	if !nova.isQuestionVisible(c, QuestionTypeFillBlank, id) {
+		nova.response404NotFound(c, errors.New("fill-blank question not found"))
+		logger.Errorf("error check fill-blank question is visible")
+		return
+	}
+	logger.Debugf("successfully check fill-blank question is visible")
+	// answer keys are only shown to staff
+	if nova.isQuestionAnswerHidden(c) {
+		response = hideQuestionAnswers(response).(QuestionFillBlank)
+	}
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionMatching(c *gin.Context) {
+	// query question matching
+	logger.Infof("handle request query matching question")
+	// extract questionId from uri
+	id := strings.ToLower(c.Param("Id"))
+	// request questionId correctness
+	logger.Debugf("check questionId is validate")
+	if b, _ := nova.isQuestionIdValidate(id); !b {
+		nova.response400BadRequest(c, errors.New("questionId format incorrect"))
+		logger.Errorf("error check questionId is validate")
+		return
+	}
+	logger.Debugf("successfully check questionId is validate")
+	// update data cache by querying matching questions in database
+	logger.Debugf("update data cache by querying matching questions in database")
+	err := nova.queryMatchingQuestionInDatabase(id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying matching questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying matching questions in database")
+	// check matching question existence
+	logger.Debugf("check matching question is existed")
+	if !nova.isMatchingQuestionExisted(id) {
+		nova.response404NotFound(c, errors.New("matching question not found"))
+		logger.Errorf("error check matching question is existed")
+		return
+	}
+	logger.Debugf("successfully check matching question is existed")
+	// query matching question from database
+	logger.Debugf("query matching question in database")
+	if err := nova.queryMatchingQuestionInDatabase(id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query matching question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query matching question in database")
+	// query matching question from data cache
+	logger.Debugf("query matching question in data cache")
+	response, err := nova.queryMatchingQuestionInDataCache(id)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query matching question in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully query matching question in data cache")
+	// check matching question is visible to principal
+	logger.Debugf("check matching question is visible")
+	if !nova.isQuestionVisible(c, QuestionTypeMatching, id) {
+		nova.response404NotFound(c, errors.New("matching question not found"))
+		logger.Errorf("error check matching question is visible")
+		return
+	}
+	logger.Debugf("successfully check matching question is visible")
+	// answer keys are only shown to staff
+	if nova.isQuestionAnswerHidden(c) {
+		response = hideQuestionAnswers(response).(QuestionMatching)
+	}
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionOrdering(c *gin.Context) {
+	// query question ordering
+	logger.Infof("handle request query ordering question")
+	// extract questionId from uri
+	id := strings.ToLower(c.Param("Id"))
+	// request questionId correctness
+	logger.Debugf("check questionId is validate")
+	if b, _ := nova.isQuestionIdValidate(id); !b {
+		nova.response400BadRequest(c, errors.New("questionId format incorrect"))
+		logger.Errorf("error check questionId is validate")
+		return
+	}
+	logger.Debugf("successfully check questionId is validate")
+	// update data cache by querying ordering questions in database
+	logger.Debugf("update data cache by querying ordering questions in database")
+	err := nova.queryOrderingQuestionInDatabase(id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying ordering questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying ordering questions in database")
+	// check ordering question existence
+	logger.Debugf("check ordering question is existed")
+	if !nova.isOrderingQuestionExisted(id) {
+		nova.response404NotFound(c, errors.New("ordering question not found"))
+		logger.Errorf("error check ordering question is existed")
+		return
+	}
+	logger.Debugf("successfully check ordering question is existed")
+	// query ordering question from database
+	logger.Debugf("query ordering question in database")
+	if err := nova.queryOrderingQuestionInDatabase(id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query ordering question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query ordering question in database")
+	// query ordering question from data cache
+	logger.Debugf("query ordering question in data cache")
+	response, err := nova.queryOrderingQuestionInDataCache(id)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query ordering question in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully query ordering question in data cache")
+	// check ordering question is visible to principal
+	logger.Debugf("check ordering question is visible")
+	if !nova.isQuestionVisible(c, QuestionTypeOrdering, id) {
+		nova.response404NotFound(c, errors.New("ordering question not found"))
+		logger.Errorf("error check ordering question is visible")
+		return
+	}
+	logger.Debugf("successfully check ordering question is visible")
+	// answer keys are only shown to staff
+	if nova.isQuestionAnswerHidden(c) {
+		response = hideQuestionAnswers(response).(QuestionOrdering)
+	}
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionNumeric(c *gin.Context) {
+	// query question numeric
+	logger.Infof("handle request query numeric question")
+	// extract questionId from uri
+	id := strings.ToLower(c.Param("Id"))
+	// request questionId correctness
+	logger.Debugf("check questionId is validate")
+	if b, _ := nova.isQuestionIdValidate(id); !b {
+		nova.response400BadRequest(c, errors.New("questionId format incorrect"))
+		logger.Errorf("error check questionId is validate")
+		return
+	}
+	logger.Debugf("successfully check questionId is validate")
+	// update data cache by querying numeric questions in database
+	logger.Debugf("update data cache by querying numeric questions in database")
+	err := nova.queryNumericQuestionInDatabase(id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying numeric questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying numeric questions in database")
+	// check numeric question existence
+	logger.Debugf("check numeric question is existed")
+	if !nova.isNumericQuestionExisted(id) {
+		nova.response404NotFound(c, errors.New("numeric question not found"))
+		logger.Errorf("error check numeric question is existed")
+		return
+	}
+	logger.Debugf("successfully check numeric question is existed")
+	// query numeric question from database
+	logger.Debugf("query numeric question in database")
+	if err := nova.queryNumericQuestionInDatabase(id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query numeric question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query numeric question in database")
+	// query numeric question from data cache
+	logger.Debugf("query numeric question in data cache")
+	response, err := nova.queryNumericQuestionInDataCache(id)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query numeric question in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully query numeric question in data cache")
+	// check numeric question is visible to principal
+	logger.Debugf("check numeric question is visible")
+	if !nova.isQuestionVisible(c, QuestionTypeNumeric, id) {
+		nova.response404NotFound(c, errors.New("numeric question not found"))
+		logger.Errorf("error check numeric question is visible")
+		return
+	}
+	logger.Debugf("successfully check numeric question is visible")
+	// answer keys are only shown to staff
+	if nova.isQuestionAnswerHidden(c) {
+		response = hideQuestionAnswers(response).(QuestionNumeric)
+	}
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionTemplate(c *gin.Context) {
+	// query question template
+	logger.Infof("handle request query template question")
+	// extract questionId from uri
+	id := strings.ToLower(c.Param("Id"))
+	// request questionId correctness
+	logger.Debugf("check questionId is validate")
+	if b, _ := nova.isQuestionIdValidate(id); !b {
+		nova.response400BadRequest(c, errors.New("questionId format incorrect"))
+		logger.Errorf("error check questionId is validate")
+		return
+	}
+	logger.Debugf("successfully check questionId is validate")
+	// update data cache by querying template questions in database
+	logger.Debugf("update data cache by querying template questions in database")
+	err := nova.queryTemplateQuestionInDatabase(id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying template questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying template questions in database")
+	// check template question existence
+	logger.Debugf("check template question is existed")
+	if !nova.isTemplateQuestionExisted(id) {
+		nova.response404NotFound(c, errors.New("template question not found"))
+		logger.Errorf("error check template question is existed")
+		return
+	}
+	logger.Debugf("successfully check template question is existed")
+	// query template question from database
+	logger.Debugf("query template question in database")
+	if err := nova.queryTemplateQuestionInDatabase(id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query template question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query template question in database")
+	// query template question from data cache
+	logger.Debugf("query template question in data cache")
+	response, err := nova.queryTemplateQuestionInDataCache(id)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query template question in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully query template question in data cache")
+	// check template question is visible to principal
+	logger.Debugf("check template question is visible")
+	if !nova.isQuestionVisible(c, QuestionTypeTemplate, id) {
+		nova.response404NotFound(c, errors.New("template question not found"))
+		logger.Errorf("error check template question is visible")
+		return
+	}
+	logger.Debugf("successfully check template question is visible")
+	// answer keys are only shown to staff
+	if nova.isQuestionAnswerHidden(c) {
+		response = hideQuestionAnswers(response).(QuestionTemplate)
+	}
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionCode(c *gin.Context) {
+	// query question code
+	logger.Infof("handle request query code question")
+	// extract questionId from uri
+	id := strings.ToLower(c.Param("Id"))
+	// request questionId correctness
+	logger.Debugf("check questionId is validate")
+	if b, _ := nova.isQuestionIdValidate(id); !b {
+		nova.response400BadRequest(c, errors.New("questionId format incorrect"))
+		logger.Errorf("error check questionId is validate")
+		return
+	}
+	logger.Debugf("successfully check questionId is validate")
+	// update data cache by querying code questions in database
+	logger.Debugf("update data cache by querying code questions in database")
+	err := nova.queryCodeQuestionInDatabase(id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying code questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying code questions in database")
+	// check code question existence
+	logger.Debugf("check code question is existed")
+	if !nova.isCodeQuestionExisted(id) {
+		nova.response404NotFound(c, errors.New("code question not found"))
+		logger.Errorf("error check code question is existed")
+		return
+	}
+	logger.Debugf("successfully check code question is existed")
+	// query code question from database
+	logger.Debugf("query code question in database")
+	if err := nova.queryCodeQuestionInDatabase(id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query code question in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query code question in database")
+	// query code question from data cache
+	logger.Debugf("query code question in data cache")
+	response, err := nova.queryCodeQuestionInDataCache(id)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query code question in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully query code question in data cache")
+	// check code question is visible to principal
+	logger.Debugf("check code question is visible")
+	if !nova.isQuestionVisible(c, QuestionTypeCode, id) {
+		nova.response404NotFound(c, errors.New("code question not found"))
+		logger.Errorf("error check code question is visible")
+		return
+	}
+	logger.Debugf("successfully check code question is visible")
+	// answer keys are only shown to staff
+	if nova.isQuestionAnswerHidden(c) {
+		response = hideQuestionAnswers(response).(QuestionCode)
+	}
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleUpdateQuestionSingleChoice(c *gin.Context) {
+	// update single-choice question
+	var request QuestionSingleChoice
+	logger.Infof("handle request update single choice question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change single-choice question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change single-choice question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check single-choice question is validate")
+	if b, err := nova.isSingleChoiceQuestionValidate(request); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check single-choice question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check single-choice question is validate")
+	// update data cache by querying single-choice questions in database
+	logger.Debugf("update data cache by querying single-choice questions in database")
+	err = nova.querySingleChoiceQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying single-choice questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying single-choice questions in database")
+	// check single-choice questions existence
+	logger.Debugf("check single-choice questions existence")
+	if !nova.isSingleChoiceQuestionExisted(strings.ToLower(request.Id)) || !nova.isResourceVisible(c, QuestionTypeSingleChoice, strings.ToLower(request.Id)) {
+		nova.response403Forbidden(c, errors.New("forbidden replace single-choice question without create it"))
+		logger.Errorf("error check single-choice question existence")
+		return
+	}
+	logger.Debugf("successfully check single-choice question existence")
+	// check single-choice question duplication
+	logger.Debugf("check single-choice question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeSingleChoice, strings.ToLower(request.Id), singleChoiceFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check single-choice question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check single-choice question is duplicated")
+	// query previous single-choice question in data cache
+	previous, _ := nova.querySingleChoiceQuestionInDataCache(strings.ToLower(request.Id))
+	// store updated single-choice question in data cache
+	logger.Debugf("update single-choice question in data cache")
+	response := QuestionSingleChoice{
+		Id:             strings.ToLower(request.Id),
+		Title:          request.Title,
+		Answers:        request.Answers,
+		StandardAnswer: request.StandardAnswer,
+	}
+	if b := nova.updateSingleChoiceQuestionInDataCache(response); !b {
+		nova.response404NotFound(c, errors.New("single-choice question not found"))
+		logger.Errorf("error update single-choice question in data cache")
+		return
+	}
+	logger.Debugf("successfully update single-choice question in data cache")
+	// store update single-choice question in database
+	logger.Debugf("update single-choice question in database")
+	if err = nova.updateSingleChoiceQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update single-choice question in database")
+		return
+	}
+	logger.Debugf("successfully update single-choice question in database")
+	// store single-choice question revision in database
+	logger.Debugf("store single-choice question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeSingleChoice, response.Id, previous, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store single-choice question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store single-choice question revision in database")
+	// edited published single-choice question goes back to draft
+	logger.Debugf("store single-choice question workflow in database")
+	if err = nova.reviseQuestionWorkflow(c, QuestionTypeSingleChoice, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store single-choice question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store single-choice question workflow in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleUpdateQuestionMultipleChoice(c *gin.Context) {
+	// update multiple-choice question
+	var request QuestionMultipleChoice
+	logger.Infof("handle request update multiple choice question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change multiple-choice question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change multiple-choice question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check multiple-choice question is validate")
+	if b, err := nova.isMultipleChoiceQuestionValidate(request); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check multiple-choice question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check multiple-choice question is validate")
+	// update data cache by querying multiple-choice questions in database
+	logger.Debugf("update data cache by querying multiple-choice questions in database")
+	err = nova.queryMultipleChoiceQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying multiple-choice questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying multiple-choice questions in database")
+	// check multiple-choice questions existence
+	logger.Debugf("check multiple-choice questions existence")
+	if !nova.isMultipleChoiceQuestionExisted(strings.ToLower(request.Id)) || !nova.isResourceVisible(c, QuestionTypeMultipleChoice, strings.ToLower(request.Id)) {
+		nova.response403Forbidden(c, errors.New("forbidden replace multiple-choice question without create it"))
+		logger.Errorf("error check multiple-choice question existence")
+		return
+	}
+	logger.Debugf("successfully check multiple-choice question existence")
+	// check multiple-choice question duplication
+	logger.Debugf("check multiple-choice question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeMultipleChoice, strings.ToLower(request.Id), multipleChoiceFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check multiple-choice question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check multiple-choice question is duplicated")
+	// query previous multiple-choice question in data cache
+	previous, _ := nova.queryMultipleChoiceQuestionInDataCache(strings.ToLower(request.Id))
+	// store updated multiple-choice question in data cache
+	logger.Debugf("update multiple-choice question in data cache")
+	response := QuestionMultipleChoice{
+		Id:              strings.ToLower(request.Id),
+		Title:           request.Title,
+		Answers:         request.Answers,
+		StandardAnswers: request.StandardAnswers,
+	}
+	if b := nova.updateMultipleChoiceQuestionInDataCache(response); !b {
+		nova.response404NotFound(c, errors.New("multiple-choice question not found"))
+		logger.Errorf("error update multiple-choice question in data cache")
+		return
+	}
+	logger.Debugf("successfully update multiple-choice question in data cache")
+	// store update multiple-choice question in database
+	logger.Debugf("update multiple-choice question in database")
+	if err = nova.updateMultipleChoiceQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update multiple-choice question in database")
+		return
+	}
+	logger.Debugf("successfully update multiple-choice question in database")
+	// store multiple-choice question revision in database
+	logger.Debugf("store multiple-choice question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeMultipleChoice, response.Id, previous, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store multiple-choice question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store multiple-choice question revision in database")
+	// edited published multiple-choice question goes back to draft
+	logger.Debugf("store multiple-choice question workflow in database")
+	if err = nova.reviseQuestionWorkflow(c, QuestionTypeMultipleChoice, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store multiple-choice question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store multiple-choice question workflow in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleUpdateQuestionJudgement(c *gin.Context) {
+	// update judgement question
+	var request QuestionJudgement
+	logger.Infof("handle request update judgement question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change judgement question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change judgement question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check judgement question is validate")
+	if b, err := nova.isJudgementQuestionValidate(request); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check judgement question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check judgement question is validate")
+	// update data cache by querying judgement in database
+	logger.Debugf("update data cache by querying judgement in database")
+	err = nova.queryJudgementQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying judgement questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying judgement questions in database")
+	// check judgement questions existence
+	logger.Debugf("check judgement questions existence")
+	if !nova.isJudgementQuestionExisted(strings.ToLower(request.Id)) || !nova.isResourceVisible(c, QuestionTypeJudgement, strings.ToLower(request.Id)) {
+		nova.response403Forbidden(c, errors.New("forbidden replace judgement question without create it"))
+		logger.Errorf("error check judgement question existence")
+		return
+	}
+	logger.Debugf("successfully check judgement question existence")
+	// check judgement question duplication
+	logger.Debugf("check judgement question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeJudgement, strings.ToLower(request.Id), judgementFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check judgement question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check judgement question is duplicated")
+	// query previous judgement question in data cache
+	previous, _ := nova.queryJudgementQuestionInDataCache(strings.ToLower(request.Id))
+	// store updated judgement question in data cache
+	logger.Debugf("update judgement question in data cache")
+	response := QuestionJudgement{
+		Id:             strings.ToLower(request.Id),
+		Title:          request.Title,
+		Answer:         request.Answer,
+		StandardAnswer: request.StandardAnswer,
+	}
+	if b := nova.updateJudgementQuestionInDataCache(response); !b {
+		nova.response404NotFound(c, errors.New("judgement question not found"))
+		logger.Errorf("error update judgement question in data cache")
+		return
+	}
+	logger.Debugf("successfully update judgement question in data cache")
+	// store update judgement question in database
+	logger.Debugf("update judgement question in database")
+	if err = nova.updateJudgementQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update judgement question in database")
+		return
+	}
+	logger.Debugf("successfully update judgement question in database")
+	// store judgement question revision in database
+	logger.Debugf("store judgement question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeJudgement, response.Id, previous, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store judgement question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store judgement question revision in database")
+	// edited published judgement question goes back to draft
+	logger.Debugf("store judgement question workflow in database")
+	if err = nova.reviseQuestionWorkflow(c, QuestionTypeJudgement, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store judgement question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store judgement question workflow in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleUpdateQuestionEssay(c *gin.Context) {
+	// update essay question
+	var request QuestionEssay
+	logger.Infof("handle request update essay question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change essay question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change essay question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check essay question is validate")
+	if b, err := nova.isEssayQuestionValidate(request); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check essay question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check essay question is validate")
+	// update data cache by querying essay in database
+	logger.Debugf("update data cache by querying essay in database")
+	err = nova.queryEssayQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying essay questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying essay questions in database")
+	// check essay questions existence
+	logger.Debugf("check essay questions existence")
+	if !nova.isEssayQuestionExisted(strings.ToLower(request.Id)) || !nova.isResourceVisible(c, QuestionTypeEssay, strings.ToLower(request.Id)) {
+		nova.response403Forbidden(c, errors.New("forbidden replace essay question without create it"))
+		logger.Errorf("error check essay question existence")
+		return
+	}
+	logger.Debugf("successfully check essay question existence")
+	// check essay question duplication
+	logger.Debugf("check essay question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeEssay, strings.ToLower(request.Id), essayFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check essay question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check essay question is duplicated")
+	// query previous essay question in data cache
+	previous, _ := nova.queryEssayQuestionInDataCache(strings.ToLower(request.Id))
+	// store updated judgement question in data cache
+	logger.Debugf("update judgement question in data cache")
+	response := QuestionEssay{
+		Id:             strings.ToLower(request.Id),
+		Title:          request.Title,
+		Answer:         request.Answer,
+		StandardAnswer: request.StandardAnswer,
+	}
+	if b := nova.updateEssayQuestionInDataCache(response); !b {
+		nova.response404NotFound(c, errors.New("essay question not found"))
+		logger.Errorf("error update essay question in data cache")
+		return
+	}
+	logger.Debugf("successfully update essay question in data cache")
+	// store update essay question in database
+	logger.Debugf("update essay question in database")
+	if err = nova.updateEssayQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update essay question in database")
+		return
+	}
+	logger.Debugf("successfully update essay question in database")
+	// store essay question revision in database
+	logger.Debugf("store essay question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeEssay, response.Id, previous, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store essay question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store essay question revision in database")
+	// edited published essay question goes back to draft
+	logger.Debugf("store essay question workflow in database")
+	if err = nova.reviseQuestionWorkflow(c, QuestionTypeEssay, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store essay question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store essay question workflow in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleUpdateQuestionFillBlank(c *gin.Context) {
+	// update fill-blank question
+	var request QuestionFillBlank
+	logger.Infof("handle request update fill-blank question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change fill-blank question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change fill-blank question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check fill-blank question is validate")
+	if b, err := nova.isFillBlankQuestionValidate(request); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check fill-blank question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check fill-blank question is validate")
+	// update data cache by querying fill-blank in database
+	logger.Debugf("update data cache by querying fill-blank in database")
+	err = nova.queryFillBlankQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying fill-blank questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying fill-blank questions in database")
+	// check fill-blank questions existence
+	logger.Debugf("check fill-blank questions existence")
+	if !nova.isFillBlankQuestionExisted(strings.ToLower(request.Id)) || !nova.isResourceVisible(c, QuestionTypeFillBlank, strings.ToLower(request.Id)) {
+		nova.response403Forbidden(c, errors.New("forbidden replace fill-blank question without create it"))
+		logger.Errorf("error check fill-blank question existence")
+		return
+	}
+	logger.Debugf("successfully check fill-blank question existence")
+	// check fill-blank question duplication
+	logger.Debugf("check fill-blank question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeFillBlank, strings.ToLower(request.Id), fillBlankFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check fill-blank question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check fill-blank question is duplicated")
+	// query previous fill-blank question in data cache
+	previous, _ := nova.queryFillBlankQuestionInDataCache(strings.ToLower(request.Id))
+	// store updated judgement question in data cache
+	logger.Debugf("update judgement question in data cache")
+	response := QuestionFillBlank{
+		Id:     strings.ToLower(request.Id),
+		Title:  request.Title,
+		Blanks: request.Blanks,
+	}
+	if b := nova.updateFillBlankQuestionInDataCache(response); !b {
+		nova.response404NotFound(c, errors.New("fill-blank question not found"))
+		logger.Errorf("error update fill-blank question in data cache")
+		return
+	}
+	logger.Debugf("successfully update fill-blank question in data cache")
+	// store update fill-blank question in database
+	logger.Debugf("update fill-blank question in database")
+	if err = nova.updateFillBlankQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update fill-blank question in database")
+		return
+	}
+	logger.Debugf("successfully update fill-blank question in database")
+	// store fill-blank question revision in database
+	logger.Debugf("store fill-blank question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeFillBlank, response.Id, previous, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store fill-blank question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store fill-blank question revision in database")
+	// edited published fill-blank question goes back to draft
+	logger.Debugf("store fill-blank question workflow in database")
+	if err = nova.reviseQuestionWorkflow(c, QuestionTypeFillBlank, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store fill-blank question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store fill-blank question workflow in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleUpdateQuestionMatching(c *gin.Context) {
+	// update matching question
+	var request QuestionMatching
+	logger.Infof("handle request update matching question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change matching question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change matching question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check matching question is validate")
+	if b, err := nova.isMatchingQuestionValidate(request); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check matching question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check matching question is validate")
+	// update data cache by querying matching in database
+	logger.Debugf("update data cache by querying matching in database")
+	err = nova.queryMatchingQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying matching questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying matching questions in database")
+	// check matching questions existence
+	logger.Debugf("check matching questions existence")
+	if !nova.isMatchingQuestionExisted(strings.ToLower(request.Id)) || !nova.isResourceVisible(c, QuestionTypeMatching, strings.ToLower(request.Id)) {
+		nova.response403Forbidden(c, errors.New("forbidden replace matching question without create it"))
+		logger.Errorf("error check matching question existence")
+		return
+	}
+	logger.Debugf("successfully check matching question existence")
+	// check matching question duplication
+	logger.Debugf("check matching question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeMatching, strings.ToLower(request.Id), matchingFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check matching question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check matching question is duplicated")
+	// query previous matching question in data cache
+	previous, _ := nova.queryMatchingQuestionInDataCache(strings.ToLower(request.Id))
+	// store updated judgement question in data cache
+	logger.Debugf("update judgement question in data cache")
+	response := QuestionMatching{
+		Id:      strings.ToLower(request.Id),
+		Title:   request.Title,
+		Pairs:   request.Pairs,
+		Scoring: request.Scoring,
+	}
+	if b := nova.updateMatchingQuestionInDataCache(response); !b {
+		nova.response404NotFound(c, errors.New("matching question not found"))
+		logger.Errorf("error update matching question in data cache")
+		return
+	}
+	logger.Debugf("successfully update matching question in data cache")
+	// store update matching question in database
+	logger.Debugf("update matching question in database")
+	if err = nova.updateMatchingQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update matching question in database")
+		return
+	}
+	logger.Debugf("successfully update matching question in database")
+	// store matching question revision in database
+	logger.Debugf("store matching question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeMatching, response.Id, previous, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store matching question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store matching question revision in database")
+	// edited published matching question goes back to draft
+	logger.Debugf("store matching question workflow in database")
+	if err = nova.reviseQuestionWorkflow(c, QuestionTypeMatching, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store matching question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store matching question workflow in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleUpdateQuestionOrdering(c *gin.Context) {
+	// update ordering question
+	var request QuestionOrdering
+	logger.Infof("handle request update ordering question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change ordering question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change ordering question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check ordering question is validate")
+	if b, err := nova.isOrderingQuestionValidate(request); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check ordering question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check ordering question is validate")
+	// update data cache by querying ordering in database
+	logger.Debugf("update data cache by querying ordering in database")
+	err = nova.queryOrderingQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying ordering questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying ordering questions in database")
+	// check ordering questions existence
+	logger.Debugf("check ordering questions existence")
+	if !nova.isOrderingQuestionExisted(strings.ToLower(request.Id)) || !nova.isResourceVisible(c, QuestionTypeOrdering, strings.ToLower(request.Id)) {
+		nova.response403Forbidden(c, errors.New("forbidden replace ordering question without create it"))
+		logger.Errorf("error check ordering question existence")
+		return
+	}
+	logger.Debugf("successfully check ordering question existence")
+	// check ordering question duplication
+	logger.Debugf("check ordering question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeOrdering, strings.ToLower(request.Id), orderingFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check ordering question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check ordering question is duplicated")
+	// query previous ordering question in data cache
+	previous, _ := nova.queryOrderingQuestionInDataCache(strings.ToLower(request.Id))
+	// store updated judgement question in data cache
+	logger.Debugf("update judgement question in data cache")
+	response := QuestionOrdering{
+		Id:      strings.ToLower(request.Id),
+		Title:   request.Title,
+		Items:   request.Items,
+		Scoring: request.Scoring,
+	}
+	if b := nova.updateOrderingQuestionInDataCache(response); !b {
+		nova.response404NotFound(c, errors.New("ordering question not found"))
+		logger.Errorf("error update ordering question in data cache")
+		return
+	}
+	logger.Debugf("successfully update ordering question in data cache")
+	// store update ordering question in database
+	logger.Debugf("update ordering question in database")
+	if err = nova.updateOrderingQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update ordering question in database")
+		return
+	}
+	logger.Debugf("successfully update ordering question in database")
+	// store ordering question revision in database
+	logger.Debugf("store ordering question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeOrdering, response.Id, previous, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store ordering question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store ordering question revision in database")
+	// edited published ordering question goes back to draft
+	logger.Debugf("store ordering question workflow in database")
+	if err = nova.reviseQuestionWorkflow(c, QuestionTypeOrdering, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store ordering question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store ordering question workflow in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleUpdateQuestionNumeric(c *gin.Context) {
+	// update numeric question
+	var request QuestionNumeric
+	logger.Infof("handle request update numeric question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change numeric question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change numeric question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check numeric question is validate")
+	if b, err := nova.isNumericQuestionValidate(request); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check numeric question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check numeric question is validate")
+	// update data cache by querying numeric in database
+	logger.Debugf("update data cache by querying numeric in database")
+	err = nova.queryNumericQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying numeric questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying numeric questions in database")
+	// check numeric questions existence
+	logger.Debugf("check numeric questions existence")
+	if !nova.isNumericQuestionExisted(strings.ToLower(request.Id)) || !nova.isResourceVisible(c, QuestionTypeNumeric, strings.ToLower(request.Id)) {
+		nova.response403Forbidden(c, errors.New("forbidden replace numeric question without create it"))
+		logger.Errorf("error check numeric question existence")
+		return
+	}
+	logger.Debugf("successfully check numeric question existence")
+	// check numeric question duplication
+	logger.Debugf("check numeric question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeNumeric, strings.ToLower(request.Id), numericFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check numeric question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check numeric question is duplicated")
+	// query previous numeric question in data cache
+	previous, _ := nova.queryNumericQuestionInDataCache(strings.ToLower(request.Id))
+	// store updated judgement question in data cache
+	logger.Debugf("update judgement question in data cache")
+	response := QuestionNumeric{
+		Id:                strings.ToLower(request.Id),
+		Title:             request.Title,
+		Value:             request.Value,
+		AbsoluteTolerance: request.AbsoluteTolerance,
+		RelativeTolerance: request.RelativeTolerance,
+		Unit:              request.Unit,
+		Units:             request.Units,
+	}
+	if b := nova.updateNumericQuestionInDataCache(response); !b {
+		nova.response404NotFound(c, errors.New("numeric question not found"))
+		logger.Errorf("error update numeric question in data cache")
+		return
+	}
+	logger.Debugf("successfully update numeric question in data cache")
+	// store update numeric question in database
+	logger.Debugf("update numeric question in database")
+	if err = nova.updateNumericQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update numeric question in database")
+		return
+	}
+	logger.Debugf("successfully update numeric question in database")
+	// store numeric question revision in database
+	logger.Debugf("store numeric question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeNumeric, response.Id, previous, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store numeric question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store numeric question revision in database")
+	// edited published numeric question goes back to draft
+	logger.Debugf("store numeric question workflow in database")
+	if err = nova.reviseQuestionWorkflow(c, QuestionTypeNumeric, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store numeric question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store numeric question workflow in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleUpdateQuestionTemplate(c *gin.Context) {
+	// update template question
+	var request QuestionTemplate
+	logger.Infof("handle request update template question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change template question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change template question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check template question is validate")
+	if b, err := nova.isTemplateQuestionValidate(request); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check template question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check template question is validate")
+	// update data cache by querying template in database
+	logger.Debugf("update data cache by querying template in database")
+	err = nova.queryTemplateQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying template questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying template questions in database")
+	// check template questions existence
+	logger.Debugf("check template questions existence")
+	if !nova.isTemplateQuestionExisted(strings.ToLower(request.Id)) || !nova.isResourceVisible(c, QuestionTypeTemplate, strings.ToLower(request.Id)) {
+		nova.response403Forbidden(c, errors.New("forbidden replace template question without create it"))
+		logger.Errorf("error check template question existence")
+		return
+	}
+	logger.Debugf("successfully check template question existence")
+	// check template question duplication
+	logger.Debugf("check template question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeTemplate, strings.ToLower(request.Id), templateFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check template question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check template question is duplicated")
+	// query previous template question in data cache
+	previous, _ := nova.queryTemplateQuestionInDataCache(strings.ToLower(request.Id))
+	// store updated judgement question in data cache
+	logger.Debugf("update judgement question in data cache")
+	response := QuestionTemplate{
+		Id:                strings.ToLower(request.Id),
+		Title:             request.Title,
+		Variables:         request.Variables,
+		Formula:           request.Formula,
+		Precision:         request.Precision,
+		AbsoluteTolerance: request.AbsoluteTolerance,
+		RelativeTolerance: request.RelativeTolerance,
+	}
+	if b := nova.updateTemplateQuestionInDataCache(response); !b {
+		nova.response404NotFound(c, errors.New("template question not found"))
+		logger.Errorf("error update template question in data cache")
+		return
+	}
+	logger.Debugf("successfully update template question in data cache")
+	// store update template question in database
+	logger.Debugf("update template question in database")
+	if err = nova.updateTemplateQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update template question in database")
+		return
+	}
+	logger.Debugf("successfully update template question in database")
+	// store template question revision in database
+	logger.Debugf("store template question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeTemplate, response.Id, previous, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store template question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store template question revision in database")
+	// edited published template question goes back to draft
+	logger.Debugf("store template question workflow in database")
+	if err = nova.reviseQuestionWorkflow(c, QuestionTypeTemplate, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store template question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store template question workflow in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleUpdateQuestionCode(c *gin.Context) {
+	// update code question
+	var request QuestionCode
+	logger.Infof("handle request update code question")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to change code question")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to change code question")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check code question is validate")
+	if b, err := nova.isCodeQuestionValidate(request); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check code question is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check code question is validate")
+	// update data cache by querying code in database
+	logger.Debugf("update data cache by querying code in database")
+	err = nova.queryCodeQuestionsInDatabase()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying code questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying code questions in database")
+	// check code questions existence
+	logger.Debugf("check code questions existence")
+	if !nova.isCodeQuestionExisted(strings.ToLower(request.Id)) || !nova.isResourceVisible(c, QuestionTypeCode, strings.ToLower(request.Id)) {
+		nova.response403Forbidden(c, errors.New("forbidden replace code question without create it"))
+		logger.Errorf("error check code question existence")
+		return
+	}
+	logger.Debugf("successfully check code question existence")
+	// check code question duplication
+	logger.Debugf("check code question is duplicated")
+	if err = nova.checkQuestionDuplicated(c, QuestionTypeCode, strings.ToLower(request.Id), codeFingerprintText(request)); err != nil {
+		if errors.Is(err, errQuestionDuplicated) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check code question is duplicated: %v", err)
+		return
+	}
+	logger.Debugf("successfully check code question is duplicated")
+	// query previous code question in data cache
+	previous, _ := nova.queryCodeQuestionInDataCache(strings.ToLower(request.Id))
+	// store updated judgement question in data cache
+	logger.Debugf("update judgement question in data cache")
+	response := QuestionCode{
+		Id:          strings.ToLower(request.Id),
+		Title:       request.Title,
+		Language:    request.Language,
+		StarterCode: request.StarterCode,
+		TestCases:   request.TestCases,
+		TimeLimit:   request.TimeLimit,
+		MemoryLimit: request.MemoryLimit,
+		Scoring:     request.Scoring,
+	}
+	if b := nova.updateCodeQuestionInDataCache(response); !b {
+		nova.response404NotFound(c, errors.New("code question not found"))
+		logger.Errorf("error update code question in data cache")
+		return
+	}
+	logger.Debugf("successfully update code question in data cache")
+	// store update code question in database
+	logger.Debugf("update code question in database")
+	if err = nova.updateCodeQuestionInDatabase(response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update code question in database")
+		return
+	}
+	logger.Debugf("successfully update code question in database")
+	// store code question revision in database
+	logger.Debugf("store code question revision in database")
+	if _, err = nova.createQuestionRevision(c, QuestionTypeCode, response.Id, previous, response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store code question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store code question revision in database")
+	// edited published code question goes back to draft
+	logger.Debugf("store code question workflow in database")
+	if err = nova.reviseQuestionWorkflow(c, QuestionTypeCode, response.Id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store code question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store code question workflow in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) queryQuestionInDataCache(questionType string, id string) (any, error) {
+	// search question of any type in data cache
+	switch questionType {
+	case QuestionTypeSingleChoice:
+		return nova.querySingleChoiceQuestionInDataCache(id)
+	case QuestionTypeMultipleChoice:
+		return nova.queryMultipleChoiceQuestionInDataCache(id)
+	case QuestionTypeJudgement:
+		return nova.queryJudgementQuestionInDataCache(id)
+	case QuestionTypeEssay:
+		return nova.queryEssayQuestionInDataCache(id)
+	case QuestionTypeFillBlank:
+		return nova.queryFillBlankQuestionInDataCache(id)
+	case QuestionTypeMatching:
+		return nova.queryMatchingQuestionInDataCache(id)
+	case QuestionTypeOrdering:
+		return nova.queryOrderingQuestionInDataCache(id)
+	case QuestionTypeNumeric:
+		return nova.queryNumericQuestionInDataCache(id)
+	case QuestionTypeTemplate:
+		return nova.queryTemplateQuestionInDataCache(id)
+	case QuestionTypeCode:
+		return nova.queryCodeQuestionInDataCache(id)
+	default:
+		return nil, fmt.Errorf("question type %v not supported", questionType)
+	}
+}
+
+func (nova *Nova) queryQuestionsInDatabase() error {
+	// update single-choice questions in data cache
+	if err := nova.querySingleChoiceQuestionsInDatabase(); err != nil {
+		return err
+	}
+	// update multiple-choice questions in data cache
+	if err := nova.queryMultipleChoiceQuestionsInDatabase(); err != nil {
+		return err
+	}
+	// update judgement questions in data cache
+	if err := nova.queryJudgementQuestionsInDatabase(); err != nil {
+		return err
+	}
+	// update essay questions in data cache
+	if err := nova.queryEssayQuestionsInDatabase(); err != nil {
+		return err
+	}
+	// update fill-blank questions in data cache
+	if err := nova.queryFillBlankQuestionsInDatabase(); err != nil {
+		return err
+	}
+	// update matching questions in data cache
+	if err := nova.queryMatchingQuestionsInDatabase(); err != nil {
+		return err
+	}
+	// update ordering questions in data cache
+	if err := nova.queryOrderingQuestionsInDatabase(); err != nil {
+		return err
+	}
+	// update numeric questions in data cache
+	if err := nova.queryNumericQuestionsInDatabase(); err != nil {
+		return err
+	}
+	// update template questions in data cache
+	if err := nova.queryTemplateQuestionsInDatabase(); err != nil {
+		return err
+	}
+	// update code questions in data cache
+	if err := nova.queryCodeQuestionsInDatabase(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) isQuestionIdValidate(id string) (bool, error) {
+	// check question identity format is UUID
+	if err := uuid.Validate(id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (nova *Nova) isQuestionTypeValidate(questionType string) (bool, error) {
+	// check question type is supported
+	switch questionType {
+	case QuestionTypeSingleChoice, QuestionTypeMultipleChoice, QuestionTypeJudgement, QuestionTypeEssay,
+		QuestionTypeFillBlank, QuestionTypeMatching, QuestionTypeOrdering, QuestionTypeNumeric, QuestionTypeTemplate,
+		QuestionTypeCode:
+		return true, nil
+	default:
+		return false, fmt.Errorf("question type %v not supported", questionType)
+	}
+}
+
+func (nova *Nova) isQuestionReferenceValidate(questionType string, id string) (bool, error) {
+	// check question type is supported
+	if b, err := nova.isQuestionTypeValidate(questionType); !b {
+		return false, err
+	}
+	// check question identity format is UUID
+	if err := uuid.Validate(id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (nova *Nova) isQuestionExisted(questionType string, id string) bool {
+	// search question in data cache of its type
+	switch questionType {
+	case QuestionTypeSingleChoice:
+		return nova.isSingleChoiceQuestionExisted(id)
+	case QuestionTypeMultipleChoice:
+		return nova.isMultipleChoiceQuestionExisted(id)
+	case QuestionTypeJudgement:
+		return nova.isJudgementQuestionExisted(id)
+	case QuestionTypeEssay:
+		return nova.isEssayQuestionExisted(id)
+	case QuestionTypeFillBlank:
+		return nova.isFillBlankQuestionExisted(id)
+	case QuestionTypeMatching:
+		return nova.isMatchingQuestionExisted(id)
+	case QuestionTypeOrdering:
+		return nova.isOrderingQuestionExisted(id)
+	case QuestionTypeNumeric:
+		return nova.isNumericQuestionExisted(id)
+	case QuestionTypeTemplate:
+		return nova.isTemplateQuestionExisted(id)
+	case QuestionTypeCode:
+		return nova.isCodeQuestionExisted(id)
+	default:
+		return false
+	}
+}
+
+func (nova *Nova) isSingleChoiceQuestionExisted(id string) bool {
+	// enable single-choice question cache read lock
+	nova.cache.questionsCache.singleChoiceCache.mutex.RLock()
+	defer nova.cache.questionsCache.singleChoiceCache.mutex.RUnlock()
+	// search Id in data cache
+	for _, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+		if v.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (nova *Nova) isMultipleChoiceQuestionExisted(id string) bool {
+	// enable multiple-choice question cache read lock
+	nova.cache.questionsCache.multipleChoiceCache.mutex.RLock()
+	defer nova.cache.questionsCache.multipleChoiceCache.mutex.RUnlock()
+	// search Id in data cache
+	for _, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+		if v.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (nova *Nova) isJudgementQuestionExisted(id string) bool {
+	// enable judgement question cache read lock
+	nova.cache.questionsCache.judgementCache.mutex.RLock()
+	defer nova.cache.questionsCache.judgementCache.mutex.RUnlock()
+	// search Id in data cache
+	for _, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+		if v.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (nova *Nova) isEssayQuestionExisted(id string) bool {
+	// enable essay question cache read lock
+	nova.cache.questionsCache.essayCache.mutex.RLock()
+	defer nova.cache.questionsCache.essayCache.mutex.RUnlock()
+	// search Id in data cache
+	for _, v := range nova.cache.questionsCache.essayCache.essaySet {
+		if v.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (nova *Nova) isFillBlankQuestionExisted(id string) bool {
+	// enable fill-blank question cache read lock
+	nova.cache.questionsCache.fillBlankCache.mutex.RLock()
+	defer nova.cache.questionsCache.fillBlankCache.mutex.RUnlock()
+	// search Id in data cache
+	for _, v := range nova.cache.questionsCache.fillBlankCache.fillBlankSet {
+		if v.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (nova *Nova) isMatchingQuestionExisted(id string) bool {
+	// enable matching question cache read lock
+	nova.cache.questionsCache.matchingCache.mutex.RLock()
+	defer nova.cache.questionsCache.matchingCache.mutex.RUnlock()
+	// search Id in data cache
+	for _, v := range nova.cache.questionsCache.matchingCache.matchingSet {
+		if v.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (nova *Nova) isOrderingQuestionExisted(id string) bool {
+	// enable ordering question cache read lock
+	nova.cache.questionsCache.orderingCache.mutex.RLock()
+	defer nova.cache.questionsCache.orderingCache.mutex.RUnlock()
+	// search Id in data cache
+	for _, v := range nova.cache.questionsCache.orderingCache.orderingSet {
+		if v.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (nova *Nova) isNumericQuestionExisted(id string) bool {
+	// enable numeric question cache read lock
+	nova.cache.questionsCache.numericCache.mutex.RLock()
+	defer nova.cache.questionsCache.numericCache.mutex.RUnlock()
+	// search Id in data cache
+	for _, v := range nova.cache.questionsCache.numericCache.numericSet {
+		if v.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (nova *Nova) isTemplateQuestionExisted(id string) bool {
+	// enable template question cache read lock
+	nova.cache.questionsCache.templateCache.mutex.RLock()
+	defer nova.cache.questionsCache.templateCache.mutex.RUnlock()
+	// search Id in data cache
+	for _, v := range nova.cache.questionsCache.templateCache.templateSet {
+		if v.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (nova *Nova) isCodeQuestionExisted(id string) bool {
+	// enable code question cache read lock
+	nova.cache.questionsCache.codeCache.mutex.RLock()
+	defer nova.cache.questionsCache.codeCache.mutex.RUnlock()
+	// search Id in data cache
+	for _, v := range nova.cache.questionsCache.codeCache.codeSet {
+		if v.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (nova *Nova) isSingleChoiceQuestionValidate(question QuestionSingleChoice) (bool, error) {
+	// check single-choice question content, all violations are reported
+	if b, err := validateSingleChoiceQuestion(question); !b {
+		return b, err
+	}
+	// check text is valid in declared content format
+	return nova.isQuestionContentValidate(QuestionTypeSingleChoice, question.Id, question)
+}
+
+func (nova *Nova) isMultipleChoiceQuestionValidate(question QuestionMultipleChoice) (bool, error) {
+	// check multiple-choice question content, all violations are reported
+	if b, err := validateMultipleChoiceQuestion(question); !b {
+		return b, err
+	}
+	// check text is valid in declared content format
+	return nova.isQuestionContentValidate(QuestionTypeMultipleChoice, question.Id, question)
+}
+
+func (nova *Nova) isJudgementQuestionValidate(question QuestionJudgement) (bool, error) {
+	// check judgement question content, all violations are reported
+	if b, err := validateJudgementQuestion(question); !b {
+		return b, err
+	}
+	// check text is valid in declared content format
+	return nova.isQuestionContentValidate(QuestionTypeJudgement, question.Id, question)
+}
+
+func (nova *Nova) isEssayQuestionValidate(question QuestionEssay) (bool, error) {
+	// check essay question content, all violations are reported
+	if b, err := validateEssayQuestion(question); !b {
+		return b, err
+	}
+	// check text is valid in declared content format
+	return nova.isQuestionContentValidate(QuestionTypeEssay, question.Id, question)
+}
+
+func (nova *Nova) isFillBlankQuestionValidate(question QuestionFillBlank) (bool, error) {
+	// check fill-blank question content, all violations are reported
+	if b, err := validateFillBlankQuestion(question); !b {
+		return b, err
+	}
+	// check text is valid in declared content format
+	return nova.isQuestionContentValidate(QuestionTypeFillBlank, question.Id, question)
+}
+
+func (nova *Nova) isMatchingQuestionValidate(question QuestionMatching) (bool, error) {
+	// check matching question content, all violations are reported
+	if b, err := validateMatchingQuestion(question); !b {
+		return b, err
+	}
+	// check text is valid in declared content format
+	return nova.isQuestionContentValidate(QuestionTypeMatching, question.Id, question)
+}
+
+func (nova *Nova) isOrderingQuestionValidate(question QuestionOrdering) (bool, error) {
+	// check ordering question content, all violations are reported
+	if b, err := validateOrderingQuestion(question); !b {
+		return b, err
+	}
+	// check text is valid in declared content format
+	return nova.isQuestionContentValidate(QuestionTypeOrdering, question.Id, question)
+}
+
+func (nova *Nova) isNumericQuestionValidate(question QuestionNumeric) (bool, error) {
+	// check numeric question content, all violations are reported
+	if b, err := validateNumericQuestion(question); !b {
+		return b, err
+	}
+	// check text is valid in declared content format
+	return nova.isQuestionContentValidate(QuestionTypeNumeric, question.Id, question)
+}
+
+func (nova *Nova) isTemplateQuestionValidate(question QuestionTemplate) (bool, error) {
+	// check template question content, all violations are reported
+	if b, err := validateTemplateQuestion(question); !b {
+		return b, err
+	}
+	// check text is valid in declared content format
+	return nova.isQuestionContentValidate(QuestionTypeTemplate, question.Id, question)
+}
+
+func (nova *Nova) isCodeQuestionValidate(question QuestionCode) (bool, error) {
+	// check code question content, all violations are reported
+	if b, err := validateCodeQuestion(question); !b {
+		return b, err
+	}
+	// check text is valid in declared content format
+	return nova.isQuestionContentValidate(QuestionTypeCode, question.Id, question)
+}
+
+func (nova *Nova) createSingleChoiceQuestionInDataCache(question QuestionSingleChoice) {
+	// enable single-choice question cache write lock
+	nova.cache.questionsCache.singleChoiceCache.mutex.Lock()
+	defer nova.cache.questionsCache.singleChoiceCache.mutex.Unlock()
+	// append single-choice question in data cache
+	nova.cache.questionsCache.singleChoiceCache.singleChoiceSet = append(nova.cache.questionsCache.singleChoiceCache.singleChoiceSet, question)
+	return
+}
+
+func (nova *Nova) deleteSingleChoiceQuestionInDataCache(id string) {
+	// enable single-choice question cache write lock
+	nova.cache.questionsCache.singleChoiceCache.mutex.Lock()
+	defer nova.cache.questionsCache.singleChoiceCache.mutex.Unlock()
+	// search & delete single-choice question from data cache
+	for k, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+		if v.Id == id {
+			nova.cache.questionsCache.singleChoiceCache.singleChoiceSet = append(nova.cache.questionsCache.singleChoiceCache.singleChoiceSet[:k], nova.cache.questionsCache.singleChoiceCache.singleChoiceSet[k+1:]...)
+			break
+		}
+	}
+	return
+}
+
+func (nova *Nova) modifySingleChoiceQuestionInDataCache(question QuestionSingleChoice) (QuestionSingleChoice, error) {
+	// enable single choice question cache write lock
+	nova.cache.questionsCache.singleChoiceCache.mutex.Lock()
+	defer nova.cache.questionsCache.singleChoiceCache.mutex.Unlock()
+	// replace single choice question in data cache
+	for k, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+		if v.Id == question.Id {
+			nova.cache.questionsCache.singleChoiceCache.singleChoiceSet[k] = question
+			return nova.cache.questionsCache.singleChoiceCache.singleChoiceSet[k], nil
+		}
+	}
+	return QuestionSingleChoice{}, errors.New("single choice question not found")
+}
+
+func (nova *Nova) querySingleChoiceQuestionInDataCache(id string) (QuestionSingleChoice, error) {
+	// enable single-choice question cache read lock
+	nova.cache.questionsCache.singleChoiceCache.mutex.RLock()
+	defer nova.cache.questionsCache.singleChoiceCache.mutex.RUnlock()
+	// search & query single-choice question from data cache
+	for k, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+		if v.Id == id {
+			return nova.cache.questionsCache.singleChoiceCache.singleChoiceSet[k], nil
+		}
+	}
+	return QuestionSingleChoice{}, errors.New("single-choice question not found")
+}
+
+func (nova *Nova) updateSingleChoiceQuestionInDataCache(question QuestionSingleChoice) bool {
+	// enable single-choice question cache write lock
+	nova.cache.questionsCache.singleChoiceCache.mutex.Lock()
+	defer nova.cache.questionsCache.singleChoiceCache.mutex.Unlock()
+	// replace single-choice question in data cache
+	for k, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+		if v.Id == question.Id {
+			// userName and phoneNumber should not be changed
+			nova.cache.questionsCache.singleChoiceCache.singleChoiceSet[k] = question
+			return true
+		}
+	}
+	return false
+}
+
+func (nova *Nova) createSingleChoiceQuestionInDatabase(id string) error {
+	// enable single-choice question cache read lock
+	nova.cache.questionsCache.singleChoiceCache.mutex.RLock()
+	defer nova.cache.questionsCache.singleChoiceCache.mutex.RUnlock()
+	// search Id in data cache
+	b := false
+	question := QuestionSingleChoice{}
+	for _, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+		if v.Id == id {
+			question = v
+			b = true
+			break
+		}
+	}
+	if !b {
+		return errors.New("single-choice question not found")
+	}
+	// create single-choice question in database
+	if _, err := nova.db.CreateQuestionSingleChoice(&question); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) deleteSingleChoiceQuestionInDatabase(id string) error {
+	// enable single choice question cache read lock
+	nova.cache.questionsCache.singleChoiceCache.mutex.RLock()
+	defer nova.cache.questionsCache.singleChoiceCache.mutex.RUnlock()
+	// search single choice question Id in data cache
+	b := false
+	for _, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+		if v.Id == id {
+			b = true
+			break
+		}
+	}
+	if !b {
+		return errors.New("single-choice question not found")
+	}
+	// delete single-choice question in database
+	if err := nova.db.DeleteQuestionSingleChoice(id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) modifySingleChoiceQuestionInDatabase(id string) error {
+	// enable single choice question cache read lock
+	nova.cache.questionsCache.singleChoiceCache.mutex.RLock()
+	defer nova.cache.questionsCache.singleChoiceCache.mutex.RUnlock()
+	// search single choice question Id in data cache
+	b := false
+	question := QuestionSingleChoice{}
+	for _, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+		if v.Id == id {
+			question = v
+			b = true
+			break
+		}
+	}
+	if !b {
+		return errors.New("single-choice question not found")
+	}
+	// update single choice question in database
+	if err := nova.db.UpdateQuestionSingleChoice(&question); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) querySingleChoiceQuestionInDatabase(id string) error {
+	// enable single-choice question cache write lock
+	nova.cache.questionsCache.singleChoiceCache.mutex.Lock()
+	defer nova.cache.questionsCache.singleChoiceCache.mutex.Unlock()
+	// query single-choice question from database
+	question, err := nova.db.QueryQuestionSingleChoice(id)
+	if err != nil {
+		return err
+	}
+	// update single-choice question in data cache
+	for k, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+		if v.Id == id {
+			nova.cache.questionsCache.singleChoiceCache.singleChoiceSet[k] = *question
+			break
+		}
+	}
+	return nil
+}
+
+func (nova *Nova) updateSingleChoiceQuestionInDatabase(id string) error {
+	// enable single-choice question cache read lock
+	nova.cache.questionsCache.singleChoiceCache.mutex.RLock()
+	defer nova.cache.questionsCache.singleChoiceCache.mutex.RUnlock()
+	// search single-choice question in data cache
+	b := false
+	question := QuestionSingleChoice{}
+	for _, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+		if v.Id == id {
+			question = v
+			b = true
+			break
+		}
+	}
+	if !b {
+		return errors.New("single-choice question not found")
+	}
+	// update single-choice question in database
+	if err := nova.db.UpdateQuestionSingleChoice(&question); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) querySingleChoiceQuestionsInDatabase() error {
+	// enable single-choice question cache write lock
+	nova.cache.questionsCache.singleChoiceCache.mutex.Lock()
+	defer nova.cache.questionsCache.singleChoiceCache.mutex.Unlock()
+	// query single-choice question from database
+	questions, err := nova.db.QueryQuestionsSingleChoice()
+	if err != nil {
+		return err
+	}
+	// update single-choice question in data cache
+	for _, question := range questions {
+		b := false
+		// update if single-choice question existed
+		for k, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+			if v.Id == question.Id {
+				nova.cache.questionsCache.singleChoiceCache.singleChoiceSet[k] = *question
+				b = true
+				break
+			}
+		}
+		// create single-choice question if question not existed
+		if !b {
+			nova.cache.questionsCache.singleChoiceCache.singleChoiceSet = append(nova.cache.questionsCache.singleChoiceCache.singleChoiceSet, *question)
+		}
+	}
+	return nil
+}
+
+func (nova *Nova) createMultipleChoiceQuestionInDataCache(question QuestionMultipleChoice) {
+	// enable multiple-choice question cache write lock
+	nova.cache.questionsCache.multipleChoiceCache.mutex.Lock()
+	defer nova.cache.questionsCache.multipleChoiceCache.mutex.Unlock()
+	// append multiple-choice question in data cache
+	nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet = append(nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet, question)
+	return
+}
+
+func (nova *Nova) deleteMultipleChoiceQuestionInDataCache(id string) {
+	// enable multiple-choice question cache write lock
+	nova.cache.questionsCache.multipleChoiceCache.mutex.Lock()
+	defer nova.cache.questionsCache.multipleChoiceCache.mutex.Unlock()
+	// search & delete multiple-choice question from data cache
+	for k, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+		if v.Id == id {
+			nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet = append(nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet[:k], nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet[k+1:]...)
+			break
+		}
+	}
+	return
+}
+
+func (nova *Nova) modifyMultipleChoiceQuestionInDataCache(question QuestionMultipleChoice) (QuestionMultipleChoice, error) {
+	// enable multiple choice question cache write lock
+	nova.cache.questionsCache.multipleChoiceCache.mutex.Lock()
+	defer nova.cache.questionsCache.multipleChoiceCache.mutex.Unlock()
+	// replace multiple choice question in data cache
+	for k, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+		if v.Id == question.Id {
+			nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet[k] = question
+			return nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet[k], nil
+		}
+	}
+	return QuestionMultipleChoice{}, errors.New("multiple choice question not found")
+}
+
+func (nova *Nova) queryMultipleChoiceQuestionInDataCache(id string) (QuestionMultipleChoice, error) {
+	// enable multiple-choice question cache read lock
+	nova.cache.questionsCache.multipleChoiceCache.mutex.RLock()
+	defer nova.cache.questionsCache.multipleChoiceCache.mutex.RUnlock()
+	// search & query multiple-choice question from data cache
+	for k, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+		if v.Id == id {
+			return nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet[k], nil
+		}
+	}
+	return QuestionMultipleChoice{}, errors.New("multiple-choice question not found")
+}
+
+func (nova *Nova) updateMultipleChoiceQuestionInDataCache(question QuestionMultipleChoice) bool {
+	// enable multiple-choice question cache write lock
+	nova.cache.questionsCache.multipleChoiceCache.mutex.Lock()
+	defer nova.cache.questionsCache.multipleChoiceCache.mutex.Unlock()
+	// replace multiple-choice question in data cache
+	for k, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+		if v.Id == question.Id {
+			// userName and phoneNumber should not be changed
+			nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet[k] = question
+			return true
+		}
+	}
+	return false
+}
+
+func (nova *Nova) createMultipleChoiceQuestionInDatabase(id string) error {
+	// enable multiple-choice question cache read lock
+	nova.cache.questionsCache.multipleChoiceCache.mutex.RLock()
+	defer nova.cache.questionsCache.multipleChoiceCache.mutex.RUnlock()
+	// search Id in data cache
+	b := false
+	question := QuestionMultipleChoice{}
+	for _, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+		if v.Id == id {
+			question = v
+			b = true
+			break
+		}
+	}
+	if !b {
+		return errors.New("multiple-choice question not found")
+	}
+	// create multiple-choice question in database
+	if _, err := nova.db.CreateQuestionMultipleChoice(&question); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) deleteMultipleChoiceQuestionInDatabase(id string) error {
+	// enable multiple-choice question cache read lock
+	nova.cache.questionsCache.multipleChoiceCache.mutex.RLock()
+	defer nova.cache.questionsCache.multipleChoiceCache.mutex.RUnlock()
+	// search multiple-choice question Id in data cache
+	b := false
+	for _, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+		if v.Id == id {
+			b = true
+			break
+		}
+	}
+	if !b {
+		return errors.New("multiple-choice question not found")
+	}
+	// delete multiple-choice question in database
+	if err := nova.db.DeleteQuestionMultipleChoice(id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) modifyMultipleChoiceQuestionInDatabase(id string) error {
+	// enable multiple choice question cache read lock
+	nova.cache.questionsCache.multipleChoiceCache.mutex.RLock()
+	defer nova.cache.questionsCache.multipleChoiceCache.mutex.RUnlock()
+	// search multiple choice question Id in data cache
+	b := false
+	question := QuestionMultipleChoice{}
+	for _, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+		if v.Id == id {
+			question = v
+			b = true
+			break
+		}
+	}
+	if !b {
+		return errors.New("multiple-choice question not found")
+	}
+	// update multiple choice question in database
+	if err := nova.db.UpdateQuestionMultipleChoice(&question); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) queryMultipleChoiceQuestionInDatabase(id string) error {
+	// enable multiple-choice question cache write lock
+	nova.cache.questionsCache.multipleChoiceCache.mutex.Lock()
+	defer nova.cache.questionsCache.multipleChoiceCache.mutex.Unlock()
+	// query multiple-choice question from database
+	question, err := nova.db.QueryQuestionMultipleChoice(id)
+	if err != nil {
+		return err
+	}
+	// update multiple-choice question in data cache
+	for k, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+		if v.Id == id {
+			nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet[k] = *question
+			break
+		}
+	}
+	return nil
+}
+
+func (nova *Nova) updateMultipleChoiceQuestionInDatabase(id string) error {
+	// enable multiple-choice question cache read lock
+	nova.cache.questionsCache.multipleChoiceCache.mutex.RLock()
+	defer nova.cache.questionsCache.multipleChoiceCache.mutex.RUnlock()
+	// search multiple-choice question in data cache
+	b := false
+	question := QuestionMultipleChoice{}
+	for _, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+		if v.Id == id {
+			question = v
+			b = true
+			break
+		}
+	}
+	if !b {
+		return errors.New("multiple-choice question not found")
+	}
+	// update multiple-choice question in database
+	if err := nova.db.UpdateQuestionMultipleChoice(&question); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) queryMultipleChoiceQuestionsInDatabase() error {
+	// enable multiple-choice question cache write lock
+	nova.cache.questionsCache.multipleChoiceCache.mutex.Lock()
+	defer nova.cache.questionsCache.multipleChoiceCache.mutex.Unlock()
+	// query multiple-choice question from database
+	questions, err := nova.db.QueryQuestionsMultipleChoice()
+	if err != nil {
+		return err
+	}
+	// update multiple-choice question in data cache
+	for _, question := range questions {
+		b := false
+		// update if multiple-choice question existed
+		for k, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+			if v.Id == question.Id {
+				nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet[k] = *question
+				b = true
+				break
+			}
+		}
+		// create multiple-choice question if question not existed
+		if !b {
+			nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet = append(nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet, *question)
+		}
+	}
+	return nil
+}
+
+func (nova *Nova) createJudgementQuestionInDataCache(question QuestionJudgement) {
+	// enable judgement question cache write lock
+	nova.cache.questionsCache.judgementCache.mutex.Lock()
+	defer nova.cache.questionsCache.judgementCache.mutex.Unlock()
+	// append judgement question in data cache
+	nova.cache.questionsCache.judgementCache.judgementSet = append(nova.cache.questionsCache.judgementCache.judgementSet, question)
+	return
+}
+
+func (nova *Nova) deleteJudgementQuestionInDataCache(id string) {
+	// enable judgement question cache write lock
+	nova.cache.questionsCache.judgementCache.mutex.Lock()
+	defer nova.cache.questionsCache.judgementCache.mutex.Unlock()
+	// search & delete judgement question from data cache
+	for k, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+		if v.Id == id {
+			nova.cache.questionsCache.judgementCache.judgementSet = append(nova.cache.questionsCache.judgementCache.judgementSet[:k], nova.cache.questionsCache.judgementCache.judgementSet[k+1:]...)
+			break
+		}
+	}
+	return
+}
+
+func (nova *Nova) modifyJudgementQuestionInDataCache(question QuestionJudgement) (QuestionJudgement, error) {
+	// enable judgement question cache write lock
+	nova.cache.questionsCache.judgementCache.mutex.Lock()
+	defer nova.cache.questionsCache.judgementCache.mutex.Unlock()
+	// replace judgement question in data cache
+	for k, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+		if v.Id == question.Id {
+			nova.cache.questionsCache.judgementCache.judgementSet[k] = question
+			return nova.cache.questionsCache.judgementCache.judgementSet[k], nil
+		}
+	}
+	return QuestionJudgement{}, errors.New("judgement question not found")
+}
+
+func (nova *Nova) queryJudgementQuestionInDataCache(id string) (QuestionJudgement, error) {
+	// enable judgement question cache read lock
+	nova.cache.questionsCache.judgementCache.mutex.RLock()
+	defer nova.cache.questionsCache.judgementCache.mutex.RUnlock()
+	// search & query judgement question from data cache
+	for k, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+		if v.Id == id {
+			return nova.cache.questionsCache.judgementCache.judgementSet[k], nil
+		}
+	}
+	return QuestionJudgement{}, errors.New("judgement question not found")
+}
+
+func (nova *Nova) updateJudgementQuestionInDataCache(question QuestionJudgement) bool {
+	// enable judgement question cache write lock
+	nova.cache.questionsCache.judgementCache.mutex.Lock()
+	defer nova.cache.questionsCache.judgementCache.mutex.Unlock()
+	// replace judgement question in data cache
+	for k, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+		if v.Id == question.Id {
+			// userName and phoneNumber should not be changed
+			nova.cache.questionsCache.judgementCache.judgementSet[k] = question
+			return true
+		}
+	}
+	return false
+}
+
+func (nova *Nova) createJudgementQuestionInDatabase(id string) error {
+	// enable judgement question cache read lock
+	nova.cache.questionsCache.judgementCache.mutex.RLock()
+	defer nova.cache.questionsCache.judgementCache.mutex.RUnlock()
+	// search Id in data cache
+	b := false
+	question := QuestionJudgement{}
+	for _, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+		if v.Id == id {
+			question = v
+			b = true
+			break
+		}
+	}
+	if !b {
+		return errors.New("judgement question not found")
+	}
+	// create judgement question in database
+	if _, err := nova.db.CreateQuestionJudgement(&question); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) deleteJudgementQuestionInDatabase(id string) error {
+	// enable judgement question cache read lock
+	nova.cache.questionsCache.judgementCache.mutex.RLock()
+	defer nova.cache.questionsCache.judgementCache.mutex.RUnlock()
+	// search judgement question Id in data cache
+	b := false
+	for _, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+		if v.Id == id {
+			b = true
+			break
+		}
+	}
+	if !b {
+		return errors.New("judgement question not found")
+	}
+	// delete judgement question in database
+	if err := nova.db.DeleteQuestionJudgement(id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) modifyJudgementQuestionInDatabase(id string) error {
+	// enable judgement question cache read lock
+	nova.cache.questionsCache.judgementCache.mutex.RLock()
+	defer nova.cache.questionsCache.judgementCache.mutex.RUnlock()
+	// search judgement question Id in data cache
+	b := false
+	question := QuestionJudgement{}
+	for _, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+		if v.Id == id {
+			question = v
+			b = true
+			break
+		}
+	}
+	if !b {
+		return errors.New("judgement question not found")
+	}
+	// update judgement question in database
+	if err := nova.db.UpdateQuestionJudgement(&question); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) queryJudgementQuestionInDatabase(id string) error {
+	// enable judgement question cache write lock
+	nova.cache.questionsCache.judgementCache.mutex.Lock()
+	defer nova.cache.questionsCache.judgementCache.mutex.Unlock()
+	// query judgement question from database
+	question, err := nova.db.QueryQuestionJudgement(id)
+	if err != nil {
+		return err
+	}
+	// update judgement question in data cache
+	for k, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+		if v.Id == id {
+			nova.cache.questionsCache.judgementCache.judgementSet[k] = *question
+			break
+		}
+	}
+	return nil
+}
+
+func (nova *Nova) updateJudgementQuestionInDatabase(id string) error {
+	// enable judgement question cache read lock
+	nova.cache.questionsCache.judgementCache.mutex.RLock()
+	defer nova.cache.questionsCache.judgementCache.mutex.RUnlock()
+	// search judgement question in data cache
+	b := false
+	question := QuestionJudgement{}
+	for _, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+		if v.Id == id {
+			question = v
+			b = true
+			break
+		}
+	}
+	if !b {
+		return errors.New("judgement question not found")
+	}
+	// update judgement question in database
+	if err := nova.db.UpdateQuestionJudgement(&question); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) queryJudgementQuestionsInDatabase() error {
+	// enable judgement question cache write lock
+	nova.cache.questionsCache.judgementCache.mutex.Lock()
+	defer nova.cache.questionsCache.judgementCache.mutex.Unlock()
+	// query judgement question from database
+	questions, err := nova.db.QueryQuestionsJudgement()
+	if err != nil {
+		return err
+	}
+	// update judgement question in data cache
+	for _, question := range questions {
+		b := false
+		// update if judgement question existed
+		for k, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+			if v.Id == question.Id {
+				nova.cache.questionsCache.judgementCache.judgementSet[k] = *question
+				b = true
+				break
+			}
+		}
+		// create judgement question if question not existed
+		if !b {
+			nova.cache.questionsCache.judgementCache.judgementSet = append(nova.cache.questionsCache.judgementCache.judgementSet, *question)
+		}
+	}
+	return nil
+}
+
+func (nova *Nova) createEssayQuestionInDataCache(question QuestionEssay) {
+	// enable essay question cache write lock
+	nova.cache.questionsCache.essayCache.mutex.Lock()
+	defer nova.cache.questionsCache.essayCache.mutex.Unlock()
+	// append essay question in data cache
+	nova.cache.questionsCache.essayCache.essaySet = append(nova.cache.questionsCache.essayCache.essaySet, question)
+	return
+}
+
+func (nova *Nova) deleteEssayQuestionInDataCache(id string) {
+	// enable essay question cache write lock
+	nova.cache.questionsCache.essayCache.mutex.Lock()
+	defer nova.cache.questionsCache.essayCache.mutex.Unlock()
+	// search & delete essay question from data cache
+	for k, v := range nova.cache.questionsCache.essayCache.essaySet {
+		if v.Id == id {
+			nova.cache.questionsCache.essayCache.essaySet = append(nova.cache.questionsCache.essayCache.essaySet[:k], nova.cache.questionsCache.essayCache.essaySet[k+1:]...)
+			break
+		}
+	}
+	return
+}
+
+func (nova *Nova) modifyEssayQuestionInDataCache(question QuestionEssay) (QuestionEssay, error) {
+	// enable essay question cache write lock
+	nova.cache.questionsCache.essayCache.mutex.Lock()
+	defer nova.cache.questionsCache.essayCache.mutex.Unlock()
+	// replace essay question in data cache
+	for k, v := range nova.cache.questionsCache.essayCache.essaySet {
+		if v.Id == question.Id {
+			nova.cache.questionsCache.essayCache.essaySet[k] = question
+			return nova.cache.questionsCache.essayCache.essaySet[k], nil
+		}
+	}
+	return QuestionEssay{}, errors.New("essay question not found")
+}
+
+func (nova *Nova) queryEssayQuestionInDataCache(id string) (QuestionEssay, error) {
+	// enable essay question cache read lock
+	nova.cache.questionsCache.essayCache.mutex.RLock()
+	defer nova.cache.questionsCache.essayCache.mutex.RUnlock()
+	// search & query essay question from data cache
+	for k, v := range nova.cache.questionsCache.essayCache.essaySet {
+		if v.Id == id {
+			return nova.cache.questionsCache.essayCache.essaySet[k], nil
+		}
+	}
+	return QuestionEssay{}, errors.New("essay question not found")
+}
+
+func (nova *Nova) updateEssayQuestionInDataCache(question QuestionEssay) bool {
+	// enable essay question cache write lock
+	nova.cache.questionsCache.essayCache.mutex.Lock()
+	defer nova.cache.questionsCache.essayCache.mutex.Unlock()
+	// replace essay question in data cache
+	for k, v := range nova.cache.questionsCache.essayCache.essaySet {
+		if v.Id == question.Id {
+			// userName and phoneNumber should not be changed
+			nova.cache.questionsCache.essayCache.essaySet[k] = question
+			return true
+		}
+	}
+	return false
+}
+
+func (nova *Nova) createEssayQuestionInDatabase(id string) error {
+	// enable essay question cache read lock
+	nova.cache.questionsCache.essayCache.mutex.RLock()
+	defer nova.cache.questionsCache.essayCache.mutex.RUnlock()
+	// search Id in data cache
+	b := false
+	question := QuestionEssay{}
+	for _, v := range nova.cache.questionsCache.essayCache.essaySet {
+		if v.Id == id {
+			question = v
+			b = true
+			break
+		}
+	}
+	if !b {
+		return errors.New("essay question not found")
+	}
+	// create essay question in database
+	if _, err := nova.db.CreateQuestionEssay(&question); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) deleteEssayQuestionInDatabase(id string) error {
+	// enable essay question cache read lock
+	nova.cache.questionsCache.essayCache.mutex.RLock()
+	defer nova.cache.questionsCache.essayCache.mutex.RUnlock()
+	// search essay question Id in data cache
+	b := false
+	for _, v := range nova.cache.questionsCache.essayCache.essaySet {
+		if v.Id == id {
+			b = true
+			break
+		}
+	}
+	if !b {
+		return errors.New("essay question not found")
+	}
+	// delete essay question in database
+	if err := nova.db.DeleteQuestionEssay(id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) modifyEssayQuestionInDatabase(id string) error {
+	// enable essay question cache read lock
+	nova.cache.questionsCache.essayCache.mutex.RLock()
+	defer nova.cache.questionsCache.essayCache.mutex.RUnlock()
+	// search essay question Id in data cache
+	b := false
+	question := QuestionEssay{}
+	for _, v := range nova.cache.questionsCache.essayCache.essaySet {
+		if v.Id == id {
+			question = v
+			b = true
+			break
+		}
+	}
+	if !b {
+		return errors.New("essay question not found")
+	}
+	// update essay question in database
+	if err := nova.db.UpdateQuestionEssay(&question); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) queryEssayQuestionInDatabase(id string) error {
+	// enable essay question cache write lock
+	nova.cache.questionsCache.essayCache.mutex.Lock()
+	defer nova.cache.questionsCache.essayCache.mutex.Unlock()
+	// query essay question from database
+	question, err := nova.db.QueryQuestionEssay(id)
+	if err != nil {
+		return err
+	}
+	// update essay question in data cache
+	for k, v := range nova.cache.questionsCache.essayCache.essaySet {
+		if v.Id == id {
+			nova.cache.questionsCache.essayCache.essaySet[k] = *question
+			break
+		}
+	}
+	return nil
+}
+
+func (nova *Nova) updateEssayQuestionInDatabase(id string) error {
+	// enable essay question cache read lock
+	nova.cache.questionsCache.essayCache.mutex.RLock()
+	defer nova.cache.questionsCache.essayCache.mutex.RUnlock()
+	// search essay question in data cache
+	b := false
+	question := QuestionEssay{}
+	for _, v := range nova.cache.questionsCache.essayCache.essaySet {
+		if v.Id == id {
+			question = v
+			b = true
+			break
+		}
+	}
+	if !b {
+		return errors.New("essay question not found")
+	}
+	// update essay question in database
+	if err := nova.db.UpdateQuestionEssay(&question); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) queryEssayQuestionsInDatabase() error {
+	// enable essay question cache write lock
+	nova.cache.questionsCache.essayCache.mutex.Lock()
+	defer nova.cache.questionsCache.essayCache.mutex.Unlock()
+	// query essay question from database
+	questions, err := nova.db.QueryQuestionsEssay()
+	if err != nil {
+		return err
+	}
+	// update essay question in data cache
+	for _, question := range questions {
+		b := false
+		// update if essay question existed
+		for k, v := range nova.cache.questionsCache.essayCache.essaySet {
+			if v.Id == question.Id {
+				nova.cache.questionsCache.essayCache.essaySet[k] = *question
+				b = true
+				break
+			}
+		}
+		// create essay question if question not existed
+		if !b {
+			nova.cache.questionsCache.essayCache.essaySet = append(nova.cache.questionsCache.essayCache.essaySet, *question)
+		}
+	}
+	return nil
+}
+
+func (nova *Nova) createFillBlankQuestionInDataCache(question QuestionFillBlank) {
+	// enable fill-blank question cache write lock
+	nova.cache.questionsCache.fillBlankCache.mutex.Lock()
+	defer nova.cache.questionsCache.fillBlankCache.mutex.Unlock()
+	// append fill-blank question in data cache
+	nova.cache.questionsCache.fillBlankCache.fillBlankSet = append(nova.cache.questionsCache.fillBlankCache.fillBlankSet, question)
+	return
+}
+
+func (nova *Nova) deleteFillBlankQuestionInDataCache(id string) {
+	// enable fill-blank question cache write lock
+	nova.cache.questionsCache.fillBlankCache.mutex.Lock()
+	defer nova.cache.questionsCache.fillBlankCache.mutex.Unlock()
+	// search & delete fill-blank question from data cache
+	for k, v := range nova.cache.questionsCache.fillBlankCache.fillBlankSet {
+		if v.Id == id {
+			nova.cache.questionsCache.fillBlankCache.fillBlankSet = append(nova.cache.questionsCache.fillBlankCache.fillBlankSet[:k], nova.cache.questionsCache.fillBlankCache.fillBlankSet[k+1:]...)
+			break
+		}
+	}
+	return
+}
+
+func (nova *Nova) modifyFillBlankQuestionInDataCache(question QuestionFillBlank) (QuestionFillBlank, error) {
+	// enable fill-blank question cache write lock
+	nova.cache.questionsCache.fillBlankCache.mutex.Lock()
+	defer nova.cache.questionsCache.fillBlankCache.mutex.Unlock()
+	// replace fill-blank question in data cache
+	for k, v := range nova.cache.questionsCache.fillBlankCache.fillBlankSet {
+		if v.Id == question.Id {
+			nova.cache.questionsCache.fillBlankCache.fillBlankSet[k] = question
+			return nova.cache.questionsCache.fillBlankCache.fillBlankSet[k], nil
+		}
+	}
+	return QuestionFillBlank{}, errors.New("fill-blank question not found")
+}
+
+func (nova *Nova) queryFillBlankQuestionInDataCache(id string) (QuestionFillBlank, error) {
+	// enable fill-blank question cache read lock
+	nova.cache.questionsCache.fillBlankCache.mutex.RLock()
+	defer nova.cache.questionsCache.fillBlankCache.mutex.RUnlock()
+	// search & query fill-blank question from data cache
+	for k, v := range nova.cache.questionsCache.fillBlankCache.fillBlankSet {
+		if v.Id == id {
+			return nova.cache.questionsCache.fillBlankCache.fillBlankSet[k], nil
+		}
+	}
+	return QuestionFillBlank{}, errors.New("fill-blank question not found")
+}
+
+func (nova *Nova) updateFillBlankQuestionInDataCache(question QuestionFillBlank) bool {
+	// enable fill-blank question cache write lock
+	nova.cache.questionsCache.fillBlankCache.mutex.Lock()
+	defer nova.cache.questionsCache.fillBlankCache.mutex.Unlock()
+	// replace fill-blank question in data cache
+	for k, v := range nova.cache.questionsCache.fillBlankCache.fillBlankSet {
+		if v.Id == question.Id {
+			// userName and phoneNumber should not be changed
+			nova.cache.questionsCache.fillBlankCache.fillBlankSet[k] = question
+			return true
+		}
+	}
+	return false
+}
+
+func (nova *Nova) createFillBlankQuestionInDatabase(id string) error {
+	// enable fill-blank question cache read lock
+	nova.cache.questionsCache.fillBlankCache.mutex.RLock()
+	defer nova.cache.questionsCache.fillBlankCache.mutex.RUnlock()
+	// search Id in data cache
+	b := false
+	question := QuestionFillBlank{}
+	for _, v := range nova.cache.questionsCache.fillBlankCache.fillBlankSet {
+		if v.Id == id {
+			question = v
+			b = true
+			break
+		}
+	}
+	if !b {
+		return errors.New("fill-blank question not found")
+	}
+	// create fill-blank question in database
+	if _, err := nova.db.CreateQuestionFillBlank(&question); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) deleteFillBlankQuestionInDatabase(id string) error {
+	// enable fill-blank question cache read lock
+	nova.cache.questionsCache.fillBlankCache.mutex.RLock()
+	defer nova.cache.questionsCache.fillBlankCache.mutex.RUnlock()
+	// search fill-blank question Id in data cache
+	b := false
+	for _, v := range nova.cache.questionsCache.fillBlankCache.fillBlankSet {
+		if v.Id == id {
+			b = true
+			break
+		}
+	}
+	if !b {
+		return errors.New("fill-blank question not found")
+	}
+	// delete fill-blank question in database
+	if err := nova.db.DeleteQuestionFillBlank(id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) modifyFillBlankQuestionInDatabase(id string) error {
+	// enable fill-blank question cache read lock
+	nova.cache.questionsCache.fillBlankCache.mutex.RLock()
+	defer nova.cache.questionsCache.fillBlankCache.mutex.RUnlock()
+	// search fill-blank question Id in data cache
+	b := false
+	question := QuestionFillBlank{}
+	for _, v := range nova.cache.questionsCache.fillBlankCache.fillBlankSet {
+		if v.Id == id {
+			question = v
+			b = true
+			break
+		}
+	}
+	if !b {
+		return errors.New("fill-blank question not found")
+	}
+	// update fill-blank question in database
+	if err := nova.db.UpdateQuestionFillBlank(&question); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) queryFillBlankQuestionInDatabase(id string) error {
+	// enable fill-blank question cache write lock
+	nova.cache.questionsCache.fillBlankCache.mutex.Lock()
+	defer nova.cache.questionsCache.fillBlankCache.mutex.Unlock()
+	// query fill-blank question from database
+	question, err := nova.db.QueryQuestionFillBlank(id)
+	if err != nil {
+		return err
+	}
+	// update fill-blank question in data cache
+	for k, v := range nova.cache.questionsCache.fillBlankCache.fillBlankSet {
+		if v.Id == id {
+			nova.cache.questionsCache.fillBlankCache.fillBlankSet[k] = *question
+			break
+		}
+	}
+	return nil
+}
+
+func (nova *Nova) updateFillBlankQuestionInDatabase(id string) error {
+	// enable fill-blank question cache read lock
+	nova.cache.questionsCache.fillBlankCache.mutex.RLock()
+	defer nova.cache.questionsCache.fillBlankCache.mutex.RUnlock()
+	// search fill-blank question in data cache
+	b := false
+	question := QuestionFillBlank{}
+	for _, v := range nova.cache.questionsCache.fillBlankCache.fillBlankSet {
+		if v.Id == id {
+			question = v
+			b = true
+			break
+		}
+	}
+	if !b {
+		return errors.New("fill-blank question not found")
+	}
+	// update fill-blank question in database
+	if err := nova.db.UpdateQuestionFillBlank(&question); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nova *Nova) queryFillBlankQuestionsInDatabase() error {
+	// enable fill-blank question cache write lock
+	nova.cache.questionsCache.fillBlankCache.mutex.Lock()
+	defer nova.cache.questionsCache.fillBlankCache.mutex.Unlock()
+	// query fill-blank question from database
+	questions, err := nova.db.QueryQuestionsFillBlank()
+	if err != nil {
+		return err
+	}
+	// update fill-blank question in data cache
+	for _, question := range questions {
+		b := false
+		// update if fill-blank question existed
+		for k, v := range nova.cache.questionsCache.fillBlankCache.fillBlankSet {
+			if v.Id == question.Id {
+				nova.cache.questionsCache.fillBlankCache.fillBlankSet[k] = *question
+				b = true
+				break
+			}
+		}
+		// create fill-blank question if question not existed
+		if !b {
+			nova.cache.questionsCache.fillBlankCache.fillBlankSet = append(nova.cache.questionsCache.fillBlankCache.fillBlankSet, *question)
+		}
+	}
+	return nil
+}
+
+func (nova *Nova) createMatchingQuestionInDataCache(question QuestionMatching) {
+	// enable matching question cache write lock
+	nova.cache.questionsCache.matchingCache.mutex.Lock()
+	defer nova.cache.questionsCache.matchingCache.mutex.Unlock()
+	// append matching question in data cache
+	nova.cache.questionsCache.matchingCache.matchingSet = append(nova.cache.questionsCache.matchingCache.matchingSet, question)
+	return
+}
+
+func (nova *Nova) deleteMatchingQuestionInDataCache(id string) {
+	// enable matching question cache write lock
+	nova.cache.questionsCache.matchingCache.mutex.Lock()
+	defer nova.cache.questionsCache.matchingCache.mutex.Unlock()
+	// search & delete matching question from data cache
+	for k, v := range nova.cache.questionsCache.matchingCache.matchingSet {
 		if v.Id == id {
-			return true
+			nova.cache.questionsCache.matchingCache.matchingSet = append(nova.cache.questionsCache.matchingCache.matchingSet[:k], nova.cache.questionsCache.matchingCache.matchingSet[k+1:]...)
+			break
 		}
 	}
-	return false
+	return
 }
 
-func (nova *Nova) isMultipleChoiceQuestionExisted(id string) bool {
-	// enable multiple-choice question cache read lock
-	nova.cache.questionsCache.multipleChoiceCache.mutex.RLock()
-	defer nova.cache.questionsCache.multipleChoiceCache.mutex.RUnlock()
-	// search Id in data cache
-	for _, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+func (nova *Nova) modifyMatchingQuestionInDataCache(question QuestionMatching) (QuestionMatching, error) {
+	// enable matching question cache write lock
+	nova.cache.questionsCache.matchingCache.mutex.Lock()
+	defer nova.cache.questionsCache.matchingCache.mutex.Unlock()
+	// replace matching question in data cache
+	for k, v := range nova.cache.questionsCache.matchingCache.matchingSet {
+		if v.Id == question.Id {
+			nova.cache.questionsCache.matchingCache.matchingSet[k] = question
+			return nova.cache.questionsCache.matchingCache.matchingSet[k], nil
+		}
+	}
+	return QuestionMatching{}, errors.New("matching question not found")
+}
+
+func (nova *Nova) queryMatchingQuestionInDataCache(id string) (QuestionMatching, error) {
+	// enable matching question cache read lock
+	nova.cache.questionsCache.matchingCache.mutex.RLock()
+	defer nova.cache.questionsCache.matchingCache.mutex.RUnlock()
+	// search & query matching question from data cache
+	for k, v := range nova.cache.questionsCache.matchingCache.matchingSet {
 		if v.Id == id {
+			return nova.cache.questionsCache.matchingCache.matchingSet[k], nil
+		}
+	}
+	return QuestionMatching{}, errors.New("matching question not found")
+}
+
+func (nova *Nova) updateMatchingQuestionInDataCache(question QuestionMatching) bool {
+	// enable matching question cache write lock
+	nova.cache.questionsCache.matchingCache.mutex.Lock()
+	defer nova.cache.questionsCache.matchingCache.mutex.Unlock()
+	// replace matching question in data cache
+	for k, v := range nova.cache.questionsCache.matchingCache.matchingSet {
+		if v.Id == question.Id {
+			// userName and phoneNumber should not be changed
+			nova.cache.questionsCache.matchingCache.matchingSet[k] = question
 			return true
 		}
 	}
 	return false
 }
 
-func (nova *Nova) isJudgementQuestionExisted(id string) bool {
-	// enable judgement question cache read lock
-	nova.cache.questionsCache.judgementCache.mutex.RLock()
-	defer nova.cache.questionsCache.judgementCache.mutex.RUnlock()
+func (nova *Nova) createMatchingQuestionInDatabase(id string) error {
+	// enable matching question cache read lock
+	nova.cache.questionsCache.matchingCache.mutex.RLock()
+	defer nova.cache.questionsCache.matchingCache.mutex.RUnlock()
 	// search Id in data cache
-	for _, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+	b := false
+	question := QuestionMatching{}
+	for _, v := range nova.cache.questionsCache.matchingCache.matchingSet {
 		if v.Id == id {
-			return true
+			question = v
+			b = true
+			break
 		}
 	}
-	return false
+	if !b {
+		return errors.New("matching question not found")
+	}
+	// create matching question in database
+	if _, err := nova.db.CreateQuestionMatching(&question); err != nil {
+		return err
+	}
+	return nil
 }
 
-func (nova *Nova) isEssayQuestionExisted(id string) bool {
-	// enable essay question cache read lock
-	nova.cache.questionsCache.essayCache.mutex.RLock()
-	defer nova.cache.questionsCache.essayCache.mutex.RUnlock()
-	// search Id in data cache
-	for _, v := range nova.cache.questionsCache.essayCache.essaySet {
+func (nova *Nova) deleteMatchingQuestionInDatabase(id string) error {
+	// enable matching question cache read lock
+	nova.cache.questionsCache.matchingCache.mutex.RLock()
+	defer nova.cache.questionsCache.matchingCache.mutex.RUnlock()
+	// search matching question Id in data cache
+	b := false
+	for _, v := range nova.cache.questionsCache.matchingCache.matchingSet {
 		if v.Id == id {
-			return true
+			b = true
+			break
 		}
 	}
-	return false
+	if !b {
+		return errors.New("matching question not found")
+	}
+	// delete matching question in database
+	if err := nova.db.DeleteQuestionMatching(id); err != nil {
+		return err
+	}
+	return nil
 }
 
-func (nova *Nova) isSingleChoiceQuestionValidate(question QuestionSingleChoice) (bool, error) {
-	// check question identity format is UUID
-	err := uuid.Validate(question.Id)
-	if err != nil {
-		return false, err
+func (nova *Nova) modifyMatchingQuestionInDatabase(id string) error {
+	// enable matching question cache read lock
+	nova.cache.questionsCache.matchingCache.mutex.RLock()
+	defer nova.cache.questionsCache.matchingCache.mutex.RUnlock()
+	// search matching question Id in data cache
+	b := false
+	question := QuestionMatching{}
+	for _, v := range nova.cache.questionsCache.matchingCache.matchingSet {
+		if v.Id == id {
+			question = v
+			b = true
+			break
+		}
 	}
-	return true, nil
+	if !b {
+		return errors.New("matching question not found")
+	}
+	// update matching question in database
+	if err := nova.db.UpdateQuestionMatching(&question); err != nil {
+		return err
+	}
+	return nil
 }
 
-func (nova *Nova) isMultipleChoiceQuestionValidate(question QuestionMultipleChoice) (bool, error) {
-	// check question identity format is UUID
-	err := uuid.Validate(question.Id)
+func (nova *Nova) queryMatchingQuestionInDatabase(id string) error {
+	// enable matching question cache write lock
+	nova.cache.questionsCache.matchingCache.mutex.Lock()
+	defer nova.cache.questionsCache.matchingCache.mutex.Unlock()
+	// query matching question from database
+	question, err := nova.db.QueryQuestionMatching(id)
 	if err != nil {
-		return false, err
+		return err
 	}
-	return true, nil
+	// update matching question in data cache
+	for k, v := range nova.cache.questionsCache.matchingCache.matchingSet {
+		if v.Id == id {
+			nova.cache.questionsCache.matchingCache.matchingSet[k] = *question
+			break
+		}
+	}
+	return nil
 }
 
-func (nova *Nova) isJudgementQuestionValidate(question QuestionJudgement) (bool, error) {
-	// check question identity format is UUID
-	err := uuid.Validate(question.Id)
-	if err != nil {
-		return false, err
+func (nova *Nova) updateMatchingQuestionInDatabase(id string) error {
+	// enable matching question cache read lock
+	nova.cache.questionsCache.matchingCache.mutex.RLock()
+	defer nova.cache.questionsCache.matchingCache.mutex.RUnlock()
+	// search matching question in data cache
+	b := false
+	question := QuestionMatching{}
+	for _, v := range nova.cache.questionsCache.matchingCache.matchingSet {
+		if v.Id == id {
+			question = v
+			b = true
+			break
+		}
 	}
-	return true, nil
+	if !b {
+		return errors.New("matching question not found")
+	}
+	// update matching question in database
+	if err := nova.db.UpdateQuestionMatching(&question); err != nil {
+		return err
+	}
+	return nil
 }
 
-func (nova *Nova) isEssayQuestionValidate(question QuestionEssay) (bool, error) {
-	// check question identity format is UUID
-	err := uuid.Validate(question.Id)
+func (nova *Nova) queryMatchingQuestionsInDatabase() error {
+	// enable matching question cache write lock
+	nova.cache.questionsCache.matchingCache.mutex.Lock()
+	defer nova.cache.questionsCache.matchingCache.mutex.Unlock()
+	// query matching question from database
+	questions, err := nova.db.QueryQuestionsMatching()
 	if err != nil {
-		return false, err
+		return err
 	}
-	return true, nil
+	// update matching question in data cache
+	for _, question := range questions {
+		b := false
+		// update if matching question existed
+		for k, v := range nova.cache.questionsCache.matchingCache.matchingSet {
+			if v.Id == question.Id {
+				nova.cache.questionsCache.matchingCache.matchingSet[k] = *question
+				b = true
+				break
+			}
+		}
+		// create matching question if question not existed
+		if !b {
+			nova.cache.questionsCache.matchingCache.matchingSet = append(nova.cache.questionsCache.matchingCache.matchingSet, *question)
+		}
+	}
+	return nil
 }
 
-func (nova *Nova) createSingleChoiceQuestionInDataCache(question QuestionSingleChoice) {
-	// enable single-choice question cache write lock
-	nova.cache.questionsCache.singleChoiceCache.mutex.Lock()
-	defer nova.cache.questionsCache.singleChoiceCache.mutex.Unlock()
-	// append single-choice question in data cache
-	nova.cache.questionsCache.singleChoiceCache.singleChoiceSet = append(nova.cache.questionsCache.singleChoiceCache.singleChoiceSet, question)
+func (nova *Nova) createOrderingQuestionInDataCache(question QuestionOrdering) {
+	// enable ordering question cache write lock
+	nova.cache.questionsCache.orderingCache.mutex.Lock()
+	defer nova.cache.questionsCache.orderingCache.mutex.Unlock()
+	// append ordering question in data cache
+	nova.cache.questionsCache.orderingCache.orderingSet = append(nova.cache.questionsCache.orderingCache.orderingSet, question)
 	return
 }
 
-func (nova *Nova) deleteSingleChoiceQuestionInDataCache(id string) {
-	// enable single-choice question cache write lock
-	nova.cache.questionsCache.singleChoiceCache.mutex.Lock()
-	defer nova.cache.questionsCache.singleChoiceCache.mutex.Unlock()
-	// search & delete single-choice question from data cache
-	for k, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+func (nova *Nova) deleteOrderingQuestionInDataCache(id string) {
+	// enable ordering question cache write lock
+	nova.cache.questionsCache.orderingCache.mutex.Lock()
+	defer nova.cache.questionsCache.orderingCache.mutex.Unlock()
+	// search & delete ordering question from data cache
+	for k, v := range nova.cache.questionsCache.orderingCache.orderingSet {
 		if v.Id == id {
-			nova.cache.questionsCache.singleChoiceCache.singleChoiceSet = append(nova.cache.questionsCache.singleChoiceCache.singleChoiceSet[:k], nova.cache.questionsCache.singleChoiceCache.singleChoiceSet[k+1:]...)
+			nova.cache.questionsCache.orderingCache.orderingSet = append(nova.cache.questionsCache.orderingCache.orderingSet[:k], nova.cache.questionsCache.orderingCache.orderingSet[k+1:]...)
 			break
 		}
 	}
 	return
 }
 
-func (nova *Nova) modifySingleChoiceQuestionInDataCache(question QuestionSingleChoice) (QuestionSingleChoice, error) {
-	// enable single choice question cache write lock
-	nova.cache.questionsCache.singleChoiceCache.mutex.Lock()
-	defer nova.cache.questionsCache.singleChoiceCache.mutex.Unlock()
-	// replace single choice question in data cache
-	for k, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+func (nova *Nova) modifyOrderingQuestionInDataCache(question QuestionOrdering) (QuestionOrdering, error) {
+	// enable ordering question cache write lock
+	nova.cache.questionsCache.orderingCache.mutex.Lock()
+	defer nova.cache.questionsCache.orderingCache.mutex.Unlock()
+	// replace ordering question in data cache
+	for k, v := range nova.cache.questionsCache.orderingCache.orderingSet {
 		if v.Id == question.Id {
-			nova.cache.questionsCache.singleChoiceCache.singleChoiceSet[k] = question
-			return nova.cache.questionsCache.singleChoiceCache.singleChoiceSet[k], nil
+			nova.cache.questionsCache.orderingCache.orderingSet[k] = question
+			return nova.cache.questionsCache.orderingCache.orderingSet[k], nil
 		}
 	}
-	return QuestionSingleChoice{}, errors.New("single choice question not found")
+	return QuestionOrdering{}, errors.New("ordering question not found")
 }
 
-func (nova *Nova) querySingleChoiceQuestionInDataCache(id string) (QuestionSingleChoice, error) {
-	// enable single-choice question cache read lock
-	nova.cache.questionsCache.singleChoiceCache.mutex.RLock()
-	defer nova.cache.questionsCache.singleChoiceCache.mutex.RUnlock()
-	// search & query single-choice question from data cache
-	for k, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+func (nova *Nova) queryOrderingQuestionInDataCache(id string) (QuestionOrdering, error) {
+	// enable ordering question cache read lock
+	nova.cache.questionsCache.orderingCache.mutex.RLock()
+	defer nova.cache.questionsCache.orderingCache.mutex.RUnlock()
+	// search & query ordering question from data cache
+	for k, v := range nova.cache.questionsCache.orderingCache.orderingSet {
 		if v.Id == id {
-			return nova.cache.questionsCache.singleChoiceCache.singleChoiceSet[k], nil
+			return nova.cache.questionsCache.orderingCache.orderingSet[k], nil
 		}
 	}
-	return QuestionSingleChoice{}, errors.New("single-choice question not found")
+	return QuestionOrdering{}, errors.New("ordering question not found")
 }
 
-func (nova *Nova) updateSingleChoiceQuestionInDataCache(question QuestionSingleChoice) bool {
-	// enable single-choice question cache write lock
-	nova.cache.questionsCache.singleChoiceCache.mutex.Lock()
-	defer nova.cache.questionsCache.singleChoiceCache.mutex.Unlock()
-	// replace single-choice question in data cache
-	for k, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+func (nova *Nova) updateOrderingQuestionInDataCache(question QuestionOrdering) bool {
+	// enable ordering question cache write lock
+	nova.cache.questionsCache.orderingCache.mutex.Lock()
+	defer nova.cache.questionsCache.orderingCache.mutex.Unlock()
+	// replace ordering question in data cache
+	for k, v := range nova.cache.questionsCache.orderingCache.orderingSet {
 		if v.Id == question.Id {
 			// userName and phoneNumber should not be changed
-			nova.cache.questionsCache.singleChoiceCache.singleChoiceSet[k] = question
+			nova.cache.questionsCache.orderingCache.orderingSet[k] = question
 			return true
 		}
 	}
 	return false
 }
 
-func (nova *Nova) createSingleChoiceQuestionInDatabase(id string) error {
-	// enable single-choice question cache read lock
-	nova.cache.questionsCache.singleChoiceCache.mutex.RLock()
-	defer nova.cache.questionsCache.singleChoiceCache.mutex.RUnlock()
+func (nova *Nova) createOrderingQuestionInDatabase(id string) error {
+	// enable ordering question cache read lock
+	nova.cache.questionsCache.orderingCache.mutex.RLock()
+	defer nova.cache.questionsCache.orderingCache.mutex.RUnlock()
 	// search Id in data cache
 	b := false
-	question := QuestionSingleChoice{}
-	for _, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+	question := QuestionOrdering{}
+	for _, v := range nova.cache.questionsCache.orderingCache.orderingSet {
 		if v.Id == id {
 			question = v
 			b = true
@@ -1320,45 +6000,45 @@ func (nova *Nova) createSingleChoiceQuestionInDatabase(id string) error {
 		}
 	}
 	if !b {
-		return errors.New("single-choice question not found")
+		return errors.New("ordering question not found")
 	}
-	// create single-choice question in database
-	if _, err := nova.db.CreateQuestionSingleChoice(&question); err != nil {
+	// create ordering question in database
+	if _, err := nova.db.CreateQuestionOrdering(&question); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (nova *Nova) deleteSingleChoiceQuestionInDatabase(id string) error {
-	// enable single choice question cache read lock
-	nova.cache.questionsCache.singleChoiceCache.mutex.RLock()
-	defer nova.cache.questionsCache.singleChoiceCache.mutex.RUnlock()
-	// search single choice question Id in data cache
+func (nova *Nova) deleteOrderingQuestionInDatabase(id string) error {
+	// enable ordering question cache read lock
+	nova.cache.questionsCache.orderingCache.mutex.RLock()
+	defer nova.cache.questionsCache.orderingCache.mutex.RUnlock()
+	// search ordering question Id in data cache
 	b := false
-	for _, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+	for _, v := range nova.cache.questionsCache.orderingCache.orderingSet {
 		if v.Id == id {
 			b = true
 			break
 		}
 	}
 	if !b {
-		return errors.New("single-choice question not found")
+		return errors.New("ordering question not found")
 	}
-	// delete single-choice question in database
-	if err := nova.db.DeleteQuestionSingleChoice(id); err != nil {
+	// delete ordering question in database
+	if err := nova.db.DeleteQuestionOrdering(id); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (nova *Nova) modifySingleChoiceQuestionInDatabase(id string) error {
-	// enable single choice question cache read lock
-	nova.cache.questionsCache.singleChoiceCache.mutex.RLock()
-	defer nova.cache.questionsCache.singleChoiceCache.mutex.RUnlock()
-	// search single choice question Id in data cache
+func (nova *Nova) modifyOrderingQuestionInDatabase(id string) error {
+	// enable ordering question cache read lock
+	nova.cache.questionsCache.orderingCache.mutex.RLock()
+	defer nova.cache.questionsCache.orderingCache.mutex.RUnlock()
+	// search ordering question Id in data cache
 	b := false
-	question := QuestionSingleChoice{}
-	for _, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+	question := QuestionOrdering{}
+	for _, v := range nova.cache.questionsCache.orderingCache.orderingSet {
 		if v.Id == id {
 			question = v
 			b = true
@@ -1366,42 +6046,42 @@ func (nova *Nova) modifySingleChoiceQuestionInDatabase(id string) error {
 		}
 	}
 	if !b {
-		return errors.New("single-choice question not found")
+		return errors.New("ordering question not found")
 	}
-	// update single choice question in database
-	if err := nova.db.UpdateQuestionSingleChoice(&question); err != nil {
+	// update ordering question in database
+	if err := nova.db.UpdateQuestionOrdering(&question); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (nova *Nova) querySingleChoiceQuestionInDatabase(id string) error {
-	// enable single-choice question cache write lock
-	nova.cache.questionsCache.singleChoiceCache.mutex.Lock()
-	defer nova.cache.questionsCache.singleChoiceCache.mutex.Unlock()
-	// query single-choice question from database
-	question, err := nova.db.QueryQuestionSingleChoice(id)
+func (nova *Nova) queryOrderingQuestionInDatabase(id string) error {
+	// enable ordering question cache write lock
+	nova.cache.questionsCache.orderingCache.mutex.Lock()
+	defer nova.cache.questionsCache.orderingCache.mutex.Unlock()
+	// query ordering question from database
+	question, err := nova.db.QueryQuestionOrdering(id)
 	if err != nil {
 		return err
 	}
-	// update single-choice question in data cache
-	for k, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+	// update ordering question in data cache
+	for k, v := range nova.cache.questionsCache.orderingCache.orderingSet {
 		if v.Id == id {
-			nova.cache.questionsCache.singleChoiceCache.singleChoiceSet[k] = *question
+			nova.cache.questionsCache.orderingCache.orderingSet[k] = *question
 			break
 		}
 	}
 	return nil
 }
 
-func (nova *Nova) updateSingleChoiceQuestionInDatabase(id string) error {
-	// enable single-choice question cache read lock
-	nova.cache.questionsCache.singleChoiceCache.mutex.RLock()
-	defer nova.cache.questionsCache.singleChoiceCache.mutex.RUnlock()
-	// search single-choice question in data cache
+func (nova *Nova) updateOrderingQuestionInDatabase(id string) error {
+	// enable ordering question cache read lock
+	nova.cache.questionsCache.orderingCache.mutex.RLock()
+	defer nova.cache.questionsCache.orderingCache.mutex.RUnlock()
+	// search ordering question in data cache
 	b := false
-	question := QuestionSingleChoice{}
-	for _, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+	question := QuestionOrdering{}
+	for _, v := range nova.cache.questionsCache.orderingCache.orderingSet {
 		if v.Id == id {
 			question = v
 			b = true
@@ -1409,116 +6089,116 @@ func (nova *Nova) updateSingleChoiceQuestionInDatabase(id string) error {
 		}
 	}
 	if !b {
-		return errors.New("single-choice question not found")
+		return errors.New("ordering question not found")
 	}
-	// update single-choice question in database
-	if err := nova.db.UpdateQuestionSingleChoice(&question); err != nil {
+	// update ordering question in database
+	if err := nova.db.UpdateQuestionOrdering(&question); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (nova *Nova) querySingleChoiceQuestionsInDatabase() error {
-	// enable single-choice question cache write lock
-	nova.cache.questionsCache.singleChoiceCache.mutex.Lock()
-	defer nova.cache.questionsCache.singleChoiceCache.mutex.Unlock()
-	// query single-choice question from database
-	questions, err := nova.db.QueryQuestionsSingleChoice()
+func (nova *Nova) queryOrderingQuestionsInDatabase() error {
+	// enable ordering question cache write lock
+	nova.cache.questionsCache.orderingCache.mutex.Lock()
+	defer nova.cache.questionsCache.orderingCache.mutex.Unlock()
+	// query ordering question from database
+	questions, err := nova.db.QueryQuestionsOrdering()
 	if err != nil {
 		return err
 	}
-	// update single-choice question in data cache
+	// update ordering question in data cache
 	for _, question := range questions {
 		b := false
-		// update if single-choice question existed
-		for k, v := range nova.cache.questionsCache.singleChoiceCache.singleChoiceSet {
+		// update if ordering question existed
+		for k, v := range nova.cache.questionsCache.orderingCache.orderingSet {
 			if v.Id == question.Id {
-				nova.cache.questionsCache.singleChoiceCache.singleChoiceSet[k] = *question
+				nova.cache.questionsCache.orderingCache.orderingSet[k] = *question
 				b = true
 				break
 			}
 		}
-		// create single-choice question if question not existed
+		// create ordering question if question not existed
 		if !b {
-			nova.cache.questionsCache.singleChoiceCache.singleChoiceSet = append(nova.cache.questionsCache.singleChoiceCache.singleChoiceSet, *question)
+			nova.cache.questionsCache.orderingCache.orderingSet = append(nova.cache.questionsCache.orderingCache.orderingSet, *question)
 		}
 	}
 	return nil
 }
 
-func (nova *Nova) createMultipleChoiceQuestionInDataCache(question QuestionMultipleChoice) {
-	// enable multiple-choice question cache write lock
-	nova.cache.questionsCache.multipleChoiceCache.mutex.Lock()
-	defer nova.cache.questionsCache.multipleChoiceCache.mutex.Unlock()
-	// append multiple-choice question in data cache
-	nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet = append(nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet, question)
+func (nova *Nova) createNumericQuestionInDataCache(question QuestionNumeric) {
+	// enable numeric question cache write lock
+	nova.cache.questionsCache.numericCache.mutex.Lock()
+	defer nova.cache.questionsCache.numericCache.mutex.Unlock()
+	// append numeric question in data cache
+	nova.cache.questionsCache.numericCache.numericSet = append(nova.cache.questionsCache.numericCache.numericSet, question)
 	return
 }
 
-func (nova *Nova) deleteMultipleChoiceQuestionInDataCache(id string) {
-	// enable multiple-choice question cache write lock
-	nova.cache.questionsCache.multipleChoiceCache.mutex.Lock()
-	defer nova.cache.questionsCache.multipleChoiceCache.mutex.Unlock()
-	// search & delete multiple-choice question from data cache
-	for k, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+func (nova *Nova) deleteNumericQuestionInDataCache(id string) {
+	// enable numeric question cache write lock
+	nova.cache.questionsCache.numericCache.mutex.Lock()
+	defer nova.cache.questionsCache.numericCache.mutex.Unlock()
+	// search & delete numeric question from data cache
+	for k, v := range nova.cache.questionsCache.numericCache.numericSet {
 		if v.Id == id {
-			nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet = append(nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet[:k], nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet[k+1:]...)
+			nova.cache.questionsCache.numericCache.numericSet = append(nova.cache.questionsCache.numericCache.numericSet[:k], nova.cache.questionsCache.numericCache.numericSet[k+1:]...)
 			break
 		}
 	}
 	return
 }
 
-func (nova *Nova) modifyMultipleChoiceQuestionInDataCache(question QuestionMultipleChoice) (QuestionMultipleChoice, error) {
-	// enable multiple choice question cache write lock
-	nova.cache.questionsCache.multipleChoiceCache.mutex.Lock()
-	defer nova.cache.questionsCache.multipleChoiceCache.mutex.Unlock()
-	// replace multiple choice question in data cache
-	for k, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+func (nova *Nova) modifyNumericQuestionInDataCache(question QuestionNumeric) (QuestionNumeric, error) {
+	// enable numeric question cache write lock
+	nova.cache.questionsCache.numericCache.mutex.Lock()
+	defer nova.cache.questionsCache.numericCache.mutex.Unlock()
+	// replace numeric question in data cache
+	for k, v := range nova.cache.questionsCache.numericCache.numericSet {
 		if v.Id == question.Id {
-			nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet[k] = question
-			return nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet[k], nil
+			nova.cache.questionsCache.numericCache.numericSet[k] = question
+			return nova.cache.questionsCache.numericCache.numericSet[k], nil
 		}
 	}
-	return QuestionMultipleChoice{}, errors.New("multiple choice question not found")
+	return QuestionNumeric{}, errors.New("numeric question not found")
 }
 
-func (nova *Nova) queryMultipleChoiceQuestionInDataCache(id string) (QuestionMultipleChoice, error) {
-	// enable multiple-choice question cache read lock
-	nova.cache.questionsCache.multipleChoiceCache.mutex.RLock()
-	defer nova.cache.questionsCache.multipleChoiceCache.mutex.RUnlock()
-	// search & query multiple-choice question from data cache
-	for k, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+func (nova *Nova) queryNumericQuestionInDataCache(id string) (QuestionNumeric, error) {
+	// enable numeric question cache read lock
+	nova.cache.questionsCache.numericCache.mutex.RLock()
+	defer nova.cache.questionsCache.numericCache.mutex.RUnlock()
+	// search & query numeric question from data cache
+	for k, v := range nova.cache.questionsCache.numericCache.numericSet {
 		if v.Id == id {
-			return nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet[k], nil
-		}
-	}
-	return QuestionMultipleChoice{}, errors.New("multiple-choice question not found")
-}
-
-func (nova *Nova) updateMultipleChoiceQuestionInDataCache(question QuestionMultipleChoice) bool {
-	// enable multiple-choice question cache write lock
-	nova.cache.questionsCache.multipleChoiceCache.mutex.Lock()
-	defer nova.cache.questionsCache.multipleChoiceCache.mutex.Unlock()
-	// replace multiple-choice question in data cache
-	for k, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+			return nova.cache.questionsCache.numericCache.numericSet[k], nil
+		}
+	}
+	return QuestionNumeric{}, errors.New("numeric question not found")
+}
+
+func (nova *Nova) updateNumericQuestionInDataCache(question QuestionNumeric) bool {
+	// enable numeric question cache write lock
+	nova.cache.questionsCache.numericCache.mutex.Lock()
+	defer nova.cache.questionsCache.numericCache.mutex.Unlock()
+	// replace numeric question in data cache
+	for k, v := range nova.cache.questionsCache.numericCache.numericSet {
 		if v.Id == question.Id {
 			// userName and phoneNumber should not be changed
-			nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet[k] = question
+			nova.cache.questionsCache.numericCache.numericSet[k] = question
 			return true
 		}
 	}
 	return false
 }
 
-func (nova *Nova) createMultipleChoiceQuestionInDatabase(id string) error {
-	// enable multiple-choice question cache read lock
-	nova.cache.questionsCache.multipleChoiceCache.mutex.RLock()
-	defer nova.cache.questionsCache.multipleChoiceCache.mutex.RUnlock()
+func (nova *Nova) createNumericQuestionInDatabase(id string) error {
+	// enable numeric question cache read lock
+	nova.cache.questionsCache.numericCache.mutex.RLock()
+	defer nova.cache.questionsCache.numericCache.mutex.RUnlock()
 	// search Id in data cache
 	b := false
-	question := QuestionMultipleChoice{}
-	for _, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+	question := QuestionNumeric{}
+	for _, v := range nova.cache.questionsCache.numericCache.numericSet {
 		if v.Id == id {
 			question = v
 			b = true
@@ -1526,45 +6206,45 @@ func (nova *Nova) createMultipleChoiceQuestionInDatabase(id string) error {
 		}
 	}
 	if !b {
-		return errors.New("multiple-choice question not found")
+		return errors.New("numeric question not found")
 	}
-	// create multiple-choice question in database
-	if _, err := nova.db.CreateQuestionMultipleChoice(&question); err != nil {
+	// create numeric question in database
+	if _, err := nova.db.CreateQuestionNumeric(&question); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (nova *Nova) deleteMultipleChoiceQuestionInDatabase(id string) error {
-	// enable multiple-choice question cache read lock
-	nova.cache.questionsCache.multipleChoiceCache.mutex.RLock()
-	defer nova.cache.questionsCache.multipleChoiceCache.mutex.RUnlock()
-	// search multiple-choice question Id in data cache
+func (nova *Nova) deleteNumericQuestionInDatabase(id string) error {
+	// enable numeric question cache read lock
+	nova.cache.questionsCache.numericCache.mutex.RLock()
+	defer nova.cache.questionsCache.numericCache.mutex.RUnlock()
+	// search numeric question Id in data cache
 	b := false
-	for _, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+	for _, v := range nova.cache.questionsCache.numericCache.numericSet {
 		if v.Id == id {
 			b = true
 			break
 		}
 	}
 	if !b {
-		return errors.New("multiple-choice question not found")
+		return errors.New("numeric question not found")
 	}
-	// delete multiple-choice question in database
-	if err := nova.db.DeleteQuestionMultipleChoice(id); err != nil {
+	// delete numeric question in database
+	if err := nova.db.DeleteQuestionNumeric(id); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (nova *Nova) modifyMultipleChoiceQuestionInDatabase(id string) error {
-	// enable multiple choice question cache read lock
-	nova.cache.questionsCache.multipleChoiceCache.mutex.RLock()
-	defer nova.cache.questionsCache.multipleChoiceCache.mutex.RUnlock()
-	// search multiple choice question Id in data cache
+func (nova *Nova) modifyNumericQuestionInDatabase(id string) error {
+	// enable numeric question cache read lock
+	nova.cache.questionsCache.numericCache.mutex.RLock()
+	defer nova.cache.questionsCache.numericCache.mutex.RUnlock()
+	// search numeric question Id in data cache
 	b := false
-	question := QuestionMultipleChoice{}
-	for _, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+	question := QuestionNumeric{}
+	for _, v := range nova.cache.questionsCache.numericCache.numericSet {
 		if v.Id == id {
 			question = v
 			b = true
@@ -1572,42 +6252,42 @@ func (nova *Nova) modifyMultipleChoiceQuestionInDatabase(id string) error {
 		}
 	}
 	if !b {
-		return errors.New("multiple-choice question not found")
+		return errors.New("numeric question not found")
 	}
-	// update multiple choice question in database
-	if err := nova.db.UpdateQuestionMultipleChoice(&question); err != nil {
+	// update numeric question in database
+	if err := nova.db.UpdateQuestionNumeric(&question); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (nova *Nova) queryMultipleChoiceQuestionInDatabase(id string) error {
-	// enable multiple-choice question cache write lock
-	nova.cache.questionsCache.multipleChoiceCache.mutex.Lock()
-	defer nova.cache.questionsCache.multipleChoiceCache.mutex.Unlock()
-	// query multiple-choice question from database
-	question, err := nova.db.QueryQuestionMultipleChoice(id)
+func (nova *Nova) queryNumericQuestionInDatabase(id string) error {
+	// enable numeric question cache write lock
+	nova.cache.questionsCache.numericCache.mutex.Lock()
+	defer nova.cache.questionsCache.numericCache.mutex.Unlock()
+	// query numeric question from database
+	question, err := nova.db.QueryQuestionNumeric(id)
 	if err != nil {
 		return err
 	}
-	// update multiple-choice question in data cache
-	for k, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+	// update numeric question in data cache
+	for k, v := range nova.cache.questionsCache.numericCache.numericSet {
 		if v.Id == id {
-			nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet[k] = *question
+			nova.cache.questionsCache.numericCache.numericSet[k] = *question
 			break
 		}
 	}
 	return nil
 }
 
-func (nova *Nova) updateMultipleChoiceQuestionInDatabase(id string) error {
-	// enable multiple-choice question cache read lock
-	nova.cache.questionsCache.multipleChoiceCache.mutex.RLock()
-	defer nova.cache.questionsCache.multipleChoiceCache.mutex.RUnlock()
-	// search multiple-choice question in data cache
+func (nova *Nova) updateNumericQuestionInDatabase(id string) error {
+	// enable numeric question cache read lock
+	nova.cache.questionsCache.numericCache.mutex.RLock()
+	defer nova.cache.questionsCache.numericCache.mutex.RUnlock()
+	// search numeric question in data cache
 	b := false
-	question := QuestionMultipleChoice{}
-	for _, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+	question := QuestionNumeric{}
+	for _, v := range nova.cache.questionsCache.numericCache.numericSet {
 		if v.Id == id {
 			question = v
 			b = true
@@ -1615,116 +6295,116 @@ func (nova *Nova) updateMultipleChoiceQuestionInDatabase(id string) error {
 		}
 	}
 	if !b {
-		return errors.New("multiple-choice question not found")
+		return errors.New("numeric question not found")
 	}
-	// update multiple-choice question in database
-	if err := nova.db.UpdateQuestionMultipleChoice(&question); err != nil {
+	// update numeric question in database
+	if err := nova.db.UpdateQuestionNumeric(&question); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (nova *Nova) queryMultipleChoiceQuestionsInDatabase() error {
-	// enable multiple-choice question cache write lock
-	nova.cache.questionsCache.multipleChoiceCache.mutex.Lock()
-	defer nova.cache.questionsCache.multipleChoiceCache.mutex.Unlock()
-	// query multiple-choice question from database
-	questions, err := nova.db.QueryQuestionsMultipleChoice()
+func (nova *Nova) queryNumericQuestionsInDatabase() error {
+	// enable numeric question cache write lock
+	nova.cache.questionsCache.numericCache.mutex.Lock()
+	defer nova.cache.questionsCache.numericCache.mutex.Unlock()
+	// query numeric question from database
+	questions, err := nova.db.QueryQuestionsNumeric()
 	if err != nil {
 		return err
 	}
-	// update multiple-choice question in data cache
+	// update numeric question in data cache
 	for _, question := range questions {
 		b := false
-		// update if multiple-choice question existed
-		for k, v := range nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet {
+		// update if numeric question existed
+		for k, v := range nova.cache.questionsCache.numericCache.numericSet {
 			if v.Id == question.Id {
-				nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet[k] = *question
+				nova.cache.questionsCache.numericCache.numericSet[k] = *question
 				b = true
 				break
 			}
 		}
-		// create multiple-choice question if question not existed
+		// create numeric question if question not existed
 		if !b {
-			nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet = append(nova.cache.questionsCache.multipleChoiceCache.multipleChoiceSet, *question)
+			nova.cache.questionsCache.numericCache.numericSet = append(nova.cache.questionsCache.numericCache.numericSet, *question)
 		}
 	}
 	return nil
 }
 
-func (nova *Nova) createJudgementQuestionInDataCache(question QuestionJudgement) {
-	// enable judgement question cache write lock
-	nova.cache.questionsCache.judgementCache.mutex.Lock()
-	defer nova.cache.questionsCache.judgementCache.mutex.Unlock()
-	// append judgement question in data cache
-	nova.cache.questionsCache.judgementCache.judgementSet = append(nova.cache.questionsCache.judgementCache.judgementSet, question)
+func (nova *Nova) createTemplateQuestionInDataCache(question QuestionTemplate) {
+	// enable template question cache write lock
+	nova.cache.questionsCache.templateCache.mutex.Lock()
+	defer nova.cache.questionsCache.templateCache.mutex.Unlock()
+	// append template question in data cache
+	nova.cache.questionsCache.templateCache.templateSet = append(nova.cache.questionsCache.templateCache.templateSet, question)
 	return
 }
 
-func (nova *Nova) deleteJudgementQuestionInDataCache(id string) {
-	// enable judgement question cache write lock
-	nova.cache.questionsCache.judgementCache.mutex.Lock()
-	defer nova.cache.questionsCache.judgementCache.mutex.Unlock()
-	// search & delete judgement question from data cache
-	for k, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+func (nova *Nova) deleteTemplateQuestionInDataCache(id string) {
+	// enable template question cache write lock
+	nova.cache.questionsCache.templateCache.mutex.Lock()
+	defer nova.cache.questionsCache.templateCache.mutex.Unlock()
+	// search & delete template question from data cache
+	for k, v := range nova.cache.questionsCache.templateCache.templateSet {
 		if v.Id == id {
-			nova.cache.questionsCache.judgementCache.judgementSet = append(nova.cache.questionsCache.judgementCache.judgementSet[:k], nova.cache.questionsCache.judgementCache.judgementSet[k+1:]...)
+			nova.cache.questionsCache.templateCache.templateSet = append(nova.cache.questionsCache.templateCache.templateSet[:k], nova.cache.questionsCache.templateCache.templateSet[k+1:]...)
 			break
 		}
 	}
 	return
 }
 
-func (nova *Nova) modifyJudgementQuestionInDataCache(question QuestionJudgement) (QuestionJudgement, error) {
-	// enable judgement question cache write lock
-	nova.cache.questionsCache.judgementCache.mutex.Lock()
-	defer nova.cache.questionsCache.judgementCache.mutex.Unlock()
-	// replace judgement question in data cache
-	for k, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+func (nova *Nova) modifyTemplateQuestionInDataCache(question QuestionTemplate) (QuestionTemplate, error) {
+	// enable template question cache write lock
+	nova.cache.questionsCache.templateCache.mutex.Lock()
+	defer nova.cache.questionsCache.templateCache.mutex.Unlock()
+	// replace template question in data cache
+	for k, v := range nova.cache.questionsCache.templateCache.templateSet {
 		if v.Id == question.Id {
-			nova.cache.questionsCache.judgementCache.judgementSet[k] = question
-			return nova.cache.questionsCache.judgementCache.judgementSet[k], nil
+			nova.cache.questionsCache.templateCache.templateSet[k] = question
+			return nova.cache.questionsCache.templateCache.templateSet[k], nil
 		}
 	}
-	return QuestionJudgement{}, errors.New("judgement question not found")
+	return QuestionTemplate{}, errors.New("template question not found")
 }
 
-func (nova *Nova) queryJudgementQuestionInDataCache(id string) (QuestionJudgement, error) {
-	// enable judgement question cache read lock
-	nova.cache.questionsCache.judgementCache.mutex.RLock()
-	defer nova.cache.questionsCache.judgementCache.mutex.RUnlock()
-	// search & query judgement question from data cache
-	for k, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+func (nova *Nova) queryTemplateQuestionInDataCache(id string) (QuestionTemplate, error) {
+	// enable template question cache read lock
+	nova.cache.questionsCache.templateCache.mutex.RLock()
+	defer nova.cache.questionsCache.templateCache.mutex.RUnlock()
+	// search & query template question from data cache
+	for k, v := range nova.cache.questionsCache.templateCache.templateSet {
 		if v.Id == id {
-			return nova.cache.questionsCache.judgementCache.judgementSet[k], nil
+			return nova.cache.questionsCache.templateCache.templateSet[k], nil
 		}
 	}
-	return QuestionJudgement{}, errors.New("judgement question not found")
+	return QuestionTemplate{}, errors.New("template question not found")
 }
 
-func (nova *Nova) updateJudgementQuestionInDataCache(question QuestionJudgement) bool {
-	// enable judgement question cache write lock
-	nova.cache.questionsCache.judgementCache.mutex.Lock()
-	defer nova.cache.questionsCache.judgementCache.mutex.Unlock()
-	// replace judgement question in data cache
-	for k, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+func (nova *Nova) updateTemplateQuestionInDataCache(question QuestionTemplate) bool {
+	// enable template question cache write lock
+	nova.cache.questionsCache.templateCache.mutex.Lock()
+	defer nova.cache.questionsCache.templateCache.mutex.Unlock()
+	// replace template question in data cache
+	for k, v := range nova.cache.questionsCache.templateCache.templateSet {
 		if v.Id == question.Id {
 			// userName and phoneNumber should not be changed
-			nova.cache.questionsCache.judgementCache.judgementSet[k] = question
+			nova.cache.questionsCache.templateCache.templateSet[k] = question
 			return true
 		}
 	}
 	return false
 }
 
-func (nova *Nova) createJudgementQuestionInDatabase(id string) error {
-	// enable judgement question cache read lock
-	nova.cache.questionsCache.judgementCache.mutex.RLock()
-	defer nova.cache.questionsCache.judgementCache.mutex.RUnlock()
+func (nova *Nova) createTemplateQuestionInDatabase(id string) error {
+	// enable template question cache read lock
+	nova.cache.questionsCache.templateCache.mutex.RLock()
+	defer nova.cache.questionsCache.templateCache.mutex.RUnlock()
 	// search Id in data cache
 	b := false
-	question := QuestionJudgement{}
-	for _, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+	question := QuestionTemplate{}
+	for _, v := range nova.cache.questionsCache.templateCache.templateSet {
 		if v.Id == id {
 			question = v
 			b = true
@@ -1732,45 +6412,45 @@ func (nova *Nova) createJudgementQuestionInDatabase(id string) error {
 		}
 	}
 	if !b {
-		return errors.New("judgement question not found")
+		return errors.New("template question not found")
 	}
-	// create judgement question in database
-	if _, err := nova.db.CreateQuestionJudgement(&question); err != nil {
+	// create template question in database
+	if _, err := nova.db.CreateQuestionTemplate(&question); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (nova *Nova) deleteJudgementQuestionInDatabase(id string) error {
-	// enable judgement question cache read lock
-	nova.cache.questionsCache.judgementCache.mutex.RLock()
-	defer nova.cache.questionsCache.judgementCache.mutex.RUnlock()
-	// search judgement question Id in data cache
+func (nova *Nova) deleteTemplateQuestionInDatabase(id string) error {
+	// enable template question cache read lock
+	nova.cache.questionsCache.templateCache.mutex.RLock()
+	defer nova.cache.questionsCache.templateCache.mutex.RUnlock()
+	// search template question Id in data cache
 	b := false
-	for _, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+	for _, v := range nova.cache.questionsCache.templateCache.templateSet {
 		if v.Id == id {
 			b = true
 			break
 		}
 	}
 	if !b {
-		return errors.New("judgement question not found")
+		return errors.New("template question not found")
 	}
-	// delete judgement question in database
-	if err := nova.db.DeleteQuestionJudgement(id); err != nil {
+	// delete template question in database
+	if err := nova.db.DeleteQuestionTemplate(id); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (nova *Nova) modifyJudgementQuestionInDatabase(id string) error {
-	// enable judgement question cache read lock
-	nova.cache.questionsCache.judgementCache.mutex.RLock()
-	defer nova.cache.questionsCache.judgementCache.mutex.RUnlock()
-	// search judgement question Id in data cache
+func (nova *Nova) modifyTemplateQuestionInDatabase(id string) error {
+	// enable template question cache read lock
+	nova.cache.questionsCache.templateCache.mutex.RLock()
+	defer nova.cache.questionsCache.templateCache.mutex.RUnlock()
+	// search template question Id in data cache
 	b := false
-	question := QuestionJudgement{}
-	for _, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+	question := QuestionTemplate{}
+	for _, v := range nova.cache.questionsCache.templateCache.templateSet {
 		if v.Id == id {
 			question = v
 			b = true
@@ -1778,42 +6458,42 @@ func (nova *Nova) modifyJudgementQuestionInDatabase(id string) error {
 		}
 	}
 	if !b {
-		return errors.New("judgement question not found")
+		return errors.New("template question not found")
 	}
-	// update judgement question in database
-	if err := nova.db.UpdateQuestionJudgement(&question); err != nil {
+	// update template question in database
+	if err := nova.db.UpdateQuestionTemplate(&question); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (nova *Nova) queryJudgementQuestionInDatabase(id string) error {
-	// enable judgement question cache write lock
-	nova.cache.questionsCache.judgementCache.mutex.Lock()
-	defer nova.cache.questionsCache.judgementCache.mutex.Unlock()
-	// query judgement question from database
-	question, err := nova.db.QueryQuestionJudgement(id)
+func (nova *Nova) queryTemplateQuestionInDatabase(id string) error {
+	// enable template question cache write lock
+	nova.cache.questionsCache.templateCache.mutex.Lock()
+	defer nova.cache.questionsCache.templateCache.mutex.Unlock()
+	// query template question from database
+	question, err := nova.db.QueryQuestionTemplate(id)
 	if err != nil {
 		return err
 	}
-	// update judgement question in data cache
-	for k, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+	// update template question in data cache
+	for k, v := range nova.cache.questionsCache.templateCache.templateSet {
 		if v.Id == id {
-			nova.cache.questionsCache.judgementCache.judgementSet[k] = *question
+			nova.cache.questionsCache.templateCache.templateSet[k] = *question
 			break
 		}
 	}
 	return nil
 }
 
-func (nova *Nova) updateJudgementQuestionInDatabase(id string) error {
-	// enable judgement question cache read lock
-	nova.cache.questionsCache.judgementCache.mutex.RLock()
-	defer nova.cache.questionsCache.judgementCache.mutex.RUnlock()
-	// search judgement question in data cache
+func (nova *Nova) updateTemplateQuestionInDatabase(id string) error {
+	// enable template question cache read lock
+	nova.cache.questionsCache.templateCache.mutex.RLock()
+	defer nova.cache.questionsCache.templateCache.mutex.RUnlock()
+	// search template question in data cache
 	b := false
-	question := QuestionJudgement{}
-	for _, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+	question := QuestionTemplate{}
+	for _, v := range nova.cache.questionsCache.templateCache.templateSet {
 		if v.Id == id {
 			question = v
 			b = true
@@ -1821,116 +6501,116 @@ func (nova *Nova) updateJudgementQuestionInDatabase(id string) error {
 		}
 	}
 	if !b {
-		return errors.New("judgement question not found")
+		return errors.New("template question not found")
 	}
-	// update judgement question in database
-	if err := nova.db.UpdateQuestionJudgement(&question); err != nil {
+	// update template question in database
+	if err := nova.db.UpdateQuestionTemplate(&question); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (nova *Nova) queryJudgementQuestionsInDatabase() error {
-	// enable judgement question cache write lock
-	nova.cache.questionsCache.judgementCache.mutex.Lock()
-	defer nova.cache.questionsCache.judgementCache.mutex.Unlock()
-	// query judgement question from database
-	questions, err := nova.db.QueryQuestionsJudgement()
+func (nova *Nova) queryTemplateQuestionsInDatabase() error {
+	// enable template question cache write lock
+	nova.cache.questionsCache.templateCache.mutex.Lock()
+	defer nova.cache.questionsCache.templateCache.mutex.Unlock()
+	// query template question from database
+	questions, err := nova.db.QueryQuestionsTemplate()
 	if err != nil {
 		return err
 	}
-	// update judgement question in data cache
+	// update template question in data cache
 	for _, question := range questions {
 		b := false
-		// update if judgement question existed
-		for k, v := range nova.cache.questionsCache.judgementCache.judgementSet {
+		// update if template question existed
+		for k, v := range nova.cache.questionsCache.templateCache.templateSet {
 			if v.Id == question.Id {
-				nova.cache.questionsCache.judgementCache.judgementSet[k] = *question
+				nova.cache.questionsCache.templateCache.templateSet[k] = *question
 				b = true
 				break
 			}
 		}
-		// create judgement question if question not existed
+		// create template question if question not existed
 		if !b {
-			nova.cache.questionsCache.judgementCache.judgementSet = append(nova.cache.questionsCache.judgementCache.judgementSet, *question)
+			nova.cache.questionsCache.templateCache.templateSet = append(nova.cache.questionsCache.templateCache.templateSet, *question)
 		}
 	}
 	return nil
 }
 
-func (nova *Nova) createEssayQuestionInDataCache(question QuestionEssay) {
-	// enable essay question cache write lock
-	nova.cache.questionsCache.essayCache.mutex.Lock()
-	defer nova.cache.questionsCache.essayCache.mutex.Unlock()
-	// append essay question in data cache
-	nova.cache.questionsCache.essayCache.essaySet = append(nova.cache.questionsCache.essayCache.essaySet, question)
+func (nova *Nova) createCodeQuestionInDataCache(question QuestionCode) {
+	// enable code question cache write lock
+	nova.cache.questionsCache.codeCache.mutex.Lock()
+	defer nova.cache.questionsCache.codeCache.mutex.Unlock()
+	// append code question in data cache
+	nova.cache.questionsCache.codeCache.codeSet = append(nova.cache.questionsCache.codeCache.codeSet, question)
 	return
 }
 
-func (nova *Nova) deleteEssayQuestionInDataCache(id string) {
-	// enable essay question cache write lock
-	nova.cache.questionsCache.essayCache.mutex.Lock()
-	defer nova.cache.questionsCache.essayCache.mutex.Unlock()
-	// search & delete essay question from data cache
-	for k, v := range nova.cache.questionsCache.essayCache.essaySet {
+func (nova *Nova) deleteCodeQuestionInDataCache(id string) {
+	// enable code question cache write lock
+	nova.cache.questionsCache.codeCache.mutex.Lock()
+	defer nova.cache.questionsCache.codeCache.mutex.Unlock()
+	// search & delete code question from data cache
+	for k, v := range nova.cache.questionsCache.codeCache.codeSet {
 		if v.Id == id {
-			nova.cache.questionsCache.essayCache.essaySet = append(nova.cache.questionsCache.essayCache.essaySet[:k], nova.cache.questionsCache.essayCache.essaySet[k+1:]...)
+			nova.cache.questionsCache.codeCache.codeSet = append(nova.cache.questionsCache.codeCache.codeSet[:k], nova.cache.questionsCache.codeCache.codeSet[k+1:]...)
 			break
 		}
 	}
 	return
 }
 
-func (nova *Nova) modifyEssayQuestionInDataCache(question QuestionEssay) (QuestionEssay, error) {
-	// enable essay question cache write lock
-	nova.cache.questionsCache.essayCache.mutex.Lock()
-	defer nova.cache.questionsCache.essayCache.mutex.Unlock()
-	// replace essay question in data cache
-	for k, v := range nova.cache.questionsCache.essayCache.essaySet {
+func (nova *Nova) modifyCodeQuestionInDataCache(question QuestionCode) (QuestionCode, error) {
+	// enable code question cache write lock
+	nova.cache.questionsCache.codeCache.mutex.Lock()
+	defer nova.cache.questionsCache.codeCache.mutex.Unlock()
+	// replace code question in data cache
+	for k, v := range nova.cache.questionsCache.codeCache.codeSet {
 		if v.Id == question.Id {
-			nova.cache.questionsCache.essayCache.essaySet[k] = question
-			return nova.cache.questionsCache.essayCache.essaySet[k], nil
+			nova.cache.questionsCache.codeCache.codeSet[k] = question
+			return nova.cache.questionsCache.codeCache.codeSet[k], nil
 		}
 	}
-	return QuestionEssay{}, errors.New("essay question not found")
+	return QuestionCode{}, errors.New("code question not found")
 }
 
-func (nova *Nova) queryEssayQuestionInDataCache(id string) (QuestionEssay, error) {
-	// enable essay question cache read lock
-	nova.cache.questionsCache.essayCache.mutex.RLock()
-	defer nova.cache.questionsCache.essayCache.mutex.RUnlock()
-	// search & query essay question from data cache
-	for k, v := range nova.cache.questionsCache.essayCache.essaySet {
+func (nova *Nova) queryCodeQuestionInDataCache(id string) (QuestionCode, error) {
+	// enable code question cache read lock
+	nova.cache.questionsCache.codeCache.mutex.RLock()
+	defer nova.cache.questionsCache.codeCache.mutex.RUnlock()
+	// search & query code question from data cache
+	for k, v := range nova.cache.questionsCache.codeCache.codeSet {
 		if v.Id == id {
-			return nova.cache.questionsCache.essayCache.essaySet[k], nil
+			return nova.cache.questionsCache.codeCache.codeSet[k], nil
 		}
 	}
-	return QuestionEssay{}, errors.New("essay question not found")
+	return QuestionCode{}, errors.New("code question not found")
 }
 
-func (nova *Nova) updateEssayQuestionInDataCache(question QuestionEssay) bool {
-	// enable essay question cache write lock
-	nova.cache.questionsCache.essayCache.mutex.Lock()
-	defer nova.cache.questionsCache.essayCache.mutex.Unlock()
-	// replace essay question in data cache
-	for k, v := range nova.cache.questionsCache.essayCache.essaySet {
+func (nova *Nova) updateCodeQuestionInDataCache(question QuestionCode) bool {
+	// enable code question cache write lock
+	nova.cache.questionsCache.codeCache.mutex.Lock()
+	defer nova.cache.questionsCache.codeCache.mutex.Unlock()
+	// replace code question in data cache
+	for k, v := range nova.cache.questionsCache.codeCache.codeSet {
 		if v.Id == question.Id {
 			// userName and phoneNumber should not be changed
-			nova.cache.questionsCache.essayCache.essaySet[k] = question
+			nova.cache.questionsCache.codeCache.codeSet[k] = question
 			return true
 		}
 	}
 	return false
 }
 
-func (nova *Nova) createEssayQuestionInDatabase(id string) error {
-	// enable essay question cache read lock
-	nova.cache.questionsCache.essayCache.mutex.RLock()
-	defer nova.cache.questionsCache.essayCache.mutex.RUnlock()
+func (nova *Nova) createCodeQuestionInDatabase(id string) error {
+	// enable code question cache read lock
+	nova.cache.questionsCache.codeCache.mutex.RLock()
+	defer nova.cache.questionsCache.codeCache.mutex.RUnlock()
 	// search Id in data cache
 	b := false
-	question := QuestionEssay{}
-	for _, v := range nova.cache.questionsCache.essayCache.essaySet {
+	question := QuestionCode{}
+	for _, v := range nova.cache.questionsCache.codeCache.codeSet {
 		if v.Id == id {
 			question = v
 			b = true
@@ -1938,45 +6618,45 @@ func (nova *Nova) createEssayQuestionInDatabase(id string) error {
 		}
 	}
 	if !b {
-		return errors.New("essay question not found")
+		return errors.New("code question not found")
 	}
-	// create essay question in database
-	if _, err := nova.db.CreateQuestionEssay(&question); err != nil {
+	// create code question in database
+	if _, err := nova.db.CreateQuestionCode(&question); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (nova *Nova) deleteEssayQuestionInDatabase(id string) error {
-	// enable essay question cache read lock
-	nova.cache.questionsCache.essayCache.mutex.RLock()
-	defer nova.cache.questionsCache.essayCache.mutex.RUnlock()
-	// search essay question Id in data cache
+func (nova *Nova) deleteCodeQuestionInDatabase(id string) error {
+	// enable code question cache read lock
+	nova.cache.questionsCache.codeCache.mutex.RLock()
+	defer nova.cache.questionsCache.codeCache.mutex.RUnlock()
+	// search code question Id in data cache
 	b := false
-	for _, v := range nova.cache.questionsCache.essayCache.essaySet {
+	for _, v := range nova.cache.questionsCache.codeCache.codeSet {
 		if v.Id == id {
 			b = true
 			break
 		}
 	}
 	if !b {
-		return errors.New("essay question not found")
+		return errors.New("code question not found")
 	}
-	// delete essay question in database
-	if err := nova.db.DeleteQuestionEssay(id); err != nil {
+	// delete code question in database
+	if err := nova.db.DeleteQuestionCode(id); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (nova *Nova) modifyEssayQuestionInDatabase(id string) error {
-	// enable essay question cache read lock
-	nova.cache.questionsCache.essayCache.mutex.RLock()
-	defer nova.cache.questionsCache.essayCache.mutex.RUnlock()
-	// search essay question Id in data cache
+func (nova *Nova) modifyCodeQuestionInDatabase(id string) error {
+	// enable code question cache read lock
+	nova.cache.questionsCache.codeCache.mutex.RLock()
+	defer nova.cache.questionsCache.codeCache.mutex.RUnlock()
+	// search code question Id in data cache
 	b := false
-	question := QuestionEssay{}
-	for _, v := range nova.cache.questionsCache.essayCache.essaySet {
+	question := QuestionCode{}
+	for _, v := range nova.cache.questionsCache.codeCache.codeSet {
 		if v.Id == id {
 			question = v
 			b = true
@@ -1984,42 +6664,42 @@ func (nova *Nova) modifyEssayQuestionInDatabase(id string) error {
 		}
 	}
 	if !b {
-		return errors.New("essay question not found")
+		return errors.New("code question not found")
 	}
-	// update essay question in database
-	if err := nova.db.UpdateQuestionEssay(&question); err != nil {
+	// update code question in database
+	if err := nova.db.UpdateQuestionCode(&question); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (nova *Nova) queryEssayQuestionInDatabase(id string) error {
-	// enable essay question cache write lock
-	nova.cache.questionsCache.essayCache.mutex.Lock()
-	defer nova.cache.questionsCache.essayCache.mutex.Unlock()
-	// query essay question from database
-	question, err := nova.db.QueryQuestionEssay(id)
+func (nova *Nova) queryCodeQuestionInDatabase(id string) error {
+	// enable code question cache write lock
+	nova.cache.questionsCache.codeCache.mutex.Lock()
+	defer nova.cache.questionsCache.codeCache.mutex.Unlock()
+	// query code question from database
+	question, err := nova.db.QueryQuestionCode(id)
 	if err != nil {
 		return err
 	}
-	// update essay question in data cache
-	for k, v := range nova.cache.questionsCache.essayCache.essaySet {
+	// update code question in data cache
+	for k, v := range nova.cache.questionsCache.codeCache.codeSet {
 		if v.Id == id {
-			nova.cache.questionsCache.essayCache.essaySet[k] = *question
+			nova.cache.questionsCache.codeCache.codeSet[k] = *question
 			break
 		}
 	}
 	return nil
 }
 
-func (nova *Nova) updateEssayQuestionInDatabase(id string) error {
-	// enable essay question cache read lock
-	nova.cache.questionsCache.essayCache.mutex.RLock()
-	defer nova.cache.questionsCache.essayCache.mutex.RUnlock()
-	// search essay question in data cache
+func (nova *Nova) updateCodeQuestionInDatabase(id string) error {
+	// enable code question cache read lock
+	nova.cache.questionsCache.codeCache.mutex.RLock()
+	defer nova.cache.questionsCache.codeCache.mutex.RUnlock()
+	// search code question in data cache
 	b := false
-	question := QuestionEssay{}
-	for _, v := range nova.cache.questionsCache.essayCache.essaySet {
+	question := QuestionCode{}
+	for _, v := range nova.cache.questionsCache.codeCache.codeSet {
 		if v.Id == id {
 			question = v
 			b = true
@@ -2027,38 +6707,38 @@ func (nova *Nova) updateEssayQuestionInDatabase(id string) error {
 		}
 	}
 	if !b {
-		return errors.New("essay question not found")
+		return errors.New("code question not found")
 	}
-	// update essay question in database
-	if err := nova.db.UpdateQuestionEssay(&question); err != nil {
+	// update code question in database
+	if err := nova.db.UpdateQuestionCode(&question); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (nova *Nova) queryEssayQuestionsInDatabase() error {
-	// enable essay question cache write lock
-	nova.cache.questionsCache.essayCache.mutex.Lock()
-	defer nova.cache.questionsCache.essayCache.mutex.Unlock()
-	// query essay question from database
-	questions, err := nova.db.QueryQuestionsEssay()
+func (nova *Nova) queryCodeQuestionsInDatabase() error {
+	// enable code question cache write lock
+	nova.cache.questionsCache.codeCache.mutex.Lock()
+	defer nova.cache.questionsCache.codeCache.mutex.Unlock()
+	// query code question from database
+	questions, err := nova.db.QueryQuestionsCode()
 	if err != nil {
 		return err
 	}
-	// update essay question in data cache
+	// update code question in data cache
 	for _, question := range questions {
 		b := false
-		// update if essay question existed
-		for k, v := range nova.cache.questionsCache.essayCache.essaySet {
+		// update if code question existed
+		for k, v := range nova.cache.questionsCache.codeCache.codeSet {
 			if v.Id == question.Id {
-				nova.cache.questionsCache.essayCache.essaySet[k] = *question
+				nova.cache.questionsCache.codeCache.codeSet[k] = *question
 				b = true
 				break
 			}
 		}
-		// create essay question if question not existed
+		// create code question if question not existed
 		if !b {
-			nova.cache.questionsCache.essayCache.essaySet = append(nova.cache.questionsCache.essayCache.essaySet, *question)
+			nova.cache.questionsCache.codeCache.codeSet = append(nova.cache.questionsCache.codeCache.codeSet, *question)
 		}
 	}
 	return nil
@@ -17,6 +17,8 @@ func setupQuestionTestRouter() *gin.Engine {
 	nova := New()
 	// initialize Nova instance
 	nova.Init()
+	// author changing questions
+	createTestAuthor(nova)
 	// apply default Gin service
 	router := gin.Default()
 	// apply Gin logger & recovery middleware
@@ -60,17 +62,10 @@ func startQuestionTestService() (*httptest.Server, *gin.Engine) {
 }
 
 func resetQuestionTestCase() error {
-	// remove database
-	err := os.Remove("nova.db")
-	if err != nil {
-		return err
-	}
+	// isolate database
+	databaseSource = newTestDatabaseSource()
 	// remove logs
-	err = os.RemoveAll("logs/")
-	if err != nil {
-		return err
-	}
-	return nil
+	return os.RemoveAll("logs/")
 }
 
 func TestNova_HandleCreateQuestionId(t *testing.T) {
@@ -90,7 +85,7 @@ func TestNova_HandleCreateQuestionId(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	w := httptest.NewRecorder()
-	request, err := http.NewRequest(http.MethodPost, url, nil)
+	request, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -127,7 +122,7 @@ func BenchmarkNova_HandleCreateQuestionId(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		w := httptest.NewRecorder()
-		request, err := http.NewRequest(http.MethodPost, url, nil)
+		request, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -166,7 +161,7 @@ func BenchmarkNova_HandleCreateQuestionIdParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			w := httptest.NewRecorder()
-			request, err := http.NewRequest(http.MethodPost, url, nil)
+			request, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -205,7 +200,7 @@ func TestNova_HandleCreateQuestionSingleChoice(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	wQuestionId := httptest.NewRecorder()
-	reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+	reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -254,7 +249,7 @@ func TestNova_HandleCreateQuestionSingleChoice(t *testing.T) {
 	}
 	// request create user
 	wQuestion := httptest.NewRecorder()
-	reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+	reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -297,7 +292,7 @@ func BenchmarkNova_HandleCreateQuestionSingleChoice(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		wQuestionId := httptest.NewRecorder()
-		reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+		reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -346,7 +341,7 @@ func BenchmarkNova_HandleCreateQuestionSingleChoice(b *testing.B) {
 		}
 		// request create user
 		wQuestion := httptest.NewRecorder()
-		reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+		reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -391,7 +386,7 @@ func BenchmarkNova_HandleCreateQuestionSingleChoiceParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			wQuestionId := httptest.NewRecorder()
-			reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+			reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -440,7 +435,7 @@ func BenchmarkNova_HandleCreateQuestionSingleChoiceParallel(b *testing.B) {
 			}
 			// request create user
 			wQuestion := httptest.NewRecorder()
-			reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+			reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -483,7 +478,7 @@ func TestNova_HandleCreateQuestionMultipleChoice(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	wQuestionId := httptest.NewRecorder()
-	reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+	reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -538,7 +533,7 @@ func TestNova_HandleCreateQuestionMultipleChoice(t *testing.T) {
 	}
 	// request create user
 	wQuestion := httptest.NewRecorder()
-	reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+	reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -581,7 +576,7 @@ func BenchmarkNova_HandleCreateQuestionMultipleChoice(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		wQuestionId := httptest.NewRecorder()
-		reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+		reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -636,7 +631,7 @@ func BenchmarkNova_HandleCreateQuestionMultipleChoice(b *testing.B) {
 		}
 		// request create user
 		wQuestion := httptest.NewRecorder()
-		reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+		reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -681,7 +676,7 @@ func BenchmarkNova_HandleCreateQuestionMultipleChoiceParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			wQuestionId := httptest.NewRecorder()
-			reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+			reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -736,7 +731,7 @@ func BenchmarkNova_HandleCreateQuestionMultipleChoiceParallel(b *testing.B) {
 			}
 			// request create user
 			wQuestion := httptest.NewRecorder()
-			reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+			reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -779,7 +774,7 @@ func TestNova_HandleCreateQuestionJudgement(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	wQuestionId := httptest.NewRecorder()
-	reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+	reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -808,7 +803,7 @@ func TestNova_HandleCreateQuestionJudgement(t *testing.T) {
 	}
 	// request create user
 	wQuestion := httptest.NewRecorder()
-	reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+	reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -851,7 +846,7 @@ func BenchmarkNova_HandleCreateQuestionJudgement(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		wQuestionId := httptest.NewRecorder()
-		reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+		reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -880,7 +875,7 @@ func BenchmarkNova_HandleCreateQuestionJudgement(b *testing.B) {
 		}
 		// request create user
 		wQuestion := httptest.NewRecorder()
-		reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+		reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -925,7 +920,7 @@ func BenchmarkNova_HandleCreateQuestionJudgementParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			wQuestionId := httptest.NewRecorder()
-			reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+			reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -954,7 +949,7 @@ func BenchmarkNova_HandleCreateQuestionJudgementParallel(b *testing.B) {
 			}
 			// request create user
 			wQuestion := httptest.NewRecorder()
-			reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+			reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -997,7 +992,7 @@ func TestNova_HandleCreateQuestionEssay(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	wQuestionId := httptest.NewRecorder()
-	reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+	reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -1026,7 +1021,7 @@ func TestNova_HandleCreateQuestionEssay(t *testing.T) {
 	}
 	// request create user
 	wQuestion := httptest.NewRecorder()
-	reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+	reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -1069,7 +1064,7 @@ func BenchmarkNova_HandleCreateQuestionEssay(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		wQuestionId := httptest.NewRecorder()
-		reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+		reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -1098,7 +1093,7 @@ func BenchmarkNova_HandleCreateQuestionEssay(b *testing.B) {
 		}
 		// request create user
 		wQuestion := httptest.NewRecorder()
-		reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+		reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -1143,7 +1138,7 @@ func BenchmarkNova_HandleCreateQuestionEssayParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			wQuestionId := httptest.NewRecorder()
-			reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+			reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -1172,7 +1167,7 @@ func BenchmarkNova_HandleCreateQuestionEssayParallel(b *testing.B) {
 			}
 			// request create user
 			wQuestion := httptest.NewRecorder()
-			reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+			reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -1217,7 +1212,7 @@ func TestNova_HandleDeleteQuestionSingleChoice(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	wQuestionId := httptest.NewRecorder()
-	reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+	reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -1266,7 +1261,7 @@ func TestNova_HandleDeleteQuestionSingleChoice(t *testing.T) {
 	}
 	// request create user
 	wQuestion := httptest.NewRecorder()
-	reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+	reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -1290,7 +1285,7 @@ func TestNova_HandleDeleteQuestionSingleChoice(t *testing.T) {
 	url = server.URL + "/nova/v1/question/single-choice"
 	// request delete question
 	wDeleteQuestion := httptest.NewRecorder()
-	reqDeleteQuestion, err := http.NewRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
+	reqDeleteQuestion, err := newAuthorTestRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -1323,7 +1318,7 @@ func BenchmarkNova_HandleDeleteQuestionSingleChoice(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		wQuestionId := httptest.NewRecorder()
-		reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+		reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -1372,7 +1367,7 @@ func BenchmarkNova_HandleDeleteQuestionSingleChoice(b *testing.B) {
 		}
 		// request create user
 		wQuestion := httptest.NewRecorder()
-		reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+		reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -1396,7 +1391,7 @@ func BenchmarkNova_HandleDeleteQuestionSingleChoice(b *testing.B) {
 		url = server.URL + "/nova/v1/question/single-choice"
 		// request delete question
 		wDeleteQuestion := httptest.NewRecorder()
-		reqDeleteQuestion, err := http.NewRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
+		reqDeleteQuestion, err := newAuthorTestRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -1431,7 +1426,7 @@ func BenchmarkNova_HandleDeleteQuestionSingleChoiceParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			wQuestionId := httptest.NewRecorder()
-			reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+			reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -1480,7 +1475,7 @@ func BenchmarkNova_HandleDeleteQuestionSingleChoiceParallel(b *testing.B) {
 			}
 			// request create user
 			wQuestion := httptest.NewRecorder()
-			reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+			reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -1504,7 +1499,7 @@ func BenchmarkNova_HandleDeleteQuestionSingleChoiceParallel(b *testing.B) {
 			url = server.URL + "/nova/v1/question/single-choice"
 			// request delete question
 			wDeleteQuestion := httptest.NewRecorder()
-			reqDeleteQuestion, err := http.NewRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
+			reqDeleteQuestion, err := newAuthorTestRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -1537,7 +1532,7 @@ func TestNova_HandleDeleteQuestionMultipleChoice(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	wQuestionId := httptest.NewRecorder()
-	reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+	reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -1592,7 +1587,7 @@ func TestNova_HandleDeleteQuestionMultipleChoice(t *testing.T) {
 	}
 	// request create user
 	wQuestion := httptest.NewRecorder()
-	reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+	reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -1616,7 +1611,7 @@ func TestNova_HandleDeleteQuestionMultipleChoice(t *testing.T) {
 	url = server.URL + "/nova/v1/question/multiple-choice"
 	// request delete question
 	wDeleteQuestion := httptest.NewRecorder()
-	reqDeleteQuestion, err := http.NewRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
+	reqDeleteQuestion, err := newAuthorTestRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -1649,7 +1644,7 @@ func BenchmarkNova_HandleDeleteQuestionMultipleChoice(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		wQuestionId := httptest.NewRecorder()
-		reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+		reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -1704,7 +1699,7 @@ func BenchmarkNova_HandleDeleteQuestionMultipleChoice(b *testing.B) {
 		}
 		// request create user
 		wQuestion := httptest.NewRecorder()
-		reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+		reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -1728,7 +1723,7 @@ func BenchmarkNova_HandleDeleteQuestionMultipleChoice(b *testing.B) {
 		url = server.URL + "/nova/v1/question/multiple-choice"
 		// request delete question
 		wDeleteQuestion := httptest.NewRecorder()
-		reqDeleteQuestion, err := http.NewRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
+		reqDeleteQuestion, err := newAuthorTestRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -1763,7 +1758,7 @@ func BenchmarkNova_HandleDeleteQuestionMultipleChoiceParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			wQuestionId := httptest.NewRecorder()
-			reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+			reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -1818,7 +1813,7 @@ func BenchmarkNova_HandleDeleteQuestionMultipleChoiceParallel(b *testing.B) {
 			}
 			// request create user
 			wQuestion := httptest.NewRecorder()
-			reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+			reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -1842,7 +1837,7 @@ func BenchmarkNova_HandleDeleteQuestionMultipleChoiceParallel(b *testing.B) {
 			url = server.URL + "/nova/v1/question/multiple-choice"
 			// request delete question
 			wDeleteQuestion := httptest.NewRecorder()
-			reqDeleteQuestion, err := http.NewRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
+			reqDeleteQuestion, err := newAuthorTestRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -1875,7 +1870,7 @@ func TestNova_HandleDeleteQuestionJudgement(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	wQuestionId := httptest.NewRecorder()
-	reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+	reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -1904,7 +1899,7 @@ func TestNova_HandleDeleteQuestionJudgement(t *testing.T) {
 	}
 	// request create user
 	wQuestion := httptest.NewRecorder()
-	reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+	reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -1928,7 +1923,7 @@ func TestNova_HandleDeleteQuestionJudgement(t *testing.T) {
 	url = server.URL + "/nova/v1/question/judgement"
 	// request delete question
 	wDeleteQuestion := httptest.NewRecorder()
-	reqDeleteQuestion, err := http.NewRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
+	reqDeleteQuestion, err := newAuthorTestRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -1961,7 +1956,7 @@ func BenchmarkNova_HandleDeleteQuestionJudgement(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		wQuestionId := httptest.NewRecorder()
-		reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+		reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -1990,7 +1985,7 @@ func BenchmarkNova_HandleDeleteQuestionJudgement(b *testing.B) {
 		}
 		// request create user
 		wQuestion := httptest.NewRecorder()
-		reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+		reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -2014,7 +2009,7 @@ func BenchmarkNova_HandleDeleteQuestionJudgement(b *testing.B) {
 		url = server.URL + "/nova/v1/question/judgement"
 		// request delete question
 		wDeleteQuestion := httptest.NewRecorder()
-		reqDeleteQuestion, err := http.NewRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
+		reqDeleteQuestion, err := newAuthorTestRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -2049,7 +2044,7 @@ func BenchmarkNova_HandleDeleteQuestionJudgementParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			wQuestionId := httptest.NewRecorder()
-			reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+			reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -2078,7 +2073,7 @@ func BenchmarkNova_HandleDeleteQuestionJudgementParallel(b *testing.B) {
 			}
 			// request create user
 			wQuestion := httptest.NewRecorder()
-			reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+			reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -2102,7 +2097,7 @@ func BenchmarkNova_HandleDeleteQuestionJudgementParallel(b *testing.B) {
 			url = server.URL + "/nova/v1/question/judgement"
 			// request delete question
 			wDeleteQuestion := httptest.NewRecorder()
-			reqDeleteQuestion, err := http.NewRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
+			reqDeleteQuestion, err := newAuthorTestRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -2135,7 +2130,7 @@ func TestNova_HandleDeleteQuestionEssay(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	wQuestionId := httptest.NewRecorder()
-	reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+	reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -2164,7 +2159,7 @@ func TestNova_HandleDeleteQuestionEssay(t *testing.T) {
 	}
 	// request create user
 	wQuestion := httptest.NewRecorder()
-	reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+	reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -2188,7 +2183,7 @@ func TestNova_HandleDeleteQuestionEssay(t *testing.T) {
 	url = server.URL + "/nova/v1/question/essay"
 	// request delete question
 	wDeleteQuestion := httptest.NewRecorder()
-	reqDeleteQuestion, err := http.NewRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
+	reqDeleteQuestion, err := newAuthorTestRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -2221,7 +2216,7 @@ func BenchmarkNova_HandleDeleteQuestionEssay(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		wQuestionId := httptest.NewRecorder()
-		reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+		reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -2250,7 +2245,7 @@ func BenchmarkNova_HandleDeleteQuestionEssay(b *testing.B) {
 		}
 		// request create user
 		wQuestion := httptest.NewRecorder()
-		reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+		reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -2274,7 +2269,7 @@ func BenchmarkNova_HandleDeleteQuestionEssay(b *testing.B) {
 		url = server.URL + "/nova/v1/question/essay"
 		// request delete question
 		wDeleteQuestion := httptest.NewRecorder()
-		reqDeleteQuestion, err := http.NewRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
+		reqDeleteQuestion, err := newAuthorTestRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -2309,7 +2304,7 @@ func BenchmarkNova_HandleDeleteQuestionEssayParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			wQuestionId := httptest.NewRecorder()
-			reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+			reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -2338,7 +2333,7 @@ func BenchmarkNova_HandleDeleteQuestionEssayParallel(b *testing.B) {
 			}
 			// request create user
 			wQuestion := httptest.NewRecorder()
-			reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+			reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -2362,7 +2357,7 @@ func BenchmarkNova_HandleDeleteQuestionEssayParallel(b *testing.B) {
 			url = server.URL + "/nova/v1/question/essay"
 			// request delete question
 			wDeleteQuestion := httptest.NewRecorder()
-			reqDeleteQuestion, err := http.NewRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
+			reqDeleteQuestion, err := newAuthorTestRequest(http.MethodDelete, url+"/"+reQuestionId, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -2395,7 +2390,7 @@ func TestNova_HandleModifyQuestionSingleChoice(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	wQuestionId := httptest.NewRecorder()
-	reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+	reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -2444,7 +2439,7 @@ func TestNova_HandleModifyQuestionSingleChoice(t *testing.T) {
 	}
 	// request create user
 	wQuestion := httptest.NewRecorder()
-	reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+	reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -2498,7 +2493,7 @@ func TestNova_HandleModifyQuestionSingleChoice(t *testing.T) {
 	}
 	// request modify question
 	wModifyQuestion := httptest.NewRecorder()
-	reqModifyQuestion, err := http.NewRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+	reqModifyQuestion, err := newAuthorTestRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -2540,7 +2535,7 @@ func BenchmarkNova_HandleModifyQuestionSingleChoice(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		wQuestionId := httptest.NewRecorder()
-		reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+		reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -2589,7 +2584,7 @@ func BenchmarkNova_HandleModifyQuestionSingleChoice(b *testing.B) {
 		}
 		// request create user
 		wQuestion := httptest.NewRecorder()
-		reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+		reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -2643,7 +2638,7 @@ func BenchmarkNova_HandleModifyQuestionSingleChoice(b *testing.B) {
 		}
 		// request modify question
 		wModifyQuestion := httptest.NewRecorder()
-		reqModifyQuestion, err := http.NewRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+		reqModifyQuestion, err := newAuthorTestRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -2687,7 +2682,7 @@ func BenchmarkNova_HandleModifyQuestionSingleChoiceParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			wQuestionId := httptest.NewRecorder()
-			reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+			reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -2736,7 +2731,7 @@ func BenchmarkNova_HandleModifyQuestionSingleChoiceParallel(b *testing.B) {
 			}
 			// request create user
 			wQuestion := httptest.NewRecorder()
-			reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+			reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -2790,7 +2785,7 @@ func BenchmarkNova_HandleModifyQuestionSingleChoiceParallel(b *testing.B) {
 			}
 			// request modify question
 			wModifyQuestion := httptest.NewRecorder()
-			reqModifyQuestion, err := http.NewRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+			reqModifyQuestion, err := newAuthorTestRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -2832,7 +2827,7 @@ func TestNova_HandleModifyQuestionMultipleChoice(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	wQuestionId := httptest.NewRecorder()
-	reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+	reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -2887,7 +2882,7 @@ func TestNova_HandleModifyQuestionMultipleChoice(t *testing.T) {
 	}
 	// request create user
 	wQuestion := httptest.NewRecorder()
-	reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+	reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -2947,7 +2942,7 @@ func TestNova_HandleModifyQuestionMultipleChoice(t *testing.T) {
 	}
 	// request modify question
 	wModifyQuestion := httptest.NewRecorder()
-	reqModifyQuestion, err := http.NewRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+	reqModifyQuestion, err := newAuthorTestRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -2989,7 +2984,7 @@ func BenchmarkNova_HandleModifyQuestionMultipleChoice(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		wQuestionId := httptest.NewRecorder()
-		reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+		reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -3044,7 +3039,7 @@ func BenchmarkNova_HandleModifyQuestionMultipleChoice(b *testing.B) {
 		}
 		// request create user
 		wQuestion := httptest.NewRecorder()
-		reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+		reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -3104,7 +3099,7 @@ func BenchmarkNova_HandleModifyQuestionMultipleChoice(b *testing.B) {
 		}
 		// request modify question
 		wModifyQuestion := httptest.NewRecorder()
-		reqModifyQuestion, err := http.NewRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+		reqModifyQuestion, err := newAuthorTestRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -3148,7 +3143,7 @@ func BenchmarkNova_HandleModifyQuestionMultipleChoiceParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			wQuestionId := httptest.NewRecorder()
-			reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+			reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -3203,7 +3198,7 @@ func BenchmarkNova_HandleModifyQuestionMultipleChoiceParallel(b *testing.B) {
 			}
 			// request create user
 			wQuestion := httptest.NewRecorder()
-			reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+			reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -3263,7 +3258,7 @@ func BenchmarkNova_HandleModifyQuestionMultipleChoiceParallel(b *testing.B) {
 			}
 			// request modify question
 			wModifyQuestion := httptest.NewRecorder()
-			reqModifyQuestion, err := http.NewRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+			reqModifyQuestion, err := newAuthorTestRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -3305,7 +3300,7 @@ func TestNova_HandleModifyQuestionJudgement(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	wQuestionId := httptest.NewRecorder()
-	reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+	reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -3334,7 +3329,7 @@ func TestNova_HandleModifyQuestionJudgement(t *testing.T) {
 	}
 	// request create user
 	wQuestion := httptest.NewRecorder()
-	reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+	reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -3368,7 +3363,7 @@ func TestNova_HandleModifyQuestionJudgement(t *testing.T) {
 	}
 	// request modify question
 	wModifyQuestion := httptest.NewRecorder()
-	reqModifyQuestion, err := http.NewRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+	reqModifyQuestion, err := newAuthorTestRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -3410,7 +3405,7 @@ func BenchmarkNova_HandleModifyQuestionJudgement(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		wQuestionId := httptest.NewRecorder()
-		reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+		reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -3439,7 +3434,7 @@ func BenchmarkNova_HandleModifyQuestionJudgement(b *testing.B) {
 		}
 		// request create user
 		wQuestion := httptest.NewRecorder()
-		reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+		reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -3473,7 +3468,7 @@ func BenchmarkNova_HandleModifyQuestionJudgement(b *testing.B) {
 		}
 		// request modify question
 		wModifyQuestion := httptest.NewRecorder()
-		reqModifyQuestion, err := http.NewRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+		reqModifyQuestion, err := newAuthorTestRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -3517,7 +3512,7 @@ func BenchmarkNova_HandleModifyQuestionJudgementParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			wQuestionId := httptest.NewRecorder()
-			reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+			reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -3546,7 +3541,7 @@ func BenchmarkNova_HandleModifyQuestionJudgementParallel(b *testing.B) {
 			}
 			// request create user
 			wQuestion := httptest.NewRecorder()
-			reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+			reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -3580,7 +3575,7 @@ func BenchmarkNova_HandleModifyQuestionJudgementParallel(b *testing.B) {
 			}
 			// request modify question
 			wModifyQuestion := httptest.NewRecorder()
-			reqModifyQuestion, err := http.NewRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+			reqModifyQuestion, err := newAuthorTestRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -3622,7 +3617,7 @@ func TestNova_HandleModifyQuestionEssay(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	wQuestionId := httptest.NewRecorder()
-	reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+	reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -3651,7 +3646,7 @@ func TestNova_HandleModifyQuestionEssay(t *testing.T) {
 	}
 	// request create user
 	wQuestion := httptest.NewRecorder()
-	reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+	reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -3685,7 +3680,7 @@ func TestNova_HandleModifyQuestionEssay(t *testing.T) {
 	}
 	// request modify question
 	wModifyQuestion := httptest.NewRecorder()
-	reqModifyQuestion, err := http.NewRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+	reqModifyQuestion, err := newAuthorTestRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -3727,7 +3722,7 @@ func BenchmarkNova_HandleModifyQuestionEssay(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		wQuestionId := httptest.NewRecorder()
-		reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+		reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -3756,7 +3751,7 @@ func BenchmarkNova_HandleModifyQuestionEssay(b *testing.B) {
 		}
 		// request create user
 		wQuestion := httptest.NewRecorder()
-		reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+		reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -3790,7 +3785,7 @@ func BenchmarkNova_HandleModifyQuestionEssay(b *testing.B) {
 		}
 		// request modify question
 		wModifyQuestion := httptest.NewRecorder()
-		reqModifyQuestion, err := http.NewRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+		reqModifyQuestion, err := newAuthorTestRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -3834,7 +3829,7 @@ func BenchmarkNova_HandleModifyQuestionEssayParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			wQuestionId := httptest.NewRecorder()
-			reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+			reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -3863,7 +3858,7 @@ func BenchmarkNova_HandleModifyQuestionEssayParallel(b *testing.B) {
 			}
 			// request create user
 			wQuestion := httptest.NewRecorder()
-			reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+			reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -3897,7 +3892,7 @@ func BenchmarkNova_HandleModifyQuestionEssayParallel(b *testing.B) {
 			}
 			// request modify question
 			wModifyQuestion := httptest.NewRecorder()
-			reqModifyQuestion, err := http.NewRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+			reqModifyQuestion, err := newAuthorTestRequest(http.MethodPatch, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -3939,7 +3934,7 @@ func TestNova_HandleQueryQuestionSingleChoice(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	wQuestionId := httptest.NewRecorder()
-	reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+	reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -3988,7 +3983,7 @@ func TestNova_HandleQueryQuestionSingleChoice(t *testing.T) {
 	}
 	// request create user
 	wQuestion := httptest.NewRecorder()
-	reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+	reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -4012,7 +4007,7 @@ func TestNova_HandleQueryQuestionSingleChoice(t *testing.T) {
 	url = server.URL + "/nova/v1/question/single-choice"
 	// request query question
 	wQueryQuestion := httptest.NewRecorder()
-	reqModifyQuestion, err := http.NewRequest(http.MethodGet, url+"/"+reQuestionId, nil)
+	reqModifyQuestion, err := newAuthorTestRequest(http.MethodGet, url+"/"+reQuestionId, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -4054,7 +4049,7 @@ func BenchmarkNova_HandleQueryQuestionSingleChoice(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		wQuestionId := httptest.NewRecorder()
-		reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+		reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -4103,7 +4098,7 @@ func BenchmarkNova_HandleQueryQuestionSingleChoice(b *testing.B) {
 		}
 		// request create user
 		wQuestion := httptest.NewRecorder()
-		reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+		reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -4127,7 +4122,7 @@ func BenchmarkNova_HandleQueryQuestionSingleChoice(b *testing.B) {
 		url = server.URL + "/nova/v1/question/single-choice"
 		// request query question
 		wQueryQuestion := httptest.NewRecorder()
-		reqModifyQuestion, err := http.NewRequest(http.MethodGet, url+"/"+reQuestionId, nil)
+		reqModifyQuestion, err := newAuthorTestRequest(http.MethodGet, url+"/"+reQuestionId, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -4171,7 +4166,7 @@ func BenchmarkNova_HandleQueryQuestionSingleChoiceParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			wQuestionId := httptest.NewRecorder()
-			reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+			reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -4220,7 +4215,7 @@ func BenchmarkNova_HandleQueryQuestionSingleChoiceParallel(b *testing.B) {
 			}
 			// request create user
 			wQuestion := httptest.NewRecorder()
-			reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+			reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -4244,7 +4239,7 @@ func BenchmarkNova_HandleQueryQuestionSingleChoiceParallel(b *testing.B) {
 			url = server.URL + "/nova/v1/question/single-choice"
 			// request query question
 			wQueryQuestion := httptest.NewRecorder()
-			reqModifyQuestion, err := http.NewRequest(http.MethodGet, url+"/"+reQuestionId, nil)
+			reqModifyQuestion, err := newAuthorTestRequest(http.MethodGet, url+"/"+reQuestionId, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -4286,7 +4281,7 @@ func TestNova_HandleQueryQuestionMultipleChoice(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	wQuestionId := httptest.NewRecorder()
-	reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+	reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -4341,7 +4336,7 @@ func TestNova_HandleQueryQuestionMultipleChoice(t *testing.T) {
 	}
 	// request create user
 	wQuestion := httptest.NewRecorder()
-	reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+	reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -4365,7 +4360,7 @@ func TestNova_HandleQueryQuestionMultipleChoice(t *testing.T) {
 	url = server.URL + "/nova/v1/question/multiple-choice"
 	// request query question
 	wQueryQuestion := httptest.NewRecorder()
-	reqModifyQuestion, err := http.NewRequest(http.MethodGet, url+"/"+reQuestionId, nil)
+	reqModifyQuestion, err := newAuthorTestRequest(http.MethodGet, url+"/"+reQuestionId, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -4407,7 +4402,7 @@ func BenchmarkNova_HandleQueryQuestionMultipleChoice(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		wQuestionId := httptest.NewRecorder()
-		reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+		reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -4462,7 +4457,7 @@ func BenchmarkNova_HandleQueryQuestionMultipleChoice(b *testing.B) {
 		}
 		// request create user
 		wQuestion := httptest.NewRecorder()
-		reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+		reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -4486,7 +4481,7 @@ func BenchmarkNova_HandleQueryQuestionMultipleChoice(b *testing.B) {
 		url = server.URL + "/nova/v1/question/multiple-choice"
 		// request query question
 		wQueryQuestion := httptest.NewRecorder()
-		reqModifyQuestion, err := http.NewRequest(http.MethodGet, url+"/"+reQuestionId, nil)
+		reqModifyQuestion, err := newAuthorTestRequest(http.MethodGet, url+"/"+reQuestionId, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -4530,7 +4525,7 @@ func BenchmarkNova_HandleQueryQuestionMultipleChoiceParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			wQuestionId := httptest.NewRecorder()
-			reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+			reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -4585,7 +4580,7 @@ func BenchmarkNova_HandleQueryQuestionMultipleChoiceParallel(b *testing.B) {
 			}
 			// request create user
 			wQuestion := httptest.NewRecorder()
-			reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+			reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -4609,7 +4604,7 @@ func BenchmarkNova_HandleQueryQuestionMultipleChoiceParallel(b *testing.B) {
 			url = server.URL + "/nova/v1/question/multiple-choice"
 			// request query question
 			wQueryQuestion := httptest.NewRecorder()
-			reqModifyQuestion, err := http.NewRequest(http.MethodGet, url+"/"+reQuestionId, nil)
+			reqModifyQuestion, err := newAuthorTestRequest(http.MethodGet, url+"/"+reQuestionId, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -4651,7 +4646,7 @@ func TestNova_HandleQueryQuestionJudgement(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	wQuestionId := httptest.NewRecorder()
-	reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+	reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -4680,7 +4675,7 @@ func TestNova_HandleQueryQuestionJudgement(t *testing.T) {
 	}
 	// request create user
 	wQuestion := httptest.NewRecorder()
-	reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+	reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -4704,7 +4699,7 @@ func TestNova_HandleQueryQuestionJudgement(t *testing.T) {
 	url = server.URL + "/nova/v1/question/judgement"
 	// request query question
 	wQueryQuestion := httptest.NewRecorder()
-	reqModifyQuestion, err := http.NewRequest(http.MethodGet, url+"/"+reQuestionId, nil)
+	reqModifyQuestion, err := newAuthorTestRequest(http.MethodGet, url+"/"+reQuestionId, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -4746,7 +4741,7 @@ func BenchmarkNova_HandleQueryQuestionJudgement(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		wQuestionId := httptest.NewRecorder()
-		reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+		reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -4775,7 +4770,7 @@ func BenchmarkNova_HandleQueryQuestionJudgement(b *testing.B) {
 		}
 		// request create user
 		wQuestion := httptest.NewRecorder()
-		reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+		reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -4799,7 +4794,7 @@ func BenchmarkNova_HandleQueryQuestionJudgement(b *testing.B) {
 		url = server.URL + "/nova/v1/question/judgement"
 		// request query question
 		wQueryQuestion := httptest.NewRecorder()
-		reqModifyQuestion, err := http.NewRequest(http.MethodGet, url+"/"+reQuestionId, nil)
+		reqModifyQuestion, err := newAuthorTestRequest(http.MethodGet, url+"/"+reQuestionId, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -4843,7 +4838,7 @@ func BenchmarkNova_HandleQueryQuestionJudgementParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			wQuestionId := httptest.NewRecorder()
-			reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+			reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -4872,7 +4867,7 @@ func BenchmarkNova_HandleQueryQuestionJudgementParallel(b *testing.B) {
 			}
 			// request create user
 			wQuestion := httptest.NewRecorder()
-			reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+			reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -4896,7 +4891,7 @@ func BenchmarkNova_HandleQueryQuestionJudgementParallel(b *testing.B) {
 			url = server.URL + "/nova/v1/question/judgement"
 			// request query question
 			wQueryQuestion := httptest.NewRecorder()
-			reqModifyQuestion, err := http.NewRequest(http.MethodGet, url+"/"+reQuestionId, nil)
+			reqModifyQuestion, err := newAuthorTestRequest(http.MethodGet, url+"/"+reQuestionId, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -4938,7 +4933,7 @@ func TestNova_HandleQueryQuestionEssay(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	wQuestionId := httptest.NewRecorder()
-	reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+	reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -4967,7 +4962,7 @@ func TestNova_HandleQueryQuestionEssay(t *testing.T) {
 	}
 	// request create user
 	wQuestion := httptest.NewRecorder()
-	reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+	reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -4991,7 +4986,7 @@ func TestNova_HandleQueryQuestionEssay(t *testing.T) {
 	url = server.URL + "/nova/v1/question/essay"
 	// request query question
 	wQueryQuestion := httptest.NewRecorder()
-	reqModifyQuestion, err := http.NewRequest(http.MethodGet, url+"/"+reQuestionId, nil)
+	reqModifyQuestion, err := newAuthorTestRequest(http.MethodGet, url+"/"+reQuestionId, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -5033,7 +5028,7 @@ func BenchmarkNova_HandleQueryQuestionEssay(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		wQuestionId := httptest.NewRecorder()
-		reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+		reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -5062,7 +5057,7 @@ func BenchmarkNova_HandleQueryQuestionEssay(b *testing.B) {
 		}
 		// request create user
 		wQuestion := httptest.NewRecorder()
-		reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+		reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -5086,7 +5081,7 @@ func BenchmarkNova_HandleQueryQuestionEssay(b *testing.B) {
 		url = server.URL + "/nova/v1/question/essay"
 		// request query question
 		wQueryQuestion := httptest.NewRecorder()
-		reqModifyQuestion, err := http.NewRequest(http.MethodGet, url+"/"+reQuestionId, nil)
+		reqModifyQuestion, err := newAuthorTestRequest(http.MethodGet, url+"/"+reQuestionId, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -5130,7 +5125,7 @@ func BenchmarkNova_HandleQueryQuestionEssayParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			wQuestionId := httptest.NewRecorder()
-			reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+			reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -5159,7 +5154,7 @@ func BenchmarkNova_HandleQueryQuestionEssayParallel(b *testing.B) {
 			}
 			// request create user
 			wQuestion := httptest.NewRecorder()
-			reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+			reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -5183,7 +5178,7 @@ func BenchmarkNova_HandleQueryQuestionEssayParallel(b *testing.B) {
 			url = server.URL + "/nova/v1/question/essay"
 			// request query question
 			wQueryQuestion := httptest.NewRecorder()
-			reqModifyQuestion, err := http.NewRequest(http.MethodGet, url+"/"+reQuestionId, nil)
+			reqModifyQuestion, err := newAuthorTestRequest(http.MethodGet, url+"/"+reQuestionId, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -5225,7 +5220,7 @@ func TestNova_HandleUpdateQuestionSingleChoice(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	wQuestionId := httptest.NewRecorder()
-	reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+	reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -5274,7 +5269,7 @@ func TestNova_HandleUpdateQuestionSingleChoice(t *testing.T) {
 	}
 	// request create user
 	wQuestion := httptest.NewRecorder()
-	reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+	reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -5328,7 +5323,7 @@ func TestNova_HandleUpdateQuestionSingleChoice(t *testing.T) {
 	}
 	// request modify question
 	wModifyQuestion := httptest.NewRecorder()
-	reqModifyQuestion, err := http.NewRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+	reqModifyQuestion, err := newAuthorTestRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -5370,7 +5365,7 @@ func BenchmarkNova_HandleUpdateQuestionSingleChoice(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		wQuestionId := httptest.NewRecorder()
-		reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+		reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -5419,7 +5414,7 @@ func BenchmarkNova_HandleUpdateQuestionSingleChoice(b *testing.B) {
 		}
 		// request create user
 		wQuestion := httptest.NewRecorder()
-		reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+		reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -5473,7 +5468,7 @@ func BenchmarkNova_HandleUpdateQuestionSingleChoice(b *testing.B) {
 		}
 		// request modify question
 		wModifyQuestion := httptest.NewRecorder()
-		reqModifyQuestion, err := http.NewRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+		reqModifyQuestion, err := newAuthorTestRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -5517,7 +5512,7 @@ func BenchmarkNova_HandleUpdateQuestionSingleChoiceParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			wQuestionId := httptest.NewRecorder()
-			reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+			reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -5566,7 +5561,7 @@ func BenchmarkNova_HandleUpdateQuestionSingleChoiceParallel(b *testing.B) {
 			}
 			// request create user
 			wQuestion := httptest.NewRecorder()
-			reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+			reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -5620,7 +5615,7 @@ func BenchmarkNova_HandleUpdateQuestionSingleChoiceParallel(b *testing.B) {
 			}
 			// request modify question
 			wModifyQuestion := httptest.NewRecorder()
-			reqModifyQuestion, err := http.NewRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+			reqModifyQuestion, err := newAuthorTestRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -5662,7 +5657,7 @@ func TestNova_HandleUpdateQuestionMultipleChoice(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	wQuestionId := httptest.NewRecorder()
-	reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+	reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -5717,7 +5712,7 @@ func TestNova_HandleUpdateQuestionMultipleChoice(t *testing.T) {
 	}
 	// request create user
 	wQuestion := httptest.NewRecorder()
-	reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+	reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -5777,7 +5772,7 @@ func TestNova_HandleUpdateQuestionMultipleChoice(t *testing.T) {
 	}
 	// request modify question
 	wModifyQuestion := httptest.NewRecorder()
-	reqModifyQuestion, err := http.NewRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+	reqModifyQuestion, err := newAuthorTestRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -5819,7 +5814,7 @@ func BenchmarkNova_HandleUpdateQuestionMultipleChoice(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		wQuestionId := httptest.NewRecorder()
-		reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+		reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -5874,7 +5869,7 @@ func BenchmarkNova_HandleUpdateQuestionMultipleChoice(b *testing.B) {
 		}
 		// request create user
 		wQuestion := httptest.NewRecorder()
-		reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+		reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -5934,7 +5929,7 @@ func BenchmarkNova_HandleUpdateQuestionMultipleChoice(b *testing.B) {
 		}
 		// request modify question
 		wModifyQuestion := httptest.NewRecorder()
-		reqModifyQuestion, err := http.NewRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+		reqModifyQuestion, err := newAuthorTestRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -5978,7 +5973,7 @@ func BenchmarkNova_HandleUpdateQuestionMultipleChoiceParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			wQuestionId := httptest.NewRecorder()
-			reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+			reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -6033,7 +6028,7 @@ func BenchmarkNova_HandleUpdateQuestionMultipleChoiceParallel(b *testing.B) {
 			}
 			// request create user
 			wQuestion := httptest.NewRecorder()
-			reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+			reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -6093,7 +6088,7 @@ func BenchmarkNova_HandleUpdateQuestionMultipleChoiceParallel(b *testing.B) {
 			}
 			// request modify question
 			wModifyQuestion := httptest.NewRecorder()
-			reqModifyQuestion, err := http.NewRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+			reqModifyQuestion, err := newAuthorTestRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -6135,7 +6130,7 @@ func TestNova_HandleUpdateQuestionJudgement(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	wQuestionId := httptest.NewRecorder()
-	reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+	reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -6164,7 +6159,7 @@ func TestNova_HandleUpdateQuestionJudgement(t *testing.T) {
 	}
 	// request create user
 	wQuestion := httptest.NewRecorder()
-	reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+	reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -6198,7 +6193,7 @@ func TestNova_HandleUpdateQuestionJudgement(t *testing.T) {
 	}
 	// request modify question
 	wModifyQuestion := httptest.NewRecorder()
-	reqModifyQuestion, err := http.NewRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+	reqModifyQuestion, err := newAuthorTestRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -6240,7 +6235,7 @@ func BenchmarkNova_HandleUpdateQuestionJudgement(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		wQuestionId := httptest.NewRecorder()
-		reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+		reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -6269,7 +6264,7 @@ func BenchmarkNova_HandleUpdateQuestionJudgement(b *testing.B) {
 		}
 		// request create user
 		wQuestion := httptest.NewRecorder()
-		reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+		reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -6303,7 +6298,7 @@ func BenchmarkNova_HandleUpdateQuestionJudgement(b *testing.B) {
 		}
 		// request modify question
 		wModifyQuestion := httptest.NewRecorder()
-		reqModifyQuestion, err := http.NewRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+		reqModifyQuestion, err := newAuthorTestRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -6347,7 +6342,7 @@ func BenchmarkNova_HandleUpdateQuestionJudgementParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			wQuestionId := httptest.NewRecorder()
-			reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+			reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -6376,7 +6371,7 @@ func BenchmarkNova_HandleUpdateQuestionJudgementParallel(b *testing.B) {
 			}
 			// request create user
 			wQuestion := httptest.NewRecorder()
-			reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+			reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -6410,7 +6405,7 @@ func BenchmarkNova_HandleUpdateQuestionJudgementParallel(b *testing.B) {
 			}
 			// request modify question
 			wModifyQuestion := httptest.NewRecorder()
-			reqModifyQuestion, err := http.NewRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+			reqModifyQuestion, err := newAuthorTestRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -6452,7 +6447,7 @@ func TestNova_HandleUpdateQuestionEssay(t *testing.T) {
 	url := server.URL + "/nova/v1/question/Id"
 	// request create questionId
 	wQuestionId := httptest.NewRecorder()
-	reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+	reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -6481,7 +6476,7 @@ func TestNova_HandleUpdateQuestionEssay(t *testing.T) {
 	}
 	// request create user
 	wQuestion := httptest.NewRecorder()
-	reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+	reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -6515,7 +6510,7 @@ func TestNova_HandleUpdateQuestionEssay(t *testing.T) {
 	}
 	// request modify question
 	wModifyQuestion := httptest.NewRecorder()
-	reqModifyQuestion, err := http.NewRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+	reqModifyQuestion, err := newAuthorTestRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
@@ -6557,7 +6552,7 @@ func BenchmarkNova_HandleUpdateQuestionEssay(b *testing.B) {
 		url := server.URL + "/nova/v1/question/Id"
 		// request create questionId
 		wQuestionId := httptest.NewRecorder()
-		reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+		reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -6586,7 +6581,7 @@ func BenchmarkNova_HandleUpdateQuestionEssay(b *testing.B) {
 		}
 		// request create user
 		wQuestion := httptest.NewRecorder()
-		reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+		reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -6620,7 +6615,7 @@ func BenchmarkNova_HandleUpdateQuestionEssay(b *testing.B) {
 		}
 		// request modify question
 		wModifyQuestion := httptest.NewRecorder()
-		reqModifyQuestion, err := http.NewRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+		reqModifyQuestion, err := newAuthorTestRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
@@ -6664,7 +6659,7 @@ func BenchmarkNova_HandleUpdateQuestionEssayParallel(b *testing.B) {
 			url := server.URL + "/nova/v1/question/Id"
 			// request create questionId
 			wQuestionId := httptest.NewRecorder()
-			reqQuestionId, err := http.NewRequest(http.MethodPost, url, nil)
+			reqQuestionId, err := newAuthorTestRequest(http.MethodPost, url, nil)
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -6693,7 +6688,7 @@ func BenchmarkNova_HandleUpdateQuestionEssayParallel(b *testing.B) {
 			}
 			// request create user
 			wQuestion := httptest.NewRecorder()
-			reqQuestion, err := http.NewRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
+			reqQuestion, err := newAuthorTestRequest(http.MethodPost, url+"/"+reQuestionId, bytes.NewReader(body))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -6727,7 +6722,7 @@ func BenchmarkNova_HandleUpdateQuestionEssayParallel(b *testing.B) {
 			}
 			// request modify question
 			wModifyQuestion := httptest.NewRecorder()
-			reqModifyQuestion, err := http.NewRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
+			reqModifyQuestion, err := newAuthorTestRequest(http.MethodPut, url+"/"+reQuestionId, bytes.NewReader(bodyNew))
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
@@ -0,0 +1,265 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"nova/logger"
+	"strings"
+	"time"
+)
+
+// errQuestionFormatNotFound is returned when question has no declared content format
+var errQuestionFormatNotFound = errors.New("question format not found")
+
+func (nova *Nova) HandleUpdateQuestionFormat(c *gin.Context) {
+	// declare content format of question text
+	var request QuestionFormat
+	logger.Infof("handle request update question format")
+	// extract question type & Id from uri
+	questionType := strings.ToLower(c.Param("type"))
+	id := strings.ToLower(c.Param("Id"))
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// request question type & Id correctness
+	logger.Debugf("check question type & Id is validate")
+	if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question type & Id is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question type & Id is validate")
+	// request content format correctness
+	logger.Debugf("check question format is validate")
+	format := strings.ToLower(strings.TrimSpace(request.Format))
+	if b, err := nova.isContentFormatValidate(format); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question format is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question format is validate")
+	// examinees may not change how questions are shown
+	logger.Debugf("check principal is allowed to update format")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to update question format"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to update question format"))
+		logger.Errorf("error check principal is allowed to update format")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to update format")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// query question content from data cache
+	logger.Debugf("query question content in data cache")
+	fields, err := nova.queryQuestionContent(questionType, id)
+	if err != nil {
+		nova.response404NotFound(c, fmt.Errorf("%v question not found", questionType))
+		logger.Errorf("error query question content in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully query question content in data cache")
+	// existing content should be valid in declared format
+	logger.Debugf("check question content is validate")
+	if b, err := validateQuestionContent(format, fields); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question content is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question content is validate")
+	// store question format in database
+	logger.Debugf("store question format in database")
+	response := QuestionFormat{
+		Type:      questionType,
+		Id:        id,
+		Format:    format,
+		UpdatedBy: nova.queryRequestAuthor(c),
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err := nova.db.UpdateQuestionFormat(&response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store question format in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store question format in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionRendering(c *gin.Context) {
+	// render question text to HTML in its declared format
+	logger.Infof("handle request query question rendering")
+	// extract question type & Id from uri
+	questionType := strings.ToLower(c.Param("type"))
+	id := strings.ToLower(c.Param("Id"))
+	// request question type & Id correctness
+	logger.Debugf("check question type & Id is validate")
+	if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question type & Id is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question type & Id is validate")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// query question content from data cache
+	logger.Debugf("query question content in data cache")
+	fields, err := nova.queryQuestionContent(questionType, id)
+	if err != nil || !nova.isQuestionVisible(c, questionType, id) {
+		nova.response404NotFound(c, fmt.Errorf("%v question not found", questionType))
+		logger.Errorf("error query question content in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully query question content in data cache")
+	// answer keys are only rendered for staff
+	if nova.isQuestionAnswerHidden(c) {
+		fields = hideQuestionAnswerFields(fields)
+	}
+	// query declared format of question
+	logger.Debugf("query question format in database")
+	format, err := nova.queryQuestionFormat(questionType, id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query question format in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query question format in database")
+	// render every text field of question
+	response := QuestionRendering{
+		Type:   questionType,
+		Id:     id,
+		Format: format,
+		Fields: fields,
+	}
+	for k, v := range response.Fields {
+		response.Fields[k].HTML, _ = renderQuestionContent(format, v.Source)
+	}
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, len(response.Fields))
+	return
+}
+
+func (nova *Nova) isContentFormatValidate(format string) (bool, error) {
+	// check content format is supported
+	switch format {
+	case ContentFormatPlain, ContentFormatMarkdown:
+		return true, nil
+	default:
+		return false, fmt.Errorf("content format %v not supported", format)
+	}
+}
+
+func (nova *Nova) queryQuestionFormat(questionType string, id string) (string, error) {
+	// questions are plain text until declared otherwise
+	format, err := nova.db.QueryQuestionFormat(questionType, id)
+	if errors.Is(err, errQuestionFormatNotFound) {
+		return ContentFormatPlain, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return format.Format, nil
+}
+
+func (nova *Nova) isQuestionContentValidate(questionType string, id string, question any) (bool, error) {
+	// check question text is valid in format declared for question
+	format, err := nova.queryQuestionFormat(questionType, strings.ToLower(id))
+	if err != nil {
+		return false, err
+	}
+	return validateQuestionContent(format, questionContentFields(question))
+}
+
+func (nova *Nova) queryQuestionContent(questionType string, id string) ([]QuestionRenderedField, error) {
+	// text fields of question in data cache
+	question, err := nova.queryQuestionInDataCache(questionType, id)
+	if err != nil {
+		return nil, err
+	}
+	return questionContentFields(question), nil
+}
+
+func questionContentFields(question any) []QuestionRenderedField {
+	// title, answer texts & essay answers carry formatted content
+	var fields []QuestionRenderedField
+	add := func(field string, text string) {
+		fields = append(fields, QuestionRenderedField{Field: field, Source: text})
+	}
+	addAnswers := func(field string, answers []QuestionAnswer) {
+		for k, v := range answers {
+			add(fmt.Sprintf("%v[%v].answerText", field, k), v.AnswerText)
+		}
+	}
+	switch q := question.(type) {
+	case QuestionSingleChoice:
+		add("title", q.Title)
+		addAnswers("answers", q.Answers)
+		add("standard_answer.answerText", q.StandardAnswer.AnswerText)
+	case QuestionMultipleChoice:
+		add("title", q.Title)
+		addAnswers("answers", q.Answers)
+		addAnswers("standard_answers", q.StandardAnswers)
+	case QuestionJudgement:
+		add("title", q.Title)
+	case QuestionEssay:
+		add("title", q.Title)
+		add("answer", q.Answer)
+		add("standard_answer", q.StandardAnswer)
+	case QuestionFillBlank:
+		add("title", q.Title)
+	case QuestionMatching:
+		add("title", q.Title)
+	case QuestionOrdering:
+		add("title", q.Title)
+	case QuestionNumeric:
+		add("title", q.Title)
+	case QuestionTemplate:
+		add("title", q.Title)
+	case QuestionCode:
+		add("title", q.Title)
+	}
+	return fields
+}
+
+func hideQuestionAnswerFields(fields []QuestionRenderedField) []QuestionRenderedField {
+	// standard answers are dropped from fields rendered for examinees
+	shown := make([]QuestionRenderedField, 0, len(fields))
+	for _, v := range fields {
+		if !strings.HasPrefix(v.Field, "standard_answer") {
+			shown = append(shown, v)
+		}
+	}
+	return shown
+}
+
+func renderQuestionContent(format string, text string) (string, []string) {
+	// render text to sanitized HTML, reports problems of markdown source
+	if format == ContentFormatMarkdown {
+		return renderMarkdown(text)
+	}
+	return renderPlainText(text), nil
+}
@@ -0,0 +1,200 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"nova/utils"
+	"testing"
+)
+
+func setupRenderTestRouter() *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// author changing questions
+	createTestAuthor(nova)
+	// first administrator is granted by command line
+	setupTestAdministrator(nova)
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	{
+		/* user management */
+		novaService.POST("/user/:userId", nova.HandleCreateUser)
+		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
+		novaService.PUT("/user/role/:userId", nova.HandleUpdateUserRole)
+		/* question management */
+		// question workflow related
+		novaService.POST("/question/workflow/:type/:Id/transition", nova.HandleCreateQuestionTransition)
+		novaService.POST("/question/workflow/:type/:Id/approve", nova.HandleCreateQuestionApproval)
+		// question format related
+		novaService.PUT("/question/format/:type/:Id", nova.HandleUpdateQuestionFormat)
+		novaService.GET("/question/render/:type/:Id", nova.HandleQueryQuestionRendering)
+		// question related
+		novaService.POST("/question/single-choice/:Id", nova.HandleCreateQuestionSingleChoice)
+		novaService.PUT("/question/single-choice/:Id", nova.HandleUpdateQuestionSingleChoice)
+		novaService.PATCH("/question/single-choice/:Id", nova.HandleModifyQuestionSingleChoice)
+		novaService.POST("/question/essay/:Id", nova.HandleCreateQuestionEssay)
+	}
+	return router
+}
+
+func startRenderTestService() (*httptest.Server, *gin.Engine) {
+	router := setupRenderTestRouter()
+	return httptest.NewServer(router), router
+}
+
+func TestNova_HandleQueryQuestionRendering(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleQueryQuestionRendering
+	// Test Purpose: Test markdown question text is rendered to sanitized HTML
+	// Test Steps:
+	// 1. send CreateQuestion request for essay question with markdown, math & HTML in text
+	// 2. send QueryQuestionRendering request, plain text is escaped
+	// 3. send UpdateQuestionFormat request declaring markdown format
+	// 4. send QueryQuestionRendering request, receive rendered HTML by using 200 OK Code
+	// 5. send QueryQuestionRendering request of published question with examinee session, standard answer is left out
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startRenderTestService()
+	defer server.Close()
+	/* create essay question */
+	question := QuestionEssay{
+		Id:             uuid.New().String(),
+		Title:          "# Prove $a^2 + b^2 = c^2$\n\nUse **similar** triangles, see [notes](https://example.com/notes) " + utils.RandomAlphabet(12),
+		Answer:         "-",
+		StandardAnswer: "<script>alert(1)</script> [click](javascript:alert(1)) ![x](data:image/png;base64,AA)\n\n```go\nfmt.Println(\"<b>\")\n```\n\n$$\n\\frac{a}{b}\n$$\n\n- one\n- `two`",
+	}
+	url := server.URL + "/nova/v1/question/essay/" + question.Id
+	w := serveTestRequest(t, router, http.MethodPost, url, question, testAuthorToken)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	/* plain text is escaped */
+	renderURL := server.URL + "/nova/v1/question/render/essay/" + question.Id
+	w = serveTestRequest(t, router, http.MethodGet, renderURL, nil, testAuthorToken)
+	var rendering QuestionRendering
+	unmarshalTestResponse(t, w, &rendering)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, ContentFormatPlain, rendering.Format)
+	if assert.Len(t, rendering.Fields, 3) {
+		assert.Equal(t, "standard_answer", rendering.Fields[2].Field)
+		assert.Contains(t, rendering.Fields[2].HTML, "&lt;script&gt;")
+	}
+	/* declare markdown format */
+	w = serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/question/format/essay/"+question.Id, QuestionFormat{Format: "Markdown"}, testAuthorToken)
+	var format QuestionFormat
+	unmarshalTestResponse(t, w, &format)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, ContentFormatMarkdown, format.Format)
+	/* markdown is rendered & sanitized */
+	w = serveTestRequest(t, router, http.MethodGet, renderURL, nil, testAuthorToken)
+	rendering = QuestionRendering{}
+	unmarshalTestResponse(t, w, &rendering)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, ContentFormatMarkdown, rendering.Format)
+	if assert.Len(t, rendering.Fields, 3) {
+		title := rendering.Fields[0].HTML
+		assert.Contains(t, title, `<h1>Prove <span class="math inline">\(a^2 + b^2 = c^2\)</span></h1>`)
+		assert.Contains(t, title, `<strong>similar</strong>`)
+		assert.Contains(t, title, `<a href="https://example.com/notes" rel="nofollow noopener noreferrer">notes</a>`)
+		standardAnswer := rendering.Fields[2].HTML
+		assert.Contains(t, standardAnswer, "&lt;script&gt;alert(1)&lt;/script&gt;")
+		assert.NotContains(t, standardAnswer, "<script")
+		assert.NotContains(t, standardAnswer, "javascript:")
+		assert.NotContains(t, standardAnswer, "<img")
+		assert.Contains(t, standardAnswer, "click")
+		assert.Contains(t, standardAnswer, `<pre><code class="language-go">fmt.Println(&#34;&lt;b&gt;&#34;)`)
+		assert.Contains(t, standardAnswer, `<span class="math display">\[\frac{a}{b}\]</span>`)
+		assert.Contains(t, standardAnswer, "<ul>\n<li>one</li>\n<li><code>two</code></li>\n</ul>")
+	}
+	/* answer key is not rendered for examinees */
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	_, examineeSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	workflowURL := server.URL + "/nova/v1/question/workflow/essay/" + question.Id
+	w = serveTestRequest(t, router, http.MethodPost, workflowURL+"/transition", QuestionTransition{State: QuestionStateInReview}, testAuthorToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, workflowURL+"/approve", nil, admin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	for _, token := range []string{examineeSession.Token, ""} {
+		w = serveTestRequest(t, router, http.MethodGet, renderURL, nil, token)
+		rendering = QuestionRendering{}
+		unmarshalTestResponse(t, w, &rendering)
+		assert.Equal(t, http.StatusOK, w.Code)
+		if assert.Len(t, rendering.Fields, 2) {
+			assert.Equal(t, "title", rendering.Fields[0].Field)
+			assert.Equal(t, "answer", rendering.Fields[1].Field)
+		}
+	}
+}
+
+func TestNova_HandleUpdateQuestionFormat(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleUpdateQuestionFormat
+	// Test Purpose: Test LaTeX delimiters are validated in markdown questions
+	// Test Steps:
+	// 1. send CreateQuestion request for single-choice question with unclosed math by using POST method
+	// 2. send UpdateQuestionFormat request declaring markdown format, receive 400 Bad Request Code
+	// 3. send UpdateQuestion request closing math, then UpdateQuestionFormat request succeeds
+	// 4. send ModifyQuestion request with unbalanced LaTeX, receive 400 Bad Request Code
+	// 5. send UpdateQuestionFormat request with examinee session, receive 403 Forbidden Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startRenderTestService()
+	defer server.Close()
+	/* create single-choice question with unclosed math */
+	question := QuestionSingleChoice{
+		Id:    uuid.New().String(),
+		Title: "Which value solves $x + 1 = 3 for " + utils.RandomAlphabet(12),
+		Answers: []QuestionAnswer{
+			{AnswerMark: "A", AnswerText: "$x = 2$"},
+			{AnswerMark: "B", AnswerText: "costs $5"},
+		},
+		StandardAnswer: QuestionAnswer{AnswerMark: "A", AnswerText: "$x = 2$"},
+	}
+	url := server.URL + "/nova/v1/question/single-choice/" + question.Id
+	w := serveTestRequest(t, router, http.MethodPost, url, question, testAuthorToken)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	/* markdown format rejects unclosed math */
+	formatURL := server.URL + "/nova/v1/question/format/single-choice/" + question.Id
+	w = serveTestRequest(t, router, http.MethodPut, formatURL, QuestionFormat{Format: ContentFormatMarkdown}, testAuthorToken)
+	var problem ProblemDetails
+	unmarshalTestResponse(t, w, &problem)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	if assert.Len(t, problem.Errors, 1) {
+		assert.Equal(t, "title", problem.Errors[0].Field)
+	}
+	w = serveTestRequest(t, router, http.MethodPut, formatURL, QuestionFormat{Format: "html"}, testAuthorToken)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	/* close math & declare markdown format */
+	question.Title = "Which value solves $x + 1 = 3$ for " + utils.RandomAlphabet(12)
+	w = serveTestRequest(t, router, http.MethodPut, url, question, testAuthorToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodPut, formatURL, QuestionFormat{Format: ContentFormatMarkdown}, testAuthorToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+	/* update with unbalanced LaTeX is rejected */
+	question.Answers[1].AnswerText = `$\frac{1}{2$`
+	w = serveTestRequest(t, router, http.MethodPatch, url, question, testAuthorToken)
+	problem = ProblemDetails{}
+	unmarshalTestResponse(t, w, &problem)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	if assert.Len(t, problem.Errors, 1) {
+		assert.Equal(t, "answers[1].answerText", problem.Errors[0].Field)
+		assert.Contains(t, problem.Errors[0].Reason, "unbalanced braces")
+	}
+	/* examinee may not change format */
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	_, examineeSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	w = serveTestRequest(t, router, http.MethodPut, formatURL, QuestionFormat{Format: ContentFormatPlain}, examineeSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
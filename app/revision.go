@@ -0,0 +1,704 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"nova/logger"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const systemAuthor = "system"
+
+func (nova *Nova) HandleQueryQuestionRevisions(c *gin.Context) {
+	// query question revisions
+	logger.Infof("handle request query question revisions")
+	// revisions reveal answer keys, examinees are not allowed to see them
+	logger.Debugf("check principal is allowed to query revisions")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to query question revisions"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to query question revisions"))
+		logger.Errorf("error check principal is allowed to query revisions")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to query revisions")
+	// extract question type & Id from uri
+	questionType := strings.ToLower(c.Param("type"))
+	id := strings.ToLower(c.Param("Id"))
+	// request question type & Id correctness
+	logger.Debugf("check question type & Id is validate")
+	if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question type & Id is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question type & Id is validate")
+	// revisions of deleted question stay in tenant of question
+	logger.Debugf("check question is in tenant scope")
+	if !nova.isResourceVisible(c, questionType, id) {
+		nova.response404NotFound(c, errors.New("question revisions not found"))
+		logger.Errorf("error check question is in tenant scope")
+		return
+	}
+	logger.Debugf("successfully check question is in tenant scope")
+	// query question revisions from database
+	logger.Debugf("query question revisions in database")
+	revisions, err := nova.db.QueryQuestionRevisions(questionType, id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query question revisions in database: %v", err)
+		return
+	}
+	if len(revisions) == 0 {
+		nova.response404NotFound(c, errors.New("question revisions not found"))
+		logger.Errorf("error query question revisions in database: not found")
+		return
+	}
+	logger.Debugf("successfully query question revisions in database")
+	// return response
+	response := make([]QuestionRevision, 0, len(revisions))
+	for _, v := range revisions {
+		response = append(response, *v)
+	}
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, len(response))
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionRevision(c *gin.Context) {
+	// query question revision
+	logger.Infof("handle request query question revision")
+	// revisions reveal answer keys, examinees are not allowed to see them
+	logger.Debugf("check principal is allowed to query revisions")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to query question revisions"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to query question revisions"))
+		logger.Errorf("error check principal is allowed to query revisions")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to query revisions")
+	// extract question type, Id & revision from uri
+	questionType := strings.ToLower(c.Param("type"))
+	id := strings.ToLower(c.Param("Id"))
+	// request question type, Id & revision correctness
+	logger.Debugf("check question type, Id & revision is validate")
+	if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question type & Id is validate: %v", err)
+		return
+	}
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil || revision <= 0 {
+		nova.response400BadRequest(c, errors.New("question revision format incorrect"))
+		logger.Errorf("error check question revision is validate: %v", c.Param("revision"))
+		return
+	}
+	logger.Debugf("successfully check question type, Id & revision is validate")
+	// revisions of deleted question stay in tenant of question
+	logger.Debugf("check question is in tenant scope")
+	if !nova.isResourceVisible(c, questionType, id) {
+		nova.response404NotFound(c, errors.New("question revisions not found"))
+		logger.Errorf("error check question is in tenant scope")
+		return
+	}
+	logger.Debugf("successfully check question is in tenant scope")
+	// query question revision from database
+	logger.Debugf("query question revision in database")
+	response, err := nova.db.QueryQuestionRevision(questionType, id, revision)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query question revision in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionRevisionDiff(c *gin.Context) {
+	// query difference between question revisions
+	logger.Infof("handle request query question revision diff")
+	// revisions reveal answer keys, examinees are not allowed to see them
+	logger.Debugf("check principal is allowed to query revisions")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to query question revisions"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to query question revisions"))
+		logger.Errorf("error check principal is allowed to query revisions")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to query revisions")
+	// extract question type, Id & revision from uri
+	questionType := strings.ToLower(c.Param("type"))
+	id := strings.ToLower(c.Param("Id"))
+	// request question type, Id & revisions correctness
+	logger.Debugf("check question type, Id & revisions is validate")
+	if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question type & Id is validate: %v", err)
+		return
+	}
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil || revision <= 0 {
+		nova.response400BadRequest(c, errors.New("question revision format incorrect"))
+		logger.Errorf("error check question revision is validate: %v", c.Param("revision"))
+		return
+	}
+	// compare with previous revision by default
+	base := revision - 1
+	if s := c.Query("base"); s != "" {
+		base, err = strconv.Atoi(s)
+		if err != nil || base <= 0 {
+			nova.response400BadRequest(c, errors.New("question base revision format incorrect"))
+			logger.Errorf("error check question base revision is validate: %v", s)
+			return
+		}
+	}
+	if base <= 0 {
+		nova.response400BadRequest(c, errors.New("question revision has no previous revision"))
+		logger.Errorf("error check question base revision is validate: %v", base)
+		return
+	}
+	logger.Debugf("successfully check question type, Id & revisions is validate")
+	// revisions of deleted question stay in tenant of question
+	logger.Debugf("check question is in tenant scope")
+	if !nova.isResourceVisible(c, questionType, id) {
+		nova.response404NotFound(c, errors.New("question revisions not found"))
+		logger.Errorf("error check question is in tenant scope")
+		return
+	}
+	logger.Debugf("successfully check question is in tenant scope")
+	// query question revisions from database
+	logger.Debugf("query question revisions in database")
+	before, err := nova.db.QueryQuestionRevision(questionType, id, base)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query question base revision in database: %v", err)
+		return
+	}
+	after, err := nova.db.QueryQuestionRevision(questionType, id, revision)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query question revisions in database")
+	// compare question revisions
+	logger.Debugf("compare question revisions")
+	changes, err := diffQuestionRevisions(before.Content, after.Content)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error compare question revisions: %v", err)
+		return
+	}
+	logger.Debugf("successfully compare question revisions")
+	// return response
+	response := QuestionRevisionDiff{
+		Type:     questionType,
+		Id:       id,
+		Base:     base,
+		Revision: revision,
+		Changes:  changes,
+	}
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleCreateQuestionRevisionRestore(c *gin.Context) {
+	// restore question to prior revision
+	logger.Infof("handle request restore question revision")
+	// only authors & administrators change questions
+	logger.Debugf("check principal is allowed to restore question revision")
+	if !nova.checkQuestionAuthor(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to restore question revision")
+	// extract question type, Id & revision from uri
+	questionType := strings.ToLower(c.Param("type"))
+	id := strings.ToLower(c.Param("Id"))
+	// request question type, Id & revision correctness
+	logger.Debugf("check question type, Id & revision is validate")
+	if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question type & Id is validate: %v", err)
+		return
+	}
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil || revision <= 0 {
+		nova.response400BadRequest(c, errors.New("question revision format incorrect"))
+		logger.Errorf("error check question revision is validate: %v", c.Param("revision"))
+		return
+	}
+	logger.Debugf("successfully check question type, Id & revision is validate")
+	// revisions of deleted question stay in tenant of question
+	logger.Debugf("check question is in tenant scope")
+	if !nova.isResourceVisible(c, questionType, id) {
+		nova.response404NotFound(c, errors.New("question revisions not found"))
+		logger.Errorf("error check question is in tenant scope")
+		return
+	}
+	logger.Debugf("successfully check question is in tenant scope")
+	// query question revision from database
+	logger.Debugf("query question revision in database")
+	restored, err := nova.db.QueryQuestionRevision(questionType, id, revision)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query question revision in database")
+	// revision recorded before content rules should still satisfy them
+	logger.Debugf("check question revision is validate")
+	if b, err := nova.isQuestionRevisionValidate(restored); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question revision is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question revision is validate")
+	// restore question in data cache & database
+	logger.Debugf("restore question in data cache & database")
+	previous, current, err := nova.restoreQuestionRevision(restored)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error restore question in data cache & database: %v", err)
+		return
+	}
+	logger.Debugf("successfully restore question in data cache & database")
+	// store restored question as new revision
+	logger.Debugf("store question revision in database")
+	response, err := nova.createQuestionRevision(c, questionType, id, previous, current)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store question revision in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store question revision in database")
+	// restore question guidance of revision
+	logger.Debugf("restore question guidance in database")
+	if err := nova.restoreQuestionGuidance(c, questionType, id, revision, response.Revision); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error restore question guidance in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully restore question guidance in database")
+	// recreated or restored published question goes through review again
+	logger.Debugf("store question workflow in database")
+	if previous == nil {
+		_, err = nova.createQuestionWorkflow(c, questionType, id)
+	} else {
+		err = nova.reviseQuestionWorkflow(c, questionType, id)
+	}
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store question workflow in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) createQuestionRevision(c *gin.Context, questionType string, id string, previous any, current any) (QuestionRevision, error) {
+	// serialize revision numbering of questions
+	nova.cache.questionsCache.revisionCache.mutex.Lock()
+	defer nova.cache.questionsCache.revisionCache.mutex.Unlock()
+	if nova.cache.questionsCache.revisionCache.revisionSet == nil {
+		nova.cache.questionsCache.revisionCache.revisionSet = make(map[string]int)
+	}
+	key := questionType + "/" + id
+	latest, ok := nova.cache.questionsCache.revisionCache.revisionSet[key]
+	if !ok {
+		v, err := nova.db.QueryQuestionLatestRevision(questionType, id)
+		if err != nil {
+			return QuestionRevision{}, err
+		}
+		latest = v
+	}
+	// question created before revision history keeps its content as first revision
+	now := time.Now().UTC()
+	if latest == 0 && previous != nil {
+		content, err := json.Marshal(previous)
+		if err != nil {
+			return QuestionRevision{}, err
+		}
+		baseline := QuestionRevision{questionType, id, 1, systemAuthor, now, content}
+		if err := nova.db.CreateQuestionRevision(&baseline); err != nil {
+			return QuestionRevision{}, err
+		}
+		latest = 1
+		nova.cache.questionsCache.revisionCache.revisionSet[key] = latest
+	}
+	// store current content as next revision
+	content, err := json.Marshal(current)
+	if err != nil {
+		return QuestionRevision{}, err
+	}
+	revision := QuestionRevision{questionType, id, latest + 1, nova.queryRequestAuthor(c), now, content}
+	if err := nova.db.CreateQuestionRevision(&revision); err != nil {
+		delete(nova.cache.questionsCache.revisionCache.revisionSet, key)
+		return QuestionRevision{}, err
+	}
+	nova.cache.questionsCache.revisionCache.revisionSet[key] = revision.Revision
+	return revision, nil
+}
+
+func (nova *Nova) queryQuestionLatestRevision(questionType string, id string) (int, error) {
+	// search latest revision in data cache
+	nova.cache.questionsCache.revisionCache.mutex.Lock()
+	defer nova.cache.questionsCache.revisionCache.mutex.Unlock()
+	if v, ok := nova.cache.questionsCache.revisionCache.revisionSet[questionType+"/"+id]; ok {
+		return v, nil
+	}
+	// query latest revision from database
+	return nova.db.QueryQuestionLatestRevision(questionType, id)
+}
+
+func (nova *Nova) isQuestionRevisionValidate(revision *QuestionRevision) (bool, error) {
+	// decode revision content & check it like question request body
+	question, err := decodeQuestionRevision(revision)
+	if err != nil {
+		return false, err
+	}
+	switch q := question.(type) {
+	case QuestionSingleChoice:
+		return nova.isSingleChoiceQuestionValidate(q)
+	case QuestionMultipleChoice:
+		return nova.isMultipleChoiceQuestionValidate(q)
+	case QuestionJudgement:
+		return nova.isJudgementQuestionValidate(q)
+	case QuestionEssay:
+		return nova.isEssayQuestionValidate(q)
+	case QuestionFillBlank:
+		return nova.isFillBlankQuestionValidate(q)
+	case QuestionMatching:
+		return nova.isMatchingQuestionValidate(q)
+	case QuestionOrdering:
+		return nova.isOrderingQuestionValidate(q)
+	case QuestionNumeric:
+		return nova.isNumericQuestionValidate(q)
+	case QuestionTemplate:
+		return nova.isTemplateQuestionValidate(q)
+	case QuestionCode:
+		return nova.isCodeQuestionValidate(q)
+	default:
+		return false, fmt.Errorf("question type %v not supported", revision.Type)
+	}
+}
+
+func (nova *Nova) pinQuestionRevision(questionType string, id string) (int, error) {
+	// serialize revision numbering of questions
+	nova.cache.questionsCache.revisionCache.mutex.Lock()
+	defer nova.cache.questionsCache.revisionCache.mutex.Unlock()
+	if nova.cache.questionsCache.revisionCache.revisionSet == nil {
+		nova.cache.questionsCache.revisionCache.revisionSet = make(map[string]int)
+	}
+	key := questionType + "/" + id
+	if v, ok := nova.cache.questionsCache.revisionCache.revisionSet[key]; ok {
+		return v, nil
+	}
+	latest, err := nova.db.QueryQuestionLatestRevision(questionType, id)
+	if err != nil || latest > 0 {
+		return latest, err
+	}
+	// question created before revision history keeps its content as first revision
+	question, err := nova.queryQuestionInDataCache(questionType, id)
+	if err != nil {
+		return 0, err
+	}
+	content, err := json.Marshal(question)
+	if err != nil {
+		return 0, err
+	}
+	baseline := QuestionRevision{questionType, id, 1, systemAuthor, time.Now().UTC(), content}
+	if err := nova.db.CreateQuestionRevision(&baseline); err != nil {
+		return 0, err
+	}
+	nova.cache.questionsCache.revisionCache.revisionSet[key] = baseline.Revision
+	return baseline.Revision, nil
+}
+
+func (nova *Nova) queryQuestionRevisionContent(questionType string, id string, revision int) (any, error) {
+	// question without pinned revision is read from data cache
+	if revision == 0 {
+		return nova.queryQuestionInDataCache(questionType, id)
+	}
+	v, err := nova.db.QueryQuestionRevision(questionType, id, revision)
+	if err != nil {
+		return nil, err
+	}
+	return decodeQuestionRevision(v)
+}
+
+func decodeQuestionRevision(revision *QuestionRevision) (any, error) {
+	// decode revision content into question of its type
+	switch revision.Type {
+	case QuestionTypeSingleChoice:
+		var question QuestionSingleChoice
+		err := json.Unmarshal(revision.Content, &question)
+		return question, err
+	case QuestionTypeMultipleChoice:
+		var question QuestionMultipleChoice
+		err := json.Unmarshal(revision.Content, &question)
+		return question, err
+	case QuestionTypeJudgement:
+		var question QuestionJudgement
+		err := json.Unmarshal(revision.Content, &question)
+		return question, err
+	case QuestionTypeEssay:
+		var question QuestionEssay
+		err := json.Unmarshal(revision.Content, &question)
+		return question, err
+	case QuestionTypeFillBlank:
+		var question QuestionFillBlank
+		err := json.Unmarshal(revision.Content, &question)
+		return question, err
+	case QuestionTypeMatching:
+		var question QuestionMatching
+		err := json.Unmarshal(revision.Content, &question)
+		return question, err
+	case QuestionTypeOrdering:
+		var question QuestionOrdering
+		err := json.Unmarshal(revision.Content, &question)
+		return question, err
+	case QuestionTypeNumeric:
+		var question QuestionNumeric
+		err := json.Unmarshal(revision.Content, &question)
+		return question, err
+	case QuestionTypeTemplate:
+		var question QuestionTemplate
+		err := json.Unmarshal(revision.Content, &question)
+		return question, err
+	case QuestionTypeCode:
+		var question QuestionCode
+		err := json.Unmarshal(revision.Content, &question)
+		return question, err
+	default:
+		return nil, fmt.Errorf("question type %v not supported", revision.Type)
+	}
+}
+
+func (nova *Nova) restoreQuestionRevision(revision *QuestionRevision) (any, any, error) {
+	switch revision.Type {
+	case QuestionTypeSingleChoice:
+		var question QuestionSingleChoice
+		if err := json.Unmarshal(revision.Content, &question); err != nil {
+			return nil, nil, err
+		}
+		if err := nova.querySingleChoiceQuestionsInDatabase(); err != nil {
+			return nil, nil, err
+		}
+		// replace existed question, otherwise create it again
+		previous, err := nova.querySingleChoiceQuestionInDataCache(revision.Id)
+		if err != nil {
+			nova.createSingleChoiceQuestionInDataCache(question)
+			return nil, question, nova.createSingleChoiceQuestionInDatabase(question.Id)
+		}
+		nova.updateSingleChoiceQuestionInDataCache(question)
+		return previous, question, nova.updateSingleChoiceQuestionInDatabase(question.Id)
+	case QuestionTypeMultipleChoice:
+		var question QuestionMultipleChoice
+		if err := json.Unmarshal(revision.Content, &question); err != nil {
+			return nil, nil, err
+		}
+		if err := nova.queryMultipleChoiceQuestionsInDatabase(); err != nil {
+			return nil, nil, err
+		}
+		// replace existed question, otherwise create it again
+		previous, err := nova.queryMultipleChoiceQuestionInDataCache(revision.Id)
+		if err != nil {
+			nova.createMultipleChoiceQuestionInDataCache(question)
+			return nil, question, nova.createMultipleChoiceQuestionInDatabase(question.Id)
+		}
+		nova.updateMultipleChoiceQuestionInDataCache(question)
+		return previous, question, nova.updateMultipleChoiceQuestionInDatabase(question.Id)
+	case QuestionTypeJudgement:
+		var question QuestionJudgement
+		if err := json.Unmarshal(revision.Content, &question); err != nil {
+			return nil, nil, err
+		}
+		if err := nova.queryJudgementQuestionsInDatabase(); err != nil {
+			return nil, nil, err
+		}
+		// replace existed question, otherwise create it again
+		previous, err := nova.queryJudgementQuestionInDataCache(revision.Id)
+		if err != nil {
+			nova.createJudgementQuestionInDataCache(question)
+			return nil, question, nova.createJudgementQuestionInDatabase(question.Id)
+		}
+		nova.updateJudgementQuestionInDataCache(question)
+		return previous, question, nova.updateJudgementQuestionInDatabase(question.Id)
+	case QuestionTypeEssay:
+		var question QuestionEssay
+		if err := json.Unmarshal(revision.Content, &question); err != nil {
+			return nil, nil, err
+		}
+		if err := nova.queryEssayQuestionsInDatabase(); err != nil {
+			return nil, nil, err
+		}
+		// replace existed question, otherwise create it again
+		previous, err := nova.queryEssayQuestionInDataCache(revision.Id)
+		if err != nil {
+			nova.createEssayQuestionInDataCache(question)
+			return nil, question, nova.createEssayQuestionInDatabase(question.Id)
+		}
+		nova.updateEssayQuestionInDataCache(question)
+		return previous, question, nova.updateEssayQuestionInDatabase(question.Id)
+	case QuestionTypeFillBlank:
+		var question QuestionFillBlank
+		if err := json.Unmarshal(revision.Content, &question); err != nil {
+			return nil, nil, err
+		}
+		if err := nova.queryFillBlankQuestionsInDatabase(); err != nil {
+			return nil, nil, err
+		}
+		// replace existed question, otherwise create it again
+		previous, err := nova.queryFillBlankQuestionInDataCache(revision.Id)
+		if err != nil {
+			nova.createFillBlankQuestionInDataCache(question)
+			return nil, question, nova.createFillBlankQuestionInDatabase(question.Id)
+		}
+		nova.updateFillBlankQuestionInDataCache(question)
+		return previous, question, nova.updateFillBlankQuestionInDatabase(question.Id)
+	case QuestionTypeMatching:
+		var question QuestionMatching
+		if err := json.Unmarshal(revision.Content, &question); err != nil {
+			return nil, nil, err
+		}
+		if err := nova.queryMatchingQuestionsInDatabase(); err != nil {
+			return nil, nil, err
+		}
+		// replace existed question, otherwise create it again
+		previous, err := nova.queryMatchingQuestionInDataCache(revision.Id)
+		if err != nil {
+			nova.createMatchingQuestionInDataCache(question)
+			return nil, question, nova.createMatchingQuestionInDatabase(question.Id)
+		}
+		nova.updateMatchingQuestionInDataCache(question)
+		return previous, question, nova.updateMatchingQuestionInDatabase(question.Id)
+	case QuestionTypeOrdering:
+		var question QuestionOrdering
+		if err := json.Unmarshal(revision.Content, &question); err != nil {
+			return nil, nil, err
+		}
+		if err := nova.queryOrderingQuestionsInDatabase(); err != nil {
+			return nil, nil, err
+		}
+		// replace existed question, otherwise create it again
+		previous, err := nova.queryOrderingQuestionInDataCache(revision.Id)
+		if err != nil {
+			nova.createOrderingQuestionInDataCache(question)
+			return nil, question, nova.createOrderingQuestionInDatabase(question.Id)
+		}
+		nova.updateOrderingQuestionInDataCache(question)
+		return previous, question, nova.updateOrderingQuestionInDatabase(question.Id)
+	case QuestionTypeNumeric:
+		var question QuestionNumeric
+		if err := json.Unmarshal(revision.Content, &question); err != nil {
+			return nil, nil, err
+		}
+		if err := nova.queryNumericQuestionsInDatabase(); err != nil {
+			return nil, nil, err
+		}
+		// replace existed question, otherwise create it again
+		previous, err := nova.queryNumericQuestionInDataCache(revision.Id)
+		if err != nil {
+			nova.createNumericQuestionInDataCache(question)
+			return nil, question, nova.createNumericQuestionInDatabase(question.Id)
+		}
+		nova.updateNumericQuestionInDataCache(question)
+		return previous, question, nova.updateNumericQuestionInDatabase(question.Id)
+	case QuestionTypeTemplate:
+		var question QuestionTemplate
+		if err := json.Unmarshal(revision.Content, &question); err != nil {
+			return nil, nil, err
+		}
+		if err := nova.queryTemplateQuestionsInDatabase(); err != nil {
+			return nil, nil, err
+		}
+		// replace existed question, otherwise create it again
+		previous, err := nova.queryTemplateQuestionInDataCache(revision.Id)
+		if err != nil {
+			nova.createTemplateQuestionInDataCache(question)
+			return nil, question, nova.createTemplateQuestionInDatabase(question.Id)
+		}
+		nova.updateTemplateQuestionInDataCache(question)
+		return previous, question, nova.updateTemplateQuestionInDatabase(question.Id)
+	case QuestionTypeCode:
+		var question QuestionCode
+		if err := json.Unmarshal(revision.Content, &question); err != nil {
+			return nil, nil, err
+		}
+		if err := nova.queryCodeQuestionsInDatabase(); err != nil {
+			return nil, nil, err
+		}
+		// replace existed question, otherwise create it again
+		previous, err := nova.queryCodeQuestionInDataCache(revision.Id)
+		if err != nil {
+			nova.createCodeQuestionInDataCache(question)
+			return nil, question, nova.createCodeQuestionInDatabase(question.Id)
+		}
+		nova.updateCodeQuestionInDataCache(question)
+		return previous, question, nova.updateCodeQuestionInDatabase(question.Id)
+	default:
+		return nil, nil, fmt.Errorf("question type %v not supported", revision.Type)
+	}
+}
+
+func diffQuestionRevisions(before json.RawMessage, after json.RawMessage) ([]QuestionRevisionChange, error) {
+	// decode revisions into top-level fields
+	var a, b map[string]json.RawMessage
+	if err := json.Unmarshal(before, &a); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(after, &b); err != nil {
+		return nil, err
+	}
+	fields := make(map[string]bool)
+	for k := range a {
+		fields[k] = true
+	}
+	for k := range b {
+		fields[k] = true
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	// collect changed fields
+	changes := make([]QuestionRevisionChange, 0)
+	for _, k := range keys {
+		if bytes.Equal(compactQuestionRevisionField(a[k]), compactQuestionRevisionField(b[k])) {
+			continue
+		}
+		changes = append(changes, QuestionRevisionChange{Field: k, Before: a[k], After: b[k]})
+	}
+	return changes, nil
+}
+
+func compactQuestionRevisionField(field json.RawMessage) []byte {
+	var buffer bytes.Buffer
+	if err := json.Compact(&buffer, field); err != nil {
+		return field
+	}
+	return buffer.Bytes()
+}
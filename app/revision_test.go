@@ -0,0 +1,256 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"nova/utils"
+	"testing"
+)
+
+func setupRevisionTestRouter() *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// author changing questions
+	createTestAuthor(nova)
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	{
+		/* user management */
+		novaService.POST("/user/:userId", nova.HandleCreateUser)
+		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
+		/* question management */
+		// question revision related
+		novaService.GET("/question/revision/:type/:Id", nova.HandleQueryQuestionRevisions)
+		novaService.GET("/question/revision/:type/:Id/:revision", nova.HandleQueryQuestionRevision)
+		novaService.GET("/question/revision/:type/:Id/:revision/diff", nova.HandleQueryQuestionRevisionDiff)
+		novaService.POST("/question/revision/:type/:Id/:revision/restore", nova.HandleCreateQuestionRevisionRestore)
+		// question related
+		novaService.POST("/question/single-choice/:Id", nova.HandleCreateQuestionSingleChoice)
+		novaService.PUT("/question/single-choice/:Id", nova.HandleUpdateQuestionSingleChoice)
+		novaService.DELETE("/question/single-choice/:Id", nova.HandleDeleteQuestionSingleChoice)
+		novaService.GET("/question/single-choice/:Id", nova.HandleQueryQuestionSingleChoice)
+		novaService.POST("/question/essay/:Id", nova.HandleCreateQuestionEssay)
+		novaService.PATCH("/question/essay/:Id", nova.HandleModifyQuestionEssay)
+	}
+	return router
+}
+
+func startRevisionTestService() (*httptest.Server, *gin.Engine) {
+	router := setupRevisionTestRouter()
+	return httptest.NewServer(router), router
+}
+
+func createRevisionTestSingleChoice(t *testing.T, server *httptest.Server, router *gin.Engine) QuestionSingleChoice {
+	// create single-choice question
+	question := QuestionSingleChoice{
+		Id:    uuid.New().String(),
+		Title: "Which port does " + utils.RandomAlphabet(12) + " listen on?",
+		Answers: []QuestionAnswer{
+			{"A", "80"},
+			{"B", "443"},
+		},
+		StandardAnswer: QuestionAnswer{"A", "80"},
+	}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/single-choice/"+question.Id, question, testAuthorToken)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	// update single-choice question answer key
+	updated := question
+	updated.Title = question.Title + " (TLS)"
+	updated.StandardAnswer = QuestionAnswer{"B", "443"}
+	w = serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/question/single-choice/"+question.Id, updated, testAuthorToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+	return question
+}
+
+func TestNova_HandleQueryQuestionRevisions(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleQueryQuestionRevisions
+	// Test Purpose: Test HandleQueryQuestionRevisions list question revisions
+	// Test Steps:
+	// 1. send CreateQuestion & UpdateQuestion request for single-choice question
+	// 2. send QueryQuestionRevisions request by using GET method
+	// 3. receive QueryQuestionRevisions response with two revisions by using 200 OK Code
+	// 4. send QueryQuestionRevision request of first revision by using GET method
+	// 5. receive QueryQuestionRevision response with original question by using 200 OK Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startRevisionTestService()
+	defer server.Close()
+	question := createRevisionTestSingleChoice(t, server, router)
+	/* query question revisions */
+	url := server.URL + "/nova/v1/question/revision/single-choice/" + question.Id
+	w := serveTestRequest(t, router, http.MethodGet, url, nil, testAuthorToken)
+	// return response
+	var revisions []QuestionRevision
+	unmarshalTestResponse(t, w, &revisions)
+	// validate response
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	if assert.Len(t, revisions, 2) {
+		assert.Equal(t, 1, revisions[0].Revision)
+		assert.Equal(t, 2, revisions[1].Revision)
+		assert.NotEqual(t, anonymousAuthor, revisions[1].Author)
+		assert.Equal(t, revisions[0].Author, revisions[1].Author)
+		assert.False(t, revisions[1].CreatedAt.Before(revisions[0].CreatedAt))
+	}
+	/* query first question revision */
+	w = serveTestRequest(t, router, http.MethodGet, url+"/1", nil, testAuthorToken)
+	// return response
+	var revision QuestionRevision
+	unmarshalTestResponse(t, w, &revision)
+	// validate response
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"`+question.Id+`","title":"`+question.Title+`","answers":[{"answerMark":"A","answerText":"80"},{"answerMark":"B","answerText":"443"}],"standard_answer":{"answerMark":"A","answerText":"80"}}`, string(revision.Content))
+	/* query unknown question revision */
+	w = serveTestRequest(t, router, http.MethodGet, url+"/9", nil, testAuthorToken)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestNova_HandleQueryQuestionRevisionDiff(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleQueryQuestionRevisionDiff
+	// Test Purpose: Test HandleQueryQuestionRevisionDiff compare question revisions
+	// Test Steps:
+	// 1. send CreateQuestion & UpdateQuestion request for single-choice question
+	// 2. send QueryQuestionRevisionDiff request of second revision by using GET method
+	// 3. receive QueryQuestionRevisionDiff response with changed title & answer by using 200 OK Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startRevisionTestService()
+	defer server.Close()
+	question := createRevisionTestSingleChoice(t, server, router)
+	/* query question revision diff */
+	w := serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/question/revision/single-choice/"+question.Id+"/2/diff", nil, testAuthorToken)
+	// return response
+	var diff QuestionRevisionDiff
+	unmarshalTestResponse(t, w, &diff)
+	// validate response
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1, diff.Base)
+	assert.Equal(t, 2, diff.Revision)
+	if assert.Len(t, diff.Changes, 2) {
+		assert.Equal(t, "standard_answer", diff.Changes[0].Field)
+		assert.JSONEq(t, `{"answerMark":"A","answerText":"80"}`, string(diff.Changes[0].Before))
+		assert.JSONEq(t, `{"answerMark":"B","answerText":"443"}`, string(diff.Changes[0].After))
+		assert.Equal(t, "title", diff.Changes[1].Field)
+	}
+	/* query first question revision diff */
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/question/revision/single-choice/"+question.Id+"/1/diff", nil, testAuthorToken)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestNova_HandleCreateQuestionRevisionRestore(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateQuestionRevisionRestore
+	// Test Purpose: Test HandleCreateQuestionRevisionRestore restore prior question revision
+	// Test Steps:
+	// 1. send CreateUser & CreateUserLogin request to acquire user session
+	// 2. send CreateQuestion & UpdateQuestion request for single-choice question
+	// 3. send CreateQuestionRevisionRestore request with examinee session, receive 403 Forbidden Code
+	// 4. send CreateQuestionRevisionRestore request of first revision with author session by using POST method
+	// 5. receive CreateQuestionRevisionRestore response with third revision by using 200 OK Code
+	// 6. send QueryQuestion request and receive original question
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startRevisionTestService()
+	defer server.Close()
+	/* create user session */
+	user := User{
+		UserId:      uuid.New().String(),
+		Username:    utils.RandomAlphabet(8),
+		Password:    utils.RandomAlphabetAndNumber(8),
+		PhoneNumber: utils.RandomNumber(11),
+	}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/"+user.UserId, user, "")
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/login/"+user.UserId, UserLogin{UserId: user.UserId, Username: user.Username, Password: user.Password}, "")
+	var session UserSession
+	unmarshalTestResponse(t, w, &session)
+	/* restore question revision */
+	question := createRevisionTestSingleChoice(t, server, router)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/revision/single-choice/"+question.Id+"/1/restore", nil, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/revision/single-choice/"+question.Id+"/1/restore", nil, session.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/revision/single-choice/"+question.Id+"/1/restore", nil, testAuthorToken)
+	// return response
+	var revision QuestionRevision
+	unmarshalTestResponse(t, w, &revision)
+	// validate response
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 3, revision.Revision)
+	assert.NotEqual(t, user.UserId, revision.Author)
+	assert.NotEqual(t, anonymousAuthor, revision.Author)
+	/* query restored question */
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/question/single-choice/"+question.Id, nil, testAuthorToken)
+	var restored QuestionSingleChoice
+	unmarshalTestResponse(t, w, &restored)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, question, restored)
+	/* restore deleted question */
+	w = serveTestRequest(t, router, http.MethodDelete, server.URL+"/nova/v1/question/single-choice/"+question.Id, nil, testAuthorToken)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/revision/single-choice/"+question.Id+"/2/restore", nil, testAuthorToken)
+	unmarshalTestResponse(t, w, &revision)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 4, revision.Revision)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/question/single-choice/"+question.Id, nil, testAuthorToken)
+	unmarshalTestResponse(t, w, &restored)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "443", restored.StandardAnswer.AnswerText)
+}
+
+func TestNova_HandleModifyQuestionEssayRevision(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleModifyQuestion (essay revision)
+	// Test Purpose: Test HandleModifyQuestion store revision of essay question
+	// Test Steps:
+	// 1. send CreateQuestion & ModifyQuestion request for essay question
+	// 2. send QueryQuestionRevisions request by using GET method
+	// 3. receive QueryQuestionRevisions response with original & modified essay question
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startRevisionTestService()
+	defer server.Close()
+	// request create & modify essay question
+	question := QuestionEssay{
+		Id:             uuid.New().String(),
+		Title:          "Explain " + utils.RandomAlphabet(12) + " in your own words.",
+		Answer:         "-",
+		StandardAnswer: "original",
+	}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/essay/"+question.Id, question, testAuthorToken)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	modified := question
+	modified.StandardAnswer = "modified"
+	w = serveTestRequest(t, router, http.MethodPatch, server.URL+"/nova/v1/question/essay/"+question.Id, modified, testAuthorToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+	// request query essay question revisions
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/question/revision/essay/"+question.Id, nil, testAuthorToken)
+	var revisions []QuestionRevision
+	unmarshalTestResponse(t, w, &revisions)
+	// validate response
+	assert.Equal(t, http.StatusOK, w.Code)
+	if assert.Len(t, revisions, 2) {
+		assert.Contains(t, string(revisions[0].Content), "original")
+		assert.Contains(t, string(revisions[1].Content), "modified")
+	}
+}
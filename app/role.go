@@ -0,0 +1,231 @@
+package app
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"nova/logger"
+	"strings"
+)
+
+func (nova *Nova) HandleUpdateUserRole(c *gin.Context) {
+	// update user role
+	var request UserRole
+	logger.Infof("handle request update user role")
+	// extract userId from uri
+	userId := strings.ToLower(c.Param("userId"))
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// check request body correctness
+	logger.Debugf("check user role is validate")
+	if b, err := nova.isUserIdValidate(userId); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check userId is validate: %v", err)
+		return
+	}
+	if b, err := nova.isUserRoleValidate(request.Role); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check user role is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check user role is validate")
+	// update data cache by querying users & roles in database
+	logger.Debugf("update data cache by querying users & roles in database")
+	if err := nova.queryUsersInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying users in database: %v", err)
+		return
+	}
+	if err := nova.queryUserRolesInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying user roles in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying users & roles in database")
+	// check user existence
+	logger.Debugf("check user is existed")
+	if !nova.isUserExisted(userId) {
+		nova.response404NotFound(c, errors.New("user not found"))
+		logger.Errorf("error check user is existed")
+		return
+	}
+	logger.Debugf("successfully check user is existed")
+	// only administrator grants roles, first administrator is granted by grant-admin command
+	logger.Debugf("check principal is administrator")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session required"))
+		logger.Errorf("error check principal is authenticated")
+		return
+	} else if role != RoleAdmin {
+		nova.response403Forbidden(c, errors.New("only administrator grants user role"))
+		logger.Errorf("error check principal is administrator")
+		return
+	}
+	logger.Debugf("successfully check principal is administrator")
+	// store user role in database & data cache
+	logger.Debugf("store user role in database & data cache")
+	response := UserRole{UserId: userId, Role: request.Role}
+	if err := nova.updateUserRole(response); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store user role in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store user role in database & data cache")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleQueryUserRole(c *gin.Context) {
+	// query user role
+	logger.Infof("handle request query user role")
+	// extract userId from uri
+	userId := strings.ToLower(c.Param("userId"))
+	// request userId correctness
+	logger.Debugf("check userId is validate")
+	if b, err := nova.isUserIdValidate(userId); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check userId is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check userId is validate")
+	// update data cache by querying users & roles in database
+	logger.Debugf("update data cache by querying users & roles in database")
+	if err := nova.queryUsersInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying users in database: %v", err)
+		return
+	}
+	if err := nova.queryUserRolesInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying user roles in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying users & roles in database")
+	// check user existence
+	logger.Debugf("check user is existed")
+	if !nova.isUserExisted(userId) {
+		nova.response404NotFound(c, errors.New("user not found"))
+		logger.Errorf("error check user is existed")
+		return
+	}
+	logger.Debugf("successfully check user is existed")
+	// return response
+	response := UserRole{UserId: userId, Role: nova.queryUserRole(userId)}
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+// GrantAdministrator grants administrator role to existing user given by command line arguments,
+// it bootstraps first administrator without any http request
+func (nova *Nova) GrantAdministrator(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("grant-admin", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	userId := flags.String("user", "", "userId of user granted administrator role")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *userId == "" {
+		_, _ = fmt.Fprintln(stderr, "grant-admin: -user is required")
+		flags.Usage()
+		return 2
+	}
+	// user should be created before it is granted
+	*userId = strings.ToLower(*userId)
+	if err := nova.queryUsersInDatabase(); err != nil {
+		_, _ = fmt.Fprintf(stderr, "grant-admin: %v\n", err)
+		return 1
+	}
+	if !nova.isUserExisted(*userId) {
+		_, _ = fmt.Fprintf(stderr, "grant-admin: user %v not found\n", *userId)
+		return 1
+	}
+	role := UserRole{UserId: *userId, Role: RoleAdmin}
+	if err := nova.updateUserRole(role); err != nil {
+		_, _ = fmt.Fprintf(stderr, "grant-admin: %v\n", err)
+		return 1
+	}
+	_, _ = fmt.Fprintf(stdout, "user %v granted %v role\n", role.UserId, role.Role)
+	return 0
+}
+
+func (nova *Nova) isUserRoleValidate(role string) (bool, error) {
+	// check user role is supported
+	switch role {
+	case RoleAdmin, RoleAuthor, RoleReviewer, RoleExaminee:
+		return true, nil
+	default:
+		return false, fmt.Errorf("user role %v not supported", role)
+	}
+}
+
+func (nova *Nova) queryUserRole(userId string) string {
+	// enable user role cache read lock
+	nova.cache.roleCache.mutex.RLock()
+	defer nova.cache.roleCache.mutex.RUnlock()
+	// user without granted role is examinee
+	if v, ok := nova.cache.roleCache.roleSet[userId]; ok {
+		return v
+	}
+	return RoleExaminee
+}
+
+func (nova *Nova) queryPrincipalRole(c *gin.Context) (User, string, bool) {
+	// search authenticated user & its role
+	user, ok := nova.queryPrincipal(c)
+	if !ok {
+		return User{}, "", false
+	}
+	// role requiring two factor is refused until two factor is enabled
+	role := nova.queryUserRole(user.UserId)
+	if nova.isUserTwoFactorRequired(role) && !nova.isUserTwoFactorEnabled(user.UserId) {
+		return user, "", false
+	}
+	return user, role, true
+}
+
+func (nova *Nova) updateUserRole(role UserRole) error {
+	// update user role in database
+	if err := nova.db.UpdateUserRole(&role); err != nil {
+		return err
+	}
+	// enable user role cache write lock
+	nova.cache.roleCache.mutex.Lock()
+	defer nova.cache.roleCache.mutex.Unlock()
+	// update user role in data cache
+	if nova.cache.roleCache.roleSet == nil {
+		nova.cache.roleCache.roleSet = make(map[string]string)
+	}
+	nova.cache.roleCache.roleSet[role.UserId] = role.Role
+	return nil
+}
+
+func (nova *Nova) queryUserRolesInDatabase() error {
+	// enable user role cache write lock
+	nova.cache.roleCache.mutex.Lock()
+	defer nova.cache.roleCache.mutex.Unlock()
+	// query user roles from database
+	roles, err := nova.db.QueryUserRoles()
+	if err != nil {
+		return err
+	}
+	// update user roles in data cache
+	if nova.cache.roleCache.roleSet == nil {
+		nova.cache.roleCache.roleSet = make(map[string]string)
+	}
+	for _, role := range roles {
+		nova.cache.roleCache.roleSet[role.UserId] = role.Role
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	. "nova/configure"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	sandboxWorkersDefault     = 4
+	sandboxWorkersLimit       = 64
+	sandboxTimeLimitDefault   = 2000
+	sandboxMemoryLimitDefault = 256
+	sandboxCompileTimeLimit   = 60 * time.Second
+	sandboxOutputLimit        = 64 << 10
+	sandboxErrorLimit         = 4 << 10
+	sandboxFileLimit          = 64
+	sandboxProcessLimit       = 64
+	// sandboxInitName is name of re-executed service entering sandbox
+	sandboxInitName = "nova-sandbox-init"
+	// sandboxWorkDir is path of submission directory inside private root
+	sandboxWorkDir = "/sandbox"
+	// sandboxCompilerId is identity of compiler building submissions
+	sandboxCompilerId = 65534
+	// sandboxProgramId is identity of programs of first worker, every worker counts down to own identity
+	// so that process limit is not shared by concurrent submissions, nor by compiler threads
+	sandboxProgramId = 65533
+)
+
+// Sandbox runs submitted programs in separate processes without network & with resource limits
+type Sandbox struct {
+	settings SandboxSettings
+	workers  chan int
+}
+
+func NewSandbox(settings SandboxSettings) *Sandbox {
+	if settings.Workers <= 0 {
+		settings.Workers = sandboxWorkersDefault
+	}
+	if settings.Workers > sandboxWorkersLimit {
+		settings.Workers = sandboxWorkersLimit
+	}
+	if settings.TimeLimit <= 0 {
+		settings.TimeLimit = sandboxTimeLimitDefault
+	}
+	if settings.MemoryLimit <= 0 {
+		settings.MemoryLimit = sandboxMemoryLimitDefault
+	}
+	if settings.GoBinary == "" {
+		settings.GoBinary = "go"
+	}
+	if settings.PythonBinary == "" {
+		settings.PythonBinary = "python3"
+	}
+	// free workers are identities of their programs
+	workers := make(chan int, settings.Workers)
+	for k := 0; k < settings.Workers; k++ {
+		workers <- sandboxProgramId - k
+	}
+	return &Sandbox{
+		settings: settings,
+		workers:  workers,
+	}
+}
+
+// sandboxOutput keeps the first bytes of program output & discards the rest
+type sandboxOutput struct {
+	bytes.Buffer
+	limit int
+}
+
+func (o *sandboxOutput) Write(p []byte) (int, error) {
+	if n := o.limit - o.Len(); n > 0 {
+		o.Buffer.Write(p[:min(n, len(p))])
+	}
+	return len(p), nil
+}
+
+// sandboxInit is passed to re-executed service which enters sandbox before it is replaced by program
+type sandboxInit struct {
+	Dir       string   `json:"dir"`
+	Source    string   `json:"source,omitempty"`
+	Root      string   `json:"root,omitempty"`
+	Binds     []string `json:"binds,omitempty"`
+	Id        int      `json:"id"`
+	CPUTime   uint64   `json:"cpuTime"`
+	Memory    uint64   `json:"memory,omitempty"`
+	Processes uint64   `json:"processes,omitempty"`
+	Isolated  bool     `json:"isolated,omitempty"`
+}
+
+// sandboxProcess is outcome of single sandboxed process
+type sandboxProcess struct {
+	stdout   string
+	stderr   string
+	timeout  bool
+	exited   bool
+	duration time.Duration
+}
+
+func (sb *Sandbox) Run(ctx context.Context, question QuestionCode, source string) ([]QuestionTestResult, error) {
+	// wait for free worker, programs of submission run as identity of worker
+	var id int
+	select {
+	case id = <-sb.workers:
+		defer func() { sb.workers <- id }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	// every submission is built & run in its own directory
+	dir, err := os.MkdirTemp("", "nova-sandbox-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	// directory is written by compiler & bound read-only into private root of program
+	if err := os.Chmod(dir, 0755); err != nil {
+		return nil, err
+	}
+	root, err := os.MkdirTemp("", "nova-sandbox-root-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(root)
+	command, message, err := sb.prepare(ctx, dir, question.Language, source)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]QuestionTestResult, 0, len(question.TestCases))
+	for _, test := range question.TestCases {
+		result := QuestionTestResult{Name: test.Name}
+		// submission which fails to compile fails every test case
+		if command == nil {
+			result.Status = TestStatusCompileError
+			result.Error = truncateSandboxText(message, sandboxErrorLimit)
+			results = append(results, result)
+			continue
+		}
+		timeLimit, memoryLimit := sb.queryLimits(question)
+		process, err := sb.execute(ctx, id, dir, root, command, nil, test.Input, timeLimit, memoryLimit)
+		if err != nil {
+			return nil, err
+		}
+		result.Duration = process.duration.Milliseconds()
+		switch {
+		case process.timeout:
+			result.Status = TestStatusTimeLimitExceeded
+		case !process.exited:
+			result.Status = TestStatusRuntimeError
+		case normalizeSandboxOutput(process.stdout) == normalizeSandboxOutput(test.Output):
+			result.Status = TestStatusPassed
+		default:
+			result.Status = TestStatusWrongAnswer
+		}
+		// output of hidden test case would reveal its expected output
+		if !test.Hidden {
+			result.Output = process.stdout
+			result.Error = truncateSandboxText(process.stderr, sandboxErrorLimit)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (sb *Sandbox) queryLimits(question QuestionCode) (time.Duration, int) {
+	// limits of question override limits of sandbox
+	timeLimit, memoryLimit := sb.settings.TimeLimit, sb.settings.MemoryLimit
+	if question.TimeLimit > 0 {
+		timeLimit = question.TimeLimit
+	}
+	if question.MemoryLimit > 0 {
+		memoryLimit = question.MemoryLimit
+	}
+	return time.Duration(timeLimit) * time.Millisecond, memoryLimit
+}
+
+func (sb *Sandbox) prepare(ctx context.Context, dir string, language string, source string) ([]string, string, error) {
+	// write source & compile it when language is compiled, returns command running program or compiler message
+	switch language {
+	case CodeLanguageGo:
+		if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(source), 0644); err != nil {
+			return nil, "", err
+		}
+		// compiler is trusted & shares build cache, it runs as unprivileged user without network
+		cache := filepath.Join(os.TempDir(), "nova-sandbox-gocache")
+		for _, v := range []string{dir, cache} {
+			if err := os.MkdirAll(v, 0755); err != nil {
+				return nil, "", err
+			}
+			if err := os.Chown(v, sandboxCompilerId, sandboxCompilerId); err != nil {
+				return nil, "", err
+			}
+		}
+		command := []string{sb.settings.GoBinary, "build", "-o", "main", "main.go"}
+		env := []string{"GOCACHE=" + cache, "GOPATH=" + filepath.Join(cache, "gopath"), "GO111MODULE=off", "GOTOOLCHAIN=local", "GOPROXY=off", "GOFLAGS=", "CGO_ENABLED=0"}
+		process, err := sb.execute(ctx, sandboxCompilerId, dir, "", command, env, "", sandboxCompileTimeLimit, 0)
+		if err != nil {
+			return nil, "", err
+		}
+		if process.timeout {
+			return nil, "compilation timed out", nil
+		}
+		if !process.exited {
+			return nil, process.stderr, nil
+		}
+		return []string{filepath.Join(sandboxWorkDir, "main")}, "", nil
+	case CodeLanguagePython:
+		if err := os.WriteFile(filepath.Join(dir, "main.py"), []byte(source), 0644); err != nil {
+			return nil, "", err
+		}
+		return []string{sb.settings.PythonBinary, "-I", "-B", filepath.Join(sandboxWorkDir, "main.py")}, "", nil
+	default:
+		return nil, "", fmt.Errorf("code language %v not supported", language)
+	}
+}
+
+func (sb *Sandbox) execute(ctx context.Context, id int, dir string, root string, command []string, env []string, input string, timeLimit time.Duration, memoryLimit int) (sandboxProcess, error) {
+	// program runs in private root holding its directory, compiler without private root
+	spec := sandboxInit{Dir: dir, Id: id, CPUTime: uint64(math.Ceil(timeLimit.Seconds())) + 1}
+	if root != "" {
+		spec = sandboxInit{Dir: sandboxWorkDir, Source: dir, Root: root, Binds: querySandboxBinds(command), Id: id,
+			CPUTime: spec.CPUTime, Processes: sandboxProcessLimit, Isolated: true}
+	}
+	if memoryLimit > 0 {
+		spec.Memory = uint64(memoryLimit) << 20
+	}
+	// program only inherits search path from service
+	env = append(env, "PATH="+os.Getenv("PATH"), "HOME="+spec.Dir, "TMPDIR="+spec.Dir, "LANG=C.UTF-8")
+	encoded, err := json.Marshal(spec)
+	if err != nil {
+		return sandboxProcess{}, err
+	}
+	attr, err := sandboxProcAttr(root != "")
+	if err != nil {
+		return sandboxProcess{}, err
+	}
+	// wall clock limit kills whole process group
+	runCtx, cancel := context.WithTimeout(ctx, timeLimit)
+	defer cancel()
+	cmd := exec.CommandContext(runCtx, "/proc/self/exe", append([]string{string(encoded)}, command...)...)
+	cmd.Args[0] = sandboxInitName
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.SysProcAttr = attr
+	cmd.Cancel = func() error { return killSandboxProcess(cmd) }
+	cmd.WaitDelay = time.Second
+	stdout := &sandboxOutput{limit: sandboxOutputLimit}
+	stderr := &sandboxOutput{limit: sandboxOutputLimit}
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	start := time.Now()
+	err = cmd.Run()
+	process := sandboxProcess{
+		stdout:   stdout.String(),
+		stderr:   stderr.String(),
+		duration: time.Since(start),
+	}
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		process.exited = true
+	case runCtx.Err() != nil && ctx.Err() == nil:
+		process.timeout = true
+	case errors.As(err, &exitErr):
+		// cpu time limit is reported by SIGXCPU or SIGKILL
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() &&
+			(status.Signal() == syscall.SIGXCPU || status.Signal() == syscall.SIGKILL) {
+			process.timeout = true
+		}
+	default:
+		return sandboxProcess{}, err
+	}
+	return process, nil
+}
+
+func normalizeSandboxOutput(output string) string {
+	// line endings & trailing blanks do not fail test case
+	lines := strings.Split(strings.ReplaceAll(output, "\r\n", "\n"), "\n")
+	for k, line := range lines {
+		lines[k] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+func truncateSandboxText(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	return text[:limit] + "..."
+}
@@ -0,0 +1,208 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"golang.org/x/sys/unix"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// sandboxSystemBinds are read-only paths of private root, missing paths are skipped
+var sandboxSystemBinds = []string{
+	"/bin", "/lib", "/lib32", "/lib64", "/usr", "/etc/ld.so.cache",
+	"/dev/null", "/dev/zero", "/dev/random", "/dev/urandom",
+}
+
+func init() {
+	// service binary is re-executed to enter sandbox before it is replaced by program
+	if len(os.Args) > 1 && os.Args[0] == sandboxInitName {
+		err := runSandboxInit(os.Args[1], os.Args[2:])
+		_, _ = fmt.Fprintf(os.Stderr, "sandbox: %v\n", err)
+		os.Exit(127)
+	}
+}
+
+func sandboxProcAttr(isolated bool) (*syscall.SysProcAttr, error) {
+	// process runs in own process group, network & mount namespace, program also in own pid namespace
+	if os.Geteuid() != 0 {
+		return nil, errors.New("code sandbox should run as root to switch submissions to unprivileged user")
+	}
+	attr := &syscall.SysProcAttr{
+		Setpgid:    true,
+		Pdeathsig:  syscall.SIGKILL,
+		Cloneflags: syscall.CLONE_NEWNET | syscall.CLONE_NEWNS,
+	}
+	if isolated {
+		attr.Cloneflags |= syscall.CLONE_NEWPID
+	}
+	return attr, nil
+}
+
+func killSandboxProcess(cmd *exec.Cmd) error {
+	// kill process group so that children of program do not survive it
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
+
+func runSandboxInit(encoded string, command []string) error {
+	// enter private root, apply limits & drop root privileges, then replace init by program
+	var spec sandboxInit
+	if err := json.Unmarshal([]byte(encoded), &spec); err != nil {
+		return err
+	}
+	if len(command) == 0 {
+		return errors.New("sandbox command is empty")
+	}
+	if spec.Root != "" {
+		if err := enterSandboxRoot(spec); err != nil {
+			return err
+		}
+	}
+	if err := os.Chdir(spec.Dir); err != nil {
+		return err
+	}
+	limits := map[int]uint64{unix.RLIMIT_CPU: spec.CPUTime, unix.RLIMIT_CORE: 0}
+	if spec.Memory > 0 {
+		limits[unix.RLIMIT_DATA] = spec.Memory
+	}
+	if spec.Processes > 0 {
+		limits[unix.RLIMIT_NPROC] = spec.Processes
+	}
+	if spec.Isolated {
+		limits[unix.RLIMIT_FSIZE] = 0
+		limits[unix.RLIMIT_NOFILE] = sandboxFileLimit
+	}
+	for resource, value := range limits {
+		if err := unix.Setrlimit(resource, &unix.Rlimit{Cur: value, Max: value}); err != nil {
+			return fmt.Errorf("set resource limit %v failed: %w", resource, err)
+		}
+	}
+	// identity is dropped for every thread, set-user-id programs are not honored afterwards
+	if err := syscall.Setgroups([]int{}); err != nil {
+		return err
+	}
+	if err := syscall.Setgid(spec.Id); err != nil {
+		return err
+	}
+	if err := syscall.Setuid(spec.Id); err != nil {
+		return err
+	}
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return err
+	}
+	path, err := exec.LookPath(command[0])
+	if err != nil {
+		return err
+	}
+	return syscall.Exec(path, command, os.Environ())
+}
+
+func enterSandboxRoot(spec sandboxInit) error {
+	// mounts of sandbox do not propagate back to service
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("make mounts private failed: %w", err)
+	}
+	// private root only holds read-only binds of toolchain & submission
+	if err := unix.Mount("tmpfs", spec.Root, "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, "size=1m,mode=0755"); err != nil {
+		return fmt.Errorf("mount private root failed: %w", err)
+	}
+	for _, source := range spec.Binds {
+		if err := bindSandboxPath(spec.Root, source, source); err != nil {
+			return err
+		}
+	}
+	if err := bindSandboxPath(spec.Root, spec.Source, spec.Dir); err != nil {
+		return err
+	}
+	// old root is detached so that nothing outside private root stays reachable
+	if err := os.Chdir(spec.Root); err != nil {
+		return err
+	}
+	if err := unix.PivotRoot(".", "."); err != nil {
+		return fmt.Errorf("pivot private root failed: %w", err)
+	}
+	if err := unix.Unmount(".", unix.MNT_DETACH); err != nil {
+		return fmt.Errorf("detach old root failed: %w", err)
+	}
+	if err := os.Chdir("/"); err != nil {
+		return err
+	}
+	if err := unix.Mount("", "/", "", unix.MS_REMOUNT|unix.MS_RDONLY|unix.MS_NOSUID|unix.MS_NODEV, ""); err != nil {
+		return fmt.Errorf("remount private root read-only failed: %w", err)
+	}
+	return nil
+}
+
+func bindSandboxPath(root string, source string, target string) error {
+	// bind source read-only at target inside root, symbolic links are recreated rather than followed
+	info, err := os.Lstat(source)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	target = filepath.Join(root, target)
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		link, err := os.Readlink(source)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(link, target)
+	case info.IsDir():
+		err = os.Mkdir(target, 0755)
+	default:
+		var file *os.File
+		if file, err = os.OpenFile(target, os.O_CREATE|os.O_WRONLY, 0644); err == nil {
+			err = file.Close()
+		}
+	}
+	if err != nil && !errors.Is(err, os.ErrExist) {
+		return err
+	}
+	// device files stay usable, everything else is neither set-user-id nor device
+	flags := uintptr(unix.MS_BIND | unix.MS_REMOUNT | unix.MS_RDONLY | unix.MS_NOSUID)
+	if !strings.HasPrefix(source, "/dev/") {
+		flags |= unix.MS_NODEV
+	}
+	if err := unix.Mount(source, target, "", unix.MS_BIND, ""); err != nil {
+		return fmt.Errorf("bind %v failed: %w", source, err)
+	}
+	if err := unix.Mount("", target, "", flags, ""); err != nil {
+		return fmt.Errorf("remount %v read-only failed: %w", source, err)
+	}
+	return nil
+}
+
+func querySandboxBinds(command []string) []string {
+	// interpreter installed outside system paths is bound together with its prefix
+	binds := append([]string{}, sandboxSystemBinds...)
+	if len(command) == 0 {
+		return binds
+	}
+	path, err := exec.LookPath(command[0])
+	if err != nil {
+		return binds
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	prefix := filepath.Dir(filepath.Dir(path))
+	if prefix == "/" {
+		return binds
+	}
+	for _, v := range binds {
+		if prefix == v || strings.HasPrefix(prefix, v+"/") {
+			return binds
+		}
+	}
+	return append(binds, prefix)
+}
@@ -0,0 +1,22 @@
+//go:build !linux
+
+package app
+
+import (
+	"errors"
+	"os/exec"
+	"syscall"
+)
+
+func sandboxProcAttr(isolated bool) (*syscall.SysProcAttr, error) {
+	// network isolation & private root rely on linux namespaces
+	return nil, errors.New("code sandbox is only supported on linux")
+}
+
+func killSandboxProcess(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
+
+func querySandboxBinds(command []string) []string {
+	return nil
+}
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"strings"
+	"time"
+)
+
+const (
+	userSessionTokenBytes = 32
+	userSessionExpiration = 24 * time.Hour
+	anonymousAuthor       = "anonymous"
+)
+
+func (nova *Nova) createUserSession(userId string) (UserSession, error) {
+	// generate random session token
+	b := make([]byte, userSessionTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return UserSession{}, err
+	}
+	now := time.Now().UTC()
+	session := UserSession{
+		Token:     base64.RawURLEncoding.EncodeToString(b),
+		UserId:    userId,
+		CreatedAt: now,
+		ExpiresAt: now.Add(userSessionExpiration),
+	}
+	// only token hash is stored, token is returned once
+	tokenHash := hashUserSessionToken(session.Token)
+	if err := nova.db.CreateUserSession(tokenHash, &session); err != nil {
+		return UserSession{}, err
+	}
+	// store user session in data cache
+	nova.cache.sessionCache.mutex.Lock()
+	defer nova.cache.sessionCache.mutex.Unlock()
+	if nova.cache.sessionCache.sessionSet == nil {
+		nova.cache.sessionCache.sessionSet = make(map[string]UserSession)
+	}
+	nova.cache.sessionCache.sessionSet[tokenHash] = UserSession{UserId: session.UserId, CreatedAt: session.CreatedAt, ExpiresAt: session.ExpiresAt}
+	return session, nil
+}
+
+func (nova *Nova) queryUserSession(token string) (UserSession, error) {
+	// search user session in data cache
+	tokenHash := hashUserSessionToken(token)
+	nova.cache.sessionCache.mutex.RLock()
+	session, ok := nova.cache.sessionCache.sessionSet[tokenHash]
+	nova.cache.sessionCache.mutex.RUnlock()
+	// query user session from database
+	if !ok {
+		v, err := nova.db.QueryUserSession(tokenHash)
+		if err != nil {
+			return UserSession{}, err
+		}
+		session = *v
+		nova.cache.sessionCache.mutex.Lock()
+		if nova.cache.sessionCache.sessionSet == nil {
+			nova.cache.sessionCache.sessionSet = make(map[string]UserSession)
+		}
+		nova.cache.sessionCache.sessionSet[tokenHash] = session
+		nova.cache.sessionCache.mutex.Unlock()
+	}
+	// check user session expiration
+	if !time.Now().Before(session.ExpiresAt) {
+		_ = nova.deleteUserSession(tokenHash)
+		return UserSession{}, errors.New("user session expired")
+	}
+	return session, nil
+}
+
+func (nova *Nova) deleteUserSession(tokenHash string) error {
+	// delete user session in data cache
+	nova.cache.sessionCache.mutex.Lock()
+	delete(nova.cache.sessionCache.sessionSet, tokenHash)
+	nova.cache.sessionCache.mutex.Unlock()
+	// delete user session in database
+	return nova.db.DeleteUserSession(tokenHash)
+}
+
+func (nova *Nova) deleteUserSessions(userId string) error {
+	// delete every session of user in data cache
+	nova.cache.sessionCache.mutex.Lock()
+	for tokenHash, session := range nova.cache.sessionCache.sessionSet {
+		if session.UserId == userId {
+			delete(nova.cache.sessionCache.sessionSet, tokenHash)
+		}
+	}
+	nova.cache.sessionCache.mutex.Unlock()
+	// delete every session of user in database
+	return nova.db.DeleteUserSessions(userId)
+}
+
+func (nova *Nova) queryPrincipal(c *gin.Context) (User, bool) {
+	// extract bearer token from authorization header
+	authorization := c.GetHeader("Authorization")
+	if !strings.HasPrefix(authorization, "Bearer ") {
+		return User{}, false
+	}
+	token := strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer "))
+	// search user session of token
+	session, err := nova.queryUserSession(token)
+	if err != nil {
+		return User{}, false
+	}
+	// search user of session
+	user, err := nova.queryUserInDataCache(session.UserId)
+	if err != nil {
+		return User{}, false
+	}
+	return user, true
+}
+
+func (nova *Nova) queryRequestAuthor(c *gin.Context) string {
+	// author is authenticated user, otherwise anonymous
+	if user, ok := nova.queryPrincipal(c); ok {
+		return user.UserId
+	}
+	return anonymousAuthor
+}
+
+func hashUserSessionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
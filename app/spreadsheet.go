@@ -0,0 +1,156 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// spreadsheetSheet is named table of rows, first row is header
+type spreadsheetSheet struct {
+	Name string
+	Rows [][]any
+}
+
+// spreadsheetParts are fixed parts of workbook package besides worksheets
+var spreadsheetParts = []struct {
+	name    string
+	content func(sheets []spreadsheetSheet) string
+}{
+	{"[Content_Types].xml", func(sheets []spreadsheetSheet) string {
+		var b bytes.Buffer
+		b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+		for k := range sheets {
+			fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, k+1)
+		}
+		b.WriteString(`</Types>`)
+		return b.String()
+	}},
+	{"_rels/.rels", func([]spreadsheetSheet) string {
+		return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`
+	}},
+	{"xl/workbook.xml", func(sheets []spreadsheetSheet) string {
+		var b bytes.Buffer
+		b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+		for k, sheet := range sheets {
+			b.WriteString(`<sheet name="`)
+			_ = xml.EscapeText(&b, []byte(sheet.Name))
+			fmt.Fprintf(&b, `" sheetId="%d" r:id="rId%d"/>`, k+1, k+1)
+		}
+		b.WriteString(`</sheets></workbook>`)
+		return b.String()
+	}},
+	{"xl/_rels/workbook.xml.rels", func(sheets []spreadsheetSheet) string {
+		var b bytes.Buffer
+		b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+		for k := range sheets {
+			fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, k+1, k+1)
+		}
+		b.WriteString(`</Relationships>`)
+		return b.String()
+	}},
+}
+
+func writeSpreadsheetCSV(w io.Writer, sheet spreadsheetSheet) error {
+	// csv holds single sheet
+	writer := csv.NewWriter(w)
+	for _, row := range sheet.Rows {
+		record := make([]string, len(row))
+		for k, v := range row {
+			record[k] = formatSpreadsheetCell(v)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeSpreadsheetXLSX(w io.Writer, sheets []spreadsheetSheet) error {
+	// xlsx is zip package of spreadsheetml parts, strings are stored inline
+	archive := zip.NewWriter(w)
+	for _, part := range spreadsheetParts {
+		f, err := archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.content(sheets)); err != nil {
+			return err
+		}
+	}
+	for k, sheet := range sheets {
+		f, err := archive.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", k+1))
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(spreadsheetWorksheet(sheet)); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+func spreadsheetWorksheet(sheet spreadsheetSheet) []byte {
+	var b bytes.Buffer
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for r, row := range sheet.Rows {
+		fmt.Fprintf(&b, `<row r="%d">`, r+1)
+		for k, v := range row {
+			reference := spreadsheetColumn(k) + strconv.Itoa(r+1)
+			switch n := v.(type) {
+			case int, float64:
+				fmt.Fprintf(&b, `<c r="%s"><v>%v</v></c>`, reference, n)
+			case bool:
+				fmt.Fprintf(&b, `<c r="%s" t="b"><v>%d</v></c>`, reference, map[bool]int{false: 0, true: 1}[n])
+			default:
+				fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, reference)
+				_ = xml.EscapeText(&b, []byte(formatSpreadsheetCell(v)))
+				b.WriteString(`</t></is></c>`)
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.Bytes()
+}
+
+func spreadsheetColumn(k int) string {
+	// zero based column index to letters A, B, ..., Z, AA, AB, ...
+	column := ""
+	for k++; k > 0; k = (k - 1) / 26 {
+		column = string(rune('A'+(k-1)%26)) + column
+	}
+	return column
+}
+
+func formatSpreadsheetCell(v any) string {
+	switch n := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return n
+	case time.Time:
+		return n.UTC().Format(time.RFC3339)
+	case float64:
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	default:
+		return fmt.Sprint(n)
+	}
+}
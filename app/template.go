@@ -0,0 +1,180 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"net/http"
+	"nova/logger"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	templatePreviewCountDefault = 5
+	templatePreviewCountMax     = 50
+)
+
+// templatePlaceholderPattern matches named variables like {{speed}} in template title
+var templatePlaceholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+func (nova *Nova) HandleQueryQuestionTemplatePreview(c *gin.Context) {
+	// preview generated instances of template question
+	logger.Infof("handle request query template question preview")
+	// extract questionId from uri
+	id := strings.ToLower(c.Param("Id"))
+	// request questionId correctness
+	logger.Debugf("check questionId is validate")
+	if b, _ := nova.isQuestionIdValidate(id); !b {
+		nova.response400BadRequest(c, errors.New("questionId format incorrect"))
+		logger.Errorf("error check questionId is validate")
+		return
+	}
+	logger.Debugf("successfully check questionId is validate")
+	// extract seed & count from query
+	logger.Debugf("check preview seed & count is validate")
+	seed, count, err := queryTemplatePreviewRange(c)
+	if err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check preview seed & count is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check preview seed & count is validate")
+	// generated instances reveal answers, examinees are not allowed to preview
+	logger.Debugf("check principal is allowed to preview")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to preview template question"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role == RoleExaminee {
+		nova.response403Forbidden(c, errors.New("examinee is not allowed to preview template question"))
+		logger.Errorf("error check principal is allowed to preview")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to preview")
+	// update data cache by querying template questions in database
+	logger.Debugf("update data cache by querying template questions in database")
+	if err := nova.queryTemplateQuestionInDatabase(id); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying template questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying template questions in database")
+	// query template question from data cache
+	logger.Debugf("query template question in data cache")
+	question, err := nova.queryTemplateQuestionInDataCache(id)
+	if err != nil {
+		nova.response404NotFound(c, err)
+		logger.Errorf("error query template question in data cache: %v", err)
+		return
+	}
+	logger.Debugf("successfully query template question in data cache")
+	// generate instance of every seed
+	logger.Debugf("generate template question instances")
+	response := make([]QuestionTemplateInstance, 0, count)
+	for k := 0; k < count; k++ {
+		instance, err := newQuestionTemplateInstance(question, seed+int64(k))
+		if err != nil {
+			nova.response500InternalServerError(c, err)
+			logger.Errorf("error generate template question instances: %v", err)
+			return
+		}
+		response = append(response, instance)
+	}
+	logger.Debugf("successfully generate template question instances")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func queryTemplatePreviewRange(c *gin.Context) (int64, int, error) {
+	// preview starts from seed 0 with default count unless specified
+	seed, count := int64(0), templatePreviewCountDefault
+	if v := c.Query("seed"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, 0, errors.New("seed should be integer")
+		}
+		seed = n
+	}
+	if v := c.Query("count"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > templatePreviewCountMax {
+			return 0, 0, fmt.Errorf("count should be integer from 1 to %v", templatePreviewCountMax)
+		}
+		count = n
+	}
+	return seed, count, nil
+}
+
+func newQuestionTemplateInstance(question QuestionTemplate, seed int64) (QuestionTemplateInstance, error) {
+	formula, _, err := parseFormula(question.Formula)
+	if err != nil {
+		return QuestionTemplateInstance{}, fmt.Errorf("formula of template question %v: %v", question.Id, err)
+	}
+	// the same seed always draws the same values, different questions draw differently
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(question.Id))
+	random := rand.New(rand.NewSource(seed ^ int64(hash.Sum64())))
+	values := make(map[string]float64, len(question.Variables))
+	for _, variable := range question.Variables {
+		values[variable.Name] = drawTemplateVariable(variable, random)
+	}
+	answer := formula.eval(values)
+	if math.IsNaN(answer) || math.IsInf(answer, 0) {
+		return QuestionTemplateInstance{}, fmt.Errorf("formula of template question %v is not finite for seed %v", question.Id, seed)
+	}
+	// substitute placeholders by drawn values
+	title := templatePlaceholderPattern.ReplaceAllStringFunc(question.Title, func(placeholder string) string {
+		name := templatePlaceholderPattern.FindStringSubmatch(placeholder)[1]
+		if v, ok := values[name]; ok {
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		return placeholder
+	})
+	return QuestionTemplateInstance{
+		Type:      QuestionTypeTemplate,
+		Id:        question.Id,
+		Seed:      seed,
+		Title:     title,
+		Variables: values,
+		Answer:    roundToDecimals(answer, question.Precision),
+	}, nil
+}
+
+func drawTemplateVariable(variable QuestionVariable, random *rand.Rand) float64 {
+	// value list takes precedence over range
+	if len(variable.Values) > 0 {
+		return variable.Values[random.Intn(len(variable.Values))]
+	}
+	// range is stepped by integers unless step is given
+	step := variable.Step
+	if step <= 0 {
+		step = 1
+	}
+	n := int(math.Floor((variable.Max-variable.Min)/step + 1e-9))
+	v := variable.Min + step*float64(random.Intn(n+1))
+	// remove float noise so that 0.1 + 0.2 is shown as 0.3
+	return roundToDecimals(v, max(decimalPlaces(step), decimalPlaces(variable.Min)))
+}
+
+func decimalPlaces(v float64) int {
+	text := strconv.FormatFloat(v, 'f', -1, 64)
+	if k := strings.IndexByte(text, '.'); k >= 0 {
+		return len(text) - k - 1
+	}
+	return 0
+}
+
+func roundToDecimals(v float64, decimals int) float64 {
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(v, 'f', decimals, 64), 64)
+	if err != nil {
+		return v
+	}
+	return rounded
+}
@@ -0,0 +1,437 @@
+package app
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"nova/logger"
+	"strings"
+	"time"
+)
+
+const (
+	tenantNameMaxLength = 256
+	// tenantScopeAll is scope of administrators outside tenants, they see resources of every tenant
+	tenantScopeAll = "*"
+	// tenantScopeAnonymous is scope of anonymous requests, they only sign up & login
+	tenantScopeAnonymous = "-"
+	// tenantRoutePrefix is path of nova router group
+	tenantRoutePrefix = "/nova/v1"
+)
+
+// errTenantNotFound is returned when tenant is not stored
+var errTenantNotFound = errors.New("tenant not found")
+
+// errTenantExisted is returned when tenant with the same Id is stored
+var errTenantExisted = errors.New("tenant already exists")
+
+// tenantCreationRoutes create resource named by last uri parameter, resource is not claimed by tenant yet
+var tenantCreationRoutes = map[string]bool{
+	"POST /user/:userId":                 true,
+	"POST /question/single-choice/:Id":   true,
+	"POST /question/multiple-choice/:Id": true,
+	"POST /question/judgement/:Id":       true,
+	"POST /question/essay/:Id":           true,
+	"POST /question/fill-blank/:Id":      true,
+	"POST /question/matching/:Id":        true,
+	"POST /question/ordering/:Id":        true,
+	"POST /question/numeric/:Id":         true,
+	"POST /question/template/:Id":        true,
+	"POST /question/code/:Id":            true,
+	"POST /exam/:examId":                 true,
+	"POST /exam/blueprint/:blueprintId":  true,
+	"POST /exam/adaptive/:examId":        true,
+	"POST /group/:groupId":               true,
+}
+
+// tenantExemptRoutes check tenant of their resources on their own
+var tenantExemptRoutes = map[string]bool{
+	"POST /user/login/:userId":              true,
+	"GET /tenant/:tenantId":                 true,
+	"POST /tenant/:tenantId":                true,
+	"PUT /tenant/:tenantId/user/:userId":    true,
+	"DELETE /tenant/:tenantId/user/:userId": true,
+}
+
+func (nova *Nova) HandleCreateTenant(c *gin.Context) {
+	// create organization isolating its users & question bank
+	var request Tenant
+	logger.Infof("handle request create tenant")
+	// extract tenantId from uri
+	tenantId := strings.ToLower(c.Param("tenantId"))
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// only administrator outside tenants manages tenants
+	logger.Debugf("check principal is allowed to manage tenants")
+	if !nova.checkTenantAdministrator(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to manage tenants")
+	// check request body correctness
+	logger.Debugf("check tenant is validate")
+	v := &questionValidator{}
+	v.checkId(request.Id)
+	if !strings.EqualFold(request.Id, tenantId) {
+		v.add("id", "should match tenantId of uri")
+	}
+	v.checkText("name", request.Name, tenantNameMaxLength)
+	if b, err := v.result(); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check tenant is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check tenant is validate")
+	// store created tenant in database
+	logger.Debugf("store tenant in database")
+	response := Tenant{Id: tenantId, Name: strings.TrimSpace(request.Name), CreatedBy: nova.queryRequestAuthor(c), CreatedAt: time.Now().UTC()}
+	if err := nova.db.CreateTenant(&response); err != nil {
+		if errors.Is(err, errTenantExisted) {
+			nova.response409Conflict(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error store tenant in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store tenant in database")
+	// return response
+	nova.response201Created(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response)
+	return
+}
+
+func (nova *Nova) HandleQueryTenants(c *gin.Context) {
+	// query tenants, members of tenant only see their own
+	logger.Infof("handle request query tenants")
+	scope := nova.queryTenantScope(c)
+	// query tenants from database
+	logger.Debugf("query tenants in database")
+	tenants, err := nova.db.QueryTenants()
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query tenants in database: %v", err)
+		return
+	}
+	response := make([]Tenant, 0, len(tenants))
+	for _, v := range tenants {
+		if scope == tenantScopeAll || scope == v.Id {
+			response = append(response, *v)
+		}
+	}
+	logger.Debugf("successfully query tenants in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, len(response))
+	return
+}
+
+func (nova *Nova) HandleQueryTenant(c *gin.Context) {
+	// query tenant
+	logger.Infof("handle request query tenant")
+	// extract tenantId from uri
+	tenantId := strings.ToLower(c.Param("tenantId"))
+	// query tenant from database
+	logger.Debugf("query tenant in database")
+	response, ok := nova.queryTenantInDatabase(c, tenantId)
+	if !ok {
+		return
+	}
+	if scope := nova.queryTenantScope(c); scope != tenantScopeAll && scope != tenantId {
+		nova.response404NotFound(c, errTenantNotFound)
+		logger.Errorf("error check tenant is visible")
+		return
+	}
+	logger.Debugf("successfully query tenant in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleUpdateTenantUser(c *gin.Context) {
+	// move user into tenant
+	logger.Infof("handle request update tenant user")
+	// extract tenantId & userId from uri
+	tenantId := strings.ToLower(c.Param("tenantId"))
+	userId := strings.ToLower(c.Param("userId"))
+	// only administrator outside tenants moves users between tenants
+	logger.Debugf("check principal is allowed to manage tenants")
+	if !nova.checkTenantAdministrator(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to manage tenants")
+	// check tenant & user existence
+	logger.Debugf("check tenant & user are existed")
+	if _, ok := nova.queryTenantInDatabase(c, tenantId); !ok {
+		return
+	}
+	if !nova.checkTenantUser(c, userId) {
+		return
+	}
+	logger.Debugf("successfully check tenant & user are existed")
+	// store tenant of user in database & data cache
+	logger.Debugf("store tenant of user in database & data cache")
+	response := TenantResource{Kind: TenantResourceUser, Id: userId, TenantId: tenantId}
+	if err := nova.updateResourceTenant(response.Kind, response.Id, response.TenantId); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store tenant of user in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store tenant of user in database & data cache")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleDeleteTenantUser(c *gin.Context) {
+	// move user out of tenant
+	logger.Infof("handle request delete tenant user")
+	// extract tenantId & userId from uri
+	tenantId := strings.ToLower(c.Param("tenantId"))
+	userId := strings.ToLower(c.Param("userId"))
+	// only administrator outside tenants moves users between tenants
+	logger.Debugf("check principal is allowed to manage tenants")
+	if !nova.checkTenantAdministrator(c) {
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to manage tenants")
+	// check user is member of tenant
+	logger.Debugf("check user is member of tenant")
+	if _, ok := nova.queryTenantInDatabase(c, tenantId); !ok {
+		return
+	}
+	if tenant, _ := nova.queryResourceTenant(TenantResourceUser, userId); tenant != tenantId {
+		nova.response404NotFound(c, errors.New("user is not member of tenant"))
+		logger.Errorf("error check user is member of tenant")
+		return
+	}
+	logger.Debugf("successfully check user is member of tenant")
+	// delete tenant of user in database & data cache
+	logger.Debugf("delete tenant of user in database & data cache")
+	if err := nova.updateResourceTenant(TenantResourceUser, userId, ""); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error delete tenant of user in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully delete tenant of user in database & data cache")
+	// return response
+	nova.response204NoContent(c, nil)
+	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, userId)
+	return
+}
+
+func (nova *Nova) HandleTenantScope(c *gin.Context) {
+	// resources named by uri belong to tenant of principal, others are not found
+	route := c.Request.Method + " " + strings.TrimPrefix(c.FullPath(), tenantRoutePrefix)
+	if c.FullPath() == "" || tenantExemptRoutes[route] {
+		c.Next()
+		return
+	}
+	scope := nova.queryTenantScope(c)
+	if scope == tenantScopeAll {
+		c.Next()
+		return
+	}
+	resources := queryTenantRouteResources(c)
+	for k, v := range resources {
+		if nova.isResourceInScope(scope, v.Kind, v.Id) {
+			continue
+		}
+		// resource being created is claimed by its handler
+		if _, owned := nova.queryResourceTenant(v.Kind, v.Id); !owned && tenantCreationRoutes[route] && k == len(resources)-1 {
+			continue
+		}
+		nova.response404NotFound(c, errors.New(strings.ReplaceAll(v.Kind, "-", " ")+" not found"))
+		logger.Errorf("error check %v %v is in tenant scope", v.Kind, v.Id)
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+func queryTenantRouteResources(c *gin.Context) []TenantResource {
+	// resource kind follows uri parameter name, question type follows uri
+	route := strings.TrimPrefix(c.FullPath(), tenantRoutePrefix)
+	var resources []TenantResource
+	for _, param := range c.Params {
+		var kind string
+		switch param.Key {
+		case "userId":
+			kind = TenantResourceUser
+		case "Id":
+			kind = strings.ToLower(c.Param("type"))
+			if segments := strings.Split(route, "/"); kind == "" && len(segments) > 2 {
+				kind = segments[2]
+			}
+		case "examId":
+			kind = TenantResourceExam
+			if strings.HasPrefix(route, "/exam/adaptive/") {
+				kind = TenantResourceAdaptiveExam
+			}
+		case "blueprintId":
+			kind = TenantResourceBlueprint
+		case "groupId":
+			kind = TenantResourceGroup
+		default:
+			continue
+		}
+		resources = append(resources, TenantResource{Kind: kind, Id: strings.ToLower(param.Value)})
+	}
+	return resources
+}
+
+func (nova *Nova) queryTenantScope(c *gin.Context) string {
+	// users outside tenants see resources outside tenants, anonymous requests see none of them
+	user, ok := nova.queryPrincipal(c)
+	if !ok {
+		return tenantScopeAnonymous
+	}
+	// user pending two factor keeps scope of its tenant without role
+	tenantId, _ := nova.queryResourceTenant(TenantResourceUser, user.UserId)
+	if _, role, ok := nova.queryPrincipalRole(c); ok && tenantId == "" && role == RoleAdmin {
+		return tenantScopeAll
+	}
+	return tenantId
+}
+
+func (nova *Nova) queryTenantOwner(c *gin.Context) string {
+	// resources created by principal belong to its tenant
+	if scope := nova.queryTenantScope(c); scope != tenantScopeAll && scope != tenantScopeAnonymous {
+		return scope
+	}
+	return ""
+}
+
+func (nova *Nova) isResourceInScope(scope string, kind string, id string) bool {
+	if scope == tenantScopeAll {
+		return true
+	}
+	// anonymous requests reach no resource, signing up & login are let through by their routes
+	if scope == tenantScopeAnonymous {
+		return false
+	}
+	tenantId, _ := nova.queryResourceTenant(kind, id)
+	return tenantId == scope
+}
+
+func (nova *Nova) isResourceVisible(c *gin.Context, kind string, id string) bool {
+	return nova.isResourceInScope(nova.queryTenantScope(c), kind, id)
+}
+
+func (nova *Nova) queryResourceTenant(kind string, id string) (string, bool) {
+	// enable tenant cache read lock
+	nova.cache.tenantCache.mutex.RLock()
+	defer nova.cache.tenantCache.mutex.RUnlock()
+	// resource without tenant belongs to no tenant
+	tenantId, ok := nova.cache.tenantCache.resourceSet[kind+"/"+id]
+	return tenantId, ok
+}
+
+func (nova *Nova) createResourceTenant(c *gin.Context, kind string, id string) error {
+	// created resource belongs to tenant of principal
+	return nova.updateResourceTenant(kind, id, nova.queryTenantOwner(c))
+}
+
+func (nova *Nova) updateResourceTenant(kind string, id string, tenantId string) error {
+	// update tenant of resource in database, resource outside tenants is not stored
+	if tenantId == "" {
+		if _, err := nova.db.DeleteTenantResource(kind, id); err != nil {
+			return err
+		}
+	} else if err := nova.db.UpdateTenantResource(&TenantResource{Kind: kind, Id: id, TenantId: tenantId}); err != nil {
+		return err
+	}
+	// enable tenant cache write lock
+	nova.cache.tenantCache.mutex.Lock()
+	defer nova.cache.tenantCache.mutex.Unlock()
+	// update tenant of resource in data cache
+	if nova.cache.tenantCache.resourceSet == nil {
+		nova.cache.tenantCache.resourceSet = make(map[string]string)
+	}
+	if tenantId == "" {
+		delete(nova.cache.tenantCache.resourceSet, kind+"/"+id)
+	} else {
+		nova.cache.tenantCache.resourceSet[kind+"/"+id] = tenantId
+	}
+	return nil
+}
+
+func (nova *Nova) deleteResourceTenant(kind string, id string) error {
+	return nova.updateResourceTenant(kind, id, "")
+}
+
+func (nova *Nova) queryTenantResourcesInDatabase() error {
+	// enable tenant cache write lock
+	nova.cache.tenantCache.mutex.Lock()
+	defer nova.cache.tenantCache.mutex.Unlock()
+	// query tenant resources from database
+	resources, err := nova.db.QueryTenantResources("", "")
+	if err != nil {
+		return err
+	}
+	// update tenant resources in data cache
+	nova.cache.tenantCache.resourceSet = make(map[string]string, len(resources))
+	for _, v := range resources {
+		nova.cache.tenantCache.resourceSet[v.Kind+"/"+v.Id] = v.TenantId
+	}
+	return nil
+}
+
+func (nova *Nova) queryTenantInDatabase(c *gin.Context, tenantId string) (Tenant, bool) {
+	// request tenantId correctness
+	if err := uuid.Validate(tenantId); err != nil {
+		nova.response400BadRequest(c, errors.New("tenantId format incorrect"))
+		logger.Errorf("error check tenantId is validate: %v", err)
+		return Tenant{}, false
+	}
+	// query tenant from database
+	tenant, err := nova.db.QueryTenant(tenantId)
+	if err != nil {
+		if errors.Is(err, errTenantNotFound) {
+			nova.response404NotFound(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error query tenant in database: %v", err)
+		return Tenant{}, false
+	}
+	return *tenant, true
+}
+
+func (nova *Nova) checkTenantAdministrator(c *gin.Context) bool {
+	// administrators of tenants only manage their own tenant through roles
+	if _, ok := nova.queryPrincipal(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to manage tenants"))
+		logger.Errorf("error check user session is validate")
+		return false
+	}
+	if nova.queryTenantScope(c) != tenantScopeAll {
+		nova.response403Forbidden(c, errors.New("only administrator outside tenants manages tenants"))
+		logger.Errorf("error check principal is administrator outside tenants")
+		return false
+	}
+	return true
+}
+
+func (nova *Nova) checkTenantUser(c *gin.Context, userId string) bool {
+	// user moved between tenants should be stored
+	if err := nova.queryUsersInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying users in database: %v", err)
+		return false
+	}
+	if !nova.isUserExisted(userId) {
+		nova.response404NotFound(c, errors.New("user not found"))
+		logger.Errorf("error check user is existed")
+		return false
+	}
+	return true
+}
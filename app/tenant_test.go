@@ -0,0 +1,510 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"nova/utils"
+	"testing"
+)
+
+func setupTenantTestRouter() *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// first administrator is granted by command line
+	setupTestAdministrator(nova)
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	// resources named by uri are restricted to tenant of principal
+	novaService.Use(nova.HandleTenantScope)
+	{
+		/* user management */
+		novaService.GET("/user/userId", nova.HandleQueryUserId)
+		novaService.POST("/user/:userId", nova.HandleCreateUser)
+		novaService.PUT("/user/:userId", nova.HandleUpdateUser)
+		novaService.DELETE("/user/:userId", nova.HandleDeleteUser)
+		novaService.PATCH("/user/:userId", nova.HandleModifyUser)
+		novaService.GET("/user/:userId", nova.HandleQueryUser)
+		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
+		novaService.PUT("/user/role/:userId", nova.HandleUpdateUserRole)
+		/* question management */
+		novaService.GET("/question/duplicates", nova.HandleQueryQuestionDuplicates)
+		// question workflow related
+		novaService.GET("/question/workflow", nova.HandleQueryQuestionWorkflows)
+		novaService.POST("/question/workflow/:type/:Id/transition", nova.HandleCreateQuestionTransition)
+		novaService.POST("/question/workflow/:type/:Id/approve", nova.HandleCreateQuestionApproval)
+		// question related
+		novaService.POST("/question/single-choice/:Id", nova.HandleCreateQuestionSingleChoice)
+		novaService.PUT("/question/single-choice/:Id", nova.HandleUpdateQuestionSingleChoice)
+		novaService.DELETE("/question/single-choice/:Id", nova.HandleDeleteQuestionSingleChoice)
+		novaService.PATCH("/question/single-choice/:Id", nova.HandleModifyQuestionSingleChoice)
+		novaService.GET("/question/single-choice/:Id", nova.HandleQueryQuestionSingleChoice)
+		novaService.POST("/question/multiple-choice/:Id", nova.HandleCreateQuestionMultipleChoice)
+		novaService.PUT("/question/multiple-choice/:Id", nova.HandleUpdateQuestionMultipleChoice)
+		novaService.DELETE("/question/multiple-choice/:Id", nova.HandleDeleteQuestionMultipleChoice)
+		novaService.PATCH("/question/multiple-choice/:Id", nova.HandleModifyQuestionMultipleChoice)
+		novaService.GET("/question/multiple-choice/:Id", nova.HandleQueryQuestionMultipleChoice)
+		novaService.POST("/question/judgement/:Id", nova.HandleCreateQuestionJudgement)
+		novaService.PUT("/question/judgement/:Id", nova.HandleUpdateQuestionJudgement)
+		novaService.DELETE("/question/judgement/:Id", nova.HandleDeleteQuestionJudgement)
+		novaService.PATCH("/question/judgement/:Id", nova.HandleModifyQuestionJudgement)
+		novaService.GET("/question/judgement/:Id", nova.HandleQueryQuestionJudgement)
+		novaService.POST("/question/essay/:Id", nova.HandleCreateQuestionEssay)
+		novaService.PUT("/question/essay/:Id", nova.HandleUpdateQuestionEssay)
+		novaService.DELETE("/question/essay/:Id", nova.HandleDeleteQuestionEssay)
+		novaService.PATCH("/question/essay/:Id", nova.HandleModifyQuestionEssay)
+		novaService.GET("/question/essay/:Id", nova.HandleQueryQuestionEssay)
+		novaService.POST("/question/fill-blank/:Id", nova.HandleCreateQuestionFillBlank)
+		novaService.PUT("/question/fill-blank/:Id", nova.HandleUpdateQuestionFillBlank)
+		novaService.DELETE("/question/fill-blank/:Id", nova.HandleDeleteQuestionFillBlank)
+		novaService.PATCH("/question/fill-blank/:Id", nova.HandleModifyQuestionFillBlank)
+		novaService.GET("/question/fill-blank/:Id", nova.HandleQueryQuestionFillBlank)
+		novaService.POST("/question/matching/:Id", nova.HandleCreateQuestionMatching)
+		novaService.PUT("/question/matching/:Id", nova.HandleUpdateQuestionMatching)
+		novaService.DELETE("/question/matching/:Id", nova.HandleDeleteQuestionMatching)
+		novaService.PATCH("/question/matching/:Id", nova.HandleModifyQuestionMatching)
+		novaService.GET("/question/matching/:Id", nova.HandleQueryQuestionMatching)
+		novaService.POST("/question/ordering/:Id", nova.HandleCreateQuestionOrdering)
+		novaService.PUT("/question/ordering/:Id", nova.HandleUpdateQuestionOrdering)
+		novaService.DELETE("/question/ordering/:Id", nova.HandleDeleteQuestionOrdering)
+		novaService.PATCH("/question/ordering/:Id", nova.HandleModifyQuestionOrdering)
+		novaService.GET("/question/ordering/:Id", nova.HandleQueryQuestionOrdering)
+		novaService.POST("/question/numeric/:Id", nova.HandleCreateQuestionNumeric)
+		novaService.PUT("/question/numeric/:Id", nova.HandleUpdateQuestionNumeric)
+		novaService.DELETE("/question/numeric/:Id", nova.HandleDeleteQuestionNumeric)
+		novaService.PATCH("/question/numeric/:Id", nova.HandleModifyQuestionNumeric)
+		novaService.GET("/question/numeric/:Id", nova.HandleQueryQuestionNumeric)
+		novaService.POST("/question/template/:Id", nova.HandleCreateQuestionTemplate)
+		novaService.PUT("/question/template/:Id", nova.HandleUpdateQuestionTemplate)
+		novaService.DELETE("/question/template/:Id", nova.HandleDeleteQuestionTemplate)
+		novaService.PATCH("/question/template/:Id", nova.HandleModifyQuestionTemplate)
+		novaService.GET("/question/template/:Id", nova.HandleQueryQuestionTemplate)
+		novaService.POST("/question/code/:Id", nova.HandleCreateQuestionCode)
+		novaService.PUT("/question/code/:Id", nova.HandleUpdateQuestionCode)
+		novaService.DELETE("/question/code/:Id", nova.HandleDeleteQuestionCode)
+		novaService.PATCH("/question/code/:Id", nova.HandleModifyQuestionCode)
+		novaService.GET("/question/code/:Id", nova.HandleQueryQuestionCode)
+		// question attachment related
+		novaService.PUT("/question/attachment/:type/:Id", nova.HandleUpdateQuestionAttachments)
+		// question revision related
+		novaService.GET("/question/revision/:type/:Id", nova.HandleQueryQuestionRevisions)
+		novaService.GET("/question/revision/:type/:Id/:revision", nova.HandleQueryQuestionRevision)
+		novaService.GET("/question/revision/:type/:Id/:revision/diff", nova.HandleQueryQuestionRevisionDiff)
+		novaService.POST("/question/revision/:type/:Id/:revision/restore", nova.HandleCreateQuestionRevisionRestore)
+		/* exam management */
+		novaService.POST("/exam/:examId", nova.HandleCreateExam)
+		novaService.GET("/exam/:examId", nova.HandleQueryExam)
+		/* tenant management */
+		novaService.POST("/tenant/:tenantId", nova.HandleCreateTenant)
+		novaService.GET("/tenant", nova.HandleQueryTenants)
+		novaService.GET("/tenant/:tenantId", nova.HandleQueryTenant)
+		novaService.PUT("/tenant/:tenantId/user/:userId", nova.HandleUpdateTenantUser)
+		novaService.DELETE("/tenant/:tenantId/user/:userId", nova.HandleDeleteTenantUser)
+		/* group management */
+		novaService.POST("/group/:groupId", nova.HandleCreateGroup)
+		novaService.GET("/group", nova.HandleQueryGroups)
+		novaService.GET("/group/:groupId", nova.HandleQueryGroup)
+		novaService.POST("/group/:groupId/member", nova.HandleCreateGroupMembers)
+		/* attachment management */
+		novaService.POST("/attachment", nova.HandleCreateAttachment)
+		novaService.GET("/attachment/:attachmentId", nova.HandleQueryAttachment)
+	}
+	return router
+}
+
+func startTenantTestService() (*httptest.Server, *gin.Engine) {
+	router := setupTenantTestRouter()
+	return httptest.NewServer(router), router
+}
+
+func TestNova_HandleCreateTenant(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateTenant
+	// Test Purpose: Test tenants isolate users, question banks, exams & groups of each other
+	// Test Steps:
+	// 1. send CreateTenant request with tenant administrator session, receive 403 Forbidden Code
+	// 2. send UpdateTenantUser requests, move users into tenants by using 200 OK Code
+	// 3. send question, exam, group & user requests across tenants, receive 404 Not Found Code
+	// 4. send QueryQuestionWorkflows & QueryQuestionDuplicates requests, receive own questions only
+	// 5. send UpdateUserRole request with tenant administrator session, grant role inside tenant
+	// 6. send DeleteTenantUser request, move user out of tenant by using 204 No Content Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startTenantTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	first, firstAdmin := createWorkflowTestUser(t, server, router, RoleAdmin, admin.Token)
+	second, secondAdmin := createWorkflowTestUser(t, server, router, RoleAdmin, admin.Token)
+	examinee, examineeSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	/* only administrator outside tenants manages tenants */
+	tenants := []Tenant{{Id: uuid.New().String(), Name: "Physics " + utils.RandomAlphabet(12)}, {Id: uuid.New().String(), Name: "History " + utils.RandomAlphabet(12)}}
+	for _, tenant := range tenants {
+		w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/tenant/"+tenant.Id, tenant, admin.Token)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/tenant/"+tenants[0].Id, tenants[0], admin.Token)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	for userId, tenantId := range map[string]string{first.UserId: tenants[0].Id, examinee.UserId: tenants[0].Id, second.UserId: tenants[1].Id} {
+		w = serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/tenant/"+tenantId+"/user/"+userId, nil, admin.Token)
+		var resource TenantResource
+		unmarshalTestResponse(t, w, &resource)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, TenantResource{Kind: TenantResourceUser, Id: userId, TenantId: tenantId}, resource)
+	}
+	third := Tenant{Id: uuid.New().String(), Name: "Biology " + utils.RandomAlphabet(12)}
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/tenant/"+third.Id, third, firstAdmin.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/tenant/"+tenants[0].Id+"/user/"+second.UserId, nil, firstAdmin.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/tenant", nil, firstAdmin.Token)
+	var visible []Tenant
+	unmarshalTestResponse(t, w, &visible)
+	assert.Equal(t, http.StatusOK, w.Code)
+	if assert.Len(t, visible, 1) {
+		assert.Equal(t, tenants[0].Id, visible[0].Id)
+		assert.Equal(t, 2, visible[0].Users)
+	}
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/tenant/"+tenants[1].Id, nil, firstAdmin.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	/* question bank of tenant is hidden from other tenants */
+	question := QuestionJudgement{Id: uuid.New().String(), Title: "Light travels faster than sound " + utils.RandomAlphabet(12), StandardAnswer: true}
+	createExamTestQuestion(t, server, router, QuestionTypeJudgement, question.Id, question, firstAdmin.Token, true)
+	other := QuestionJudgement{Id: uuid.New().String(), Title: "Rome was founded in 753 BC " + utils.RandomAlphabet(12), StandardAnswer: true}
+	createExamTestQuestion(t, server, router, QuestionTypeJudgement, other.Id, other, secondAdmin.Token, false)
+	url := server.URL + "/nova/v1/question/judgement/"
+	w = serveTestRequest(t, router, http.MethodGet, url+question.Id, nil, secondAdmin.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, url+question.Id, nil, admin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodDelete, url+question.Id, nil, secondAdmin.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodPut, url+other.Id, QuestionJudgement{Id: question.Id, Title: question.Title, StandardAnswer: false}, secondAdmin.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, url+question.Id, nil, firstAdmin.Token)
+	var stored QuestionJudgement
+	unmarshalTestResponse(t, w, &stored)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, stored.StandardAnswer)
+	/* near-duplicates are only searched inside tenant */
+	duplicate := QuestionJudgement{Id: uuid.New().String(), Title: question.Title, StandardAnswer: true}
+	w = serveTestRequest(t, router, http.MethodPost, url+duplicate.Id, duplicate, secondAdmin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get("Warning"))
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/question/duplicates", nil, secondAdmin.Token)
+	var clusters []QuestionDuplicateCluster
+	unmarshalTestResponse(t, w, &clusters)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, clusters)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/question/workflow", nil, secondAdmin.Token)
+	var workflows []QuestionWorkflow
+	unmarshalTestResponse(t, w, &workflows)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, workflows, 2)
+	for _, workflow := range workflows {
+		assert.NotEqual(t, question.Id, workflow.Id)
+	}
+	/* exams only refer to questions of tenant */
+	exam := Exam{Id: uuid.New().String(), Title: "Optics quiz " + utils.RandomAlphabet(12),
+		Questions: []ExamQuestion{{Type: QuestionTypeJudgement, Id: question.Id}}}
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id, exam, secondAdmin.Token)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id, exam, firstAdmin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/exam/"+exam.Id, nil, examineeSession.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/exam/"+exam.Id, nil, secondAdmin.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	/* groups only hold users of tenant */
+	group := Group{Id: uuid.New().String(), Name: "Optics lab " + utils.RandomAlphabet(12), Kind: GroupKindClass}
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/group/"+group.Id, group, firstAdmin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/group/"+group.Id+"/member", GroupMembers{Members: []string{second.UserId}}, firstAdmin.Token)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/group/"+group.Id+"/member", GroupMembers{Members: []string{examinee.UserId}}, firstAdmin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/group/"+group.Id, nil, secondAdmin.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/group", nil, secondAdmin.Token)
+	var groups []Group
+	unmarshalTestResponse(t, w, &groups)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, groups)
+	/* users & roles are managed inside tenant */
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/user/"+second.UserId, nil, firstAdmin.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/user/userId", second.Username, firstAdmin.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodDelete, server.URL+"/nova/v1/user/"+second.UserId, nil, firstAdmin.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/user/role/"+second.UserId, UserRole{Role: RoleExaminee}, firstAdmin.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/user/role/"+examinee.UserId, UserRole{Role: RoleReviewer}, firstAdmin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	created := User{UserId: uuid.New().String(), Username: utils.RandomAlphabet(8), Password: utils.RandomAlphabetAndNumber(8), PhoneNumber: utils.RandomNumber(11)}
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/"+created.UserId, created, firstAdmin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/user/"+created.UserId, nil, secondAdmin.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/user/"+created.UserId, nil, firstAdmin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	/* user moved out of tenant loses its resources */
+	w = serveTestRequest(t, router, http.MethodDelete, server.URL+"/nova/v1/tenant/"+tenants[0].Id+"/user/"+examinee.UserId, nil, admin.Token)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = serveTestRequest(t, router, http.MethodDelete, server.URL+"/nova/v1/tenant/"+tenants[0].Id+"/user/"+examinee.UserId, nil, admin.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/exam/"+exam.Id, nil, examineeSession.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestNova_HandleTenantScope(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleTenantScope
+	// Test Purpose: Test anonymous requests & attachments are restricted to tenant scope
+	// Test Steps:
+	// 1. send QueryQuestion & QueryExam requests without session, receive 404 Not Found Code
+	// 2. send QueryUser, UpdateUser, ModifyUser & DeleteUser requests, only user itself & administrator of its tenant reach it
+	// 3. send CreateAttachment request of same content in other tenant, stored attachment is not disclosed
+	// 4. send QueryAttachment requests, attachment is found in its tenant & through its questions only
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startTenantTestService()
+	defer server.Close()
+	outside, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	first, firstAdmin := createWorkflowTestUser(t, server, router, RoleAdmin, admin.Token)
+	second, secondAdmin := createWorkflowTestUser(t, server, router, RoleAdmin, admin.Token)
+	examinee, examineeSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	tenants := []Tenant{{Id: uuid.New().String(), Name: "Physics " + utils.RandomAlphabet(12)}, {Id: uuid.New().String(), Name: "History " + utils.RandomAlphabet(12)}}
+	for _, tenant := range tenants {
+		w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/tenant/"+tenant.Id, tenant, admin.Token)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	}
+	for userId, tenantId := range map[string]string{first.UserId: tenants[0].Id, examinee.UserId: tenants[0].Id, second.UserId: tenants[1].Id} {
+		w := serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/tenant/"+tenantId+"/user/"+userId, nil, admin.Token)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+	/* anonymous requests see no question bank or exam */
+	question := QuestionJudgement{Id: uuid.New().String(), Title: "Water boils at 100 degrees " + utils.RandomAlphabet(12), StandardAnswer: true}
+	createExamTestQuestion(t, server, router, QuestionTypeJudgement, question.Id, question, admin.Token, true)
+	w := serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/question/judgement/"+question.Id, nil, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/question/judgement/"+question.Id, nil, admin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	exam := Exam{Id: uuid.New().String(), Title: "Heat quiz " + utils.RandomAlphabet(12),
+		Questions: []ExamQuestion{{Type: QuestionTypeJudgement, Id: question.Id}}}
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id, exam, admin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/exam/"+exam.Id, nil, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	/* only signing up & login are anonymous */
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/user/userId", outside.Username, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/user/userId", first.Username, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	for _, user := range []User{outside, first} {
+		url := server.URL + "/nova/v1/user/" + user.UserId
+		w = serveTestRequest(t, router, http.MethodGet, url, nil, "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		user.Password = "taken-over"
+		w = serveTestRequest(t, router, http.MethodPut, url, user, "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w = serveTestRequest(t, router, http.MethodPatch, url, user, "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w = serveTestRequest(t, router, http.MethodDelete, url, nil, "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	}
+	/* users change themselves, administrators change users of their tenant */
+	url := server.URL + "/nova/v1/user/" + first.UserId
+	takenOver, moved := first, first
+	takenOver.Password, moved.Company = "taken-over", "Physics Lab"
+	w = serveTestRequest(t, router, http.MethodPatch, url, takenOver, examineeSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodDelete, url, nil, examineeSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodPut, url, takenOver, secondAdmin.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodPatch, url, moved, firstAdmin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	moved = examinee
+	moved.Company = "Physics Lab"
+	w = serveTestRequest(t, router, http.MethodPatch, server.URL+"/nova/v1/user/"+examinee.UserId, moved, firstAdmin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/user/"+examinee.UserId, examinee, examineeSession.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/user/"+second.UserId, nil, secondAdmin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	/* uploads of other tenant are not disclosed */
+	content := newAttachmentTestImage(t)
+	w = serveAttachmentTestRequest(t, router, server.URL+"/nova/v1/attachment", "lens.png", content, firstAdmin.Token)
+	var attachment Attachment
+	unmarshalTestResponse(t, w, &attachment)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveAttachmentTestRequest(t, router, server.URL+"/nova/v1/attachment", "copy.png", content, secondAdmin.Token)
+	var copied Attachment
+	unmarshalTestResponse(t, w, &copied)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, attachment.Id, copied.Id)
+	assert.Equal(t, "copy.png", copied.Name)
+	assert.Equal(t, second.UserId, copied.CreatedBy)
+	/* attachment is seen in its tenant & through questions of tenant */
+	url = server.URL + "/nova/v1/attachment/" + attachment.Id
+	for token, code := range map[string]int{firstAdmin.Token: http.StatusOK, admin.Token: http.StatusOK, secondAdmin.Token: http.StatusNotFound, examineeSession.Token: http.StatusNotFound, "": http.StatusNotFound} {
+		w = serveTestRequest(t, router, http.MethodGet, url, nil, token)
+		assert.Equal(t, code, w.Code)
+	}
+	optics := QuestionJudgement{Id: uuid.New().String(), Title: "Lenses refract light " + utils.RandomAlphabet(12), StandardAnswer: true}
+	createExamTestQuestion(t, server, router, QuestionTypeJudgement, optics.Id, optics, firstAdmin.Token, true)
+	w = serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/question/attachment/judgement/"+optics.Id, QuestionAttachments{Attachments: []Attachment{{Id: attachment.Id}}}, firstAdmin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	for token, code := range map[string]int{examineeSession.Token: http.StatusOK, secondAdmin.Token: http.StatusNotFound, "": http.StatusNotFound} {
+		w = serveTestRequest(t, router, http.MethodGet, url, nil, token)
+		assert.Equal(t, code, w.Code)
+	}
+}
+
+func TestNova_HandleTenantQuestionRevisions(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleTenantQuestionRevisions
+	// Test Purpose: Test revisions of deleted question stay in tenant of question
+	// Test Steps:
+	// 1. create, update & delete question in tenant
+	// 2. send QueryQuestionRevisions, diff & restore requests outside tenant, receive 404 Not Found Code
+	// 3. send CreateQuestionRevisionRestore request in tenant, restored question stays in tenant
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startTenantTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	first, firstAdmin := createWorkflowTestUser(t, server, router, RoleAdmin, admin.Token)
+	_, outsideAuthor := createWorkflowTestUser(t, server, router, RoleAuthor, admin.Token)
+	tenant := Tenant{Id: uuid.New().String(), Name: "Physics " + utils.RandomAlphabet(12)}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/tenant/"+tenant.Id, tenant, admin.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	w = serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/tenant/"+tenant.Id+"/user/"+first.UserId, nil, admin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	/* question of tenant is revised & deleted */
+	question := QuestionJudgement{Id: uuid.New().String(), Title: "Light travels faster than sound " + utils.RandomAlphabet(12), StandardAnswer: true}
+	createExamTestQuestion(t, server, router, QuestionTypeJudgement, question.Id, question, firstAdmin.Token, false)
+	question.Title = "Sound travels faster than light " + utils.RandomAlphabet(12)
+	question.StandardAnswer = false
+	url := server.URL + "/nova/v1/question/judgement/" + question.Id
+	w = serveTestRequest(t, router, http.MethodPut, url, question, firstAdmin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodDelete, url, nil, firstAdmin.Token)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	/* revisions are not reached outside tenant */
+	url = server.URL + "/nova/v1/question/revision/judgement/" + question.Id
+	for _, token := range []string{outsideAuthor.Token, ""} {
+		w = serveTestRequest(t, router, http.MethodGet, url, nil, token)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w = serveTestRequest(t, router, http.MethodGet, url+"/1", nil, token)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w = serveTestRequest(t, router, http.MethodGet, url+"/2/diff", nil, token)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w = serveTestRequest(t, router, http.MethodPost, url+"/1/restore", nil, token)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	}
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/judgement/"+question.Id, question, outsideAuthor.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	/* question restored in tenant stays in tenant */
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, firstAdmin.Token)
+	var revisions []QuestionRevision
+	unmarshalTestResponse(t, w, &revisions)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, revisions, 2)
+	w = serveTestRequest(t, router, http.MethodPost, url+"/1/restore", nil, firstAdmin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/question/judgement/"+question.Id, nil, outsideAuthor.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/question/judgement/"+question.Id, nil, firstAdmin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestNova_HandleTenantQuestions(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleTenantQuestions
+	// Test Purpose: Test questions of every type are only reached in tenant of question
+	// Test Steps:
+	// 1. send CreateQuestion request of every question type in tenant
+	// 2. send Create, Query, Update, Modify & Delete requests from other tenant & outside tenants, receive 404 Not Found Code
+	// 3. send Query, Update, Modify & Delete requests in tenant, receive 200 OK & 204 No Content Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startTenantTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	first, firstAdmin := createWorkflowTestUser(t, server, router, RoleAdmin, admin.Token)
+	second, secondAdmin := createWorkflowTestUser(t, server, router, RoleAdmin, admin.Token)
+	_, outsideAuthor := createWorkflowTestUser(t, server, router, RoleAuthor, admin.Token)
+	for _, user := range []User{first, second} {
+		tenant := Tenant{Id: uuid.New().String(), Name: "Tenant " + utils.RandomAlphabet(12)}
+		w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/tenant/"+tenant.Id, tenant, admin.Token)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		w = serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/tenant/"+tenant.Id+"/user/"+user.UserId, nil, admin.Token)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+	ids := make([]string, 10)
+	for k := range ids {
+		ids[k] = uuid.New().String()
+	}
+	answers := []QuestionAnswer{{"A", "UDP"}, {"B", "TCP"}}
+	questions := []struct {
+		questionType string
+		id           string
+		question     any
+	}{
+		{QuestionTypeSingleChoice, ids[0], QuestionSingleChoice{Id: ids[0], Title: "Which protocol is reliable " + utils.RandomAlphabet(12), Answers: answers, StandardAnswer: answers[1]}},
+		{QuestionTypeMultipleChoice, ids[1], QuestionMultipleChoice{Id: ids[1], Title: "Which protocols are transport " + utils.RandomAlphabet(12), Answers: answers, StandardAnswers: answers}},
+		{QuestionTypeJudgement, ids[2], QuestionJudgement{Id: ids[2], Title: "TCP is reliable " + utils.RandomAlphabet(12), StandardAnswer: true}},
+		{QuestionTypeEssay, ids[3], QuestionEssay{Id: ids[3], Title: "Explain congestion control " + utils.RandomAlphabet(12), Answer: "-", StandardAnswer: "slow start"}},
+		{QuestionTypeFillBlank, ids[4], QuestionFillBlank{Id: ids[4], Title: "TCP handshake has {{1}} steps " + utils.RandomAlphabet(12), Blanks: []QuestionBlank{{Answers: []string{"3"}, Match: BlankMatchText}}}},
+		{QuestionTypeMatching, ids[5], QuestionMatching{Id: ids[5], Title: "Match protocols to ports " + utils.RandomAlphabet(12), Pairs: []QuestionPair{{"HTTP", "80"}, {"SSH", "22"}}}},
+		{QuestionTypeOrdering, ids[6], QuestionOrdering{Id: ids[6], Title: "Order handshake steps " + utils.RandomAlphabet(12), Items: []string{"SYN", "SYN-ACK", "ACK"}}},
+		{QuestionTypeNumeric, ids[7], QuestionNumeric{Id: ids[7], Title: "Default port of HTTPS " + utils.RandomAlphabet(12), Value: 443}},
+		{QuestionTypeTemplate, ids[8], QuestionTemplate{Id: ids[8], Title: "Send {{size}} MB in {{seconds}} s " + utils.RandomAlphabet(12), Variables: []QuestionVariable{{Name: "size", Min: 10, Max: 20, Step: 5}, {Name: "seconds", Values: []float64{2, 5}}}, Formula: "size / seconds", Precision: 1}},
+		{QuestionTypeCode, ids[9], QuestionCode{Id: ids[9], Title: "Print port of SSH " + utils.RandomAlphabet(12), Language: CodeLanguagePython, TestCases: []QuestionTestCase{{Name: "port", Output: "22\n"}}}},
+	}
+	for _, v := range questions {
+		url := server.URL + "/nova/v1/question/" + v.questionType + "/" + v.id
+		w := serveTestRequest(t, router, http.MethodPost, url, v.question, firstAdmin.Token)
+		assert.Equal(t, http.StatusCreated, w.Code, v.questionType)
+		/* question is not reached from other tenant or outside tenants */
+		for _, token := range []string{secondAdmin.Token, outsideAuthor.Token, ""} {
+			for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch} {
+				w = serveTestRequest(t, router, method, url, v.question, token)
+				assert.Equal(t, http.StatusNotFound, w.Code, method+" "+v.questionType)
+			}
+			for _, method := range []string{http.MethodGet, http.MethodDelete} {
+				w = serveTestRequest(t, router, method, url, nil, token)
+				assert.Equal(t, http.StatusNotFound, w.Code, method+" "+v.questionType)
+			}
+		}
+		/* question is reached in its tenant & by administrator outside tenants */
+		w = serveTestRequest(t, router, http.MethodGet, url, nil, admin.Token)
+		assert.Equal(t, http.StatusOK, w.Code, v.questionType)
+		w = serveTestRequest(t, router, http.MethodGet, url, nil, firstAdmin.Token)
+		assert.Equal(t, http.StatusOK, w.Code, v.questionType)
+		w = serveTestRequest(t, router, http.MethodPut, url, v.question, firstAdmin.Token)
+		assert.Equal(t, http.StatusOK, w.Code, v.questionType)
+		w = serveTestRequest(t, router, http.MethodPatch, url, v.question, firstAdmin.Token)
+		assert.Equal(t, http.StatusOK, w.Code, v.questionType)
+		w = serveTestRequest(t, router, http.MethodDelete, url, nil, firstAdmin.Token)
+		assert.Equal(t, http.StatusNoContent, w.Code, v.questionType)
+	}
+}
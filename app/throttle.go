@@ -0,0 +1,385 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	. "nova/cache"
+	"nova/logger"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	loginThrottlePrefix     = "nova:login:"
+	loginThrottleSweepSize  = 4096
+	loginFailureWindow      = 15 * time.Minute
+	loginBackoffThreshold   = 3
+	loginBackoffBase        = time.Second
+	loginBackoffMax         = 5 * time.Minute
+	loginLockoutThreshold   = 10
+	loginIPThreshold        = 50
+	loginThrottleAccountKey = "account:"
+	loginThrottleIPKey      = "ip:"
+)
+
+// LoginThrottle counts failed logins of accounts & client ips, counters expire after window of last failure
+type LoginThrottle interface {
+	Fail(ctx context.Context, key string, window time.Duration) (int64, error)
+	Failures(ctx context.Context, key string) (int64, error)
+	Block(ctx context.Context, key string, duration time.Duration) error
+	Blocked(ctx context.Context, key string) (bool, error)
+	Reset(ctx context.Context, key string) error
+}
+
+func NewLoginThrottle(rc *RedisCache) LoginThrottle {
+	// counters are shared by every instance through redis, otherwise kept in memory
+	if rc != nil {
+		return NewRedisLoginThrottle(rc.redisCache)
+	}
+	return NewMemoryLoginThrottle()
+}
+
+// MemoryLoginThrottle keeps counters in memory of single instance
+type MemoryLoginThrottle struct {
+	failureSet map[string]loginThrottleEntry
+	blockSet   map[string]time.Time
+	mutex      sync.Mutex
+}
+
+type loginThrottleEntry struct {
+	failures  int64
+	expiresAt time.Time
+}
+
+func NewMemoryLoginThrottle() *MemoryLoginThrottle {
+	return &MemoryLoginThrottle{failureSet: make(map[string]loginThrottleEntry), blockSet: make(map[string]time.Time)}
+}
+
+func (lt *MemoryLoginThrottle) Fail(ctx context.Context, key string, window time.Duration) (int64, error) {
+	lt.mutex.Lock()
+	defer lt.mutex.Unlock()
+	now := time.Now()
+	// expired counters of many client ips are swept once map grows
+	if len(lt.failureSet) >= loginThrottleSweepSize {
+		lt.sweep(now)
+	}
+	entry := lt.failureSet[key]
+	if !now.Before(entry.expiresAt) {
+		entry.failures = 0
+	}
+	entry.failures++
+	entry.expiresAt = now.Add(window)
+	lt.failureSet[key] = entry
+	return entry.failures, ctx.Err()
+}
+
+func (lt *MemoryLoginThrottle) Failures(ctx context.Context, key string) (int64, error) {
+	lt.mutex.Lock()
+	defer lt.mutex.Unlock()
+	if entry, ok := lt.failureSet[key]; ok && time.Now().Before(entry.expiresAt) {
+		return entry.failures, ctx.Err()
+	}
+	return 0, ctx.Err()
+}
+
+func (lt *MemoryLoginThrottle) Block(ctx context.Context, key string, duration time.Duration) error {
+	lt.mutex.Lock()
+	defer lt.mutex.Unlock()
+	lt.blockSet[key] = time.Now().Add(duration)
+	return ctx.Err()
+}
+
+func (lt *MemoryLoginThrottle) Blocked(ctx context.Context, key string) (bool, error) {
+	lt.mutex.Lock()
+	defer lt.mutex.Unlock()
+	until, ok := lt.blockSet[key]
+	return ok && time.Now().Before(until), ctx.Err()
+}
+
+func (lt *MemoryLoginThrottle) Reset(ctx context.Context, key string) error {
+	lt.mutex.Lock()
+	defer lt.mutex.Unlock()
+	delete(lt.failureSet, key)
+	delete(lt.blockSet, key)
+	return ctx.Err()
+}
+
+func (lt *MemoryLoginThrottle) sweep(now time.Time) {
+	for k, v := range lt.failureSet {
+		if !now.Before(v.expiresAt) {
+			delete(lt.failureSet, k)
+		}
+	}
+	for k, v := range lt.blockSet {
+		if !now.Before(v) {
+			delete(lt.blockSet, k)
+		}
+	}
+}
+
+// RedisLoginThrottle keeps counters in redis, expiration of keys forgets failures
+type RedisLoginThrottle struct {
+	client *RedisClient
+}
+
+func NewRedisLoginThrottle(client *RedisClient) *RedisLoginThrottle {
+	return &RedisLoginThrottle{client: client}
+}
+
+func (lt *RedisLoginThrottle) Fail(ctx context.Context, key string, window time.Duration) (int64, error) {
+	failures, err := lt.client.IncrBy(ctx, loginThrottlePrefix+key, 1)
+	if err != nil {
+		return 0, err
+	}
+	if err := lt.client.Expire(ctx, loginThrottlePrefix+key, window); err != nil {
+		return 0, err
+	}
+	return failures, nil
+}
+
+func (lt *RedisLoginThrottle) Failures(ctx context.Context, key string) (int64, error) {
+	// missing key has no failures
+	if ok, err := lt.client.Exists(ctx, loginThrottlePrefix+key); err != nil || !ok {
+		return 0, err
+	}
+	v, err := lt.client.Get(ctx, loginThrottlePrefix+key)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(v, 10, 64)
+}
+
+func (lt *RedisLoginThrottle) Block(ctx context.Context, key string, duration time.Duration) error {
+	return lt.client.Set(ctx, loginThrottlePrefix+key+":blocked", 1, duration)
+}
+
+func (lt *RedisLoginThrottle) Blocked(ctx context.Context, key string) (bool, error) {
+	return lt.client.Exists(ctx, loginThrottlePrefix+key+":blocked")
+}
+
+func (lt *RedisLoginThrottle) Reset(ctx context.Context, key string) error {
+	_, err := lt.client.Delete(ctx, loginThrottlePrefix+key, loginThrottlePrefix+key+":blocked")
+	return err
+}
+
+// loginPolicy is login throttling of configure, defaults apply to missing values
+type loginPolicy struct {
+	window           time.Duration
+	backoffThreshold int64
+	backoffBase      time.Duration
+	backoffMax       time.Duration
+	lockoutThreshold int64
+	ipThreshold      int64
+}
+
+var (
+	errLoginCredentials   = errors.New("login credentials incorrect")
+	errLoginIPBlocked     = errors.New("too many failed logins from client, retry later")
+	errLoginAccountLocked = errors.New("account locked after too many failed logins")
+	errLoginBackoff       = errors.New("too many failed logins, retry later")
+)
+
+func (nova *Nova) HandleQueryUserLockout(c *gin.Context) {
+	// query failed logins of user
+	logger.Infof("handle request query user lockout")
+	// extract userId from uri
+	userId := strings.ToLower(c.Param("userId"))
+	// only administrators query lockout
+	logger.Debugf("check principal is administrator")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to query lockout"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role != RoleAdmin {
+		nova.response403Forbidden(c, errors.New("only administrator queries lockout"))
+		logger.Errorf("error check principal is administrator")
+		return
+	}
+	logger.Debugf("successfully check principal is administrator")
+	// check user existence
+	logger.Debugf("check user is existed")
+	if err := nova.queryUsersInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying users in database: %v", err)
+		return
+	}
+	if !nova.isUserExisted(userId) || !nova.isResourceVisible(c, TenantResourceUser, userId) {
+		nova.response404NotFound(c, errors.New("user not found"))
+		logger.Errorf("error check user is existed")
+		return
+	}
+	logger.Debugf("successfully check user is existed")
+	// query failed logins of user
+	logger.Debugf("query login throttle of user")
+	ctx, policy := c.Request.Context(), nova.queryLoginPolicy()
+	failures, err := nova.lt.Failures(ctx, loginThrottleAccountKey+userId)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query login throttle of user: %v", err)
+		return
+	}
+	backoff, err := nova.lt.Blocked(ctx, loginThrottleAccountKey+userId)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query login throttle of user: %v", err)
+		return
+	}
+	logger.Debugf("successfully query login throttle of user")
+	// return response
+	response := UserLoginLockout{UserId: userId, Failures: failures, Locked: failures >= policy.lockoutThreshold, Backoff: backoff}
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleDeleteUserLockout(c *gin.Context) {
+	// unlock user by forgetting failed logins
+	logger.Infof("handle request delete user lockout")
+	// extract userId from uri
+	userId := strings.ToLower(c.Param("userId"))
+	// only administrators unlock users
+	logger.Debugf("check principal is administrator")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to unlock user"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role != RoleAdmin {
+		nova.response403Forbidden(c, errors.New("only administrator unlocks user"))
+		logger.Errorf("error check principal is administrator")
+		return
+	}
+	logger.Debugf("successfully check principal is administrator")
+	// check user existence
+	logger.Debugf("check user is existed")
+	if err := nova.queryUsersInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying users in database: %v", err)
+		return
+	}
+	if !nova.isUserExisted(userId) || !nova.isResourceVisible(c, TenantResourceUser, userId) {
+		nova.response404NotFound(c, errors.New("user not found"))
+		logger.Errorf("error check user is existed")
+		return
+	}
+	logger.Debugf("successfully check user is existed")
+	// reset failed logins of user
+	logger.Debugf("reset login throttle of user")
+	if err := nova.lt.Reset(c.Request.Context(), loginThrottleAccountKey+userId); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error reset login throttle of user: %v", err)
+		return
+	}
+	logger.Debugf("successfully reset login throttle of user")
+	// return response
+	nova.response204NoContent(c, nil)
+	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, userId)
+	return
+}
+
+func (nova *Nova) queryLoginPolicy() loginPolicy {
+	settings := nova.conf.Configure.Login
+	policy := loginPolicy{
+		window:           loginFailureWindow,
+		backoffThreshold: loginBackoffThreshold,
+		backoffBase:      loginBackoffBase,
+		backoffMax:       loginBackoffMax,
+		lockoutThreshold: loginLockoutThreshold,
+		ipThreshold:      loginIPThreshold,
+	}
+	if settings.FailureWindow > 0 {
+		policy.window = time.Duration(settings.FailureWindow) * time.Second
+	}
+	if settings.BackoffThreshold > 0 {
+		policy.backoffThreshold = int64(settings.BackoffThreshold)
+	}
+	if settings.BackoffBase > 0 {
+		policy.backoffBase = time.Duration(settings.BackoffBase) * time.Second
+	}
+	if settings.BackoffMax > 0 {
+		policy.backoffMax = time.Duration(settings.BackoffMax) * time.Second
+	}
+	if settings.LockoutThreshold > 0 {
+		policy.lockoutThreshold = int64(settings.LockoutThreshold)
+	}
+	if settings.IPThreshold > 0 {
+		policy.ipThreshold = int64(settings.IPThreshold)
+	}
+	return policy
+}
+
+func (nova *Nova) setupTrustedProxies(router *gin.Engine) error {
+	// forwarding headers only name client ip behind configured proxies, otherwise remote address is client ip
+	return router.SetTrustedProxies(nova.conf.Configure.Login.TrustedProxies)
+}
+
+func (nova *Nova) checkLoginThrottle(ctx context.Context, userId string, ip string) error {
+	// blocked client ip, locked account or backoff refuse login before credentials are checked
+	policy := nova.queryLoginPolicy()
+	failures, err := nova.lt.Failures(ctx, loginThrottleIPKey+ip)
+	if err != nil {
+		return err
+	}
+	if failures >= policy.ipThreshold {
+		return errLoginIPBlocked
+	}
+	failures, err = nova.lt.Failures(ctx, loginThrottleAccountKey+userId)
+	if err != nil {
+		return err
+	}
+	if failures >= policy.lockoutThreshold {
+		return errLoginAccountLocked
+	}
+	if blocked, err := nova.lt.Blocked(ctx, loginThrottleAccountKey+userId); err != nil {
+		return err
+	} else if blocked {
+		return errLoginBackoff
+	}
+	return nil
+}
+
+func (nova *Nova) failLogin(ctx context.Context, userId string, ip string) error {
+	// unknown users are counted as well so lockout does not tell which users exist
+	policy := nova.queryLoginPolicy()
+	if _, err := nova.lt.Fail(ctx, loginThrottleIPKey+ip, policy.window); err != nil {
+		return err
+	}
+	failures, err := nova.lt.Fail(ctx, loginThrottleAccountKey+userId, policy.window)
+	if err != nil {
+		return err
+	}
+	if failures < policy.backoffThreshold {
+		return nil
+	}
+	return nova.lt.Block(ctx, loginThrottleAccountKey+userId, policy.queryBackoff(failures))
+}
+
+func (policy loginPolicy) queryBackoff(failures int64) time.Duration {
+	// backoff doubles for every failure after threshold, clamped before shift so it never overflows
+	shift := failures - policy.backoffThreshold
+	if shift < 0 {
+		shift = 0
+	}
+	if shift >= 62 || policy.backoffBase > policy.backoffMax>>shift {
+		return policy.backoffMax
+	}
+	return policy.backoffBase << shift
+}
+
+func (nova *Nova) resetLogin(ctx context.Context, userId string) error {
+	// successful login forgets failures of account, failures of client ip stay
+	return nova.lt.Reset(ctx, loginThrottleAccountKey+userId)
+}
+
+func (nova *Nova) failUserLogin(c *gin.Context, userId string, ip string, cause error) {
+	// every credential failure is counted & answered alike
+	if err := nova.failLogin(c.Request.Context(), userId, ip); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error count failed login of user: %v", err)
+		return
+	}
+	nova.response401Unauthorized(c, cause)
+}
@@ -0,0 +1,221 @@
+package app
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	. "nova/configure"
+	"testing"
+	"time"
+)
+
+func setupThrottleTestRouter(settings LoginSettings) *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// login throttling under test
+	nova.conf.Configure.Login = settings
+	// first administrator is granted by command line
+	setupTestAdministrator(nova)
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	{
+		/* user management */
+		novaService.POST("/user/:userId", nova.HandleCreateUser)
+		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
+		novaService.PUT("/user/role/:userId", nova.HandleUpdateUserRole)
+		novaService.GET("/user/lockout/:userId", nova.HandleQueryUserLockout)
+		novaService.DELETE("/user/lockout/:userId", nova.HandleDeleteUserLockout)
+	}
+	return router
+}
+
+func startThrottleTestService(settings LoginSettings) (*httptest.Server, *gin.Engine) {
+	router := setupThrottleTestRouter(settings)
+	return httptest.NewServer(router), router
+}
+
+func serveLoginTestRequest(t *testing.T, server *httptest.Server, router *gin.Engine, userId string, username string, password string) (int, ProblemDetails) {
+	// login & decode failure
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/login/"+userId, UserLogin{UserId: userId, Username: username, Password: password}, "")
+	var problem ProblemDetails
+	if w.Code != http.StatusOK {
+		unmarshalTestResponse(t, w, &problem)
+	}
+	return w.Code, problem
+}
+
+func TestMemoryLoginThrottle(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestMemoryLoginThrottle
+	// Test Purpose: Test failed logins & backoff are forgotten after window
+	// Test Steps:
+	// 1. count failed logins of key, receive increasing counter
+	// 2. wait until window passes, receive reset counter & expired backoff
+	-----------------------------------------------------------------------------------------*/
+	ctx, lt := context.Background(), NewMemoryLoginThrottle()
+	n, err := lt.Fail(ctx, "account:first", 50*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	n, _ = lt.Fail(ctx, "account:first", 50*time.Millisecond)
+	assert.Equal(t, int64(2), n)
+	assert.NoError(t, lt.Block(ctx, "account:first", 50*time.Millisecond))
+	blocked, _ := lt.Blocked(ctx, "account:first")
+	assert.True(t, blocked)
+	n, _ = lt.Failures(ctx, "account:second")
+	assert.Equal(t, int64(0), n)
+	time.Sleep(60 * time.Millisecond)
+	n, _ = lt.Failures(ctx, "account:first")
+	assert.Equal(t, int64(0), n)
+	blocked, _ = lt.Blocked(ctx, "account:first")
+	assert.False(t, blocked)
+	n, _ = lt.Fail(ctx, "account:first", time.Minute)
+	assert.Equal(t, int64(1), n)
+	assert.NoError(t, lt.Reset(ctx, "account:first"))
+	n, _ = lt.Failures(ctx, "account:first")
+	assert.Equal(t, int64(0), n)
+}
+
+func TestLoginPolicyBackoff(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestLoginPolicyBackoff
+	// Test Purpose: Test backoff doubles after threshold & never exceeds maximum
+	// Test Steps:
+	// 1. query backoff of failures after threshold, receive doubled backoff
+	// 2. query backoff of failures overflowing shift, receive maximum backoff
+	-----------------------------------------------------------------------------------------*/
+	policy := loginPolicy{backoffThreshold: 5, backoffBase: time.Second, backoffMax: time.Hour}
+	assert.Equal(t, time.Second, policy.queryBackoff(5))
+	assert.Equal(t, 8*time.Second, policy.queryBackoff(8))
+	assert.Equal(t, time.Hour, policy.queryBackoff(17))
+	assert.Equal(t, time.Hour, policy.queryBackoff(5+40))
+	assert.Equal(t, time.Hour, policy.queryBackoff(5+63))
+	assert.Equal(t, time.Hour, policy.queryBackoff(1<<40))
+}
+
+func TestNova_HandleDeleteUserLockout(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleDeleteUserLockout
+	// Test Purpose: Test failed logins answer alike & lock account until administrator unlocks
+	// Test Steps:
+	// 1. send CreateUserLogin request of unknown user, wrong username or password, receive same 401 Unauthorized
+	// 2. send CreateUserLogin request with user credential, receive 429 Too Many Requests Code
+	// 3. send QueryUserLockout & DeleteUserLockout request with administrator session
+	// 4. send CreateUserLogin request with user credential, receive 200 OK Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startThrottleTestService(LoginSettings{LockoutThreshold: 3, BackoffThreshold: 10, IPThreshold: 100})
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	user, examinee := createWorkflowTestUser(t, server, router, RoleExaminee, "")
+	/* failures do not tell what was wrong */
+	code, unknown := serveLoginTestRequest(t, server, router, uuid.New().String(), user.Username, user.Password)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, username := serveLoginTestRequest(t, server, router, user.UserId, user.Username+"x", user.Password)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	assert.Equal(t, unknown, username)
+	code, password := serveLoginTestRequest(t, server, router, user.UserId, user.Username, user.Password+"x")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	assert.Equal(t, unknown, password)
+	/* third failure locks account */
+	code, _ = serveLoginTestRequest(t, server, router, user.UserId, user.Username, user.Password+"y")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, problem := serveLoginTestRequest(t, server, router, user.UserId, user.Username, user.Password)
+	assert.Equal(t, http.StatusTooManyRequests, code)
+	assert.Equal(t, errLoginAccountLocked.Error(), problem.Cause)
+	/* administrator unlocks account */
+	url := server.URL + "/nova/v1/user/lockout/" + user.UserId
+	w := serveTestRequest(t, router, http.MethodGet, url, nil, examinee.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodDelete, url, nil, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, admin.Token)
+	var lockout UserLoginLockout
+	unmarshalTestResponse(t, w, &lockout)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, UserLoginLockout{UserId: user.UserId, Failures: 3, Locked: true}, lockout)
+	w = serveTestRequest(t, router, http.MethodDelete, url, nil, admin.Token)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	code, _ = serveLoginTestRequest(t, server, router, user.UserId, user.Username, user.Password)
+	assert.Equal(t, http.StatusOK, code)
+	w = serveTestRequest(t, router, http.MethodDelete, server.URL+"/nova/v1/user/lockout/"+uuid.New().String(), nil, admin.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestNova_HandleCreateUserLoginBackoff(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateUserLoginBackoff
+	// Test Purpose: Test repeated failures back off account & block client ip
+	// Test Steps:
+	// 1. send CreateUserLogin request with wrong password until backoff threshold
+	// 2. send CreateUserLogin request with user credential, receive 429 Too Many Requests Code
+	// 3. send failed CreateUserLogin request of other user until client ip threshold
+	// 4. send CreateUserLogin request of third user, receive 429 Too Many Requests Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startThrottleTestService(LoginSettings{BackoffThreshold: 2, BackoffBase: 60, LockoutThreshold: 100, IPThreshold: 4})
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	first, _ := createWorkflowTestUser(t, server, router, RoleExaminee, "")
+	second, _ := createWorkflowTestUser(t, server, router, RoleExaminee, "")
+	third, _ := createWorkflowTestUser(t, server, router, RoleExaminee, "")
+	/* backoff refuses even correct password */
+	code, _ := serveLoginTestRequest(t, server, router, first.UserId, first.Username, "wrong")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, _ = serveLoginTestRequest(t, server, router, first.UserId, first.Username, first.Password)
+	assert.Equal(t, http.StatusOK, code)
+	code, _ = serveLoginTestRequest(t, server, router, first.UserId, first.Username, "wrong")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, _ = serveLoginTestRequest(t, server, router, first.UserId, first.Username, "wrong")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, problem := serveLoginTestRequest(t, server, router, first.UserId, first.Username, first.Password)
+	assert.Equal(t, http.StatusTooManyRequests, code)
+	assert.Equal(t, errLoginBackoff.Error(), problem.Cause)
+	w := serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/user/lockout/"+first.UserId, nil, admin.Token)
+	var lockout UserLoginLockout
+	unmarshalTestResponse(t, w, &lockout)
+	assert.Equal(t, UserLoginLockout{UserId: first.UserId, Failures: 2, Backoff: true}, lockout)
+	/* client ip is blocked for every user */
+	code, _ = serveLoginTestRequest(t, server, router, second.UserId, second.Username, "wrong")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, _ = serveLoginTestRequest(t, server, router, third.UserId, third.Username, third.Password)
+	assert.Equal(t, http.StatusTooManyRequests, code)
+	code, problem = serveLoginTestRequest(t, server, router, second.UserId, second.Username, second.Password)
+	assert.Equal(t, http.StatusTooManyRequests, code)
+	assert.Equal(t, errLoginIPBlocked.Error(), problem.Cause)
+}
+
+func TestNova_SetupTrustedProxies(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_SetupTrustedProxies
+	// Test Purpose: Test X-Forwarded-For only names client ip behind trusted proxies
+	-----------------------------------------------------------------------------------------*/
+	for proxies, ip := range map[string]string{"": "198.51.100.9", "198.51.100.0/24": "203.0.113.7"} {
+		nova := New()
+		if proxies != "" {
+			nova.conf.Configure.Login.TrustedProxies = []string{proxies}
+		}
+		router := gin.New()
+		assert.NoError(t, nova.setupTrustedProxies(router))
+		router.GET("/ip", func(c *gin.Context) { c.String(http.StatusOK, c.ClientIP()) })
+		request := httptest.NewRequest(http.MethodGet, "/ip", nil)
+		request.RemoteAddr = "198.51.100.9:40000"
+		request.Header.Set("X-Forwarded-For", "203.0.113.7")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+		assert.Equal(t, ip, w.Body.String())
+	}
+}
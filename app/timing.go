@@ -0,0 +1,511 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"nova/logger"
+	"strings"
+	"time"
+)
+
+const (
+	examTimingDurationMax     = 7 * 24 * 60 * 60
+	examSectionsMax           = 20
+	examSectionTitleMaxLength = 128
+	examLatePenaltyMax        = 100
+	examSweepIntervalDefault  = 30 * time.Second
+)
+
+// errExamAttemptDeadlinePassed is returned when attempt is no longer accepted
+var errExamAttemptDeadlinePassed = errors.New("exam attempt deadline passed")
+
+func (nova *Nova) HandleQueryServerTime(c *gin.Context) {
+	// clients sync countdown timers against server clock
+	logger.Infof("handle request query server time")
+	now := time.Now().UTC()
+	response := ServerTime{Time: now, UnixMilli: now.UnixMilli()}
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleUpdateExamAttemptDraft(c *gin.Context) {
+	// save answers of open attempt before its deadline
+	var request ExamSubmission
+	logger.Infof("handle request update exam attempt draft")
+	// extract attemptId from uri
+	attemptId := strings.ToLower(c.Param("attemptId"))
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	if request.Locale == "" {
+		request.Locale = c.GetHeader("Accept-Language")
+	}
+	// query exam attempt & its exam from database
+	logger.Debugf("query exam attempt in database")
+	attempt, exam, ok := nova.queryExamAttemptInDatabase(c, attemptId)
+	if !ok {
+		return
+	}
+	logger.Debugf("successfully query exam attempt in database")
+	// only examinee of attempt saves its answers
+	logger.Debugf("check principal is examinee of attempt")
+	if attempt.UserId != nova.queryRequestAuthor(c) {
+		nova.response403Forbidden(c, errors.New("only examinee of attempt saves answers"))
+		logger.Errorf("error check principal is examinee of attempt")
+		return
+	}
+	if attempt.State != ExamAttemptStateOpen {
+		nova.response409Conflict(c, errors.New("exam attempt already submitted"))
+		logger.Errorf("error check exam attempt is open")
+		return
+	}
+	logger.Debugf("successfully check principal is examinee of attempt")
+	// drafts are accepted until deadline & grace period only, late window is for submission
+	logger.Debugf("check exam attempt deadline")
+	now := time.Now().UTC()
+	applyExamAttemptTiming(&attempt, exam.Timing)
+	if attempt.Deadline != nil && now.After(attempt.Deadline.Add(examGracePeriod(exam.Timing))) {
+		nova.response409Conflict(c, errExamAttemptDeadlinePassed)
+		logger.Errorf("error check exam attempt deadline: %v", attempt.Deadline)
+		return
+	}
+	logger.Debugf("successfully check exam attempt deadline")
+	// update data cache by querying questions in database
+	logger.Debugf("update data cache by querying questions in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions in database")
+	// answers should belong to attempt & to section still open
+	logger.Debugf("check exam attempt draft is validate")
+	questions, err := nova.queryExamAttemptQuestions(exam, attempt.Seed)
+	if err != nil {
+		nova.response409Conflict(c, err)
+		logger.Errorf("error create exam attempt questions: %v", err)
+		return
+	}
+	sections := queryExamAttemptQuestionSections(questions, attempt.Sections)
+	invalid, closed := &questionValidator{}, &questionValidator{}
+	for k, answer := range request.Answers {
+		key := strings.ToLower(answer.Type) + "/" + strings.ToLower(answer.Id)
+		section, ok := sections[key]
+		switch {
+		case !ok:
+			invalid.add(fmt.Sprintf("answers[%v].id", k), "question %v is not in exam", answer.Id)
+		case isExamAttemptSectionClosed(attempt, exam.Timing, section, now):
+			closed.add(fmt.Sprintf("answers[%v].id", k), "section %v closed at %v", section, attempt.Sections[section].Deadline.Format(time.RFC3339))
+		}
+		request.Answers[k].Type, request.Answers[k].Id = strings.ToLower(answer.Type), strings.ToLower(answer.Id)
+	}
+	if b, err := invalid.result(); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check exam attempt draft is validate: %v", err)
+		return
+	}
+	if b, err := closed.result(); !b {
+		nova.response409Conflict(c, err)
+		logger.Errorf("error check exam attempt draft is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check exam attempt draft is validate")
+	// store saved answers in database
+	logger.Debugf("store exam attempt draft in database")
+	draft := ExamAttemptDraft{AttemptId: attempt.AttemptId, Answers: request.Answers, Locale: request.Locale, SavedAt: &now}
+	if err := nova.db.UpdateExamAttemptDraft(&draft); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store exam attempt draft in database: %v", err)
+		return
+	}
+	response, err := nova.db.QueryExamAttemptDraft(attempt.AttemptId)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query exam attempt draft in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store exam attempt draft in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, len(response.Answers))
+	return
+}
+
+func (nova *Nova) HandleQueryExamAttemptDraft(c *gin.Context) {
+	// query answers saved for attempt
+	logger.Infof("handle request query exam attempt draft")
+	// extract attemptId from uri
+	attemptId := strings.ToLower(c.Param("attemptId"))
+	// query exam attempt & its exam from database
+	logger.Debugf("query exam attempt in database")
+	attempt, _, ok := nova.queryExamAttemptInDatabase(c, attemptId)
+	if !ok {
+		return
+	}
+	logger.Debugf("successfully query exam attempt in database")
+	// query saved answers from database
+	logger.Debugf("query exam attempt draft in database")
+	response, err := nova.db.QueryExamAttemptDraft(attempt.AttemptId)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query exam attempt draft in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query exam attempt draft in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, len(response.Answers))
+	return
+}
+
+func (nova *Nova) HandleCreateExamAttemptSweep(c *gin.Context) {
+	// auto-submit attempts past their deadline without waiting for sweeper
+	logger.Infof("handle request create exam attempt sweep")
+	// only admins sweep attempts
+	logger.Debugf("check principal is allowed to sweep exam attempts")
+	if _, role, ok := nova.queryPrincipalRole(c); !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to sweep exam attempts"))
+		logger.Errorf("error check user session is validate")
+		return
+	} else if role != RoleAdmin {
+		nova.response403Forbidden(c, errors.New("only admin is allowed to sweep exam attempts"))
+		logger.Errorf("error check principal is allowed to sweep exam attempts")
+		return
+	}
+	logger.Debugf("successfully check principal is allowed to sweep exam attempts")
+	// auto-submit expired attempts
+	logger.Debugf("sweep exam attempts")
+	attempts, err := nova.sweepExamAttempts(c.Request.Context(), time.Now().UTC())
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error sweep exam attempts: %v", err)
+		return
+	}
+	logger.Debugf("successfully sweep exam attempts")
+	// return response
+	response := ExamAttemptSweep{Attempts: attempts}
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) startExamAttemptSweeper() {
+	// sweeper runs until server stops
+	ctx, cancel := context.WithCancel(context.Background())
+	nova.sweep, nova.swept = cancel, make(chan struct{})
+	interval := nova.queryExamSweepInterval()
+	go func() {
+		defer close(nova.swept)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				attempts, err := nova.sweepExamAttempts(ctx, time.Now().UTC())
+				if err != nil {
+					logger.Errorf("error sweep exam attempts: %v", err)
+				} else if len(attempts) > 0 {
+					logger.Infof("auto-submit %v exam attempts past deadline", len(attempts))
+				}
+			}
+		}
+	}()
+}
+
+func (nova *Nova) stopExamAttemptSweeper() {
+	// wait for running sweep so that database is closed afterwards
+	if nova.sweep != nil {
+		nova.sweep()
+		<-nova.swept
+	}
+}
+
+func (nova *Nova) sweepExamAttempts(ctx context.Context, now time.Time) ([]string, error) {
+	swept := []string{}
+	// open attempts of timed exams are candidates
+	attempts, err := nova.db.QueryOpenExamAttemptsContext(ctx)
+	if err != nil {
+		return swept, err
+	}
+	if len(attempts) == 0 {
+		return swept, nil
+	}
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		return swept, err
+	}
+	exams := make(map[string]*Exam)
+	for _, attempt := range attempts {
+		exam, ok := exams[attempt.ExamId]
+		if !ok {
+			if exam, err = nova.db.QueryExamContext(ctx, attempt.ExamId); err != nil {
+				return swept, err
+			}
+			exams[attempt.ExamId] = exam
+		}
+		// attempt is closed once deadline, grace period & late window passed
+		if !now.After(queryExamAttemptClosingTime(exam.Timing, attempt.CreatedAt)) {
+			continue
+		}
+		timed := *exam
+		if len(attempt.ExamQuestions) > 0 {
+			timed.Questions = attempt.ExamQuestions
+		}
+		// saved answers are submitted on behalf of examinee, broken attempts are skipped
+		if err := nova.submitExamAttemptDraft(ctx, attempt, timed, now); err != nil {
+			logger.Errorf("error auto-submit exam attempt %v: %v", attempt.AttemptId, err)
+			continue
+		}
+		swept = append(swept, attempt.AttemptId)
+	}
+	return swept, nil
+}
+
+func (nova *Nova) submitExamAttemptDraft(ctx context.Context, attempt *ExamAttempt, exam Exam, now time.Time) error {
+	questions, err := nova.queryExamAttemptQuestions(exam, attempt.Seed)
+	if err != nil {
+		return err
+	}
+	draft, err := nova.db.QueryExamAttemptDraftContext(ctx, attempt.AttemptId)
+	if err != nil {
+		return err
+	}
+	attempt.AutoSubmitted = true
+	updated, err := nova.submitExamAttempt(attempt, exam, questions, ExamSubmission{Answers: draft.Answers, Locale: draft.Locale}, now, false)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return errors.New("exam attempt already submitted")
+	}
+	return nil
+}
+
+func (nova *Nova) mergeExamAttemptDraft(attempt ExamAttempt, exam Exam, questions []ExamAttemptQuestion, submission ExamSubmission, now time.Time) (ExamSubmission, error) {
+	// saved answers fill in questions left out, answers of closed sections cannot replace them
+	draft, err := nova.db.QueryExamAttemptDraft(attempt.AttemptId)
+	if err != nil {
+		return submission, err
+	}
+	saved := make(map[string]ExamAnswer, len(draft.Answers))
+	for _, answer := range draft.Answers {
+		saved[answer.Type+"/"+answer.Id] = answer
+	}
+	sections := queryExamAttemptQuestionSections(questions, attempt.Sections)
+	merged := ExamSubmission{Answers: make([]ExamAnswer, 0, len(submission.Answers)+len(draft.Answers)), Locale: submission.Locale}
+	if merged.Locale == "" {
+		merged.Locale = draft.Locale
+	}
+	// submitted answers keep their positions so that errors point at request
+	for _, answer := range submission.Answers {
+		key := strings.ToLower(answer.Type) + "/" + strings.ToLower(answer.Id)
+		if section, ok := sections[key]; ok && isExamAttemptSectionClosed(attempt, exam.Timing, section, now) {
+			if v, ok := saved[key]; ok {
+				merged.Answers = append(merged.Answers, v)
+				delete(saved, key)
+			}
+			continue
+		}
+		merged.Answers = append(merged.Answers, answer)
+		delete(saved, key)
+	}
+	for _, answer := range draft.Answers {
+		if _, ok := saved[answer.Type+"/"+answer.Id]; ok {
+			merged.Answers = append(merged.Answers, answer)
+		}
+	}
+	return merged, nil
+}
+
+func checkExamTiming(v *questionValidator, timing *ExamTiming, count int) {
+	// exam without timing is not time limited
+	if timing == nil {
+		return
+	}
+	if timing.Duration < 0 || timing.Duration > examTimingDurationMax {
+		v.add("timing.duration", "should be 0 to %v seconds, got %v", examTimingDurationMax, timing.Duration)
+	}
+	if timing.GracePeriod < 0 {
+		v.add("timing.grace_period", "should not be negative")
+	}
+	switch strings.ToLower(strings.TrimSpace(timing.LatePolicy)) {
+	case "", LatePolicyReject:
+	case LatePolicyPenalty:
+		if timing.LatePeriod <= 0 {
+			v.add("timing.late_period", "should be positive for %v late policy", LatePolicyPenalty)
+		}
+	default:
+		v.add("timing.late_policy", "late policy %v not supported", timing.LatePolicy)
+	}
+	if timing.LatePeriod < 0 {
+		v.add("timing.late_period", "should not be negative")
+	}
+	if timing.LatePenalty < 0 || timing.LatePenalty > examLatePenaltyMax {
+		v.add("timing.late_penalty", "should be 0 to %v percent, got %v", examLatePenaltyMax, timing.LatePenalty)
+	}
+	// sections split questions in exam order & fit into exam duration
+	if len(timing.Sections) == 0 {
+		if timing.Duration == 0 {
+			v.add("timing.duration", "should be positive when exam has no sections")
+		}
+		return
+	}
+	if len(timing.Sections) > examSectionsMax {
+		v.add("timing.sections", "should have at most %v sections, got %v", examSectionsMax, len(timing.Sections))
+	}
+	questions, duration := 0, 0
+	for k, section := range timing.Sections {
+		field := fmt.Sprintf("timing.sections[%v]", k)
+		if n := len([]rune(section.Title)); n > examSectionTitleMaxLength {
+			v.add(field+".title", "should be at most %v characters, got %v", examSectionTitleMaxLength, n)
+		}
+		if section.Count <= 0 {
+			v.add(field+".count", "should be positive")
+		}
+		if section.Duration <= 0 {
+			v.add(field+".duration", "should be positive")
+		}
+		questions += section.Count
+		duration += section.Duration
+	}
+	if questions != count {
+		v.add("timing.sections", "should cover %v questions, got %v", count, questions)
+	}
+	if timing.Duration > 0 && duration > timing.Duration {
+		v.add("timing.sections", "should last at most %v seconds of exam, got %v", timing.Duration, duration)
+	}
+}
+
+func normalizeExamTiming(timing *ExamTiming) *ExamTiming {
+	if timing == nil {
+		return nil
+	}
+	normalized := *timing
+	normalized.LatePolicy = strings.ToLower(strings.TrimSpace(timing.LatePolicy))
+	if normalized.LatePolicy == "" {
+		normalized.LatePolicy = LatePolicyReject
+	}
+	// rejecting late submissions leaves no late window
+	if normalized.LatePolicy == LatePolicyReject {
+		normalized.LatePeriod, normalized.LatePenalty = 0, 0
+	}
+	// exam lasts as long as its sections unless duration is given
+	normalized.Sections = make([]ExamSection, 0, len(timing.Sections))
+	duration := 0
+	for _, section := range timing.Sections {
+		section.Title = strings.TrimSpace(section.Title)
+		normalized.Sections = append(normalized.Sections, section)
+		duration += section.Duration
+	}
+	if normalized.Duration == 0 {
+		normalized.Duration = duration
+	}
+	return &normalized
+}
+
+func applyExamAttemptTiming(attempt *ExamAttempt, timing *ExamTiming) {
+	// deadlines follow from start of attempt, clients never supply them
+	if timing == nil {
+		return
+	}
+	deadline := attempt.CreatedAt.Add(time.Duration(timing.Duration) * time.Second)
+	attempt.Deadline = &deadline
+	attempt.Sections = make([]ExamAttemptSection, 0, len(timing.Sections))
+	start := attempt.CreatedAt
+	for _, section := range timing.Sections {
+		end := start.Add(time.Duration(section.Duration) * time.Second)
+		attempt.Sections = append(attempt.Sections, ExamAttemptSection{Title: section.Title, Count: section.Count, StartsAt: start, Deadline: end})
+		start = end
+	}
+}
+
+func queryExamAttemptClosingTime(timing *ExamTiming, createdAt time.Time) time.Time {
+	// attempt accepts submissions until deadline, grace period & late window passed
+	closing := createdAt.Add(time.Duration(timing.Duration)*time.Second + examGracePeriod(timing))
+	if timing.LatePolicy == LatePolicyPenalty {
+		closing = closing.Add(time.Duration(timing.LatePeriod) * time.Second)
+	}
+	return closing
+}
+
+func checkExamAttemptDeadline(timing *ExamTiming, createdAt time.Time, now time.Time) (bool, error) {
+	// submission within deadline & grace period is on time
+	if timing == nil {
+		return false, nil
+	}
+	deadline := createdAt.Add(time.Duration(timing.Duration) * time.Second)
+	if !now.After(deadline.Add(examGracePeriod(timing))) {
+		return false, nil
+	}
+	if !now.After(queryExamAttemptClosingTime(timing, createdAt)) {
+		return true, nil
+	}
+	return false, errExamAttemptDeadlinePassed
+}
+
+func isExamAttemptSectionClosed(attempt ExamAttempt, timing *ExamTiming, section int, now time.Time) bool {
+	if section >= len(attempt.Sections) {
+		return false
+	}
+	return now.After(attempt.Sections[section].Deadline.Add(examGracePeriod(timing)))
+}
+
+func queryExamAttemptQuestionSections(questions []ExamAttemptQuestion, sections []ExamAttemptSection) map[string]int {
+	// questions are shown section by section, questions of untimed exam belong to first section
+	index := make(map[string]int, len(questions))
+	section, end := 0, len(questions)
+	if len(sections) > 0 {
+		end = sections[0].Count
+	}
+	for k, question := range questions {
+		for k >= end && section+1 < len(sections) {
+			section++
+			end += sections[section].Count
+		}
+		index[question.Type+"/"+question.Id] = section
+	}
+	return index
+}
+
+func queryExamQuestionRanges(exam Exam) [][2]int {
+	// questions are shuffled within their sections only
+	if exam.Timing == nil || len(exam.Timing.Sections) == 0 {
+		return [][2]int{{0, len(exam.Questions)}}
+	}
+	ranges := make([][2]int, 0, len(exam.Timing.Sections))
+	start := 0
+	for _, section := range exam.Timing.Sections {
+		end := min(start+section.Count, len(exam.Questions))
+		ranges = append(ranges, [2]int{start, end})
+		start = end
+	}
+	if start < len(exam.Questions) {
+		ranges = append(ranges, [2]int{start, len(exam.Questions)})
+	}
+	return ranges
+}
+
+func examGracePeriod(timing *ExamTiming) time.Duration {
+	if timing == nil {
+		return 0
+	}
+	return time.Duration(timing.GracePeriod) * time.Second
+}
+
+func (nova *Nova) queryExamSweepInterval() time.Duration {
+	if interval := nova.conf.Configure.Exam.SweepInterval; interval > 0 {
+		return time.Duration(interval) * time.Second
+	}
+	return examSweepIntervalDefault
+}
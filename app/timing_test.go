@@ -0,0 +1,206 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"nova/utils"
+	"testing"
+	"time"
+)
+
+func setupTimingTestRouter() *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// first administrator is granted by command line
+	setupTestAdministrator(nova)
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	{
+		novaService.GET("/time", nova.HandleQueryServerTime)
+		/* user management */
+		novaService.POST("/user/:userId", nova.HandleCreateUser)
+		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
+		novaService.PUT("/user/role/:userId", nova.HandleUpdateUserRole)
+		/* exam management */
+		novaService.POST("/exam/:examId", nova.HandleCreateExam)
+		novaService.POST("/exam/:examId/attempt", nova.HandleCreateExamAttempt)
+		novaService.GET("/exam/attempt/:attemptId", nova.HandleQueryExamAttempt)
+		novaService.POST("/exam/attempt/sweep", nova.HandleCreateExamAttemptSweep)
+		novaService.POST("/exam/attempt/:attemptId/submission", nova.HandleCreateExamSubmission)
+		novaService.PUT("/exam/attempt/:attemptId/draft", nova.HandleUpdateExamAttemptDraft)
+		novaService.GET("/exam/attempt/:attemptId/draft", nova.HandleQueryExamAttemptDraft)
+		/* question management */
+		// question workflow related
+		novaService.POST("/question/workflow/:type/:Id/transition", nova.HandleCreateQuestionTransition)
+		novaService.POST("/question/workflow/:type/:Id/approve", nova.HandleCreateQuestionApproval)
+		// question related
+		novaService.POST("/question/judgement/:Id", nova.HandleCreateQuestionJudgement)
+	}
+	return router
+}
+
+func startTimingTestService() (*httptest.Server, *gin.Engine) {
+	router := setupTimingTestRouter()
+	return httptest.NewServer(router), router
+}
+
+func createTimingTestExam(t *testing.T, server *httptest.Server, router *gin.Engine, timing *ExamTiming, token string) Exam {
+	// create timed exam of two published judgement questions
+	exam := Exam{Id: uuid.New().String(), Title: "Timed quiz " + utils.RandomAlphabet(12), Timing: timing}
+	for k := 0; k < 2; k++ {
+		question := QuestionJudgement{Id: uuid.New().String(), Title: "Pings use ICMP " + utils.RandomAlphabet(12), StandardAnswer: true}
+		createExamTestQuestion(t, server, router, QuestionTypeJudgement, question.Id, question, token, true)
+		exam.Questions = append(exam.Questions, ExamQuestion{Type: QuestionTypeJudgement, Id: question.Id})
+	}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id, exam, token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	return exam
+}
+
+func TestNova_HandleQueryServerTime(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleQueryServerTime
+	// Test Purpose: Test clients read server clock to sync countdown timers
+	// Test Steps:
+	// 1. send QueryServerTime request, receive server time by using 200 OK Code
+	-----------------------------------------------------------------------------------------*/
+	// start http test service
+	server, router := startTimingTestService()
+	defer server.Close()
+	before := time.Now()
+	w := serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/time", nil, "")
+	var response ServerTime
+	unmarshalTestResponse(t, w, &response)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.False(t, response.Time.Before(before.Truncate(time.Millisecond)))
+	assert.Equal(t, response.Time.UnixMilli(), response.UnixMilli)
+}
+
+func TestNova_HandleCreateExamTiming(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateExamTiming
+	// Test Purpose: Test timed exams enforce section limits, deadline & auto-submission
+	// Test Steps:
+	// 1. send CreateExam request with sections not covering questions, receive 400 Bad Request Code
+	// 2. send CreateExamAttempt request, receive deadlines of attempt & its sections
+	// 3. send UpdateExamAttemptDraft request after first section closed, receive 409 Conflict Code
+	// 4. send CreateExamSubmission request after deadline, receive 409 Conflict Code
+	// 5. send CreateExamAttemptSweep request, attempt is auto-submitted with saved answers
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startTimingTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	_, examineeSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	/* sections should cover every question */
+	invalid := Exam{Id: uuid.New().String(), Title: "Timed quiz", Questions: []ExamQuestion{{Type: QuestionTypeJudgement, Id: uuid.New().String()}},
+		Timing: &ExamTiming{Sections: []ExamSection{{Count: 3, Duration: 60}}, LatePolicy: "forgive"}}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+invalid.Id, invalid, admin.Token)
+	var problem ProblemDetails
+	unmarshalTestResponse(t, w, &problem)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	fields := []string{}
+	for _, v := range problem.Errors {
+		fields = append(fields, v.Field)
+	}
+	assert.Contains(t, fields, "timing.sections")
+	assert.Contains(t, fields, "timing.late_policy")
+	/* start timed attempt */
+	exam := createTimingTestExam(t, server, router, &ExamTiming{Sections: []ExamSection{{Title: "Part A", Count: 1, Duration: 1}, {Title: "Part B", Count: 1, Duration: 1}}}, admin.Token)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id+"/attempt", nil, examineeSession.Token)
+	var attempt ExamAttempt
+	unmarshalTestResponse(t, w, &attempt)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	if !assert.NotNil(t, attempt.Deadline) || !assert.Len(t, attempt.Sections, 2) {
+		return
+	}
+	assert.Equal(t, attempt.CreatedAt.Add(2*time.Second), *attempt.Deadline)
+	assert.Equal(t, attempt.CreatedAt.Add(time.Second), attempt.Sections[0].Deadline)
+	assert.Equal(t, attempt.Sections[0].Deadline, attempt.Sections[1].StartsAt)
+	first := ExamAnswer{Type: QuestionTypeJudgement, Id: attempt.Questions[0].Id, Answer: []byte(`true`)}
+	second := ExamAnswer{Type: QuestionTypeJudgement, Id: attempt.Questions[1].Id, Answer: []byte(`false`)}
+	url := server.URL + "/nova/v1/exam/attempt/" + attempt.AttemptId
+	/* save answers while sections are open */
+	w = serveTestRequest(t, router, http.MethodPut, url+"/draft", ExamSubmission{Answers: []ExamAnswer{first}}, examineeSession.Token)
+	var draft ExamAttemptDraft
+	unmarshalTestResponse(t, w, &draft)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, draft.Answers, 1)
+	time.Sleep(time.Until(attempt.Sections[0].Deadline.Add(100 * time.Millisecond)))
+	first.Answer = []byte(`false`)
+	w = serveTestRequest(t, router, http.MethodPut, url+"/draft", ExamSubmission{Answers: []ExamAnswer{first}}, examineeSession.Token)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	w = serveTestRequest(t, router, http.MethodPut, url+"/draft", ExamSubmission{Answers: []ExamAnswer{second}}, examineeSession.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	/* submission after deadline is rejected, sweeper submits saved answers */
+	time.Sleep(time.Until(attempt.Deadline.Add(100 * time.Millisecond)))
+	w = serveTestRequest(t, router, http.MethodPost, url+"/submission", ExamSubmission{Answers: []ExamAnswer{first, second}}, examineeSession.Token)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/attempt/sweep", nil, examineeSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/attempt/sweep", nil, admin.Token)
+	var sweep ExamAttemptSweep
+	unmarshalTestResponse(t, w, &sweep)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, sweep.Attempts, attempt.AttemptId)
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, examineeSession.Token)
+	var swept ExamAttempt
+	unmarshalTestResponse(t, w, &swept)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, ExamAttemptStateSubmitted, swept.State)
+	assert.True(t, swept.AutoSubmitted)
+	assert.False(t, swept.Late)
+	assert.Equal(t, 1.0, swept.Score)
+	assert.Equal(t, 2.0, swept.MaxScore)
+}
+
+func TestNova_HandleCreateExamSubmissionLate(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateExamSubmissionLate
+	// Test Purpose: Test late submission within late window is accepted with penalty
+	// Test Steps:
+	// 1. send CreateExam & CreateExamAttempt requests with penalty late policy
+	// 2. send CreateExamSubmission request after deadline, receive penalized score by using 200 OK Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startTimingTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	_, examineeSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	exam := createTimingTestExam(t, server, router, &ExamTiming{Duration: 1, LatePolicy: "Penalty", LatePeriod: 60, LatePenalty: 25}, admin.Token)
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/"+exam.Id+"/attempt", nil, examineeSession.Token)
+	var attempt ExamAttempt
+	unmarshalTestResponse(t, w, &attempt)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	if !assert.NotNil(t, attempt.Deadline) {
+		return
+	}
+	assert.Empty(t, attempt.Sections)
+	submission := ExamSubmission{}
+	for _, question := range attempt.Questions {
+		submission.Answers = append(submission.Answers, ExamAnswer{Type: question.Type, Id: question.Id, Answer: []byte(`true`)})
+	}
+	time.Sleep(time.Until(attempt.Deadline.Add(100 * time.Millisecond)))
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/exam/attempt/"+attempt.AttemptId+"/submission", submission, examineeSession.Token)
+	var graded ExamAttempt
+	unmarshalTestResponse(t, w, &graded)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, graded.Late)
+	assert.Equal(t, 25.0, graded.Penalty)
+	assert.Equal(t, 1.5, graded.Score)
+	assert.Equal(t, 2.0, graded.MaxScore)
+}
@@ -0,0 +1,479 @@
+package app
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"math"
+	"net/http"
+	"net/url"
+	"nova/logger"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	totpSecretBytes        = 20
+	totpPeriod             = 30
+	totpDigits             = 6
+	totpSkew               = 1
+	totpDefaultIssuer      = "Nova"
+	recoveryCodeCount      = 10
+	recoveryCodeGroups     = 4
+	recoveryCodeGroupChars = 4
+)
+
+// errUserTwoFactorNotFound is returned when user never enrolled two-factor authentication
+var errUserTwoFactorNotFound = errors.New("user two factor not found")
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func (nova *Nova) HandleCreateUserTwoFactor(c *gin.Context) {
+	// enroll two-factor authentication of principal, secret is enabled after first code is verified
+	logger.Infof("handle request create user two factor")
+	// principal enrolls own authenticator
+	logger.Debugf("check user session is validate")
+	user, ok := nova.queryPrincipal(c)
+	if !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to enroll two-factor authentication"))
+		logger.Errorf("error check user session is validate")
+		return
+	}
+	logger.Debugf("successfully check user session is validate")
+	// enabled two-factor authentication is disabled before enrolling again
+	logger.Debugf("check two factor is not enabled")
+	if twoFactor, err := nova.db.QueryUserTwoFactor(user.UserId); err == nil && twoFactor.Enabled {
+		nova.response409Conflict(c, errors.New("two-factor authentication already enabled"))
+		logger.Errorf("error check two factor is not enabled")
+		return
+	} else if err != nil && !errors.Is(err, errUserTwoFactorNotFound) {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query two factor in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully check two factor is not enabled")
+	// store new secret in database
+	logger.Debugf("store two factor secret in database")
+	b := make([]byte, totpSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error generate two factor secret: %v", err)
+		return
+	}
+	secret := totpEncoding.EncodeToString(b)
+	if err := nova.db.UpdateUserTwoFactor(&UserTwoFactor{UserId: user.UserId, Secret: secret, CreatedAt: time.Now().UTC()}); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store two factor secret in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store two factor secret in database")
+	// return response
+	response := UserTwoFactorEnrollment{UserId: user.UserId, Secret: secret, ProvisioningURI: nova.queryTOTPProvisioningURI(user.Username, secret)}
+	nova.response201Created(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusCreated, user.UserId)
+	return
+}
+
+func (nova *Nova) HandleCreateUserTwoFactorVerification(c *gin.Context) {
+	// enable enrolled two-factor authentication by first code, recovery codes are returned once
+	var request UserTwoFactorCode
+	logger.Infof("handle request create user two factor verification")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// principal verifies own authenticator
+	logger.Debugf("check user session is validate")
+	user, ok := nova.queryPrincipal(c)
+	if !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to verify two-factor authentication"))
+		logger.Errorf("error check user session is validate")
+		return
+	}
+	logger.Debugf("successfully check user session is validate")
+	// query enrolled secret
+	logger.Debugf("query two factor in database")
+	twoFactor, err := nova.db.QueryUserTwoFactor(user.UserId)
+	if err != nil {
+		if errors.Is(err, errUserTwoFactorNotFound) {
+			nova.response404NotFound(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error query two factor in database: %v", err)
+		return
+	}
+	if twoFactor.Enabled {
+		nova.response409Conflict(c, errors.New("two-factor authentication already enabled"))
+		logger.Errorf("error check two factor is not enabled")
+		return
+	}
+	logger.Debugf("successfully query two factor in database")
+	// check code correctness, recovery codes do not exist yet
+	logger.Debugf("check two factor code correctness")
+	if ok, err := nova.isUserTOTPCodeMatched(twoFactor, request.Code); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error check two factor code correctness: %v", err)
+		return
+	} else if !ok {
+		nova.response417ExpectationFailed(c, errors.New("two-factor code inconsistent with authenticator"))
+		logger.Errorf("error check two factor code correctness")
+		return
+	}
+	logger.Debugf("successfully check two factor code correctness")
+	// enable two factor & store recovery codes in database
+	logger.Debugf("enable two factor in database")
+	now := time.Now().UTC()
+	twoFactor.Enabled, twoFactor.EnabledAt = true, &now
+	if err := nova.db.UpdateUserTwoFactor(twoFactor); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error enable two factor in database: %v", err)
+		return
+	}
+	response, err := nova.createUserRecoveryCodes(user.UserId)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store recovery codes in database: %v", err)
+		return
+	}
+	nova.updateUserTwoFactorEnabled(user.UserId, true)
+	logger.Debugf("successfully enable two factor in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, user.UserId)
+	return
+}
+
+func (nova *Nova) HandleCreateUserRecoveryCodes(c *gin.Context) {
+	// replace recovery codes of principal, current code is required
+	var request UserTwoFactorCode
+	logger.Infof("handle request create user recovery codes")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// principal replaces own recovery codes
+	logger.Debugf("check user session is validate")
+	user, ok := nova.queryPrincipal(c)
+	if !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to replace recovery codes"))
+		logger.Errorf("error check user session is validate")
+		return
+	}
+	logger.Debugf("successfully check user session is validate")
+	// check code correctness
+	logger.Debugf("check two factor code correctness")
+	if !nova.checkUserTwoFactorCode(c, user.UserId, request.Code, false) {
+		return
+	}
+	logger.Debugf("successfully check two factor code correctness")
+	// store recovery codes in database
+	logger.Debugf("store recovery codes in database")
+	response, err := nova.createUserRecoveryCodes(user.UserId)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store recovery codes in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store recovery codes in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, user.UserId)
+	return
+}
+
+func (nova *Nova) HandleDeleteUserTwoFactor(c *gin.Context) {
+	// disable two-factor authentication, administrators reset lost authenticators
+	var request UserTwoFactorCode
+	logger.Infof("handle request delete user two factor")
+	// extract userId from uri
+	userId := strings.ToLower(c.Param("userId"))
+	// principal disables own two factor, administrator disables any
+	logger.Debugf("check user session is validate")
+	user, ok := nova.queryPrincipal(c)
+	if !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to disable two-factor authentication"))
+		logger.Errorf("error check user session is validate")
+		return
+	}
+	// user pending two factor has no role yet
+	_, role, _ := nova.queryPrincipalRole(c)
+	if role != RoleAdmin && user.UserId != userId {
+		nova.response403Forbidden(c, errors.New("only administrators disable two-factor authentication of other users"))
+		logger.Errorf("error check user role is administrator")
+		return
+	}
+	logger.Debugf("successfully check user session is validate")
+	// users of role requiring two factor & without administrator role keep it, own code is required
+	if role != RoleAdmin {
+		logger.Debugf("check two factor is optional")
+		if nova.isUserTwoFactorRequired(nova.queryUserRole(userId)) {
+			nova.response403Forbidden(c, errors.New("two-factor authentication is required for user role"))
+			logger.Errorf("error check two factor is optional")
+			return
+		}
+		logger.Debugf("successfully check two factor is optional")
+		logger.Debugf("request body bind json format")
+		if err := c.ShouldBindJSON(&request); err != nil {
+			nova.response400BadRequest(c, err)
+			logger.Errorf("error bind request to json: %v", err)
+			return
+		}
+		logger.Debugf("successfully bind request json format")
+		logger.Debugf("check two factor code correctness")
+		if !nova.checkUserTwoFactorCode(c, userId, request.Code, true) {
+			return
+		}
+		logger.Debugf("successfully check two factor code correctness")
+	}
+	// delete two factor & recovery codes in database
+	logger.Debugf("delete two factor in database")
+	if err := nova.db.DeleteUserTwoFactor(userId); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error delete two factor in database: %v", err)
+		return
+	}
+	nova.updateUserTwoFactorEnabled(userId, false)
+	logger.Debugf("successfully delete two factor in database")
+	// return response
+	nova.response204NoContent(c, nil)
+	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, userId)
+	return
+}
+
+func (nova *Nova) HandleQueryUserTwoFactor(c *gin.Context) {
+	// query two-factor authentication state, secret is never returned
+	logger.Infof("handle request query user two factor")
+	// extract userId from uri
+	userId := strings.ToLower(c.Param("userId"))
+	// principal queries own two factor, administrator queries any
+	logger.Debugf("check user session is validate")
+	user, ok := nova.queryPrincipal(c)
+	if !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to query two-factor authentication"))
+		logger.Errorf("error check user session is validate")
+		return
+	}
+	// user pending two factor has no role yet
+	_, role, _ := nova.queryPrincipalRole(c)
+	if role != RoleAdmin && user.UserId != userId {
+		nova.response403Forbidden(c, errors.New("only administrators query two-factor authentication of other users"))
+		logger.Errorf("error check user role is administrator")
+		return
+	}
+	logger.Debugf("successfully check user session is validate")
+	// check user existence
+	logger.Debugf("check user is existed")
+	if err := nova.queryUsersInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying users in database: %v", err)
+		return
+	}
+	if !nova.isUserExisted(userId) || !nova.isResourceVisible(c, TenantResourceUser, userId) {
+		nova.response404NotFound(c, errors.New("user not found"))
+		logger.Errorf("error check user is existed")
+		return
+	}
+	logger.Debugf("successfully check user is existed")
+	// query two factor in database, enrollment is not enabled yet
+	logger.Debugf("query two factor in database")
+	twoFactor, err := nova.db.QueryUserTwoFactor(userId)
+	if errors.Is(err, errUserTwoFactorNotFound) {
+		twoFactor, err = &UserTwoFactor{UserId: userId}, nil
+	}
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query two factor in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully query two factor in database")
+	// return response
+	response := UserTwoFactor{UserId: userId, Enabled: twoFactor.Enabled, Required: nova.isUserTwoFactorRequired(nova.queryUserRole(userId))}
+	if twoFactor.Enabled {
+		response.RecoveryCodes, response.CreatedAt, response.EnabledAt = twoFactor.RecoveryCodes, twoFactor.CreatedAt, twoFactor.EnabledAt
+	}
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) checkUserTwoFactorCode(c *gin.Context, userId string, code string, recovery bool) bool {
+	// enabled two factor of user should accept code
+	twoFactor, err := nova.db.QueryUserTwoFactor(userId)
+	if err != nil || !twoFactor.Enabled {
+		if err == nil || errors.Is(err, errUserTwoFactorNotFound) {
+			nova.response404NotFound(c, errUserTwoFactorNotFound)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error query two factor in database: %v", err)
+		return false
+	}
+	ok, err := nova.isUserTOTPCodeMatched(twoFactor, code)
+	if err == nil && !ok && recovery {
+		ok, err = nova.isUserRecoveryCodeMatched(userId, code)
+	}
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error check two factor code correctness: %v", err)
+		return false
+	}
+	if !ok {
+		nova.response417ExpectationFailed(c, errors.New("two-factor code inconsistent with authenticator"))
+		logger.Errorf("error check two factor code correctness")
+		return false
+	}
+	return true
+}
+
+func (nova *Nova) verifyUserLoginTwoFactor(userId string, code string) (bool, error) {
+	// user without enabled two factor logs in by password only
+	twoFactor, err := nova.db.QueryUserTwoFactor(userId)
+	if errors.Is(err, errUserTwoFactorNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !twoFactor.Enabled {
+		return true, nil
+	}
+	// authenticator code, otherwise recovery code
+	if ok, err := nova.isUserTOTPCodeMatched(twoFactor, code); err != nil || ok {
+		return ok, err
+	}
+	return nova.isUserRecoveryCodeMatched(userId, code)
+}
+
+func (nova *Nova) isUserTOTPCodeMatched(twoFactor *UserTwoFactor, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return false, nil
+	}
+	secret, err := totpEncoding.DecodeString(twoFactor.Secret)
+	if err != nil {
+		return false, err
+	}
+	// clock skew of one time step is tolerated, each time step is used once
+	current := time.Now().Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if subtle.ConstantTimeCompare([]byte(generateTOTPCode(secret, step)), []byte(code)) != 1 {
+			continue
+		}
+		return nova.db.UpdateUserTwoFactorStep(twoFactor.UserId, step)
+	}
+	return false, nil
+}
+
+func (nova *Nova) isUserRecoveryCodeMatched(userId string, code string) (bool, error) {
+	// recovery code is deleted once used
+	return nova.db.DeleteUserRecoveryCode(userId, hashUserRecoveryCode(code))
+}
+
+func (nova *Nova) createUserRecoveryCodes(userId string) (UserTwoFactorRecoveryCodes, error) {
+	// only hashes of recovery codes are stored, codes are returned once
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for k := range codes {
+		b := make([]byte, recoveryCodeGroups*recoveryCodeGroupChars)
+		if _, err := rand.Read(b); err != nil {
+			return UserTwoFactorRecoveryCodes{}, err
+		}
+		groups := make([]string, recoveryCodeGroups)
+		for i := range groups {
+			group := make([]byte, recoveryCodeGroupChars)
+			for j := range group {
+				group[j] = "abcdefghijklmnopqrstuvwxyz234567"[b[i*recoveryCodeGroupChars+j]&31]
+			}
+			groups[i] = string(group)
+		}
+		codes[k] = strings.Join(groups, "-")
+		hashes[k] = hashUserRecoveryCode(codes[k])
+	}
+	if err := nova.db.UpdateUserRecoveryCodes(userId, hashes); err != nil {
+		return UserTwoFactorRecoveryCodes{}, err
+	}
+	return UserTwoFactorRecoveryCodes{UserId: userId, RecoveryCodes: codes}, nil
+}
+
+func (nova *Nova) isUserTwoFactorRequired(role string) bool {
+	// policy lists roles required to enable two factor
+	return slices.Contains(nova.conf.Configure.User.TwoFactorRoles, role)
+}
+
+func (nova *Nova) isUserTwoFactorEnabled(userId string) bool {
+	// search two factor state in data cache
+	nova.cache.twoFactorCache.mutex.RLock()
+	enabled, ok := nova.cache.twoFactorCache.enabledSet[userId]
+	nova.cache.twoFactorCache.mutex.RUnlock()
+	if ok {
+		return enabled
+	}
+	// query two factor state from database
+	twoFactor, err := nova.db.QueryUserTwoFactor(userId)
+	if err != nil && !errors.Is(err, errUserTwoFactorNotFound) {
+		return false
+	}
+	enabled = err == nil && twoFactor.Enabled
+	nova.updateUserTwoFactorEnabled(userId, enabled)
+	return enabled
+}
+
+func (nova *Nova) updateUserTwoFactorEnabled(userId string, enabled bool) {
+	// enable two factor cache write lock
+	nova.cache.twoFactorCache.mutex.Lock()
+	defer nova.cache.twoFactorCache.mutex.Unlock()
+	if nova.cache.twoFactorCache.enabledSet == nil {
+		nova.cache.twoFactorCache.enabledSet = make(map[string]bool)
+	}
+	nova.cache.twoFactorCache.enabledSet[userId] = enabled
+}
+
+func (nova *Nova) queryTOTPProvisioningURI(username string, secret string) string {
+	// key uri format understood by authenticator apps, rendered as qr code by clients
+	issuer := nova.conf.Configure.User.TwoFactorIssuer
+	if issuer == "" {
+		issuer = totpDefaultIssuer
+	}
+	values := url.Values{}
+	values.Set("secret", secret)
+	values.Set("issuer", issuer)
+	values.Set("algorithm", "SHA1")
+	values.Set("digits", fmt.Sprint(totpDigits))
+	values.Set("period", fmt.Sprint(totpPeriod))
+	return "otpauth://totp/" + url.PathEscape(issuer) + ":" + url.PathEscape(username) + "?" + values.Encode()
+}
+
+func generateTOTPCode(secret []byte, step int64) string {
+	// rfc 6238 time-based one-time password with hmac-sha1
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+	// rfc 4226 dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%uint32(math.Pow10(totpDigits)))
+}
+
+func hashUserRecoveryCode(code string) string {
+	// recovery codes are compared without case & separators
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return hashUserSessionToken(code)
+}
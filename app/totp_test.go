@@ -0,0 +1,207 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func setupTwoFactorTestRouter(roles []string) *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// roles required to enable two factor
+	nova.conf.Configure.User.TwoFactorRoles = roles
+	// first administrator is granted by command line
+	setupTestAdministrator(nova)
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	{
+		/* user management */
+		novaService.POST("/user/:userId", nova.HandleCreateUser)
+		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
+		novaService.PUT("/user/role/:userId", nova.HandleUpdateUserRole)
+		novaService.POST("/user/totp", nova.HandleCreateUserTwoFactor)
+		novaService.POST("/user/totp/verification", nova.HandleCreateUserTwoFactorVerification)
+		novaService.POST("/user/totp/recovery", nova.HandleCreateUserRecoveryCodes)
+		novaService.DELETE("/user/totp/:userId", nova.HandleDeleteUserTwoFactor)
+		novaService.GET("/user/totp/:userId", nova.HandleQueryUserTwoFactor)
+	}
+	return router
+}
+
+func startTwoFactorTestService(roles []string) (*httptest.Server, *gin.Engine) {
+	router := setupTwoFactorTestRouter(roles)
+	return httptest.NewServer(router), router
+}
+
+func queryTwoFactorTestCode(t *testing.T, secret string, skew int64) string {
+	// code of authenticator at current time step
+	b, err := totpEncoding.DecodeString(secret)
+	assert.NoError(t, err)
+	return generateTOTPCode(b, time.Now().Unix()/totpPeriod+skew)
+}
+
+func enrollTwoFactorTestUser(t *testing.T, server *httptest.Server, router *gin.Engine, user User, token string) (UserTwoFactorEnrollment, UserTwoFactorRecoveryCodes) {
+	// enroll & enable two factor of user
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/totp", nil, token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var enrollment UserTwoFactorEnrollment
+	unmarshalTestResponse(t, w, &enrollment)
+	assert.True(t, strings.HasPrefix(enrollment.ProvisioningURI, "otpauth://totp/Nova:"+user.Username+"?"))
+	assert.Contains(t, enrollment.ProvisioningURI, "secret="+enrollment.Secret)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/totp/verification", UserTwoFactorCode{Code: queryTwoFactorTestCode(t, enrollment.Secret, 0)}, token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var recovery UserTwoFactorRecoveryCodes
+	unmarshalTestResponse(t, w, &recovery)
+	assert.Len(t, recovery.RecoveryCodes, recoveryCodeCount)
+	return enrollment, recovery
+}
+
+func TestGenerateTOTPCode(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestGenerateTOTPCode
+	// Test Purpose: Test generateTOTPCode matches rfc 6238 sha1 test vectors
+	// Test Steps:
+	// 1. generate codes of rfc 6238 test times, receive last six digits of test vectors
+	-----------------------------------------------------------------------------------------*/
+	secret := []byte("12345678901234567890")
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for unix, code := range vectors {
+		assert.Equal(t, code, generateTOTPCode(secret, unix/totpPeriod))
+	}
+}
+
+func TestNova_HandleCreateUserTwoFactor(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateUserTwoFactor
+	// Test Purpose: Test administrators required to enable two factor log in by authenticator
+	// Test Steps:
+	// 1. login administrator without two factor, privileged requests are refused
+	// 2. send CreateUserTwoFactor & CreateUserTwoFactorVerification request, receive recovery codes
+	// 3. send CreateUserLogin request without code or with replayed code, receive same 401 Unauthorized Code as wrong password
+	// 4. send CreateUserLogin request with authenticator or recovery code, receive 200 OK Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startTwoFactorTestService([]string{RoleAdmin})
+	defer server.Close()
+	admin, session := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	assert.True(t, session.TwoFactorRequired)
+	examinee, _ := createWorkflowTestUser(t, server, router, RoleExaminee, "")
+	/* administrator without two factor is refused until it is enabled */
+	w := serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/user/role/"+examinee.UserId, UserRole{Role: RoleAuthor}, session.Token)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/user/totp/"+admin.UserId, nil, session.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/totp/verification", UserTwoFactorCode{Code: "000000"}, session.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/totp", nil, session.Token)
+	var enrollment UserTwoFactorEnrollment
+	unmarshalTestResponse(t, w, &enrollment)
+	code := queryTwoFactorTestCode(t, enrollment.Secret, -2)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/totp/verification", UserTwoFactorCode{Code: code}, session.Token)
+	assert.Equal(t, http.StatusExpectationFailed, w.Code)
+	enrollment, recovery := enrollTwoFactorTestUser(t, server, router, admin, session.Token)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/totp", nil, session.Token)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	w = serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/user/role/"+examinee.UserId, UserRole{Role: RoleAuthor}, session.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	/* code is required & used once */
+	url := server.URL + "/nova/v1/user/login/" + admin.UserId
+	w = serveTestRequest(t, router, http.MethodPost, url, UserLogin{UserId: admin.UserId, Username: admin.Username, Password: admin.Password}, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	var missing, wrong ProblemDetails
+	unmarshalTestResponse(t, w, &missing)
+	w = serveTestRequest(t, router, http.MethodPost, url, UserLogin{UserId: admin.UserId, Username: admin.Username, Password: admin.Password + "x"}, "")
+	unmarshalTestResponse(t, w, &wrong)
+	assert.Equal(t, wrong, missing)
+	code = queryTwoFactorTestCode(t, enrollment.Secret, 1)
+	w = serveTestRequest(t, router, http.MethodPost, url, UserLogin{UserId: admin.UserId, Username: admin.Username, Password: admin.Password, Code: code}, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	session = UserSession{}
+	unmarshalTestResponse(t, w, &session)
+	assert.False(t, session.TwoFactorRequired)
+	w = serveTestRequest(t, router, http.MethodPost, url, UserLogin{UserId: admin.UserId, Username: admin.Username, Password: admin.Password, Code: code}, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	/* recovery code is used once */
+	login := UserLogin{UserId: admin.UserId, Username: admin.Username, Password: admin.Password, Code: strings.ToUpper(recovery.RecoveryCodes[0])}
+	w = serveTestRequest(t, router, http.MethodPost, url, login, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, url, login, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/user/totp/"+admin.UserId, nil, session.Token)
+	var twoFactor UserTwoFactor
+	unmarshalTestResponse(t, w, &twoFactor)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, twoFactor.Enabled)
+	assert.True(t, twoFactor.Required)
+	assert.Equal(t, recoveryCodeCount-1, twoFactor.RecoveryCodes)
+}
+
+func TestNova_HandleDeleteUserTwoFactor(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleDeleteUserTwoFactor
+	// Test Purpose: Test two factor is disabled by own code or reset by administrator
+	// Test Steps:
+	// 1. send CreateUserRecoveryCodes request, earlier recovery codes stop working
+	// 2. send DeleteUserTwoFactor request of other user, receive 403 Forbidden Code
+	// 3. send DeleteUserTwoFactor request with recovery code, receive 204 No Content Code
+	// 4. send DeleteUserTwoFactor request of administrator, receive 204 No Content Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startTwoFactorTestService(nil)
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	first, firstSession := createWorkflowTestUser(t, server, router, RoleExaminee, "")
+	second, secondSession := createWorkflowTestUser(t, server, router, RoleExaminee, "")
+	_, firstRecovery := enrollTwoFactorTestUser(t, server, router, first, firstSession.Token)
+	secondEnrollment, _ := enrollTwoFactorTestUser(t, server, router, second, secondSession.Token)
+	/* recovery codes are replaced */
+	code := queryTwoFactorTestCode(t, secondEnrollment.Secret, 1)
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/totp/recovery", UserTwoFactorCode{Code: code}, secondSession.Token)
+	var secondRecovery UserTwoFactorRecoveryCodes
+	unmarshalTestResponse(t, w, &secondRecovery)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, secondRecovery.RecoveryCodes, recoveryCodeCount)
+	/* users disable own two factor by code */
+	url := server.URL + "/nova/v1/user/totp/" + first.UserId
+	w = serveTestRequest(t, router, http.MethodDelete, url, UserTwoFactorCode{Code: secondRecovery.RecoveryCodes[0]}, secondSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, secondSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodDelete, url, UserTwoFactorCode{Code: secondRecovery.RecoveryCodes[0]}, firstSession.Token)
+	assert.Equal(t, http.StatusExpectationFailed, w.Code)
+	w = serveTestRequest(t, router, http.MethodDelete, url, UserTwoFactorCode{Code: firstRecovery.RecoveryCodes[0]}, firstSession.Token)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/login/"+first.UserId, UserLogin{UserId: first.UserId, Username: first.Username, Password: first.Password}, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	/* administrator resets lost authenticator */
+	w = serveTestRequest(t, router, http.MethodDelete, server.URL+"/nova/v1/user/totp/"+second.UserId, nil, admin.Token)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/user/totp/"+second.UserId, nil, admin.Token)
+	var twoFactor UserTwoFactor
+	unmarshalTestResponse(t, w, &twoFactor)
+	assert.False(t, twoFactor.Enabled)
+	assert.False(t, twoFactor.Required)
+}
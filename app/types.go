@@ -1,5 +1,157 @@
 package app
 
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	RoleAdmin    = "admin"
+	RoleAuthor   = "author"
+	RoleReviewer = "reviewer"
+	RoleExaminee = "examinee"
+)
+
+const (
+	QuestionStateDraft     = "draft"
+	QuestionStateInReview  = "in_review"
+	QuestionStatePublished = "published"
+	QuestionStateArchived  = "archived"
+)
+
+const (
+	QuestionTypeSingleChoice   = "single-choice"
+	QuestionTypeMultipleChoice = "multiple-choice"
+	QuestionTypeJudgement      = "judgement"
+	QuestionTypeEssay          = "essay"
+	QuestionTypeFillBlank      = "fill-blank"
+	QuestionTypeMatching       = "matching"
+	QuestionTypeOrdering       = "ordering"
+	QuestionTypeNumeric        = "numeric"
+	QuestionTypeTemplate       = "template"
+	QuestionTypeCode           = "code"
+)
+
+const (
+	CodeLanguageGo     = "go"
+	CodeLanguagePython = "python"
+)
+
+const (
+	TestStatusPassed            = "passed"
+	TestStatusWrongAnswer       = "wrong_answer"
+	TestStatusRuntimeError      = "runtime_error"
+	TestStatusTimeLimitExceeded = "time_limit_exceeded"
+	TestStatusCompileError      = "compile_error"
+)
+
+const (
+	ContentFormatPlain    = "plain"
+	ContentFormatMarkdown = "markdown"
+)
+
+const (
+	BlankMatchText    = "text"
+	BlankMatchRegex   = "regex"
+	BlankMatchNumeric = "numeric"
+)
+
+const (
+	ScoringAllOrNothing = "all-or-nothing"
+	ScoringPartial      = "partial"
+)
+
+const (
+	DifficultyEasy   = "easy"
+	DifficultyMedium = "medium"
+	DifficultyHard   = "hard"
+)
+
+const (
+	BlueprintModeFixed      = "fixed"
+	BlueprintModePerAttempt = "per-attempt"
+)
+
+const (
+	ExamAttemptStateOpen      = "open"
+	ExamAttemptStateSubmitted = "submitted"
+)
+
+const (
+	LatePolicyReject  = "reject"
+	LatePolicyPenalty = "penalty"
+)
+
+const (
+	IRTModel1PL = "1pl"
+	IRTModel2PL = "2pl"
+	IRTModel3PL = "3pl"
+)
+
+const (
+	AdaptiveSessionStateOpen     = "open"
+	AdaptiveSessionStateFinished = "finished"
+)
+
+const (
+	AdaptiveStopStandardError = "standard-error"
+	AdaptiveStopMaxLength     = "max-length"
+	AdaptiveStopPoolExhausted = "pool-exhausted"
+)
+
+const (
+	GroupKindClass  = "class"
+	GroupKindTeam   = "team"
+	GroupKindCohort = "cohort"
+)
+
+const (
+	TenantResourceUser         = "user"
+	TenantResourceExam         = "exam"
+	TenantResourceAdaptiveExam = "adaptive-exam"
+	TenantResourceBlueprint    = "blueprint"
+	TenantResourceGroup        = "group"
+	TenantResourceAttachment   = "attachment"
+)
+
+const (
+	UserImportFormatJSON = "json"
+	UserImportFormatCSV  = "csv"
+)
+
+const (
+	UserImportStatusCreated = "created"
+	UserImportStatusInvited = "invited"
+	UserImportStatusFailed  = "failed"
+)
+
+const (
+	UserTokenPasswordReset     = "password-reset"
+	UserTokenEmailVerification = "email-verification"
+)
+
+const (
+	GradebookFormatJSON = "json"
+	GradebookFormatCSV  = "csv"
+	GradebookFormatXLSX = "xlsx"
+)
+
+const (
+	PracticeRatingAgain = "again"
+	PracticeRatingHard  = "hard"
+	PracticeRatingGood  = "good"
+	PracticeRatingEasy  = "easy"
+)
+
+const (
+	ItemFlagInsufficientData         = "insufficient-data"
+	ItemFlagTooEasy                  = "too-easy"
+	ItemFlagTooHard                  = "too-hard"
+	ItemFlagLowDiscrimination        = "low-discrimination"
+	ItemFlagNegativePointBiserial    = "negative-point-biserial"
+	ItemFlagNonFunctioningDistractor = "non-functioning-distractor"
+)
+
 type User struct {
 	UserId      string `json:"userId" yaml:"userId" binding:"required"`
 	Username    string `json:"username" yaml:"username" binding:"required"`
@@ -10,17 +162,128 @@ type User struct {
 	Company     string `json:"company" yaml:"company" binding:"omitempty"`
 }
 
+type UserImportRow struct {
+	UserId      string `json:"userId" yaml:"userId"`
+	Username    string `json:"username" yaml:"username"`
+	Password    string `json:"password" yaml:"password"`
+	PhoneNumber string `json:"phone_number" yaml:"phone_number"`
+	Email       string `json:"email" yaml:"email"`
+	Address     string `json:"address" yaml:"address"`
+	Company     string `json:"company" yaml:"company"`
+}
+
+type UserImportResult struct {
+	Row            int    `json:"row" yaml:"row"`
+	UserId         string `json:"userId,omitempty" yaml:"userId,omitempty"`
+	Username       string `json:"username" yaml:"username"`
+	Status         string `json:"status" yaml:"status"`
+	Reason         string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	InvitationLink string `json:"invitation_link,omitempty" yaml:"invitation_link,omitempty"`
+}
+
+type UserImportReport struct {
+	Created int                `json:"created" yaml:"created"`
+	Invited int                `json:"invited" yaml:"invited"`
+	Failed  int                `json:"failed" yaml:"failed"`
+	Results []UserImportResult `json:"results" yaml:"results"`
+}
+
+type UserInvitation struct {
+	UserId    string    `json:"userId" yaml:"userId"`
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+	ExpiresAt time.Time `json:"expires_at" yaml:"expires_at"`
+}
+
+type UserInvitationAcceptance struct {
+	Password string `json:"password" yaml:"password" binding:"required"`
+}
+
+type UserToken struct {
+	UserId    string    `json:"userId" yaml:"userId"`
+	Purpose   string    `json:"purpose" yaml:"purpose"`
+	Email     string    `json:"email" yaml:"email"`
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+	ExpiresAt time.Time `json:"expires_at" yaml:"expires_at"`
+}
+
+type UserPasswordResetRequest struct {
+	Username string `json:"username" yaml:"username" binding:"required"`
+}
+
+type UserPasswordReset struct {
+	Password string `json:"password" yaml:"password" binding:"required"`
+}
+
+type UserEmailVerification struct {
+	UserId     string     `json:"userId" yaml:"userId"`
+	Email      string     `json:"email" yaml:"email"`
+	Verified   bool       `json:"verified" yaml:"verified"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty" yaml:"verified_at,omitempty"`
+}
+
 type UserLogin struct {
 	UserId   string `json:"userId" yaml:"userId" binding:"required"`
 	Username string `json:"username" yaml:"username" binding:"required"`
 	Password string `json:"password" yaml:"password" binding:"required"`
+	Code     string `json:"code,omitempty" yaml:"code,omitempty"`
+}
+
+type UserSession struct {
+	Token             string    `json:"token" yaml:"token"`
+	UserId            string    `json:"userId" yaml:"userId"`
+	CreatedAt         time.Time `json:"created_at" yaml:"created_at"`
+	ExpiresAt         time.Time `json:"expires_at" yaml:"expires_at"`
+	TwoFactorRequired bool      `json:"two_factor_required,omitempty" yaml:"two_factor_required,omitempty"`
+}
+
+type UserLoginLockout struct {
+	UserId   string `json:"userId" yaml:"userId"`
+	Failures int64  `json:"failures" yaml:"failures"`
+	Locked   bool   `json:"locked" yaml:"locked"`
+	Backoff  bool   `json:"backoff" yaml:"backoff"`
+}
+
+type UserTwoFactor struct {
+	UserId        string     `json:"userId" yaml:"userId"`
+	Secret        string     `json:"-" yaml:"-"`
+	Enabled       bool       `json:"enabled" yaml:"enabled"`
+	Required      bool       `json:"required" yaml:"required"`
+	RecoveryCodes int        `json:"recovery_codes" yaml:"recovery_codes"`
+	CreatedAt     time.Time  `json:"created_at" yaml:"created_at"`
+	EnabledAt     *time.Time `json:"enabled_at,omitempty" yaml:"enabled_at,omitempty"`
+}
+
+type UserTwoFactorEnrollment struct {
+	UserId          string `json:"userId" yaml:"userId"`
+	Secret          string `json:"secret" yaml:"secret"`
+	ProvisioningURI string `json:"provisioning_uri" yaml:"provisioning_uri"`
+}
+
+type UserTwoFactorCode struct {
+	Code string `json:"code" yaml:"code" binding:"required"`
+}
+
+type UserTwoFactorRecoveryCodes struct {
+	UserId        string   `json:"userId" yaml:"userId"`
+	RecoveryCodes []string `json:"recovery_codes" yaml:"recovery_codes"`
+}
+
+type UserRole struct {
+	UserId string `json:"userId" yaml:"userId"`
+	Role   string `json:"role" yaml:"role" binding:"required"`
 }
 
 type ProblemDetails struct {
-	Type   string `json:"type" yaml:"type"`
-	Title  string `json:"title" yaml:"title"`
-	Status int    `json:"status" yaml:"status"`
-	Cause  string `json:"cause" yaml:"cause"`
+	Type   string       `json:"type" yaml:"type"`
+	Title  string       `json:"title" yaml:"title"`
+	Status int          `json:"status" yaml:"status"`
+	Cause  string       `json:"cause" yaml:"cause"`
+	Errors []FieldError `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+type FieldError struct {
+	Field  string `json:"field" yaml:"field"`
+	Reason string `json:"reason" yaml:"reason"`
 }
 
 type QuestionSingleChoice struct {
@@ -51,6 +314,123 @@ type QuestionEssay struct {
 	StandardAnswer string `json:"standard_answer" yaml:"standard_answer" binding:"required"`
 }
 
+type QuestionFillBlank struct {
+	Id     string          `json:"id" yaml:"id" binding:"required"`
+	Title  string          `json:"title" yaml:"title" binding:"required"`
+	Blanks []QuestionBlank `json:"blanks" yaml:"blanks" binding:"required"`
+}
+
+type QuestionBlank struct {
+	Answers    []string `json:"answers" yaml:"answers" binding:"required"`
+	Match      string   `json:"match" yaml:"match"`
+	IgnoreCase bool     `json:"ignore_case" yaml:"ignore_case"`
+	TrimSpace  bool     `json:"trim_space" yaml:"trim_space"`
+	Tolerance  float64  `json:"tolerance" yaml:"tolerance"`
+}
+
+type QuestionMatching struct {
+	Id      string         `json:"id" yaml:"id" binding:"required"`
+	Title   string         `json:"title" yaml:"title" binding:"required"`
+	Pairs   []QuestionPair `json:"pairs" yaml:"pairs" binding:"required"`
+	Scoring string         `json:"scoring" yaml:"scoring"`
+}
+
+type QuestionPair struct {
+	Left  string `json:"left" yaml:"left" binding:"required"`
+	Right string `json:"right" yaml:"right" binding:"required"`
+}
+
+type QuestionOrdering struct {
+	Id      string   `json:"id" yaml:"id" binding:"required"`
+	Title   string   `json:"title" yaml:"title" binding:"required"`
+	Items   []string `json:"items" yaml:"items" binding:"required"`
+	Scoring string   `json:"scoring" yaml:"scoring"`
+}
+
+type QuestionNumeric struct {
+	Id                string         `json:"id" yaml:"id" binding:"required"`
+	Title             string         `json:"title" yaml:"title" binding:"required"`
+	Value             float64        `json:"value" yaml:"value"`
+	AbsoluteTolerance float64        `json:"absolute_tolerance" yaml:"absolute_tolerance"`
+	RelativeTolerance float64        `json:"relative_tolerance" yaml:"relative_tolerance"`
+	Unit              string         `json:"unit" yaml:"unit"`
+	Units             []QuestionUnit `json:"units" yaml:"units"`
+}
+
+type QuestionUnit struct {
+	Symbol string  `json:"symbol" yaml:"symbol" binding:"required"`
+	Factor float64 `json:"factor" yaml:"factor" binding:"required"`
+}
+
+type QuestionTemplate struct {
+	Id                string             `json:"id" yaml:"id" binding:"required"`
+	Title             string             `json:"title" yaml:"title" binding:"required"`
+	Variables         []QuestionVariable `json:"variables" yaml:"variables" binding:"required"`
+	Formula           string             `json:"formula" yaml:"formula" binding:"required"`
+	Precision         int                `json:"precision" yaml:"precision"`
+	AbsoluteTolerance float64            `json:"absolute_tolerance" yaml:"absolute_tolerance"`
+	RelativeTolerance float64            `json:"relative_tolerance" yaml:"relative_tolerance"`
+}
+
+type QuestionVariable struct {
+	Name   string    `json:"name" yaml:"name" binding:"required"`
+	Min    float64   `json:"min" yaml:"min"`
+	Max    float64   `json:"max" yaml:"max"`
+	Step   float64   `json:"step" yaml:"step"`
+	Values []float64 `json:"values,omitempty" yaml:"values,omitempty"`
+}
+
+type QuestionTemplateInstance struct {
+	Type      string             `json:"type" yaml:"type"`
+	Id        string             `json:"id" yaml:"id"`
+	Seed      int64              `json:"seed" yaml:"seed"`
+	Title     string             `json:"title" yaml:"title"`
+	Variables map[string]float64 `json:"variables" yaml:"variables"`
+	Answer    float64            `json:"answer" yaml:"answer"`
+}
+
+type QuestionCode struct {
+	Id          string             `json:"id" yaml:"id" binding:"required"`
+	Title       string             `json:"title" yaml:"title" binding:"required"`
+	Language    string             `json:"language" yaml:"language" binding:"required"`
+	StarterCode string             `json:"starter_code" yaml:"starter_code"`
+	TestCases   []QuestionTestCase `json:"test_cases" yaml:"test_cases" binding:"required"`
+	TimeLimit   int                `json:"time_limit" yaml:"time_limit"`
+	MemoryLimit int                `json:"memory_limit" yaml:"memory_limit"`
+	Scoring     string             `json:"scoring" yaml:"scoring"`
+}
+
+type QuestionTestCase struct {
+	Name   string `json:"name" yaml:"name" binding:"required"`
+	Input  string `json:"input" yaml:"input"`
+	Output string `json:"output" yaml:"output"`
+	Hidden bool   `json:"hidden" yaml:"hidden"`
+}
+
+type QuestionCodeSubmission struct {
+	Source string `json:"source" yaml:"source" binding:"required"`
+}
+
+type QuestionCodeAttempt struct {
+	AttemptId string               `json:"attempt_id" yaml:"attempt_id"`
+	Id        string               `json:"id" yaml:"id"`
+	UserId    string               `json:"user_id" yaml:"user_id"`
+	Language  string               `json:"language" yaml:"language"`
+	Source    string               `json:"source" yaml:"source"`
+	Results   []QuestionTestResult `json:"results" yaml:"results"`
+	Score     float64              `json:"score" yaml:"score"`
+	MaxScore  float64              `json:"max_score" yaml:"max_score"`
+	CreatedAt time.Time            `json:"created_at" yaml:"created_at"`
+}
+
+type QuestionTestResult struct {
+	Name     string `json:"name" yaml:"name"`
+	Status   string `json:"status" yaml:"status"`
+	Duration int64  `json:"duration" yaml:"duration"`
+	Output   string `json:"output,omitempty" yaml:"output,omitempty"`
+	Error    string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
 type QuestionTitle struct {
 	TitleText string `json:"title_text" yaml:"title_text"`
 }
@@ -59,3 +439,517 @@ type QuestionAnswer struct {
 	AnswerMark string `json:"answerMark" yaml:"answerMark" binding:"required"`
 	AnswerText string `json:"answerText" yaml:"answerText" binding:"required"`
 }
+
+type QuestionSubmission struct {
+	Answer json.RawMessage `json:"answer" yaml:"answer" binding:"required"`
+	Locale string          `json:"locale" yaml:"locale"`
+	Seed   int64           `json:"seed" yaml:"seed"`
+}
+
+type QuestionGrade struct {
+	Type        string            `json:"type" yaml:"type"`
+	Id          string            `json:"id" yaml:"id"`
+	Graded      bool              `json:"graded" yaml:"graded"`
+	Correct     bool              `json:"correct" yaml:"correct"`
+	Score       float64           `json:"score" yaml:"score"`
+	MaxScore    float64           `json:"max_score" yaml:"max_score"`
+	Parts       []bool            `json:"parts,omitempty" yaml:"parts,omitempty"`
+	Penalty     float64           `json:"penalty,omitempty" yaml:"penalty,omitempty"`
+	Explanation string            `json:"explanation,omitempty" yaml:"explanation,omitempty"`
+	Feedback    map[string]string `json:"feedback,omitempty" yaml:"feedback,omitempty"`
+}
+
+type QuestionDuplicate struct {
+	Type       string  `json:"type" yaml:"type"`
+	Id         string  `json:"id" yaml:"id"`
+	Title      string  `json:"title" yaml:"title"`
+	Similarity float64 `json:"similarity" yaml:"similarity"`
+}
+
+type QuestionDuplicateCluster struct {
+	Similarity float64             `json:"similarity" yaml:"similarity"`
+	Questions  []QuestionDuplicate `json:"questions" yaml:"questions"`
+}
+
+type QuestionRevision struct {
+	Type      string          `json:"type" yaml:"type"`
+	Id        string          `json:"id" yaml:"id"`
+	Revision  int             `json:"revision" yaml:"revision"`
+	Author    string          `json:"author" yaml:"author"`
+	CreatedAt time.Time       `json:"created_at" yaml:"created_at"`
+	Content   json.RawMessage `json:"content" yaml:"content"`
+}
+
+type QuestionRevisionChange struct {
+	Field  string          `json:"field" yaml:"field"`
+	Before json.RawMessage `json:"before" yaml:"before"`
+	After  json.RawMessage `json:"after" yaml:"after"`
+}
+
+type QuestionRevisionDiff struct {
+	Type     string                   `json:"type" yaml:"type"`
+	Id       string                   `json:"id" yaml:"id"`
+	Base     int                      `json:"base" yaml:"base"`
+	Revision int                      `json:"revision" yaml:"revision"`
+	Changes  []QuestionRevisionChange `json:"changes" yaml:"changes"`
+}
+
+type QuestionWorkflow struct {
+	Type      string           `json:"type" yaml:"type"`
+	Id        string           `json:"id" yaml:"id"`
+	State     string           `json:"state" yaml:"state"`
+	UpdatedBy string           `json:"updated_by" yaml:"updated_by"`
+	UpdatedAt time.Time        `json:"updated_at" yaml:"updated_at"`
+	Reviews   []QuestionReview `json:"reviews,omitempty" yaml:"reviews,omitempty"`
+}
+
+type QuestionReview struct {
+	Reviewer  string    `json:"reviewer" yaml:"reviewer"`
+	From      string    `json:"from" yaml:"from"`
+	To        string    `json:"to" yaml:"to"`
+	Comment   string    `json:"comment" yaml:"comment"`
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+}
+
+type QuestionGuidance struct {
+	Type        string            `json:"type" yaml:"type"`
+	Id          string            `json:"id" yaml:"id"`
+	Revision    int               `json:"revision" yaml:"revision"`
+	Explanation string            `json:"explanation" yaml:"explanation"`
+	Hints       []QuestionHint    `json:"hints" yaml:"hints"`
+	Feedback    map[string]string `json:"feedback" yaml:"feedback"`
+	UpdatedBy   string            `json:"updated_by" yaml:"updated_by"`
+	UpdatedAt   time.Time         `json:"updated_at" yaml:"updated_at"`
+}
+
+type QuestionHint struct {
+	Text    string  `json:"text" yaml:"text" binding:"required"`
+	Penalty float64 `json:"penalty" yaml:"penalty"`
+}
+
+type QuestionFormat struct {
+	Type      string    `json:"type" yaml:"type"`
+	Id        string    `json:"id" yaml:"id"`
+	Format    string    `json:"format" yaml:"format" binding:"required"`
+	UpdatedBy string    `json:"updated_by" yaml:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at" yaml:"updated_at"`
+}
+
+type QuestionRendering struct {
+	Type   string                  `json:"type" yaml:"type"`
+	Id     string                  `json:"id" yaml:"id"`
+	Format string                  `json:"format" yaml:"format"`
+	Fields []QuestionRenderedField `json:"fields" yaml:"fields"`
+}
+
+type QuestionRenderedField struct {
+	Field  string `json:"field" yaml:"field"`
+	Source string `json:"source" yaml:"source"`
+	HTML   string `json:"html" yaml:"html"`
+}
+
+type Attachment struct {
+	Id        string     `json:"id" yaml:"id" binding:"required"`
+	Name      string     `json:"name" yaml:"name"`
+	MediaType string     `json:"media_type" yaml:"media_type"`
+	Size      int64      `json:"size" yaml:"size"`
+	CreatedBy string     `json:"created_by" yaml:"created_by"`
+	CreatedAt time.Time  `json:"created_at" yaml:"created_at"`
+	URL       string     `json:"url,omitempty" yaml:"url,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" yaml:"expires_at,omitempty"`
+}
+
+type QuestionAttachments struct {
+	Type        string       `json:"type" yaml:"type"`
+	Id          string       `json:"id" yaml:"id"`
+	Attachments []Attachment `json:"attachments" yaml:"attachments" binding:"required,dive"`
+}
+
+type AttachmentCollection struct {
+	References  int      `json:"references" yaml:"references"`
+	Attachments []string `json:"attachments" yaml:"attachments"`
+}
+
+type QuestionTransition struct {
+	State   string `json:"state" yaml:"state"`
+	Comment string `json:"comment" yaml:"comment"`
+}
+
+type Exam struct {
+	Id               string         `json:"id" yaml:"id" binding:"required"`
+	Title            string         `json:"title" yaml:"title" binding:"required"`
+	Questions        []ExamQuestion `json:"questions" yaml:"questions" binding:"required,dive"`
+	ShuffleQuestions bool           `json:"shuffle_questions" yaml:"shuffle_questions"`
+	ShuffleAnswers   bool           `json:"shuffle_answers" yaml:"shuffle_answers"`
+	BlueprintId      string         `json:"blueprint_id,omitempty" yaml:"blueprint_id,omitempty"`
+	Timing           *ExamTiming    `json:"timing,omitempty" yaml:"timing,omitempty"`
+	CreatedBy        string         `json:"created_by" yaml:"created_by"`
+	CreatedAt        time.Time      `json:"created_at" yaml:"created_at"`
+}
+
+type ExamTiming struct {
+	Duration    int           `json:"duration" yaml:"duration"`
+	GracePeriod int           `json:"grace_period" yaml:"grace_period"`
+	LatePolicy  string        `json:"late_policy" yaml:"late_policy"`
+	LatePeriod  int           `json:"late_period" yaml:"late_period"`
+	LatePenalty float64       `json:"late_penalty" yaml:"late_penalty"`
+	Sections    []ExamSection `json:"sections,omitempty" yaml:"sections,omitempty" binding:"dive"`
+}
+
+type ExamSection struct {
+	Title    string `json:"title" yaml:"title"`
+	Count    int    `json:"count" yaml:"count" binding:"required"`
+	Duration int    `json:"duration" yaml:"duration" binding:"required"`
+}
+
+type ExamQuestion struct {
+	Type     string  `json:"type" yaml:"type" binding:"required"`
+	Id       string  `json:"id" yaml:"id" binding:"required"`
+	Points   float64 `json:"points" yaml:"points"`
+	Revision int     `json:"revision,omitempty" yaml:"revision,omitempty"`
+}
+
+type ExamAttempt struct {
+	AttemptId     string                `json:"attempt_id" yaml:"attempt_id"`
+	ExamId        string                `json:"exam_id" yaml:"exam_id"`
+	UserId        string                `json:"user_id" yaml:"user_id"`
+	Seed          int64                 `json:"seed" yaml:"seed"`
+	State         string                `json:"state" yaml:"state"`
+	Questions     []ExamAttemptQuestion `json:"questions" yaml:"questions"`
+	Score         float64               `json:"score" yaml:"score"`
+	MaxScore      float64               `json:"max_score" yaml:"max_score"`
+	CreatedAt     time.Time             `json:"created_at" yaml:"created_at"`
+	SubmittedAt   *time.Time            `json:"submitted_at,omitempty" yaml:"submitted_at,omitempty"`
+	Deadline      *time.Time            `json:"deadline,omitempty" yaml:"deadline,omitempty"`
+	Sections      []ExamAttemptSection  `json:"sections,omitempty" yaml:"sections,omitempty"`
+	Late          bool                  `json:"late,omitempty" yaml:"late,omitempty"`
+	Penalty       float64               `json:"penalty,omitempty" yaml:"penalty,omitempty"`
+	AutoSubmitted bool                  `json:"auto_submitted,omitempty" yaml:"auto_submitted,omitempty"`
+	Answers       []ExamAnswer          `json:"-" yaml:"-"`
+	Grades        []QuestionGrade       `json:"-" yaml:"-"`
+	ExamQuestions []ExamQuestion        `json:"-" yaml:"-"`
+}
+
+type ExamAttemptQuestion struct {
+	Type     string           `json:"type" yaml:"type"`
+	Id       string           `json:"id" yaml:"id"`
+	Revision int              `json:"revision,omitempty" yaml:"revision,omitempty"`
+	Points   float64          `json:"points" yaml:"points"`
+	Title    string           `json:"title" yaml:"title"`
+	Answers  []QuestionAnswer `json:"answers,omitempty" yaml:"answers,omitempty"`
+	Seed     int64            `json:"seed,omitempty" yaml:"seed,omitempty"`
+	Answer   json.RawMessage  `json:"answer,omitempty" yaml:"answer,omitempty"`
+	Grade    *QuestionGrade   `json:"grade,omitempty" yaml:"grade,omitempty"`
+}
+
+type ExamAttemptSection struct {
+	Title    string    `json:"title" yaml:"title"`
+	Count    int       `json:"count" yaml:"count"`
+	StartsAt time.Time `json:"starts_at" yaml:"starts_at"`
+	Deadline time.Time `json:"deadline" yaml:"deadline"`
+}
+
+type ExamAttemptDraft struct {
+	AttemptId string       `json:"attempt_id" yaml:"attempt_id"`
+	Answers   []ExamAnswer `json:"answers" yaml:"answers"`
+	Locale    string       `json:"locale" yaml:"locale"`
+	SavedAt   *time.Time   `json:"saved_at,omitempty" yaml:"saved_at,omitempty"`
+}
+
+type ExamAttemptSweep struct {
+	Attempts []string `json:"attempts" yaml:"attempts"`
+}
+
+type ServerTime struct {
+	Time      time.Time `json:"time" yaml:"time"`
+	UnixMilli int64     `json:"unix_milli" yaml:"unix_milli"`
+}
+
+type ExamSubmission struct {
+	Answers []ExamAnswer `json:"answers" yaml:"answers" binding:"required,dive"`
+	Locale  string       `json:"locale" yaml:"locale"`
+}
+
+type ExamAnswer struct {
+	Type      string          `json:"type" yaml:"type" binding:"required"`
+	Id        string          `json:"id" yaml:"id" binding:"required"`
+	Answer    json.RawMessage `json:"answer" yaml:"answer" binding:"required"`
+	TimeSpent float64         `json:"time_spent,omitempty" yaml:"time_spent,omitempty" binding:"gte=0"`
+}
+
+type QuestionClassification struct {
+	Type       string    `json:"type" yaml:"type"`
+	Id         string    `json:"id" yaml:"id"`
+	Category   string    `json:"category" yaml:"category"`
+	Difficulty string    `json:"difficulty" yaml:"difficulty"`
+	Tags       []string  `json:"tags" yaml:"tags"`
+	UpdatedBy  string    `json:"updated_by" yaml:"updated_by"`
+	UpdatedAt  time.Time `json:"updated_at" yaml:"updated_at"`
+}
+
+type ExamBlueprint struct {
+	Id               string              `json:"id" yaml:"id" binding:"required"`
+	Title            string              `json:"title" yaml:"title" binding:"required"`
+	Rules            []ExamBlueprintRule `json:"rules" yaml:"rules" binding:"required,dive"`
+	TotalPoints      float64             `json:"total_points" yaml:"total_points"`
+	Mode             string              `json:"mode" yaml:"mode"`
+	RecentDays       int                 `json:"recent_days" yaml:"recent_days"`
+	ShuffleQuestions bool                `json:"shuffle_questions" yaml:"shuffle_questions"`
+	ShuffleAnswers   bool                `json:"shuffle_answers" yaml:"shuffle_answers"`
+	CreatedBy        string              `json:"created_by" yaml:"created_by"`
+	CreatedAt        time.Time           `json:"created_at" yaml:"created_at"`
+}
+
+type ExamBlueprintRule struct {
+	Type       string  `json:"type" yaml:"type" binding:"required"`
+	Category   string  `json:"category" yaml:"category"`
+	Difficulty string  `json:"difficulty" yaml:"difficulty"`
+	Tag        string  `json:"tag" yaml:"tag"`
+	Count      int     `json:"count" yaml:"count" binding:"required"`
+	Points     float64 `json:"points" yaml:"points"`
+}
+
+type ExamGeneration struct {
+	Id     string      `json:"id" yaml:"id" binding:"required"`
+	Title  string      `json:"title" yaml:"title"`
+	Timing *ExamTiming `json:"timing,omitempty" yaml:"timing,omitempty"`
+}
+
+type QuestionAnalysis struct {
+	Type           string               `json:"type" yaml:"type"`
+	Id             string               `json:"id" yaml:"id"`
+	Responses      int                  `json:"responses" yaml:"responses"`
+	Facility       float64              `json:"facility" yaml:"facility"`
+	Discrimination float64              `json:"discrimination" yaml:"discrimination"`
+	PointBiserial  float64              `json:"point_biserial" yaml:"point_biserial"`
+	AverageTime    float64              `json:"average_time" yaml:"average_time"`
+	Distractors    []DistractorAnalysis `json:"distractors,omitempty" yaml:"distractors,omitempty"`
+	Omitted        int                  `json:"omitted" yaml:"omitted"`
+	Flags          []string             `json:"flags" yaml:"flags"`
+	Review         bool                 `json:"review" yaml:"review"`
+}
+
+type DistractorAnalysis struct {
+	AnswerMark string  `json:"answer_mark" yaml:"answer_mark"`
+	AnswerText string  `json:"answer_text" yaml:"answer_text"`
+	Key        bool    `json:"key" yaml:"key"`
+	Count      int     `json:"count" yaml:"count"`
+	Frequency  float64 `json:"frequency" yaml:"frequency"`
+}
+
+type ExamAnalysis struct {
+	ExamId       string             `json:"exam_id" yaml:"exam_id"`
+	Attempts     int                `json:"attempts" yaml:"attempts"`
+	AverageScore float64            `json:"average_score" yaml:"average_score"`
+	Questions    []QuestionAnalysis `json:"questions" yaml:"questions"`
+}
+
+type IRTParameters struct {
+	Type           string    `json:"type" yaml:"type"`
+	Id             string    `json:"id" yaml:"id"`
+	Model          string    `json:"model" yaml:"model"`
+	Discrimination float64   `json:"discrimination" yaml:"discrimination"`
+	Difficulty     float64   `json:"difficulty" yaml:"difficulty"`
+	Guessing       float64   `json:"guessing" yaml:"guessing"`
+	Responses      int       `json:"responses" yaml:"responses"`
+	CalibratedAt   time.Time `json:"calibrated_at" yaml:"calibrated_at"`
+}
+
+type IRTCalibration struct {
+	Model string `json:"model" yaml:"model" binding:"required"`
+}
+
+type IRTCalibrationResult struct {
+	Model      string          `json:"model" yaml:"model"`
+	Responses  int             `json:"responses" yaml:"responses"`
+	Calibrated []IRTParameters `json:"calibrated" yaml:"calibrated"`
+	Skipped    int             `json:"skipped" yaml:"skipped"`
+}
+
+type AdaptiveExam struct {
+	Id            string         `json:"id" yaml:"id" binding:"required"`
+	Title         string         `json:"title" yaml:"title" binding:"required"`
+	Model         string         `json:"model" yaml:"model" binding:"required"`
+	Questions     []ExamQuestion `json:"questions" yaml:"questions" binding:"dive"`
+	MaxLength     int            `json:"max_length" yaml:"max_length"`
+	StandardError float64        `json:"standard_error" yaml:"standard_error"`
+	CreatedBy     string         `json:"created_by" yaml:"created_by"`
+	CreatedAt     time.Time      `json:"created_at" yaml:"created_at"`
+}
+
+type AdaptiveSession struct {
+	SessionId     string               `json:"session_id" yaml:"session_id"`
+	ExamId        string               `json:"exam_id" yaml:"exam_id"`
+	UserId        string               `json:"user_id" yaml:"user_id"`
+	State         string               `json:"state" yaml:"state"`
+	Ability       float64              `json:"ability" yaml:"ability"`
+	StandardError float64              `json:"standard_error" yaml:"standard_error"`
+	Lower         float64              `json:"lower" yaml:"lower"`
+	Upper         float64              `json:"upper" yaml:"upper"`
+	Items         []AdaptiveItem       `json:"items" yaml:"items"`
+	Question      *ExamAttemptQuestion `json:"question,omitempty" yaml:"question,omitempty"`
+	StopReason    string               `json:"stop_reason,omitempty" yaml:"stop_reason,omitempty"`
+	CreatedAt     time.Time            `json:"created_at" yaml:"created_at"`
+	FinishedAt    *time.Time           `json:"finished_at,omitempty" yaml:"finished_at,omitempty"`
+}
+
+type AdaptiveItem struct {
+	Type          string          `json:"type" yaml:"type"`
+	Id            string          `json:"id" yaml:"id"`
+	Answer        json.RawMessage `json:"answer,omitempty" yaml:"answer,omitempty"`
+	Correct       bool            `json:"correct" yaml:"correct"`
+	Ability       float64         `json:"ability" yaml:"ability"`
+	StandardError float64         `json:"standard_error" yaml:"standard_error"`
+	AnsweredAt    time.Time       `json:"answered_at" yaml:"answered_at"`
+}
+
+type AdaptiveResponse struct {
+	Type   string          `json:"type" yaml:"type" binding:"required"`
+	Id     string          `json:"id" yaml:"id" binding:"required"`
+	Answer json.RawMessage `json:"answer" yaml:"answer" binding:"required"`
+}
+
+type PracticeCard struct {
+	UserId      string     `json:"user_id" yaml:"user_id"`
+	Type        string     `json:"type" yaml:"type"`
+	Id          string     `json:"id" yaml:"id"`
+	Ease        float64    `json:"ease" yaml:"ease"`
+	Interval    int        `json:"interval" yaml:"interval"`
+	Repetitions int        `json:"repetitions" yaml:"repetitions"`
+	Lapses      int        `json:"lapses" yaml:"lapses"`
+	Reviews     int        `json:"reviews" yaml:"reviews"`
+	DueAt       time.Time  `json:"due_at" yaml:"due_at"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty" yaml:"reviewed_at,omitempty"`
+}
+
+type PracticeReview struct {
+	Answer json.RawMessage `json:"answer" yaml:"answer" binding:"required"`
+	Rating string          `json:"rating" yaml:"rating"`
+	Locale string          `json:"locale" yaml:"locale"`
+	Seed   int64           `json:"seed" yaml:"seed"`
+}
+
+type PracticeReviewResult struct {
+	Grade  QuestionGrade `json:"grade" yaml:"grade"`
+	Rating string        `json:"rating" yaml:"rating"`
+	Card   PracticeCard  `json:"card" yaml:"card"`
+}
+
+type PracticeQueue struct {
+	Date      string              `json:"date" yaml:"date"`
+	Due       int                 `json:"due" yaml:"due"`
+	New       int                 `json:"new" yaml:"new"`
+	Questions []PracticeQueueItem `json:"questions" yaml:"questions"`
+}
+
+type PracticeQueueItem struct {
+	Question ExamAttemptQuestion `json:"question" yaml:"question"`
+	Card     *PracticeCard       `json:"card,omitempty" yaml:"card,omitempty"`
+}
+
+type Gradebook struct {
+	UserId       string             `json:"user_id" yaml:"user_id"`
+	Username     string             `json:"username" yaml:"username"`
+	AverageScore float64            `json:"average_score" yaml:"average_score"`
+	Attempts     []GradebookAttempt `json:"attempts" yaml:"attempts"`
+	Mastery      []CategoryMastery  `json:"mastery" yaml:"mastery"`
+}
+
+type GradebookAttempt struct {
+	AttemptId   string    `json:"attempt_id" yaml:"attempt_id"`
+	ExamId      string    `json:"exam_id" yaml:"exam_id"`
+	ExamTitle   string    `json:"exam_title" yaml:"exam_title"`
+	Score       float64   `json:"score" yaml:"score"`
+	MaxScore    float64   `json:"max_score" yaml:"max_score"`
+	Percent     float64   `json:"percent" yaml:"percent"`
+	Percentile  float64   `json:"percentile" yaml:"percentile"`
+	Late        bool      `json:"late,omitempty" yaml:"late,omitempty"`
+	SubmittedAt time.Time `json:"submitted_at" yaml:"submitted_at"`
+}
+
+type CategoryMastery struct {
+	Category  string  `json:"category" yaml:"category"`
+	Questions int     `json:"questions" yaml:"questions"`
+	Score     float64 `json:"score" yaml:"score"`
+	MaxScore  float64 `json:"max_score" yaml:"max_score"`
+	Mastery   float64 `json:"mastery" yaml:"mastery"`
+}
+
+type GradebookReport struct {
+	ExamId  string      `json:"exam_id,omitempty" yaml:"exam_id,omitempty"`
+	GroupId string      `json:"group_id,omitempty" yaml:"group_id,omitempty"`
+	From    *time.Time  `json:"from,omitempty" yaml:"from,omitempty"`
+	To      *time.Time  `json:"to,omitempty" yaml:"to,omitempty"`
+	Users   []Gradebook `json:"users" yaml:"users"`
+}
+
+type Group struct {
+	Id          string    `json:"id" yaml:"id" binding:"required"`
+	Name        string    `json:"name" yaml:"name" binding:"required"`
+	Kind        string    `json:"kind" yaml:"kind" binding:"required"`
+	Description string    `json:"description" yaml:"description"`
+	Members     int       `json:"members" yaml:"members"`
+	CreatedBy   string    `json:"created_by" yaml:"created_by"`
+	CreatedAt   time.Time `json:"created_at" yaml:"created_at"`
+}
+
+type GroupMember struct {
+	UserId   string    `json:"user_id" yaml:"user_id"`
+	Username string    `json:"username" yaml:"username"`
+	AddedAt  time.Time `json:"added_at" yaml:"added_at"`
+}
+
+type GroupMembers struct {
+	Members []string `json:"members" yaml:"members" binding:"required"`
+}
+
+type GroupMembership struct {
+	GroupId string `json:"group_id" yaml:"group_id"`
+	Added   int    `json:"added" yaml:"added"`
+	Removed int    `json:"removed" yaml:"removed"`
+	Members int    `json:"members" yaml:"members"`
+}
+
+type ExamAssignment struct {
+	GroupId    string     `json:"group_id" yaml:"group_id"`
+	ExamId     string     `json:"exam_id" yaml:"exam_id"`
+	DueAt      *time.Time `json:"due_at,omitempty" yaml:"due_at,omitempty"`
+	AssignedBy string     `json:"assigned_by" yaml:"assigned_by"`
+	AssignedAt time.Time  `json:"assigned_at" yaml:"assigned_at"`
+}
+
+type GroupResults struct {
+	GroupId      string              `json:"group_id" yaml:"group_id"`
+	ExamId       string              `json:"exam_id" yaml:"exam_id"`
+	Members      int                 `json:"members" yaml:"members"`
+	Submitted    int                 `json:"submitted" yaml:"submitted"`
+	Pending      int                 `json:"pending" yaml:"pending"`
+	AverageScore float64             `json:"average_score" yaml:"average_score"`
+	MedianScore  float64             `json:"median_score" yaml:"median_score"`
+	HighestScore float64             `json:"highest_score" yaml:"highest_score"`
+	LowestScore  float64             `json:"lowest_score" yaml:"lowest_score"`
+	Users        []GroupMemberResult `json:"users" yaml:"users"`
+}
+
+type GroupMemberResult struct {
+	UserId      string     `json:"user_id" yaml:"user_id"`
+	Username    string     `json:"username" yaml:"username"`
+	Attempts    int        `json:"attempts" yaml:"attempts"`
+	BestScore   float64    `json:"best_score" yaml:"best_score"`
+	Percentile  float64    `json:"percentile" yaml:"percentile"`
+	SubmittedAt *time.Time `json:"submitted_at,omitempty" yaml:"submitted_at,omitempty"`
+}
+
+type Tenant struct {
+	Id        string    `json:"id" yaml:"id" binding:"required"`
+	Name      string    `json:"name" yaml:"name" binding:"required"`
+	Users     int       `json:"users" yaml:"users"`
+	CreatedBy string    `json:"created_by" yaml:"created_by"`
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+}
+
+type TenantResource struct {
+	Kind     string `json:"kind" yaml:"kind"`
+	Id       string `json:"id" yaml:"id"`
+	TenantId string `json:"tenant_id" yaml:"tenant_id"`
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"net/http"
@@ -38,6 +39,14 @@ func (nova *Nova) HandleQueryUserId(c *gin.Context) {
 	// query userId from data cache
 	logger.Debugf("query userId from data cache")
 	userId, err := nova.queryUserFromDataCache(userName)
+	// userId is looked up before login, anonymous requests reach users outside tenants
+	scope := nova.queryTenantScope(c)
+	if scope == tenantScopeAnonymous {
+		scope = ""
+	}
+	if err == nil && !nova.isResourceInScope(scope, TenantResourceUser, userId) {
+		err = errors.New("userId not found")
+	}
 	if err != nil {
 		nova.response404NotFound(c, err)
 		logger.Errorf("error query user from data cache: %v", err)
@@ -97,12 +106,29 @@ func (nova *Nova) HandleCreateUser(c *gin.Context) {
 		return
 	}
 	logger.Debugf("successfully check userName or phoneNumber is existed")
+	// store user tenant in database & data cache
+	logger.Debugf("store user tenant in database & data cache")
+	if err = nova.createResourceTenant(c, TenantResourceUser, strings.ToLower(request.UserId)); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store user tenant in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store user tenant in database & data cache")
+	// password is only stored as bcrypt hash
+	logger.Debugf("hash user password")
+	password, err := hashUserPassword(request.Password)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error hash user password: %v", err)
+		return
+	}
+	logger.Debugf("successfully hash user password")
 	// store created user in data cache
 	logger.Debugf("store user in data cache")
 	response := User{
 		UserId:      strings.ToLower(request.UserId),
 		Username:    request.Username,
-		Password:    request.Password,
+		Password:    password,
 		PhoneNumber: request.PhoneNumber,
 		Email:       request.Email,
 		Address:     request.Address,
@@ -118,7 +144,8 @@ func (nova *Nova) HandleCreateUser(c *gin.Context) {
 		return
 	}
 	logger.Debugf("successfully store user in database")
-	// return response
+	// return response without password hash
+	response.Password = ""
 	nova.response201Created(c, response)
 	logger.Infof("response status code: %v, body: %v", http.StatusCreated, response)
 	return
@@ -137,6 +164,12 @@ func (nova *Nova) HandleDeleteUser(c *gin.Context) {
 		return
 	}
 	logger.Debugf("successfully check userId is validate")
+	// users reach themselves, administrators reach users of their tenant
+	logger.Debugf("check principal is user or its administrator")
+	if !nova.checkUserPrincipal(c, userId) {
+		return
+	}
+	logger.Debugf("successfully check principal is user or its administrator")
 	// update data cache by querying users in database
 	logger.Debugf("update data cache by querying users in database")
 	err := nova.queryUsersInDatabase()
@@ -165,6 +198,14 @@ func (nova *Nova) HandleDeleteUser(c *gin.Context) {
 	// delete user from data cache
 	logger.Debugf("delete user in data cache")
 	nova.deleteUserInDataCache(userId)
+	// delete user tenant in database & data cache
+	logger.Debugf("delete user tenant in database & data cache")
+	if err := nova.deleteResourceTenant(TenantResourceUser, userId); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error delete user tenant in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully delete user tenant in database & data cache")
 	// return response
 	nova.response204NoContent(c, nil)
 	logger.Infof("response status code: %v, body: %v", http.StatusNoContent, nil)
@@ -193,6 +234,12 @@ func (nova *Nova) HandleModifyUser(c *gin.Context) {
 		return
 	}
 	logger.Debugf("successfully check user is validate")
+	// users change themselves, administrators change users of their tenant
+	logger.Debugf("check principal is user or its administrator")
+	if !nova.checkUserPrincipal(c, strings.ToLower(request.UserId)) {
+		return
+	}
+	logger.Debugf("successfully check principal is user or its administrator")
 	// update data cache by querying users in database
 	logger.Debugf("update data cache by querying users in database")
 	err = nova.queryUsersInDatabase()
@@ -204,7 +251,7 @@ func (nova *Nova) HandleModifyUser(c *gin.Context) {
 	logger.Debugf("successfully update data cache by querying users in database")
 	// check user existence
 	logger.Debugf("check user is existed")
-	if !nova.isUserExisted(strings.ToLower(request.UserId)) {
+	if !nova.isUserExisted(strings.ToLower(request.UserId)) || !nova.isResourceVisible(c, TenantResourceUser, strings.ToLower(request.UserId)) {
 		nova.response404NotFound(c, errors.New("user not found"))
 		logger.Errorf("error check user is existed: %v", err)
 		return
@@ -218,6 +265,16 @@ func (nova *Nova) HandleModifyUser(c *gin.Context) {
 		return
 	}
 	logger.Debugf("successfully check userName or phoneNumber is modified")
+	// password is only stored as bcrypt hash, omitted password is kept
+	if request.Password != "" {
+		logger.Debugf("hash user password")
+		if request.Password, err = hashUserPassword(request.Password); err != nil {
+			nova.response500InternalServerError(c, err)
+			logger.Errorf("error hash user password: %v", err)
+			return
+		}
+		logger.Debugf("successfully hash user password")
+	}
 	// store modified user in data cache
 	logger.Debugf("store modify user in data cache")
 	response, err := nova.modifyUserInDataCache(request)
@@ -235,7 +292,8 @@ func (nova *Nova) HandleModifyUser(c *gin.Context) {
 		return
 	}
 	logger.Debugf("successfully store modify user in database")
-	// return response
+	// return response without password hash
+	response.Password = ""
 	nova.response200OK(c, response)
 	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
 	return
@@ -254,6 +312,12 @@ func (nova *Nova) HandleQueryUser(c *gin.Context) {
 		return
 	}
 	logger.Debugf("successfully check userId is validate")
+	// users reach themselves, administrators reach users of their tenant
+	logger.Debugf("check principal is user or its administrator")
+	if !nova.checkUserPrincipal(c, userId) {
+		return
+	}
+	logger.Debugf("successfully check principal is user or its administrator")
 	// update data cache by querying users in database
 	logger.Debugf("update data cache by querying users in database")
 	err := nova.queryUsersInDatabase()
@@ -288,7 +352,8 @@ func (nova *Nova) HandleQueryUser(c *gin.Context) {
 		return
 	}
 	logger.Debugf("successfully query user in data cache")
-	// return response
+	// return response without password hash
+	response.Password = ""
 	nova.response200OK(c, response)
 	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
 	return
@@ -308,6 +373,19 @@ func (nova *Nova) HandleUpdateUser(c *gin.Context) {
 	}
 	logger.Debugf("successfully bind request json format")
 	// check request body correctness
+	logger.Debugf("check user is validate")
+	if b, err := nova.isUserValidate(request); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check user is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check user is validate")
+	// users change themselves, administrators change users of their tenant
+	logger.Debugf("check principal is user or its administrator")
+	if !nova.checkUserPrincipal(c, strings.ToLower(request.UserId)) {
+		return
+	}
+	logger.Debugf("successfully check principal is user or its administrator")
 	// update data cache by querying users in database
 	logger.Debugf("update data cache by querying users in database")
 	err = nova.queryUsersInDatabase()
@@ -319,7 +397,7 @@ func (nova *Nova) HandleUpdateUser(c *gin.Context) {
 	logger.Debugf("successfully update data cache by querying users in database")
 	// check user existence
 	logger.Debugf("check user existence")
-	if !nova.isUserExisted(strings.ToLower(request.UserId)) {
+	if !nova.isUserExisted(strings.ToLower(request.UserId)) || !nova.isResourceVisible(c, TenantResourceUser, strings.ToLower(request.UserId)) {
 		nova.response403Forbidden(c, errors.New("forbidden replace user without create it"))
 		logger.Errorf("error check user existence")
 		return
@@ -333,12 +411,21 @@ func (nova *Nova) HandleUpdateUser(c *gin.Context) {
 		return
 	}
 	logger.Debugf("successfully check userName or phoneNumber is modified")
+	// password is only stored as bcrypt hash
+	logger.Debugf("hash user password")
+	password, err := hashUserPassword(request.Password)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error hash user password: %v", err)
+		return
+	}
+	logger.Debugf("successfully hash user password")
 	// store updated user in data cache
 	logger.Debugf("update user in data cache")
 	response := User{
 		UserId:      strings.ToLower(request.UserId),
 		Username:    request.Username,
-		Password:    request.Password,
+		Password:    password,
 		PhoneNumber: request.PhoneNumber,
 		Email:       request.Email,
 		Address:     request.Address,
@@ -358,7 +445,8 @@ func (nova *Nova) HandleUpdateUser(c *gin.Context) {
 		return
 	}
 	logger.Debugf("successfully update user in database")
-	// return response
+	// return response without password hash
+	response.Password = ""
 	nova.response200OK(c, response)
 	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
 	return
@@ -387,6 +475,19 @@ func (nova *Nova) HandleCreateUserLogin(c *gin.Context) {
 		return
 	}
 	logger.Debugf("successfully check userId is validate")
+	// blocked client ip, locked account & backoff refuse login
+	logger.Debugf("check login is not throttled")
+	ctx, ip := c.Request.Context(), c.ClientIP()
+	if err := nova.checkLoginThrottle(ctx, userId, ip); err != nil {
+		if errors.Is(err, errLoginIPBlocked) || errors.Is(err, errLoginAccountLocked) || errors.Is(err, errLoginBackoff) {
+			nova.response429TooManyRequests(c, err)
+		} else {
+			nova.response500InternalServerError(c, err)
+		}
+		logger.Errorf("error check login is not throttled: %v", err)
+		return
+	}
+	logger.Debugf("successfully check login is not throttled")
 	// update data cache by querying users in database
 	logger.Debugf("update data cache by querying users in database")
 	err = nova.queryUsersInDatabase()
@@ -396,10 +497,10 @@ func (nova *Nova) HandleCreateUserLogin(c *gin.Context) {
 		return
 	}
 	logger.Debugf("successfully update data cache by querying users in database")
-	// check user existence
+	// check user existence, unknown user fails like wrong password
 	logger.Debugf("check user is existed")
 	if !nova.isUserExisted(userId) {
-		nova.response404NotFound(c, errors.New("user not found"))
+		nova.failUserLogin(c, userId, ip, errLoginCredentials)
 		logger.Errorf("error check user is existed")
 		return
 	}
@@ -424,25 +525,74 @@ func (nova *Nova) HandleCreateUserLogin(c *gin.Context) {
 	// check username
 	logger.Debugf("check username consistentance")
 	if user.Username != request.Username {
-		nova.response412PreconditionFailed(c, errors.New("request username inconsistent with database"))
+		nova.failUserLogin(c, userId, ip, errLoginCredentials)
 		logger.Errorf("error check username consistentance.")
 		return
 	}
 	logger.Debugf("successfully check username consistentance")
 	// verify password correctness
 	logger.Debugf("check password correctness")
-	if user.Password != request.Password {
-		nova.response417ExpectationFailed(c, errors.New("request password inconsistent with database"))
+	if !isUserPasswordMatched(user.Password, request.Password) {
+		nova.failUserLogin(c, userId, ip, errLoginCredentials)
 		logger.Errorf("error check password correctness.")
 		return
 	}
 	logger.Debugf("successfully check password correctness")
+	// verify two-factor code of user enabled two factor, recovery code is accepted once
+	logger.Debugf("check two factor code correctness")
+	if ok, err := nova.verifyUserLoginTwoFactor(user.UserId, request.Code); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error check two factor code correctness: %v", err)
+		return
+	} else if !ok {
+		nova.failUserLogin(c, userId, ip, errLoginCredentials)
+		logger.Errorf("error check two factor code correctness.")
+		return
+	}
+	logger.Debugf("successfully check two factor code correctness")
+	// successful login forgets failed logins of account
+	logger.Debugf("reset login throttle of user")
+	if err := nova.resetLogin(ctx, userId); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error reset login throttle of user: %v", err)
+		return
+	}
+	logger.Debugf("successfully reset login throttle of user")
+	// create user session
+	logger.Debugf("create user session")
+	response, err := nova.createUserSession(user.UserId)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error create user session: %v", err)
+		return
+	}
+	// session of user required to enroll two factor is refused privileged requests
+	response.TwoFactorRequired = nova.isUserTwoFactorRequired(nova.queryUserRole(user.UserId)) && !nova.isUserTwoFactorEnabled(user.UserId)
+	logger.Debugf("successfully create user session")
 	// return response
-	nova.response200OK(c, nil)
+	nova.response200OK(c, response)
 	logger.Infof("response status code: %v", http.StatusOK)
 	return
 }
 
+func (nova *Nova) checkUserPrincipal(c *gin.Context, userId string) bool {
+	// only sign up & login are open to anonymous requests
+	principal, ok := nova.queryPrincipal(c)
+	if !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to access user"))
+		logger.Errorf("error check user session is validate")
+		return false
+	}
+	// user pending two factor has no role yet, it still reaches itself
+	_, role, _ := nova.queryPrincipalRole(c)
+	if principal.UserId != userId && (role != RoleAdmin || !nova.isResourceVisible(c, TenantResourceUser, userId)) {
+		nova.response403Forbidden(c, errors.New("only user itself or administrator of its tenant accesses user"))
+		logger.Errorf("error check principal is user or its administrator")
+		return false
+	}
+	return true
+}
+
 func (nova *Nova) isUserExisted(userId string) bool {
 	// enable user cache read lock
 	nova.cache.userCache.mutex.RLock()
@@ -501,6 +651,10 @@ func (nova *Nova) isUserValidate(user User) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	// bcrypt only hashes first bytes of password
+	if len(user.Password) > userPasswordMaxLength {
+		return false, fmt.Errorf("password should be at most %v bytes", userPasswordMaxLength)
+	}
 	return true, nil
 }
 
@@ -37,6 +37,8 @@ func setupUserTestRouter() *gin.Engine {
 		novaService.DELETE("/user/:userId", nova.HandleDeleteUser)
 		novaService.PATCH("/user/:userId", nova.HandleModifyUser)
 		novaService.GET("/user/:userId", nova.HandleQueryUser)
+		// user login related
+		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
 	}
 	return router
 }
@@ -47,17 +49,19 @@ func startUserTestService() (*httptest.Server, *gin.Engine) {
 }
 
 func resetUserTestCase() error {
-	// remove database
-	err := os.Remove("nova.db")
-	if err != nil {
-		return err
-	}
+	// isolate database
+	databaseSource = newTestDatabaseSource()
 	// remove logs
-	err = os.RemoveAll("logs/")
-	if err != nil {
-		return err
-	}
-	return nil
+	return os.RemoveAll("logs/")
+}
+
+func queryUserTestToken(t testing.TB, router *gin.Engine, url string, user User) string {
+	// session of user itself, only signing up & login are anonymous
+	w := serveTestRequest(t, router, http.MethodPost, url+"/nova/v1/user/login/"+user.UserId, UserLogin{UserId: user.UserId, Username: user.Username, Password: user.Password}, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var session UserSession
+	unmarshalTestResponse(t, w, &session)
+	return session.Token
 }
 
 func TestNova_HandleCreateUserId(t *testing.T) {
@@ -243,7 +247,7 @@ func TestNova_HandleQueryUserId(t *testing.T) {
 	assert.Equal(t, "application/json", wUser.Header().Get("Content-Type"))
 	assert.Equal(t, user.UserId, resUser.UserId)
 	assert.Equal(t, user.Username, resUser.Username)
-	assert.Equal(t, user.Password, resUser.Password)
+	assert.Empty(t, resUser.Password)
 	assert.Equal(t, user.PhoneNumber, resUser.PhoneNumber)
 	assert.Equal(t, user.Email, resUser.Email)
 	assert.Equal(t, user.Address, resUser.Address)
@@ -351,7 +355,7 @@ func BenchmarkNova_HandleQueryUserId(b *testing.B) {
 		assert.Equal(b, "application/json", wUser.Header().Get("Content-Type"))
 		assert.Equal(b, user.UserId, resUser.UserId)
 		assert.Equal(b, user.Username, resUser.Username)
-		assert.Equal(b, user.Password, resUser.Password)
+		assert.Empty(b, resUser.Password)
 		assert.Equal(b, user.PhoneNumber, resUser.PhoneNumber)
 		assert.Equal(b, user.Email, resUser.Email)
 		assert.Equal(b, user.Address, resUser.Address)
@@ -461,7 +465,7 @@ func BenchmarkNova_HandleQueryUserIdParallel(b *testing.B) {
 			assert.Equal(b, "application/json", wUser.Header().Get("Content-Type"))
 			assert.Equal(b, user.UserId, resUser.UserId)
 			assert.Equal(b, user.Username, resUser.Username)
-			assert.Equal(b, user.Password, resUser.Password)
+			assert.Empty(b, resUser.Password)
 			assert.Equal(b, user.PhoneNumber, resUser.PhoneNumber)
 			assert.Equal(b, user.Email, resUser.Email)
 			assert.Equal(b, user.Address, resUser.Address)
@@ -567,7 +571,7 @@ func TestNova_HandleCreateUser(t *testing.T) {
 	assert.Equal(t, "application/json", wUser.Header().Get("Content-Type"))
 	assert.Equal(t, user.UserId, resUser.UserId)
 	assert.Equal(t, user.Username, resUser.Username)
-	assert.Equal(t, user.Password, resUser.Password)
+	assert.Empty(t, resUser.Password)
 	assert.Equal(t, user.PhoneNumber, resUser.PhoneNumber)
 	assert.Equal(t, user.Email, resUser.Email)
 	assert.Equal(t, user.Address, resUser.Address)
@@ -646,7 +650,7 @@ func BenchmarkNova_HandleCreateUser(b *testing.B) {
 		assert.Equal(b, "application/json", wUser.Header().Get("Content-Type"))
 		assert.Equal(b, user.UserId, resUser.UserId)
 		assert.Equal(b, user.Username, resUser.Username)
-		assert.Equal(b, user.Password, resUser.Password)
+		assert.Empty(b, resUser.Password)
 		assert.Equal(b, user.PhoneNumber, resUser.PhoneNumber)
 		assert.Equal(b, user.Email, resUser.Email)
 		assert.Equal(b, user.Address, resUser.Address)
@@ -727,7 +731,7 @@ func BenchmarkNova_HandleCreateUserParallel(b *testing.B) {
 			assert.Equal(b, "application/json", wUser.Header().Get("Content-Type"))
 			assert.Equal(b, user.UserId, resUser.UserId)
 			assert.Equal(b, user.Username, resUser.Username)
-			assert.Equal(b, user.Password, resUser.Password)
+			assert.Empty(b, resUser.Password)
 			assert.Equal(b, user.PhoneNumber, resUser.PhoneNumber)
 			assert.Equal(b, user.Email, resUser.Email)
 			assert.Equal(b, user.Address, resUser.Address)
@@ -745,8 +749,9 @@ func TestNova_HandleDeleteUser(t *testing.T) {
 	// 2. receive CreateUserId response with created userId by using 201 Created Code
 	// 3. send CreateUser request with user information by using POST method
 	// 4. receive CreateUser response with user information by using 201 Created Code
-	// 5. send DeleteUser request with userId by using DELETE method
-	// 6. receive DeleteUser request by using 204 No Content Code
+	// 5. send DeleteUser request without user session, receive 401 Unauthorized Code
+	// 6. send DeleteUser request with userId by using DELETE method
+	// 7. receive DeleteUser request by using 204 No Content Code
 	----------------------------------------------------------------------------------*/
 	// reset test case
 	_ = resetUserTestCase()
@@ -808,7 +813,7 @@ func TestNova_HandleDeleteUser(t *testing.T) {
 	assert.Equal(t, "application/json", wCreateUser.Header().Get("Content-Type"))
 	assert.Equal(t, user.UserId, resUser.UserId)
 	assert.Equal(t, user.Username, resUser.Username)
-	assert.Equal(t, user.Password, resUser.Password)
+	assert.Empty(t, resUser.Password)
 	assert.Equal(t, user.PhoneNumber, resUser.PhoneNumber)
 	assert.Equal(t, user.Email, resUser.Email)
 	assert.Equal(t, user.Address, resUser.Address)
@@ -816,12 +821,15 @@ func TestNova_HandleDeleteUser(t *testing.T) {
 	/* delete user */
 	// request content
 	url = server.URL + "/nova/v1/user"
+	w := serveTestRequest(t, router, http.MethodDelete, url+"/"+resUserId, nil, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
 	// request delete user
 	wDeleteUser := httptest.NewRecorder()
 	reqDeleteUser, err := http.NewRequest(http.MethodDelete, url+"/"+resUserId, nil)
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
+	reqDeleteUser.Header.Set("Authorization", "Bearer "+queryUserTestToken(t, router, server.URL, user))
 	router.ServeHTTP(wDeleteUser, reqDeleteUser)
 	// validate response
 	assert.Equal(t, http.StatusNoContent, wDeleteUser.Code)
@@ -901,7 +909,7 @@ func BenchmarkNova_HandleDeleteUser(b *testing.B) {
 		assert.Equal(b, "application/json", wCreateUser.Header().Get("Content-Type"))
 		assert.Equal(b, user.UserId, resUser.UserId)
 		assert.Equal(b, user.Username, resUser.Username)
-		assert.Equal(b, user.Password, resUser.Password)
+		assert.Empty(b, resUser.Password)
 		assert.Equal(b, user.PhoneNumber, resUser.PhoneNumber)
 		assert.Equal(b, user.Email, resUser.Email)
 		assert.Equal(b, user.Address, resUser.Address)
@@ -915,6 +923,7 @@ func BenchmarkNova_HandleDeleteUser(b *testing.B) {
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
+		reqDeleteUser.Header.Set("Authorization", "Bearer "+queryUserTestToken(b, router, server.URL, user))
 		router.ServeHTTP(wDeleteUser, reqDeleteUser)
 		// validate response
 		assert.Equal(b, http.StatusNoContent, wDeleteUser.Code)
@@ -996,7 +1005,7 @@ func BenchmarkNova_HandleDeleteUserParallel(b *testing.B) {
 			assert.Equal(b, "application/json", wCreateUser.Header().Get("Content-Type"))
 			assert.Equal(b, user.UserId, resUser.UserId)
 			assert.Equal(b, user.Username, resUser.Username)
-			assert.Equal(b, user.Password, resUser.Password)
+			assert.Empty(b, resUser.Password)
 			assert.Equal(b, user.PhoneNumber, resUser.PhoneNumber)
 			assert.Equal(b, user.Email, resUser.Email)
 			assert.Equal(b, user.Address, resUser.Address)
@@ -1010,6 +1019,7 @@ func BenchmarkNova_HandleDeleteUserParallel(b *testing.B) {
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
+			reqDeleteUser.Header.Set("Authorization", "Bearer "+queryUserTestToken(b, router, server.URL, user))
 			router.ServeHTTP(wDeleteUser, reqDeleteUser)
 			// validate response
 			assert.Equal(b, http.StatusNoContent, wDeleteUser.Code)
@@ -1089,7 +1099,7 @@ func TestNova_HandleQueryUserUser(t *testing.T) {
 	assert.Equal(t, "application/json", wCreateUser.Header().Get("Content-Type"))
 	assert.Equal(t, user.UserId, resCreateUser.UserId)
 	assert.Equal(t, user.Username, resCreateUser.Username)
-	assert.Equal(t, user.Password, resCreateUser.Password)
+	assert.Empty(t, resCreateUser.Password)
 	assert.Equal(t, user.PhoneNumber, resCreateUser.PhoneNumber)
 	assert.Equal(t, user.Email, resCreateUser.Email)
 	assert.Equal(t, user.Address, resCreateUser.Address)
@@ -1103,6 +1113,7 @@ func TestNova_HandleQueryUserUser(t *testing.T) {
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
+	reqQueryUser.Header.Set("Authorization", "Bearer "+queryUserTestToken(t, router, server.URL, user))
 	router.ServeHTTP(wQueryUser, reqQueryUser)
 	// return response
 	var resQueryUser User
@@ -1115,7 +1126,7 @@ func TestNova_HandleQueryUserUser(t *testing.T) {
 	assert.Equal(t, "application/json", wQueryUser.Header().Get("Content-Type"))
 	assert.Equal(t, user.UserId, resQueryUser.UserId)
 	assert.Equal(t, user.Username, resQueryUser.Username)
-	assert.Equal(t, user.Password, resQueryUser.Password)
+	assert.Empty(t, resQueryUser.Password)
 	assert.Equal(t, user.PhoneNumber, resQueryUser.PhoneNumber)
 	assert.Equal(t, user.Email, resQueryUser.Email)
 	assert.Equal(t, user.Address, resQueryUser.Address)
@@ -1196,7 +1207,7 @@ func BenchmarkNova_HandleQueryUser(b *testing.B) {
 		assert.Equal(b, "application/json", wCreateUser.Header().Get("Content-Type"))
 		assert.Equal(b, user.UserId, resCreateUser.UserId)
 		assert.Equal(b, user.Username, resCreateUser.Username)
-		assert.Equal(b, user.Password, resCreateUser.Password)
+		assert.Empty(b, resCreateUser.Password)
 		assert.Equal(b, user.PhoneNumber, resCreateUser.PhoneNumber)
 		assert.Equal(b, user.Email, resCreateUser.Email)
 		assert.Equal(b, user.Address, resCreateUser.Address)
@@ -1210,6 +1221,7 @@ func BenchmarkNova_HandleQueryUser(b *testing.B) {
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
+		reqQueryUser.Header.Set("Authorization", "Bearer "+queryUserTestToken(b, router, server.URL, user))
 		router.ServeHTTP(wQueryUser, reqQueryUser)
 		// return response
 		var resQueryUser User
@@ -1222,7 +1234,7 @@ func BenchmarkNova_HandleQueryUser(b *testing.B) {
 		assert.Equal(b, "application/json", wQueryUser.Header().Get("Content-Type"))
 		assert.Equal(b, user.UserId, resQueryUser.UserId)
 		assert.Equal(b, user.Username, resQueryUser.Username)
-		assert.Equal(b, user.Password, resQueryUser.Password)
+		assert.Empty(b, resQueryUser.Password)
 		assert.Equal(b, user.PhoneNumber, resQueryUser.PhoneNumber)
 		assert.Equal(b, user.Email, resQueryUser.Email)
 		assert.Equal(b, user.Address, resQueryUser.Address)
@@ -1305,7 +1317,7 @@ func BenchmarkNova_HandleQueryUserParallel(b *testing.B) {
 			assert.Equal(b, "application/json", wCreateUser.Header().Get("Content-Type"))
 			assert.Equal(b, user.UserId, resCreateUser.UserId)
 			assert.Equal(b, user.Username, resCreateUser.Username)
-			assert.Equal(b, user.Password, resCreateUser.Password)
+			assert.Empty(b, resCreateUser.Password)
 			assert.Equal(b, user.PhoneNumber, resCreateUser.PhoneNumber)
 			assert.Equal(b, user.Email, resCreateUser.Email)
 			assert.Equal(b, user.Address, resCreateUser.Address)
@@ -1319,6 +1331,7 @@ func BenchmarkNova_HandleQueryUserParallel(b *testing.B) {
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
+			reqQueryUser.Header.Set("Authorization", "Bearer "+queryUserTestToken(b, router, server.URL, user))
 			router.ServeHTTP(wQueryUser, reqQueryUser)
 			// return response
 			var resQueryUser User
@@ -1331,7 +1344,7 @@ func BenchmarkNova_HandleQueryUserParallel(b *testing.B) {
 			assert.Equal(b, "application/json", wQueryUser.Header().Get("Content-Type"))
 			assert.Equal(b, user.UserId, resQueryUser.UserId)
 			assert.Equal(b, user.Username, resQueryUser.Username)
-			assert.Equal(b, user.Password, resQueryUser.Password)
+			assert.Empty(b, resQueryUser.Password)
 			assert.Equal(b, user.PhoneNumber, resQueryUser.PhoneNumber)
 			assert.Equal(b, user.Email, resQueryUser.Email)
 			assert.Equal(b, user.Address, resQueryUser.Address)
@@ -1412,7 +1425,7 @@ func TestNova_HandleUpdateUser(t *testing.T) {
 	assert.Equal(t, "application/json", wCreateUser.Header().Get("Content-Type"))
 	assert.Equal(t, user.UserId, resCreateUser.UserId)
 	assert.Equal(t, user.Username, resCreateUser.Username)
-	assert.Equal(t, user.Password, resCreateUser.Password)
+	assert.Empty(t, resCreateUser.Password)
 	assert.Equal(t, user.PhoneNumber, resCreateUser.PhoneNumber)
 	assert.Equal(t, user.Email, resCreateUser.Email)
 	assert.Equal(t, user.Address, resCreateUser.Address)
@@ -1439,6 +1452,7 @@ func TestNova_HandleUpdateUser(t *testing.T) {
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
+	reqUpdateUser.Header.Set("Authorization", "Bearer "+queryUserTestToken(t, router, server.URL, user))
 	router.ServeHTTP(wUpdateUser, reqUpdateUser)
 	// return response
 	var resUpdateUser User
@@ -1451,7 +1465,7 @@ func TestNova_HandleUpdateUser(t *testing.T) {
 	assert.Equal(t, "application/json", wUpdateUser.Header().Get("Content-Type"))
 	assert.Equal(t, userNew.UserId, resUpdateUser.UserId)
 	assert.Equal(t, userNew.Username, resUpdateUser.Username)
-	assert.Equal(t, userNew.Password, resUpdateUser.Password)
+	assert.Empty(t, resUpdateUser.Password)
 	assert.Equal(t, userNew.PhoneNumber, resUpdateUser.PhoneNumber)
 	assert.Equal(t, userNew.Email, resUpdateUser.Email)
 	assert.Equal(t, userNew.Address, resUpdateUser.Address)
@@ -1532,7 +1546,7 @@ func BenchmarkNova_HandleUpdateUser(b *testing.B) {
 		assert.Equal(b, "application/json", wCreateUser.Header().Get("Content-Type"))
 		assert.Equal(b, user.UserId, resCreateUser.UserId)
 		assert.Equal(b, user.Username, resCreateUser.Username)
-		assert.Equal(b, user.Password, resCreateUser.Password)
+		assert.Empty(b, resCreateUser.Password)
 		assert.Equal(b, user.PhoneNumber, resCreateUser.PhoneNumber)
 		assert.Equal(b, user.Email, resCreateUser.Email)
 		assert.Equal(b, user.Address, resCreateUser.Address)
@@ -1559,6 +1573,7 @@ func BenchmarkNova_HandleUpdateUser(b *testing.B) {
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
+		reqUpdateUser.Header.Set("Authorization", "Bearer "+queryUserTestToken(b, router, server.URL, user))
 		router.ServeHTTP(wUpdateUser, reqUpdateUser)
 		// return response
 		var resUpdateUser User
@@ -1571,7 +1586,7 @@ func BenchmarkNova_HandleUpdateUser(b *testing.B) {
 		assert.Equal(b, "application/json", wUpdateUser.Header().Get("Content-Type"))
 		assert.Equal(b, userNew.UserId, resUpdateUser.UserId)
 		assert.Equal(b, userNew.Username, resUpdateUser.Username)
-		assert.Equal(b, userNew.Password, resUpdateUser.Password)
+		assert.Empty(b, resUpdateUser.Password)
 		assert.Equal(b, userNew.PhoneNumber, resUpdateUser.PhoneNumber)
 		assert.Equal(b, userNew.Email, resUpdateUser.Email)
 		assert.Equal(b, userNew.Address, resUpdateUser.Address)
@@ -1654,7 +1669,7 @@ func BenchmarkNova_HandleUpdateUserParallel(b *testing.B) {
 			assert.Equal(b, "application/json", wCreateUser.Header().Get("Content-Type"))
 			assert.Equal(b, user.UserId, resCreateUser.UserId)
 			assert.Equal(b, user.Username, resCreateUser.Username)
-			assert.Equal(b, user.Password, resCreateUser.Password)
+			assert.Empty(b, resCreateUser.Password)
 			assert.Equal(b, user.PhoneNumber, resCreateUser.PhoneNumber)
 			assert.Equal(b, user.Email, resCreateUser.Email)
 			assert.Equal(b, user.Address, resCreateUser.Address)
@@ -1681,6 +1696,7 @@ func BenchmarkNova_HandleUpdateUserParallel(b *testing.B) {
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
+			reqUpdateUser.Header.Set("Authorization", "Bearer "+queryUserTestToken(b, router, server.URL, user))
 			router.ServeHTTP(wUpdateUser, reqUpdateUser)
 			// return response
 			var resUpdateUser User
@@ -1693,7 +1709,7 @@ func BenchmarkNova_HandleUpdateUserParallel(b *testing.B) {
 			assert.Equal(b, "application/json", wUpdateUser.Header().Get("Content-Type"))
 			assert.Equal(b, userNew.UserId, resUpdateUser.UserId)
 			assert.Equal(b, userNew.Username, resUpdateUser.Username)
-			assert.Equal(b, userNew.Password, resUpdateUser.Password)
+			assert.Empty(b, resUpdateUser.Password)
 			assert.Equal(b, userNew.PhoneNumber, resUpdateUser.PhoneNumber)
 			assert.Equal(b, userNew.Email, resUpdateUser.Email)
 			assert.Equal(b, userNew.Address, resUpdateUser.Address)
@@ -1774,7 +1790,7 @@ func TestNova_HandleModifyUser(t *testing.T) {
 	assert.Equal(t, "application/json", wCreateUser.Header().Get("Content-Type"))
 	assert.Equal(t, user.UserId, resCreateUser.UserId)
 	assert.Equal(t, user.Username, resCreateUser.Username)
-	assert.Equal(t, user.Password, resCreateUser.Password)
+	assert.Empty(t, resCreateUser.Password)
 	assert.Equal(t, user.PhoneNumber, resCreateUser.PhoneNumber)
 	assert.Equal(t, user.Email, resCreateUser.Email)
 	assert.Equal(t, user.Address, resCreateUser.Address)
@@ -1799,6 +1815,7 @@ func TestNova_HandleModifyUser(t *testing.T) {
 	if err != nil {
 		t.Errorf("error creating request: %v", err)
 	}
+	reqModifyUser.Header.Set("Authorization", "Bearer "+queryUserTestToken(t, router, server.URL, user))
 	router.ServeHTTP(wModifyUser, reqModifyUser)
 	// return response
 	var resModifyUser User
@@ -1811,7 +1828,7 @@ func TestNova_HandleModifyUser(t *testing.T) {
 	assert.Equal(t, "application/json", wModifyUser.Header().Get("Content-Type"))
 	assert.Equal(t, userNew.UserId, resModifyUser.UserId)
 	assert.Equal(t, userNew.Username, resModifyUser.Username)
-	assert.Equal(t, userNew.Password, resModifyUser.Password)
+	assert.Empty(t, resModifyUser.Password)
 	assert.Equal(t, userNew.PhoneNumber, resModifyUser.PhoneNumber)
 	assert.Equal(t, user.Email, resModifyUser.Email)
 	assert.Equal(t, user.Address, resModifyUser.Address)
@@ -1892,7 +1909,7 @@ func BenchmarkNova_HandleModifyUser(b *testing.B) {
 		assert.Equal(b, "application/json", wCreateUser.Header().Get("Content-Type"))
 		assert.Equal(b, user.UserId, resCreateUser.UserId)
 		assert.Equal(b, user.Username, resCreateUser.Username)
-		assert.Equal(b, user.Password, resCreateUser.Password)
+		assert.Empty(b, resCreateUser.Password)
 		assert.Equal(b, user.PhoneNumber, resCreateUser.PhoneNumber)
 		assert.Equal(b, user.Email, resCreateUser.Email)
 		assert.Equal(b, user.Address, resCreateUser.Address)
@@ -1917,6 +1934,7 @@ func BenchmarkNova_HandleModifyUser(b *testing.B) {
 		if err != nil {
 			b.Errorf("error creating request: %v", err)
 		}
+		reqModifyUser.Header.Set("Authorization", "Bearer "+queryUserTestToken(b, router, server.URL, user))
 		router.ServeHTTP(wModifyUser, reqModifyUser)
 		// return response
 		var resModifyUser User
@@ -1929,7 +1947,7 @@ func BenchmarkNova_HandleModifyUser(b *testing.B) {
 		assert.Equal(b, "application/json", wModifyUser.Header().Get("Content-Type"))
 		assert.Equal(b, userNew.UserId, resModifyUser.UserId)
 		assert.Equal(b, userNew.Username, resModifyUser.Username)
-		assert.Equal(b, userNew.Password, resModifyUser.Password)
+		assert.Empty(b, resModifyUser.Password)
 		assert.Equal(b, userNew.PhoneNumber, resModifyUser.PhoneNumber)
 		assert.Equal(b, user.Email, resModifyUser.Email)
 		assert.Equal(b, user.Address, resModifyUser.Address)
@@ -2012,7 +2030,7 @@ func BenchmarkNova_HandleModifyUserParallel(b *testing.B) {
 			assert.Equal(b, "application/json", wCreateUser.Header().Get("Content-Type"))
 			assert.Equal(b, user.UserId, resCreateUser.UserId)
 			assert.Equal(b, user.Username, resCreateUser.Username)
-			assert.Equal(b, user.Password, resCreateUser.Password)
+			assert.Empty(b, resCreateUser.Password)
 			assert.Equal(b, user.PhoneNumber, resCreateUser.PhoneNumber)
 			assert.Equal(b, user.Email, resCreateUser.Email)
 			assert.Equal(b, user.Address, resCreateUser.Address)
@@ -2037,6 +2055,7 @@ func BenchmarkNova_HandleModifyUserParallel(b *testing.B) {
 			if err != nil {
 				b.Errorf("error creating request: %v", err)
 			}
+			reqModifyUser.Header.Set("Authorization", "Bearer "+queryUserTestToken(b, router, server.URL, user))
 			router.ServeHTTP(wModifyUser, reqModifyUser)
 			// return response
 			var resModifyUser User
@@ -2049,7 +2068,7 @@ func BenchmarkNova_HandleModifyUserParallel(b *testing.B) {
 			assert.Equal(b, "application/json", wModifyUser.Header().Get("Content-Type"))
 			assert.Equal(b, userNew.UserId, resModifyUser.UserId)
 			assert.Equal(b, userNew.Username, resModifyUser.Username)
-			assert.Equal(b, userNew.Password, resModifyUser.Password)
+			assert.Empty(b, resModifyUser.Password)
 			assert.Equal(b, userNew.PhoneNumber, resModifyUser.PhoneNumber)
 			assert.Equal(b, user.Email, resModifyUser.Email)
 			assert.Equal(b, user.Address, resModifyUser.Address)
@@ -2057,3 +2076,50 @@ func BenchmarkNova_HandleModifyUserParallel(b *testing.B) {
 		}
 	})
 }
+
+func TestNova_HandleCreateUserLogin(t *testing.T) {
+	/*--------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateUserLogin
+	// Test Purpose: Test HandleCreateUserLogin login user
+	// Test Steps:
+	// 1. send CreateUser request with user information by using POST method
+	// 2. send CreateUserLogin request with wrong password by using POST method
+	// 3. receive CreateUserLogin response by using 401 Unauthorized Code
+	// 4. send CreateUserLogin request with user credential by using POST method
+	// 5. receive CreateUserLogin response with user session by using 200 OK Code
+	----------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetUserTestCase()
+	// start http test service
+	server, router := startUserTestService()
+	defer server.Close()
+	/* create user */
+	user := User{
+		UserId:      uuid.New().String(),
+		Username:    RandomAlphabet(8),
+		Password:    RandomAlphabetAndNumber(8),
+		PhoneNumber: RandomNumber(11),
+	}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/"+user.UserId, user, "")
+	assert.Equal(t, http.StatusCreated, w.Code)
+	/* login user with wrong password */
+	login := UserLogin{
+		UserId:   user.UserId,
+		Username: user.Username,
+		Password: user.Password + "x",
+	}
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/login/"+user.UserId, login, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	/* login user */
+	login.Password = user.Password
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/login/"+user.UserId, login, "")
+	// return response
+	var session UserSession
+	unmarshalTestResponse(t, w, &session)
+	// validate response
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, user.UserId, session.UserId)
+	assert.NotEmpty(t, session.Token)
+	assert.True(t, session.ExpiresAt.After(session.CreatedAt))
+}
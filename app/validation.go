@@ -0,0 +1,496 @@
+package app
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	questionTitleMaxLength  = 1024
+	questionAnswerMaxLength = 512
+	questionEssayMaxLength  = 10000
+	questionAnswersMin      = 2
+	questionAnswersMax      = 26
+	questionBlanksMax       = 20
+	questionVariablesMax    = 20
+	questionPrecisionMax    = 10
+	templateSampleSeeds     = 32
+	questionTestCasesMax    = 50
+	questionTimeLimitMax    = 10000
+	questionMemoryLimitMax  = 2048
+	questionHintsMax        = 10
+)
+
+// blankPlaceholderPattern matches numbered blanks like {{1}} in fill-blank title
+var blankPlaceholderPattern = regexp.MustCompile(`\{\{\s*(\d+)\s*\}\}`)
+
+// variableNamePattern matches names of template variables usable in formula
+var variableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// FieldErrors collects every violation of a request, it is reported in ProblemDetails
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	reasons := make([]string, 0, len(e))
+	for _, v := range e {
+		reasons = append(reasons, v.Field+": "+v.Reason)
+	}
+	return strings.Join(reasons, "; ")
+}
+
+type questionValidator struct {
+	errors FieldErrors
+}
+
+func (v *questionValidator) add(field string, format string, args ...any) {
+	v.errors = append(v.errors, FieldError{Field: field, Reason: fmt.Sprintf(format, args...)})
+}
+
+func (v *questionValidator) result() (bool, error) {
+	if len(v.errors) > 0 {
+		return false, v.errors
+	}
+	return true, nil
+}
+
+func (v *questionValidator) checkId(id string) {
+	// check question identity format is UUID
+	if err := uuid.Validate(id); err != nil {
+		v.add("id", "should be UUID: %v", err)
+	}
+}
+
+func (v *questionValidator) checkText(field string, text string, maxLength int) {
+	// check text is non-blank & within size limit
+	if strings.TrimSpace(text) == "" {
+		v.add(field, "should not be blank")
+	} else if n := utf8.RuneCountInString(text); n > maxLength {
+		v.add(field, "should be at most %v characters, got %v", maxLength, n)
+	}
+}
+
+func (v *questionValidator) checkAnswers(answers []QuestionAnswer) map[string]string {
+	// check answer count
+	if len(answers) < questionAnswersMin || len(answers) > questionAnswersMax {
+		v.add("answers", "should have %v to %v answers, got %v", questionAnswersMin, questionAnswersMax, len(answers))
+	}
+	// check answer marks are unique & texts are non-blank
+	marks := make(map[string]string, len(answers))
+	for k, answer := range answers {
+		field := fmt.Sprintf("answers[%v]", k)
+		v.checkText(field+".answerMark", answer.AnswerMark, questionAnswerMaxLength)
+		v.checkText(field+".answerText", answer.AnswerText, questionAnswerMaxLength)
+		mark := normalizeAnswerMark(answer.AnswerMark)
+		if mark == "" {
+			continue
+		}
+		if _, ok := marks[mark]; ok {
+			v.add(field+".answerMark", "duplicates answer mark %v", answer.AnswerMark)
+			continue
+		}
+		marks[mark] = answer.AnswerText
+	}
+	return marks
+}
+
+func (v *questionValidator) checkStandardAnswer(field string, answer QuestionAnswer, marks map[string]string) {
+	// standard answer should be one of answers
+	text, ok := marks[normalizeAnswerMark(answer.AnswerMark)]
+	if !ok {
+		v.add(field+".answerMark", "answer mark %v is not one of answers", answer.AnswerMark)
+		return
+	}
+	if text != answer.AnswerText {
+		v.add(field+".answerText", "should match text of answer %v", answer.AnswerMark)
+	}
+}
+
+func normalizeAnswerMark(mark string) string {
+	return strings.ToUpper(strings.TrimSpace(mark))
+}
+
+func validateSingleChoiceQuestion(question QuestionSingleChoice) (bool, error) {
+	v := &questionValidator{}
+	v.checkId(question.Id)
+	v.checkText("title", question.Title, questionTitleMaxLength)
+	marks := v.checkAnswers(question.Answers)
+	v.checkStandardAnswer("standard_answer", question.StandardAnswer, marks)
+	return v.result()
+}
+
+func validateMultipleChoiceQuestion(question QuestionMultipleChoice) (bool, error) {
+	v := &questionValidator{}
+	v.checkId(question.Id)
+	v.checkText("title", question.Title, questionTitleMaxLength)
+	marks := v.checkAnswers(question.Answers)
+	// standard answers should be a non-empty subset of answers
+	if len(question.StandardAnswers) == 0 {
+		v.add("standard_answers", "should have at least one answer")
+	}
+	chosen := make(map[string]bool, len(question.StandardAnswers))
+	for k, answer := range question.StandardAnswers {
+		field := fmt.Sprintf("standard_answers[%v]", k)
+		mark := normalizeAnswerMark(answer.AnswerMark)
+		if chosen[mark] {
+			v.add(field+".answerMark", "duplicates standard answer mark %v", answer.AnswerMark)
+			continue
+		}
+		chosen[mark] = true
+		v.checkStandardAnswer(field, answer, marks)
+	}
+	return v.result()
+}
+
+func validateJudgementQuestion(question QuestionJudgement) (bool, error) {
+	v := &questionValidator{}
+	v.checkId(question.Id)
+	v.checkText("title", question.Title, questionTitleMaxLength)
+	return v.result()
+}
+
+func validateEssayQuestion(question QuestionEssay) (bool, error) {
+	v := &questionValidator{}
+	v.checkId(question.Id)
+	v.checkText("title", question.Title, questionTitleMaxLength)
+	v.checkText("standard_answer", question.StandardAnswer, questionEssayMaxLength)
+	if n := utf8.RuneCountInString(question.Answer); n > questionEssayMaxLength {
+		v.add("answer", "should be at most %v characters, got %v", questionEssayMaxLength, n)
+	}
+	return v.result()
+}
+
+func validateFillBlankQuestion(question QuestionFillBlank) (bool, error) {
+	v := &questionValidator{}
+	v.checkId(question.Id)
+	v.checkText("title", question.Title, questionTitleMaxLength)
+	// every blank is referenced by exactly one placeholder in title
+	if len(question.Blanks) < 1 || len(question.Blanks) > questionBlanksMax {
+		v.add("blanks", "should have 1 to %v blanks, got %v", questionBlanksMax, len(question.Blanks))
+	}
+	placeholders := make(map[int]int)
+	var numbers []int
+	for _, m := range blankPlaceholderPattern.FindAllStringSubmatch(question.Title, -1) {
+		n, _ := strconv.Atoi(m[1])
+		if placeholders[n] == 0 {
+			numbers = append(numbers, n)
+		}
+		placeholders[n]++
+	}
+	sort.Ints(numbers)
+	for _, n := range numbers {
+		if n < 1 || n > len(question.Blanks) {
+			v.add("title", "placeholder {{%v}} has no blank", n)
+		} else if placeholders[n] > 1 {
+			v.add("title", "placeholder {{%v}} appears %v times", n, placeholders[n])
+		}
+	}
+	for k := range question.Blanks {
+		if placeholders[k+1] == 0 {
+			v.add("title", "placeholder {{%v}} is missing", k+1)
+		}
+	}
+	// check matching options of every blank
+	for k, blank := range question.Blanks {
+		v.checkBlank(fmt.Sprintf("blanks[%v]", k), blank)
+	}
+	return v.result()
+}
+
+func validateMatchingQuestion(question QuestionMatching) (bool, error) {
+	v := &questionValidator{}
+	v.checkId(question.Id)
+	v.checkText("title", question.Title, questionTitleMaxLength)
+	v.checkScoring(question.Scoring)
+	// left items are unique so that every one has a single match
+	if len(question.Pairs) < questionAnswersMin || len(question.Pairs) > questionAnswersMax {
+		v.add("pairs", "should have %v to %v pairs, got %v", questionAnswersMin, questionAnswersMax, len(question.Pairs))
+	}
+	lefts := make(map[string]bool, len(question.Pairs))
+	for k, pair := range question.Pairs {
+		field := fmt.Sprintf("pairs[%v]", k)
+		v.checkText(field+".left", pair.Left, questionAnswerMaxLength)
+		v.checkText(field+".right", pair.Right, questionAnswerMaxLength)
+		if lefts[pair.Left] {
+			v.add(field+".left", "duplicates left item %v", pair.Left)
+		}
+		lefts[pair.Left] = true
+	}
+	return v.result()
+}
+
+func validateOrderingQuestion(question QuestionOrdering) (bool, error) {
+	v := &questionValidator{}
+	v.checkId(question.Id)
+	v.checkText("title", question.Title, questionTitleMaxLength)
+	v.checkScoring(question.Scoring)
+	// items are unique so that correct order is unambiguous
+	if len(question.Items) < questionAnswersMin || len(question.Items) > questionAnswersMax {
+		v.add("items", "should have %v to %v items, got %v", questionAnswersMin, questionAnswersMax, len(question.Items))
+	}
+	items := make(map[string]bool, len(question.Items))
+	for k, item := range question.Items {
+		field := fmt.Sprintf("items[%v]", k)
+		v.checkText(field, item, questionAnswerMaxLength)
+		if items[item] {
+			v.add(field, "duplicates item %v", item)
+		}
+		items[item] = true
+	}
+	return v.result()
+}
+
+func validateNumericQuestion(question QuestionNumeric) (bool, error) {
+	v := &questionValidator{}
+	v.checkId(question.Id)
+	v.checkText("title", question.Title, questionTitleMaxLength)
+	if math.IsNaN(question.Value) || math.IsInf(question.Value, 0) {
+		v.add("value", "should be finite number")
+	}
+	if question.AbsoluteTolerance < 0 {
+		v.add("absolute_tolerance", "should not be negative")
+	}
+	if question.RelativeTolerance < 0 || question.RelativeTolerance >= 1 {
+		v.add("relative_tolerance", "should be fraction in [0, 1)")
+	}
+	// accepted units convert into unit of value
+	if len(question.Units) > 0 && strings.TrimSpace(question.Unit) == "" {
+		v.add("unit", "should not be blank when units are accepted")
+	}
+	symbols := map[string]bool{question.Unit: true}
+	for k, unit := range question.Units {
+		field := fmt.Sprintf("units[%v]", k)
+		v.checkText(field+".symbol", unit.Symbol, questionAnswerMaxLength)
+		if symbols[unit.Symbol] {
+			v.add(field+".symbol", "duplicates unit %v", unit.Symbol)
+		}
+		symbols[unit.Symbol] = true
+		if unit.Factor <= 0 || math.IsInf(unit.Factor, 0) {
+			v.add(field+".factor", "should be positive number")
+		}
+	}
+	return v.result()
+}
+
+func validateTemplateQuestion(question QuestionTemplate) (bool, error) {
+	v := &questionValidator{}
+	v.checkId(question.Id)
+	v.checkText("title", question.Title, questionTitleMaxLength)
+	if question.Precision < 0 || question.Precision > questionPrecisionMax {
+		v.add("precision", "should be 0 to %v decimal places", questionPrecisionMax)
+	}
+	if question.AbsoluteTolerance < 0 {
+		v.add("absolute_tolerance", "should not be negative")
+	}
+	if question.RelativeTolerance < 0 || question.RelativeTolerance >= 1 {
+		v.add("relative_tolerance", "should be fraction in [0, 1)")
+	}
+	// variable names are unique & do not shadow formula functions or constants
+	if len(question.Variables) < 1 || len(question.Variables) > questionVariablesMax {
+		v.add("variables", "should have 1 to %v variables, got %v", questionVariablesMax, len(question.Variables))
+	}
+	names := make(map[string]bool, len(question.Variables))
+	for k, variable := range question.Variables {
+		field := fmt.Sprintf("variables[%v]", k)
+		_, isFunction := formulaFunctions[variable.Name]
+		_, isConstant := formulaConstants[variable.Name]
+		switch {
+		case !variableNamePattern.MatchString(variable.Name):
+			v.add(field+".name", "should be letters, digits or underscores not starting with digit")
+		case isFunction || isConstant:
+			v.add(field+".name", "%v is reserved in formula", variable.Name)
+		case names[variable.Name]:
+			v.add(field+".name", "duplicates variable %v", variable.Name)
+		}
+		names[variable.Name] = true
+		v.checkVariable(field, variable)
+	}
+	// every placeholder in title refers to variable
+	for _, m := range templatePlaceholderPattern.FindAllStringSubmatch(question.Title, -1) {
+		if !names[m[1]] {
+			v.add("title", "placeholder {{%v}} has no variable", m[1])
+		}
+	}
+	// formula parses & only refers to variables
+	_, variables, err := parseFormula(question.Formula)
+	if err != nil {
+		v.add("formula", "should be arithmetic formula: %v", err)
+		return v.result()
+	}
+	for _, name := range variables {
+		if !names[name] {
+			v.add("formula", "variable %v is not defined", name)
+		}
+	}
+	if len(v.errors) > 0 {
+		return v.result()
+	}
+	// formula evaluates to finite answer for sample of instances
+	for seed := int64(0); seed < templateSampleSeeds; seed++ {
+		if _, err := newQuestionTemplateInstance(question, seed); err != nil {
+			v.add("formula", "should be finite number for every instance, not for seed %v", seed)
+			break
+		}
+	}
+	return v.result()
+}
+
+func validateCodeQuestion(question QuestionCode) (bool, error) {
+	v := &questionValidator{}
+	v.checkId(question.Id)
+	v.checkText("title", question.Title, questionEssayMaxLength)
+	v.checkScoring(question.Scoring)
+	switch question.Language {
+	case CodeLanguageGo, CodeLanguagePython:
+	default:
+		v.add("language", "language %v not supported", question.Language)
+	}
+	if n := utf8.RuneCountInString(question.StarterCode); n > codeSourceMaxLength {
+		v.add("starter_code", "should be at most %v characters, got %v", codeSourceMaxLength, n)
+	}
+	if question.TimeLimit < 0 || question.TimeLimit > questionTimeLimitMax {
+		v.add("time_limit", "should be 0 to %v milliseconds", questionTimeLimitMax)
+	}
+	if question.MemoryLimit < 0 || question.MemoryLimit > questionMemoryLimitMax {
+		v.add("memory_limit", "should be 0 to %v megabytes", questionMemoryLimitMax)
+	}
+	// test case names are unique so that results can be told apart
+	if len(question.TestCases) < 1 || len(question.TestCases) > questionTestCasesMax {
+		v.add("test_cases", "should have 1 to %v test cases, got %v", questionTestCasesMax, len(question.TestCases))
+	}
+	names := make(map[string]bool, len(question.TestCases))
+	for k, test := range question.TestCases {
+		field := fmt.Sprintf("test_cases[%v]", k)
+		v.checkText(field+".name", test.Name, questionAnswerMaxLength)
+		if names[test.Name] {
+			v.add(field+".name", "duplicates test case %v", test.Name)
+		}
+		names[test.Name] = true
+		if len(test.Input) > sandboxOutputLimit {
+			v.add(field+".input", "should be at most %v bytes", sandboxOutputLimit)
+		}
+		if len(test.Output) > sandboxOutputLimit {
+			v.add(field+".output", "should be at most %v bytes", sandboxOutputLimit)
+		}
+	}
+	return v.result()
+}
+
+func validateQuestionGuidance(guidance QuestionGuidance, options map[string]bool) (bool, error) {
+	v := &questionValidator{}
+	if n := utf8.RuneCountInString(guidance.Explanation); n > questionEssayMaxLength {
+		v.add("explanation", "should be at most %v characters, got %v", questionEssayMaxLength, n)
+	}
+	// hints are revealed in order, their penalties add up to at most the whole score
+	if len(guidance.Hints) > questionHintsMax {
+		v.add("hints", "should have at most %v hints, got %v", questionHintsMax, len(guidance.Hints))
+	}
+	penalty := 0.0
+	for k, hint := range guidance.Hints {
+		field := fmt.Sprintf("hints[%v]", k)
+		v.checkText(field+".text", hint.Text, questionTitleMaxLength)
+		if hint.Penalty < 0 || hint.Penalty > 1 {
+			v.add(field+".penalty", "should be fraction of score in [0, 1]")
+		}
+		penalty += hint.Penalty
+	}
+	if penalty > 1+1e-9 {
+		v.add("hints", "penalties should add up to at most 1, got %v", penalty)
+	}
+	// feedback refers to options of question
+	if options == nil && len(guidance.Feedback) > 0 {
+		v.add("feedback", "per-option feedback is not supported by question type")
+		return v.result()
+	}
+	keys := make([]string, 0, len(guidance.Feedback))
+	for k := range guidance.Feedback {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		field := fmt.Sprintf("feedback[%v]", k)
+		if !options[k] {
+			v.add(field, "option %v is not one of answers", k)
+		}
+		v.checkText(field, guidance.Feedback[k], questionAnswerMaxLength)
+	}
+	return v.result()
+}
+
+func validateQuestionContent(format string, fields []QuestionRenderedField) (bool, error) {
+	// markdown text should have closed math delimiters & balanced LaTeX
+	v := &questionValidator{}
+	for _, field := range fields {
+		_, problems := renderQuestionContent(format, field.Source)
+		for _, problem := range problems {
+			v.add(field.Field, "%v", problem)
+		}
+	}
+	return v.result()
+}
+
+func (v *questionValidator) checkVariable(field string, variable QuestionVariable) {
+	// variable draws from value list or from range
+	for k, value := range variable.Values {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			v.add(fmt.Sprintf("%v.values[%v]", field, k), "should be finite number")
+		}
+	}
+	if len(variable.Values) > 0 {
+		return
+	}
+	if math.IsNaN(variable.Min) || math.IsInf(variable.Min, 0) || math.IsNaN(variable.Max) || math.IsInf(variable.Max, 0) {
+		v.add(field, "should have finite min & max")
+	} else if variable.Min > variable.Max {
+		v.add(field+".max", "should not be less than min")
+	}
+	if variable.Step < 0 || math.IsInf(variable.Step, 0) {
+		v.add(field+".step", "should be positive number")
+	}
+}
+
+func (v *questionValidator) checkScoring(scoring string) {
+	// empty scoring is all-or-nothing
+	switch scoring {
+	case "", ScoringAllOrNothing, ScoringPartial:
+	default:
+		v.add("scoring", "scoring %v not supported", scoring)
+	}
+}
+
+func (v *questionValidator) checkBlank(field string, blank QuestionBlank) {
+	// check match option
+	switch blank.Match {
+	case "", BlankMatchText, BlankMatchRegex, BlankMatchNumeric:
+	default:
+		v.add(field+".match", "match %v not supported", blank.Match)
+	}
+	if blank.Tolerance < 0 {
+		v.add(field+".tolerance", "should not be negative")
+	} else if blank.Tolerance > 0 && blank.Match != BlankMatchNumeric {
+		v.add(field+".tolerance", "only applies to numeric match")
+	}
+	// check accepted answers
+	if len(blank.Answers) == 0 {
+		v.add(field+".answers", "should have at least one answer")
+	}
+	for k, answer := range blank.Answers {
+		answerField := fmt.Sprintf("%v.answers[%v]", field, k)
+		v.checkText(answerField, answer, questionAnswerMaxLength)
+		switch blank.Match {
+		case BlankMatchRegex:
+			if _, err := compileBlankPattern(blank, answer); err != nil {
+				v.add(answerField, "should be regular expression: %v", err)
+			}
+		case BlankMatchNumeric:
+			if _, err := strconv.ParseFloat(strings.TrimSpace(answer), 64); err != nil {
+				v.add(answerField, "should be number")
+			}
+		}
+	}
+}
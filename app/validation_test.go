@@ -0,0 +1,145 @@
+package app
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNova_HandleCreateQuestionSingleChoiceValidation(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateQuestionSingleChoiceValidation
+	// Test Purpose: Test HandleCreateQuestionSingleChoice reports all content violations
+	// Test Steps:
+	// 1. send CreateQuestion request with blank title, duplicated answer marks & unknown standard answer
+	// 2. receive CreateQuestion response with field errors by using 400 Bad Request Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startQuestionTestService()
+	defer server.Close()
+	/* create invalid single-choice question */
+	question := QuestionSingleChoice{
+		Id:    uuid.New().String(),
+		Title: " ",
+		Answers: []QuestionAnswer{
+			{"A", "apple"},
+			{"a", "watermelon"},
+		},
+		StandardAnswer: QuestionAnswer{"C", "orange"},
+	}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/single-choice/"+question.Id, question, testAuthorToken)
+	// return response
+	var problemDetails ProblemDetails
+	unmarshalTestResponse(t, w, &problemDetails)
+	// validate response
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	assert.Equal(t, []FieldError{
+		{Field: "title", Reason: "should not be blank"},
+		{Field: "answers[1].answerMark", Reason: "duplicates answer mark a"},
+		{Field: "standard_answer.answerMark", Reason: "answer mark C is not one of answers"},
+	}, problemDetails.Errors)
+}
+
+func TestNova_HandleCreateQuestionMultipleChoiceValidation(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateQuestionMultipleChoiceValidation
+	// Test Purpose: Test HandleCreateQuestionMultipleChoice checks standard answers are subset of answers
+	// Test Steps:
+	// 1. send CreateQuestion request with empty standard answers by using POST method
+	// 2. receive CreateQuestion response with field errors by using 400 Bad Request Code
+	// 3. send CreateQuestion request with mismatched standard answer text & oversize title
+	// 4. receive CreateQuestion response with field errors by using 400 Bad Request Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startQuestionTestService()
+	defer server.Close()
+	/* create multiple-choice question without standard answers */
+	question := QuestionMultipleChoice{
+		Id:    uuid.New().String(),
+		Title: "Which of these are fruits?",
+		Answers: []QuestionAnswer{
+			{"A", "apple"},
+			{"B", "carrot"},
+			{"C", "peach"},
+		},
+		StandardAnswers: []QuestionAnswer{},
+	}
+	url := server.URL + "/nova/v1/question/multiple-choice/" + question.Id
+	w := serveTestRequest(t, router, http.MethodPost, url, question, testAuthorToken)
+	// return response
+	var problemDetails ProblemDetails
+	unmarshalTestResponse(t, w, &problemDetails)
+	// validate response
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, []FieldError{{Field: "standard_answers", Reason: "should have at least one answer"}}, problemDetails.Errors)
+	/* create multiple-choice question with mismatched standard answers */
+	question.Title = strings.Repeat("x", questionTitleMaxLength+1)
+	question.StandardAnswers = []QuestionAnswer{{"A", "apple"}, {"C", "plum"}}
+	w = serveTestRequest(t, router, http.MethodPost, url, question, testAuthorToken)
+	// return response
+	problemDetails = ProblemDetails{}
+	unmarshalTestResponse(t, w, &problemDetails)
+	// validate response
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, []FieldError{
+		{Field: "title", Reason: "should be at most 1024 characters, got 1025"},
+		{Field: "standard_answers[1].answerText", Reason: "should match text of answer C"},
+	}, problemDetails.Errors)
+}
+
+func TestNova_HandleUpdateQuestionSingleChoiceValidation(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleUpdateQuestionSingleChoiceValidation
+	// Test Purpose: Test HandleUpdateQuestionSingleChoice reports content violations like create
+	// Test Steps:
+	// 1. send CreateQuestion request with valid single-choice question
+	// 2. send UpdateQuestion request with blank title & unknown standard answer
+	// 3. receive UpdateQuestion response with field errors by using 400 Bad Request Code
+	// 4. send QueryQuestion request, receive unchanged question by using 200 OK Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startQuestionTestService()
+	defer server.Close()
+	/* create valid single-choice question */
+	question := QuestionSingleChoice{
+		Id:    uuid.New().String(),
+		Title: "Which of these is a fruit?",
+		Answers: []QuestionAnswer{
+			{"A", "apple"},
+			{"B", "carrot"},
+		},
+		StandardAnswer: QuestionAnswer{"A", "apple"},
+	}
+	url := server.URL + "/nova/v1/question/single-choice/" + question.Id
+	w := serveTestRequest(t, router, http.MethodPost, url, question, testAuthorToken)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	/* replace single-choice question with invalid content */
+	invalid := question
+	invalid.Title = " "
+	invalid.StandardAnswer = QuestionAnswer{"C", "orange"}
+	w = serveTestRequest(t, router, http.MethodPut, url, invalid, testAuthorToken)
+	// return response
+	var problemDetails ProblemDetails
+	unmarshalTestResponse(t, w, &problemDetails)
+	// validate response
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, []FieldError{
+		{Field: "title", Reason: "should not be blank"},
+		{Field: "standard_answer.answerMark", Reason: "answer mark C is not one of answers"},
+	}, problemDetails.Errors)
+	/* question is unchanged */
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, testAuthorToken)
+	var stored QuestionSingleChoice
+	unmarshalTestResponse(t, w, &stored)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, question, stored)
+}
@@ -0,0 +1,425 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"nova/logger"
+	"sort"
+	"strings"
+	"time"
+)
+
+// questionTransitions lists roles allowed to move a question from one state to another
+var questionTransitions = map[string]map[string][]string{
+	QuestionStateDraft: {
+		QuestionStateInReview: {RoleAuthor, RoleAdmin},
+		QuestionStateArchived: {RoleAuthor, RoleAdmin},
+	},
+	QuestionStateInReview: {
+		QuestionStateDraft:     {RoleReviewer, RoleAdmin},
+		QuestionStatePublished: {RoleReviewer, RoleAdmin},
+	},
+	QuestionStatePublished: {
+		QuestionStateDraft:    {RoleAdmin},
+		QuestionStateArchived: {RoleReviewer, RoleAdmin},
+	},
+	QuestionStateArchived: {
+		QuestionStateDraft: {RoleAuthor, RoleAdmin},
+	},
+}
+
+func (nova *Nova) HandleQueryQuestionWorkflows(c *gin.Context) {
+	// query question workflows
+	logger.Infof("handle request query question workflows")
+	// extract state from query
+	state := strings.ToLower(c.Query("state"))
+	logger.Debugf("check question state is validate")
+	if state != "" {
+		if b, err := nova.isQuestionStateValidate(state); !b {
+			nova.response400BadRequest(c, err)
+			logger.Errorf("error check question state is validate: %v", err)
+			return
+		}
+	}
+	logger.Debugf("successfully check question state is validate")
+	// update data cache by querying questions & workflows in database
+	logger.Debugf("update data cache by querying questions & workflows in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	if err := nova.queryQuestionWorkflowsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying question workflows in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions & workflows in database")
+	// filter question workflows by state
+	logger.Debugf("query question workflows in data cache")
+	response := make([]QuestionWorkflow, 0)
+	for _, source := range nova.queryQuestionFingerprintSources(nova.queryTenantScope(c)) {
+		workflow := nova.queryQuestionWorkflow(source.Type, source.Id)
+		if state == "" || workflow.State == state {
+			response = append(response, workflow)
+		}
+	}
+	logger.Debugf("successfully query question workflows in data cache")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, len(response))
+	return
+}
+
+func (nova *Nova) HandleQueryQuestionWorkflow(c *gin.Context) {
+	// query question workflow
+	logger.Infof("handle request query question workflow")
+	// extract question type & Id from uri
+	questionType := strings.ToLower(c.Param("type"))
+	id := strings.ToLower(c.Param("Id"))
+	// request question type & Id correctness
+	logger.Debugf("check question type & Id is validate")
+	if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question type & Id is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question type & Id is validate")
+	// update data cache by querying questions & workflows in database
+	logger.Debugf("update data cache by querying questions & workflows in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	if err := nova.queryQuestionWorkflowsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying question workflows in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions & workflows in database")
+	// check question existence
+	logger.Debugf("check question is existed")
+	if !nova.isQuestionExisted(questionType, id) {
+		nova.response404NotFound(c, fmt.Errorf("%v question not found", questionType))
+		logger.Errorf("error check question is existed")
+		return
+	}
+	logger.Debugf("successfully check question is existed")
+	// query question reviews from database
+	logger.Debugf("query question reviews in database")
+	response := nova.queryQuestionWorkflow(questionType, id)
+	reviews, err := nova.db.QueryQuestionReviews(questionType, id)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error query question reviews in database: %v", err)
+		return
+	}
+	for _, v := range reviews {
+		response.Reviews = append(response.Reviews, *v)
+	}
+	logger.Debugf("successfully query question reviews in database")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+	return
+}
+
+func (nova *Nova) HandleCreateQuestionTransition(c *gin.Context) {
+	// move question to another lifecycle state
+	var request QuestionTransition
+	logger.Infof("handle request create question transition")
+	// request body should bind json
+	logger.Debugf("request body bind json format")
+	if err := c.ShouldBindJSON(&request); err != nil {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error bind request to json: %v", err)
+		return
+	}
+	logger.Debugf("successfully bind request json format")
+	// transit question & return response
+	nova.handleQuestionTransition(c, request)
+	return
+}
+
+func (nova *Nova) HandleCreateQuestionApproval(c *gin.Context) {
+	// approve question under review for publishing
+	var request QuestionTransition
+	logger.Infof("handle request create question approval")
+	// request body is optional, it only carries reviewer comment
+	logger.Debugf("request body bind json format")
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			nova.response400BadRequest(c, err)
+			logger.Errorf("error bind request to json: %v", err)
+			return
+		}
+	}
+	logger.Debugf("successfully bind request json format")
+	// approval only publishes question in review
+	request.State = QuestionStatePublished
+	nova.handleQuestionTransition(c, request)
+	return
+}
+
+func (nova *Nova) handleQuestionTransition(c *gin.Context, request QuestionTransition) {
+	// extract question type & Id from uri
+	questionType := strings.ToLower(c.Param("type"))
+	id := strings.ToLower(c.Param("Id"))
+	request.State = strings.ToLower(request.State)
+	// request question type, Id & state correctness
+	logger.Debugf("check question type, Id & state is validate")
+	if b, err := nova.isQuestionReferenceValidate(questionType, id); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question type & Id is validate: %v", err)
+		return
+	}
+	if b, err := nova.isQuestionStateValidate(request.State); !b {
+		nova.response400BadRequest(c, err)
+		logger.Errorf("error check question state is validate: %v", err)
+		return
+	}
+	logger.Debugf("successfully check question type, Id & state is validate")
+	// check principal is authenticated
+	logger.Debugf("check principal is authenticated")
+	user, role, ok := nova.queryPrincipalRole(c)
+	if !ok {
+		nova.response401Unauthorized(c, errors.New("user session required"))
+		logger.Errorf("error check principal is authenticated")
+		return
+	}
+	logger.Debugf("successfully check principal is authenticated")
+	// update data cache by querying questions & workflows in database
+	logger.Debugf("update data cache by querying questions & workflows in database")
+	if err := nova.queryQuestionsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying questions in database: %v", err)
+		return
+	}
+	if err := nova.queryQuestionWorkflowsInDatabase(); err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error update data cache by querying question workflows in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully update data cache by querying questions & workflows in database")
+	// check question existence
+	logger.Debugf("check question is existed")
+	if !nova.isQuestionExisted(questionType, id) {
+		nova.response404NotFound(c, fmt.Errorf("%v question not found", questionType))
+		logger.Errorf("error check question is existed")
+		return
+	}
+	logger.Debugf("successfully check question is existed")
+	// check transition is allowed for principal role
+	logger.Debugf("check question transition is allowed")
+	current := nova.queryQuestionWorkflow(questionType, id)
+	roles, ok := questionTransitions[current.State][request.State]
+	if !ok {
+		nova.response409Conflict(c, fmt.Errorf("question can not transit from %v to %v", current.State, request.State))
+		logger.Errorf("error check question transition is allowed: %v -> %v", current.State, request.State)
+		return
+	}
+	if !isUserRoleIncluded(role, roles) {
+		nova.response403Forbidden(c, fmt.Errorf("%v can not transit question from %v to %v", role, current.State, request.State))
+		logger.Errorf("error check question transition is allowed for role: %v", role)
+		return
+	}
+	// rejecting question back to author requires reviewer comment
+	if current.State == QuestionStateInReview && request.State == QuestionStateDraft && strings.TrimSpace(request.Comment) == "" {
+		nova.response400BadRequest(c, errors.New("comment is required when rejecting question"))
+		logger.Errorf("error check question rejection comment is validate")
+		return
+	}
+	logger.Debugf("successfully check question transition is allowed")
+	// store question workflow & review in database & data cache
+	logger.Debugf("store question workflow in database & data cache")
+	response, err := nova.updateQuestionWorkflow(questionType, id, request.State, user.UserId, request.Comment, current.State)
+	if err != nil {
+		nova.response500InternalServerError(c, err)
+		logger.Errorf("error store question workflow in database: %v", err)
+		return
+	}
+	logger.Debugf("successfully store question workflow in database & data cache")
+	// return response
+	nova.response200OK(c, response)
+	logger.Infof("response status code: %v, body: %v", http.StatusOK, response)
+}
+
+func (nova *Nova) isQuestionStateValidate(state string) (bool, error) {
+	// check question state is supported
+	switch state {
+	case QuestionStateDraft, QuestionStateInReview, QuestionStatePublished, QuestionStateArchived:
+		return true, nil
+	default:
+		return false, fmt.Errorf("question state %v not supported", state)
+	}
+}
+
+func isUserRoleIncluded(role string, roles []string) bool {
+	for _, v := range roles {
+		if v == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (nova *Nova) isQuestionPublished(questionType string, id string) bool {
+	// only published questions are added to exams or returned to examinees
+	return nova.queryQuestionWorkflow(questionType, id).State == QuestionStatePublished
+}
+
+func (nova *Nova) isQuestionVisible(c *gin.Context, questionType string, id string) bool {
+	// examinees & anonymous requests only see published questions
+	if _, role, ok := nova.queryPrincipalRole(c); ok && role != RoleExaminee {
+		return true
+	}
+	return nova.isQuestionPublished(questionType, id)
+}
+
+func (nova *Nova) isQuestionAnswerHidden(c *gin.Context) bool {
+	// examinees & anonymous requests do not see answer keys
+	_, role, ok := nova.queryPrincipalRole(c)
+	return !ok || role == RoleExaminee
+}
+
+func hideQuestionAnswers(question any) any {
+	// answer keys are dropped from question shown to examinees, ordering items lose their order
+	switch q := question.(type) {
+	case QuestionSingleChoice:
+		q.StandardAnswer = QuestionAnswer{}
+		return q
+	case QuestionMultipleChoice:
+		q.StandardAnswers = nil
+		return q
+	case QuestionJudgement:
+		q.StandardAnswer = false
+		return q
+	case QuestionEssay:
+		q.StandardAnswer = ""
+		return q
+	case QuestionFillBlank:
+		blanks := make([]QuestionBlank, len(q.Blanks))
+		for k, v := range q.Blanks {
+			v.Answers = nil
+			blanks[k] = v
+		}
+		q.Blanks = blanks
+		return q
+	case QuestionMatching:
+		q.Pairs = nil
+		return q
+	case QuestionOrdering:
+		items := append([]string{}, q.Items...)
+		sort.Strings(items)
+		q.Items = items
+		return q
+	case QuestionNumeric:
+		q.Value = 0
+		return q
+	case QuestionTemplate:
+		q.Formula = ""
+		return q
+	case QuestionCode:
+		return hideCodeTestCases(q)
+	default:
+		return question
+	}
+}
+
+func (nova *Nova) checkQuestionAuthor(c *gin.Context) bool {
+	// only authors & administrators create, change or delete questions
+	_, role, ok := nova.queryPrincipalRole(c)
+	if !ok {
+		nova.response401Unauthorized(c, errors.New("user session is required to change question"))
+		logger.Errorf("error check user session is validate")
+		return false
+	}
+	if role != RoleAuthor && role != RoleAdmin {
+		nova.response403Forbidden(c, fmt.Errorf("%v is not allowed to change question", role))
+		logger.Errorf("error check principal is author or administrator")
+		return false
+	}
+	return true
+}
+
+func (nova *Nova) queryQuestionWorkflow(questionType string, id string) QuestionWorkflow {
+	// enable question workflow cache read lock
+	nova.cache.questionsCache.workflowCache.mutex.RLock()
+	defer nova.cache.questionsCache.workflowCache.mutex.RUnlock()
+	// question created before workflow was introduced stays published
+	if v, ok := nova.cache.questionsCache.workflowCache.workflowSet[questionType+"/"+id]; ok {
+		return v
+	}
+	return QuestionWorkflow{Type: questionType, Id: id, State: QuestionStatePublished, UpdatedBy: systemAuthor}
+}
+
+func (nova *Nova) createQuestionWorkflow(c *gin.Context, questionType string, id string) (QuestionWorkflow, error) {
+	// new question starts as draft of its author
+	return nova.updateQuestionWorkflow(questionType, id, QuestionStateDraft, nova.queryRequestAuthor(c), "", "")
+}
+
+func (nova *Nova) reviseQuestionWorkflow(c *gin.Context, questionType string, id string) error {
+	// edited published question goes back to draft & through review again
+	current := nova.queryQuestionWorkflow(questionType, id)
+	if current.State != QuestionStatePublished {
+		return nil
+	}
+	_, err := nova.updateQuestionWorkflow(questionType, id, QuestionStateDraft, nova.queryRequestAuthor(c), "question edited", current.State)
+	return err
+}
+
+func (nova *Nova) updateQuestionWorkflow(questionType string, id string, state string, userId string, comment string, from string) (QuestionWorkflow, error) {
+	now := time.Now().UTC()
+	workflow := QuestionWorkflow{Type: questionType, Id: id, State: state, UpdatedBy: userId, UpdatedAt: now}
+	// update question workflow in database
+	if err := nova.db.UpdateQuestionWorkflow(&workflow); err != nil {
+		return QuestionWorkflow{}, err
+	}
+	// record transition of question in database
+	review := QuestionReview{Reviewer: userId, From: from, To: state, Comment: comment, CreatedAt: now}
+	if err := nova.db.CreateQuestionReview(questionType, id, &review); err != nil {
+		return QuestionWorkflow{}, err
+	}
+	// enable question workflow cache write lock
+	nova.cache.questionsCache.workflowCache.mutex.Lock()
+	defer nova.cache.questionsCache.workflowCache.mutex.Unlock()
+	// update question workflow in data cache
+	if nova.cache.questionsCache.workflowCache.workflowSet == nil {
+		nova.cache.questionsCache.workflowCache.workflowSet = make(map[string]QuestionWorkflow)
+	}
+	nova.cache.questionsCache.workflowCache.workflowSet[questionType+"/"+id] = workflow
+	return workflow, nil
+}
+
+func (nova *Nova) deleteQuestionWorkflow(questionType string, id string) error {
+	// delete question workflow in database
+	if err := nova.db.DeleteQuestionWorkflow(questionType, id); err != nil {
+		return err
+	}
+	// enable question workflow cache write lock
+	nova.cache.questionsCache.workflowCache.mutex.Lock()
+	defer nova.cache.questionsCache.workflowCache.mutex.Unlock()
+	// delete question workflow in data cache
+	delete(nova.cache.questionsCache.workflowCache.workflowSet, questionType+"/"+id)
+	return nil
+}
+
+func (nova *Nova) queryQuestionWorkflowsInDatabase() error {
+	// enable question workflow cache write lock
+	nova.cache.questionsCache.workflowCache.mutex.Lock()
+	defer nova.cache.questionsCache.workflowCache.mutex.Unlock()
+	// query question workflows from database
+	workflows, err := nova.db.QueryQuestionWorkflows()
+	if err != nil {
+		return err
+	}
+	// replace question workflows in data cache
+	nova.cache.questionsCache.workflowCache.workflowSet = make(map[string]QuestionWorkflow, len(workflows))
+	for _, workflow := range workflows {
+		nova.cache.questionsCache.workflowCache.workflowSet[workflow.Type+"/"+workflow.Id] = *workflow
+	}
+	return nil
+}
@@ -0,0 +1,329 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"nova/utils"
+	"testing"
+)
+
+func setupWorkflowTestRouter() *gin.Engine {
+	// create Nova instance
+	nova := New()
+	// initialize Nova instance
+	nova.Init()
+	// first administrator is granted by command line
+	setupTestAdministrator(nova)
+	// apply default Gin service
+	router := gin.Default()
+	// apply Gin logger & recovery middleware
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	// create router group for nova
+	novaService := router.Group("nova/v1")
+	{
+		/* user management */
+		novaService.POST("/user/:userId", nova.HandleCreateUser)
+		novaService.POST("/user/login/:userId", nova.HandleCreateUserLogin)
+		novaService.PUT("/user/role/:userId", nova.HandleUpdateUserRole)
+		novaService.GET("/user/role/:userId", nova.HandleQueryUserRole)
+		/* question management */
+		// question workflow related
+		novaService.GET("/question/workflow", nova.HandleQueryQuestionWorkflows)
+		novaService.GET("/question/workflow/:type/:Id", nova.HandleQueryQuestionWorkflow)
+		novaService.POST("/question/workflow/:type/:Id/transition", nova.HandleCreateQuestionTransition)
+		novaService.POST("/question/workflow/:type/:Id/approve", nova.HandleCreateQuestionApproval)
+		// question related
+		novaService.POST("/question/judgement/:Id", nova.HandleCreateQuestionJudgement)
+		novaService.PUT("/question/judgement/:Id", nova.HandleUpdateQuestionJudgement)
+		novaService.DELETE("/question/judgement/:Id", nova.HandleDeleteQuestionJudgement)
+		novaService.PATCH("/question/judgement/:Id", nova.HandleModifyQuestionJudgement)
+		novaService.GET("/question/judgement/:Id", nova.HandleQueryQuestionJudgement)
+	}
+	return router
+}
+
+func startWorkflowTestService() (*httptest.Server, *gin.Engine) {
+	router := setupWorkflowTestRouter()
+	return httptest.NewServer(router), router
+}
+
+func createWorkflowTestUser(t *testing.T, server *httptest.Server, router *gin.Engine, role string, token string) (User, UserSession) {
+	// create user
+	user := User{
+		UserId:      uuid.New().String(),
+		Username:    utils.RandomAlphabet(8),
+		Password:    utils.RandomAlphabetAndNumber(8),
+		PhoneNumber: utils.RandomNumber(11),
+	}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/"+user.UserId, user, "")
+	assert.Equal(t, http.StatusCreated, w.Code)
+	// grant user role, first administrator is granted without user session
+	if role == RoleAdmin && token == "" {
+		assert.Equal(t, 0, testAdministratorGrant(user.UserId))
+	} else if role != RoleExaminee {
+		w = serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/user/role/"+user.UserId, UserRole{Role: role}, token)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+	// login user
+	w = serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/user/login/"+user.UserId, UserLogin{UserId: user.UserId, Username: user.Username, Password: user.Password}, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var session UserSession
+	unmarshalTestResponse(t, w, &session)
+	return user, session
+}
+
+func TestNova_HandleUpdateUserRole(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleUpdateUserRole
+	// Test Purpose: Test HandleUpdateUserRole grant user role by administrator
+	// Test Steps:
+	// 1. send UpdateUserRole request of first administrator without user session
+	// 2. receive UpdateUserRole response by using 401 Unauthorized Code, grant-admin command grants it
+	// 3. send UpdateUserRole request without user session by using PUT method
+	// 4. receive UpdateUserRole response by using 401 Unauthorized Code
+	// 5. send UpdateUserRole request with administrator session by using PUT method
+	// 6. receive UpdateUserRole response with granted role by using 200 OK Code
+	// 7. send UpdateUserRole request with author session by using PUT method
+	// 8. receive UpdateUserRole response by using 403 Forbidden Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startWorkflowTestService()
+	defer server.Close()
+	/* first administrator is not granted by http request */
+	first, _ := createWorkflowTestUser(t, server, router, RoleExaminee, "")
+	w := serveTestRequest(t, router, http.MethodPut, server.URL+"/nova/v1/user/role/"+first.UserId, UserRole{Role: RoleAdmin}, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	/* grant role without user session */
+	user, _ := createWorkflowTestUser(t, server, router, RoleExaminee, "")
+	url := server.URL + "/nova/v1/user/role/" + user.UserId
+	w = serveTestRequest(t, router, http.MethodPut, url, UserRole{Role: RoleAuthor}, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	/* grant role with administrator session */
+	w = serveTestRequest(t, router, http.MethodPut, url, UserRole{Role: RoleAuthor}, admin.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, "")
+	// return response
+	var role UserRole
+	unmarshalTestResponse(t, w, &role)
+	// validate response
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, UserRole{UserId: user.UserId, Role: RoleAuthor}, role)
+	/* grant role with author session */
+	_, author := createWorkflowTestUser(t, server, router, RoleAuthor, admin.Token)
+	w = serveTestRequest(t, router, http.MethodPut, url, UserRole{Role: RoleAdmin}, author.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	/* grant unsupported role */
+	w = serveTestRequest(t, router, http.MethodPut, url, UserRole{Role: "owner"}, admin.Token)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestNova_HandleCreateQuestionTransition(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleCreateQuestionTransition
+	// Test Purpose: Test question lifecycle from draft to published
+	// Test Steps:
+	// 1. send CreateQuestion request with author session, question starts as draft
+	// 2. send QueryQuestion request with examinee session, draft question is hidden
+	// 3. send CreateQuestionTransition request to submit question for review
+	// 4. send CreateQuestionTransition request to reject question without comment
+	// 5. send CreateQuestionApproval request with author & reviewer sessions
+	// 6. send QueryQuestion request with examinee session, published question is returned
+	// 7. send QueryQuestionWorkflow request with reviews by using 200 OK Code
+	// 8. send QueryQuestionWorkflows request, anonymous request sees empty review queue
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startWorkflowTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	author, authorSession := createWorkflowTestUser(t, server, router, RoleAuthor, admin.Token)
+	reviewer, reviewerSession := createWorkflowTestUser(t, server, router, RoleReviewer, admin.Token)
+	_, examineeSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	/* create draft question */
+	question := QuestionJudgement{
+		Id:             uuid.New().String(),
+		Title:          "Is " + utils.RandomAlphabet(12) + " a prime number?",
+		StandardAnswer: false,
+	}
+	w := serveTestRequest(t, router, http.MethodPost, server.URL+"/nova/v1/question/judgement/"+question.Id, question, authorSession.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	workflowURL := server.URL + "/nova/v1/question/workflow/judgement/" + question.Id
+	w = serveTestRequest(t, router, http.MethodGet, workflowURL, nil, "")
+	var workflow QuestionWorkflow
+	unmarshalTestResponse(t, w, &workflow)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, QuestionStateDraft, workflow.State)
+	assert.Equal(t, author.UserId, workflow.UpdatedBy)
+	/* draft question is hidden from examinee */
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/question/judgement/"+question.Id, nil, examineeSession.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	/* submit question for review */
+	w = serveTestRequest(t, router, http.MethodPost, workflowURL+"/transition", QuestionTransition{State: QuestionStateInReview}, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, workflowURL+"/transition", QuestionTransition{State: QuestionStateInReview}, reviewerSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, workflowURL+"/transition", QuestionTransition{State: QuestionStateInReview}, authorSession.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	/* reject question without comment */
+	w = serveTestRequest(t, router, http.MethodPost, workflowURL+"/transition", QuestionTransition{State: QuestionStateDraft}, reviewerSession.Token)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	/* approve question */
+	w = serveTestRequest(t, router, http.MethodPost, workflowURL+"/approve", nil, authorSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, workflowURL+"/approve", QuestionTransition{Comment: "clear wording"}, reviewerSession.Token)
+	unmarshalTestResponse(t, w, &workflow)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, QuestionStatePublished, workflow.State)
+	assert.Equal(t, reviewer.UserId, workflow.UpdatedBy)
+	/* published question is returned to examinee */
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/question/judgement/"+question.Id, nil, examineeSession.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	/* published question can not be submitted again */
+	w = serveTestRequest(t, router, http.MethodPost, workflowURL+"/transition", QuestionTransition{State: QuestionStateInReview}, authorSession.Token)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	/* query question workflow with reviews */
+	w = serveTestRequest(t, router, http.MethodGet, workflowURL, nil, "")
+	workflow = QuestionWorkflow{}
+	unmarshalTestResponse(t, w, &workflow)
+	assert.Equal(t, http.StatusOK, w.Code)
+	if assert.Len(t, workflow.Reviews, 3) {
+		assert.Equal(t, QuestionStateDraft, workflow.Reviews[0].To)
+		assert.Equal(t, QuestionStateInReview, workflow.Reviews[1].To)
+		assert.Equal(t, QuestionStatePublished, workflow.Reviews[2].To)
+		assert.Equal(t, "clear wording", workflow.Reviews[2].Comment)
+	}
+	/* query review queue */
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/question/workflow?state=published", nil, "")
+	var workflows []QuestionWorkflow
+	unmarshalTestResponse(t, w, &workflows)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, workflows)
+	w = serveTestRequest(t, router, http.MethodGet, server.URL+"/nova/v1/question/workflow?state=published", nil, reviewerSession.Token)
+	unmarshalTestResponse(t, w, &workflows)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, workflows, QuestionWorkflow{Type: QuestionTypeJudgement, Id: question.Id, State: QuestionStatePublished, UpdatedBy: reviewer.UserId, UpdatedAt: workflow.UpdatedAt})
+}
+
+func TestNova_HandleUpdateQuestionPublished(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestNova_HandleUpdateQuestionPublished
+	// Test Purpose: Test only authors change questions & edited published question is reviewed again
+	// Test Steps:
+	// 1. send CreateQuestion request without session or with examinee session, receive 401 & 403
+	// 2. send CreateQuestion, CreateQuestionTransition & CreateQuestionApproval request, question is published
+	// 3. send QueryQuestion request without session, draft is hidden & published question is returned without answer key
+	// 4. send UpdateQuestion & ModifyQuestion request with author session, question goes back to draft
+	// 5. send DeleteQuestion request with examinee session, receive 403 Forbidden Code
+	-----------------------------------------------------------------------------------------*/
+	// reset test case
+	_ = resetQuestionTestCase()
+	// start http test service
+	server, router := startWorkflowTestService()
+	defer server.Close()
+	_, admin := createWorkflowTestUser(t, server, router, RoleAdmin, "")
+	_, authorSession := createWorkflowTestUser(t, server, router, RoleAuthor, admin.Token)
+	_, reviewerSession := createWorkflowTestUser(t, server, router, RoleReviewer, admin.Token)
+	_, examineeSession := createWorkflowTestUser(t, server, router, RoleExaminee, admin.Token)
+	question := QuestionJudgement{
+		Id:             uuid.New().String(),
+		Title:          "Is " + utils.RandomAlphabet(12) + " an even number?",
+		StandardAnswer: true,
+	}
+	url := server.URL + "/nova/v1/question/judgement/" + question.Id
+	workflowURL := server.URL + "/nova/v1/question/workflow/judgement/" + question.Id
+	/* only authors & administrators create questions */
+	w := serveTestRequest(t, router, http.MethodPost, url, question, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, url, question, examineeSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, url, question, authorSession.Token)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	/* draft question is hidden from anonymous requests */
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, workflowURL+"/transition", QuestionTransition{State: QuestionStateInReview}, authorSession.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, workflowURL+"/approve", nil, reviewerSession.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	for token, standardAnswer := range map[string]bool{"": false, examineeSession.Token: false, authorSession.Token: true} {
+		w = serveTestRequest(t, router, http.MethodGet, url, nil, token)
+		var published QuestionJudgement
+		unmarshalTestResponse(t, w, &published)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, standardAnswer, published.StandardAnswer)
+	}
+	/* edited published question goes back to draft */
+	question.Title += " (edited)"
+	w = serveTestRequest(t, router, http.MethodPut, url, question, examineeSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodPut, url, question, authorSession.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, workflowURL, nil, "")
+	var workflow QuestionWorkflow
+	unmarshalTestResponse(t, w, &workflow)
+	assert.Equal(t, QuestionStateDraft, workflow.State)
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, examineeSession.Token)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, workflowURL+"/transition", QuestionTransition{State: QuestionStateInReview}, authorSession.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodPost, workflowURL+"/approve", nil, reviewerSession.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodPatch, url, question, authorSession.Token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveTestRequest(t, router, http.MethodGet, url, nil, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	/* only authors & administrators delete questions */
+	w = serveTestRequest(t, router, http.MethodDelete, url, nil, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serveTestRequest(t, router, http.MethodDelete, url, nil, examineeSession.Token)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveTestRequest(t, router, http.MethodDelete, url, nil, authorSession.Token)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestHideQuestionAnswers(t *testing.T) {
+	/*---------------------------------------------------------------------------------------
+	// Test Case: TestHideQuestionAnswers
+	// Test Purpose: Test answer keys of every question type are dropped for examinees
+	-----------------------------------------------------------------------------------------*/
+	answers := []QuestionAnswer{{AnswerMark: "A", AnswerText: "two"}, {AnswerMark: "B", AnswerText: "three"}}
+	blank := QuestionBlank{Answers: []string{"Paris"}, IgnoreCase: true}
+	ordering := QuestionOrdering{Items: []string{"tadpole", "froglet", "frog"}}
+	code := QuestionCode{TestCases: []QuestionTestCase{{Name: "shown", Output: "1"}, {Name: "hidden", Output: "2", Hidden: true}}}
+	for question, hidden := range map[string]any{
+		"single-choice":   hideQuestionAnswers(QuestionSingleChoice{Answers: answers, StandardAnswer: answers[0]}),
+		"multiple-choice": hideQuestionAnswers(QuestionMultipleChoice{Answers: answers, StandardAnswers: answers}),
+		"judgement":       hideQuestionAnswers(QuestionJudgement{StandardAnswer: true}),
+		"essay":           hideQuestionAnswers(QuestionEssay{StandardAnswer: "answer key"}),
+		"fill-blank":      hideQuestionAnswers(QuestionFillBlank{Blanks: []QuestionBlank{blank}}),
+		"matching":        hideQuestionAnswers(QuestionMatching{Pairs: []QuestionPair{{Left: "H", Right: "hydrogen"}}}),
+		"ordering":        hideQuestionAnswers(ordering),
+		"numeric":         hideQuestionAnswers(QuestionNumeric{Value: 9.81, Unit: "m/s^2"}),
+		"template":        hideQuestionAnswers(QuestionTemplate{Formula: "a + b"}),
+		"code":            hideQuestionAnswers(code),
+	} {
+		expected := map[string]any{
+			"single-choice":   QuestionSingleChoice{Answers: answers},
+			"multiple-choice": QuestionMultipleChoice{Answers: answers},
+			"judgement":       QuestionJudgement{},
+			"essay":           QuestionEssay{},
+			"fill-blank":      QuestionFillBlank{Blanks: []QuestionBlank{{IgnoreCase: true}}},
+			"matching":        QuestionMatching{},
+			"ordering":        QuestionOrdering{Items: []string{"frog", "froglet", "tadpole"}},
+			"numeric":         QuestionNumeric{Unit: "m/s^2"},
+			"template":        QuestionTemplate{},
+			"code":            QuestionCode{TestCases: code.TestCases[:1]},
+		}[question]
+		assert.Equal(t, expected, hidden, question)
+	}
+	assert.Equal(t, []string{"tadpole", "froglet", "frog"}, ordering.Items)
+	assert.Equal(t, []string{"Paris"}, blank.Answers)
+}
@@ -22,12 +22,20 @@ func (c *Config) LoadConfig() (err error) {
 }
 
 type NovaConfig struct {
-	FQDN     string        `json:"FQDN" yaml:"FQDN"`
-	IPv4Addr string        `json:"IPv4Addr" yaml:"IPv4Addr"`
-	IPv6Addr string        `json:"IPv6Addr" yaml:"IPv6Addr"`
-	Port     int           `json:"Port" yaml:"Port"`
-	TLS      TLSSettings   `json:"TLSSettings" yaml:"TLSSettings"`
-	Cache    CacheSettings `json:"CacheSettings" yaml:"CacheSettings"`
+	FQDN       string             `json:"FQDN" yaml:"FQDN"`
+	IPv4Addr   string             `json:"IPv4Addr" yaml:"IPv4Addr"`
+	IPv6Addr   string             `json:"IPv6Addr" yaml:"IPv6Addr"`
+	Port       int                `json:"Port" yaml:"Port"`
+	TLS        TLSSettings        `json:"TLSSettings" yaml:"TLSSettings"`
+	Cache      CacheSettings      `json:"CacheSettings" yaml:"CacheSettings"`
+	Duplicate  DuplicateSettings  `json:"DuplicateSettings" yaml:"DuplicateSettings"`
+	Sandbox    SandboxSettings    `json:"SandboxSettings" yaml:"SandboxSettings"`
+	Guidance   GuidanceSettings   `json:"GuidanceSettings" yaml:"GuidanceSettings"`
+	Attachment AttachmentSettings `json:"AttachmentSettings" yaml:"AttachmentSettings"`
+	Exam       ExamSettings       `json:"ExamSettings" yaml:"ExamSettings"`
+	User       UserSettings       `json:"UserSettings" yaml:"UserSettings"`
+	Mail       MailSettings       `json:"MailSettings" yaml:"MailSettings"`
+	Login      LoginSettings      `json:"LoginSettings" yaml:"LoginSettings"`
 }
 
 type TLSSettings struct {
@@ -42,6 +50,64 @@ type CacheSettings struct {
 	CacheType string `json:"cacheType" yaml:"cacheType"`
 }
 
+type DuplicateSettings struct {
+	Action    string  `json:"action" yaml:"action"`
+	Threshold float64 `json:"threshold" yaml:"threshold"`
+}
+
+type SandboxSettings struct {
+	GoBinary     string `json:"goBinary" yaml:"goBinary"`
+	PythonBinary string `json:"pythonBinary" yaml:"pythonBinary"`
+	Workers      int    `json:"workers" yaml:"workers"`
+	TimeLimit    int    `json:"timeLimit" yaml:"timeLimit"`
+	MemoryLimit  int    `json:"memoryLimit" yaml:"memoryLimit"`
+}
+
+type GuidanceSettings struct {
+	Reveal string `json:"reveal" yaml:"reveal"`
+}
+
+type AttachmentSettings struct {
+	Directory  string   `json:"directory" yaml:"directory"`
+	MaxSize    int64    `json:"maxSize" yaml:"maxSize"`
+	MediaTypes []string `json:"mediaTypes" yaml:"mediaTypes"`
+	SigningKey string   `json:"signingKey" yaml:"signingKey"`
+	URLExpiry  int      `json:"urlExpiry" yaml:"urlExpiry"`
+	GCGrace    int      `json:"gcGrace" yaml:"gcGrace"`
+}
+
+type ExamSettings struct {
+	SweepInterval int `json:"sweepInterval" yaml:"sweepInterval"`
+}
+
+type UserSettings struct {
+	InvitationExpiry        int      `json:"invitationExpiry" yaml:"invitationExpiry"`
+	PasswordResetExpiry     int      `json:"passwordResetExpiry" yaml:"passwordResetExpiry"`
+	EmailVerificationExpiry int      `json:"emailVerificationExpiry" yaml:"emailVerificationExpiry"`
+	TwoFactorIssuer         string   `json:"twoFactorIssuer" yaml:"twoFactorIssuer"`
+	TwoFactorRoles          []string `json:"twoFactorRoles" yaml:"twoFactorRoles"`
+}
+
+type MailSettings struct {
+	SenderType string `json:"senderType" yaml:"senderType"`
+	Host       string `json:"host" yaml:"host"`
+	Port       int    `json:"port" yaml:"port"`
+	Username   string `json:"username" yaml:"username"`
+	Password   string `json:"password" yaml:"password"`
+	From       string `json:"from" yaml:"from"`
+	Directory  string `json:"directory" yaml:"directory"`
+}
+
+type LoginSettings struct {
+	FailureWindow    int      `json:"failureWindow" yaml:"failureWindow"`
+	BackoffThreshold int      `json:"backoffThreshold" yaml:"backoffThreshold"`
+	BackoffBase      int      `json:"backoffBase" yaml:"backoffBase"`
+	BackoffMax       int      `json:"backoffMax" yaml:"backoffMax"`
+	LockoutThreshold int      `json:"lockoutThreshold" yaml:"lockoutThreshold"`
+	IPThreshold      int      `json:"ipThreshold" yaml:"ipThreshold"`
+	TrustedProxies   []string `json:"trustedProxies" yaml:"trustedProxies"`
+}
+
 func MarshalTo(file string, t interface{}) (err error) {
 	return marshalTo(file, t)
 }
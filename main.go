@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"nova/app"
+	"os"
 	"runtime"
 )
 
@@ -21,5 +22,13 @@ func main() {
 	fmt.Println("The Nova Project")
 	nova := app.New()
 	nova.Init()
+	// provision users from csv or json file instead of starting service
+	if len(os.Args) > 1 && os.Args[1] == "import-users" {
+		os.Exit(nova.ImportUsers(os.Args[2:], os.Stdout, os.Stderr))
+	}
+	// grant first administrator instead of starting service
+	if len(os.Args) > 1 && os.Args[1] == "grant-admin" {
+		os.Exit(nova.GrantAdministrator(os.Args[2:], os.Stdout, os.Stderr))
+	}
 	nova.Start()
 }